@@ -0,0 +1,8 @@
+package abicheck
+
+import "github.com/pkg/errors"
+
+var (
+	ErrNoCode        = errors.New("no contract deployed at address")
+	ErrUnknownMethod = errors.New("method not in the binding's abi")
+)
@@ -0,0 +1,81 @@
+package abicheck
+
+import (
+	"context"
+	"encoding/binary"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// implementationSlot is the EIP-1967 storage slot upgradeable proxies keep their
+// implementation's address in
+var implementationSlot = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+
+// Client reads deployed contracts, satisfied by both *ethclient.Client and *failover.Client
+type Client interface {
+	CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error)
+	StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error)
+}
+
+// Contract is a deployed contract, and the methods of its binding the relayer calls
+type Contract struct {
+	Name    string
+	Address common.Address
+	ABI     *abi.ABI
+	// Methods are keys of ABI.Methods, i.e. "resolve0" for an overloaded resolve
+	Methods []string
+}
+
+// MissingMethods returns the signatures of the contract's Methods the deployed bytecode has
+// no selector for, i.e. ones removed or changed by an upgrade the binding wasn't regenerated
+// for. An upgradeable proxy's implementation is checked instead of the proxy.
+func MissingMethods(ctx context.Context, c Client, contract Contract) ([]string, error) {
+	address, err := implementation(ctx, c, contract.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := c.CodeAt(ctx, address, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "c.CodeAt")
+	}
+
+	if len(code) == 0 {
+		return nil, errors.Wrapf(ErrNoCode, "%v at %v", contract.Name, address.Hex())
+	}
+
+	selectors := pushedSelectors(code)
+
+	missing := make([]string, 0)
+
+	for _, name := range contract.Methods {
+		method, ok := contract.ABI.Methods[name]
+		if !ok {
+			return nil, errors.Wrapf(ErrUnknownMethod, "%v.%v", contract.Name, name)
+		}
+
+		if _, ok := selectors[binary.BigEndian.Uint32(method.ID)]; !ok {
+			missing = append(missing, method.Sig)
+		}
+	}
+
+	return missing, nil
+}
+
+// implementation returns the implementation behind an EIP-1967 proxy, or the address
+// itself when it isn't one
+func implementation(ctx context.Context, c Client, address common.Address) (common.Address, error) {
+	slot, err := c.StorageAt(ctx, address, implementationSlot, nil)
+	if err != nil {
+		return common.Address{}, errors.Wrap(err, "c.StorageAt")
+	}
+
+	if impl := common.BytesToAddress(slot); impl != (common.Address{}) {
+		return impl, nil
+	}
+
+	return address, nil
+}
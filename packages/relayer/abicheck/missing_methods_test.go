@@ -0,0 +1,136 @@
+package abicheck
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+)
+
+var (
+	proxyAddress          = common.HexToAddress("0x1000777700000000000000000000000000000004")
+	implementationAddress = common.HexToAddress("0x2000777700000000000000000000000000000004")
+)
+
+type client struct {
+	code    map[common.Address][]byte
+	storage map[common.Address]common.Hash
+}
+
+func (c *client) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return c.code[account], nil
+}
+
+func (c *client) StorageAt(
+	ctx context.Context,
+	account common.Address,
+	key common.Hash,
+	blockNumber *big.Int,
+) ([]byte, error) {
+	if key != implementationSlot {
+		return nil, errors.New("unexpected slot")
+	}
+
+	return c.storage[account].Bytes(), nil
+}
+
+// dispatcher returns bytecode comparing the calldata selector against the given methods'
+func dispatcher(t *testing.T, contractABI *abi.ABI, methods ...string) []byte {
+	code := []byte{byte(vm.PUSH1), 0xe0, byte(vm.SHR)}
+
+	for _, name := range methods {
+		method, ok := contractABI.Methods[name]
+		assert.True(t, ok)
+
+		code = append(code, byte(vm.DUP1), byte(vm.PUSH4))
+		code = append(code, method.ID...)
+		code = append(code, byte(vm.EQ))
+	}
+
+	return code
+}
+
+func bridgeContract(t *testing.T) Contract {
+	bridgeABI, err := bridge.BridgeMetaData.GetAbi()
+	assert.Nil(t, err)
+
+	return Contract{
+		Name:    "Bridge",
+		Address: proxyAddress,
+		ABI:     bridgeABI,
+		Methods: []string{"processMessage", "resolve0"},
+	}
+}
+
+func Test_MissingMethods(t *testing.T) {
+	contract := bridgeContract(t)
+
+	tests := []struct {
+		name    string
+		client  *client
+		missing []string
+		wantErr error
+	}{
+		{
+			"allPresent",
+			&client{code: map[common.Address][]byte{
+				proxyAddress: dispatcher(t, contract.ABI, "processMessage", "resolve0"),
+			}},
+			[]string{},
+			nil,
+		},
+		{
+			"overloadMissing",
+			&client{code: map[common.Address][]byte{
+				proxyAddress: dispatcher(t, contract.ABI, "processMessage", "resolve"),
+			}},
+			[]string{"resolve(bytes32,bool)"},
+			nil,
+		},
+		{
+			"checksImplementationBehindProxy",
+			&client{
+				code: map[common.Address][]byte{
+					proxyAddress:          dispatcher(t, contract.ABI),
+					implementationAddress: dispatcher(t, contract.ABI, "resolve0"),
+				},
+				storage: map[common.Address]common.Hash{
+					proxyAddress: common.BytesToHash(implementationAddress.Bytes()),
+				},
+			},
+			[]string{contract.ABI.Methods["processMessage"].Sig},
+			nil,
+		},
+		{
+			"noCode",
+			&client{},
+			nil,
+			ErrNoCode,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			missing, err := MissingMethods(context.Background(), tt.client, contract)
+			assert.Equal(t, tt.wantErr, errors.Cause(err))
+			assert.Equal(t, tt.missing, missing)
+		})
+	}
+}
+
+func Test_MissingMethods_unknownMethod(t *testing.T) {
+	contract := bridgeContract(t)
+	contract.Methods = []string{"notAMethod"}
+
+	c := &client{code: map[common.Address][]byte{proxyAddress: {byte(vm.STOP)}}}
+
+	_, err := MissingMethods(context.Background(), c, contract)
+	assert.Equal(t, ErrUnknownMethod, errors.Cause(err))
+}
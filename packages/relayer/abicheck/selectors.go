@@ -0,0 +1,33 @@
+package abicheck
+
+import "github.com/ethereum/go-ethereum/core/vm"
+
+// pushedSelectors returns every value of up to 4 bytes the bytecode pushes onto the stack.
+// Solidity's dispatcher compares the calldata's selector against each function's with a
+// PUSH4, or a shorter PUSH when the selector has leading zero bytes, so a function the
+// contract has is in the set. Push data is skipped so it isn't mistaken for opcodes.
+func pushedSelectors(code []byte) map[uint32]struct{} {
+	selectors := make(map[uint32]struct{})
+
+	for pc := 0; pc < len(code); pc++ {
+		op := vm.OpCode(code[pc])
+		if op < vm.PUSH1 || op > vm.PUSH32 {
+			continue
+		}
+
+		size := int(op-vm.PUSH1) + 1
+
+		if size <= 4 && pc+size < len(code) {
+			var selector uint32
+			for _, b := range code[pc+1 : pc+1+size] {
+				selector = selector<<8 | uint32(b)
+			}
+
+			selectors[selector] = struct{}{}
+		}
+
+		pc += size
+	}
+
+	return selectors
+}
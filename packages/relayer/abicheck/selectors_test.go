@@ -0,0 +1,27 @@
+package abicheck
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_pushedSelectors(t *testing.T) {
+	code := []byte{
+		byte(vm.PUSH4), 0xde, 0xad, 0xbe, 0xef,
+		byte(vm.EQ),
+		byte(vm.PUSH3), 0x01, 0x02, 0x03,
+		// push data that looks like a PUSH4 isn't read as one
+		byte(vm.PUSH5), byte(vm.PUSH4), 0x11, 0x22, 0x33, 0x44,
+		// truncated push at the end of the code
+		byte(vm.PUSH4), 0xaa,
+	}
+
+	selectors := pushedSelectors(code)
+
+	assert.Equal(t, map[uint32]struct{}{
+		0xdeadbeef: {},
+		0x010203:   {},
+	}, selectors)
+}
@@ -0,0 +1,10 @@
+package admin
+
+import "github.com/cyberhorsey/errors"
+
+var (
+	ErrNoAPIKey = errors.Validation.NewWithKeyAndDetail(
+		"ERR_NO_API_KEY",
+		"API key is required to authenticate admin calls",
+	)
+)
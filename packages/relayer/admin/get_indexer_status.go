@@ -0,0 +1,35 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/admin/adminpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetIndexerStatus returns each indexer's status, ordered by chain ID
+func (srv *Server) GetIndexerStatus(
+	ctx context.Context,
+	req *adminpb.GetIndexerStatusRequest,
+) (*adminpb.GetIndexerStatusResponse, error) {
+	resp := &adminpb.GetIndexerStatusResponse{}
+
+	for _, chainID := range srv.chainIDs() {
+		s, err := srv.indexers[chainID].IndexerStatus(ctx)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "chain %v: %v", chainID, err)
+		}
+
+		resp.Indexers = append(resp.Indexers, &adminpb.IndexerStatus{
+			ChainId:                  s.ChainID,
+			LatestProcessedBlock:     s.LatestProcessedBlock.Height,
+			LatestProcessedBlockHash: s.LatestProcessedBlock.Hash,
+			HeadBlock:                s.HeadBlock,
+			LatestSyncedHeight:       s.LatestSyncedHeight,
+			ProcessingPaused:         s.ProcessingPaused,
+		})
+	}
+
+	return resp, nil
+}
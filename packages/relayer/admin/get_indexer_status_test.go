@@ -0,0 +1,35 @@
+package admin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/admin/adminpb"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_GetIndexerStatus(t *testing.T) {
+	srv, indexers := newTestServer()
+	indexers[otherChainID].Paused = true
+
+	resp, err := srv.GetIndexerStatus(context.Background(), &adminpb.GetIndexerStatusRequest{})
+	assert.Nil(t, err)
+	assert.Len(t, resp.Indexers, 2)
+
+	assert.Equal(t, mock.MockChainID.Int64(), resp.Indexers[0].ChainId)
+	assert.Equal(t, mock.LatestBlock.Height, resp.Indexers[0].LatestProcessedBlock)
+	assert.Equal(t, mock.LatestBlock.Hash, resp.Indexers[0].LatestProcessedBlockHash)
+	assert.Equal(t, mock.LatestBlockNumber.Uint64(), resp.Indexers[0].HeadBlock)
+	assert.False(t, resp.Indexers[0].ProcessingPaused)
+
+	assert.Equal(t, otherChainID, resp.Indexers[1].ChainId)
+	assert.True(t, resp.Indexers[1].ProcessingPaused)
+
+	indexers[otherChainID].Fail = true
+
+	_, err = srv.GetIndexerStatus(context.Background(), &adminpb.GetIndexerStatusRequest{})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
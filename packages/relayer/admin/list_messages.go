@@ -0,0 +1,88 @@
+package admin
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/admin/adminpb"
+	"github.com/ethereum/go-ethereum/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	defaultListMessagesLimit int32 = 100
+	maxListMessagesLimit     int32 = 1000
+)
+
+// ListMessages pages through indexed MessageSent events, oldest first
+func (srv *Server) ListMessages(
+	ctx context.Context,
+	req *adminpb.ListMessagesRequest,
+) (*adminpb.ListMessagesResponse, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultListMessagesLimit
+	}
+
+	if limit > maxListMessagesLimit {
+		limit = maxListMessagesLimit
+	}
+
+	opts := relayer.FindAllForExportOpts{
+		AfterID: int(req.AfterId),
+		Limit:   int(limit),
+		Name:    relayer.EventNameMessageSent,
+	}
+
+	if req.ChainId != 0 {
+		opts.ChainID = big.NewInt(req.ChainId)
+	}
+
+	if req.Status != "" {
+		s, err := relayer.ParseEventStatus(req.Status)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		opts.Status = &s
+	}
+
+	if req.MessageOwner != "" {
+		if !common.IsHexAddress(req.MessageOwner) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid message owner %v", req.MessageOwner)
+		}
+
+		// owners are stored checksummed
+		opts.MessageOwner = common.HexToAddress(req.MessageOwner).Hex()
+	}
+
+	events, err := srv.eventRepo.FindAllForExport(ctx, opts)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &adminpb.ListMessagesResponse{}
+
+	for _, e := range events {
+		resp.Messages = append(resp.Messages, &adminpb.Message{
+			Id:                   int64(e.ID),
+			MsgHash:              e.MsgHash,
+			ChainId:              e.ChainID,
+			Status:               e.Status.String(),
+			EventType:            e.EventType.String(),
+			MessageOwner:         e.MessageOwner,
+			Amount:               e.Amount,
+			CanonicalTokenSymbol: e.CanonicalTokenSymbol,
+			ProcessingTxHash:     e.ProcessingTxHash,
+			CreatedAt:            e.CreatedAt.Unix(),
+		})
+	}
+
+	if len(events) == int(limit) {
+		resp.NextAfterId = int64(events[len(events)-1].ID)
+	}
+
+	return resp, nil
+}
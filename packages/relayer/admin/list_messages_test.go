@@ -0,0 +1,127 @@
+package admin
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/admin/adminpb"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_ListMessages(t *testing.T) {
+	srv, _ := newTestServer()
+
+	owner := common.HexToAddress("0x63FaC9201494f0bd17B9892B9fae4d52fe3BD377")
+
+	for _, e := range []struct {
+		name    string
+		chainID *big.Int
+		status  relayer.EventStatus
+		owner   string
+	}{
+		{relayer.EventNameMessageSent, mock.MockChainID, relayer.EventStatusNew, owner.Hex()},
+		{relayer.EventNameMessageSent, mock.MockChainID, relayer.EventStatusRetriable, owner.Hex()},
+		{relayer.EventNameMessageSent, big.NewInt(otherChainID), relayer.EventStatusDone, "0x0"},
+		{relayer.EventNameMessageStatusChanged, mock.MockChainID, relayer.EventStatusDone, owner.Hex()},
+	} {
+		_, err := srv.eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+			Name:         e.name,
+			Event:        e.name,
+			Data:         "{}",
+			ChainID:      e.chainID,
+			Status:       e.status,
+			MessageOwner: e.owner,
+		})
+		assert.Nil(t, err)
+	}
+
+	tests := []struct {
+		name         string
+		req          *adminpb.ListMessagesRequest
+		wantMessages int
+		wantNext     bool
+		wantCode     codes.Code
+	}{
+		{
+			"all",
+			&adminpb.ListMessagesRequest{},
+			3,
+			false,
+			codes.OK,
+		},
+		{
+			"byChainID",
+			&adminpb.ListMessagesRequest{ChainId: otherChainID},
+			1,
+			false,
+			codes.OK,
+		},
+		{
+			"byStatus",
+			&adminpb.ListMessagesRequest{Status: "retriable"},
+			1,
+			false,
+			codes.OK,
+		},
+		{
+			"byLowercaseOwner",
+			&adminpb.ListMessagesRequest{MessageOwner: "0x63fac9201494f0bd17b9892b9fae4d52fe3bd377"},
+			2,
+			false,
+			codes.OK,
+		},
+		{
+			"limited",
+			&adminpb.ListMessagesRequest{Limit: 2},
+			2,
+			true,
+			codes.OK,
+		},
+		{
+			"invalidStatus",
+			&adminpb.ListMessagesRequest{Status: "unknown"},
+			0,
+			false,
+			codes.InvalidArgument,
+		},
+		{
+			"invalidOwner",
+			&adminpb.ListMessagesRequest{MessageOwner: "0x1"},
+			0,
+			false,
+			codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := srv.ListMessages(context.Background(), tt.req)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+
+			if tt.wantCode != codes.OK {
+				return
+			}
+
+			assert.Len(t, resp.Messages, tt.wantMessages)
+			assert.Equal(t, tt.wantNext, resp.NextAfterId != 0)
+
+			for i := 1; i < len(resp.Messages); i++ {
+				assert.Less(t, resp.Messages[i-1].Id, resp.Messages[i].Id)
+			}
+		})
+	}
+
+	// the next page starts after the previous one
+	first, err := srv.ListMessages(context.Background(), &adminpb.ListMessagesRequest{Limit: 2})
+	assert.Nil(t, err)
+
+	next, err := srv.ListMessages(context.Background(), &adminpb.ListMessagesRequest{AfterId: first.NextAfterId})
+	assert.Nil(t, err)
+	assert.Len(t, next.Messages, 1)
+}
@@ -0,0 +1,41 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/admin/adminpb"
+)
+
+// PauseProcessing pauses the processor relaying messages from the requested chain, or all of them
+func (srv *Server) PauseProcessing(
+	ctx context.Context,
+	req *adminpb.PauseProcessingRequest,
+) (*adminpb.PauseProcessingResponse, error) {
+	indexers, err := srv.indexersFor(req.ChainId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, i := range indexers {
+		i.PauseProcessing()
+	}
+
+	return &adminpb.PauseProcessingResponse{}, nil
+}
+
+// ResumeProcessing resumes the processor relaying messages from the requested chain, or all of them
+func (srv *Server) ResumeProcessing(
+	ctx context.Context,
+	req *adminpb.ResumeProcessingRequest,
+) (*adminpb.ResumeProcessingResponse, error) {
+	indexers, err := srv.indexersFor(req.ChainId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, i := range indexers {
+		i.ResumeProcessing()
+	}
+
+	return &adminpb.ResumeProcessingResponse{}, nil
+}
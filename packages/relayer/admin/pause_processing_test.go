@@ -0,0 +1,42 @@
+package admin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/admin/adminpb"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_PauseAndResumeProcessing(t *testing.T) {
+	srv, indexers := newTestServer()
+	ctx := context.Background()
+
+	_, err := srv.PauseProcessing(ctx, &adminpb.PauseProcessingRequest{ChainId: otherChainID})
+	assert.Nil(t, err)
+	assert.False(t, indexers[mock.MockChainID.Int64()].Paused)
+	assert.True(t, indexers[otherChainID].Paused)
+
+	_, err = srv.PauseProcessing(ctx, &adminpb.PauseProcessingRequest{})
+	assert.Nil(t, err)
+	assert.True(t, indexers[mock.MockChainID.Int64()].Paused)
+	assert.True(t, indexers[otherChainID].Paused)
+
+	_, err = srv.ResumeProcessing(ctx, &adminpb.ResumeProcessingRequest{ChainId: mock.MockChainID.Int64()})
+	assert.Nil(t, err)
+	assert.False(t, indexers[mock.MockChainID.Int64()].Paused)
+	assert.True(t, indexers[otherChainID].Paused)
+
+	_, err = srv.ResumeProcessing(ctx, &adminpb.ResumeProcessingRequest{})
+	assert.Nil(t, err)
+	assert.False(t, indexers[otherChainID].Paused)
+
+	_, err = srv.PauseProcessing(ctx, &adminpb.PauseProcessingRequest{ChainId: 1})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = srv.ResumeProcessing(ctx, &adminpb.ResumeProcessingRequest{ChainId: 1})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,45 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/admin/adminpb"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ReprocessMessage processes a message the destination bridge still sees as new again,
+// using the indexer for the message's source chain
+func (srv *Server) ReprocessMessage(
+	ctx context.Context,
+	req *adminpb.ReprocessMessageRequest,
+) (*adminpb.ReprocessMessageResponse, error) {
+	// reprocessing acts on the event's current status, which a lagging replica could have stale.
+	ctx = relayer.WithPrimaryReads(ctx)
+
+	e, err := srv.eventRepo.FirstByEventAndMsgHash(ctx, relayer.EventNameMessageSent, req.MsgHash)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if e == nil {
+		return nil, status.Errorf(codes.NotFound, "message %v not found", req.MsgHash)
+	}
+
+	indexer, ok := srv.indexers[e.ChainID]
+	if !ok {
+		return nil, status.Errorf(codes.FailedPrecondition, "no indexer for chain %v", e.ChainID)
+	}
+
+	if err := indexer.ReprocessMessage(ctx, e); err != nil {
+		if errors.Is(err, relayer.ErrMessageNotReprocessable) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &adminpb.ReprocessMessageResponse{}, nil
+}
@@ -0,0 +1,74 @@
+package admin
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/admin/adminpb"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_ReprocessMessage(t *testing.T) {
+	srv, indexers := newTestServer()
+
+	for _, e := range []struct {
+		msgHash string
+		chainID *big.Int
+		status  relayer.EventStatus
+	}{
+		{"0x1", mock.MockChainID, relayer.EventStatusNew},
+		{"0x2", mock.MockChainID, relayer.EventStatusDone},
+		{"0x3", big.NewInt(1), relayer.EventStatusNew},
+	} {
+		_, err := srv.eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+			Name:    relayer.EventNameMessageSent,
+			Event:   relayer.EventNameMessageSent,
+			Data:    "{}",
+			ChainID: e.chainID,
+			Status:  e.status,
+			MsgHash: e.msgHash,
+		})
+		assert.Nil(t, err)
+	}
+
+	tests := []struct {
+		name     string
+		msgHash  string
+		wantCode codes.Code
+	}{
+		{
+			"success",
+			"0x1",
+			codes.OK,
+		},
+		{
+			"notReprocessable",
+			"0x2",
+			codes.FailedPrecondition,
+		},
+		{
+			"noIndexerForChain",
+			"0x3",
+			codes.FailedPrecondition,
+		},
+		{
+			"notFound",
+			"0x4",
+			codes.NotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := srv.ReprocessMessage(context.Background(), &adminpb.ReprocessMessageRequest{MsgHash: tt.msgHash})
+			assert.Equal(t, tt.wantCode, status.Code(err))
+		})
+	}
+
+	assert.Len(t, indexers[mock.MockChainID.Int64()].Reprocessed, 1)
+}
@@ -0,0 +1,147 @@
+package admin
+
+import (
+	"context"
+	"crypto/subtle"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/admin/adminpb"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Server serves the admin gRPC API, see adminpb/admin.proto
+type Server struct {
+	adminpb.UnimplementedAdminServiceServer
+
+	grpc      *grpc.Server
+	eventRepo relayer.EventRepository
+	indexers  map[int64]relayer.IndexerAdmin
+	apiKey    string
+}
+
+type NewServerOpts struct {
+	EventRepo relayer.EventRepository
+	// Indexers are keyed by the chain ID they index
+	Indexers map[int64]relayer.IndexerAdmin
+	// APIKey must be presented as a Bearer token in every call's authorization metadata
+	APIKey string
+}
+
+func (opts NewServerOpts) Validate() error {
+	if opts.EventRepo == nil {
+		return relayer.ErrNoEventRepository
+	}
+
+	if opts.APIKey == "" {
+		return ErrNoAPIKey
+	}
+
+	return nil
+}
+
+func NewServer(opts NewServerOpts) (*Server, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	srv := &Server{
+		eventRepo: opts.EventRepo,
+		indexers:  opts.Indexers,
+		apiKey:    opts.APIKey,
+	}
+
+	srv.grpc = grpc.NewServer(grpc.UnaryInterceptor(srv.authenticate))
+	adminpb.RegisterAdminServiceServer(srv.grpc, srv)
+
+	return srv, nil
+}
+
+// Start listens on address and serves until Shutdown is called
+func (srv *Server) Start(address string) error {
+	lis, err := net.Listen("tcp", address)
+	if err != nil {
+		return errors.Wrap(err, "net.Listen")
+	}
+
+	return srv.Serve(lis)
+}
+
+// Serve serves on an existing listener until Shutdown is called
+func (srv *Server) Serve(lis net.Listener) error {
+	return srv.grpc.Serve(lis)
+}
+
+// Shutdown waits for in progress calls to finish, or stops them when ctx is done first
+func (srv *Server) Shutdown(ctx context.Context) error {
+	stopped := make(chan struct{})
+
+	go func() {
+		srv.grpc.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		srv.grpc.Stop()
+		return ctx.Err()
+	}
+}
+
+// authenticate rejects calls without the API key as a Bearer token
+func (srv *Server) authenticate(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	for _, v := range md.Get("authorization") {
+		key := strings.TrimPrefix(v, "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(key), []byte(srv.apiKey)) == 1 {
+			return handler(ctx, req)
+		}
+	}
+
+	return nil, status.Error(codes.Unauthenticated, "invalid or missing api key")
+}
+
+// chainIDs returns the chain IDs with an indexer, in ascending order
+func (srv *Server) chainIDs() []int64 {
+	chainIDs := make([]int64, 0, len(srv.indexers))
+	for chainID := range srv.indexers {
+		chainIDs = append(chainIDs, chainID)
+	}
+
+	sort.Slice(chainIDs, func(i, j int) bool { return chainIDs[i] < chainIDs[j] })
+
+	return chainIDs
+}
+
+// indexersFor returns the indexer for chainID, or every indexer when it's 0
+func (srv *Server) indexersFor(chainID int64) ([]relayer.IndexerAdmin, error) {
+	if chainID == 0 {
+		indexers := make([]relayer.IndexerAdmin, 0, len(srv.indexers))
+		for _, id := range srv.chainIDs() {
+			indexers = append(indexers, srv.indexers[id])
+		}
+
+		return indexers, nil
+	}
+
+	i, ok := srv.indexers[chainID]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no indexer for chain %v", chainID)
+	}
+
+	return []relayer.IndexerAdmin{i}, nil
+}
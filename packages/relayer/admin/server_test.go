@@ -0,0 +1,141 @@
+package admin
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/admin/adminpb"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+var (
+	testAPIKey   = "admin-key"
+	otherChainID = int64(167002)
+)
+
+func newTestServer() (*Server, map[int64]*mock.IndexerAdmin) {
+	indexers := map[int64]*mock.IndexerAdmin{
+		mock.MockChainID.Int64(): {ChainID: mock.MockChainID.Int64()},
+		otherChainID:             {ChainID: otherChainID},
+	}
+
+	srv, _ := NewServer(NewServerOpts{
+		EventRepo: mock.NewEventRepository(),
+		Indexers: map[int64]relayer.IndexerAdmin{
+			mock.MockChainID.Int64(): indexers[mock.MockChainID.Int64()],
+			otherChainID:             indexers[otherChainID],
+		},
+		APIKey: testAPIKey,
+	})
+
+	return srv, indexers
+}
+
+func Test_NewServer(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    NewServerOpts
+		wantErr error
+	}{
+		{
+			"success",
+			NewServerOpts{
+				EventRepo: mock.NewEventRepository(),
+				APIKey:    testAPIKey,
+			},
+			nil,
+		},
+		{
+			"noEventRepo",
+			NewServerOpts{
+				APIKey: testAPIKey,
+			},
+			relayer.ErrNoEventRepository,
+		},
+		{
+			"noAPIKey",
+			NewServerOpts{
+				EventRepo: mock.NewEventRepository(),
+			},
+			ErrNoAPIKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewServer(tt.opts)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_authenticate(t *testing.T) {
+	srv, _ := newTestServer()
+
+	lis := bufconn.Listen(1024 * 1024)
+
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		_ = srv.Shutdown(ctx)
+	}()
+
+	conn, err := grpc.Dial(
+		"bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.Nil(t, err)
+
+	defer conn.Close()
+
+	client := adminpb.NewAdminServiceClient(conn)
+
+	tests := []struct {
+		name     string
+		md       metadata.MD
+		wantCode codes.Code
+	}{
+		{
+			"success",
+			metadata.Pairs("authorization", "Bearer "+testAPIKey),
+			codes.OK,
+		},
+		{
+			"wrongKey",
+			metadata.Pairs("authorization", "Bearer wrong"),
+			codes.Unauthenticated,
+		},
+		{
+			"noKey",
+			metadata.MD{},
+			codes.Unauthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewOutgoingContext(context.Background(), tt.md)
+
+			_, err := client.GetIndexerStatus(ctx, &adminpb.GetIndexerStatusRequest{})
+			assert.Equal(t, tt.wantCode, status.Code(err))
+		})
+	}
+}
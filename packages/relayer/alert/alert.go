@@ -0,0 +1,16 @@
+package alert
+
+import "context"
+
+// Alert is a condition on-call should know about. Key identifies the condition, so it's
+// only sent when the condition starts firing, and again once it's resolved.
+type Alert struct {
+	Key      string
+	Message  string
+	Resolved bool
+}
+
+// Notifier delivers alerts to on-call
+type Notifier interface {
+	Notify(ctx context.Context, a Alert) error
+}
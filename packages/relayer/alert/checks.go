@@ -0,0 +1,103 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// BalanceClient is the RPC call BalanceBelow makes, satisfied by *failover.Client
+type BalanceClient interface {
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+}
+
+// IndexerStatuser is the call IndexerLagAbove makes, satisfied by relayer.IndexerAdmin
+type IndexerStatuser interface {
+	IndexerStatus(ctx context.Context) (*relayer.IndexerStatus, error)
+}
+
+// BalanceBelow fires while account's balance on the chain called name is below min
+func BalanceBelow(name string, client BalanceClient, account common.Address, min *big.Int) Check {
+	return Check{
+		Key: "balance:" + name,
+		Run: func(ctx context.Context) (bool, string, error) {
+			balance, err := client.BalanceAt(ctx, account, nil)
+			if err != nil {
+				return false, "", errors.Wrap(err, "client.BalanceAt")
+			}
+
+			return balance.Cmp(min) < 0, fmt.Sprintf(
+				"relayer %v balance on %v is %v wei, threshold %v wei",
+				account.Hex(),
+				name,
+				balance,
+				min,
+			), nil
+		},
+	}
+}
+
+// IndexerLagAbove fires while the indexer for the chain called name is more than maxLag
+// blocks behind the chain's head
+func IndexerLagAbove(name string, indexer IndexerStatuser, maxLag uint64) Check {
+	return Check{
+		Key: "indexerLag:" + name,
+		Run: func(ctx context.Context) (bool, string, error) {
+			status, err := indexer.IndexerStatus(ctx)
+			if err != nil {
+				return false, "", errors.Wrap(err, "indexer.IndexerStatus")
+			}
+
+			var lag uint64
+			if status.LatestProcessedBlock != nil && status.HeadBlock > status.LatestProcessedBlock.Height {
+				lag = status.HeadBlock - status.LatestProcessedBlock.Height
+			}
+
+			return lag > maxLag, fmt.Sprintf(
+				"indexer for %v is %v blocks behind head %v, threshold %v blocks",
+				name,
+				lag,
+				status.HeadBlock,
+				maxLag,
+			), nil
+		},
+	}
+}
+
+// RunwayBelow fires while the relayer's balance on the chain called name lasts fewer than minHours
+// at its recent gas spend. It doesn't while no gas was spent.
+func RunwayBelow(name string, reporter relayer.RunwayReporter, minHours float64) Check {
+	return Check{
+		Key: "runway:" + name,
+		Run: func(ctx context.Context) (bool, string, error) {
+			r, err := reporter.Runway(ctx)
+			if err != nil {
+				return false, "", errors.Wrap(err, "reporter.Runway")
+			}
+
+			if r.HoursRemaining == nil {
+				return false, fmt.Sprintf(
+					"relayer balance on %v is %v wei, no gas spent in the last %v hours",
+					name,
+					r.Balance,
+					r.WindowInHours,
+				), nil
+			}
+
+			return *r.HoursRemaining < minHours, fmt.Sprintf(
+				"relayer balance on %v is %v wei, lasting %.1f hours at %v wei an hour over the last %v hours, "+
+					"threshold %v hours",
+				name,
+				r.Balance,
+				*r.HoursRemaining,
+				r.GasSpentPerHour,
+				r.WindowInHours,
+				minHours,
+			), nil
+		},
+	}
+}
@@ -0,0 +1,129 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeBalanceClient struct {
+	balance *big.Int
+}
+
+func (c *fakeBalanceClient) BalanceAt(
+	ctx context.Context,
+	account common.Address,
+	blockNumber *big.Int,
+) (*big.Int, error) {
+	if c.balance == nil {
+		return nil, errors.New("fail")
+	}
+
+	return c.balance, nil
+}
+
+type fakeIndexer struct {
+	status *relayer.IndexerStatus
+}
+
+func (i *fakeIndexer) IndexerStatus(ctx context.Context) (*relayer.IndexerStatus, error) {
+	return i.status, nil
+}
+
+func Test_BalanceBelow(t *testing.T) {
+	tests := []struct {
+		name       string
+		balance    *big.Int
+		wantFiring bool
+		wantErr    bool
+	}{
+		{"below", big.NewInt(9), true, false},
+		{"atThreshold", big.NewInt(10), false, false},
+		{"error", nil, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := BalanceBelow("L1", &fakeBalanceClient{tt.balance}, common.Address{}, big.NewInt(10))
+
+			firing, _, err := check.Run(context.Background())
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantFiring, firing)
+		})
+	}
+}
+
+func Test_IndexerLagAbove(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     *relayer.IndexerStatus
+		wantFiring bool
+	}{
+		{
+			"behind",
+			&relayer.IndexerStatus{LatestProcessedBlock: &relayer.Block{Height: 89}, HeadBlock: 100},
+			true,
+		},
+		{
+			"atThreshold",
+			&relayer.IndexerStatus{LatestProcessedBlock: &relayer.Block{Height: 90}, HeadBlock: 100},
+			false,
+		},
+		{
+			"processedAhead",
+			&relayer.IndexerStatus{LatestProcessedBlock: &relayer.Block{Height: 101}, HeadBlock: 100},
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			firing, _, err := IndexerLagAbove("L1", &fakeIndexer{tt.status}, 10).Run(context.Background())
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantFiring, firing)
+		})
+	}
+}
+
+type fakeRunwayReporter struct {
+	runway *relayer.Runway
+}
+
+func (r *fakeRunwayReporter) Runway(ctx context.Context) (*relayer.Runway, error) {
+	if r.runway == nil {
+		return nil, errors.New("fail")
+	}
+
+	return r.runway, nil
+}
+
+func Test_RunwayBelow(t *testing.T) {
+	hours := func(h float64) *float64 { return &h }
+
+	tests := []struct {
+		name       string
+		runway     *relayer.Runway
+		wantFiring bool
+		wantErr    bool
+	}{
+		{"below", &relayer.Runway{HoursRemaining: hours(23.5)}, true, false},
+		{"atThreshold", &relayer.Runway{HoursRemaining: hours(24)}, false, false},
+		{"nothingSpent", &relayer.Runway{}, false, false},
+		{"error", nil, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := RunwayBelow("L2", &fakeRunwayReporter{tt.runway}, 24)
+
+			firing, _, err := check.Run(context.Background())
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantFiring, firing)
+		})
+	}
+}
@@ -0,0 +1,8 @@
+package alert
+
+import "github.com/pkg/errors"
+
+var (
+	ErrNoNotifier      = errors.New("alert: notifier is required")
+	ErrInvalidInterval = errors.New("alert: interval must be positive")
+)
@@ -0,0 +1,86 @@
+package alert
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Check watches a condition, reporting whether it's firing and a message describing it
+type Check struct {
+	Key string
+	Run func(ctx context.Context) (firing bool, message string, err error)
+}
+
+// Monitor runs its checks every interval, and notifies when one starts or stops firing.
+// A check that keeps firing isn't sent again.
+type Monitor struct {
+	notifier Notifier
+	checks   []Check
+	interval time.Duration
+	// firing is the state last notified for each check's Key
+	firing map[string]bool
+}
+
+type NewMonitorOpts struct {
+	Notifier Notifier
+	Checks   []Check
+	Interval time.Duration
+}
+
+func NewMonitor(opts NewMonitorOpts) (*Monitor, error) {
+	if opts.Notifier == nil {
+		return nil, ErrNoNotifier
+	}
+
+	if opts.Interval <= 0 {
+		return nil, ErrInvalidInterval
+	}
+
+	return &Monitor{
+		notifier: opts.Notifier,
+		checks:   opts.Checks,
+		interval: opts.Interval,
+		firing:   make(map[string]bool),
+	}, nil
+}
+
+// Start runs the checks straight away, then every interval until ctx is done
+func (m *Monitor) Start(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		m.runChecks(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runChecks notifies about each check whose state changed since it was last notified. A failed
+// check or notification leaves the state as it was, so it's tried again next time.
+func (m *Monitor) runChecks(ctx context.Context) {
+	for _, c := range m.checks {
+		firing, message, err := c.Run(ctx)
+		if err != nil {
+			log.Warnf("alert check %v: %v", c.Key, err)
+			continue
+		}
+
+		if firing == m.firing[c.Key] {
+			continue
+		}
+
+		if err := m.notifier.Notify(ctx, Alert{Key: c.Key, Message: message, Resolved: !firing}); err != nil {
+			log.Errorf("alert %v, m.notifier.Notify: %v", c.Key, err)
+			continue
+		}
+
+		m.firing[c.Key] = firing
+	}
+}
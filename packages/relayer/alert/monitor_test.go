@@ -0,0 +1,140 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingNotifier struct {
+	alerts []Alert
+	fail   bool
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, a Alert) error {
+	if n.fail {
+		return errors.New("fail")
+	}
+
+	n.alerts = append(n.alerts, a)
+
+	return nil
+}
+
+func Test_NewMonitor(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    NewMonitorOpts
+		wantErr error
+	}{
+		{
+			"success",
+			NewMonitorOpts{Notifier: &recordingNotifier{}, Interval: time.Second},
+			nil,
+		},
+		{
+			"noNotifier",
+			NewMonitorOpts{Interval: time.Second},
+			ErrNoNotifier,
+		},
+		{
+			"invalidInterval",
+			NewMonitorOpts{Notifier: &recordingNotifier{}},
+			ErrInvalidInterval,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewMonitor(tt.opts)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_runChecks_notifiesOnlyOnCrossings(t *testing.T) {
+	notifier := &recordingNotifier{}
+
+	firing := []bool{false, true, true, false, false}
+
+	var runs int
+
+	m, err := NewMonitor(NewMonitorOpts{
+		Notifier: notifier,
+		Interval: time.Second,
+		Checks: []Check{{
+			Key: "test",
+			Run: func(ctx context.Context) (bool, string, error) {
+				f := firing[runs]
+				runs++
+
+				return f, "message", nil
+			},
+		}},
+	})
+	assert.Nil(t, err)
+
+	for range firing {
+		m.runChecks(context.Background())
+	}
+
+	assert.Equal(t, []Alert{
+		{Key: "test", Message: "message"},
+		{Key: "test", Message: "message", Resolved: true},
+	}, notifier.alerts)
+}
+
+func Test_runChecks_retriesFailedNotifications(t *testing.T) {
+	notifier := &recordingNotifier{fail: true}
+
+	m, err := NewMonitor(NewMonitorOpts{
+		Notifier: notifier,
+		Interval: time.Second,
+		Checks: []Check{{
+			Key: "test",
+			Run: func(ctx context.Context) (bool, string, error) {
+				return true, "message", nil
+			},
+		}},
+	})
+	assert.Nil(t, err)
+
+	m.runChecks(context.Background())
+	assert.Equal(t, 0, len(notifier.alerts))
+
+	notifier.fail = false
+
+	m.runChecks(context.Background())
+	assert.Equal(t, []Alert{{Key: "test", Message: "message"}}, notifier.alerts)
+}
+
+func Test_runChecks_skipsFailedChecks(t *testing.T) {
+	notifier := &recordingNotifier{}
+
+	m, err := NewMonitor(NewMonitorOpts{
+		Notifier: notifier,
+		Interval: time.Second,
+		Checks: []Check{
+			{
+				Key: "failing",
+				Run: func(ctx context.Context) (bool, string, error) {
+					return false, "", errors.New("fail")
+				},
+			},
+			{
+				Key: "firing",
+				Run: func(ctx context.Context) (bool, string, error) {
+					return true, "message", nil
+				},
+			},
+		},
+	})
+	assert.Nil(t, err)
+
+	m.runChecks(context.Background())
+
+	assert.Equal(t, []Alert{{Key: "firing", Message: "message"}}, notifier.alerts)
+}
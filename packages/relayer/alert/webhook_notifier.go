@@ -0,0 +1,82 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var defaultTimeout = 10 * time.Second
+
+// WebhookNotifier posts alerts to a Slack or Discord incoming webhook
+type WebhookNotifier struct {
+	url string
+	// field is the JSON field the webhook reads the message from
+	field      string
+	httpClient *http.Client
+}
+
+// NewSlackNotifier posts alerts to a Slack incoming webhook URL
+func NewSlackNotifier(url string, httpClient *http.Client) *WebhookNotifier {
+	return newWebhookNotifier(url, "text", httpClient)
+}
+
+// NewDiscordNotifier posts alerts to a Discord webhook URL
+func NewDiscordNotifier(url string, httpClient *http.Client) *WebhookNotifier {
+	return newWebhookNotifier(url, "content", httpClient)
+}
+
+func newWebhookNotifier(url string, field string, httpClient *http.Client) *WebhookNotifier {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultTimeout}
+	}
+
+	return &WebhookNotifier{
+		url:        url,
+		field:      field,
+		httpClient: httpClient,
+	}
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, a Alert) error {
+	body, err := json.Marshal(map[string]string{n.field: text(a)})
+	if err != nil {
+		return errors.Wrap(err, "json.Marshal")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "http.NewRequestWithContext")
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "n.httpClient.Do")
+	}
+
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %v", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func text(a Alert) string {
+	if a.Resolved {
+		return "RESOLVED: " + a.Message
+	}
+
+	return "ALERT: " + a.Message
+}
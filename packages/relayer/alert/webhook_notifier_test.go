@@ -0,0 +1,64 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WebhookNotifier_Notify(t *testing.T) {
+	tests := []struct {
+		name     string
+		notifier func(url string) *WebhookNotifier
+		alert    Alert
+		status   int
+		wantBody map[string]string
+		wantErr  bool
+	}{
+		{
+			"slack",
+			func(url string) *WebhookNotifier { return NewSlackNotifier(url, nil) },
+			Alert{Key: "k", Message: "low balance"},
+			http.StatusOK,
+			map[string]string{"text": "ALERT: low balance"},
+			false,
+		},
+		{
+			"discordResolved",
+			func(url string) *WebhookNotifier { return NewDiscordNotifier(url, nil) },
+			Alert{Key: "k", Message: "low balance", Resolved: true},
+			http.StatusNoContent,
+			map[string]string{"content": "RESOLVED: low balance"},
+			false,
+		},
+		{
+			"errorStatus",
+			func(url string) *WebhookNotifier { return NewSlackNotifier(url, nil) },
+			Alert{Key: "k", Message: "low balance"},
+			http.StatusInternalServerError,
+			map[string]string{"text": "ALERT: low balance"},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body map[string]string
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			err := tt.notifier(srv.URL).Notify(context.Background(), tt.alert)
+
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantBody, body)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package beacon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+var defaultTimeout = 10 * time.Second
+
+// Client reads the finalized execution block from a consensus client's beacon API, by the root of
+// the finalized checkpoint rather than the execution node's finalized tag.
+type Client struct {
+	url        string
+	httpClient *http.Client
+}
+
+func NewClient(url string, httpClient *http.Client) (*Client, error) {
+	if url == "" {
+		return nil, ErrNoURL
+	}
+
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultTimeout}
+	}
+
+	return &Client{url: strings.TrimSuffix(url, "/"), httpClient: httpClient}, nil
+}
+
+type checkpoint struct {
+	Epoch string      `json:"epoch"`
+	Root  common.Hash `json:"root"`
+}
+
+type finalityCheckpoints struct {
+	Data struct {
+		Finalized checkpoint `json:"finalized"`
+	} `json:"data"`
+}
+
+type executionPayload struct {
+	BlockNumber string      `json:"block_number"`
+	BlockHash   common.Hash `json:"block_hash"`
+}
+
+type signedBeaconBlock struct {
+	Data struct {
+		Message struct {
+			Body struct {
+				ExecutionPayload *executionPayload `json:"execution_payload"`
+			} `json:"body"`
+		} `json:"message"`
+	} `json:"data"`
+}
+
+// FinalizedBlock is the execution block in the beacon block the head state's finalized checkpoint
+// is the root of.
+func (c *Client) FinalizedBlock(ctx context.Context) (*relayer.FinalizedBlock, error) {
+	checkpoints := &finalityCheckpoints{}
+
+	if err := c.get(ctx, "/eth/v1/beacon/states/head/finality_checkpoints", checkpoints); err != nil {
+		return nil, errors.Wrap(err, "c.get(finality_checkpoints)")
+	}
+
+	root := checkpoints.Data.Finalized.Root
+	if root == (common.Hash{}) {
+		return nil, ErrNoFinalizedBlock
+	}
+
+	block := &signedBeaconBlock{}
+
+	if err := c.get(ctx, "/eth/v2/beacon/blocks/"+root.Hex(), block); err != nil {
+		return nil, errors.Wrapf(err, "c.get(blocks/%v)", root.Hex())
+	}
+
+	payload := block.Data.Message.Body.ExecutionPayload
+	if payload == nil || payload.BlockHash == (common.Hash{}) {
+		return nil, errors.Wrapf(ErrNoExecutionPayload, "root: %v", root.Hex())
+	}
+
+	number, err := strconv.ParseUint(payload.BlockNumber, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "block_number: %q", payload.BlockNumber)
+	}
+
+	return &relayer.FinalizedBlock{Number: number, Hash: payload.BlockHash}, nil
+}
+
+// get decodes the JSON answer to a GET of path into v
+func (c *Client) get(ctx context.Context, path string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+path, nil)
+	if err != nil {
+		return errors.Wrap(err, "http.NewRequestWithContext")
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "c.httpClient.Do")
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("beacon API responded %v: %s", resp.StatusCode, body)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return errors.Wrap(err, "json.NewDecoder.Decode")
+	}
+
+	return nil
+}
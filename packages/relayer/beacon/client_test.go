@@ -0,0 +1,99 @@
+package beacon
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	finalizedRoot = "0x1111111111111111111111111111111111111111111111111111111111111111"
+	finalizedHash = "0x2222222222222222222222222222222222222222222222222222222222222222"
+)
+
+// beaconAPI answers the beacon API with checkpointRoot finalized, and a block at finalizedRoot with block
+func beaconAPI(checkpointRoot string, block string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/eth/v1/beacon/states/head/finality_checkpoints":
+			_, _ = w.Write([]byte(`{"data":{"finalized":{"epoch":"10","root":"` + checkpointRoot + `"}}}`))
+		case "/eth/v2/beacon/blocks/" + finalizedRoot:
+			_, _ = w.Write([]byte(block))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func Test_NewClient(t *testing.T) {
+	_, err := NewClient("", nil)
+	assert.Equal(t, ErrNoURL, err)
+
+	c, err := NewClient("http://localhost:5052/", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "http://localhost:5052", c.url)
+}
+
+func Test_FinalizedBlock(t *testing.T) {
+	srv := beaconAPI(finalizedRoot, `{"version":"capella","data":{"message":{"body":{"execution_payload":`+
+		`{"block_number":"1234","block_hash":"`+finalizedHash+`"}}}}}`)
+	defer srv.Close()
+
+	c, err := NewClient(srv.URL, nil)
+	assert.Nil(t, err)
+
+	block, err := c.FinalizedBlock(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, &relayer.FinalizedBlock{Number: 1234, Hash: common.HexToHash(finalizedHash)}, block)
+}
+
+func Test_FinalizedBlock_errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		checkpointRoot string
+		block          string
+		wantErr        error
+	}{
+		{
+			"nothingFinalized",
+			"0x0000000000000000000000000000000000000000000000000000000000000000",
+			"",
+			ErrNoFinalizedBlock,
+		},
+		{
+			"preMerge",
+			finalizedRoot,
+			`{"version":"phase0","data":{"message":{"body":{}}}}`,
+			ErrNoExecutionPayload,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := beaconAPI(tt.checkpointRoot, tt.block)
+			defer srv.Close()
+
+			c, err := NewClient(srv.URL, nil)
+			assert.Nil(t, err)
+
+			_, err = c.FinalizedBlock(context.Background())
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_FinalizedBlock_unknownBlock(t *testing.T) {
+	srv := beaconAPI("0x3333333333333333333333333333333333333333333333333333333333333333", "")
+	defer srv.Close()
+
+	c, err := NewClient(srv.URL, nil)
+	assert.Nil(t, err)
+
+	_, err = c.FinalizedBlock(context.Background())
+	assert.ErrorContains(t, err, "beacon API responded 404")
+}
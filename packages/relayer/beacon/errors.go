@@ -0,0 +1,13 @@
+package beacon
+
+import "github.com/pkg/errors"
+
+var (
+	// ErrNoURL is returned by NewClient without a consensus client to query.
+	ErrNoURL = errors.New("beacon client needs a URL")
+	// ErrNoFinalizedBlock is returned while the chain hasn't finalized a block since genesis.
+	ErrNoFinalizedBlock = errors.New("no finalized block")
+	// ErrNoExecutionPayload is returned for a finalized beacon block without an execution payload,
+	// one from before the merge.
+	ErrNoExecutionPayload = errors.New("finalized block has no execution payload")
+)
@@ -0,0 +1,18 @@
+package relayer
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Blocklist is the addresses flagged by compliance, whose messages aren't relayed
+type Blocklist interface {
+	Blocked(address common.Address) bool
+}
+
+// BlocklistRefresher reloads the blocklist from its source without a restart, returning how many
+// addresses are on it
+type BlocklistRefresher interface {
+	RefreshBlocklist(ctx context.Context) (int, error)
+}
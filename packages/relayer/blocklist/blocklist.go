@@ -0,0 +1,98 @@
+package blocklist
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// Blocklist is the addresses listed in a file, one per line. Blank lines, and anything after a #,
+// are ignored. It's safe to check while it's being refreshed.
+type Blocklist struct {
+	path string
+
+	mu        sync.RWMutex
+	addresses map[common.Address]bool
+}
+
+// New loads the blocklist at path
+func New(path string) (*Blocklist, error) {
+	if path == "" {
+		return nil, ErrNoPath
+	}
+
+	b := &Blocklist{path: path}
+
+	if _, err := b.RefreshBlocklist(context.Background()); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// Blocked is whether address is on the blocklist
+func (b *Blocklist) Blocked(address common.Address) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.addresses[address]
+}
+
+// RefreshBlocklist reloads the blocklist from its file, returning how many addresses are on it.
+// If the file can't be read or parsed, the blocklist is left as it was.
+func (b *Blocklist) RefreshBlocklist(ctx context.Context) (int, error) {
+	f, err := os.Open(b.path)
+	if err != nil {
+		return 0, errors.Wrap(err, "os.Open")
+	}
+
+	defer f.Close()
+
+	addresses, err := parse(f)
+	if err != nil {
+		return 0, errors.Wrapf(err, "blocklist %v", b.path)
+	}
+
+	b.mu.Lock()
+	b.addresses = addresses
+	b.mu.Unlock()
+
+	return len(addresses), nil
+}
+
+func parse(r io.Reader) (map[common.Address]bool, error) {
+	addresses := make(map[common.Address]bool)
+
+	scanner := bufio.NewScanner(r)
+
+	for line := 1; scanner.Scan(); line++ {
+		s := scanner.Text()
+
+		if i := strings.Index(s, "#"); i >= 0 {
+			s = s[:i]
+		}
+
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+
+		if !common.IsHexAddress(s) {
+			return nil, errors.Errorf("line %v: invalid address %v", line, s)
+		}
+
+		addresses[common.HexToAddress(s)] = true
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "scanner.Err")
+	}
+
+	return addresses, nil
+}
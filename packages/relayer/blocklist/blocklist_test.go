@@ -0,0 +1,142 @@
+package blocklist
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	alice = common.HexToAddress("0x63FaC9201494f0bd17B9892B9fae4d52fe3BD377")
+	bob   = common.HexToAddress("0x9F1F41DeB8CaD6bFB2c2dc37C6c1f20d8dd8a1f0")
+)
+
+func writeBlocklist(t *testing.T, path string, contents string) {
+	t.Helper()
+
+	assert.Nil(t, os.WriteFile(path, []byte(contents), 0o600))
+}
+
+func Test_New(t *testing.T) {
+	_, err := New("")
+	assert.Equal(t, ErrNoPath, err)
+
+	_, err = New(filepath.Join(t.TempDir(), "missing"))
+	assert.NotNil(t, err)
+
+	path := filepath.Join(t.TempDir(), "blocklist")
+	writeBlocklist(t, path, alice.Hex()+"\n")
+
+	b, err := New(path)
+	assert.Nil(t, err)
+	assert.True(t, b.Blocked(alice))
+	assert.False(t, b.Blocked(bob))
+}
+
+func Test_parse(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		want     []common.Address
+		wantErr  string
+	}{
+		{
+			"empty",
+			"",
+			nil,
+			"",
+		},
+		{
+			"commentsAndBlankLines",
+			"# sanctioned\n\n" + strings.ToLower(alice.Hex()) + "  # lowercase\n  " + bob.Hex() + "\n",
+			[]common.Address{alice, bob},
+			"",
+		},
+		{
+			"invalidAddress",
+			alice.Hex() + "\nnot-an-address\n",
+			nil,
+			"line 2: invalid address not-an-address",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addresses, err := parse(strings.NewReader(tt.contents))
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, len(tt.want), len(addresses))
+
+			for _, a := range tt.want {
+				assert.True(t, addresses[a])
+			}
+		})
+	}
+}
+
+func Test_RefreshBlocklist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blocklist")
+	writeBlocklist(t, path, alice.Hex()+"\n")
+
+	b, err := New(path)
+	assert.Nil(t, err)
+
+	writeBlocklist(t, path, bob.Hex()+"\n")
+
+	n, err := b.RefreshBlocklist(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, n)
+	assert.False(t, b.Blocked(alice))
+	assert.True(t, b.Blocked(bob))
+
+	// a bad file keeps the list it had
+	writeBlocklist(t, path, "0x1234\n")
+
+	_, err = b.RefreshBlocklist(context.Background())
+	assert.NotNil(t, err)
+	assert.True(t, b.Blocked(bob))
+
+	assert.Nil(t, os.Remove(path))
+
+	_, err = b.RefreshBlocklist(context.Background())
+	assert.NotNil(t, err)
+	assert.True(t, b.Blocked(bob))
+}
+
+func Test_RefreshBlocklist_concurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blocklist")
+	writeBlocklist(t, path, alice.Hex()+"\n")
+
+	b, err := New(path)
+	assert.Nil(t, err)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			_, _ = b.RefreshBlocklist(context.Background())
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			assert.True(t, b.Blocked(alice))
+		}()
+	}
+
+	wg.Wait()
+}
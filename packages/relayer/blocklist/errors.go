@@ -0,0 +1,7 @@
+package blocklist
+
+import "github.com/pkg/errors"
+
+var (
+	ErrNoPath = errors.New("blocklist: path is required")
+)
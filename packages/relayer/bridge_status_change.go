@@ -0,0 +1,37 @@
+package relayer
+
+import (
+	"context"
+	"math/big"
+	"time"
+)
+
+// BridgeStatusChange is a database model mirroring a MessageStatusChanged event, a message's status
+// as the bridge on the chain with ChainID reported it.
+type BridgeStatusChange struct {
+	ID          int         `json:"id"`
+	MsgHash     string      `json:"msgHash"`
+	ChainID     int64       `json:"chainID"`
+	Status      EventStatus `json:"status"`
+	BlockNumber uint64      `json:"blockNumber"`
+	TxHash      string      `json:"txHash"`
+	LogIndex    uint        `json:"logIndex"`
+	// CreatedAt is when the change was indexed
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// SaveBridgeStatusChangeOpts is required to store a new BridgeStatusChange
+type SaveBridgeStatusChangeOpts struct {
+	MsgHash     string
+	ChainID     *big.Int
+	Status      EventStatus
+	BlockNumber uint64
+	TxHash      string
+	LogIndex    uint
+}
+
+// BridgeStatusChangeRepository is used to interact with mirrored MessageStatusChanged events in the store
+type BridgeStatusChangeRepository interface {
+	Save(ctx context.Context, opts SaveBridgeStatusChangeOpts) error
+	FindAllByMsgHash(ctx context.Context, msgHash string) ([]*BridgeStatusChange, error)
+}
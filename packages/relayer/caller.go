@@ -1,7 +1,35 @@
 package relayer
 
-import "context"
+import (
+	"context"
+	"strings"
+)
 
 type Caller interface {
 	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
 }
+
+// prunedStateErrors are substrings of the errors nodes answer with when asked for state
+// they no longer have, e.g. eth_getProof against an old block on a node that isn't an archive node.
+var prunedStateErrors = []string{
+	"missing trie node",
+	"required historical state unavailable",
+	"state is not available",
+	"state not available",
+}
+
+// IsStatePrunedError reports whether err is a node saying it no longer has the state
+// a request was for, which an archive node would still have.
+func IsStatePrunedError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	for _, s := range prunedStateErrors {
+		if strings.Contains(err.Error(), s) {
+			return true
+		}
+	}
+
+	return false
+}
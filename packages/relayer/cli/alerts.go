@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/alert"
+)
+
+// makeAlertMonitor returns a monitor alerting ALERT_SLACK_WEBHOOK_URL, or else
+// ALERT_DISCORD_WEBHOOK_URL, or nil when neither is set and alerting is disabled.
+// Each check is only made when its threshold is set.
+func makeAlertMonitor(
+	getenv func(string) string,
+	balanceClients map[relayer.Layer]alert.BalanceClient,
+	indexers map[int64]relayer.IndexerAdmin,
+	runwayReporters map[int64]relayer.RunwayReporter,
+) (*alert.Monitor, error) {
+	var notifier alert.Notifier
+
+	if url := getenv("ALERT_SLACK_WEBHOOK_URL"); url != "" {
+		notifier = alert.NewSlackNotifier(url, nil)
+	} else if url := getenv("ALERT_DISCORD_WEBHOOK_URL"); url != "" {
+		notifier = alert.NewDiscordNotifier(url, nil)
+	} else {
+		return nil, nil
+	}
+
+	checks, err := alertChecks(getenv, balanceClients, indexers, runwayReporters)
+	if err != nil {
+		return nil, err
+	}
+
+	interval := defaultAlertCheckInterval
+	if i, err := strconv.Atoi(getenv("ALERT_CHECK_INTERVAL_IN_SECONDS")); err == nil && i > 0 {
+		interval = time.Duration(i) * time.Second
+	}
+
+	return alert.NewMonitor(alert.NewMonitorOpts{
+		Notifier: notifier,
+		Checks:   checks,
+		Interval: interval,
+	})
+}
+
+// alertChecks returns a check of each relayer key's balance on each layer with <LAYER>_ALERT_MIN_BALANCE set,
+// of every indexer's lag if ALERT_MAX_INDEXER_LAG_BLOCKS is set, and of the gas runway on every chain if
+// ALERT_MIN_RUNWAY_HOURS is set
+func alertChecks(
+	getenv func(string) string,
+	balanceClients map[relayer.Layer]alert.BalanceClient,
+	indexers map[int64]relayer.IndexerAdmin,
+	runwayReporters map[int64]relayer.RunwayReporter,
+) ([]alert.Check, error) {
+	relayerAddrs, err := relayerAddresses(getenv)
+	if err != nil {
+		return nil, err
+	}
+
+	checks := make([]alert.Check, 0)
+
+	for _, layer := range []relayer.Layer{relayer.L1, relayer.L2} {
+		prefix := strings.ToUpper(string(layer))
+
+		minBalance, ok := new(big.Int).SetString(getenv(prefix+"_ALERT_MIN_BALANCE"), 10)
+		if !ok {
+			continue
+		}
+
+		client, ok := balanceClients[layer]
+		if !ok {
+			continue
+		}
+
+		for i, addr := range relayerAddrs {
+			check := alert.BalanceBelow(prefix, client, addr, minBalance)
+
+			// RELAYER_ECDSA_KEY's check keeps its key from before there were extra keys
+			if i > 0 {
+				check.Key += ":" + addr.Hex()
+			}
+
+			checks = append(checks, check)
+		}
+	}
+
+	if maxLag, err := strconv.ParseUint(getenv("ALERT_MAX_INDEXER_LAG_BLOCKS"), 10, 64); err == nil {
+		for _, chainID := range sortedChainIDs(indexers) {
+			checks = append(checks, alert.IndexerLagAbove(fmt.Sprintf("chain %v", chainID), indexers[chainID], maxLag))
+		}
+	}
+
+	if minHours, err := strconv.ParseFloat(getenv("ALERT_MIN_RUNWAY_HOURS"), 64); err == nil && minHours > 0 {
+		for _, chainID := range sortedChainIDs(runwayReporters) {
+			checks = append(checks, alert.RunwayBelow(fmt.Sprintf("chain %v", chainID), runwayReporters[chainID], minHours))
+		}
+	}
+
+	return checks, nil
+}
+
+// sortedChainIDs are m's keys in ascending order
+func sortedChainIDs[V any](m map[int64]V) []int64 {
+	chainIDs := make([]int64, 0, len(m))
+	for chainID := range m {
+		chainIDs = append(chainIDs, chainID)
+	}
+
+	sort.Slice(chainIDs, func(i, j int) bool { return chainIDs[i] < chainIDs[j] })
+
+	return chainIDs
+}
+
+// relayerAddresses are the addresses of RELAYER_ECDSA_KEY, then of each of RELAYER_ECDSA_KEYS
+func relayerAddresses(getenv func(string) string) ([]common.Address, error) {
+	privateKey, err := crypto.HexToECDSA(getenv("RELAYER_ECDSA_KEY"))
+	if err != nil {
+		return nil, errors.Wrap(err, "crypto.HexToECDSA")
+	}
+
+	extraKeys, err := extraRelayerKeys(getenv)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := []common.Address{crypto.PubkeyToAddress(privateKey.PublicKey)}
+	for _, k := range extraKeys {
+		addrs = append(addrs, crypto.PubkeyToAddress(k.Key.PublicKey))
+	}
+
+	return addrs, nil
+}
@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/alert"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+)
+
+func Test_makeAlertMonitor_disabledWithoutWebhook(t *testing.T) {
+	monitor, err := makeAlertMonitor(func(k string) string { return "" }, nil, nil, nil)
+	assert.Nil(t, err)
+	assert.Nil(t, monitor)
+}
+
+func Test_makeAlertMonitor(t *testing.T) {
+	env := map[string]string{
+		"ALERT_DISCORD_WEBHOOK_URL": "https://discord.com/api/webhooks/1/token",
+		"RELAYER_ECDSA_KEY":         dummyEcdsaKey,
+	}
+
+	monitor, err := makeAlertMonitor(func(k string) string { return env[k] }, nil, nil, nil)
+	assert.Nil(t, err)
+	assert.NotNil(t, monitor)
+}
+
+func Test_alertChecks(t *testing.T) {
+	env := map[string]string{
+		"RELAYER_ECDSA_KEY":            dummyEcdsaKey,
+		"L2_ALERT_MIN_BALANCE":         "1000000000000000000",
+		"ALERT_MAX_INDEXER_LAG_BLOCKS": "100",
+		"ALERT_MIN_RUNWAY_HOURS":       "24",
+	}
+
+	checks, err := alertChecks(
+		func(k string) string { return env[k] },
+		map[relayer.Layer]alert.BalanceClient{relayer.L1: &mock.EthClient{}, relayer.L2: &mock.EthClient{}},
+		map[int64]relayer.IndexerAdmin{
+			2: &mock.IndexerAdmin{ChainID: 2},
+			1: &mock.IndexerAdmin{ChainID: 1},
+		},
+		map[int64]relayer.RunwayReporter{
+			2: &mock.RunwayReporter{},
+		},
+	)
+	assert.Nil(t, err)
+
+	keys := make([]string, 0, len(checks))
+	for _, c := range checks {
+		keys = append(keys, c.Key)
+	}
+
+	assert.Equal(t, []string{"balance:L2", "indexerLag:chain 1", "indexerLag:chain 2", "runway:chain 2"}, keys)
+}
+
+func Test_alertChecks_extraKeys(t *testing.T) {
+	env := map[string]string{
+		"RELAYER_ECDSA_KEY":    dummyEcdsaKey,
+		"RELAYER_ECDSA_KEYS":   "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80:2",
+		"L1_ALERT_MIN_BALANCE": "1000000000000000000",
+	}
+
+	checks, err := alertChecks(
+		func(k string) string { return env[k] },
+		map[relayer.Layer]alert.BalanceClient{relayer.L1: &mock.EthClient{}},
+		nil,
+		nil,
+	)
+	assert.Nil(t, err)
+
+	keys := make([]string, 0, len(checks))
+	for _, c := range checks {
+		keys = append(keys, c.Key)
+	}
+
+	assert.Equal(t, []string{"balance:L1", "balance:L1:0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"}, keys)
+}
+
+func Test_alertChecks_invalidKey(t *testing.T) {
+	_, err := alertChecks(func(k string) string { return "" }, nil, nil, nil)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,22 @@
+package cli
+
+import (
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/blocklist"
+)
+
+// makeBlocklist loads the blocklist at BLOCKLIST_FILE, returning it both to check messages
+// against and to refresh, or nils when it's not set and nothing is blocked.
+func makeBlocklist(getenv func(string) string) (relayer.Blocklist, relayer.BlocklistRefresher, error) {
+	path := getenv("BLOCKLIST_FILE")
+	if path == "" {
+		return nil, nil, nil
+	}
+
+	b, err := blocklist.New(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return b, b, nil
+}
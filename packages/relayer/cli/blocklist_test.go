@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_makeBlocklist(t *testing.T) {
+	blocked := common.HexToAddress("0x63FaC9201494f0bd17B9892B9fae4d52fe3BD377")
+
+	path := filepath.Join(t.TempDir(), "blocklist")
+	assert.Nil(t, os.WriteFile(path, []byte(blocked.Hex()+"\n"), 0o600))
+
+	env := func(vars map[string]string) func(string) string {
+		return func(name string) string { return vars[name] }
+	}
+
+	b, r, err := makeBlocklist(env(nil))
+	assert.Nil(t, err)
+	assert.Nil(t, b)
+	assert.Nil(t, r)
+
+	b, r, err = makeBlocklist(env(map[string]string{"BLOCKLIST_FILE": path}))
+	assert.Nil(t, err)
+	assert.True(t, b.Blocked(blocked))
+	assert.NotNil(t, r)
+
+	_, _, err = makeBlocklist(env(map[string]string{"BLOCKLIST_FILE": filepath.Join(t.TempDir(), "missing")}))
+	assert.NotNil(t, err)
+}
@@ -2,24 +2,44 @@ package cli
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"math/big"
 	"os"
+	"runtime"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/labstack/echo/v4"
 
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/admin"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/alert"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/beacon"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/clockdrift"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/mxcl2"
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer/db"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/failover"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/forwarder"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/gasoracle"
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer/http"
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer/indexer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/kafka"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/leader"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/migrations"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/priceoracle"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/privatetx"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/proof"
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer/repo"
-	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/runway"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/webhook"
 	"github.com/joho/godotenv"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	log "github.com/sirupsen/logrus"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
@@ -39,17 +59,34 @@ var (
 		"MYSQL_USER",
 		"MYSQL_DATABASE",
 		"MYSQL_HOST",
-		"RELAYER_ECDSA_KEY",
 		"CONFIRMATIONS_BEFORE_PROCESSING",
 		"PROMETHEUS_HTTP_PORT",
 	}
 
-	defaultBlockBatchSize                    = 2
-	defaultNumGoroutines                     = 10
-	defaultSubscriptionBackoff               = 600 * time.Second
-	defaultConfirmations                     = 15
-	defaultHeaderSyncIntervalSeconds     int = 60
-	defaultConfirmationsTimeoutInSeconds     = 900
+	defaultBlockBatchSize                        = 2
+	defaultNumGoroutines                         = 10
+	defaultSubscriptionBackoff                   = 600 * time.Second
+	defaultConfirmations                         = 15
+	defaultHeaderSyncIntervalSeconds         int = 60
+	defaultConfirmationsTimeoutInSeconds         = 900
+	defaultReceiptPollInterval                   = 1 * time.Second
+	defaultHeadPollInterval                      = 1 * time.Second
+	defaultReceiptTimeout                        = 240 * time.Second
+	defaultMaxInFlightTxs                        = 8
+	defaultMaxConcurrentProofs                   = 10
+	defaultWebhookStatuses                       = "done"
+	defaultWebhookMaxRetries                     = 5
+	defaultRPCReprobeIntervalInSeconds           = 30
+	defaultRPCRequestTimeoutInSeconds            = 30
+	defaultAlertCheckInterval                    = 60 * time.Second
+	defaultClockDriftSampleIntervalInSeconds     = 30
+	defaultClockDriftWarnThresholdInSeconds      = 60
+	defaultL1FeeToken                            = "ETH"
+	defaultL2FeeToken                            = "MXC"
+	defaultPriceOracleCacheTTLInSeconds          = 60
+	defaultLeaderLeaseTTLInSeconds               = 15
+	defaultGasRunwayWindow                       = 24 * time.Hour
+	defaultGasRunwayInterval                     = 60 * time.Second
 )
 
 func Run(
@@ -58,6 +95,8 @@ func Run(
 	layer relayer.Layer,
 	httpOnly relayer.HTTPOnly,
 	profitableOnly relayer.ProfitableOnly,
+	processingPaused relayer.ProcessingPaused,
+	orderedDelivery relayer.OrderedDelivery,
 ) {
 	if err := loadAndValidateEnv(); err != nil {
 		log.Fatal(err)
@@ -65,23 +104,26 @@ func Run(
 
 	log.SetFormatter(&log.JSONFormatter{})
 
-	db, err := openDBConnection(relayer.DBConnectionOpts{
-		Name:     os.Getenv("MYSQL_USER"),
-		Password: os.Getenv("MYSQL_PASSWORD"),
-		Database: os.Getenv("MYSQL_DATABASE"),
-		Host:     os.Getenv("MYSQL_HOST"),
-		OpenFunc: func(dsn string) (relayer.DB, error) {
-			gormDB, err := gorm.Open(mysql.Open(dsn), &gorm.Config{
-				Logger: logger.Default.LogMode(logger.Silent),
-			})
-			if err != nil {
-				return nil, err
-			}
+	buildInfo := relayer.CurrentBuildInfo()
 
-			return db.New(gormDB), nil
-		},
-	})
+	log.Infof(
+		"relayer version: %v, commit: %v, built: %v with %v",
+		buildInfo.Version,
+		buildInfo.GitCommit,
+		buildInfo.BuildDate,
+		buildInfo.GoVersion,
+	)
+
+	relayer.RecordBuildInfo()
+
+	relayerAddr, err := loadRelayerKey()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Infof("relaying from address: %v", relayerAddr.Hex())
 
+	db, err := openMySQL()
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -91,31 +133,48 @@ func Run(
 		log.Fatal(err)
 	}
 
-	l1EthClient, err := ethclient.Dial(os.Getenv("L1_RPC_URL"))
+	if err := migrations.Up(context.Background(), sqlDB); err != nil {
+		log.Fatal(err)
+	}
+
+	l1EthClient, err := dialRPC(relayer.L1, os.Getenv("L1_RPC_URL"))
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	l2EthClient, err := ethclient.Dial(os.Getenv("L2_RPC_URL"))
+	l2EthClient, err := dialRPC(relayer.L2, os.Getenv("L2_RPC_URL"))
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	srv, err := newHTTPServer(db, l1EthClient, l2EthClient)
+	var indexers []*indexer.Service
+
+	messageReleasers := make(map[int64]relayer.MessageReleaser)
+
+	indexerAdmins := make(map[int64]relayer.IndexerAdmin)
+
+	processingPausers := make(map[int64]relayer.ProcessingPauser)
+
+	messageDiagnosers := make(map[int64]relayer.MessageDiagnoser)
+
+	messageReprocessors := make(map[int64]relayer.MessageReprocessor)
+
+	syncProgressReporters := make(map[int64]relayer.SyncProgressReporter)
+
+	blocklist, blocklistRefresher, err := makeBlocklist(os.Getenv)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	forever := make(chan struct{})
+	var elector *leader.Elector
 
-	go func() {
-		if err := srv.Start(fmt.Sprintf(":%v", os.Getenv("HTTP_PORT"))); err != nil {
-			log.Fatal(err)
-		}
-	}()
+	// nil without leader election, the health check reporting every instance as the leader
+	var roleReporter relayer.RoleReporter
 
 	if !httpOnly {
-		indexers, closeFunc, err := makeIndexers(layer, db, profitableOnly)
+		var closeFunc func()
+
+		indexers, closeFunc, err = makeIndexers(layer, db, profitableOnly, orderedDelivery, blocklist)
 		if err != nil {
 			sqlDB.Close()
 			log.Fatal(err)
@@ -125,14 +184,120 @@ func Run(
 		defer closeFunc()
 
 		for _, i := range indexers {
-			go func(i *indexer.Service) {
-				if err := i.FilterThenSubscribe(context.Background(), mode, watchMode); err != nil {
-					log.Fatal(err)
-				}
-			}(i)
+			chainID, err := i.ChainID(context.Background())
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			messageReleasers[chainID] = i
+			indexerAdmins[chainID] = i
+			processingPausers[chainID] = i
+			messageDiagnosers[chainID] = i
+			messageReprocessors[chainID] = i
+			syncProgressReporters[chainID] = i
+
+			if processingPaused {
+				i.PauseProcessing()
+			}
+		}
+
+		elector, err = makeElector(db, indexers)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		// every instance starts on standby, and sends once it's elected
+		if elector != nil {
+			roleReporter = elector
+
+			for _, i := range indexers {
+				i.SetStandby(true)
+			}
 		}
 	}
 
+	runwayEstimators, err := makeRunwayEstimators(
+		os.Getenv,
+		db,
+		map[relayer.Layer]runway.Client{relayer.L1: l1EthClient, relayer.L2: l2EthClient},
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	runwayReporters := make(map[int64]relayer.RunwayReporter)
+	for chainID, e := range runwayEstimators {
+		runwayReporters[chainID] = e
+	}
+
+	srv, err := newHTTPServer(
+		db,
+		l1EthClient,
+		l2EthClient,
+		messageReleasers,
+		processingPausers,
+		messageDiagnosers,
+		messageReprocessors,
+		syncProgressReporters,
+		blocklistRefresher,
+		roleReporter,
+		runwayReporters,
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	forever := make(chan struct{})
+
+	go func() {
+		if err := srv.Start(fmt.Sprintf(":%v", os.Getenv("HTTP_PORT"))); err != nil {
+			log.Fatal(err)
+		}
+	}()
+
+	if port := os.Getenv("ADMIN_GRPC_PORT"); port != "" {
+		adminSrv, err := newAdminServer(db, indexerAdmins)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		go func() {
+			if err := adminSrv.Start(fmt.Sprintf(":%v", port)); err != nil {
+				log.Fatal(err)
+			}
+		}()
+	}
+
+	alertMonitor, err := makeAlertMonitor(
+		os.Getenv,
+		map[relayer.Layer]alert.BalanceClient{relayer.L1: l1EthClient, relayer.L2: l2EthClient},
+		indexerAdmins,
+		runwayReporters,
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if alertMonitor != nil {
+		go alertMonitor.Start(context.Background())
+	}
+
+	for _, e := range runwayEstimators {
+		go e.Start(context.Background())
+	}
+
+	if elector != nil {
+		go elector.Start(context.Background())
+	}
+
+	for _, i := range indexers {
+		go func(i *indexer.Service) {
+			if err := i.FilterThenSubscribe(context.Background(), mode, watchMode); err != nil {
+				log.Fatal(err)
+			}
+		}(i)
+	}
+
 	<-forever
 }
 
@@ -140,6 +305,8 @@ func makeIndexers(
 	layer relayer.Layer,
 	db relayer.DB,
 	profitableOnly relayer.ProfitableOnly,
+	orderedDelivery relayer.OrderedDelivery,
+	blocklist relayer.Blocklist,
 ) ([]*indexer.Service, func(), error) {
 	eventRepository, err := repo.NewEventRepository(db)
 	if err != nil {
@@ -151,6 +318,30 @@ func makeIndexers(
 		return nil, nil, err
 	}
 
+	crossChainSyncRepository, err := repo.NewCrossChainSyncRepository(db)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bridgeStatusChangeRepository, err := repo.NewBridgeStatusChangeRepository(db)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gasSpendRepository, err := repo.NewGasSpendRepository(db)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// logs that can't be unpacked are only skipped and logged, unless they're quarantined too
+	var quarantinedLogRepository relayer.QuarantinedLogRepository
+	if envBool("QUARANTINE_UNPACK_FAILURES", false) {
+		quarantinedLogRepository, err = repo.NewQuarantinedLogRepository(db)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	blockBatchSize, err := strconv.Atoi(os.Getenv("BLOCK_BATCH_SIZE"))
 	if err != nil || blockBatchSize <= 0 {
 		blockBatchSize = defaultBlockBatchSize
@@ -180,41 +371,246 @@ func makeIndexers(
 		confirmations = defaultConfirmations
 	}
 
+	confirmationStrategy, err := relayer.ParseConfirmationStrategy(
+		os.Getenv("CONFIRMATION_STRATEGY"),
+		uint64(confirmations),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	confirmationsTimeoutInSeconds, err := strconv.Atoi(os.Getenv("CONFIRMATIONS_TIMEOUT_IN_SECONDS"))
 	if err != nil || confirmationsTimeoutInSeconds <= 0 {
 		confirmationsTimeoutInSeconds = defaultConfirmationsTimeoutInSeconds
 	}
 
-	l1EthClient, err := ethclient.Dial(os.Getenv("L1_RPC_URL"))
+	var receiptPollInterval time.Duration
+
+	receiptPollIntervalInSeconds, err := strconv.Atoi(os.Getenv("RECEIPT_POLL_INTERVAL_IN_SECONDS"))
+	if err != nil || receiptPollIntervalInSeconds <= 0 {
+		receiptPollInterval = defaultReceiptPollInterval
+	} else {
+		receiptPollInterval = time.Duration(receiptPollIntervalInSeconds) * time.Second
+	}
+
+	var headPollInterval time.Duration
+
+	headPollIntervalInSeconds, err := strconv.Atoi(os.Getenv("HEAD_POLL_INTERVAL_IN_SECONDS"))
+	if err != nil || headPollIntervalInSeconds <= 0 {
+		headPollInterval = defaultHeadPollInterval
+	} else {
+		headPollInterval = time.Duration(headPollIntervalInSeconds) * time.Second
+	}
+
+	var receiptTimeout time.Duration
+
+	receiptTimeoutInSeconds, err := strconv.Atoi(os.Getenv("RECEIPT_TIMEOUT_IN_SECONDS"))
+	if err != nil || receiptTimeoutInSeconds <= 0 {
+		receiptTimeout = defaultReceiptTimeout
+	} else {
+		receiptTimeout = time.Duration(receiptTimeoutInSeconds) * time.Second
+	}
+
+	maxInFlightTxs, err := strconv.Atoi(os.Getenv("MAX_IN_FLIGHT_TXS"))
+	if err != nil || maxInFlightTxs <= 0 {
+		maxInFlightTxs = defaultMaxInFlightTxs
+	}
+
+	maxConcurrentProofs := envInt("MAX_CONCURRENT_PROOFS", defaultMaxConcurrentProofs)
+
+	// unset never marks messages stale
+	maxMessageAge := time.Duration(envInt("MAX_MESSAGE_AGE_IN_HOURS", 0)) * time.Hour
+
+	retryPolicy, err := relayer.ParseRetryPolicy(os.Getenv("RETRY_POLICY"))
 	if err != nil {
-		log.Fatal(err)
+		return nil, nil, err
 	}
 
-	l2EthClient, err := ethclient.Dial(os.Getenv("L2_RPC_URL"))
+	processorConcurrency, err := parseProcessorConcurrency(os.Getenv("PROCESSOR_CONCURRENCY"))
 	if err != nil {
-		log.Fatal(err)
+		return nil, nil, err
 	}
 
-	l1RpcClient, err := rpc.DialContext(context.Background(), os.Getenv("L1_RPC_URL"))
+	// only messages to these destination chains are indexed. unset indexes every destination's.
+	l1DestChainIDs, err := parseDestChainIDs("L1_INDEX_DEST_CHAIN_IDS", os.Getenv("L1_INDEX_DEST_CHAIN_IDS"))
 	if err != nil {
 		return nil, nil, err
 	}
 
-	l2RpcClient, err := rpc.DialContext(context.Background(), os.Getenv("L2_RPC_URL"))
+	l2DestChainIDs, err := parseDestChainIDs("L2_INDEX_DEST_CHAIN_IDS", os.Getenv("L2_INDEX_DEST_CHAIN_IDS"))
 	if err != nil {
 		return nil, nil, err
 	}
 
+	statusChangeNotifier, err := makeStatusChangeNotifier(db)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// both indexers publish through one producer, so its buffer bounds them together
+	kafkaProducer, err := makeKafkaProducer()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	extraKeys, err := extraRelayerKeys(os.Getenv)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keySelection := relayer.KeySelection(envString("RELAYER_KEY_SELECTION", string(relayer.RoundRobinKeySelection)))
+
+	// in wei, a key below it on a chain isn't sent that chain's messages. unset keeps every key in rotation.
+	l1KeyMinBalance, _ := new(big.Int).SetString(os.Getenv("L1_KEY_MIN_BALANCE"), 10)
+	l2KeyMinBalance, _ := new(big.Int).SetString(os.Getenv("L2_KEY_MIN_BALANCE"), 10)
+
+	// the token amount is in whole tokens, the ETH amount in wei. unset disables holding.
+	holdTokenAmountThreshold, _ := new(big.Int).SetString(os.Getenv("HOLD_TOKEN_AMOUNT_THRESHOLD"), 10)
+	holdETHAmountThreshold, _ := new(big.Int).SetString(os.Getenv("HOLD_ETH_AMOUNT_THRESHOLD"), 10)
+
+	// each chain's client is both its eth and rpc client, failing over between the
+	// comma separated endpoints in <LAYER>_RPC_URL
+	l1Client, err := dialRPC(relayer.L1, os.Getenv("L1_RPC_URL"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	l2Client, err := dialRPC(relayer.L2, os.Getenv("L2_RPC_URL"))
+	if err != nil {
+		l1Client.Close()
+		return nil, nil, err
+	}
+
+	clockDriftMonitor, err := clockdrift.NewMonitor(clockdrift.NewMonitorOpts{
+		Clients: []clockdrift.Client{l1Client, l2Client},
+		Interval: time.Duration(
+			envInt("CLOCK_DRIFT_SAMPLE_INTERVAL_IN_SECONDS", defaultClockDriftSampleIntervalInSeconds),
+		) * time.Second,
+		WarnThreshold: time.Duration(
+			envInt("CLOCK_DRIFT_WARN_THRESHOLD_IN_SECONDS", defaultClockDriftWarnThresholdInSeconds),
+		) * time.Second,
+	})
+	if err != nil {
+		l1Client.Close()
+		l2Client.Close()
+
+		return nil, nil, err
+	}
+
+	clockDriftCtx, stopClockDriftMonitor := context.WithCancel(context.Background())
+
+	go clockDriftMonitor.Start(clockDriftCtx)
+
+	// left nil when kafka is disabled, as a nil *kafka.Producer wouldn't be a nil EventPublisher
+	var eventPublisher relayer.EventPublisher
+
+	kafkaCtx, stopKafkaProducer := context.WithCancel(context.Background())
+
+	if kafkaProducer != nil {
+		eventPublisher = kafkaProducer
+
+		go kafkaProducer.Start(kafkaCtx)
+	}
+
+	closeFunc := func() {
+		stopClockDriftMonitor()
+		stopKafkaProducer()
+		l1Client.Close()
+		l2Client.Close()
+	}
+
+	if err := verifySignalSlotLayouts(layer, l1Client, l2Client); err != nil {
+		closeFunc()
+		return nil, nil, err
+	}
+
+	verifyBindings(l1Client, l2Client)
+
+	// gas oracles are named for the chain they price transactions on, the destination
+	l1GasOracle, err := makeGasOracle(relayer.L1, l1Client, relayer.ZeroAddress)
+	if err != nil {
+		closeFunc()
+		return nil, nil, err
+	}
+
+	l2GasOracle, err := makeGasOracle(relayer.L2, l2Client, common.HexToAddress(os.Getenv("L2_MXC_ADDRESS")))
+	if err != nil {
+		closeFunc()
+		return nil, nil, err
+	}
+
+	// both indexers share one, so a price read for one is cached for the other
+	priceOracle, err := makePriceOracle(l1Client)
+	if err != nil {
+		closeFunc()
+		return nil, nil, err
+	}
+
+	// like gas oracles, forwarders are named for the chain they send transactions on
+	l1TxBuilder, err := makeTxBuilder(relayer.L1, l1Client)
+	if err != nil {
+		closeFunc()
+		return nil, nil, err
+	}
+
+	l2TxBuilder, err := makeTxBuilder(relayer.L2, l2Client)
+	if err != nil {
+		closeFunc()
+		return nil, nil, err
+	}
+
+	// and private relays for the chain they relay transactions to
+	l1PrivateTxRelay, err := makePrivateTxRelay(relayer.L1, l1Client)
+	if err != nil {
+		closeFunc()
+		return nil, nil, err
+	}
+
+	l2PrivateTxRelay, err := makePrivateTxRelay(relayer.L2, l2Client)
+	if err != nil {
+		closeFunc()
+		return nil, nil, err
+	}
+
+	// and gas limit floors for the chain the recipients are on
+	l1GasLimitFloors, err := makeGasLimitFloors(context.Background(), relayer.L1, l1Client, os.Getenv)
+	if err != nil {
+		closeFunc()
+		return nil, nil, err
+	}
+
+	l2GasLimitFloors, err := makeGasLimitFloors(context.Background(), relayer.L2, l2Client, os.Getenv)
+	if err != nil {
+		closeFunc()
+		return nil, nil, err
+	}
+
+	// and consensus clients for the chain whose blocks are proven, the source
+	l1FinalityOracle, err := makeFinalityOracle(relayer.L1)
+	if err != nil {
+		closeFunc()
+		return nil, nil, err
+	}
+
+	l2FinalityOracle, err := makeFinalityOracle(relayer.L2)
+	if err != nil {
+		closeFunc()
+		return nil, nil, err
+	}
+
 	indexers := make([]*indexer.Service, 0)
 
 	if layer == relayer.L1 || layer == relayer.Both {
 		l1Indexer, err := indexer.NewService(indexer.NewServiceOpts{
-			EventRepo:     eventRepository,
-			BlockRepo:     blockRepository,
-			DestEthClient: l2EthClient,
-			EthClient:     l1EthClient,
-			RPCClient:     l1RpcClient,
-			DestRPCClient: l2RpcClient,
+			EventRepo: eventRepository,
+			BlockRepo: blockRepository,
+
+			CrossChainSyncRepo:     crossChainSyncRepository,
+			BridgeStatusChangeRepo: bridgeStatusChangeRepository,
+			DestEthClient:          l2Client,
+			EthClient:              l1Client,
+			RPCClient:              l1Client,
+			DestRPCClient:          l2Client,
 
 			ECDSAKey:                      os.Getenv("RELAYER_ECDSA_KEY"),
 			BridgeAddress:                 common.HexToAddress(os.Getenv("L1_BRIDGE_ADDRESS")),
@@ -223,13 +619,54 @@ func makeIndexers(
 			SrcMxcAddress:                 common.HexToAddress(os.Getenv("L1_MXC_ADDRESS")),
 			SrcSignalServiceAddress:       common.HexToAddress(os.Getenv("L1_SIGNAL_SERVICE_ADDRESS")),
 			DestTokenVaultAddress:         common.HexToAddress(os.Getenv("L2_TOKEN_VAULT_ADDRESS")),
+			FeeToken:                      envString("L1_FEE_TOKEN", defaultL1FeeToken),
+			DestNativeToken:               envString("L2_FEE_TOKEN", defaultL2FeeToken),
+			PriceOracle:                   priceOracle,
+			GasOracle:                     l2GasOracle,
+			TxBuilder:                     l2TxBuilder,
+			AccessLists:                   envBool("L2_ACCESS_LISTS", false),
+			PrivateTxRelay:                l2PrivateTxRelay,
+			ClockDrift:                    clockDriftMonitor,
+			Blocklist:                     blocklist,
+			TreasuryAddress:               common.HexToAddress(os.Getenv("TREASURY_ADDRESS")),
 			BlockBatchSize:                uint64(blockBatchSize),
 			NumGoroutines:                 numGoroutines,
 			SubscriptionBackoff:           subscriptionBackoff,
+			HeadPollInterval:              headPollInterval,
 			Confirmations:                 uint64(confirmations),
 			ProfitableOnly:                profitableOnly,
 			HeaderSyncIntervalInSeconds:   int64(headerSyncIntervalInSeconds),
 			ConfirmationsTimeoutInSeconds: int64(confirmationsTimeoutInSeconds),
+			ConfirmationStrategy:          confirmationStrategy,
+			ReceiptPollInterval:           receiptPollInterval,
+			ReceiptTimeout:                receiptTimeout,
+			HoldTokenAmountThreshold:      holdTokenAmountThreshold,
+			HoldETHAmountThreshold:        holdETHAmountThreshold,
+			StartHeight:                   os.Getenv("START_HEIGHT"),
+			OrderedDelivery:               orderedDelivery,
+			MaxInFlightTxs:                maxInFlightTxs,
+			ProcessorConcurrency:          processorConcurrency,
+			MaxConcurrentProofs:           maxConcurrentProofs,
+			StatusChangeNotifier:          statusChangeNotifier,
+			EventPublisher:                eventPublisher,
+			MaxMessageAge:                 maxMessageAge,
+			EventWriteBatchSize:           envInt("EVENT_WRITE_BATCH_SIZE", 0),
+			FetchAllLogTopics:             envBool("FETCH_ALL_LOG_TOPICS", false),
+			DestConfirmationsBeforeDone:   uint64(envInt("DEST_CONFIRMATIONS_BEFORE_DONE", 0)),
+			DestReorgWindow:               uint64(envInt("DEST_REORG_WINDOW", 0)),
+			GasLimitFloors:                l2GasLimitFloors,
+			ProveFinalizedOnly:            envBool("PROVE_FINALIZED_ONLY", false),
+			FinalityOracle:                l1FinalityOracle,
+			SubscriptionHeartbeat:         time.Duration(envInt("L1_SUBSCRIPTION_HEARTBEAT_IN_SECONDS", 0)) * time.Second,
+			GasSpendRepo:                  gasSpendRepository,
+			DestChainIDs:                  l1DestChainIDs,
+			StrictLogUnpacking:            envBool("STRICT_LOG_UNPACKING", false),
+			QuarantinedLogRepo:            quarantinedLogRepository,
+			ECDSAKeyWeight:                envInt("RELAYER_ECDSA_KEY_WEIGHT", 1),
+			ExtraECDSAKeys:                extraKeys,
+			KeySelection:                  keySelection,
+			KeyMinBalance:                 l2KeyMinBalance,
+			RetryPolicy:                   retryPolicy,
 		})
 		if err != nil {
 			log.Fatal(err)
@@ -240,12 +677,15 @@ func makeIndexers(
 
 	if layer == relayer.L2 || layer == relayer.Both {
 		l2Indexer, err := indexer.NewService(indexer.NewServiceOpts{
-			EventRepo:     eventRepository,
-			BlockRepo:     blockRepository,
-			DestEthClient: l1EthClient,
-			EthClient:     l2EthClient,
-			RPCClient:     l2RpcClient,
-			DestRPCClient: l1RpcClient,
+			EventRepo: eventRepository,
+			BlockRepo: blockRepository,
+
+			CrossChainSyncRepo:     crossChainSyncRepository,
+			BridgeStatusChangeRepo: bridgeStatusChangeRepository,
+			DestEthClient:          l1Client,
+			EthClient:              l2Client,
+			RPCClient:              l2Client,
+			DestRPCClient:          l1Client,
 
 			ECDSAKey:                      os.Getenv("RELAYER_ECDSA_KEY"),
 			BridgeAddress:                 common.HexToAddress(os.Getenv("L2_BRIDGE_ADDRESS")),
@@ -253,13 +693,54 @@ func makeIndexers(
 			DestMxcAddress:                common.HexToAddress(os.Getenv("L1_MXC_ADDRESS")),
 			SrcSignalServiceAddress:       common.HexToAddress(os.Getenv("L2_SIGNAL_SERVICE_ADDRESS")),
 			DestTokenVaultAddress:         common.HexToAddress(os.Getenv("L1_TOKEN_VAULT_ADDRESS")),
+			FeeToken:                      envString("L2_FEE_TOKEN", defaultL2FeeToken),
+			DestNativeToken:               envString("L1_FEE_TOKEN", defaultL1FeeToken),
+			PriceOracle:                   priceOracle,
+			GasOracle:                     l1GasOracle,
+			TxBuilder:                     l1TxBuilder,
+			AccessLists:                   envBool("L1_ACCESS_LISTS", false),
+			PrivateTxRelay:                l1PrivateTxRelay,
+			ClockDrift:                    clockDriftMonitor,
+			Blocklist:                     blocklist,
+			TreasuryAddress:               common.HexToAddress(os.Getenv("TREASURY_ADDRESS")),
 			BlockBatchSize:                uint64(blockBatchSize),
 			NumGoroutines:                 numGoroutines,
 			SubscriptionBackoff:           subscriptionBackoff,
+			HeadPollInterval:              headPollInterval,
 			Confirmations:                 uint64(confirmations),
 			ProfitableOnly:                profitableOnly,
 			HeaderSyncIntervalInSeconds:   int64(headerSyncIntervalInSeconds),
 			ConfirmationsTimeoutInSeconds: int64(confirmationsTimeoutInSeconds),
+			ConfirmationStrategy:          confirmationStrategy,
+			ReceiptPollInterval:           receiptPollInterval,
+			ReceiptTimeout:                receiptTimeout,
+			HoldTokenAmountThreshold:      holdTokenAmountThreshold,
+			HoldETHAmountThreshold:        holdETHAmountThreshold,
+			StartHeight:                   os.Getenv("START_HEIGHT"),
+			OrderedDelivery:               orderedDelivery,
+			MaxInFlightTxs:                maxInFlightTxs,
+			ProcessorConcurrency:          processorConcurrency,
+			MaxConcurrentProofs:           maxConcurrentProofs,
+			StatusChangeNotifier:          statusChangeNotifier,
+			EventPublisher:                eventPublisher,
+			MaxMessageAge:                 maxMessageAge,
+			EventWriteBatchSize:           envInt("EVENT_WRITE_BATCH_SIZE", 0),
+			FetchAllLogTopics:             envBool("FETCH_ALL_LOG_TOPICS", false),
+			DestConfirmationsBeforeDone:   uint64(envInt("DEST_CONFIRMATIONS_BEFORE_DONE", 0)),
+			DestReorgWindow:               uint64(envInt("DEST_REORG_WINDOW", 0)),
+			GasLimitFloors:                l1GasLimitFloors,
+			ProveFinalizedOnly:            envBool("PROVE_FINALIZED_ONLY", false),
+			FinalityOracle:                l2FinalityOracle,
+			SubscriptionHeartbeat:         time.Duration(envInt("L2_SUBSCRIPTION_HEARTBEAT_IN_SECONDS", 0)) * time.Second,
+			GasSpendRepo:                  gasSpendRepository,
+			DestChainIDs:                  l2DestChainIDs,
+			StrictLogUnpacking:            envBool("STRICT_LOG_UNPACKING", false),
+			QuarantinedLogRepo:            quarantinedLogRepository,
+			ECDSAKeyWeight:                envInt("RELAYER_ECDSA_KEY_WEIGHT", 1),
+			ExtraECDSAKeys:                extraKeys,
+			KeySelection:                  keySelection,
+			KeyMinBalance:                 l1KeyMinBalance,
+			RetryPolicy:                   retryPolicy,
 		})
 		if err != nil {
 			log.Fatal(err)
@@ -268,36 +749,430 @@ func makeIndexers(
 		indexers = append(indexers, l2Indexer)
 	}
 
-	closeFunc := func() {
-		l1EthClient.Close()
-		l2EthClient.Close()
-		l1RpcClient.Close()
-		l2RpcClient.Close()
+	return indexers, closeFunc, nil
+}
+
+// dialRPC dials a chain's comma separated list of RPC endpoints, failing over to the next when one
+// can't be reached, and re-probing failed ones every RPC_REPROBE_INTERVAL_IN_SECONDS. Requests
+// carry the layer's custom headers, see rpcHeaders. Connections to http endpoints are kept open
+// for reuse per RPC_MAX_IDLE_CONNS_PER_HOST and RPC_IDLE_CONN_TIMEOUT_IN_SECONDS.
+func dialRPC(layer relayer.Layer, urls string) (*failover.Client, error) {
+	endpoints := failover.SplitURLs(urls)
+
+	headers, err := rpcHeaders(layer, len(endpoints), os.Getenv)
+	if err != nil {
+		return nil, err
 	}
 
-	return indexers, closeFunc, nil
+	return failover.Dial(context.Background(), failover.DialOpts{
+		URLs:    endpoints,
+		Headers: headers,
+		ReprobeInterval: time.Duration(
+			envInt("RPC_REPROBE_INTERVAL_IN_SECONDS", defaultRPCReprobeIntervalInSeconds),
+		) * time.Second,
+		RequestTimeout: time.Duration(
+			envInt("RPC_REQUEST_TIMEOUT_IN_SECONDS", defaultRPCRequestTimeoutInSeconds),
+		) * time.Second,
+		Transport: failover.TransportOpts{
+			MaxIdleConnsPerHost: envInt("RPC_MAX_IDLE_CONNS_PER_HOST", failover.DefaultMaxIdleConnsPerHost),
+			IdleConnTimeout: time.Duration(
+				envInt("RPC_IDLE_CONN_TIMEOUT_IN_SECONDS", int(failover.DefaultIdleConnTimeout/time.Second)),
+			) * time.Second,
+			DisableHTTP2: !envBool("RPC_HTTP2", true),
+		},
+	})
+}
+
+// makeGasOracle returns the oracle pricing transactions sent to layer, from the comma separated
+// sources in <LAYER>_GAS_ORACLE, or nil to use the node's suggestion when it's unset.
+// mxcL2Address is the layer's MxcL2 contract, or the zero address if it doesn't have one.
+func makeGasOracle(
+	layer relayer.Layer,
+	client *failover.Client,
+	mxcL2Address common.Address,
+) (relayer.GasOracle, error) {
+	prefix := strings.ToUpper(string(layer))
+
+	opts := gasoracle.NewOpts{
+		Sources: os.Getenv(prefix + "_GAS_ORACLE"),
+		Client:  client,
+	}
+
+	// in wei
+	opts.FixedPrice, _ = new(big.Int).SetString(os.Getenv(prefix+"_GAS_ORACLE_FIXED_PRICE"), 10)
+
+	if mxcL2Address != relayer.ZeroAddress {
+		mxcL2, err := mxcl2.NewMxcL2(mxcL2Address, client)
+		if err != nil {
+			return nil, errors.Wrap(err, "mxcl2.NewMxcL2")
+		}
+
+		opts.MxcL2 = mxcL2
+	}
+
+	oracle, err := gasoracle.New(opts)
+	if err != nil {
+		return nil, errors.Wrapf(err, "gasoracle.New(%v)", layer)
+	}
+
+	return oracle, nil
+}
+
+// makePriceOracle returns the oracle valuing fee tokens in ETH, from the SYMBOL=price pairs in
+// PRICE_ORACLE_STATIC_PRICES and the SYMBOL=address Chainlink feeds, read on L1, in
+// PRICE_ORACLE_FEEDS, or nil to compare fees and costs as is when both are unset.
+func makePriceOracle(l1Client *failover.Client) (relayer.PriceOracle, error) {
+	staticPrices, err := priceoracle.ParseStaticPrices(os.Getenv("PRICE_ORACLE_STATIC_PRICES"))
+	if err != nil {
+		return nil, errors.Wrap(err, "priceoracle.ParseStaticPrices")
+	}
+
+	feeds, err := priceoracle.ParseFeeds(os.Getenv("PRICE_ORACLE_FEEDS"))
+	if err != nil {
+		return nil, errors.Wrap(err, "priceoracle.ParseFeeds")
+	}
+
+	oracle, err := priceoracle.New(priceoracle.NewOpts{
+		StaticPrices: staticPrices,
+		Feeds:        feeds,
+		Client:       l1Client,
+		CacheTTL: time.Duration(
+			envInt("PRICE_ORACLE_CACHE_TTL_IN_SECONDS", defaultPriceOracleCacheTTLInSeconds),
+		) * time.Second,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "priceoracle.New")
+	}
+
+	return oracle, nil
+}
+
+// makeKafkaProducer returns a producer publishing indexed events to KAFKA_EVENTS_TOPIC, and status
+// changes to KAFKA_STATUS_CHANGES_TOPIC, or nil if KAFKA_BROKERS is unset and nothing is published.
+func makeKafkaProducer() (*kafka.Producer, error) {
+	producer, err := kafka.New(kafka.NewProducerOpts{
+		Brokers:            os.Getenv("KAFKA_BROKERS"),
+		EventsTopic:        os.Getenv("KAFKA_EVENTS_TOPIC"),
+		StatusChangesTopic: os.Getenv("KAFKA_STATUS_CHANGES_TOPIC"),
+		BufferSize:         envInt("KAFKA_BUFFER_SIZE", kafka.DefaultBufferSize),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "kafka.New")
+	}
+
+	return producer, nil
+}
+
+// makeTxBuilder returns a forwarder sending processMessage transactions to layer's bridge through
+// <LAYER>_FORWARDER_ADDRESS, or nil to call the bridge directly when it's unset.
+func makeTxBuilder(layer relayer.Layer, client *failover.Client) (relayer.TxBuilder, error) {
+	prefix := strings.ToUpper(string(layer))
+
+	forwarderAddress := os.Getenv(prefix + "_FORWARDER_ADDRESS")
+	if forwarderAddress == "" {
+		return nil, nil
+	}
+
+	if !common.IsHexAddress(forwarderAddress) {
+		return nil, errors.Errorf("invalid %v_FORWARDER_ADDRESS %v", prefix, forwarderAddress)
+	}
+
+	key, err := crypto.HexToECDSA(os.Getenv("RELAYER_ECDSA_KEY"))
+	if err != nil {
+		return nil, errors.Wrap(err, "crypto.HexToECDSA")
+	}
+
+	f, err := forwarder.New(forwarder.NewOpts{
+		Address:       common.HexToAddress(forwarderAddress),
+		BridgeAddress: common.HexToAddress(os.Getenv(prefix + "_BRIDGE_ADDRESS")),
+		Backend:       client,
+		ECDSAKey:      key,
+		DomainName:    os.Getenv(prefix + "_FORWARDER_DOMAIN_NAME"),
+		DomainVersion: os.Getenv(prefix + "_FORWARDER_DOMAIN_VERSION"),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "forwarder.New(%v)", layer)
+	}
+
+	return f, nil
+}
+
+// makePrivateTxRelay returns a private relay sending processMessage transactions to layer through
+// <LAYER>_PRIVATE_TX_RELAY_URL, or nil to send them to the node's public mempool when it's unset.
+func makePrivateTxRelay(layer relayer.Layer, client *failover.Client) (relayer.PrivateTxRelay, error) {
+	prefix := strings.ToUpper(string(layer))
+
+	url := os.Getenv(prefix + "_PRIVATE_TX_RELAY_URL")
+	if url == "" {
+		return nil, nil
+	}
+
+	key, err := crypto.HexToECDSA(os.Getenv("RELAYER_ECDSA_KEY"))
+	if err != nil {
+		return nil, errors.Wrap(err, "crypto.HexToECDSA")
+	}
+
+	r, err := privatetx.New(privatetx.NewOpts{
+		URL:       url,
+		Backend:   client,
+		ECDSAKey:  key,
+		MaxBlocks: uint64(envInt(prefix+"_PRIVATE_TX_MAX_BLOCKS", privatetx.DefaultMaxBlocks)),
+		Fast:      envBool(prefix+"_PRIVATE_TX_FAST", false),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "privatetx.New(%v)", layer)
+	}
+
+	return r, nil
+}
+
+// makeFinalityOracle returns a beacon API client for layer's consensus client at <LAYER>_BEACON_URL,
+// which layer's blocks are only proven against once it's finalized them, or nil if it's unset and
+// the execution node's finalized tag is used instead. It's only used with PROVE_FINALIZED_ONLY.
+func makeFinalityOracle(layer relayer.Layer) (relayer.FinalityOracle, error) {
+	prefix := strings.ToUpper(string(layer))
+
+	url := os.Getenv(prefix + "_BEACON_URL")
+	if url == "" {
+		return nil, nil
+	}
+
+	if !envBool("PROVE_FINALIZED_ONLY", false) {
+		return nil, errors.Errorf("%v_BEACON_URL is only used with PROVE_FINALIZED_ONLY=true", prefix)
+	}
+
+	c, err := beacon.NewClient(url, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "beacon.NewClient(%v)", layer)
+	}
+
+	return c, nil
+}
+
+// makeStatusChangeNotifier returns a webhook notifier if WEBHOOK_SECRET is set, or nil if webhooks are disabled.
+// WEBHOOK_URL is notified of every message with a status in WEBHOOK_STATUSES, and owners
+// can be subscribed individually in the webhook_subscriptions table.
+func makeStatusChangeNotifier(db relayer.DB) (relayer.StatusChangeNotifier, error) {
+	secret := os.Getenv("WEBHOOK_SECRET")
+	if secret == "" {
+		return nil, nil
+	}
+
+	subscriptionRepository, err := repo.NewWebhookSubscriptionRepository(db)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveryRepository, err := repo.NewWebhookDeliveryRepository(db)
+	if err != nil {
+		return nil, err
+	}
+
+	statusesEnv := os.Getenv("WEBHOOK_STATUSES")
+	if statusesEnv == "" {
+		statusesEnv = defaultWebhookStatuses
+	}
+
+	statuses, err := relayer.ParseEventStatuses(statusesEnv)
+	if err != nil {
+		return nil, errors.Wrap(err, "relayer.ParseEventStatuses")
+	}
+
+	maxRetries, err := strconv.Atoi(os.Getenv("WEBHOOK_MAX_RETRIES"))
+	if err != nil || maxRetries <= 0 {
+		maxRetries = defaultWebhookMaxRetries
+	}
+
+	return webhook.NewNotifier(webhook.NewNotifierOpts{
+		URL:              os.Getenv("WEBHOOK_URL"),
+		Secret:           secret,
+		Statuses:         statuses,
+		SubscriptionRepo: subscriptionRepository,
+		DeliveryRepo:     deliveryRepository,
+		MaxRetries:       maxRetries,
+	})
+}
+
+// makeElector returns the leader elector for LEADER_ELECTION, or nil without it. Only the
+// elected instance's indexers send transactions, the others' are put on standby, indexing
+// until they take over within LEADER_LEASE_TTL_IN_SECONDS of the leader going away.
+func makeElector(db relayer.DB, indexers []*indexer.Service) (*leader.Elector, error) {
+	if !envBool("LEADER_ELECTION", false) {
+		return nil, nil
+	}
+
+	leaseRepository, err := repo.NewLeaseRepository(db)
+	if err != nil {
+		return nil, err
+	}
+
+	setStandby := func(standby bool) {
+		for _, i := range indexers {
+			i.SetStandby(standby)
+		}
+	}
+
+	elector, err := leader.NewElector(leader.NewElectorOpts{
+		LeaseRepo: leaseRepository,
+		Name:      os.Getenv("LEADER_LEASE_NAME"),
+		TTL:       time.Duration(envInt("LEADER_LEASE_TTL_IN_SECONDS", defaultLeaderLeaseTTLInSeconds)) * time.Second,
+		OnPromote: func() { setStandby(false) },
+		OnDemote:  func() { setStandby(true) },
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "leader.NewElector")
+	}
+
+	log.Infof("leader election on, as %v", elector.Holder())
+
+	return elector, nil
+}
+
+// verifySignalSlotLayouts fails fast if a source chain's SignalService stores signals at a
+// different slot than we generate proofs for, i.e. after an upgrade changed its layout.
+func verifySignalSlotLayouts(layer relayer.Layer, l1Client *failover.Client, l2Client *failover.Client) error {
+	if layer == relayer.L1 || layer == relayer.Both {
+		if err := verifySignalSlotLayout(l1Client, os.Getenv("L1_SIGNAL_SERVICE_ADDRESS")); err != nil {
+			return errors.Wrap(err, "verifySignalSlotLayout(L1)")
+		}
+	}
+
+	if layer == relayer.L2 || layer == relayer.Both {
+		if err := verifySignalSlotLayout(l2Client, os.Getenv("L2_SIGNAL_SERVICE_ADDRESS")); err != nil {
+			return errors.Wrap(err, "verifySignalSlotLayout(L2)")
+		}
+	}
+
+	return nil
+}
+
+func verifySignalSlotLayout(client *failover.Client, signalServiceAddress string) error {
+	addr := common.HexToAddress(signalServiceAddress)
+	if addr == relayer.ZeroAddress {
+		return nil
+	}
+
+	chainID, err := client.ChainID(context.Background())
+	if err != nil {
+		return errors.Wrap(err, "client.ChainID")
+	}
+
+	return proof.VerifySignalSlotLayout(context.Background(), client, addr, chainID.Uint64())
+}
+
+// defaultQueryTimeoutInMs bounds repository queries when MYSQL_QUERY_TIMEOUT_IN_MS isn't set,
+// long enough for an export batch but short enough that a starved pool surfaces as errors.
+const defaultQueryTimeoutInMs = 30000
+
+func openMySQL() (relayer.DB, error) {
+	queryTimeout := time.Duration(envInt("MYSQL_QUERY_TIMEOUT_IN_MS", defaultQueryTimeoutInMs)) * time.Millisecond
+
+	return openDBConnection(relayer.DBConnectionOpts{
+		Name:            os.Getenv("MYSQL_USER"),
+		Password:        os.Getenv("MYSQL_PASSWORD"),
+		Database:        os.Getenv("MYSQL_DATABASE"),
+		Host:            os.Getenv("MYSQL_HOST"),
+		MaxIdleConns:    envInt("MYSQL_MAX_IDLE_CONNS", 0),
+		MaxOpenConns:    envInt("MYSQL_MAX_OPEN_CONNS", 0),
+		ConnMaxLifetime: time.Duration(envInt("MYSQL_CONN_MAX_LIFETIME_IN_MS", 0)) * time.Millisecond,
+		QueryTimeout:    queryTimeout,
+		ReplicaHost:     os.Getenv("MYSQL_REPLICA_HOST"),
+		OpenFunc: func(dsn string, replicaDSN string) (relayer.DB, error) {
+			gormDB, err := gorm.Open(mysql.Open(dsn), &gorm.Config{
+				Logger: logger.Default.LogMode(logger.Silent),
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			d := db.New(gormDB).WithQueryTimeout(queryTimeout)
+
+			if replicaDSN != "" {
+				replica, err := gorm.Open(mysql.Open(replicaDSN), &gorm.Config{
+					Logger: logger.Default.LogMode(logger.Silent),
+				})
+				if err != nil {
+					return nil, errors.Wrap(err, "gorm.Open replica")
+				}
+
+				d = d.WithReplica(replica)
+			}
+
+			return d, nil
+		},
+	})
+}
+
+// envInt parses an int env var, returning defaultValue if it's unset, invalid or not positive.
+func envInt(name string, defaultValue int) int {
+	v, err := strconv.Atoi(os.Getenv(name))
+	if err != nil || v <= 0 {
+		return defaultValue
+	}
+
+	return v
+}
+
+func envBool(name string, defaultValue bool) bool {
+	v, err := strconv.ParseBool(os.Getenv(name))
+	if err != nil {
+		return defaultValue
+	}
+
+	return v
+}
+
+func envString(name string, defaultValue string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+
+	return defaultValue
+}
+
+// parseProcessorConcurrency parses PROCESSOR_CONCURRENCY, which defaults to GOMAXPROCS, and must be >= 1 if set.
+func parseProcessorConcurrency(v string) (int, error) {
+	if v == "" {
+		return runtime.GOMAXPROCS(0), nil
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 {
+		return 0, errors.Errorf("invalid PROCESSOR_CONCURRENCY %q, must be an integer >= 1", v)
+	}
+
+	return n, nil
+}
+
+// parseDestChainIDs parses the comma separated chain IDs in the env var name, nil if it's unset.
+func parseDestChainIDs(name string, v string) ([]int64, error) {
+	if strings.TrimSpace(v) == "" {
+		return nil, nil
+	}
+
+	chainIDs := make([]int64, 0)
+
+	for _, s := range strings.Split(v, ",") {
+		chainID, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+		if err != nil || chainID <= 0 {
+			return nil, errors.Errorf("invalid %v %q, must be comma separated chain IDs", name, v)
+		}
+
+		chainIDs = append(chainIDs, chainID)
+	}
+
+	return chainIDs, nil
 }
 
 func openDBConnection(opts relayer.DBConnectionOpts) (relayer.DB, error) {
-	dsn := ""
-	if opts.Password == "" {
-		dsn = fmt.Sprintf(
-			"%v@tcp(%v)/%v?charset=utf8mb4&parseTime=True&loc=Local",
-			opts.Name,
-			opts.Host,
-			opts.Database,
-		)
-	} else {
-		dsn = fmt.Sprintf(
-			"%v:%v@tcp(%v)/%v?charset=utf8mb4&parseTime=True&loc=Local",
-			opts.Name,
-			opts.Password,
-			opts.Host,
-			opts.Database,
-		)
+	dsn := mysqlDSN(opts.Name, opts.Password, opts.Host, opts.Database)
+
+	replicaDSN := ""
+	if opts.ReplicaHost != "" {
+		replicaDSN = mysqlDSN(opts.Name, opts.Password, opts.ReplicaHost, opts.Database)
 	}
 
-	db, err := opts.OpenFunc(dsn)
+	db, err := opts.OpenFunc(dsn, replicaDSN)
 	if err != nil {
 		return nil, err
 	}
@@ -313,25 +1188,79 @@ func openDBConnection(opts relayer.DBConnectionOpts) (relayer.DB, error) {
 		defaultConnMaxLifetime = 10 * time.Second
 	)
 
-	maxIdleConns, err := strconv.Atoi(os.Getenv("MYSQL_MAX_IDLE_CONNS"))
-	if err != nil || maxIdleConns <= 0 {
+	maxIdleConns := opts.MaxIdleConns
+	if maxIdleConns <= 0 {
 		maxIdleConns = defaultMaxIdleConns
 	}
 
-	maxOpenConns, err := strconv.Atoi(os.Getenv("MYSQL_MAX_OPEN_CONNS"))
-	if err != nil || maxOpenConns <= 0 {
+	maxOpenConns := opts.MaxOpenConns
+	if maxOpenConns <= 0 {
 		maxOpenConns = defaultMaxOpenConns
 	}
 
-	var maxLifetime time.Duration
-
-	connMaxLifetime, err := strconv.Atoi(os.Getenv("MYSQL_CONN_MAX_LIFETIME_IN_MS"))
-	if err != nil || connMaxLifetime <= 0 {
+	maxLifetime := opts.ConnMaxLifetime
+	if maxLifetime <= 0 {
 		maxLifetime = defaultConnMaxLifetime
-	} else {
-		maxLifetime = time.Duration(connMaxLifetime)
 	}
 
+	if err := configurePool(sqlDB, opts.Database, maxOpenConns, maxIdleConns, maxLifetime); err != nil {
+		return nil, err
+	}
+
+	log.Infof(
+		"db pool: maxOpenConns: %v, maxIdleConns: %v, connMaxLifetime: %v, queryTimeout: %v",
+		maxOpenConns,
+		maxIdleConns,
+		maxLifetime,
+		db.QueryTimeout(),
+	)
+
+	// the replica gets a pool of its own, sized the same as the primary's.
+	if replica := db.ReadGormDB(); replica != db.GormDB() {
+		replicaSQLDB, err := replica.DB()
+		if err != nil {
+			return nil, err
+		}
+
+		err = configurePool(replicaSQLDB, opts.Database+"_replica", maxOpenConns, maxIdleConns, maxLifetime)
+		if err != nil {
+			return nil, err
+		}
+
+		log.Infof("db read replica: %v", opts.ReplicaHost)
+	}
+
+	return db, nil
+}
+
+func mysqlDSN(name string, password string, host string, database string) string {
+	if password == "" {
+		return fmt.Sprintf(
+			"%v@tcp(%v)/%v?charset=utf8mb4&parseTime=True&loc=Local",
+			name,
+			host,
+			database,
+		)
+	}
+
+	return fmt.Sprintf(
+		"%v:%v@tcp(%v)/%v?charset=utf8mb4&parseTime=True&loc=Local",
+		name,
+		password,
+		host,
+		database,
+	)
+}
+
+// configurePool sizes sqlDB's connection pool, and exports its usage to prometheus
+// labelled with name.
+func configurePool(
+	sqlDB *sql.DB,
+	name string,
+	maxOpenConns int,
+	maxIdleConns int,
+	maxLifetime time.Duration,
+) error {
 	// SetMaxOpenConns sets the maximum number of open connections to the database.
 	sqlDB.SetMaxOpenConns(maxOpenConns)
 
@@ -341,15 +1270,27 @@ func openDBConnection(opts relayer.DBConnectionOpts) (relayer.DB, error) {
 	// SetConnMaxLifetime sets the maximum amount of time a connection may be reused.
 	sqlDB.SetConnMaxLifetime(maxLifetime)
 
-	return db, nil
+	// exposes go_sql_open_connections, go_sql_in_use_connections, go_sql_wait_count_total etc.
+	if err := prometheus.Register(collectors.NewDBStatsCollector(sqlDB, name)); err != nil {
+		var alreadyRegistered prometheus.AlreadyRegisteredError
+		if !errors.As(err, &alreadyRegistered) {
+			return errors.Wrap(err, "prometheus.Register")
+		}
+	}
+
+	return nil
 }
 
 func loadAndValidateEnv() error {
+	return loadAndValidateEnvVars(envVars)
+}
+
+func loadAndValidateEnvVars(vars []string) error {
 	_ = godotenv.Load()
 
 	missing := make([]string, 0)
 
-	for _, v := range envVars {
+	for _, v := range vars {
 		e := os.Getenv(v)
 		if e == "" {
 			missing = append(missing, v)
@@ -363,7 +1304,19 @@ func loadAndValidateEnv() error {
 	return errors.Errorf("Missing env vars: %v", missing)
 }
 
-func newHTTPServer(db relayer.DB, l1EthClient relayer.EthClient, l2EthClient relayer.EthClient) (*http.Server, error) {
+func newHTTPServer(
+	db relayer.DB,
+	l1EthClient relayer.EthClient,
+	l2EthClient relayer.EthClient,
+	messageReleasers map[int64]relayer.MessageReleaser,
+	processingPausers map[int64]relayer.ProcessingPauser,
+	messageDiagnosers map[int64]relayer.MessageDiagnoser,
+	messageReprocessors map[int64]relayer.MessageReprocessor,
+	syncProgressReporters map[int64]relayer.SyncProgressReporter,
+	blocklistRefresher relayer.BlocklistRefresher,
+	roleReporter relayer.RoleReporter,
+	runwayReporters map[int64]relayer.RunwayReporter,
+) (*http.Server, error) {
 	eventRepo, err := repo.NewEventRepository(db)
 	if err != nil {
 		return nil, err
@@ -374,6 +1327,11 @@ func newHTTPServer(db relayer.DB, l1EthClient relayer.EthClient, l2EthClient rel
 		return nil, err
 	}
 
+	bridgeStatusChangeRepo, err := repo.NewBridgeStatusChangeRepository(db)
+	if err != nil {
+		return nil, err
+	}
+
 	srv, err := http.NewServer(http.NewServerOpts{
 		EventRepo:   eventRepo,
 		Echo:        echo.New(),
@@ -381,6 +1339,22 @@ func newHTTPServer(db relayer.DB, l1EthClient relayer.EthClient, l2EthClient rel
 		L1EthClient: l1EthClient,
 		L2EthClient: l2EthClient,
 		BlockRepo:   blockRepo,
+
+		BridgeStatusChangeRepo: bridgeStatusChangeRepo,
+
+		AdminAPIKey:           os.Getenv("ADMIN_API_KEY"),
+		MessageReleasers:      messageReleasers,
+		ProcessingPausers:     processingPausers,
+		MessageDiagnosers:     messageDiagnosers,
+		MessageReprocessors:   messageReprocessors,
+		SyncProgressReporters: syncProgressReporters,
+		BlocklistRefresher:    blocklistRefresher,
+		RoleReporter:          roleReporter,
+		GzipMinLength:         envInt("HTTP_GZIP_MIN_LENGTH_IN_BYTES", http.DefaultGzipMinLength),
+		RunwayReporters:       runwayReporters,
+		StatsCacheTTL: time.Duration(
+			envInt("STATS_CACHE_TTL_IN_SECONDS", int(http.DefaultStatsCacheTTL/time.Second)),
+		) * time.Second,
 	})
 	if err != nil {
 		return nil, err
@@ -388,3 +1362,23 @@ func newHTTPServer(db relayer.DB, l1EthClient relayer.EthClient, l2EthClient rel
 
 	return srv, nil
 }
+
+// newAdminServer makes the admin gRPC server, authenticated with the same ADMIN_API_KEY
+// as the HTTP /admin routes.
+func newAdminServer(db relayer.DB, indexers map[int64]relayer.IndexerAdmin) (*admin.Server, error) {
+	eventRepo, err := repo.NewEventRepository(db)
+	if err != nil {
+		return nil, err
+	}
+
+	srv, err := admin.NewServer(admin.NewServerOpts{
+		EventRepo: eventRepo,
+		Indexers:  indexers,
+		APIKey:    os.Getenv("ADMIN_API_KEY"),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "admin.NewServer")
+	}
+
+	return srv, nil
+}
@@ -2,10 +2,13 @@ package cli
 
 import (
 	"os"
+	"runtime"
 	"strings"
 	"testing"
 
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/db"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/failover"
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
 	"github.com/stretchr/testify/assert"
 )
@@ -37,6 +40,245 @@ func Test_loadAndValidateEnvVars_missing(t *testing.T) {
 	}
 }
 
+func Test_envInt(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  int
+	}{
+		{"unset", "", 7},
+		{"invalid", "abc", 7},
+		{"notPositive", "-1", 7},
+		{"set", "42", 42},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TEST_ENV_INT", tt.value)
+
+			assert.Equal(t, tt.want, envInt("TEST_ENV_INT", 7))
+		})
+	}
+}
+
+func Test_envBool(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  bool
+	}{
+		{"unset", "", true},
+		{"invalid", "yes please", true},
+		{"false", "false", false},
+		{"true", "true", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TEST_ENV_BOOL", tt.value)
+
+			assert.Equal(t, tt.want, envBool("TEST_ENV_BOOL", true))
+		})
+	}
+}
+
+func Test_parseDestChainIDs(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []int64
+		wantErr bool
+	}{
+		{"unset", "", nil, false},
+		{"one", "5167003", []int64{5167003}, false},
+		{"several", "1, 5167003", []int64{1, 5167003}, false},
+		{"invalid", "1,abc", nil, true},
+		{"zero", "0", nil, true},
+		{"trailingComma", "1,", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDestChainIDs("L1_INDEX_DEST_CHAIN_IDS", tt.value)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_parseProcessorConcurrency(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    int
+		wantErr bool
+	}{
+		{"unset", "", runtime.GOMAXPROCS(0), false},
+		{"set", "16", 16, false},
+		{"one", "1", 1, false},
+		{"zero", "0", 0, true},
+		{"negative", "-2", 0, true},
+		{"invalid", "abc", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseProcessorConcurrency(tt.value)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_makeTxBuilder(t *testing.T) {
+	tests := []struct {
+		name             string
+		forwarderAddress string
+		wantTxBuilder    bool
+		wantErr          bool
+	}{
+		{"unset", "", false, false},
+		{"invalid", "0x1", false, true},
+		{"set", "0x1000777700000000000000000000000000000009", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("L2_FORWARDER_ADDRESS", tt.forwarderAddress)
+			t.Setenv("L2_BRIDGE_ADDRESS", dummyAddress)
+			t.Setenv("RELAYER_ECDSA_KEY", dummyEcdsaKey)
+
+			txBuilder, err := makeTxBuilder(relayer.L2, &failover.Client{})
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantTxBuilder, txBuilder != nil)
+		})
+	}
+}
+
+func Test_makePriceOracle(t *testing.T) {
+	tests := []struct {
+		name         string
+		staticPrices string
+		feeds        string
+		wantOracle   bool
+		wantErr      bool
+	}{
+		{"unset", "", "", false, false},
+		{"invalidStaticPrices", "MXC", "", false, true},
+		{"invalidFeeds", "", "MXC=0x1", false, true},
+		{"static", "MXC=0.0004", "", true, false},
+		{"feeds", "", "MXC=0x1000777700000000000000000000000000000009", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("PRICE_ORACLE_STATIC_PRICES", tt.staticPrices)
+			t.Setenv("PRICE_ORACLE_FEEDS", tt.feeds)
+
+			oracle, err := makePriceOracle(&failover.Client{})
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantOracle, oracle != nil)
+		})
+	}
+}
+
+func Test_makeKafkaProducer(t *testing.T) {
+	tests := []struct {
+		name         string
+		brokers      string
+		eventsTopic  string
+		wantProducer bool
+		wantErr      bool
+	}{
+		{"unset", "", "relayer-events", false, false},
+		{"noTopics", "localhost:9092", "", false, true},
+		{"set", "localhost:9092", "relayer-events", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("KAFKA_BROKERS", tt.brokers)
+			t.Setenv("KAFKA_EVENTS_TOPIC", tt.eventsTopic)
+			t.Setenv("KAFKA_STATUS_CHANGES_TOPIC", "")
+
+			producer, err := makeKafkaProducer()
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantProducer, producer != nil)
+		})
+	}
+}
+
+func Test_makePrivateTxRelay(t *testing.T) {
+	tests := []struct {
+		name      string
+		url       string
+		wantRelay bool
+	}{
+		{"unset", "", false},
+		{"set", "http://localhost:8545", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("L2_PRIVATE_TX_RELAY_URL", tt.url)
+			t.Setenv("RELAYER_ECDSA_KEY", dummyEcdsaKey)
+
+			relay, err := makePrivateTxRelay(relayer.L2, &failover.Client{})
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantRelay, relay != nil)
+		})
+	}
+}
+
+func Test_makeFinalityOracle(t *testing.T) {
+	tests := []struct {
+		name               string
+		url                string
+		proveFinalizedOnly string
+		wantOracle         bool
+		wantErr            bool
+	}{
+		{"unset", "", "true", false, false},
+		{"set", "http://localhost:5052", "true", true, false},
+		{"setWithoutProveFinalizedOnly", "http://localhost:5052", "", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("L1_BEACON_URL", tt.url)
+			t.Setenv("PROVE_FINALIZED_ONLY", tt.proveFinalizedOnly)
+
+			oracle, err := makeFinalityOracle(relayer.L1)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantOracle, oracle != nil)
+		})
+	}
+}
+
+func Test_makeElector(t *testing.T) {
+	tests := []struct {
+		name           string
+		leaderElection string
+		db             relayer.DB
+		wantElector    bool
+		wantErr        bool
+	}{
+		{"unset", "", nil, false, false},
+		{"set", "true", &db.DB{}, true, false},
+		{"setWithoutDB", "true", nil, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LEADER_ELECTION", tt.leaderElection)
+
+			elector, err := makeElector(tt.db, nil)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantElector, elector != nil)
+		})
+	}
+}
+
 func Test_openDBConnection(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -50,7 +292,7 @@ func Test_openDBConnection(t *testing.T) {
 				Password: "password",
 				Host:     "host",
 				Database: "database",
-				OpenFunc: func(dsn string) (relayer.DB, error) {
+				OpenFunc: func(dsn string, replicaDSN string) (relayer.DB, error) {
 					db, cancel, err := testMysql(t)
 					if err != nil {
 						return nil, err
@@ -180,7 +422,13 @@ func Test_makeIndexers(t *testing.T) {
 				defer reset()
 			}
 
-			indexers, cancel, err := makeIndexers(tt.layer, tt.dbFunc(t), relayer.ProfitableOnly(true))
+			indexers, cancel, err := makeIndexers(
+				tt.layer,
+				tt.dbFunc(t),
+				relayer.ProfitableOnly(true),
+				relayer.OrderedDelivery(false),
+				nil,
+			)
 			if cancel != nil {
 				defer cancel()
 			}
@@ -203,12 +451,50 @@ func Test_newHTTPServer(t *testing.T) {
 
 	defer cancel()
 
-	srv, err := newHTTPServer(db, &mock.EthClient{}, &mock.EthClient{})
+	srv, err := newHTTPServer(db, &mock.EthClient{}, &mock.EthClient{}, nil, nil, nil, nil, nil, nil, nil, nil)
 	assert.Nil(t, err)
 	assert.NotNil(t, srv)
 }
 
 func Test_newHTTPServer_nilDB(t *testing.T) {
-	_, err := newHTTPServer(nil, &mock.EthClient{}, &mock.EthClient{})
+	_, err := newHTTPServer(nil, &mock.EthClient{}, &mock.EthClient{}, nil, nil, nil, nil, nil, nil, nil, nil)
 	assert.NotNil(t, err)
 }
+
+func Test_newAdminServer(t *testing.T) {
+	db, cancel, err := testMysql(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer cancel()
+
+	t.Setenv("ADMIN_API_KEY", "")
+
+	_, err = newAdminServer(db, nil)
+	assert.NotNil(t, err)
+
+	t.Setenv("ADMIN_API_KEY", "key")
+
+	srv, err := newAdminServer(db, nil)
+	assert.Nil(t, err)
+	assert.NotNil(t, srv)
+}
+
+func Test_newAdminServer_nilDB(t *testing.T) {
+	_, err := newAdminServer(nil, nil)
+	assert.NotNil(t, err)
+}
+
+func Test_mysqlDSN(t *testing.T) {
+	assert.Equal(
+		t,
+		"user:pass@tcp(replica:3306)/relayer?charset=utf8mb4&parseTime=True&loc=Local",
+		mysqlDSN("user", "pass", "replica:3306", "relayer"),
+	)
+	assert.Equal(
+		t,
+		"user@tcp(replica:3306)/relayer?charset=utf8mb4&parseTime=True&loc=Local",
+		mysqlDSN("user", "", "replica:3306", "relayer"),
+	)
+}
@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"io"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/export"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/repo"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	// exportEnvVars are the env vars needed to connect to the database, which export and migrate use
+	exportEnvVars = []string{
+		"MYSQL_USER",
+		"MYSQL_DATABASE",
+		"MYSQL_HOST",
+	}
+)
+
+// Export dumps indexed events to a file for analytics, i.e.
+// `relayer export --format csv --from 2023-01-01T00:00:00Z --to 2023-02-01T00:00:00Z --out events.csv`
+func Export(args []string) {
+	opts, out, err := parseExportFlags(args)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := loadAndValidateEnvVars(exportEnvVars); err != nil {
+		log.Fatal(err)
+	}
+
+	db, err := openMySQL()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer sqlDB.Close()
+
+	eventRepository, err := repo.NewEventRepository(db)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var w io.Writer = os.Stdout
+
+	if out != "" {
+		f, err := os.Create(out)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		defer f.Close()
+
+		w = f
+	}
+
+	written, err := export.Export(context.Background(), eventRepository, w, opts)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Infof("exported %v events", written)
+}
+
+func parseExportFlags(args []string) (export.Opts, string, error) {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+
+	format := fs.String("format", string(relayer.CSVExportFormat), "output format, csv or json")
+	from := fs.String("from", "", "only export events indexed at or after this RFC3339 time")
+	to := fs.String("to", "", "only export events indexed before this RFC3339 time")
+	out := fs.String("out", "", "file to write to, defaults to stdout")
+	chainID := fs.Int64("chain-id", 0, "only export events from this source chain")
+	status := fs.String("status", "", "only export events with this status, i.e. done")
+	batchSize := fs.Int("batch-size", 0, "number of events to read from the database at a time")
+
+	if err := fs.Parse(args); err != nil {
+		return export.Opts{}, "", err
+	}
+
+	opts := export.Opts{
+		Format:    relayer.ExportFormat(*format),
+		BatchSize: *batchSize,
+	}
+
+	if !relayer.IsInSlice(opts.Format, relayer.ExportFormats) {
+		return export.Opts{}, "", relayer.ErrInvalidExportFormat
+	}
+
+	var err error
+
+	if *from != "" {
+		if opts.From, err = time.Parse(time.RFC3339, *from); err != nil {
+			return export.Opts{}, "", errors.Wrap(err, "time.Parse(from)")
+		}
+	}
+
+	if *to != "" {
+		if opts.To, err = time.Parse(time.RFC3339, *to); err != nil {
+			return export.Opts{}, "", errors.Wrap(err, "time.Parse(to)")
+		}
+	}
+
+	if *chainID != 0 {
+		opts.ChainID = big.NewInt(*chainID)
+	}
+
+	if *status != "" {
+		s, err := relayer.ParseEventStatus(*status)
+		if err != nil {
+			return export.Opts{}, "", err
+		}
+
+		opts.Status = &s
+	}
+
+	return opts, *out, nil
+}
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/export"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseExportFlags(t *testing.T) {
+	done := relayer.EventStatusDone
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantOpts export.Opts
+		wantOut  string
+		wantErr  bool
+	}{
+		{
+			"defaults",
+			[]string{},
+			export.Opts{Format: relayer.CSVExportFormat},
+			"",
+			false,
+		},
+		{
+			"allFlags",
+			[]string{
+				"--format", "json",
+				"--from", "2023-01-01T00:00:00Z",
+				"--to", "2023-02-01T00:00:00Z",
+				"--out", "events.json",
+				"--chain-id", "5",
+				"--status", "done",
+				"--batch-size", "10",
+			},
+			export.Opts{
+				Format:    relayer.JSONExportFormat,
+				From:      time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+				To:        time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC),
+				ChainID:   big.NewInt(5),
+				Status:    &done,
+				BatchSize: 10,
+			},
+			"events.json",
+			false,
+		},
+		{
+			"invalidFormat",
+			[]string{"--format", "xml"},
+			export.Opts{},
+			"",
+			true,
+		},
+		{
+			"invalidFrom",
+			[]string{"--from", "yesterday"},
+			export.Opts{},
+			"",
+			true,
+		},
+		{
+			"invalidStatus",
+			[]string{"--status", "pending"},
+			export.Opts{},
+			"",
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, out, err := parseExportFlags(tt.args)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantOpts, opts)
+			assert.Equal(t, tt.wantOut, out)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+type codeAtClient interface {
+	CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error)
+}
+
+// makeGasLimitFloors parses <LAYER>_GAS_LIMIT_FLOORS, the least gas processMessage transactions sent
+// on layer to each recipient contract are sent with, and logs them. Like gas oracles, they're named
+// for the chain the transactions are sent on. A recipient without code on it is warned about, as
+// it's more likely a typo than a contract yet to be deployed.
+func makeGasLimitFloors(
+	ctx context.Context,
+	layer relayer.Layer,
+	client codeAtClient,
+	getenv func(string) string,
+) (map[common.Address]uint64, error) {
+	name := strings.ToUpper(string(layer)) + "_GAS_LIMIT_FLOORS"
+
+	floors, err := parseGasLimitFloors(getenv(name))
+	if err != nil {
+		return nil, errors.Wrap(err, name)
+	}
+
+	if len(floors) == 0 {
+		return nil, nil
+	}
+
+	recipients := make([]common.Address, 0, len(floors))
+	for recipient := range floors {
+		recipients = append(recipients, recipient)
+	}
+
+	sort.Slice(recipients, func(i, j int) bool {
+		return recipients[i].Hex() < recipients[j].Hex()
+	})
+
+	effective := make([]string, 0, len(recipients))
+
+	for _, recipient := range recipients {
+		code, err := client.CodeAt(ctx, recipient, nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "client.CodeAt(%v)", recipient.Hex())
+		}
+
+		if len(code) == 0 {
+			log.Warnf("%v recipient %v has no code on %v, check the address", name, recipient.Hex(), layer)
+		}
+
+		effective = append(effective, recipient.Hex()+"="+strconv.FormatUint(floors[recipient], 10))
+	}
+
+	log.Infof("%v gas limit floors: %v", layer, strings.Join(effective, ","))
+
+	return floors, nil
+}
+
+// parseGasLimitFloors parses comma separated "recipient:gas", i.e. "0xabc...:800000,0xdef...:1200000".
+// Empty is no floors.
+func parseGasLimitFloors(s string) (map[common.Address]uint64, error) {
+	floors := make(map[common.Address]uint64)
+
+	if strings.TrimSpace(s) == "" {
+		return floors, nil
+	}
+
+	for _, entry := range strings.Split(s, ",") {
+		addr, gasStr, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok || !common.IsHexAddress(addr) || common.HexToAddress(addr) == relayer.ZeroAddress {
+			return nil, errors.Errorf("entry %q is not a recipient address and gas limit", entry)
+		}
+
+		recipient := common.HexToAddress(addr)
+		if _, ok := floors[recipient]; ok {
+			return nil, errors.Errorf("recipient %v is configured more than once", recipient.Hex())
+		}
+
+		gas, err := strconv.ParseUint(gasStr, 10, 64)
+		if err != nil || gas == 0 {
+			return nil, errors.Errorf("recipient %v has invalid gas limit %q", recipient.Hex(), gasStr)
+		}
+
+		floors[recipient] = gas
+	}
+
+	return floors, nil
+}
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseGasLimitFloors(t *testing.T) {
+	a := common.HexToAddress("0x1000777700000000000000000000000000000001")
+	b := common.HexToAddress("0x1000777700000000000000000000000000000002")
+
+	tests := []struct {
+		name    string
+		s       string
+		want    map[common.Address]uint64
+		wantErr bool
+	}{
+		{"empty", "", map[common.Address]uint64{}, false},
+		{
+			"floors",
+			a.Hex() + ":800000, " + b.Hex() + ":1200000",
+			map[common.Address]uint64{a: 800000, b: 1200000},
+			false,
+		},
+		{"missingGas", a.Hex(), nil, true},
+		{"invalidAddress", "0x1:800000", nil, true},
+		{"zeroAddress", relayer.ZeroAddress.Hex() + ":800000", nil, true},
+		{"invalidGas", a.Hex() + ":lots", nil, true},
+		{"zeroGas", a.Hex() + ":0", nil, true},
+		{"duplicate", a.Hex() + ":1," + a.Hex() + ":2", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseGasLimitFloors(tt.s)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_makeGasLimitFloors(t *testing.T) {
+	a := common.HexToAddress("0x1000777700000000000000000000000000000001")
+
+	getenv := func(env map[string]string) func(string) string {
+		return func(name string) string {
+			return env[name]
+		}
+	}
+
+	floors, err := makeGasLimitFloors(
+		context.Background(),
+		relayer.L2,
+		&mock.EthClient{},
+		getenv(map[string]string{"L2_GAS_LIMIT_FLOORS": a.Hex() + ":800000"}),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, map[common.Address]uint64{a: 800000}, floors)
+
+	// only the layer's own floors
+	floors, err = makeGasLimitFloors(
+		context.Background(),
+		relayer.L1,
+		&mock.EthClient{},
+		getenv(map[string]string{"L2_GAS_LIMIT_FLOORS": a.Hex() + ":800000"}),
+	)
+	assert.Nil(t, err)
+	assert.Nil(t, floors)
+
+	_, err = makeGasLimitFloors(
+		context.Background(),
+		relayer.L2,
+		&mock.EthClient{},
+		getenv(map[string]string{"L2_GAS_LIMIT_FLOORS": "0x1:800000"}),
+	)
+	assert.ErrorContains(t, err, "L2_GAS_LIMIT_FLOORS")
+
+	// a recipient without code is only warned about
+	_, err = makeGasLimitFloors(
+		context.Background(),
+		relayer.L2,
+		noCodeClient{},
+		getenv(map[string]string{"L2_GAS_LIMIT_FLOORS": a.Hex() + ":800000"}),
+	)
+	assert.Nil(t, err)
+}
+
+type noCodeClient struct{}
+
+func (noCodeClient) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{}, nil
+}
@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/encoding"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// MessageHash prints the hash the bridge computes for a message given its fields, i.e.
+// `relayer message-hash --id 1 --src-chain-id 5 --dest-chain-id 167001 --owner 0x... ...`, or
+// `relayer message-hash --json '{"Id": 1, ...}'`
+func MessageHash(args []string) {
+	message, err := parseMessageHashFlags(args)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	hash, err := encoding.HashMessage(message)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Fprintln(os.Stdout, hash.Hex())
+}
+
+// parseMessageHashFlags parses the message to hash, from --json, a message as the relayer saves
+// and serves it, or from a flag per field, whose numbers default to 0.
+func parseMessageHashFlags(args []string) (bridge.IBridgeMessage, error) {
+	fs := flag.NewFlagSet("message-hash", flag.ContinueOnError)
+
+	jsonMessage := fs.String("json", "", "the message as json, i.e. an event's Message, instead of the other flags")
+
+	uints := map[string]*string{}
+	for _, name := range []string{
+		"id",
+		"src-chain-id",
+		"dest-chain-id",
+		"deposit-value",
+		"call-value",
+		"processing-fee",
+		"gas-limit",
+	} {
+		uints[name] = fs.String(name, "0", "the message's "+name+", in decimal or 0x prefixed hex")
+	}
+
+	addresses := map[string]*string{}
+	for _, name := range []string{"sender", "owner", "to", "refund-address"} {
+		addresses[name] = fs.String(name, common.Address{}.Hex(), "the message's "+name)
+	}
+
+	data := fs.String("data", "0x", "the message's calldata, hex encoded")
+	memo := fs.String("memo", "", "the message's memo")
+
+	if err := fs.Parse(args); err != nil {
+		return bridge.IBridgeMessage{}, err
+	}
+
+	if *jsonMessage != "" {
+		if fs.NFlag() > 1 {
+			return bridge.IBridgeMessage{}, errors.New("--json can't be combined with the field flags")
+		}
+
+		var message bridge.IBridgeMessage
+		if err := json.Unmarshal([]byte(*jsonMessage), &message); err != nil {
+			return bridge.IBridgeMessage{}, errors.Wrap(err, "json.Unmarshal")
+		}
+
+		return message, nil
+	}
+
+	parsed := map[string]*big.Int{}
+
+	for name, v := range uints {
+		n, ok := new(big.Int).SetString(*v, 0)
+		if !ok || n.Sign() < 0 {
+			return bridge.IBridgeMessage{}, errors.Errorf("invalid --%v %v", name, *v)
+		}
+
+		parsed[name] = n
+	}
+
+	for name, v := range addresses {
+		if !common.IsHexAddress(*v) {
+			return bridge.IBridgeMessage{}, errors.Errorf("invalid --%v address %v", name, *v)
+		}
+	}
+
+	dataBytes, err := hexutil.Decode(*data)
+	if err != nil {
+		return bridge.IBridgeMessage{}, errors.Errorf("--data must be 0x prefixed hex, got %q", *data)
+	}
+
+	return bridge.IBridgeMessage{
+		Id:            parsed["id"],
+		Sender:        common.HexToAddress(*addresses["sender"]),
+		SrcChainId:    parsed["src-chain-id"],
+		DestChainId:   parsed["dest-chain-id"],
+		Owner:         common.HexToAddress(*addresses["owner"]),
+		To:            common.HexToAddress(*addresses["to"]),
+		RefundAddress: common.HexToAddress(*addresses["refund-address"]),
+		DepositValue:  parsed["deposit-value"],
+		CallValue:     parsed["call-value"],
+		ProcessingFee: parsed["processing-fee"],
+		GasLimit:      parsed["gas-limit"],
+		Data:          dataBytes,
+		Memo:          *memo,
+	}, nil
+}
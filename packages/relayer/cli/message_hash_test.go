@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseMessageHashFlags(t *testing.T) {
+	want := bridge.IBridgeMessage{
+		Id:            big.NewInt(1),
+		Sender:        common.HexToAddress("0x1000777700000000000000000000000000000001"),
+		SrcChainId:    big.NewInt(5),
+		DestChainId:   big.NewInt(167001),
+		Owner:         common.HexToAddress("0x1000777700000000000000000000000000000002"),
+		To:            common.HexToAddress("0x1000777700000000000000000000000000000003"),
+		RefundAddress: common.Address{},
+		DepositValue:  big.NewInt(256),
+		CallValue:     big.NewInt(0),
+		ProcessingFee: big.NewInt(7),
+		GasLimit:      big.NewInt(140000),
+		Data:          []byte{0xde, 0xad},
+		Memo:          "memo",
+	}
+
+	wantJSON, err := json.Marshal(want)
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    bridge.IBridgeMessage
+		wantErr bool
+	}{
+		{
+			"flags",
+			[]string{
+				"--id", "1",
+				"--sender", want.Sender.Hex(),
+				"--src-chain-id", "5",
+				"--dest-chain-id", "167001",
+				"--owner", want.Owner.Hex(),
+				"--to", want.To.Hex(),
+				"--deposit-value", "0x100",
+				"--processing-fee", "7",
+				"--gas-limit", "140000",
+				"--data", "0xdead",
+				"--memo", "memo",
+			},
+			want,
+			false,
+		},
+		{"json", []string{"--json", string(wantJSON)}, want, false},
+		{"jsonAndFlags", []string{"--json", string(wantJSON), "--id", "2"}, bridge.IBridgeMessage{}, true},
+		{"invalidJSON", []string{"--json", "{"}, bridge.IBridgeMessage{}, true},
+		{"invalidNumber", []string{"--id", "one"}, bridge.IBridgeMessage{}, true},
+		{"negativeNumber", []string{"--gas-limit", "-1"}, bridge.IBridgeMessage{}, true},
+		{"invalidAddress", []string{"--owner", "0x1"}, bridge.IBridgeMessage{}, true},
+		{"invalidData", []string{"--data", "dead"}, bridge.IBridgeMessage{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseMessageHashFlags(tt.args)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"context"
+	"flag"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/migrations"
+	log "github.com/sirupsen/logrus"
+)
+
+// Migrate applies the database migrations the relayer would at startup, then exits, or with
+// -down rolls back the latest one, i.e. `relayer migrate` or `relayer migrate -down`.
+func Migrate(args []string) {
+	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+
+	down := fs.Bool("down", false, "roll back the latest applied migration instead")
+
+	_ = fs.Parse(args)
+
+	if err := loadAndValidateEnvVars(exportEnvVars); err != nil {
+		log.Fatal(err)
+	}
+
+	db, err := openMySQL()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer sqlDB.Close()
+
+	migrate := migrations.Up
+	if *down {
+		migrate = migrations.Down
+	}
+
+	if err := migrate(context.Background(), sqlDB); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/indexer"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// ReindexBlock re-scans one block of a source chain and fixes the events indexed from it, printing
+// the ones it removed and added, i.e. `relayer reindex-block --chain 5 --block 100`. It doesn't touch
+// the processing checkpoint, so it can run while the relayer does.
+func ReindexBlock(args []string) {
+	chainID, blockNumber, err := parseReindexBlockFlags(args)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := loadAndValidateEnv(); err != nil {
+		log.Fatal(err)
+	}
+
+	if _, err := loadRelayerKey(); err != nil {
+		log.Fatal(err)
+	}
+
+	db, err := openMySQL()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer sqlDB.Close()
+
+	blocklist, _, err := makeBlocklist(os.Getenv)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	indexers, closeFunc, err := makeIndexers(relayer.Both, db, false, false, blocklist)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer closeFunc()
+
+	ctx := context.Background()
+
+	svc, err := indexerForChain(ctx, indexers, chainID)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	result, err := svc.ReindexBlock(ctx, big.NewInt(chainID), blockNumber)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	printReindexBlockResult(os.Stdout, result)
+}
+
+func parseReindexBlockFlags(args []string) (int64, uint64, error) {
+	fs := flag.NewFlagSet("reindex-block", flag.ContinueOnError)
+
+	chainID := fs.Int64("chain", 0, "chain ID of the source chain the block is from")
+	blockNumber := fs.Uint64("block", 0, "number of the block to reindex")
+
+	if err := fs.Parse(args); err != nil {
+		return 0, 0, err
+	}
+
+	if *chainID <= 0 {
+		return 0, 0, errors.New("--chain is required")
+	}
+
+	if *blockNumber == 0 {
+		return 0, 0, relayer.ErrInvalidBlockNumber
+	}
+
+	return *chainID, *blockNumber, nil
+}
+
+// indexerForChain finds the indexer for the source chain with chainID
+func indexerForChain(ctx context.Context, indexers []*indexer.Service, chainID int64) (*indexer.Service, error) {
+	for _, i := range indexers {
+		id, err := i.ChainID(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if id == chainID {
+			return i, nil
+		}
+	}
+
+	return nil, errors.Errorf("no indexer for chain %v, it should be L1's or L2's chain ID", chainID)
+}
+
+// printReindexBlockResult writes the events reindexing removed, then the ones it added, diff style
+func printReindexBlockResult(w io.Writer, result *indexer.ReindexBlockResult) {
+	for _, e := range result.Removed {
+		fmt.Fprintf(w, "- %v\n", formatReindexedEvent(e))
+	}
+
+	for _, e := range result.Added {
+		fmt.Fprintf(w, "+ %v\n", formatReindexedEvent(e))
+	}
+
+	fmt.Fprintf(w, "%v removed, %v added, %v unchanged\n", len(result.Removed), len(result.Added), result.Unchanged)
+}
+
+func formatReindexedEvent(e indexer.ReindexedEvent) string {
+	return fmt.Sprintf(
+		"%v msgHash: %v txHash: %v log: %v status: %v",
+		e.Name,
+		e.MsgHash,
+		e.TxHash.Hex(),
+		e.LogIndex,
+		e.Status.String(),
+	)
+}
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/indexer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseReindexBlockFlags(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		wantChain int64
+		wantBlock uint64
+		wantErr   bool
+	}{
+		{"valid", []string{"--chain", "5", "--block", "100"}, 5, 100, false},
+		{"noChain", []string{"--block", "100"}, 0, 0, true},
+		{"noBlock", []string{"--chain", "5"}, 0, 0, true},
+		{"invalidBlock", []string{"--chain", "5", "--block", "-1"}, 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chainID, blockNumber, err := parseReindexBlockFlags(tt.args)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantChain, chainID)
+			assert.Equal(t, tt.wantBlock, blockNumber)
+		})
+	}
+}
+
+func Test_printReindexBlockResult(t *testing.T) {
+	var buf bytes.Buffer
+
+	txHash := common.HexToHash("0x1")
+
+	printReindexBlockResult(&buf, &indexer.ReindexBlockResult{
+		Removed: []indexer.ReindexedEvent{
+			{
+				Name:    relayer.EventNameMessageSent,
+				MsgHash: "0x2",
+				TxHash:  txHash,
+				Status:  relayer.EventStatusNew,
+			},
+		},
+		Added: []indexer.ReindexedEvent{
+			{
+				Name:    relayer.EventNameMessageSent,
+				MsgHash: "0x3",
+				TxHash:  txHash,
+				Status:  relayer.EventStatusDone,
+			},
+		},
+		Unchanged: 2,
+	})
+
+	assert.Equal(t,
+		"- MessageSent msgHash: 0x2 txHash: "+txHash.Hex()+" log: 0 status: new\n"+
+			"+ MessageSent msgHash: 0x3 txHash: "+txHash.Hex()+" log: 0 status: done\n"+
+			"1 removed, 1 added, 2 unchanged\n",
+		buf.String(),
+	)
+}
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/message"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// loadRelayerKey resolves the relayer's private key from the file at RELAYER_ECDSA_KEY_FILE, or
+// else RELAYER_ECDSA_KEY, and leaves it in RELAYER_ECDSA_KEY, where everything that signs reads it
+// from. It returns the key's address, which is the only part of it ever logged.
+func loadRelayerKey() (common.Address, error) {
+	path := os.Getenv("RELAYER_ECDSA_KEY_FILE")
+
+	if path != "" && os.Getenv("RELAYER_ECDSA_KEY") != "" {
+		return common.Address{}, errors.New("only one of RELAYER_ECDSA_KEY and RELAYER_ECDSA_KEY_FILE can be set")
+	}
+
+	if path != "" {
+		key, err := readKeyFile(path)
+		if err != nil {
+			return common.Address{}, err
+		}
+
+		if err := os.Setenv("RELAYER_ECDSA_KEY", key); err != nil {
+			return common.Address{}, errors.Wrap(err, "os.Setenv")
+		}
+	}
+
+	key := os.Getenv("RELAYER_ECDSA_KEY")
+	if key == "" {
+		return common.Address{}, errors.New("Missing env vars: one of RELAYER_ECDSA_KEY or RELAYER_ECDSA_KEY_FILE")
+	}
+
+	// the error could quote the key, so it isn't wrapped
+	privateKey, err := crypto.HexToECDSA(key)
+	if err != nil {
+		return common.Address{}, errors.New("relayer key is not a hex encoded secp256k1 private key")
+	}
+
+	return crypto.PubkeyToAddress(privateKey.PublicKey), nil
+}
+
+// extraRelayerKeys parses RELAYER_ECDSA_KEYS, the comma separated hex encoded private keys messages
+// are sent from besides RELAYER_ECDSA_KEY, each optionally followed by :weight. Like
+// RELAYER_ECDSA_KEY, none of them is quoted in an error.
+func extraRelayerKeys(getenv func(string) string) ([]message.RelayerKey, error) {
+	keys := make([]message.RelayerKey, 0)
+
+	if getenv("RELAYER_ECDSA_KEYS") == "" {
+		return keys, nil
+	}
+
+	seen := make(map[common.Address]bool)
+
+	if primary, err := crypto.HexToECDSA(getenv("RELAYER_ECDSA_KEY")); err == nil {
+		seen[crypto.PubkeyToAddress(primary.PublicKey)] = true
+	}
+
+	for i, entry := range strings.Split(getenv("RELAYER_ECDSA_KEYS"), ",") {
+		hexKey, weightStr, hasWeight := strings.Cut(strings.TrimSpace(entry), ":")
+
+		privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(hexKey, "0x"))
+		if err != nil {
+			return nil, errors.Errorf("RELAYER_ECDSA_KEYS key %v is not a hex encoded secp256k1 private key", i+1)
+		}
+
+		addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+		if seen[addr] {
+			return nil, errors.Errorf("RELAYER_ECDSA_KEYS key %v, %v, is configured more than once", i+1, addr.Hex())
+		}
+
+		seen[addr] = true
+
+		weight := 1
+
+		if hasWeight {
+			weight, err = strconv.Atoi(weightStr)
+			if err != nil || weight <= 0 {
+				return nil, errors.Errorf("RELAYER_ECDSA_KEYS key %v, %v, has invalid weight %q", i+1, addr.Hex(), weightStr)
+			}
+		}
+
+		keys = append(keys, message.RelayerKey{Key: privateKey, Weight: weight})
+	}
+
+	return keys, nil
+}
+
+// readKeyFile reads a hex encoded private key from path, warning if anyone but its owner can
+// read it.
+func readKeyFile(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", errors.Wrap(err, "os.Stat")
+	}
+
+	if info.Mode().Perm()&0o077 != 0 {
+		log.Warnf(
+			"relayer key file %v has permissions %v, readable beyond its owner, restrict it with chmod 600",
+			path,
+			info.Mode().Perm(),
+		)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrap(err, "os.ReadFile")
+	}
+
+	return strings.TrimPrefix(strings.TrimSpace(string(b)), "0x"), nil
+}
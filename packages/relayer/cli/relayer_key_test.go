@@ -0,0 +1,183 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_loadRelayerKey(t *testing.T) {
+	dir := t.TempDir()
+
+	keyFile := filepath.Join(dir, "key")
+	assert.Nil(t, os.WriteFile(keyFile, []byte("0x"+dummyEcdsaKey+"\n"), 0600))
+
+	tests := []struct {
+		name     string
+		key      string
+		keyFile  string
+		wantAddr string
+		wantErr  bool
+	}{
+		{
+			"env",
+			dummyEcdsaKey,
+			"",
+			dummyAddress,
+			false,
+		},
+		{
+			"file",
+			"",
+			keyFile,
+			dummyAddress,
+			false,
+		},
+		{
+			"both",
+			dummyEcdsaKey,
+			keyFile,
+			"",
+			true,
+		},
+		{
+			"neither",
+			"",
+			"",
+			"",
+			true,
+		},
+		{
+			"missingFile",
+			"",
+			filepath.Join(dir, "missing"),
+			"",
+			true,
+		},
+		{
+			"invalidKey",
+			"nope",
+			"",
+			"",
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("RELAYER_ECDSA_KEY", tt.key)
+			t.Setenv("RELAYER_ECDSA_KEY_FILE", tt.keyFile)
+
+			addr, err := loadRelayerKey()
+			assert.Equal(t, tt.wantErr, err != nil)
+
+			if !tt.wantErr {
+				assert.Equal(t, tt.wantAddr, addr.Hex())
+				assert.Equal(t, dummyEcdsaKey, os.Getenv("RELAYER_ECDSA_KEY"))
+			}
+		})
+	}
+}
+
+func Test_readKeyFile_groupReadable(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "key")
+	assert.Nil(t, os.WriteFile(keyFile, []byte(dummyEcdsaKey), 0644))
+
+	key, err := readKeyFile(keyFile)
+	assert.Nil(t, err)
+	assert.Equal(t, dummyEcdsaKey, key)
+}
+
+func Test_extraRelayerKeys(t *testing.T) {
+	otherKey := "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+	otherAddress := "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+
+	tests := []struct {
+		name        string
+		keys        string
+		wantAddrs   []string
+		wantWeights []int
+		wantErr     string
+	}{
+		{
+			"unset",
+			"",
+			[]string{},
+			[]int{},
+			"",
+		},
+		{
+			"weighted",
+			"0x" + otherKey + ":3",
+			[]string{otherAddress},
+			[]int{3},
+			"",
+		},
+		{
+			"unweighted",
+			" " + otherKey + " ",
+			[]string{otherAddress},
+			[]int{1},
+			"",
+		},
+		{
+			"sameAsRelayerKey",
+			dummyEcdsaKey,
+			nil,
+			nil,
+			"key 1, " + dummyAddress + ", is configured more than once",
+		},
+		{
+			"duplicate",
+			otherKey + "," + otherKey + ":2",
+			nil,
+			nil,
+			"key 2, " + otherAddress + ", is configured more than once",
+		},
+		{
+			"invalidWeight",
+			otherKey + ":0",
+			nil,
+			nil,
+			"has invalid weight",
+		},
+		{
+			"invalidKey",
+			"nope",
+			nil,
+			nil,
+			"key 1 is not a hex encoded secp256k1 private key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := map[string]string{"RELAYER_ECDSA_KEY": dummyEcdsaKey, "RELAYER_ECDSA_KEYS": tt.keys}
+
+			keys, err := extraRelayerKeys(func(k string) string { return env[k] })
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				assert.False(t, strings.Contains(err.Error(), otherKey))
+
+				return
+			}
+
+			assert.Nil(t, err)
+
+			addrs := make([]string, 0)
+			weights := make([]int, 0)
+
+			for _, k := range keys {
+				addrs = append(addrs, crypto.PubkeyToAddress(k.Key.PublicKey).Hex())
+				weights = append(weights, k.Weight)
+			}
+
+			assert.Equal(t, tt.wantAddrs, addrs)
+			assert.Equal(t, tt.wantWeights, weights)
+		})
+	}
+}
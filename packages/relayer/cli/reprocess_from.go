@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strconv"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/indexer"
+	log "github.com/sirupsen/logrus"
+)
+
+// ReprocessFrom processes the new messages already indexed from each layer's source blocks at or
+// after fromBlock once more, prints what it did with each, then returns, i.e.
+// `relayer -layers l1 -reprocess-from 100`. It doesn't index the blocks again or move the
+// processing checkpoint, so the next run carries on from where the last one stopped.
+func ReprocessFrom(layer relayer.Layer, fromBlock string, profitableOnly relayer.ProfitableOnly) {
+	from, err := parseReprocessFrom(fromBlock)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := loadAndValidateEnv(); err != nil {
+		log.Fatal(err)
+	}
+
+	if _, err := loadRelayerKey(); err != nil {
+		log.Fatal(err)
+	}
+
+	db, err := openMySQL()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer sqlDB.Close()
+
+	blocklist, _, err := makeBlocklist(os.Getenv)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	indexers, closeFunc, err := makeIndexers(layer, db, profitableOnly, false, blocklist)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer closeFunc()
+
+	ctx := context.Background()
+
+	for _, svc := range indexers {
+		chainID, err := svc.ChainID(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		result, err := svc.ReprocessFrom(ctx, big.NewInt(chainID), from)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		printReprocessFromResult(os.Stdout, chainID, result)
+	}
+}
+
+func parseReprocessFrom(fromBlock string) (uint64, error) {
+	from, err := strconv.ParseUint(fromBlock, 10, 64)
+	if err != nil {
+		return 0, relayer.ErrInvalidBlockNumber
+	}
+
+	return from, nil
+}
+
+// printReprocessFromResult writes the messages reprocessing processed, skipped and failed for chainID
+func printReprocessFromResult(w io.Writer, chainID int64, result *indexer.ReprocessFromResult) {
+	fmt.Fprintf(w, "chain %v:\n", chainID)
+
+	for _, m := range result.Processed {
+		fmt.Fprintf(w, "processed %v\n", formatReprocessedMessage(m))
+	}
+
+	for _, m := range result.Skipped {
+		fmt.Fprintf(w, "skipped %v: %v\n", formatReprocessedMessage(m), m.Error)
+	}
+
+	for _, m := range result.Failed {
+		fmt.Fprintf(w, "failed %v: %v\n", formatReprocessedMessage(m), m.Error)
+	}
+
+	fmt.Fprintf(
+		w,
+		"%v processed, %v skipped, %v failed\n",
+		len(result.Processed),
+		len(result.Skipped),
+		len(result.Failed),
+	)
+}
+
+func formatReprocessedMessage(m indexer.ReprocessedMessage) string {
+	return fmt.Sprintf("msgHash: %v block: %v status: %v", m.MsgHash, m.BlockNumber, m.Status.String())
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/indexer"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseReprocessFrom(t *testing.T) {
+	tests := []struct {
+		name      string
+		fromBlock string
+		want      uint64
+		wantErr   error
+	}{
+		{"valid", "100", 100, nil},
+		{"genesis", "0", 0, nil},
+		{"negative", "-1", 0, relayer.ErrInvalidBlockNumber},
+		{"notANumber", "latest", 0, relayer.ErrInvalidBlockNumber},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, err := parseReprocessFrom(tt.fromBlock)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, from)
+		})
+	}
+}
+
+func Test_printReprocessFromResult(t *testing.T) {
+	var buf bytes.Buffer
+
+	printReprocessFromResult(&buf, 5, &indexer.ReprocessFromResult{
+		Processed: []indexer.ReprocessedMessage{
+			{MsgHash: "0x1", BlockNumber: 100, Status: relayer.EventStatusDone},
+		},
+		Skipped: []indexer.ReprocessedMessage{
+			{MsgHash: "0x2", BlockNumber: 101, Status: relayer.EventStatusRetriable, Error: "not reprocessable"},
+		},
+		Failed: []indexer.ReprocessedMessage{},
+	})
+
+	assert.Equal(t, `chain 5:
+processed msgHash: 0x1 block: 100 status: done
+skipped msgHash: 0x2 block: 101 status: retriable: not reprocessable
+1 processed, 1 skipped, 0 failed
+`, buf.String())
+}
@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/failover"
+	"github.com/pkg/errors"
+)
+
+// rpcHeaders returns the headers each of layer's n RPC endpoints sends, i.e. API keys:
+// <LAYER>_RPC_HEADERS for all of them, and <LAYER>_RPC_HEADERS_<N> for the Nth, from 1, in
+// <LAYER>_RPC_URL, which take precedence. Both are `Name: value` headers separated by semicolons.
+func rpcHeaders(layer relayer.Layer, n int, getenv func(string) string) ([]http.Header, error) {
+	prefix := strings.ToUpper(string(layer)) + "_RPC_HEADERS"
+
+	shared, err := failover.ParseHeaders(getenv(prefix))
+	if err != nil {
+		return nil, errors.Wrap(err, prefix)
+	}
+
+	headers := make([]http.Header, n)
+
+	for i := range headers {
+		name := fmt.Sprintf("%v_%v", prefix, i+1)
+
+		own, err := failover.ParseHeaders(getenv(name))
+		if err != nil {
+			return nil, errors.Wrap(err, name)
+		}
+
+		headers[i] = shared.Clone()
+
+		for k, v := range own {
+			headers[i][k] = v
+		}
+	}
+
+	return headers, nil
+}
@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/failover"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_rpcHeaders(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    []http.Header
+		wantErr error
+	}{
+		{
+			"unset",
+			map[string]string{},
+			[]http.Header{{}, {}},
+			nil,
+		},
+		{
+			"sharedAndPerEndpoint",
+			map[string]string{
+				"L1_RPC_HEADERS":   "Authorization: Bearer shared; X-Client: relayer",
+				"L1_RPC_HEADERS_2": "Authorization: Bearer second",
+			},
+			[]http.Header{
+				{"Authorization": {"Bearer shared"}, "X-Client": {"relayer"}},
+				{"Authorization": {"Bearer second"}, "X-Client": {"relayer"}},
+			},
+			nil,
+		},
+		{
+			"otherLayer",
+			map[string]string{"L2_RPC_HEADERS": "X-Api-Key: abc"},
+			[]http.Header{{}, {}},
+			nil,
+		},
+		{
+			"invalid",
+			map[string]string{"L1_RPC_HEADERS_1": "Bearer abc"},
+			nil,
+			failover.ErrInvalidHeader,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers, err := rpcHeaders(relayer.L1, 2, func(k string) string { return tt.env[k] })
+			assert.Equal(t, tt.wantErr, errors.Cause(err))
+			assert.Equal(t, tt.want, headers)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/repo"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/runway"
+)
+
+// makeRunwayEstimators returns an estimator of the relayer keys' gas runway on each layer's chain,
+// keyed by its chain ID. The gas spent is averaged over the last GAS_RUNWAY_WINDOW_IN_HOURS.
+func makeRunwayEstimators(
+	getenv func(string) string,
+	db relayer.DB,
+	clients map[relayer.Layer]runway.Client,
+) (map[int64]*runway.Estimator, error) {
+	gasSpendRepository, err := repo.NewGasSpendRepository(db)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, err := relayerAddresses(getenv)
+	if err != nil {
+		return nil, err
+	}
+
+	window := defaultGasRunwayWindow
+	if h, err := strconv.Atoi(getenv("GAS_RUNWAY_WINDOW_IN_HOURS")); err == nil && h > 0 {
+		window = time.Duration(h) * time.Hour
+	}
+
+	interval := defaultGasRunwayInterval
+	if i, err := strconv.Atoi(getenv("GAS_RUNWAY_INTERVAL_IN_SECONDS")); err == nil && i > 0 {
+		interval = time.Duration(i) * time.Second
+	}
+
+	estimators := make(map[int64]*runway.Estimator)
+
+	for _, layer := range []relayer.Layer{relayer.L1, relayer.L2} {
+		client, ok := clients[layer]
+		if !ok {
+			continue
+		}
+
+		chainID, err := client.ChainID(context.Background())
+		if err != nil {
+			return nil, errors.Wrapf(err, "%v client.ChainID", layer)
+		}
+
+		estimator, err := runway.NewEstimator(runway.NewEstimatorOpts{
+			GasSpendRepo: gasSpendRepository,
+			Client:       client,
+			Accounts:     accounts,
+			Window:       window,
+			Interval:     interval,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "runway.NewEstimator")
+		}
+
+		estimators[chainID.Int64()] = estimator
+	}
+
+	return estimators, nil
+}
@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/db"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/runway"
+)
+
+func Test_makeRunwayEstimators(t *testing.T) {
+	env := map[string]string{
+		"RELAYER_ECDSA_KEY": dummyEcdsaKey,
+	}
+
+	estimators, err := makeRunwayEstimators(
+		func(k string) string { return env[k] },
+		&db.DB{},
+		map[relayer.Layer]runway.Client{relayer.L2: &mock.EthClient{}},
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(estimators))
+	assert.NotNil(t, estimators[mock.MockChainID.Int64()])
+}
+
+func Test_makeRunwayEstimators_errors(t *testing.T) {
+	env := map[string]string{
+		"RELAYER_ECDSA_KEY": dummyEcdsaKey,
+	}
+
+	_, err := makeRunwayEstimators(func(k string) string { return env[k] }, nil, nil)
+	assert.Equal(t, relayer.ErrNoDB, err)
+
+	_, err = makeRunwayEstimators(func(k string) string { return "" }, &db.DB{}, nil)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,555 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/joho/godotenv"
+	"github.com/pkg/errors"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/abicheck"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/failover"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/migrations"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/proof"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/repo"
+)
+
+// defaultSelfTestTimeout bounds each check, so an unreachable node or database fails it rather than hangs
+var defaultSelfTestTimeout = 30 * time.Second
+
+var selfTestLayers = []relayer.Layer{relayer.L1, relayer.L2}
+
+// skipCheck ends a self-test check without passing or failing it, saying why, i.e. when a check it
+// depends on failed
+type skipCheck string
+
+func (s skipCheck) Error() string {
+	return string(s)
+}
+
+type selfTestCheck struct {
+	name string
+	// run returns what the check found, or why it failed
+	run func(ctx context.Context) (string, error)
+}
+
+type selfTestResult struct {
+	name   string
+	status string
+	detail string
+}
+
+var (
+	selfTestPassed  = "ok"
+	selfTestFailed  = "FAIL"
+	selfTestSkipped = "skip"
+)
+
+// selfTest holds what later checks need from earlier ones, left unset where they failed
+type selfTest struct {
+	getenv func(string) string
+
+	relayerAddr    common.Address
+	extraAddrs     []common.Address
+	clients        map[relayer.Layer]*failover.Client
+	chainIDs       map[relayer.Layer]*big.Int
+	db             relayer.DB
+	eventRepo      relayer.EventRepository
+	signalServices map[relayer.Layer]proof.SignalService
+}
+
+// SelfTest checks the configuration end to end before going live, and prints a report of each
+// check, exiting non-zero if any failed, i.e. `relayer selftest`. It connects to both chains and
+// the database, checks the contracts, the relayer's balances, and generates and verifies a proof
+// for the latest message indexed from each chain, without sending any transactions.
+func SelfTest(args []string) {
+	fs := flag.NewFlagSet("selftest", flag.ExitOnError)
+
+	timeout := fs.Duration("timeout", defaultSelfTestTimeout, "how long each check can take")
+
+	_ = fs.Parse(args)
+
+	_ = godotenv.Load()
+
+	st := newSelfTest(os.Getenv)
+
+	results := runSelfTestChecks(context.Background(), st.checks(), *timeout)
+
+	st.close()
+
+	if failed := printSelfTestReport(os.Stdout, results); failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func newSelfTest(getenv func(string) string) *selfTest {
+	return &selfTest{
+		getenv:         getenv,
+		clients:        make(map[relayer.Layer]*failover.Client),
+		chainIDs:       make(map[relayer.Layer]*big.Int),
+		signalServices: make(map[relayer.Layer]proof.SignalService),
+	}
+}
+
+// checks are run in order, each layer's after the ones they depend on
+func (st *selfTest) checks() []selfTestCheck {
+	checks := []selfTestCheck{{"config", st.checkConfig}}
+
+	for _, layer := range selfTestLayers {
+		checks = append(checks, selfTestCheck{fmt.Sprintf("%v rpc", layer), st.checkRPC(layer)})
+	}
+
+	checks = append(checks,
+		selfTestCheck{"chain ids", st.checkChainIDs},
+		selfTestCheck{"database", st.checkDatabase},
+		selfTestCheck{"migrations", st.checkMigrations},
+	)
+
+	for _, layer := range selfTestLayers {
+		checks = append(checks,
+			selfTestCheck{fmt.Sprintf("%v contracts", layer), st.checkContracts(layer)},
+			selfTestCheck{fmt.Sprintf("%v signal service", layer), st.checkSignalService(layer)},
+			selfTestCheck{fmt.Sprintf("%v balance", layer), st.checkBalance(layer)},
+		)
+	}
+
+	for _, layer := range selfTestLayers {
+		checks = append(checks, selfTestCheck{fmt.Sprintf("%v proof", layer), st.checkProof(layer)})
+	}
+
+	return checks
+}
+
+func (st *selfTest) close() {
+	for _, client := range st.clients {
+		client.Close()
+	}
+
+	if st.db == nil {
+		return
+	}
+
+	if sqlDB, err := st.db.DB(); err == nil {
+		sqlDB.Close()
+	}
+}
+
+// runSelfTestChecks runs every check, however many fail, each bounded by timeout
+func runSelfTestChecks(ctx context.Context, checks []selfTestCheck, timeout time.Duration) []selfTestResult {
+	results := make([]selfTestResult, 0, len(checks))
+
+	for _, check := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, timeout)
+
+		detail, err := check.run(checkCtx)
+
+		cancel()
+
+		var skip skipCheck
+
+		switch {
+		case errors.As(err, &skip):
+			results = append(results, selfTestResult{check.name, selfTestSkipped, skip.Error()})
+		case err != nil:
+			results = append(results, selfTestResult{check.name, selfTestFailed, err.Error()})
+		default:
+			results = append(results, selfTestResult{check.name, selfTestPassed, detail})
+		}
+	}
+
+	return results
+}
+
+// printSelfTestReport writes each check's result, then a summary, and returns how many failed
+func printSelfTestReport(w io.Writer, results []selfTestResult) int {
+	counts := make(map[string]int)
+
+	for _, r := range results {
+		fmt.Fprintf(w, "%-6v%v: %v\n", r.status, r.name, r.detail)
+
+		counts[r.status]++
+	}
+
+	fmt.Fprintf(
+		w,
+		"%v passed, %v failed, %v skipped\n",
+		counts[selfTestPassed],
+		counts[selfTestFailed],
+		counts[selfTestSkipped],
+	)
+
+	return counts[selfTestFailed]
+}
+
+func (st *selfTest) checkConfig(ctx context.Context) (string, error) {
+	missing := make([]string, 0)
+
+	for _, v := range envVars {
+		if st.getenv(v) == "" {
+			missing = append(missing, v)
+		}
+	}
+
+	if len(missing) > 0 {
+		return "", errors.Errorf("missing env vars: %v", strings.Join(missing, ", "))
+	}
+
+	relayerAddr, err := loadRelayerKey()
+	if err != nil {
+		return "", errors.Wrap(err, "loadRelayerKey")
+	}
+
+	extraKeys, err := extraRelayerKeys(st.getenv)
+	if err != nil {
+		return "", errors.Wrap(err, "extraRelayerKeys")
+	}
+
+	st.relayerAddr = relayerAddr
+
+	for _, k := range extraKeys {
+		st.extraAddrs = append(st.extraAddrs, crypto.PubkeyToAddress(k.Key.PublicKey))
+	}
+
+	if len(extraKeys) > 0 {
+		return fmt.Sprintf("relaying from %v and %v more keys", relayerAddr.Hex(), len(extraKeys)), nil
+	}
+
+	return fmt.Sprintf("relaying from %v", relayerAddr.Hex()), nil
+}
+
+func (st *selfTest) checkRPC(layer relayer.Layer) func(ctx context.Context) (string, error) {
+	return func(ctx context.Context) (string, error) {
+		envVar := strings.ToUpper(string(layer)) + "_RPC_URL"
+
+		urls := st.getenv(envVar)
+		if urls == "" {
+			return "", errors.Errorf("%v is unset", envVar)
+		}
+
+		client, err := dialRPC(layer, urls)
+		if err != nil {
+			return "", errors.Wrap(err, "dialRPC")
+		}
+
+		st.clients[layer] = client
+
+		chainID, err := client.ChainID(ctx)
+		if err != nil {
+			return "", errors.Wrap(err, "client.ChainID")
+		}
+
+		head, err := client.BlockNumber(ctx)
+		if err != nil {
+			return "", errors.Wrap(err, "client.BlockNumber")
+		}
+
+		st.chainIDs[layer] = chainID
+
+		return fmt.Sprintf("chain id %v, at block %v", chainID, head), nil
+	}
+}
+
+func (st *selfTest) checkChainIDs(ctx context.Context) (string, error) {
+	l1, l2 := st.chainIDs[relayer.L1], st.chainIDs[relayer.L2]
+	if l1 == nil || l2 == nil {
+		return "", skipCheck("needs both rpcs")
+	}
+
+	if l1.Cmp(l2) == 0 {
+		return "", errors.Errorf("l1 and l2 are both chain %v, check L1_RPC_URL and L2_RPC_URL", l1)
+	}
+
+	return fmt.Sprintf("l1 is chain %v, l2 is chain %v", l1, l2), nil
+}
+
+func (st *selfTest) checkDatabase(ctx context.Context) (string, error) {
+	db, err := openMySQL()
+	if err != nil {
+		return "", errors.Wrap(err, "openMySQL")
+	}
+
+	st.db = db
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return "", errors.Wrap(err, "db.DB")
+	}
+
+	if err := sqlDB.PingContext(ctx); err != nil {
+		return "", errors.Wrap(err, "sqlDB.PingContext")
+	}
+
+	eventRepo, err := repo.NewEventRepository(db)
+	if err != nil {
+		return "", errors.Wrap(err, "repo.NewEventRepository")
+	}
+
+	st.eventRepo = eventRepo
+
+	return fmt.Sprintf("connected to %v on %v", st.getenv("MYSQL_DATABASE"), st.getenv("MYSQL_HOST")), nil
+}
+
+func (st *selfTest) checkMigrations(ctx context.Context) (string, error) {
+	if st.db == nil {
+		return "", skipCheck("needs the database")
+	}
+
+	sqlDB, err := st.db.DB()
+	if err != nil {
+		return "", errors.Wrap(err, "db.DB")
+	}
+
+	version, err := migrations.Version(ctx, sqlDB)
+	if err != nil {
+		return "", errors.Wrap(err, "migrations.Version")
+	}
+
+	latest, err := migrations.LatestVersion()
+	if err != nil {
+		return "", errors.Wrap(err, "migrations.LatestVersion")
+	}
+
+	return describeMigrations(version, latest)
+}
+
+// describeMigrations passes a database behind the known migrations, since the relayer applies them at
+// startup, but fails one ahead of them, which the relayer refuses to start against.
+func describeMigrations(version int64, latest int64) (string, error) {
+	switch {
+	case version > latest:
+		return "", errors.Errorf(
+			"database is at version %v, ahead of the latest known migration %v, run a newer relayer",
+			version,
+			latest,
+		)
+	case version < latest:
+		return fmt.Sprintf(
+			"database is at version %v, migrations up to %v will be applied at startup, or with `relayer migrate`",
+			version,
+			latest,
+		), nil
+	default:
+		return fmt.Sprintf("database is at the latest version, %v", version), nil
+	}
+}
+
+// checkContracts checks each contract configured on layer is deployed, and has the methods its
+// binding calls
+func (st *selfTest) checkContracts(layer relayer.Layer) func(ctx context.Context) (string, error) {
+	return func(ctx context.Context) (string, error) {
+		client, ok := st.clients[layer]
+		if !ok {
+			return "", skipCheck(fmt.Sprintf("needs the %v rpc", layer))
+		}
+
+		contracts, err := bindingContracts(st.getenv)
+		if err != nil {
+			return "", errors.Wrap(err, "bindingContracts")
+		}
+
+		if len(contracts[layer]) == 0 {
+			return "", errors.Errorf("no %v contract addresses are set", layer)
+		}
+
+		found := make([]string, 0, len(contracts[layer]))
+
+		for _, c := range contracts[layer] {
+			missing, err := abicheck.MissingMethods(ctx, client, c)
+			if err != nil {
+				return "", errors.Wrapf(err, "%v at %v", c.Name, c.Address.Hex())
+			}
+
+			if len(missing) > 0 {
+				return "", errors.Errorf(
+					"%v at %v is missing %v, regenerate the bindings",
+					c.Name,
+					c.Address.Hex(),
+					strings.Join(missing, ", "),
+				)
+			}
+
+			found = append(found, fmt.Sprintf("%v %v", c.Name, c.Address.Hex()))
+		}
+
+		return strings.Join(found, ", "), nil
+	}
+}
+
+// checkSignalService checks layer's bridge resolves the SignalService configured, if one is, and that
+// we know its storage layout
+func (st *selfTest) checkSignalService(layer relayer.Layer) func(ctx context.Context) (string, error) {
+	return func(ctx context.Context) (string, error) {
+		client, chainID := st.clients[layer], st.chainIDs[layer]
+		if client == nil || chainID == nil {
+			return "", skipCheck(fmt.Sprintf("needs the %v rpc", layer))
+		}
+
+		prefix := strings.ToUpper(string(layer))
+
+		bridgeAddress := common.HexToAddress(st.getenv(prefix + "_BRIDGE_ADDRESS"))
+
+		head, err := client.BlockNumber(ctx)
+		if err != nil {
+			return "", errors.Wrap(err, "client.BlockNumber")
+		}
+
+		resolved, err := proof.ResolveSignalService(ctx, client, bridgeAddress, new(big.Int).SetUint64(head))
+		if err != nil {
+			return "", errors.Wrap(err, "proof.ResolveSignalService")
+		}
+
+		if configured := st.getenv(prefix + "_SIGNAL_SERVICE_ADDRESS"); configured != "" &&
+			common.HexToAddress(configured) != resolved {
+			return "", errors.Errorf(
+				"%v_SIGNAL_SERVICE_ADDRESS is %v, but the bridge resolves %v",
+				prefix,
+				configured,
+				resolved.Hex(),
+			)
+		}
+
+		version, err := proof.DetectSignalServiceVersion(ctx, client, resolved, chainID.Uint64())
+		if err != nil {
+			return "", errors.Wrap(err, "proof.DetectSignalServiceVersion")
+		}
+
+		st.signalServices[layer] = proof.SignalService{
+			Address: resolved,
+			Version: version,
+			ChainID: chainID.Uint64(),
+		}
+
+		return fmt.Sprintf("bridge resolves %v, a v%v SignalService", resolved.Hex(), version), nil
+	}
+}
+
+func (st *selfTest) checkBalance(layer relayer.Layer) func(ctx context.Context) (string, error) {
+	return func(ctx context.Context) (string, error) {
+		client, ok := st.clients[layer]
+		if !ok {
+			return "", skipCheck(fmt.Sprintf("needs the %v rpc", layer))
+		}
+
+		if st.relayerAddr == relayer.ZeroAddress {
+			return "", skipCheck("needs the relayer key")
+		}
+
+		// unset only needs a balance
+		minBalance, _ := new(big.Int).SetString(st.getenv(strings.ToUpper(string(layer))+"_ALERT_MIN_BALANCE"), 10)
+
+		details := make([]string, 0)
+
+		for _, addr := range append([]common.Address{st.relayerAddr}, st.extraAddrs...) {
+			detail, err := checkRelayerBalance(ctx, client, addr, minBalance)
+			if err != nil {
+				return "", err
+			}
+
+			details = append(details, detail)
+		}
+
+		return strings.Join(details, ", "), nil
+	}
+}
+
+type balanceClient interface {
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+}
+
+// checkRelayerBalance fails if the relayer can't pay for transactions, or its balance is below
+// minBalance, if it's set
+func checkRelayerBalance(
+	ctx context.Context,
+	client balanceClient,
+	relayerAddr common.Address,
+	minBalance *big.Int,
+) (string, error) {
+	balance, err := client.BalanceAt(ctx, relayerAddr, nil)
+	if err != nil {
+		return "", errors.Wrap(err, "client.BalanceAt")
+	}
+
+	if balance.Sign() == 0 {
+		return "", errors.Errorf("%v has no balance to pay for transactions with", relayerAddr.Hex())
+	}
+
+	if minBalance != nil && balance.Cmp(minBalance) < 0 {
+		return "", errors.Errorf("%v has %v wei, below the alert threshold of %v", relayerAddr.Hex(), balance, minBalance)
+	}
+
+	return fmt.Sprintf("%v has %v wei", relayerAddr.Hex(), balance), nil
+}
+
+// checkProof generates a proof for the latest message indexed from layer, against the block it was
+// sent in, then verifies it the way the destination bridge would. Nothing is sent.
+func (st *selfTest) checkProof(layer relayer.Layer) func(ctx context.Context) (string, error) {
+	return func(ctx context.Context) (string, error) {
+		chainID := st.chainIDs[layer]
+		if st.eventRepo == nil || chainID == nil {
+			return "", skipCheck(fmt.Sprintf("needs the database and the %v rpc", layer))
+		}
+
+		e, err := st.eventRepo.LatestMessageSent(ctx, chainID)
+		if err != nil {
+			return "", errors.Wrap(err, "st.eventRepo.LatestMessageSent")
+		}
+
+		if e == nil {
+			return "", skipCheck(fmt.Sprintf("no messages indexed from %v yet", layer))
+		}
+
+		event := &bridge.BridgeMessageSent{}
+		if err := json.Unmarshal(e.Data, event); err != nil {
+			return "", errors.Wrap(err, "json.Unmarshal")
+		}
+
+		client := st.clients[layer]
+
+		signalService, ok := st.signalServices[layer]
+		if client == nil || !ok {
+			return "", skipCheck(fmt.Sprintf("needs the %v signal service", layer))
+		}
+
+		app := common.HexToAddress(st.getenv(strings.ToUpper(string(layer)) + "_BRIDGE_ADDRESS"))
+
+		prover, err := proof.New(client, client)
+		if err != nil {
+			return "", errors.Wrap(err, "proof.New")
+		}
+
+		encodedProof, err := prover.EncodedSignalProof(ctx, client, signalService, app, event.MsgHash, event.Raw.BlockHash)
+		if err != nil {
+			return "", errors.Wrapf(err, "message %v, prover.EncodedSignalProof", e.MsgHash)
+		}
+
+		if _, err := proof.VerifySignalProof(ctx, client, proof.VerifySignalProofOpts{
+			SignalServiceAddress: signalService.Address,
+			SignalServiceVersion: signalService.Version,
+			ChainID:              signalService.ChainID,
+			App:                  app,
+			Signal:               event.MsgHash,
+			EncodedProof:         encodedProof,
+			BlockNumber:          new(big.Int).SetUint64(event.Raw.BlockNumber),
+		}); err != nil {
+			return "", errors.Wrapf(err, "message %v, proof.VerifySignalProof", e.MsgHash)
+		}
+
+		return fmt.Sprintf(
+			"proved message %v, sent in block %v, %v bytes",
+			e.MsgHash,
+			event.Raw.BlockNumber,
+			len(encodedProof),
+		), nil
+	}
+}
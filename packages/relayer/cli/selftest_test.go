@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_runSelfTestChecks(t *testing.T) {
+	checks := []selfTestCheck{
+		{"passes", func(ctx context.Context) (string, error) {
+			return "found it", nil
+		}},
+		{"fails", func(ctx context.Context) (string, error) {
+			return "", errors.New("not found")
+		}},
+		{"skips", func(ctx context.Context) (string, error) {
+			return "", errors.Wrap(skipCheck("needs the database"), "wrapped")
+		}},
+		{"timesOut", func(ctx context.Context) (string, error) {
+			<-ctx.Done()
+			return "", ctx.Err()
+		}},
+	}
+
+	results := runSelfTestChecks(context.Background(), checks, 10*time.Millisecond)
+
+	assert.Equal(t, []selfTestResult{
+		{"passes", selfTestPassed, "found it"},
+		{"fails", selfTestFailed, "not found"},
+		{"skips", selfTestSkipped, "needs the database"},
+		{"timesOut", selfTestFailed, context.DeadlineExceeded.Error()},
+	}, results)
+}
+
+func Test_printSelfTestReport(t *testing.T) {
+	var out bytes.Buffer
+
+	failed := printSelfTestReport(&out, []selfTestResult{
+		{"l1 rpc", selfTestPassed, "chain id 1, at block 5"},
+		{"l2 rpc", selfTestFailed, "client.ChainID: connection refused"},
+		{"chain ids", selfTestSkipped, "needs both rpcs"},
+	})
+
+	assert.Equal(t, 1, failed)
+	assert.Equal(t, "ok    l1 rpc: chain id 1, at block 5\n"+
+		"FAIL  l2 rpc: client.ChainID: connection refused\n"+
+		"skip  chain ids: needs both rpcs\n"+
+		"1 passed, 1 failed, 1 skipped\n", out.String())
+}
+
+func Test_selfTest_checks(t *testing.T) {
+	st := newSelfTest(func(string) string { return "" })
+
+	names := make([]string, 0)
+	for _, c := range st.checks() {
+		names = append(names, c.name)
+	}
+
+	assert.Equal(t, []string{
+		"config",
+		"l1 rpc",
+		"l2 rpc",
+		"chain ids",
+		"database",
+		"migrations",
+		"l1 contracts",
+		"l1 signal service",
+		"l1 balance",
+		"l2 contracts",
+		"l2 signal service",
+		"l2 balance",
+		"l1 proof",
+		"l2 proof",
+	}, names)
+}
+
+func Test_selfTest_checkConfig(t *testing.T) {
+	st := newSelfTest(func(string) string { return "" })
+
+	_, err := st.checkConfig(context.Background())
+	assert.ErrorContains(t, err, "missing env vars: HTTP_PORT, L1_BRIDGE_ADDRESS")
+}
+
+func Test_selfTest_checkChainIDs(t *testing.T) {
+	tests := []struct {
+		name     string
+		chainIDs map[relayer.Layer]*big.Int
+		wantSkip bool
+		wantErr  bool
+	}{
+		{"noRPC", map[relayer.Layer]*big.Int{relayer.L1: big.NewInt(1)}, true, false},
+		{"same", map[relayer.Layer]*big.Int{relayer.L1: big.NewInt(1), relayer.L2: big.NewInt(1)}, false, true},
+		{"different", map[relayer.Layer]*big.Int{relayer.L1: big.NewInt(1), relayer.L2: big.NewInt(5167003)}, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newSelfTest(func(string) string { return "" })
+			st.chainIDs = tt.chainIDs
+
+			_, err := st.checkChainIDs(context.Background())
+
+			var skip skipCheck
+
+			assert.Equal(t, tt.wantSkip, errors.As(err, &skip))
+			assert.Equal(t, tt.wantErr, err != nil && !tt.wantSkip)
+		})
+	}
+}
+
+func Test_describeMigrations(t *testing.T) {
+	detail, err := describeMigrations(1666650720, 1666650720)
+	assert.Nil(t, err)
+	assert.Equal(t, "database is at the latest version, 1666650720", detail)
+
+	detail, err = describeMigrations(1666650700, 1666650720)
+	assert.Nil(t, err)
+	assert.Contains(t, detail, "will be applied at startup")
+
+	_, err = describeMigrations(1666650721, 1666650720)
+	assert.ErrorContains(t, err, "run a newer relayer")
+}
+
+func Test_checkRelayerBalance(t *testing.T) {
+	relayerAddr := common.HexToAddress("0x63FaC9201494f0bd17B9892B9fae4d52fe3BD377")
+
+	detail, err := checkRelayerBalance(context.Background(), &mock.EthClient{}, relayerAddr, nil)
+	assert.Nil(t, err)
+	assert.Contains(t, detail, mock.Balance.String())
+
+	minBalance := new(big.Int).Add(mock.Balance, common.Big1)
+
+	_, err = checkRelayerBalance(context.Background(), &mock.EthClient{}, relayerAddr, minBalance)
+	assert.ErrorContains(t, err, "below the alert threshold")
+}
+
+func Test_selfTest_checkProof_noMessages(t *testing.T) {
+	st := newSelfTest(func(string) string { return "" })
+
+	// without the database, there are no messages to prove
+	_, err := st.checkProof(relayer.L1)(context.Background())
+	assert.Equal(t, skipCheck("needs the database and the l1 rpc"), err)
+
+	st.eventRepo = mock.NewEventRepository()
+	st.chainIDs[relayer.L1] = big.NewInt(1)
+
+	_, err = st.checkProof(relayer.L1)(context.Background())
+	assert.Equal(t, skipCheck("no messages indexed from l1 yet"), err)
+}
@@ -0,0 +1,260 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/icrosschainsync"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/proof"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/joho/godotenv"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+type validateProofFlags struct {
+	// layer is the one the messages were sent from
+	layer  relayer.Layer
+	height uint64
+}
+
+// proofValidation is the outcome of proving one message, and checking the proof against the
+// destination's signal root
+type proofValidation struct {
+	msgHash     common.Hash
+	storageRoot common.Hash
+	err         error
+}
+
+// ValidateProof generates the signal proof of every message sent in a source block, and checks the
+// SignalService storage root each proves against the signal root the destination synced for that
+// block, which the destination bridge verifies it against, i.e. `relayer validate-proof --height 100`.
+// It's for when processMessage reverts though the block looks synced. Nothing is sent.
+func ValidateProof(args []string) {
+	_ = godotenv.Load()
+
+	f, err := parseValidateProofFlags(args)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	destLayer := relayer.L2
+	if f.layer == relayer.L2 {
+		destLayer = relayer.L1
+	}
+
+	srcPrefix, destPrefix := strings.ToUpper(string(f.layer)), strings.ToUpper(string(destLayer))
+
+	srcClient, err := dialRPC(f.layer, os.Getenv(srcPrefix+"_RPC_URL"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer srcClient.Close()
+
+	destClient, err := dialRPC(destLayer, os.Getenv(destPrefix+"_RPC_URL"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer destClient.Close()
+
+	ctx := context.Background()
+	height := new(big.Int).SetUint64(f.height)
+	bridgeAddress := common.HexToAddress(os.Getenv(srcPrefix + "_BRIDGE_ADDRESS"))
+
+	chainID, err := srcClient.ChainID(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// the SignalService the bridge used at the height, which a migration since could have changed
+	signalServiceAddress, err := proof.ResolveSignalService(ctx, srcClient, bridgeAddress, height)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	version, err := proof.DetectSignalServiceVersion(ctx, srcClient, signalServiceAddress, chainID.Uint64())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	signalService := proof.SignalService{Address: signalServiceAddress, Version: version, ChainID: chainID.Uint64()}
+
+	srcBridge, err := bridge.NewBridge(bridgeAddress, srcClient)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	messages, err := messagesSentAt(ctx, srcBridge, f.height)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	destHeaderSyncer, err := icrosschainsync.NewICrossChainSync(
+		common.HexToAddress(os.Getenv(destPrefix+"_MXC_ADDRESS")),
+		destClient,
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	signalRoot, err := destHeaderSyncer.GetCrossChainSignalRoot(&bind.CallOpts{Context: ctx}, height)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	prover, err := proof.New(srcClient, srcClient)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	validations := validateProofs(ctx, height, messages, common.Hash(signalRoot), func(
+		ctx context.Context,
+		m *bridge.BridgeMessageSent,
+	) ([]byte, error) {
+		return prover.EncodedSignalProof(ctx, srcClient, signalService, bridgeAddress, m.MsgHash, m.Raw.BlockHash)
+	})
+
+	if failed := printValidateProofReport(os.Stdout, f, common.Hash(signalRoot), validations); failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func parseValidateProofFlags(args []string) (validateProofFlags, error) {
+	fs := flag.NewFlagSet("validate-proof", flag.ContinueOnError)
+
+	height := fs.Uint64("height", 0, "source block to prove the messages sent in")
+	layer := fs.String("layer", string(relayer.L1), "layer the messages were sent from, l1 or l2")
+
+	if err := fs.Parse(args); err != nil {
+		return validateProofFlags{}, err
+	}
+
+	if relayer.Layer(*layer) != relayer.L1 && relayer.Layer(*layer) != relayer.L2 {
+		return validateProofFlags{}, errors.Errorf("invalid layer %v, must be l1 or l2", *layer)
+	}
+
+	if *height == 0 {
+		return validateProofFlags{}, relayer.ErrInvalidBlockNumber
+	}
+
+	return validateProofFlags{layer: relayer.Layer(*layer), height: *height}, nil
+}
+
+// messagesSentAt returns the MessageSent events the bridge emitted in block height
+func messagesSentAt(ctx context.Context, b relayer.Bridge, height uint64) ([]*bridge.BridgeMessageSent, error) {
+	iter, err := b.FilterMessageSent(&bind.FilterOpts{Start: height, End: &height, Context: ctx}, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "b.FilterMessageSent")
+	}
+
+	defer iter.Close()
+
+	messages := make([]*bridge.BridgeMessageSent, 0)
+
+	for iter.Next() {
+		messages = append(messages, iter.Event)
+	}
+
+	if err := iter.Error(); err != nil {
+		return nil, errors.Wrap(err, "iter.Error")
+	}
+
+	return messages, nil
+}
+
+// validateProofs proves each message with prove, and checks the proof is for height, against
+// signalRoot. A message failing doesn't stop the others being checked.
+func validateProofs(
+	ctx context.Context,
+	height *big.Int,
+	messages []*bridge.BridgeMessageSent,
+	signalRoot common.Hash,
+	prove func(ctx context.Context, m *bridge.BridgeMessageSent) ([]byte, error),
+) []proofValidation {
+	validations := make([]proofValidation, 0, len(messages))
+
+	for _, m := range messages {
+		v := proofValidation{msgHash: m.MsgHash}
+
+		encodedProof, err := prove(ctx, m)
+		if err != nil {
+			v.err = errors.Wrap(err, "prove")
+			validations = append(validations, v)
+
+			continue
+		}
+
+		provenHeight, storageRoot, err := proof.SignalProofStorageRoot(encodedProof)
+		if err != nil {
+			v.err = errors.Wrap(err, "proof.SignalProofStorageRoot")
+			validations = append(validations, v)
+
+			continue
+		}
+
+		v.storageRoot = storageRoot
+
+		switch {
+		case provenHeight.Cmp(height) != 0:
+			v.err = errors.Errorf("proof is for block %v, not %v", provenHeight, height)
+		case storageRoot != signalRoot:
+			v.err = errors.Wrapf(
+				proof.ErrSignalRootMismatch,
+				"proof is against storage root %v, but the destination synced %v",
+				storageRoot.Hex(),
+				signalRoot.Hex(),
+			)
+		}
+
+		validations = append(validations, v)
+	}
+
+	return validations
+}
+
+// printValidateProofReport writes the signal root synced, then each message's validation, and
+// returns how many failed. The destination not having synced the height at all fails it too.
+func printValidateProofReport(
+	w io.Writer,
+	f validateProofFlags,
+	signalRoot common.Hash,
+	validations []proofValidation,
+) int {
+	failed := 0
+
+	if signalRoot == (common.Hash{}) {
+		fmt.Fprintf(w, "FAIL  destination hasn't synced %v block %v, no signal root to check against\n", f.layer, f.height)
+
+		failed++
+	} else {
+		fmt.Fprintf(w, "ok    destination synced %v block %v with signal root %v\n", f.layer, f.height, signalRoot.Hex())
+	}
+
+	failedMessages := 0
+
+	for _, v := range validations {
+		if v.err != nil {
+			fmt.Fprintf(w, "FAIL  message %v: %v\n", v.msgHash.Hex(), v.err)
+
+			failedMessages++
+
+			continue
+		}
+
+		fmt.Fprintf(w, "ok    message %v: proof matches storage root %v\n", v.msgHash.Hex(), v.storageRoot.Hex())
+	}
+
+	fmt.Fprintf(w, "%v messages sent in block %v, %v failed\n", len(validations), f.height, failedMessages)
+
+	return failed + failedMessages
+}
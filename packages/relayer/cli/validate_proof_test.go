@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/encoding"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/proof"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseValidateProofFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    validateProofFlags
+		wantErr bool
+	}{
+		{"l1ByDefault", []string{"--height", "100"}, validateProofFlags{layer: relayer.L1, height: 100}, false},
+		{"l2", []string{"--height", "5", "--layer", "l2"}, validateProofFlags{layer: relayer.L2, height: 5}, false},
+		{"noHeight", []string{}, validateProofFlags{}, true},
+		{"invalidLayer", []string{"--height", "5", "--layer", "l3"}, validateProofFlags{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := parseValidateProofFlags(tt.args)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, f)
+		})
+	}
+}
+
+// encodedStorageRootProof is a signal proof for height whose storage proof's root node is node
+func encodedStorageRootProof(t *testing.T, height int64, node []byte) []byte {
+	storageProof, err := rlp.EncodeToBytes([][]byte{node})
+	assert.Nil(t, err)
+
+	encoded, err := encoding.EncodeSignalProof(encoding.SignalProof{Height: big.NewInt(height), Proof: storageProof})
+	assert.Nil(t, err)
+
+	return encoded
+}
+
+func Test_validateProofs(t *testing.T) {
+	node := []byte{0x01}
+	signalRoot := crypto.Keccak256Hash(node)
+
+	messages := []*bridge.BridgeMessageSent{
+		{MsgHash: [32]byte{1}},
+		{MsgHash: [32]byte{2}},
+		{MsgHash: [32]byte{3}},
+		{MsgHash: [32]byte{4}},
+	}
+
+	proofs := map[[32]byte][]byte{
+		{1}: encodedStorageRootProof(t, 100, node),
+		{2}: encodedStorageRootProof(t, 100, []byte{0x02}),
+		{3}: encodedStorageRootProof(t, 99, node),
+	}
+
+	validations := validateProofs(context.Background(), big.NewInt(100), messages, signalRoot, func(
+		ctx context.Context,
+		m *bridge.BridgeMessageSent,
+	) ([]byte, error) {
+		if p, ok := proofs[m.MsgHash]; ok {
+			return p, nil
+		}
+
+		return nil, proof.ErrStateRootPruned
+	})
+
+	assert.Equal(t, 4, len(validations))
+
+	assert.Nil(t, validations[0].err)
+	assert.Equal(t, signalRoot, validations[0].storageRoot)
+
+	assert.True(t, errors.Is(validations[1].err, proof.ErrSignalRootMismatch), "got %v", validations[1].err)
+	assert.Equal(t, crypto.Keccak256Hash([]byte{0x02}), validations[1].storageRoot)
+
+	assert.EqualError(t, validations[2].err, "proof is for block 99, not 100")
+
+	// one message failing to prove doesn't stop the rest
+	assert.True(t, errors.Is(validations[3].err, proof.ErrStateRootPruned), "got %v", validations[3].err)
+}
+
+func Test_printValidateProofReport(t *testing.T) {
+	f := validateProofFlags{layer: relayer.L1, height: 100}
+
+	validations := []proofValidation{
+		{msgHash: common.Hash{1}, storageRoot: common.Hash{9}},
+		{msgHash: common.Hash{2}, err: proof.ErrSignalRootMismatch},
+	}
+
+	var w bytes.Buffer
+
+	assert.Equal(t, 1, printValidateProofReport(&w, f, common.Hash{9}, validations))
+	assert.Contains(t, w.String(), "ok    destination synced l1 block 100")
+	assert.Contains(t, w.String(), "FAIL  message "+common.Hash{2}.Hex()+": signal root mismatch")
+	assert.Contains(t, w.String(), "2 messages sent in block 100, 1 failed")
+
+	// nothing synced for the height fails, even with no messages to check
+	w.Reset()
+
+	assert.Equal(t, 1, printValidateProofReport(&w, f, common.Hash{}, nil))
+	assert.Contains(t, w.String(), "FAIL  destination hasn't synced l1 block 100")
+}
@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/abicheck"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/mxcl1"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/mxcl2"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/tokenvault"
+)
+
+// boundContract is a contract the relayer calls through a generated binding, and the
+// binding methods it depends on
+type boundContract struct {
+	layer   relayer.Layer
+	envVar  string
+	name    string
+	meta    *bind.MetaData
+	methods []string
+}
+
+var boundContracts = []boundContract{
+	{relayer.L1, "L1_BRIDGE_ADDRESS", "Bridge", bridge.BridgeMetaData, bridgeMethods},
+	{relayer.L2, "L2_BRIDGE_ADDRESS", "Bridge", bridge.BridgeMetaData, bridgeMethods},
+	{relayer.L1, "L1_MXC_ADDRESS", "MxcL1", mxcl1.MxcL1MetaData, mxcL1Methods},
+	{relayer.L2, "L2_MXC_ADDRESS", "MxcL2", mxcl2.MxcL2MetaData, mxcL2Methods},
+	{relayer.L1, "L1_TOKEN_VAULT_ADDRESS", "TokenVault", tokenvault.TokenVaultMetaData, tokenVaultMethods},
+	{relayer.L2, "L2_TOKEN_VAULT_ADDRESS", "TokenVault", tokenvault.TokenVaultMetaData, tokenVaultMethods},
+}
+
+var (
+	// resolve0 is the binding's name for resolve(bytes32,bool), which signal proofs resolve
+	// the source chain's SignalService with
+	bridgeMethods     = []string{"processMessage", "getMessageStatus", "isMessageReceived", "resolve0"}
+	mxcL1Methods      = []string{"getCrossChainBlockHash", "getStateVariables"}
+	mxcL2Methods      = []string{"anchor", "getBasefee", "getCrossChainBlockHash"}
+	tokenVaultMethods = []string{"canonicalToBridged", "isBridgedToken"}
+)
+
+// bindingContracts returns the configured contracts on each layer, skipping unset addresses.
+func bindingContracts(getenv func(string) string) (map[relayer.Layer][]abicheck.Contract, error) {
+	contracts := make(map[relayer.Layer][]abicheck.Contract)
+
+	for _, b := range boundContracts {
+		address := common.HexToAddress(getenv(b.envVar))
+		if address == relayer.ZeroAddress {
+			continue
+		}
+
+		contractABI, err := b.meta.GetAbi()
+		if err != nil {
+			return nil, errors.Wrapf(err, "%v.GetAbi", b.name)
+		}
+
+		contracts[b.layer] = append(contracts[b.layer], abicheck.Contract{
+			Name:    b.name,
+			Address: address,
+			ABI:     contractABI,
+			Methods: b.methods,
+		})
+	}
+
+	return contracts, nil
+}
+
+// verifyBindings warns loudly when a deployed contract no longer has a method the generated
+// bindings call, i.e. it was upgraded and the bindings need regenerating. It never fails
+// startup, since a method we don't reach may be all that changed.
+func verifyBindings(l1Client abicheck.Client, l2Client abicheck.Client) {
+	contracts, err := bindingContracts(os.Getenv)
+	if err != nil {
+		log.Warnf("unable to verify contract bindings: %v", err)
+		return
+	}
+
+	clients := map[relayer.Layer]abicheck.Client{
+		relayer.L1: l1Client,
+		relayer.L2: l2Client,
+	}
+
+	for _, layer := range []relayer.Layer{relayer.L1, relayer.L2} {
+		for _, c := range contracts[layer] {
+			verifyBinding(layer, clients[layer], c)
+		}
+	}
+}
+
+func verifyBinding(layer relayer.Layer, client abicheck.Client, c abicheck.Contract) {
+	missing, err := abicheck.MissingMethods(context.Background(), client, c)
+	if err != nil {
+		log.Warnf("unable to verify %v %v binding at %v: %v", layer, c.Name, c.Address.Hex(), err)
+		return
+	}
+
+	if len(missing) > 0 {
+		log.Warnf(
+			"!!! %v %v at %v is missing %v, calls to them will fail; regenerate the bindings !!!",
+			layer,
+			c.Name,
+			c.Address.Hex(),
+			strings.Join(missing, ", "),
+		)
+	}
+}
@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+)
+
+func Test_bindingContracts(t *testing.T) {
+	env := map[string]string{
+		"L1_BRIDGE_ADDRESS": "0xBb7a150E1247Da7B4c339f0997Bb18A4038147Af",
+		"L2_MXC_ADDRESS":    "0x1000777700000000000000000000000000000001",
+	}
+
+	contracts, err := bindingContracts(func(k string) string { return env[k] })
+	assert.Nil(t, err)
+
+	assert.Len(t, contracts[relayer.L1], 1)
+	assert.Equal(t, "Bridge", contracts[relayer.L1][0].Name)
+	assert.Equal(t, common.HexToAddress(env["L1_BRIDGE_ADDRESS"]), contracts[relayer.L1][0].Address)
+
+	assert.Len(t, contracts[relayer.L2], 1)
+	assert.Equal(t, "MxcL2", contracts[relayer.L2][0].Name)
+	assert.Equal(t, mxcL2Methods, contracts[relayer.L2][0].Methods)
+}
+
+func Test_bindingContracts_methodsInBindings(t *testing.T) {
+	for _, b := range boundContracts {
+		contractABI, err := b.meta.GetAbi()
+		assert.Nil(t, err)
+
+		for _, m := range b.methods {
+			_, ok := contractABI.Methods[m]
+			assert.True(t, ok, "%v.%v", b.name, m)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/proof"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/joho/godotenv"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+type verifyProofFlags struct {
+	// layer is the one the message was sent from, whose RPC headers are sent
+	layer  relayer.Layer
+	rpcURL string
+	opts   proof.VerifySignalProofOpts
+}
+
+// verifyProofHints suggest what to check when a verification step fails with one of these errors
+var verifyProofHints = []struct {
+	err  error
+	hint string
+}{
+	{proof.ErrMalformedProof, "the proof should be an abi encoded (uint256 height, bytes proof), check it was copied in full"},
+	{proof.ErrBlockNotFound, "the node doesn't know the block yet, or --rpc-url isn't the source chain"},
+	{proof.ErrStateRootPruned, "the node no longer has the block's state, use an archive node"},
+	{proof.ErrStateRootMismatch, "the proof was generated against a different block, regenerate it for --block"},
+	{proof.ErrAccountProofInvalid, "check --signal-service is the source chain's SignalService, and --rpc-url the source chain"},
+	{proof.ErrStorageProofInvalid, "the proof is corrupt or incomplete, regenerate it"},
+	{proof.ErrSignalNotSet, "check --app is the source bridge, and the message was sent at or before --block"},
+}
+
+// VerifyProof checks a user supplied signal proof against the source chain, reporting the step
+// it fails at, i.e.
+// `relayer verify-proof --signal 0x... --proof 0x... --block 100`
+func VerifyProof(args []string) {
+	_ = godotenv.Load()
+
+	f, err := parseVerifyProofFlags(args, os.Getenv)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	rpcClient, err := dialRPC(f.layer, f.rpcURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer rpcClient.Close()
+
+	ctx := context.Background()
+
+	chainID, err := rpcClient.ChainID(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	f.opts.ChainID = chainID.Uint64()
+
+	f.opts.SignalServiceVersion, err = proof.DetectSignalServiceVersion(
+		ctx,
+		rpcClient,
+		f.opts.SignalServiceAddress,
+		f.opts.ChainID,
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	steps, err := proof.VerifySignalProof(ctx, rpcClient, f.opts)
+
+	printVerifyProofReport(os.Stdout, steps, err)
+
+	if err != nil {
+		os.Exit(1)
+	}
+}
+
+// parseVerifyProofFlags parses the verify-proof flags. --layer is the chain the message was sent
+// from, and picks the env vars the RPC url and addresses default to.
+func parseVerifyProofFlags(args []string, getenv func(string) string) (verifyProofFlags, error) {
+	fs := flag.NewFlagSet("verify-proof", flag.ContinueOnError)
+
+	signal := fs.String("signal", "", "the signal to verify, the message hash for bridge messages")
+	encodedProof := fs.String("proof", "", "the encoded signal proof")
+	block := fs.Int64("block", 0, "block to verify against, defaults to the height in the proof")
+	layer := fs.String("layer", string(relayer.L1), "layer the message was sent from, l1 or l2")
+	rpcURL := fs.String("rpc-url", "", "source chain rpc url, defaults to <LAYER>_RPC_URL")
+	app := fs.String("app", "", "address that sent the signal, defaults to <LAYER>_BRIDGE_ADDRESS")
+	signalService := fs.String(
+		"signal-service",
+		"",
+		"source chain SignalService address, defaults to <LAYER>_SIGNAL_SERVICE_ADDRESS",
+	)
+
+	if err := fs.Parse(args); err != nil {
+		return verifyProofFlags{}, err
+	}
+
+	if relayer.Layer(*layer) != relayer.L1 && relayer.Layer(*layer) != relayer.L2 {
+		return verifyProofFlags{}, errors.Errorf("invalid layer %v, must be l1 or l2", *layer)
+	}
+
+	prefix := strings.ToUpper(*layer)
+
+	withDefault := func(v string, env string) (string, error) {
+		if v != "" {
+			return v, nil
+		}
+
+		if v = getenv(prefix + env); v == "" {
+			return "", errors.Errorf("missing flag or env var %v", prefix+env)
+		}
+
+		return v, nil
+	}
+
+	f := verifyProofFlags{layer: relayer.Layer(*layer)}
+
+	var err error
+
+	if f.rpcURL, err = withDefault(*rpcURL, "_RPC_URL"); err != nil {
+		return verifyProofFlags{}, err
+	}
+
+	if *app, err = withDefault(*app, "_BRIDGE_ADDRESS"); err != nil {
+		return verifyProofFlags{}, err
+	}
+
+	if *signalService, err = withDefault(*signalService, "_SIGNAL_SERVICE_ADDRESS"); err != nil {
+		return verifyProofFlags{}, err
+	}
+
+	for name, addr := range map[string]string{"app": *app, "signal-service": *signalService} {
+		if !common.IsHexAddress(addr) {
+			return verifyProofFlags{}, errors.Errorf("invalid %v address %v", name, addr)
+		}
+	}
+
+	signalBytes, err := hexutil.Decode(*signal)
+	if err != nil || len(signalBytes) != 32 {
+		return verifyProofFlags{}, errors.Errorf("--signal must be 32 hex encoded bytes, got %q", *signal)
+	}
+
+	f.opts.EncodedProof, err = hexutil.Decode(*encodedProof)
+	if err != nil || len(f.opts.EncodedProof) == 0 {
+		return verifyProofFlags{}, errors.Errorf("--proof must be hex encoded, got %q", *encodedProof)
+	}
+
+	copy(f.opts.Signal[:], signalBytes)
+	f.opts.App = common.HexToAddress(*app)
+	f.opts.SignalServiceAddress = common.HexToAddress(*signalService)
+
+	if *block > 0 {
+		f.opts.BlockNumber = big.NewInt(*block)
+	}
+
+	return f, nil
+}
+
+// printVerifyProofReport writes the steps that passed, then the one that failed, if any,
+// with a hint at what to check.
+func printVerifyProofReport(w io.Writer, steps []proof.VerifyStep, err error) {
+	for _, s := range steps {
+		fmt.Fprintf(w, "ok    %v: %v\n", s.Name, s.Detail)
+	}
+
+	if err == nil {
+		fmt.Fprintln(w, "proof is valid")
+
+		return
+	}
+
+	fmt.Fprintf(w, "FAIL  %v\n", err)
+
+	for _, h := range verifyProofHints {
+		if errors.Is(err, h.err) {
+			fmt.Fprintf(w, "      %v\n", h.hint)
+
+			return
+		}
+	}
+}
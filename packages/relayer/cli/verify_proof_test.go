@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/proof"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var verifyProofEnv = map[string]string{
+	"L1_RPC_URL":                "http://l1",
+	"L1_BRIDGE_ADDRESS":         "0xBb7a150E1247Da7B4c339f0997Bb18A4038147Af",
+	"L1_SIGNAL_SERVICE_ADDRESS": "0x1B80c7bCCEF6D6BEfB62Db052b3E3D782c566a4c",
+	"L2_RPC_URL":                "http://l2",
+	"L2_BRIDGE_ADDRESS":         "0x1000777700000000000000000000000000000004",
+	"L2_SIGNAL_SERVICE_ADDRESS": "0x1000777700000000000000000000000000000007",
+}
+
+func Test_parseVerifyProofFlags(t *testing.T) {
+	signal := "0x0100000000000000000000000000000000000000000000000000000000000000"
+
+	tests := []struct {
+		name              string
+		args              []string
+		wantRPCURL        string
+		wantApp           common.Address
+		wantSignalService common.Address
+		wantBlock         *big.Int
+		wantErr           bool
+	}{
+		{
+			"envDefaults",
+			[]string{"--signal", signal, "--proof", "0x01"},
+			"http://l1",
+			common.HexToAddress(verifyProofEnv["L1_BRIDGE_ADDRESS"]),
+			common.HexToAddress(verifyProofEnv["L1_SIGNAL_SERVICE_ADDRESS"]),
+			nil,
+			false,
+		},
+		{
+			"l2WithBlock",
+			[]string{"--signal", signal, "--proof", "0x01", "--layer", "l2", "--block", "100"},
+			"http://l2",
+			common.HexToAddress(verifyProofEnv["L2_BRIDGE_ADDRESS"]),
+			common.HexToAddress(verifyProofEnv["L2_SIGNAL_SERVICE_ADDRESS"]),
+			big.NewInt(100),
+			false,
+		},
+		{
+			"flagsOverrideEnv",
+			[]string{
+				"--signal", signal,
+				"--proof", "0x01",
+				"--rpc-url", "http://other",
+				"--app", "0x0000000000000000000000000000000000000001",
+				"--signal-service", "0x0000000000000000000000000000000000000002",
+			},
+			"http://other",
+			common.HexToAddress("0x0000000000000000000000000000000000000001"),
+			common.HexToAddress("0x0000000000000000000000000000000000000002"),
+			nil,
+			false,
+		},
+		{
+			"shortSignal",
+			[]string{"--signal", "0x01", "--proof", "0x01"},
+			"",
+			common.Address{},
+			common.Address{},
+			nil,
+			true,
+		},
+		{
+			"noProof",
+			[]string{"--signal", signal},
+			"",
+			common.Address{},
+			common.Address{},
+			nil,
+			true,
+		},
+		{
+			"invalidLayer",
+			[]string{"--signal", signal, "--proof", "0x01", "--layer", "l3"},
+			"",
+			common.Address{},
+			common.Address{},
+			nil,
+			true,
+		},
+		{
+			"invalidApp",
+			[]string{"--signal", signal, "--proof", "0x01", "--app", "bridge"},
+			"",
+			common.Address{},
+			common.Address{},
+			nil,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := parseVerifyProofFlags(tt.args, func(k string) string { return verifyProofEnv[k] })
+			assert.Equal(t, tt.wantErr, err != nil)
+
+			if tt.wantErr {
+				return
+			}
+
+			assert.Equal(t, tt.wantRPCURL, f.rpcURL)
+			assert.Equal(t, tt.wantApp, f.opts.App)
+			assert.Equal(t, tt.wantSignalService, f.opts.SignalServiceAddress)
+			assert.Equal(t, tt.wantBlock, f.opts.BlockNumber)
+			assert.Equal(t, [32]byte{0x1}, f.opts.Signal)
+			assert.Equal(t, []byte{0x1}, f.opts.EncodedProof)
+		})
+	}
+}
+
+func Test_printVerifyProofReport(t *testing.T) {
+	steps := []proof.VerifyStep{{Name: "decode proof", Detail: "height 5, 3 storage proof nodes"}}
+
+	var w bytes.Buffer
+
+	printVerifyProofReport(&w, steps, errors.Wrap(proof.ErrSignalNotSet, "slot 0x01 is empty"))
+
+	assert.Equal(
+		t,
+		"ok    decode proof: height 5, 3 storage proof nodes\n"+
+			"FAIL  slot 0x01 is empty: signal not set\n"+
+			"      check --app is the source bridge, and the message was sent at or before --block\n",
+		w.String(),
+	)
+
+	w.Reset()
+
+	printVerifyProofReport(&w, steps, nil)
+
+	assert.Equal(t, "ok    decode proof: height 5, 3 storage proof nodes\nproof is valid\n", w.String())
+}
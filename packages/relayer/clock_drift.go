@@ -0,0 +1,12 @@
+package relayer
+
+import (
+	"math/big"
+	"time"
+)
+
+// ClockDrift reports how far ahead of the local clock a chain's latest block timestamp is,
+// negative when it's behind, and whether it's been measured yet
+type ClockDrift interface {
+	Drift(chainID *big.Int) (time.Duration, bool)
+}
@@ -0,0 +1,8 @@
+package clockdrift
+
+import "github.com/pkg/errors"
+
+var (
+	ErrNoClients       = errors.New("clockdrift: at least one client is required")
+	ErrInvalidInterval = errors.New("clockdrift: interval must be positive")
+)
@@ -0,0 +1,154 @@
+package clockdrift
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+)
+
+// Client is a chain's RPC connection, as the monitor samples it
+type Client interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// Monitor samples each chain's latest block timestamp every interval, and compares it to the
+// local clock. A chain whose blocks drift further than the warn threshold from it may have
+// stalled, or be producing blocks with a skewed clock.
+type Monitor struct {
+	clients       []Client
+	interval      time.Duration
+	warnThreshold time.Duration
+	now           func() time.Time
+
+	mu sync.RWMutex
+	// drifts is the drift last sampled for each chain, by chain ID
+	drifts map[string]time.Duration
+	// chainIDs caches each client's chain ID, by its index in clients
+	chainIDs map[int]*big.Int
+}
+
+type NewMonitorOpts struct {
+	Clients  []Client
+	Interval time.Duration
+	// WarnThreshold is how far a chain can drift from the local clock before it's warned about,
+	// never if <= 0
+	WarnThreshold time.Duration
+}
+
+func NewMonitor(opts NewMonitorOpts) (*Monitor, error) {
+	if len(opts.Clients) == 0 {
+		return nil, ErrNoClients
+	}
+
+	if opts.Interval <= 0 {
+		return nil, ErrInvalidInterval
+	}
+
+	return &Monitor{
+		clients:       opts.Clients,
+		interval:      opts.Interval,
+		warnThreshold: opts.WarnThreshold,
+		now:           time.Now,
+		drifts:        make(map[string]time.Duration),
+		chainIDs:      make(map[int]*big.Int),
+	}, nil
+}
+
+// Start samples every chain straight away, then every interval until ctx is done
+func (m *Monitor) Start(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		m.sampleAll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Drift returns how far ahead of the local clock chainID's latest block timestamp was when
+// last sampled, and false if it hasn't been yet
+func (m *Monitor) Drift(chainID *big.Int) (time.Duration, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	drift, ok := m.drifts[chainID.String()]
+
+	return drift, ok
+}
+
+// sampleAll samples each chain, leaving the last drift of any that can't be reached as it was
+func (m *Monitor) sampleAll(ctx context.Context) {
+	for i := range m.clients {
+		if err := m.sample(ctx, i); err != nil {
+			log.Warnf("clock drift: %v", err)
+		}
+	}
+}
+
+func (m *Monitor) sample(ctx context.Context, i int) error {
+	chainID, err := m.chainID(ctx, i)
+	if err != nil {
+		return err
+	}
+
+	header, err := m.clients[i].HeaderByNumber(ctx, nil)
+	if err != nil {
+		return errors.Wrapf(err, "chain %v, HeaderByNumber", chainID)
+	}
+
+	drift := time.Unix(int64(header.Time), 0).Sub(m.now())
+
+	m.mu.Lock()
+	m.drifts[chainID.String()] = drift
+	m.mu.Unlock()
+
+	relayer.ChainClockDrift.WithLabelValues(chainID.String()).Set(drift.Seconds())
+
+	if m.warnThreshold > 0 && (drift > m.warnThreshold || drift < -m.warnThreshold) {
+		log.Warnf(
+			"clock drift: chain %v's latest block %v is %v from the local clock, beyond %v. "+
+				"the chain may have stalled, or either clock is skewed",
+			chainID,
+			header.Number,
+			drift,
+			m.warnThreshold,
+		)
+	}
+
+	return nil
+}
+
+// chainID returns the chain ID of the client at index i, cached once it's known
+func (m *Monitor) chainID(ctx context.Context, i int) (*big.Int, error) {
+	m.mu.RLock()
+	chainID, ok := m.chainIDs[i]
+	m.mu.RUnlock()
+
+	if ok {
+		return chainID, nil
+	}
+
+	chainID, err := m.clients[i].ChainID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "ChainID")
+	}
+
+	m.mu.Lock()
+	m.chainIDs[i] = chainID
+	m.mu.Unlock()
+
+	return chainID, nil
+}
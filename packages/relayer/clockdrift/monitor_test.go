@@ -0,0 +1,104 @@
+package clockdrift
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClient struct {
+	chainID *big.Int
+	time    uint64
+	fail    bool
+}
+
+func (c *fakeClient) ChainID(ctx context.Context) (*big.Int, error) {
+	return c.chainID, nil
+}
+
+func (c *fakeClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if c.fail {
+		return nil, errors.New("fail")
+	}
+
+	return &types.Header{Number: big.NewInt(1), Time: c.time}, nil
+}
+
+func Test_NewMonitor(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    NewMonitorOpts
+		wantErr error
+	}{
+		{
+			"success",
+			NewMonitorOpts{Clients: []Client{&fakeClient{}}, Interval: time.Second},
+			nil,
+		},
+		{
+			"noClients",
+			NewMonitorOpts{Interval: time.Second},
+			ErrNoClients,
+		},
+		{
+			"invalidInterval",
+			NewMonitorOpts{Clients: []Client{&fakeClient{}}},
+			ErrInvalidInterval,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewMonitor(tt.opts)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_sampleAll(t *testing.T) {
+	now := time.Unix(1000, 0)
+
+	l1 := &fakeClient{chainID: big.NewInt(1), time: 988}
+	l2 := &fakeClient{chainID: big.NewInt(2), time: 1003}
+
+	m, err := NewMonitor(NewMonitorOpts{
+		Clients:       []Client{l1, l2},
+		Interval:      time.Second,
+		WarnThreshold: 10 * time.Second,
+	})
+	assert.Nil(t, err)
+
+	m.now = func() time.Time { return now }
+
+	_, ok := m.Drift(big.NewInt(1))
+	assert.False(t, ok)
+
+	m.sampleAll(context.Background())
+
+	drift, ok := m.Drift(big.NewInt(1))
+	assert.True(t, ok)
+	assert.Equal(t, -12*time.Second, drift)
+
+	drift, ok = m.Drift(big.NewInt(2))
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, drift)
+
+	// an unreachable chain keeps the drift last sampled
+	l1.fail = true
+	now = now.Add(time.Minute)
+
+	m.sampleAll(context.Background())
+
+	drift, ok = m.Drift(big.NewInt(1))
+	assert.True(t, ok)
+	assert.Equal(t, -12*time.Second, drift)
+
+	drift, ok = m.Drift(big.NewInt(2))
+	assert.True(t, ok)
+	assert.Equal(t, -57*time.Second, drift)
+}
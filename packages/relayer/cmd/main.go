@@ -3,12 +3,55 @@ package main
 import (
 	"flag"
 	"log"
+	"os"
 
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer/cli"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		cli.Export(os.Args[2:])
+
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		cli.Migrate(os.Args[2:])
+
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "verify-proof" {
+		cli.VerifyProof(os.Args[2:])
+
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "validate-proof" {
+		cli.ValidateProof(os.Args[2:])
+
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "message-hash" {
+		cli.MessageHash(os.Args[2:])
+
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "reindex-block" {
+		cli.ReindexBlock(os.Args[2:])
+
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "selftest" {
+		cli.SelfTest(os.Args[2:])
+
+		return
+	}
+
 	modePtr := flag.String("mode", string(relayer.SyncMode), `mode to run in. 
 	options:
 	  sync: continue syncing from previous block
@@ -28,6 +71,7 @@ func main() {
 	  filter: only filter previous messages
 	  subscribe: only subscribe to new messages
 	  filter-and-subscribe: catch up on all previous messages, then subscribe to new messages
+	  poll: catch up on all previous messages, then poll the latest block for new messages
 	`)
 
 	httpOnlyPtr := flag.Bool("http-only", false, `only run an http server and don't index blocks. 
@@ -42,6 +86,24 @@ func main() {
 	  false:
 	`)
 
+	processingPausedPtr := flag.Bool("processing-paused", false, `start with processing paused. 
+	options:
+	  true: index messages, and process them once resumed via the admin API
+	  false: index and process messages
+	`)
+
+	orderedDeliveryPtr := flag.Bool("ordered-delivery", false, `process each sender's messages in order. 
+	options:
+	  true: only process a sender's message to a chain once the ones it sent there before are done
+	  false: process messages as soon as they can be
+	`)
+
+	reprocessFromPtr := flag.String("reprocess-from", "", `process already indexed messages once more, then exit. 
+	options:
+	  a block number: reprocess the new messages indexed from that source block on, leaving the checkpoint alone
+	  unset: run the relayer
+	`)
+
 	flag.Parse()
 
 	if !relayer.IsInSlice(relayer.Mode(*modePtr), relayer.Modes) {
@@ -52,11 +114,23 @@ func main() {
 		log.Fatal("mode not valid")
 	}
 
+	if *reprocessFromPtr != "" {
+		cli.ReprocessFrom(
+			relayer.Layer(*layersPtr),
+			*reprocessFromPtr,
+			relayer.ProfitableOnly(*profitableOnlyPtr),
+		)
+
+		return
+	}
+
 	cli.Run(
 		relayer.Mode(*modePtr),
 		relayer.WatchMode(*watchModePtr),
 		relayer.Layer(*layersPtr),
 		relayer.HTTPOnly(*httpOnlyPtr),
 		relayer.ProfitableOnly(*profitableOnlyPtr),
+		relayer.ProcessingPaused(*processingPausedPtr),
+		relayer.OrderedDelivery(*orderedDeliveryPtr),
 	)
 }
@@ -0,0 +1,160 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	ConfirmationTagFinalized = "finalized"
+	ConfirmationTagSafe      = "safe"
+)
+
+// ConfirmationStrategy is when a block is treated as final: once it's buried under Blocks blocks,
+// or once the block the node tags Tag has reached it. Nodes that don't support Tag fall back to
+// Blocks.
+type ConfirmationStrategy struct {
+	// Tag is ConfirmationTagFinalized or ConfirmationTagSafe, or empty to count blocks
+	Tag    string
+	Blocks uint64
+}
+
+// ParseConfirmationStrategy parses "blocks:N", "finalized" or "safe". Empty counts `blocks`
+// blocks, which the tags also fall back to.
+func ParseConfirmationStrategy(s string, blocks uint64) (ConfirmationStrategy, error) {
+	switch {
+	case s == "":
+		return ConfirmationStrategy{Blocks: blocks}, nil
+	case s == ConfirmationTagFinalized || s == ConfirmationTagSafe:
+		return ConfirmationStrategy{Tag: s, Blocks: blocks}, nil
+	case strings.HasPrefix(s, "blocks:"):
+		n, err := strconv.ParseUint(strings.TrimPrefix(s, "blocks:"), 10, 64)
+		if err != nil || n == 0 {
+			return ConfirmationStrategy{}, errors.Wrapf(ErrInvalidConfirmationStrategy, "strategy: %v", s)
+		}
+
+		return ConfirmationStrategy{Blocks: n}, nil
+	default:
+		return ConfirmationStrategy{}, errors.Wrapf(ErrInvalidConfirmationStrategy, "strategy: %v", s)
+	}
+}
+
+func (s ConfirmationStrategy) String() string {
+	if s.Tag != "" {
+		return s.Tag
+	}
+
+	return fmt.Sprintf("blocks:%v", s.Blocks)
+}
+
+type blockConfirmer interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+type strategyConfirmer interface {
+	confirmer
+	blockConfirmer
+}
+
+// ConfirmedHeight is the latest block treated as final, and false if none are yet.
+func (s ConfirmationStrategy) ConfirmedHeight(ctx context.Context, c blockConfirmer) (uint64, bool, error) {
+	if s.Tag != "" {
+		number := big.NewInt(int64(rpc.FinalizedBlockNumber))
+		if s.Tag == ConfirmationTagSafe {
+			number = big.NewInt(int64(rpc.SafeBlockNumber))
+		}
+
+		header, err := c.HeaderByNumber(ctx, number)
+		if err == nil && header != nil && header.Number != nil && header.Number.Sign() >= 0 {
+			return header.Number.Uint64(), true, nil
+		}
+
+		// nodes that predate the tags reject them, or answer with null
+		var rpcErr rpc.Error
+		if err != nil && !errors.As(err, &rpcErr) && !errors.Is(err, ethereum.NotFound) {
+			return 0, false, errors.Wrapf(err, "c.HeaderByNumber(%v)", s.Tag)
+		}
+
+		log.Debugf("%v tag not supported, falling back to %v confirmations: %v", s.Tag, s.Blocks, err)
+	}
+
+	latest, err := c.BlockNumber(ctx)
+	if err != nil {
+		return 0, false, errors.Wrap(err, "c.BlockNumber")
+	}
+
+	if latest < s.Blocks {
+		return 0, false, nil
+	}
+
+	return latest - s.Blocks, true, nil
+}
+
+// WaitConfirmed won't return before txHash's block is confirmed by strategy on the chain.
+func WaitConfirmed(
+	ctx context.Context,
+	c strategyConfirmer,
+	strategy ConfirmationStrategy,
+	txHash common.Hash,
+) error {
+	if strategy.Tag == "" {
+		return WaitConfirmations(ctx, c, strategy.Blocks, txHash)
+	}
+
+	log.Infof("txHash %v beginning waiting for the %v block to reach it", txHash.Hex(), strategy.Tag)
+
+	ticker := time.NewTicker(10 * time.Second)
+
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			receipt, err := c.TransactionReceipt(ctx, txHash)
+			if err != nil {
+				if err == ethereum.NotFound {
+					continue
+				}
+
+				log.Errorf("txHash: %v encountered error getting receipt: %v", txHash.Hex(), err)
+
+				return err
+			}
+
+			confirmed, ok, err := strategy.ConfirmedHeight(ctx, c)
+			if err != nil {
+				return err
+			}
+
+			if !ok || confirmed < receipt.BlockNumber.Uint64() {
+				log.Infof(
+					"txHash: %v in block %v waiting for the %v block, at %v",
+					txHash.Hex(),
+					receipt.BlockNumber,
+					strategy.Tag,
+					confirmed,
+				)
+
+				continue
+			}
+
+			log.Infof("txHash %v reached by the %v block %v, done", txHash.Hex(), strategy.Tag, confirmed)
+
+			return nil
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package relayer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+// tagConfirmer is at block 100, and answers the finalized and safe tags with its fields,
+// or err if set
+type tagConfirmer struct {
+	finalized uint64
+	safe      uint64
+	err       error
+}
+
+func (c *tagConfirmer) BlockNumber(ctx context.Context) (uint64, error) {
+	return 100, nil
+}
+
+func (c *tagConfirmer) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	switch number.Int64() {
+	case int64(rpc.FinalizedBlockNumber):
+		return &types.Header{Number: new(big.Int).SetUint64(c.finalized)}, nil
+	case int64(rpc.SafeBlockNumber):
+		return &types.Header{Number: new(big.Int).SetUint64(c.safe)}, nil
+	}
+
+	return &types.Header{Number: number}, nil
+}
+
+type unsupportedTagError struct{}
+
+func (e *unsupportedTagError) Error() string {
+	return "invalid argument 0: hex string without 0x prefix"
+}
+
+func (e *unsupportedTagError) ErrorCode() int { return -32602 }
+
+func Test_ParseConfirmationStrategy(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    ConfirmationStrategy
+		wantErr bool
+	}{
+		{"", ConfirmationStrategy{Blocks: 15}, false},
+		{"blocks:3", ConfirmationStrategy{Blocks: 3}, false},
+		{"finalized", ConfirmationStrategy{Tag: ConfirmationTagFinalized, Blocks: 15}, false},
+		{"safe", ConfirmationStrategy{Tag: ConfirmationTagSafe, Blocks: 15}, false},
+		{"blocks:0", ConfirmationStrategy{}, true},
+		{"blocks:", ConfirmationStrategy{}, true},
+		{"latest", ConfirmationStrategy{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParseConfirmationStrategy(tt.s, 15)
+			assert.Equal(t, tt.wantErr, errors.Is(err, ErrInvalidConfirmationStrategy))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_ConfirmationStrategy_ConfirmedHeight(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy ConfirmationStrategy
+		c        *tagConfirmer
+		want     uint64
+		wantOK   bool
+		wantErr  bool
+	}{
+		{"blocks", ConfirmationStrategy{Blocks: 15}, &tagConfirmer{}, 85, true, false},
+		{"noneBuriedYet", ConfirmationStrategy{Blocks: 150}, &tagConfirmer{}, 0, false, false},
+		{"finalized", ConfirmationStrategy{Tag: "finalized", Blocks: 15}, &tagConfirmer{finalized: 64}, 64, true, false},
+		{"safe", ConfirmationStrategy{Tag: "safe", Blocks: 15}, &tagConfirmer{safe: 90}, 90, true, false},
+		{
+			"tagUnsupported",
+			ConfirmationStrategy{Tag: "finalized", Blocks: 15},
+			&tagConfirmer{err: &unsupportedTagError{}},
+			85,
+			true,
+			false,
+		},
+		{
+			"tagNotFound",
+			ConfirmationStrategy{Tag: "finalized", Blocks: 15},
+			&tagConfirmer{err: ethereum.NotFound},
+			85,
+			true,
+			false,
+		},
+		{
+			"connectionError",
+			ConfirmationStrategy{Tag: "finalized", Blocks: 15},
+			&tagConfirmer{err: errors.New("connection refused")},
+			0,
+			false,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok, err := tt.strategy.ConfirmedHeight(context.Background(), tt.c)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
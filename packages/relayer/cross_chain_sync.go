@@ -0,0 +1,51 @@
+package relayer
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	EventNameCrossChainSynced = "CrossChainSynced"
+)
+
+// CrossChainSync is a database model mirroring a CrossChainSynced event, recording
+// that a source chain block has been synced to the chain with ChainID.
+type CrossChainSync struct {
+	ID         int       `json:"id"`
+	ChainID    int64     `json:"chainID"`
+	SrcHeight  uint64    `json:"srcHeight"`
+	BlockHash  string    `json:"blockHash"`
+	SignalRoot string    `json:"signalRoot"`
+	SyncedAt   time.Time `json:"syncedAt"`
+	DestTxHash string    `json:"destTxHash"`
+}
+
+// SyncedHeight is published when the chain with ChainID syncs the source chain's block SrcHeight,
+// whose hash is BlockHash.
+type SyncedHeight struct {
+	ChainID   *big.Int
+	SrcHeight uint64
+	BlockHash common.Hash
+}
+
+// SaveCrossChainSyncOpts is required to store a new CrossChainSync
+type SaveCrossChainSyncOpts struct {
+	ChainID    *big.Int
+	SrcHeight  uint64
+	BlockHash  common.Hash
+	SignalRoot common.Hash
+	SyncedAt   time.Time
+	DestTxHash common.Hash
+}
+
+// CrossChainSyncRepository is used to interact with mirrored CrossChainSynced events in the store
+type CrossChainSyncRepository interface {
+	Save(ctx context.Context, opts SaveCrossChainSyncOpts) error
+	LatestSyncedHeight(ctx context.Context, chainID *big.Int) (uint64, error)
+	FindSyncByHeight(ctx context.Context, chainID *big.Int, srcHeight uint64) (*CrossChainSync, error)
+	FindLatestSyncBetween(ctx context.Context, chainID *big.Int, fromHeight, toHeight uint64) (*CrossChainSync, error)
+}
@@ -1,7 +1,9 @@
 package relayer
 
 import (
+	"context"
 	"database/sql"
+	"time"
 
 	"github.com/cyberhorsey/errors"
 	"gorm.io/gorm"
@@ -16,10 +18,40 @@ type DBConnectionOpts struct {
 	Password string
 	Host     string
 	Database string
-	OpenFunc func(dsn string) (DB, error)
+	// MaxIdleConns, MaxOpenConns and ConnMaxLifetime size the connection pool,
+	// defaults are used when they are zero.
+	MaxIdleConns    int
+	MaxOpenConns    int
+	ConnMaxLifetime time.Duration
+	// QueryTimeout bounds every repository query, zero means no timeout.
+	QueryTimeout time.Duration
+	// ReplicaHost is an optional read replica, reached with the same credentials and
+	// database as Host, that read-only repository queries are sent to.
+	ReplicaHost string
+	// OpenFunc opens the primary at dsn, and the replica at replicaDSN unless it's empty.
+	OpenFunc func(dsn string, replicaDSN string) (DB, error)
 }
 
 type DB interface {
 	DB() (*sql.DB, error)
 	GormDB() *gorm.DB
+	// ReadGormDB is for read-only queries, the read replica if there is one, otherwise GormDB.
+	ReadGormDB() *gorm.DB
+	// QueryTimeout is how long a single repository query may take, zero means no timeout.
+	QueryTimeout() time.Duration
+}
+
+type primaryReadsKey struct{}
+
+// WithPrimaryReads pins the repository reads made with ctx to the primary, for callers that
+// read back their own writes and can't tolerate replica lag.
+func WithPrimaryReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryReadsKey{}, true)
+}
+
+// PrimaryReads reports whether ctx was pinned to the primary with WithPrimaryReads.
+func PrimaryReads(ctx context.Context) bool {
+	pinned, _ := ctx.Value(primaryReadsKey{}).(bool)
+
+	return pinned
 }
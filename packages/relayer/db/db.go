@@ -2,12 +2,15 @@ package db
 
 import (
 	"database/sql"
+	"time"
 
 	"gorm.io/gorm"
 )
 
 type DB struct {
-	gormdb *gorm.DB
+	gormdb       *gorm.DB
+	replica      *gorm.DB
+	queryTimeout time.Duration
 }
 
 func (db *DB) DB() (*sql.DB, error) {
@@ -18,6 +21,32 @@ func (db *DB) GormDB() *gorm.DB {
 	return db.gormdb
 }
 
+func (db *DB) ReadGormDB() *gorm.DB {
+	if db.replica != nil {
+		return db.replica
+	}
+
+	return db.gormdb
+}
+
+func (db *DB) QueryTimeout() time.Duration {
+	return db.queryTimeout
+}
+
+// WithQueryTimeout sets the timeout repositories apply to each query.
+func (db *DB) WithQueryTimeout(timeout time.Duration) *DB {
+	db.queryTimeout = timeout
+
+	return db
+}
+
+// WithReplica sends read-only queries to replica instead of the primary.
+func (db *DB) WithReplica(replica *gorm.DB) *DB {
+	db.replica = replica
+
+	return db
+}
+
 func New(gormdb *gorm.DB) *DB {
 	return &DB{
 		gormdb: gormdb,
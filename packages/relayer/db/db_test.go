@@ -2,6 +2,7 @@ package db
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
@@ -11,4 +12,20 @@ func Test_DB(t *testing.T) {
 	d := New(&gorm.DB{})
 
 	assert.Equal(t, &gorm.DB{}, d.GormDB())
+	assert.Same(t, d.GormDB(), d.ReadGormDB())
+	assert.Equal(t, time.Duration(0), d.QueryTimeout())
+}
+
+func Test_WithQueryTimeout(t *testing.T) {
+	d := New(&gorm.DB{}).WithQueryTimeout(5 * time.Second)
+
+	assert.Equal(t, 5*time.Second, d.QueryTimeout())
+}
+
+func Test_WithReplica(t *testing.T) {
+	replica := &gorm.DB{}
+	d := New(&gorm.DB{}).WithReplica(replica)
+
+	assert.Same(t, replica, d.ReadGormDB())
+	assert.NotSame(t, replica, d.GormDB())
 }
@@ -6,6 +6,10 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
+// BlockToBlockHeader converts a block's header to the BlockHeader our contracts hash. Fields a
+// block's fork doesn't have are left zero: BaseFeePerGas before London, and WithdrawalsRoot
+// before Shanghai. LibBlockHeader hashes a header without them when they're zero, so the
+// hash matches the block's in either era.
 func BlockToBlockHeader(block *types.Block) BlockHeader {
 	baseFee := block.BaseFee()
 	if baseFee == nil {
@@ -38,3 +42,36 @@ func BlockToBlockHeader(block *types.Block) BlockHeader {
 		WithdrawalsRoot:  withdrawalsRoot,
 	}
 }
+
+// Hash is the block hash of the header, the way LibBlockHeader hashes it: a zero BaseFeePerGas
+// is a pre-London header without it, and a zero WithdrawalsRoot a pre-Shanghai header without it.
+func (h BlockHeader) Hash() common.Hash {
+	header := &types.Header{
+		ParentHash:  h.ParentHash,
+		UncleHash:   h.OmmersHash,
+		Coinbase:    h.Beneficiary,
+		Root:        h.StateRoot,
+		TxHash:      h.TransactionsRoot,
+		ReceiptHash: h.ReceiptsRoot,
+		Bloom:       bytesToLogsBloom(h.LogsBloom),
+		Difficulty:  h.Difficulty,
+		Number:      h.Height,
+		GasLimit:    h.GasLimit,
+		GasUsed:     h.GasUsed,
+		Time:        h.Timestamp,
+		Extra:       h.ExtraData,
+		MixDigest:   h.MixHash,
+		Nonce:       types.EncodeNonce(h.Nonce),
+	}
+
+	if h.BaseFeePerGas != nil && h.BaseFeePerGas.Sign() != 0 {
+		header.BaseFee = h.BaseFeePerGas
+
+		if h.WithdrawalsRoot != relayer.ZeroHash {
+			withdrawalsRoot := common.Hash(h.WithdrawalsRoot)
+			header.WithdrawalsHash = &withdrawalsRoot
+		}
+	}
+
+	return header.Hash()
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
 	"gopkg.in/go-playground/assert.v1"
 )
 
@@ -146,3 +147,56 @@ func Test_BlockToBlockHeader_Shanghai(t *testing.T) {
 
 	assert.Equal(t, e, h)
 }
+
+// mainnetGenesis is Ethereum mainnet's genesis header, a pre-London header without a base fee.
+var mainnetGenesis = &types.Header{
+	ParentHash:  common.Hash{},
+	UncleHash:   types.EmptyUncleHash,
+	Coinbase:    common.Address{},
+	Root:        common.HexToHash("0xd7f8974fb5ac78d9ac099b9ad5018bedc2ce0a72dad1827a1709da30580f0544"),
+	TxHash:      types.EmptyRootHash,
+	ReceiptHash: types.EmptyRootHash,
+	Bloom:       types.Bloom{},
+	Difficulty:  big.NewInt(17179869184),
+	Number:      big.NewInt(0),
+	GasLimit:    5000,
+	GasUsed:     0,
+	Time:        0,
+	Extra:       common.FromHex("0x11bbe8db4e347b4e8c937c1c8370e4b5ed33adb3db69cbdb7a38e1e50b1b82fa"),
+	MixDigest:   common.Hash{},
+	Nonce:       types.EncodeNonce(66),
+}
+
+// londonBlockRLP is go-ethereum's EIP-1559 block encoding test vector, with a 1 gwei base fee.
+var londonBlockRLP = common.FromHex("f9030bf901fea083cafc574e1f51ba9dc0568fc617a08ea2429fb384059c972f13b19fa1c8dd55a01dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347948888f1f195afa192cfee860698584c030f4c9db1a0ef1552a40b7165c3cd773806b9e0c165b75356e0314bf0706f279c729f51e017a05fe50b260da6308036625b850b5d6ced6d0a9f814c0688bc91ffb7b7a3a54b67a0bc37d79753ad738a6dac4921e57392f145d8887476de3f783dfa7edae9283e52b90100000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000008302000001832fefd8825208845506eb0780a0bd4472abb6659ebe3ee06ee4d7b72a00a9f4d001caca51342001075469aff49888a13a5a8c8f2bb1c4843b9aca00f90106f85f800a82c35094095e7baea6a6c7c4c2dfeb977efac326af552d870a801ba09bea4c4daac7c7c52e093e6a4c35dbbcf8856f1af7b059ba20253e70848d094fa08a8fae537ce25ed8cb5af9adac3f141af69bd515bd2ba031522df09b97dd72b1b8a302f8a0018080843b9aca008301e24194095e7baea6a6c7c4c2dfeb977efac326af552d878080f838f7940000000000000000000000000000000000000001e1a0000000000000000000000000000000000000000000000000000000000000000080a0fe38ca4e44a30002ac54af7cf922a6ac2ba11b7d22f548e8ecb3f51f41cb31b0a06de6a5cbae13c0c856e33acf021b51819636cfc009d39eafb9f606d546e305a8c0") // nolint: lll
+
+func Test_BlockHeader_Hash_PreLondon(t *testing.T) {
+	h := BlockToBlockHeader(types.NewBlockWithHeader(mainnetGenesis))
+
+	assert.Equal(t, common.Big0, h.BaseFeePerGas)
+	assert.Equal(t, common.HexToHash("0xd4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3"), h.Hash())
+}
+
+func Test_BlockHeader_Hash_London(t *testing.T) {
+	var block types.Block
+
+	err := rlp.DecodeBytes(londonBlockRLP, &block)
+	assert.Equal(t, nil, err)
+
+	h := BlockToBlockHeader(&block)
+
+	assert.Equal(t, big.NewInt(1000000000), h.BaseFeePerGas)
+	assert.Equal(t, common.HexToHash("0xc7252048cd273fe0dac09650027d07f0e3da4ee0675ebbb26627cea92729c372"), h.Hash())
+}
+
+func Test_BlockHeader_Hash_Shanghai(t *testing.T) {
+	withdrawalsRoot := types.EmptyRootHash
+
+	header := types.CopyHeader(mainnetGenesis)
+	header.BaseFee = big.NewInt(10)
+	header.WithdrawalsHash = &withdrawalsRoot
+
+	h := BlockToBlockHeader(types.NewBlockWithHeader(header))
+
+	assert.Equal(t, header.Hash(), h.Hash())
+}
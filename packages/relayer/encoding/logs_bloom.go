@@ -11,3 +11,14 @@ func logsBloomToBytes(bloom types.Bloom) [8][32]byte {
 
 	return b
 }
+
+// bytesToLogsBloom is the inverse of logsBloomToBytes.
+func bytesToLogsBloom(b [8][32]byte) types.Bloom {
+	bloom := types.Bloom{}
+
+	for i := 0; i < 8; i++ {
+		copy(bloom[i*32:(i+1)*32], b[i][:])
+	}
+
+	return bloom
+}
@@ -30,3 +30,9 @@ func Test_logsBloomToBytes(t *testing.T) {
 		index += 32
 	}
 }
+
+func Test_bytesToLogsBloom(t *testing.T) {
+	testLogsBloom := types.BytesToBloom(randomBytes(256))
+
+	assert.Equal(t, testLogsBloom, bytesToLogsBloom(logsBloomToBytes(testLogsBloom)))
+}
@@ -0,0 +1,56 @@
+package encoding
+
+import (
+	"math/big"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+var messageT, _ = abi.NewType("tuple", "", []abi.ArgumentMarshaling{
+	{Name: "id", Type: "uint256"},
+	{Name: "sender", Type: "address"},
+	{Name: "srcChainId", Type: "uint256"},
+	{Name: "destChainId", Type: "uint256"},
+	{Name: "owner", Type: "address"},
+	{Name: "to", Type: "address"},
+	{Name: "refundAddress", Type: "address"},
+	{Name: "depositValue", Type: "uint256"},
+	{Name: "callValue", Type: "uint256"},
+	{Name: "processingFee", Type: "uint256"},
+	{Name: "gasLimit", Type: "uint256"},
+	{Name: "data", Type: "bytes"},
+	{Name: "memo", Type: "string"},
+})
+
+// messageArgs are the arguments the bridge abi encodes a message as to hash it
+var messageArgs = abi.Arguments{{Type: messageT}}
+
+// HashMessage is the message's hash the way the bridge computes it, keccak256(abi.encode(message)),
+// which is the msgHash its events are indexed by and its signal.
+func HashMessage(message bridge.IBridgeMessage) (common.Hash, error) {
+	// packing a nil uint256 panics rather than erroring
+	for _, v := range []*big.Int{
+		message.Id,
+		message.SrcChainId,
+		message.DestChainId,
+		message.DepositValue,
+		message.CallValue,
+		message.ProcessingFee,
+		message.GasLimit,
+	} {
+		if v == nil {
+			return common.Hash{}, errors.New("message has a nil uint256 field")
+		}
+	}
+
+	encoded, err := messageArgs.Pack(message)
+	if err != nil {
+		return common.Hash{}, errors.Wrap(err, "messageArgs.Pack")
+	}
+
+	return crypto.Keccak256Hash(encoded), nil
+}
@@ -0,0 +1,76 @@
+package encoding
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func Test_HashMessage(t *testing.T) {
+	m := bridge.IBridgeMessage{
+		Id:            big.NewInt(1),
+		Sender:        common.HexToAddress("0x1000777700000000000000000000000000000001"),
+		SrcChainId:    big.NewInt(5),
+		DestChainId:   big.NewInt(167001),
+		Owner:         common.HexToAddress("0x1000777700000000000000000000000000000002"),
+		To:            common.HexToAddress("0x1000777700000000000000000000000000000003"),
+		RefundAddress: common.HexToAddress("0x1000777700000000000000000000000000000004"),
+		DepositValue:  big.NewInt(100),
+		CallValue:     big.NewInt(0),
+		ProcessingFee: big.NewInt(7),
+		GasLimit:      big.NewInt(140000),
+		Data:          []byte{0xde, 0xad, 0xbe, 0xef},
+		Memo:          "memo",
+	}
+
+	word := func(b []byte) []byte {
+		return common.LeftPadBytes(b, 32)
+	}
+
+	padded := func(b []byte) []byte {
+		return common.RightPadBytes(b, (len(b)+31)/32*32)
+	}
+
+	// abi.encode of the message by hand: the offset of the tuple, then its static fields, the
+	// offsets of data and memo from the start of the tuple, and their length prefixed contents
+	encoded := bytes.Join([][]byte{
+		word([]byte{0x20}),
+		word(m.Id.Bytes()),
+		word(m.Sender.Bytes()),
+		word(m.SrcChainId.Bytes()),
+		word(m.DestChainId.Bytes()),
+		word(m.Owner.Bytes()),
+		word(m.To.Bytes()),
+		word(m.RefundAddress.Bytes()),
+		word(m.DepositValue.Bytes()),
+		word(m.CallValue.Bytes()),
+		word(m.ProcessingFee.Bytes()),
+		word(m.GasLimit.Bytes()),
+		word(big.NewInt(13 * 32).Bytes()),
+		word(big.NewInt(15 * 32).Bytes()),
+		word([]byte{byte(len(m.Data))}),
+		padded(m.Data),
+		word([]byte{byte(len(m.Memo))}),
+		padded([]byte(m.Memo)),
+	}, nil)
+
+	hash, err := HashMessage(m)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, crypto.Keccak256Hash(encoded), hash)
+
+	m.Memo = "other"
+
+	other, err := HashMessage(m)
+	assert.Equal(t, nil, err)
+	assert.NotEqual(t, hash, other)
+
+	m.Id = nil
+
+	_, err = HashMessage(m)
+	assert.NotEqual(t, nil, err)
+}
@@ -0,0 +1,33 @@
+package encoding
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/pkg/errors"
+)
+
+// receiptProofArgs are the arguments a ReceiptProof is abi encoded as, built once rather than on every call
+var receiptProofArgs = abi.Arguments{{Type: receiptProofT}}
+
+func EncodeReceiptProof(receiptProof ReceiptProof) ([]byte, error) {
+	encodedReceiptProof, err := receiptProofArgs.Pack(receiptProof)
+	if err != nil {
+		return nil, errors.Wrap(err, "receiptProofArgs.Pack")
+	}
+
+	return encodedReceiptProof, nil
+}
+
+// DecodeReceiptProof is the inverse of EncodeReceiptProof.
+func DecodeReceiptProof(encoded []byte) (ReceiptProof, error) {
+	out, err := receiptProofArgs.Unpack(encoded)
+	if err != nil {
+		return ReceiptProof{}, errors.Wrap(err, "receiptProofArgs.Unpack")
+	}
+
+	receiptProof, ok := abi.ConvertType(out[0], new(ReceiptProof)).(*ReceiptProof)
+	if !ok {
+		return ReceiptProof{}, errors.New("unexpected ReceiptProof type")
+	}
+
+	return *receiptProof, nil
+}
@@ -0,0 +1,39 @@
+package encoding
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func Test_DecodeReceiptProof(t *testing.T) {
+	r := ReceiptProof{
+		Header: BlockHeader{
+			ParentHash:    common.HexToHash("0x1"),
+			Beneficiary:   common.HexToAddress("0x2"),
+			ReceiptsRoot:  common.HexToHash("0x3"),
+			LogsBloom:     [8][32]byte{{0x4}},
+			Difficulty:    big.NewInt(1),
+			Height:        big.NewInt(5),
+			GasLimit:      6,
+			Timestamp:     7,
+			ExtraData:     []byte{0x8},
+			BaseFeePerGas: big.NewInt(9),
+		},
+		ReceiptsRoot: common.HexToHash("0x3"),
+		Index:        10,
+		Proof:        []byte{0x1, 0x2, 0x3},
+	}
+
+	encoded, err := EncodeReceiptProof(r)
+	assert.Equal(t, nil, err)
+
+	decoded, err := DecodeReceiptProof(encoded)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, r, decoded)
+
+	_, err = DecodeReceiptProof([]byte{0x1})
+	assert.NotEqual(t, nil, err)
+}
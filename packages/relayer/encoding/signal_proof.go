@@ -5,17 +5,29 @@ import (
 	"github.com/pkg/errors"
 )
 
+// signalProofArgs are the arguments a SignalProof is abi encoded as, built once rather than on every call
+var signalProofArgs = abi.Arguments{{Type: signalProofT}}
+
 func EncodeSignalProof(signalProof SignalProof) ([]byte, error) {
-	args := abi.Arguments{
-		{
-			Type: signalProofT,
-		},
+	encodedSignalProof, err := signalProofArgs.Pack(signalProof)
+	if err != nil {
+		return nil, errors.Wrap(err, "signalProofArgs.Pack")
 	}
 
-	encodedSignalProof, err := args.Pack(signalProof)
+	return encodedSignalProof, nil
+}
+
+// DecodeSignalProof is the inverse of EncodeSignalProof.
+func DecodeSignalProof(encoded []byte) (SignalProof, error) {
+	out, err := signalProofArgs.Unpack(encoded)
 	if err != nil {
-		return nil, errors.Wrap(err, "args.Pack")
+		return SignalProof{}, errors.Wrap(err, "signalProofArgs.Unpack")
 	}
 
-	return encodedSignalProof, nil
+	signalProof, ok := abi.ConvertType(out[0], new(SignalProof)).(*SignalProof)
+	if !ok {
+		return SignalProof{}, errors.New("unexpected SignalProof type")
+	}
+
+	return *signalProof, nil
 }
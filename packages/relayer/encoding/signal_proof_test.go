@@ -24,3 +24,20 @@ func Test_EncodeSignalProof(t *testing.T) {
 	assert.Equal(t, nil, err)
 	assert.Equal(t, hexutil.Encode(proof), want)
 }
+
+func Test_DecodeSignalProof(t *testing.T) {
+	s := SignalProof{
+		Height: big.NewInt(1),
+		Proof:  []byte{0x1, 0x2, 0x3},
+	}
+
+	encoded, err := EncodeSignalProof(s)
+	assert.Equal(t, nil, err)
+
+	decoded, err := DecodeSignalProof(encoded)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, s, decoded)
+
+	_, err = DecodeSignalProof([]byte{0x1})
+	assert.NotEqual(t, nil, err)
+}
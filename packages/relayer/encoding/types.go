@@ -47,3 +47,52 @@ var signalProofT, _ = abi.NewType("tuple", "", []abi.ArgumentMarshaling{
 		Type: "bytes",
 	},
 })
+
+// ReceiptProof proves the receipt at Index in the block with Header is included under
+// ReceiptsRoot, Proof being the rlp encoded list of receipts trie nodes from the root to it.
+type ReceiptProof struct {
+	Header       BlockHeader `abi:"header"`
+	ReceiptsRoot [32]byte    `abi:"receiptsRoot"`
+	Index        uint64      `abi:"index"`
+	Proof        []byte      `abi:"proof"`
+}
+
+var blockHeaderComponents = []abi.ArgumentMarshaling{
+	{Name: "parentHash", Type: "bytes32"},
+	{Name: "ommersHash", Type: "bytes32"},
+	{Name: "beneficiary", Type: "address"},
+	{Name: "stateRoot", Type: "bytes32"},
+	{Name: "transactionsRoot", Type: "bytes32"},
+	{Name: "receiptsRoot", Type: "bytes32"},
+	{Name: "logsBloom", Type: "bytes32[8]"},
+	{Name: "difficulty", Type: "uint256"},
+	{Name: "height", Type: "uint256"},
+	{Name: "gasLimit", Type: "uint64"},
+	{Name: "gasUsed", Type: "uint64"},
+	{Name: "timestamp", Type: "uint64"},
+	{Name: "extraData", Type: "bytes"},
+	{Name: "mixHash", Type: "bytes32"},
+	{Name: "nonce", Type: "uint64"},
+	{Name: "baseFeePerGas", Type: "uint256"},
+	{Name: "withdrawalsRoot", Type: "bytes32"},
+}
+
+var receiptProofT, _ = abi.NewType("tuple", "", []abi.ArgumentMarshaling{
+	{
+		Name:       "header",
+		Type:       "tuple",
+		Components: blockHeaderComponents,
+	},
+	{
+		Name: "receiptsRoot",
+		Type: "bytes32",
+	},
+	{
+		Name: "index",
+		Type: "uint64",
+	},
+	{
+		Name: "proof",
+		Type: "bytes",
+	},
+})
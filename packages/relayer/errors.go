@@ -11,6 +11,14 @@ var (
 		"ERR_NO_BLOCK_REPOSITORY",
 		"BlockRepository is required",
 	)
+	ErrNoCrossChainSyncRepository = errors.Validation.NewWithKeyAndDetail(
+		"ERR_NO_CROSS_CHAIN_SYNC_REPOSITORY",
+		"CrossChainSyncRepository is required",
+	)
+	ErrNoBridgeStatusChangeRepository = errors.Validation.NewWithKeyAndDetail(
+		"ERR_NO_BRIDGE_STATUS_CHANGE_REPOSITORY",
+		"BridgeStatusChangeRepository is required",
+	)
 	ErrNoCORSOrigins = errors.Validation.NewWithKeyAndDetail("ERR_NO_CORS_ORIGINS", "CORS Origins are required")
 	ErrNoProver      = errors.Validation.NewWithKeyAndDetail("ERR_NO_PROVER", "Prover is required")
 	ErrNoRPCClient   = errors.Validation.NewWithKeyAndDetail("ERR_NO_RPC_CLIENT", "RPCClient is required")
@@ -25,10 +33,90 @@ var (
 		"ERR_INVALID_CONFIRMATIONS_TIMEOUT_IN_SECONDS",
 		"ConfirmationsTimeoutInSeconds amount is invalid, must be numerical and > 0",
 	)
+	ErrInvalidReceiptPollInterval = errors.Validation.NewWithKeyAndDetail(
+		"ERR_INVALID_RECEIPT_POLL_INTERVAL",
+		"ReceiptPollInterval is invalid, must be > 0",
+	)
+	ErrInvalidHeadPollInterval = errors.Validation.NewWithKeyAndDetail(
+		"ERR_INVALID_HEAD_POLL_INTERVAL",
+		"HeadPollInterval is invalid, must be > 0",
+	)
+	ErrInvalidReceiptTimeout = errors.Validation.NewWithKeyAndDetail(
+		"ERR_INVALID_RECEIPT_TIMEOUT",
+		"ReceiptTimeout is invalid, must be > 0",
+	)
+	ErrNoWebhookSecret = errors.Validation.NewWithKeyAndDetail(
+		"ERR_NO_WEBHOOK_SECRET",
+		"Webhook secret is required to sign payloads",
+	)
+	ErrNoWebhookSubscriptionRepository = errors.Validation.NewWithKeyAndDetail(
+		"ERR_NO_WEBHOOK_SUBSCRIPTION_REPOSITORY",
+		"WebhookSubscriptionRepository is required",
+	)
+	ErrNoWebhookDeliveryRepository = errors.Validation.NewWithKeyAndDetail(
+		"ERR_NO_WEBHOOK_DELIVERY_REPOSITORY",
+		"WebhookDeliveryRepository is required",
+	)
+	ErrInvalidMaxInFlightTxs = errors.Validation.NewWithKeyAndDetail(
+		"ERR_INVALID_MAX_IN_FLIGHT_TXS",
+		"MaxInFlightTxs is invalid, must be > 0",
+	)
+	ErrInvalidProcessorConcurrency = errors.Validation.NewWithKeyAndDetail(
+		"ERR_INVALID_PROCESSOR_CONCURRENCY",
+		"ProcessorConcurrency is invalid, must be > 0",
+	)
+	ErrInvalidStartHeight = errors.Validation.NewWithKeyAndDetail(
+		"ERR_INVALID_START_HEIGHT",
+		"StartHeight is invalid, must be a block number, latest or deployment",
+	)
+	ErrInvalidExportFormat = errors.Validation.NewWithKeyAndDetail(
+		"ERR_INVALID_EXPORT_FORMAT",
+		"Export format not supported",
+	)
 	ErrInvalidMode  = errors.Validation.NewWithKeyAndDetail("ERR_INVALID_MODE", "Mode not supported")
 	ErrUnprofitable = errors.Validation.NewWithKeyAndDetail("ERR_UNPROFITABLE", "Transaction is unprofitable to process")
 	ErrNotReceived  = errors.BadRequest.NewWithKeyAndDetail(
 		"ERR_NOT_RECEIVED",
 		"Message not received on destination chain",
 	)
+	ErrMessageNotHeld = errors.BadRequest.NewWithKeyAndDetail(
+		"ERR_MESSAGE_NOT_HELD",
+		"Message is not held",
+	)
+	ErrProcessingPaused = errors.BadRequest.NewWithKeyAndDetail(
+		"ERR_PROCESSING_PAUSED",
+		"Processing is paused",
+	)
+	ErrMessageNotReprocessable = errors.BadRequest.NewWithKeyAndDetail(
+		"ERR_MESSAGE_NOT_REPROCESSABLE",
+		"Message is not new on the destination chain",
+	)
+	ErrChainIDMismatch = errors.Validation.NewWithKeyAndDetail(
+		"ERR_CHAIN_ID_MISMATCH",
+		"Message's destination chain ID is not the destination node's chain ID",
+	)
+	ErrInvalidConfirmationStrategy = errors.Validation.NewWithKeyAndDetail(
+		"ERR_INVALID_CONFIRMATION_STRATEGY",
+		"Confirmation strategy is invalid, must be blocks:N with N > 0, finalized or safe",
+	)
+	ErrInvalidBlockNumber = errors.Validation.NewWithKeyAndDetail(
+		"ERR_INVALID_BLOCK_NUMBER",
+		"Block number is invalid, must be > 0",
+	)
+	ErrInvalidKeySelection = errors.Validation.NewWithKeyAndDetail(
+		"ERR_INVALID_KEY_SELECTION",
+		"Key selection is invalid, must be round-robin or hash",
+	)
+	ErrForwarderWithExtraKeys = errors.Validation.NewWithKeyAndDetail(
+		"ERR_FORWARDER_WITH_EXTRA_KEYS",
+		"A forwarder can't be used with extra relayer keys, its requests must be sent in nonce order",
+	)
+	ErrInvalidRetryPolicy = errors.Validation.NewWithKeyAndDetail(
+		"ERR_INVALID_RETRY_POLICY",
+		"Retry policy is invalid, must be reason=sync or reason=delay[+jitter], comma separated",
+	)
+	ErrNoRelayerKeyAvailable = errors.Validation.NewWithKeyAndDetail(
+		"ERR_NO_RELAYER_KEY_AVAILABLE",
+		"Every relayer key is out of rotation, its balance below the minimum",
+	)
 )
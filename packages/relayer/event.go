@@ -2,8 +2,10 @@ package relayer
 
 import (
 	"context"
+	"encoding/json"
 	"math/big"
 	"net/http"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/morkid/paginate"
@@ -25,6 +27,25 @@ const (
 	EventStatusDone
 	EventStatusFailed
 	EventStatusNewOnlyOwner
+	EventStatusHeld
+	// EventStatusPendingSent means a processMessage transaction was sent, but its outcome
+	// hasn't been recorded yet. ProcessingTxHash is the transaction to check on restart.
+	EventStatusPendingSent
+	// EventStatusDuplicate means the message was already indexed from another log, i.e. re-emitted
+	// by an upgraded bridge. It's never processed, DuplicateOfEventID is the event it duplicates.
+	EventStatusDuplicate
+	// EventStatusBlocked means the message's sender or recipient is on the blocklist, so it's
+	// never relayed
+	EventStatusBlocked
+	// EventStatusOutOfScope means the message is for a destination chain we don't relay to
+	EventStatusOutOfScope
+	// EventStatusStale means the message was still new when it got older than the max message
+	// age, and is no longer processed
+	EventStatusStale
+	// EventStatusProcessedUnconfirmed means the message's processMessage transaction was mined and
+	// the message is done on the destination chain, but the transaction, ProcessingTxHash, isn't
+	// deep enough yet that a reorg couldn't undo it
+	EventStatusProcessedUnconfirmed
 )
 
 type EventType int
@@ -32,15 +53,29 @@ type EventType int
 const (
 	EventTypeSendETH EventType = iota
 	EventTypeSendERC20
+	EventTypeMessageCall
 )
 
 // String returns string representation of an event status for logging
 func (e EventStatus) String() string {
-	return [...]string{"new", "retriable", "done", "failed", "onlyOwner"}[e]
+	return [...]string{
+		"new",
+		"retriable",
+		"done",
+		"failed",
+		"onlyOwner",
+		"held",
+		"pendingSent",
+		"duplicate",
+		"blocked",
+		"outOfScope",
+		"stale",
+		"processedUnconfirmed",
+	}[e]
 }
 
 func (e EventType) String() string {
-	return [...]string{"sendETH", "sendERC20"}[e]
+	return [...]string{"sendETH", "sendERC20", "messageCall"}[e]
 }
 
 // Event represents a stored EVM event. The fields will be serialized
@@ -61,6 +96,63 @@ type Event struct {
 	MsgHash                string         `json:"msgHash"`
 	MessageOwner           string         `json:"messageOwner"`
 	Event                  string         `json:"event"`
+	MessageSentTimestamp   uint64         `json:"messageSentTimestamp"`
+	DoneTimestamp          uint64         `json:"doneTimestamp"`
+	TimeToDoneInSeconds    uint64         `json:"timeToDoneInSeconds"`
+	MessageCallTo          string         `json:"messageCallTo"`
+	MessageCallSelector    string         `json:"messageCallSelector"`
+	ProcessingTxHash       string         `json:"processingTxHash"`
+	// ProcessingError is the error the last attempt to process the message failed with
+	ProcessingError string `json:"processingError"`
+	// DuplicateOfEventID is the event first indexed for the same message, if this one is a duplicate
+	DuplicateOfEventID *int `json:"duplicateOfEventID"`
+	// BlockNumber is the block the event was emitted in
+	BlockNumber uint64 `json:"blockNumber"`
+	// TxHash and LogIndex are the log the event was indexed from, nil for events saved before they were
+	// recorded, or not indexed from a log
+	TxHash   *string `json:"txHash"`
+	LogIndex *uint   `json:"logIndex"`
+	// MessageSender, lower case, and DestChainID are the MessageSent event's message's, copied out of
+	// Data to filter on
+	MessageSender string `json:"messageSender"`
+	DestChainID   int64  `json:"destChainID"`
+	// MessageTo, lower case, is the MessageSent event's message's recipient, copied out of Data to
+	// count failures by
+	MessageTo string `json:"messageTo"`
+	// RetryReason is why the last attempt to process the message failed, if it's to be retried, and
+	// NextRetryAt when. A message not synced yet has no NextRetryAt until the destination chain syncs.
+	RetryReason RetryReason `json:"retryReason"`
+	NextRetryAt *time.Time  `json:"nextRetryAt"`
+	// ProcessingBlockNumber is the destination block ProcessingTxHash was mined in, nil until it's mined
+	ProcessingBlockNumber *uint64 `json:"processingBlockNumber"`
+	// RejectionReason is why the last attempt to process the message found it can't be, if it's
+	// a known reason
+	RejectionReason RejectionReason `json:"rejectionReason"`
+}
+
+// Rejection is why the message can't be processed, by its status, or else by why the last attempt to
+// process it failed, nil if it isn't rejected.
+func (e *Event) Rejection() *Rejection {
+	switch e.Status {
+	case EventStatusDone, EventStatusProcessedUnconfirmed, EventStatusDuplicate:
+		return nil
+	}
+
+	if reason := RejectionReasonForStatus(e.Status); reason != "" {
+		return NewRejection(reason)
+	}
+
+	return NewRejection(e.RejectionReason)
+}
+
+// MarshalJSON adds the event's Rejection, with its retry hint, to its fields
+func (e Event) MarshalJSON() ([]byte, error) {
+	type event Event
+
+	return json.Marshal(struct {
+		event
+		Rejection *Rejection `json:"rejection"`
+	}{event(e), e.Rejection()})
 }
 
 // SaveEventOpts
@@ -78,6 +170,21 @@ type SaveEventOpts struct {
 	MsgHash                string
 	MessageOwner           string
 	Event                  string
+	MessageCallTo          string
+	MessageCallSelector    string
+	DuplicateOfEventID     *int
+	BlockNumber            uint64
+	// TxHash and LogIndex are the log the event is from, if any. An event already saved
+	// from the same log isn't saved again.
+	TxHash   string
+	LogIndex uint
+	// MessageSender, DestChainID and MessageTo are the message's, for MessageSent events
+	MessageSender string
+	DestChainID   *big.Int
+	MessageTo     string
+	// MessageSentTimestamp is the timestamp of the source block the message was sent in, for
+	// MessageSent events
+	MessageSentTimestamp uint64
 }
 
 type FindAllByAddressOpts struct {
@@ -86,12 +193,86 @@ type FindAllByAddressOpts struct {
 	Event     *string
 	MsgHash   *string
 	ChainID   *big.Int
+	// Sender and DestChainID filter on the message's sender and destination chain when set
+	Sender      *common.Address
+	DestChainID *big.Int
+}
+
+// FindAllForExportOpts filters events for export. Results are ordered by ID,
+// and AfterID is used as a cursor so callers can page through large ranges
+// without holding them all in memory.
+type FindAllForExportOpts struct {
+	AfterID int
+	Limit   int
+	From    time.Time
+	To      time.Time
+	ChainID *big.Int
+	Status  *EventStatus
+	// Name and MessageOwner match any event when empty
+	Name         string
+	MessageOwner string
+}
+
+// ExportedEvent is an Event along with the time it was indexed at
+type ExportedEvent struct {
+	Event
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// MessageReleaser moves a held message back to new and processes it
+type MessageReleaser interface {
+	ReleaseMessage(ctx context.Context, e *Event) error
 }
 
 // EventRepository is used to interact with events in the store
+// FailingRecipient is a message recipient, and how many messages sent to it are Retriable or Failed
+type FailingRecipient struct {
+	Recipient string `json:"recipient"`
+	Failures  int    `json:"failures"`
+}
+
+// EventStats are aggregates of the messages indexed, for dashboards
+type EventStats struct {
+	// MessagesRelayed is how many messages the relayer took to done since the time asked for, by the
+	// timestamp of the destination block they were processed in
+	MessagesRelayed int64 `json:"messagesRelayed"`
+	// AverageTimeToDoneInSeconds is how long those took from being sent, nil if there were none
+	AverageTimeToDoneInSeconds *float64 `json:"averageTimeToDoneInSeconds"`
+	// ValueBridged is what those messages bridged, per source chain and token
+	ValueBridged []*TokenValue `json:"valueBridged"`
+	// Backlog is how many messages are waiting to be processed now, new, retriable or pending sent
+	Backlog int64 `json:"backlog"`
+}
+
+// TokenValue is an amount of a token sent from the chain with ChainID, in the token's smallest unit.
+// The zero CanonicalTokenAddress is the chain's native token.
+type TokenValue struct {
+	ChainID                int64  `json:"chainID"`
+	CanonicalTokenAddress  string `json:"canonicalTokenAddress"`
+	CanonicalTokenSymbol   string `json:"canonicalTokenSymbol"`
+	CanonicalTokenDecimals uint8  `json:"canonicalTokenDecimals"`
+	Amount                 string `json:"amount"`
+}
+
+// ProcessingStatuses are the statuses of messages a processMessage transaction can be sent for,
+// including one already sent and being replaced
+var ProcessingStatuses = []EventStatus{EventStatusNew, EventStatusNewOnlyOwner, EventStatusPendingSent}
+
+// BacklogStatuses are the statuses of messages waiting to be processed
+var BacklogStatuses = []EventStatus{EventStatusNew, EventStatusRetriable, EventStatusPendingSent}
+
 type EventRepository interface {
 	Save(ctx context.Context, opts SaveEventOpts) (*Event, error)
+	SaveBatch(ctx context.Context, opts []SaveEventOpts, batchSize int) ([]*Event, error)
 	UpdateStatus(ctx context.Context, id int, status EventStatus) error
+	UpdateStatusIf(ctx context.Context, id int, from EventStatus, status EventStatus) (bool, error)
+	UpdateTimeToDone(
+		ctx context.Context,
+		id int,
+		messageSentTimestamp uint64,
+		doneTimestamp uint64,
+		timeToDoneInSeconds uint64,
+	) error
 	FindAllByAddress(
 		ctx context.Context,
 		req *http.Request,
@@ -106,5 +287,33 @@ type EventRepository interface {
 		event string,
 		msgHash string,
 	) (*Event, error)
+	FindAllForExport(ctx context.Context, opts FindAllForExportOpts) ([]*ExportedEvent, error)
+	FindAllByStatus(ctx context.Context, chainID *big.Int, status EventStatus) ([]*Event, error)
+	FindAllByBlockNumber(ctx context.Context, chainID *big.Int, blockNumber uint64) ([]*Event, error)
+	FindAllMessageSentFromBlock(ctx context.Context, chainID *big.Int, fromBlock uint64) ([]*Event, error)
+	FindProcessable(
+		ctx context.Context,
+		chainID *big.Int,
+		syncedHeight uint64,
+		now time.Time,
+		limit int,
+	) ([]*Event, error)
+	MarkStale(
+		ctx context.Context,
+		chainID *big.Int,
+		sentBefore time.Time,
+		exceptSenders []common.Address,
+	) (int64, error)
+	MarkPendingSent(ctx context.Context, id int, txHash common.Hash) error
+	MarkProcessedUnconfirmed(ctx context.Context, id int, txHash common.Hash) error
+	FindTopFailingRecipients(ctx context.Context, limit int) ([]*FailingRecipient, error)
+	UpdateProcessingError(ctx context.Context, id int, processingError string, rejectionReason RejectionReason) error
+	UpdateNextRetry(ctx context.Context, id int, reason RetryReason, nextRetryAt *time.Time) error
+	RetryWaitingOnSync(ctx context.Context, chainID *big.Int, now time.Time) (int64, error)
+	UpdateProcessingBlock(ctx context.Context, id int, blockNumber uint64) error
+	FindDoneProcessedBetween(ctx context.Context, chainID *big.Int, fromBlock uint64, toBlock uint64) ([]*Event, error)
 	Delete(ctx context.Context, id int) error
+	LatestBlockNumber(ctx context.Context, chainID *big.Int) (uint64, error)
+	LatestMessageSent(ctx context.Context, chainID *big.Int) (*Event, error)
+	Stats(ctx context.Context, since time.Time) (*EventStats, error)
 }
@@ -0,0 +1,10 @@
+package relayer
+
+// EventPublisher streams what the indexer observes to downstream consumers. Publishing is
+// fire and forget: it must never block, or fail, indexing.
+type EventPublisher interface {
+	// PublishEvent publishes an event the indexer saved
+	PublishEvent(opts SaveEventOpts)
+	// PublishStatusChange publishes a message's status changing on chain
+	PublishStatusChange(change MessageStatusChange)
+}
@@ -37,6 +37,16 @@ func Test_EventStatus_String(t *testing.T) {
 			EventStatusNewOnlyOwner,
 			"onlyOwner",
 		},
+		{
+			"duplicate",
+			EventStatusDuplicate,
+			"duplicate",
+		},
+		{
+			"processedUnconfirmed",
+			EventStatusProcessedUnconfirmed,
+			"processedUnconfirmed",
+		},
 	}
 
 	for _, tt := range tests {
@@ -62,6 +72,11 @@ func Test_EventType_String(t *testing.T) {
 			EventTypeSendERC20,
 			"sendERC20",
 		},
+		{
+			"messageCall",
+			EventTypeMessageCall,
+			"messageCall",
+		},
 	}
 
 	for _, tt := range tests {
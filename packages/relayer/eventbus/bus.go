@@ -0,0 +1,108 @@
+package eventbus
+
+import (
+	"sync"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+)
+
+// Bus is an in-process topic components publish values of T to, and subscribe to, rather than
+// polling the database or the chain for them. Publishing never blocks: each subscriber has a bounded
+// buffer, and when a slow subscriber's is full its oldest value is dropped, so it always gets the
+// latest. A new subscriber is replayed the last value published, so it needn't wait for the next.
+type Bus[T any] struct {
+	topic string
+
+	mu   sync.Mutex
+	subs map[*Subscription[T]]struct{}
+	// last is the last value published, replayed to new subscribers
+	last    T
+	hasLast bool
+}
+
+// Subscription receives the values published to a Bus after it subscribed, and the one before
+type Subscription[T any] struct {
+	bus *Bus[T]
+	ch  chan T
+}
+
+// New is a Bus for topic, which names it in metrics
+func New[T any](topic string) *Bus[T] {
+	return &Bus[T]{
+		topic: topic,
+		subs:  make(map[*Subscription[T]]struct{}),
+	}
+}
+
+// Publish sends v to every subscriber without waiting on any of them
+func (b *Bus[T]) Publish(v T) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.last = v
+	b.hasLast = true
+
+	for s := range b.subs {
+		b.send(s, v)
+	}
+}
+
+// send delivers v to s, dropping s's oldest value if its buffer is full. Publishers hold b.mu, so
+// once one value is dropped there's room for v.
+func (b *Bus[T]) send(s *Subscription[T], v T) {
+	select {
+	case s.ch <- v:
+		return
+	default:
+	}
+
+	select {
+	case <-s.ch:
+		relayer.EventBusDropped.WithLabelValues(b.topic).Inc()
+	default:
+	}
+
+	select {
+	case s.ch <- v:
+	default:
+	}
+}
+
+// Subscribe returns a Subscription buffering up to buffer values, at least 1, starting with the last
+// value published, if any.
+func (b *Bus[T]) Subscribe(buffer int) *Subscription[T] {
+	if buffer < 1 {
+		buffer = 1
+	}
+
+	s := &Subscription[T]{bus: b, ch: make(chan T, buffer)}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.hasLast {
+		s.ch <- b.last
+	}
+
+	b.subs[s] = struct{}{}
+
+	return s
+}
+
+// C is the channel the subscription's values are received on, closed once it's unsubscribed
+func (s *Subscription[T]) C() <-chan T {
+	return s.ch
+}
+
+// Unsubscribe stops values being sent to the subscription. It's safe to call more than once.
+func (s *Subscription[T]) Unsubscribe() {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+
+	if _, ok := s.bus.subs[s]; !ok {
+		return
+	}
+
+	delete(s.bus.subs, s)
+	close(s.ch)
+}
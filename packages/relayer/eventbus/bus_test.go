@@ -0,0 +1,67 @@
+package eventbus
+
+import (
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Bus_Publish(t *testing.T) {
+	b := New[int]("test_publish")
+
+	sub1 := b.Subscribe(2)
+	sub2 := b.Subscribe(2)
+
+	b.Publish(1)
+	b.Publish(2)
+
+	for _, sub := range []*Subscription[int]{sub1, sub2} {
+		assert.Equal(t, 1, <-sub.C())
+		assert.Equal(t, 2, <-sub.C())
+	}
+}
+
+func Test_Bus_Subscribe_replaysLast(t *testing.T) {
+	b := New[int]("test_replay")
+
+	sub := b.Subscribe(1)
+	assert.Equal(t, 0, len(sub.C()))
+
+	b.Publish(1)
+	b.Publish(2)
+
+	late := b.Subscribe(1)
+	assert.Equal(t, 2, <-late.C())
+}
+
+func Test_Bus_Publish_dropsOldestForSlowSubscriber(t *testing.T) {
+	b := New[int]("test_slow")
+	dropped := relayer.EventBusDropped.WithLabelValues("test_slow")
+	before := testutil.ToFloat64(dropped)
+
+	slow := b.Subscribe(2)
+
+	// doesn't block on the subscriber not reading
+	for i := 1; i <= 5; i++ {
+		b.Publish(i)
+	}
+
+	assert.Equal(t, 4, <-slow.C())
+	assert.Equal(t, 5, <-slow.C())
+	assert.Equal(t, before+3, testutil.ToFloat64(dropped))
+}
+
+func Test_Subscription_Unsubscribe(t *testing.T) {
+	b := New[int]("test_unsubscribe")
+
+	sub := b.Subscribe(1)
+	sub.Unsubscribe()
+	sub.Unsubscribe()
+
+	b.Publish(1)
+
+	_, ok := <-sub.C()
+	assert.False(t, ok)
+}
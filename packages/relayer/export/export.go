@@ -0,0 +1,84 @@
+package export
+
+import (
+	"context"
+	"io"
+	"math/big"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/pkg/errors"
+)
+
+var (
+	defaultBatchSize = 1000
+)
+
+type Opts struct {
+	Format    relayer.ExportFormat
+	From      time.Time
+	To        time.Time
+	ChainID   *big.Int
+	Status    *relayer.EventStatus
+	BatchSize int
+}
+
+// Export pages through events matching opts, ordered by ID, and writes them to w
+// in the requested format. Only one batch is held in memory at a time.
+// It returns the number of events written.
+func Export(ctx context.Context, eventRepo relayer.EventRepository, w io.Writer, opts Opts) (int, error) {
+	if eventRepo == nil {
+		return 0, relayer.ErrNoEventRepository
+	}
+
+	rw, err := newRowWriter(opts.Format, w)
+	if err != nil {
+		return 0, errors.Wrap(err, "newRowWriter")
+	}
+
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+
+	var (
+		afterID int
+		written int
+	)
+
+	for {
+		events, err := eventRepo.FindAllForExport(ctx, relayer.FindAllForExportOpts{
+			AfterID: afterID,
+			Limit:   batchSize,
+			From:    opts.From,
+			To:      opts.To,
+			ChainID: opts.ChainID,
+			Status:  opts.Status,
+		})
+		if err != nil {
+			return written, errors.Wrap(err, "eventRepo.FindAllForExport")
+		}
+
+		for _, e := range events {
+			row, err := NewRow(e)
+			if err != nil {
+				return written, errors.Wrapf(err, "NewRow(%v)", e.ID)
+			}
+
+			if err := rw.Write(row); err != nil {
+				return written, errors.Wrap(err, "rw.Write")
+			}
+
+			afterID = e.ID
+			written++
+		}
+
+		if err := rw.Flush(); err != nil {
+			return written, errors.Wrap(err, "rw.Flush")
+		}
+
+		if len(events) < batchSize {
+			return written, nil
+		}
+	}
+}
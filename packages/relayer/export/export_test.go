@@ -0,0 +1,91 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestEventRepo(t *testing.T) relayer.EventRepository {
+	repo := mock.NewEventRepository()
+
+	statuses := []relayer.EventStatus{
+		relayer.EventStatusDone,
+		relayer.EventStatusNew,
+		relayer.EventStatusDone,
+	}
+
+	for _, s := range statuses {
+		_, err := repo.Save(context.Background(), relayer.SaveEventOpts{
+			Name:    relayer.EventNameMessageSent,
+			Data:    `{"Message":{"SrcChainId":1,"DestChainId":2,"DepositValue":100}}`,
+			ChainID: big.NewInt(1),
+			Status:  s,
+			Event:   relayer.EventNameMessageSent,
+		})
+		assert.Nil(t, err)
+	}
+
+	return repo
+}
+
+func Test_Export_csv(t *testing.T) {
+	var buf bytes.Buffer
+
+	written, err := Export(context.Background(), newTestEventRepo(t), &buf, Opts{
+		Format:    relayer.CSVExportFormat,
+		BatchSize: 2,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, written)
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(records))
+	assert.Equal(t, Columns, records[0])
+
+	for _, r := range records[1:] {
+		assert.Equal(t, len(Columns), len(r))
+		assert.Equal(t, "100", r[18])
+	}
+}
+
+func Test_Export_json(t *testing.T) {
+	var buf bytes.Buffer
+
+	status := relayer.EventStatusDone
+
+	written, err := Export(context.Background(), newTestEventRepo(t), &buf, Opts{
+		Format: relayer.JSONExportFormat,
+		Status: &status,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, written)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, 2, len(lines))
+
+	for _, l := range lines {
+		var row Row
+		assert.Nil(t, json.Unmarshal([]byte(l), &row))
+		assert.Equal(t, "done", row.Status)
+		assert.Equal(t, "2", row.DestChainID)
+	}
+}
+
+func Test_Export_invalidFormat(t *testing.T) {
+	var buf bytes.Buffer
+
+	_, err := Export(context.Background(), newTestEventRepo(t), &buf, Opts{
+		Format: "xml",
+	})
+	assert.ErrorIs(t, err, relayer.ErrInvalidExportFormat)
+}
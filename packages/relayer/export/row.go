@@ -0,0 +1,180 @@
+package export
+
+import (
+	"encoding/json"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// Columns is the CSV header. Its order must match Row.Record, and new columns
+// should only ever be appended so downstream schemas stay stable.
+var Columns = []string{
+	"id",
+	"created_at",
+	"chain_id",
+	"event",
+	"status",
+	"event_type",
+	"msg_hash",
+	"message_owner",
+	"canonical_token_address",
+	"canonical_token_symbol",
+	"canonical_token_name",
+	"canonical_token_decimals",
+	"amount",
+	"src_chain_id",
+	"dest_chain_id",
+	"sender",
+	"to",
+	"refund_address",
+	"deposit_value",
+	"call_value",
+	"processing_fee",
+	"gas_limit",
+	"tx_hash",
+	"block_number",
+	"message_sent_timestamp",
+	"done_timestamp",
+	"time_to_done_in_seconds",
+	"message_call_to",
+	"message_call_selector",
+	"processing_tx_hash",
+}
+
+// Row is a flattened, decoded event. Message fields are only populated for
+// MessageSent events, since other events don't carry the message.
+type Row struct {
+	ID                     int    `json:"id"`
+	CreatedAt              string `json:"created_at"`
+	ChainID                int64  `json:"chain_id"`
+	Event                  string `json:"event"`
+	Status                 string `json:"status"`
+	EventType              string `json:"event_type"`
+	MsgHash                string `json:"msg_hash"`
+	MessageOwner           string `json:"message_owner"`
+	CanonicalTokenAddress  string `json:"canonical_token_address"`
+	CanonicalTokenSymbol   string `json:"canonical_token_symbol"`
+	CanonicalTokenName     string `json:"canonical_token_name"`
+	CanonicalTokenDecimals uint8  `json:"canonical_token_decimals"`
+	Amount                 string `json:"amount"`
+	SrcChainID             string `json:"src_chain_id"`
+	DestChainID            string `json:"dest_chain_id"`
+	Sender                 string `json:"sender"`
+	To                     string `json:"to"`
+	RefundAddress          string `json:"refund_address"`
+	DepositValue           string `json:"deposit_value"`
+	CallValue              string `json:"call_value"`
+	ProcessingFee          string `json:"processing_fee"`
+	GasLimit               string `json:"gas_limit"`
+	TxHash                 string `json:"tx_hash"`
+	BlockNumber            uint64 `json:"block_number"`
+	MessageSentTimestamp   uint64 `json:"message_sent_timestamp"`
+	DoneTimestamp          uint64 `json:"done_timestamp"`
+	TimeToDoneInSeconds    uint64 `json:"time_to_done_in_seconds"`
+	MessageCallTo          string `json:"message_call_to"`
+	MessageCallSelector    string `json:"message_call_selector"`
+	ProcessingTxHash       string `json:"processing_tx_hash"`
+}
+
+// NewRow decodes an exported event into a Row
+func NewRow(e *relayer.ExportedEvent) (Row, error) {
+	row := Row{
+		ID:                     e.ID,
+		CreatedAt:              e.CreatedAt.UTC().Format(time.RFC3339),
+		ChainID:                e.ChainID,
+		Event:                  e.Event.Event,
+		Status:                 e.Status.String(),
+		EventType:              e.EventType.String(),
+		MsgHash:                e.MsgHash,
+		MessageOwner:           e.MessageOwner,
+		CanonicalTokenAddress:  e.CanonicalTokenAddress,
+		CanonicalTokenSymbol:   e.CanonicalTokenSymbol,
+		CanonicalTokenName:     e.CanonicalTokenName,
+		CanonicalTokenDecimals: e.CanonicalTokenDecimals,
+		Amount:                 e.Amount,
+		MessageSentTimestamp:   e.MessageSentTimestamp,
+		DoneTimestamp:          e.DoneTimestamp,
+		TimeToDoneInSeconds:    e.TimeToDoneInSeconds,
+		MessageCallTo:          e.MessageCallTo,
+		MessageCallSelector:    e.MessageCallSelector,
+		ProcessingTxHash:       e.ProcessingTxHash,
+	}
+
+	if e.Name != relayer.EventNameMessageSent {
+		return row, nil
+	}
+
+	var event bridge.BridgeMessageSent
+	if err := json.Unmarshal(e.Data, &event); err != nil {
+		return Row{}, errors.Wrap(err, "json.Unmarshal")
+	}
+
+	m := event.Message
+
+	row.SrcChainID = bigString(m.SrcChainId)
+	row.DestChainID = bigString(m.DestChainId)
+	row.Sender = m.Sender.Hex()
+	row.To = m.To.Hex()
+	row.RefundAddress = m.RefundAddress.Hex()
+	row.DepositValue = bigString(m.DepositValue)
+	row.CallValue = bigString(m.CallValue)
+	row.ProcessingFee = bigString(m.ProcessingFee)
+	row.GasLimit = bigString(m.GasLimit)
+	row.BlockNumber = event.Raw.BlockNumber
+
+	if event.Raw.TxHash != (common.Hash{}) {
+		row.TxHash = event.Raw.TxHash.Hex()
+	}
+
+	return row, nil
+}
+
+// Record returns the row's values in Columns order
+func (r Row) Record() []string {
+	return []string{
+		strconv.Itoa(r.ID),
+		r.CreatedAt,
+		strconv.FormatInt(r.ChainID, 10),
+		r.Event,
+		r.Status,
+		r.EventType,
+		r.MsgHash,
+		r.MessageOwner,
+		r.CanonicalTokenAddress,
+		r.CanonicalTokenSymbol,
+		r.CanonicalTokenName,
+		strconv.Itoa(int(r.CanonicalTokenDecimals)),
+		r.Amount,
+		r.SrcChainID,
+		r.DestChainID,
+		r.Sender,
+		r.To,
+		r.RefundAddress,
+		r.DepositValue,
+		r.CallValue,
+		r.ProcessingFee,
+		r.GasLimit,
+		r.TxHash,
+		strconv.FormatUint(r.BlockNumber, 10),
+		strconv.FormatUint(r.MessageSentTimestamp, 10),
+		strconv.FormatUint(r.DoneTimestamp, 10),
+		strconv.FormatUint(r.TimeToDoneInSeconds, 10),
+		r.MessageCallTo,
+		r.MessageCallSelector,
+		r.ProcessingTxHash,
+	}
+}
+
+func bigString(i *big.Int) string {
+	if i == nil {
+		return ""
+	}
+
+	return i.String()
+}
@@ -0,0 +1,90 @@
+package export
+
+import (
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/datatypes"
+)
+
+func Test_NewRow(t *testing.T) {
+	tests := []struct {
+		name    string
+		event   relayer.Event
+		wantRow Row
+		wantErr bool
+	}{
+		{
+			"messageSent",
+			relayer.Event{
+				ID:      1,
+				Name:    relayer.EventNameMessageSent,
+				Event:   relayer.EventNameMessageSent,
+				ChainID: 1,
+				Status:  relayer.EventStatusDone,
+				// nolint: lll
+				Data: datatypes.JSON(`{"Message":{"SrcChainId":1,"DestChainId":2,"DepositValue":3,"CallValue":4,"ProcessingFee":5,"GasLimit":6},"Raw":{"address":"0x0000000000000000000000000000000000000000","topics":[],"data":"0x","blockNumber":"0xa","transactionHash":"0x0000000000000000000000000000000000000000000000000000000000000001","transactionIndex":"0x0","blockHash":"0x0000000000000000000000000000000000000000000000000000000000000000","logIndex":"0x0","removed":false}}`),
+			},
+			Row{
+				ID:            1,
+				CreatedAt:     "0001-01-01T00:00:00Z",
+				ChainID:       1,
+				Event:         relayer.EventNameMessageSent,
+				Status:        "done",
+				EventType:     "sendETH",
+				SrcChainID:    "1",
+				DestChainID:   "2",
+				Sender:        "0x0000000000000000000000000000000000000000",
+				To:            "0x0000000000000000000000000000000000000000",
+				RefundAddress: "0x0000000000000000000000000000000000000000",
+				DepositValue:  "3",
+				CallValue:     "4",
+				ProcessingFee: "5",
+				GasLimit:      "6",
+				TxHash:        "0x0000000000000000000000000000000000000000000000000000000000000001",
+				BlockNumber:   10,
+			},
+			false,
+		},
+		{
+			"messageStatusChangedIsNotDecoded",
+			relayer.Event{
+				ID:     2,
+				Name:   relayer.EventNameMessageStatusChanged,
+				Event:  relayer.EventNameMessageStatusChanged,
+				Status: relayer.EventStatusDone,
+				Data:   datatypes.JSON(`{"Status":2}`),
+			},
+			Row{
+				ID:        2,
+				CreatedAt: "0001-01-01T00:00:00Z",
+				Event:     relayer.EventNameMessageStatusChanged,
+				Status:    "done",
+				EventType: "sendETH",
+			},
+			false,
+		},
+		{
+			"badData",
+			relayer.Event{
+				Name: relayer.EventNameMessageSent,
+				Data: datatypes.JSON(`{`),
+			},
+			Row{},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			row, err := NewRow(&relayer.ExportedEvent{Event: tt.event})
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantRow, row)
+		})
+	}
+}
+
+func Test_Row_Record(t *testing.T) {
+	assert.Equal(t, len(Columns), len(Row{}.Record()))
+}
@@ -0,0 +1,59 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/pkg/errors"
+)
+
+type rowWriter interface {
+	Write(row Row) error
+	Flush() error
+}
+
+func newRowWriter(format relayer.ExportFormat, w io.Writer) (rowWriter, error) {
+	switch format {
+	case relayer.CSVExportFormat:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(Columns); err != nil {
+			return nil, errors.Wrap(err, "cw.Write")
+		}
+
+		return &csvRowWriter{w: cw}, nil
+	case relayer.JSONExportFormat:
+		return &jsonRowWriter{enc: json.NewEncoder(w)}, nil
+	default:
+		return nil, relayer.ErrInvalidExportFormat
+	}
+}
+
+type csvRowWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvRowWriter) Write(row Row) error {
+	return c.w.Write(row.Record())
+}
+
+func (c *csvRowWriter) Flush() error {
+	c.w.Flush()
+
+	return c.w.Error()
+}
+
+// jsonRowWriter writes one JSON object per line, so the output can be
+// streamed instead of buffered into a single array.
+type jsonRowWriter struct {
+	enc *json.Encoder
+}
+
+func (j *jsonRowWriter) Write(row Row) error {
+	return j.enc.Encode(row)
+}
+
+func (j *jsonRowWriter) Flush() error {
+	return nil
+}
@@ -0,0 +1,379 @@
+package failover
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	defaultReprobeInterval = 30 * time.Second
+	probeTimeout           = 10 * time.Second
+)
+
+type DialOpts struct {
+	// URLs are the chain's endpoints in order of preference.
+	URLs []string
+	// ReprobeInterval is how often failed endpoints are checked to see if they've recovered,
+	// defaults to 30s.
+	ReprobeInterval time.Duration
+	// RequestTimeout bounds each attempt at a request, so an endpoint that hangs is failed
+	// over from rather than waited on. Zero means no timeout beyond the caller's.
+	RequestTimeout time.Duration
+	// Headers are sent with every request to the endpoint at the same index in URLs, and with
+	// the websocket handshake, i.e. an API key. Endpoints without an entry send none.
+	Headers []http.Header
+	// Transport tunes the connections to http(s) endpoints, the defaults if zero
+	Transport TransportOpts
+}
+
+type endpoint struct {
+	url     string
+	headers http.Header
+	// httpClient is shared by the chain's endpoints, so they share a transport
+	httpClient *http.Client
+
+	mu        sync.Mutex
+	rpcClient *rpc.Client
+	ethClient *ethclient.Client
+
+	healthy bool
+}
+
+// clients returns the endpoint's clients, dialing it if it hasn't been yet or a
+// previous dial failed.
+func (e *endpoint) clients(ctx context.Context) (*rpc.Client, *ethclient.Client, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.rpcClient == nil {
+		rpcClient, err := rpc.DialOptions(ctx, e.url, rpc.WithHeaders(e.headers), rpc.WithHTTPClient(e.httpClient))
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "rpc.DialOptions")
+		}
+
+		e.rpcClient = rpcClient
+		e.ethClient = ethclient.NewClient(rpcClient)
+	}
+
+	return e.rpcClient, e.ethClient, nil
+}
+
+// Client sends requests to one of a chain's RPC endpoints at a time. When the current endpoint
+// can't be reached, drops the connection, times out or answers with a 5xx, the request is retried
+// against the next healthy endpoint, which becomes the current one. Failed endpoints are
+// re-probed in the background, and become candidates again once they answer.
+//
+// It can be used anywhere an *ethclient.Client or *rpc.Client is, and subscriptions are made on the
+// current endpoint, so a subscription is dropped when its endpoint fails and resubscribing
+// moves it over.
+type Client struct {
+	mu        sync.Mutex
+	endpoints []*endpoint
+	current   int
+
+	requestTimeout time.Duration
+
+	quit      chan struct{}
+	closeOnce sync.Once
+}
+
+// SplitURLs splits a comma separated list of endpoint urls, like an <LAYER>_RPC_URL env var.
+func SplitURLs(s string) []string {
+	urls := make([]string, 0)
+
+	for _, u := range strings.Split(s, ",") {
+		if u = strings.TrimSpace(u); u != "" {
+			urls = append(urls, u)
+		}
+	}
+
+	return urls
+}
+
+// Dial connects to opts.URLs. Endpoints that can't be dialed yet are failed over from,
+// and only if none can be is an error returned.
+func Dial(ctx context.Context, opts DialOpts) (*Client, error) {
+	if len(opts.URLs) == 0 {
+		return nil, ErrNoEndpoints
+	}
+
+	c := &Client{
+		endpoints:      make([]*endpoint, 0, len(opts.URLs)),
+		requestTimeout: opts.RequestTimeout,
+		quit:           make(chan struct{}),
+	}
+
+	httpClient := newHTTPClient(opts.Transport)
+
+	var lastErr error
+
+	for i, u := range opts.URLs {
+		e := &endpoint{url: u, httpClient: httpClient}
+
+		if i < len(opts.Headers) && len(opts.Headers[i]) > 0 {
+			e.headers = opts.Headers[i]
+
+			log.Infof("rpc endpoint %v sends headers %v", redact(u), redactHeaders(e.headers))
+		}
+
+		if _, _, err := e.clients(ctx); err != nil {
+			log.Warnf("rpc endpoint %v: %v", redact(u), err)
+
+			lastErr = err
+		} else {
+			e.healthy = true
+		}
+
+		c.endpoints = append(c.endpoints, e)
+	}
+
+	current := c.nextHealthy(-1)
+	if current < 0 {
+		return nil, errors.Wrap(lastErr, "no rpc endpoint could be dialed")
+	}
+
+	c.current = current
+
+	interval := opts.ReprobeInterval
+	if interval <= 0 {
+		interval = defaultReprobeInterval
+	}
+
+	go c.reprobe(interval)
+
+	return c, nil
+}
+
+// Close stops re-probing and closes every endpoint's connection.
+func (c *Client) Close() {
+	c.closeOnce.Do(func() {
+		close(c.quit)
+
+		for _, e := range c.endpoints {
+			e.mu.Lock()
+			if e.rpcClient != nil {
+				e.rpcClient.Close()
+			}
+			e.mu.Unlock()
+		}
+	})
+}
+
+// URL is the endpoint requests are currently sent to, with any credentials removed.
+func (c *Client) URL() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return redact(c.endpoints[c.current].url)
+}
+
+// nextHealthy returns the index of the first healthy endpoint after from, wrapping around,
+// or -1 if there isn't one. c.mu must be held, or not yet shared.
+func (c *Client) nextHealthy(from int) int {
+	for i := 1; i <= len(c.endpoints); i++ {
+		next := (from + i + len(c.endpoints)) % len(c.endpoints)
+		if c.endpoints[next].healthy {
+			return next
+		}
+	}
+
+	return -1
+}
+
+// candidates are the endpoints to try a request against: the current one, the other healthy
+// ones after it, then the failed ones as a last resort, rather than failing outright.
+func (c *Client) candidates() []*endpoint {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	healthy := make([]*endpoint, 0, len(c.endpoints))
+	failed := make([]*endpoint, 0)
+
+	for i := 0; i < len(c.endpoints); i++ {
+		e := c.endpoints[(c.current+i)%len(c.endpoints)]
+		if e.healthy {
+			healthy = append(healthy, e)
+		} else {
+			failed = append(failed, e)
+		}
+	}
+
+	return append(healthy, failed...)
+}
+
+func (c *Client) failed() []*endpoint {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	failed := make([]*endpoint, 0)
+
+	for _, e := range c.endpoints {
+		if !e.healthy {
+			failed = append(failed, e)
+		}
+	}
+
+	return failed
+}
+
+func (c *Client) markFailed(e *endpoint, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !e.healthy {
+		return
+	}
+
+	e.healthy = false
+
+	if c.endpoints[c.current] != e {
+		return
+	}
+
+	relayer.RPCFailovers.Inc()
+
+	if next := c.nextHealthy(c.current); next >= 0 {
+		c.current = next
+
+		log.Warnf("rpc endpoint %v failed: %v, failing over to %v", redact(e.url), err, redact(c.endpoints[next].url))
+	} else {
+		log.Errorf("rpc endpoint %v failed: %v, no healthy endpoints left", redact(e.url), err)
+	}
+}
+
+// markHealthy brings e back, and makes it the current endpoint if the current one has failed.
+func (c *Client) markHealthy(e *endpoint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e.healthy {
+		return
+	}
+
+	e.healthy = true
+
+	log.Infof("rpc endpoint %v is healthy again", redact(e.url))
+
+	if !c.endpoints[c.current].healthy {
+		for i, candidate := range c.endpoints {
+			if candidate == e {
+				c.current = i
+			}
+		}
+	}
+}
+
+// reprobe checks failed endpoints every interval until the client is closed.
+func (c *Client) reprobe(interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-c.quit:
+			return
+		case <-t.C:
+			for _, e := range c.failed() {
+				c.probe(e)
+			}
+		}
+	}
+}
+
+func (c *Client) probe(e *endpoint) {
+	ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+	defer cancel()
+
+	_, ethClient, err := e.clients(ctx)
+	if err == nil {
+		_, err = ethClient.BlockNumber(ctx)
+	}
+
+	if err != nil {
+		log.Debugf("rpc endpoint %v still failing: %v", redact(e.url), err)
+		return
+	}
+
+	c.markHealthy(e)
+}
+
+// do calls f against each candidate endpoint in turn, until one doesn't fail with an
+// endpoint error, or for want of pruned state. The last error is returned if every endpoint fails.
+func do[T any](ctx context.Context, c *Client, f func(ctx context.Context, e *endpoint) (T, error)) (T, error) {
+	var (
+		result T
+		err    error
+	)
+
+	for _, e := range c.candidates() {
+		result, err = attempt(ctx, c, e, f)
+		if err == nil {
+			c.markHealthy(e)
+
+			return result, nil
+		}
+
+		if ctx.Err() != nil {
+			return result, err
+		}
+
+		// the endpoint no longer has the state the request is for, but another may be an archive
+		// node that does. It still answers everything else, so it isn't failed over from.
+		if relayer.IsStatePrunedError(err) {
+			continue
+		}
+
+		// every endpoint would answer the same
+		if !isEndpointError(err) {
+			return result, err
+		}
+
+		c.markFailed(e, err)
+	}
+
+	return result, err
+}
+
+func attempt[T any](
+	ctx context.Context,
+	c *Client,
+	e *endpoint,
+	f func(ctx context.Context, e *endpoint) (T, error),
+) (T, error) {
+	if c.requestTimeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, c.requestTimeout)
+		defer cancel()
+	}
+
+	return f(ctx, e)
+}
+
+// redact removes credentials from an endpoint url, many providers put an api key in the path,
+// so only the scheme and host are kept.
+func redact(u string) string {
+	scheme := ""
+	if i := strings.Index(u, "://"); i >= 0 {
+		scheme, u = u[:i+3], u[i+3:]
+	}
+
+	if i := strings.Index(u, "@"); i >= 0 {
+		u = u[i+1:]
+	}
+
+	if i := strings.IndexAny(u, "/?"); i >= 0 {
+		u = u[:i]
+	}
+
+	return scheme + u
+}
@@ -0,0 +1,94 @@
+package failover
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrNoEndpoints is returned by Dial when it isn't given any endpoint urls.
+	ErrNoEndpoints = errors.New("no rpc endpoints")
+	// ErrInvalidHeader is returned by ParseHeaders for a header that isn't `Name: value`.
+	ErrInvalidHeader = errors.New("invalid header, must be Name: value")
+)
+
+// connectionErrors are substrings of errors that mean the connection to the endpoint
+// was lost, for those that aren't typed, i.e. geth's unexported "connection lost".
+var connectionErrors = []string{
+	"connection lost",
+	"connection refused",
+	"connection reset",
+	"broken pipe",
+	"use of closed network connection",
+	"websocket: close",
+}
+
+// alreadySentErrors are substrings of the errors nodes answer a transaction with when they've
+// seen its nonce before, which may be because it's the same transaction.
+var alreadySentErrors = []string{
+	"already known",
+	"known transaction",
+	"nonce too low",
+}
+
+// isAlreadySentError reports whether err is a node refusing a transaction it may already have.
+func isAlreadySentError(err error) bool {
+	for _, s := range alreadySentErrors {
+		if strings.Contains(strings.ToLower(err.Error()), s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isEndpointError reports whether err is the endpoint's fault rather than the request's,
+// so the request is worth retrying against another endpoint: it couldn't be reached,
+// dropped the connection, timed out, or answered with a 5xx or 429. An error the node
+// answered with, like a revert, isn't, since every endpoint would answer the same.
+func isEndpointError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode >= http.StatusInternalServerError ||
+			httpErr.StatusCode == http.StatusTooManyRequests
+	}
+
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, rpc.ErrClientQuit) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+
+	for _, s := range connectionErrors {
+		if strings.Contains(err.Error(), s) {
+			return true
+		}
+	}
+
+	return false
+}
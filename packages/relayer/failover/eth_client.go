@@ -0,0 +1,280 @@
+package failover
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// the methods below are the *ethclient.Client and *rpc.Client methods the relayer uses,
+// sent to the current endpoint with failover.
+
+// noResult adapts calls that only return an error to do.
+type noResult struct{}
+
+func (c *Client) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	_, err := do(ctx, c, func(ctx context.Context, e *endpoint) (noResult, error) {
+		rpcClient, _, err := e.clients(ctx)
+		if err != nil {
+			return noResult{}, err
+		}
+
+		return noResult{}, rpcClient.CallContext(ctx, result, method, args...)
+	})
+
+	return err
+}
+
+func (c *Client) ChainID(ctx context.Context) (*big.Int, error) {
+	return do(ctx, c, func(ctx context.Context, e *endpoint) (*big.Int, error) {
+		_, ethClient, err := e.clients(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return ethClient.ChainID(ctx)
+	})
+}
+
+func (c *Client) BlockNumber(ctx context.Context) (uint64, error) {
+	return do(ctx, c, func(ctx context.Context, e *endpoint) (uint64, error) {
+		_, ethClient, err := e.clients(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		return ethClient.BlockNumber(ctx)
+	})
+}
+
+func (c *Client) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	return do(ctx, c, func(ctx context.Context, e *endpoint) (*types.Block, error) {
+		_, ethClient, err := e.clients(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return ethClient.BlockByHash(ctx, hash)
+	})
+}
+
+func (c *Client) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	return do(ctx, c, func(ctx context.Context, e *endpoint) (*types.Header, error) {
+		_, ethClient, err := e.clients(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return ethClient.HeaderByHash(ctx, hash)
+	})
+}
+
+func (c *Client) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return do(ctx, c, func(ctx context.Context, e *endpoint) (*types.Header, error) {
+		_, ethClient, err := e.clients(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return ethClient.HeaderByNumber(ctx, number)
+	})
+}
+
+func (c *Client) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	type txResult struct {
+		tx        *types.Transaction
+		isPending bool
+	}
+
+	r, err := do(ctx, c, func(ctx context.Context, e *endpoint) (txResult, error) {
+		_, ethClient, err := e.clients(ctx)
+		if err != nil {
+			return txResult{}, err
+		}
+
+		tx, isPending, err := ethClient.TransactionByHash(ctx, hash)
+
+		return txResult{tx, isPending}, err
+	})
+
+	return r.tx, r.isPending, err
+}
+
+func (c *Client) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return do(ctx, c, func(ctx context.Context, e *endpoint) (*types.Receipt, error) {
+		_, ethClient, err := e.clients(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return ethClient.TransactionReceipt(ctx, txHash)
+	})
+}
+
+func (c *Client) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return do(ctx, c, func(ctx context.Context, e *endpoint) (*big.Int, error) {
+		_, ethClient, err := e.clients(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return ethClient.BalanceAt(ctx, account, blockNumber)
+	})
+}
+
+func (c *Client) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return do(ctx, c, func(ctx context.Context, e *endpoint) ([]byte, error) {
+		_, ethClient, err := e.clients(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return ethClient.CodeAt(ctx, account, blockNumber)
+	})
+}
+
+func (c *Client) StorageAt(
+	ctx context.Context,
+	account common.Address,
+	key common.Hash,
+	blockNumber *big.Int,
+) ([]byte, error) {
+	return do(ctx, c, func(ctx context.Context, e *endpoint) ([]byte, error) {
+		_, ethClient, err := e.clients(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return ethClient.StorageAt(ctx, account, key, blockNumber)
+	})
+}
+
+func (c *Client) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	return do(ctx, c, func(ctx context.Context, e *endpoint) ([]byte, error) {
+		_, ethClient, err := e.clients(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return ethClient.PendingCodeAt(ctx, account)
+	})
+}
+
+func (c *Client) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return do(ctx, c, func(ctx context.Context, e *endpoint) (uint64, error) {
+		_, ethClient, err := e.clients(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		return ethClient.PendingNonceAt(ctx, account)
+	})
+}
+
+func (c *Client) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return do(ctx, c, func(ctx context.Context, e *endpoint) ([]byte, error) {
+		_, ethClient, err := e.clients(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return ethClient.CallContract(ctx, msg, blockNumber)
+	})
+}
+
+func (c *Client) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	return do(ctx, c, func(ctx context.Context, e *endpoint) (uint64, error) {
+		_, ethClient, err := e.clients(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		return ethClient.EstimateGas(ctx, msg)
+	})
+}
+
+func (c *Client) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return do(ctx, c, func(ctx context.Context, e *endpoint) (*big.Int, error) {
+		_, ethClient, err := e.clients(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return ethClient.SuggestGasPrice(ctx)
+	})
+}
+
+func (c *Client) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return do(ctx, c, func(ctx context.Context, e *endpoint) (*big.Int, error) {
+		_, ethClient, err := e.clients(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return ethClient.SuggestGasTipCap(ctx)
+	})
+}
+
+// SendTransaction resends tx to the next endpoint if the current one fails, which is safe since
+// a signed transaction can only be included once. If the failed endpoint had already broadcast it,
+// the next one may answer "already known", or "nonce too low" once it's mined. That's a success
+// when the endpoint has tx itself, so callers wait on its receipt rather than sending it again.
+func (c *Client) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	_, err := do(ctx, c, func(ctx context.Context, e *endpoint) (noResult, error) {
+		_, ethClient, err := e.clients(ctx)
+		if err != nil {
+			return noResult{}, err
+		}
+
+		err = ethClient.SendTransaction(ctx, tx)
+		if err != nil && isAlreadySentError(err) {
+			if _, _, lookupErr := ethClient.TransactionByHash(ctx, tx.Hash()); lookupErr == nil {
+				return noResult{}, nil
+			}
+		}
+
+		return noResult{}, err
+	})
+
+	return err
+}
+
+func (c *Client) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	return do(ctx, c, func(ctx context.Context, e *endpoint) ([]types.Log, error) {
+		_, ethClient, err := e.clients(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return ethClient.FilterLogs(ctx, q)
+	})
+}
+
+func (c *Client) SubscribeFilterLogs(
+	ctx context.Context,
+	q ethereum.FilterQuery,
+	ch chan<- types.Log,
+) (ethereum.Subscription, error) {
+	return do(ctx, c, func(ctx context.Context, e *endpoint) (ethereum.Subscription, error) {
+		_, ethClient, err := e.clients(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return ethClient.SubscribeFilterLogs(ctx, q, ch)
+	})
+}
+
+func (c *Client) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	return do(ctx, c, func(ctx context.Context, e *endpoint) (ethereum.Subscription, error) {
+		_, ethClient, err := e.clients(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return ethClient.SubscribeNewHead(ctx, ch)
+	})
+}
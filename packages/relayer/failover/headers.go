@@ -0,0 +1,53 @@
+package failover
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ParseHeaders parses a semicolon separated list of `Name: value` headers, like an
+// <LAYER>_RPC_HEADERS env var, i.e. "Authorization: Bearer abc; x-api-key: def".
+func ParseHeaders(s string) (http.Header, error) {
+	headers := make(http.Header)
+
+	for _, h := range strings.Split(s, ";") {
+		if h = strings.TrimSpace(h); h == "" {
+			continue
+		}
+
+		name, value, ok := strings.Cut(h, ":")
+		if name = strings.TrimSpace(name); !ok || name == "" {
+			return nil, errors.Wrapf(ErrInvalidHeader, "%q", redactHeader(h))
+		}
+
+		headers.Add(name, strings.TrimSpace(value))
+	}
+
+	return headers, nil
+}
+
+// redactHeaders lists the names of headers, with their values redacted, since they're
+// usually credentials
+func redactHeaders(headers http.Header) string {
+	names := make([]string, 0, len(headers))
+
+	for name := range headers {
+		names = append(names, name+": <redacted>")
+	}
+
+	sort.Strings(names)
+
+	return strings.Join(names, ", ")
+}
+
+// redactHeader is h without the value it would have if it had a name
+func redactHeader(h string) string {
+	if i := strings.Index(h, ":"); i >= 0 {
+		return h[:i] + ": <redacted>"
+	}
+
+	return "<redacted>"
+}
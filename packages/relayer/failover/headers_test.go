@@ -0,0 +1,89 @@
+package failover
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseHeaders(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    http.Header
+		wantErr error
+	}{
+		{"empty", "", http.Header{}, nil},
+		{
+			"several",
+			"Authorization: Bearer abc; x-api-key:def ;",
+			http.Header{"Authorization": {"Bearer abc"}, "X-Api-Key": {"def"}},
+			nil,
+		},
+		{"valueWithColon", "Authorization: Basic a:b", http.Header{"Authorization": {"Basic a:b"}}, nil},
+		{"noValue", "Authorization", nil, ErrInvalidHeader},
+		{"noName", ": abc", nil, ErrInvalidHeader},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers, err := ParseHeaders(tt.s)
+			assert.Equal(t, tt.wantErr, errors.Cause(err))
+			assert.Equal(t, tt.want, headers)
+		})
+	}
+}
+
+func Test_ParseHeaders_redactsInvalid(t *testing.T) {
+	_, err := ParseHeaders("Authorization Bearer secret")
+	assert.NotContains(t, err.Error(), "secret")
+}
+
+func Test_redactHeaders(t *testing.T) {
+	assert.Equal(
+		t,
+		"Authorization: <redacted>, X-Api-Key: <redacted>",
+		redactHeaders(http.Header{"X-Api-Key": {"def"}, "Authorization": {"Bearer abc"}}),
+	)
+}
+
+func Test_Client_sendsHeaders(t *testing.T) {
+	srv := rpc.NewServer()
+	assert.Nil(t, srv.RegisterName("eth", &ethService{blockNumber: 1}))
+
+	// like a provider requiring an API key
+	endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Api-Key") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		srv.ServeHTTP(w, r)
+	}))
+	defer endpoint.Close()
+
+	c, err := Dial(context.Background(), DialOpts{
+		URLs:    []string{endpoint.URL},
+		Headers: []http.Header{{"X-Api-Key": {"secret"}}},
+	})
+	assert.Nil(t, err)
+
+	defer c.Close()
+
+	blockNumber, err := c.BlockNumber(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), blockNumber)
+
+	withoutHeaders, err := Dial(context.Background(), DialOpts{URLs: []string{endpoint.URL}})
+	assert.Nil(t, err)
+
+	defer withoutHeaders.Close()
+
+	_, err = withoutHeaders.BlockNumber(context.Background())
+	assert.NotNil(t, err)
+}
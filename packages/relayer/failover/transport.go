@@ -0,0 +1,76 @@
+package failover
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"strconv"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+)
+
+var (
+	// DefaultMaxIdleConnsPerHost keeps enough connections to an endpoint open for the indexers',
+	// processors' and proof workers' concurrent requests, where Go's default of 2 closes all but two
+	// after every burst, and the next burst opens them again.
+	DefaultMaxIdleConnsPerHost = 64
+	DefaultIdleConnTimeout     = 90 * time.Second
+)
+
+// TransportOpts tunes the connections to a chain's http(s) endpoints. Websocket endpoints keep
+// a single connection open, and aren't affected.
+type TransportOpts struct {
+	// MaxIdleConnsPerHost is how many idle connections to each endpoint are kept open to be reused,
+	// DefaultMaxIdleConnsPerHost if 0
+	MaxIdleConnsPerHost int
+	// IdleConnTimeout is how long an idle connection is kept open, DefaultIdleConnTimeout if 0
+	IdleConnTimeout time.Duration
+	// DisableHTTP2 only speaks HTTP/1.1 to https endpoints. Otherwise HTTP/2 is negotiated with
+	// those that support it, multiplexing requests over one connection. Plain http endpoints always
+	// speak HTTP/1.1.
+	DisableHTTP2 bool
+}
+
+// newHTTPClient returns the client requests to http(s) endpoints are sent with, on a transport
+// built from opts, which counts whether each request reused a connection.
+func newHTTPClient(opts TransportOpts) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	transport.MaxIdleConnsPerHost = opts.MaxIdleConnsPerHost
+	if transport.MaxIdleConnsPerHost <= 0 {
+		transport.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	}
+
+	// bounded per endpoint instead
+	transport.MaxIdleConns = 0
+
+	transport.IdleConnTimeout = opts.IdleConnTimeout
+	if transport.IdleConnTimeout <= 0 {
+		transport.IdleConnTimeout = DefaultIdleConnTimeout
+	}
+
+	if opts.DisableHTTP2 {
+		// a non-nil, empty TLSNextProto stops the transport offering h2
+		transport.ForceAttemptHTTP2 = false
+		transport.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+	}
+
+	return &http.Client{Transport: &connReuseCounter{next: transport}}
+}
+
+// connReuseCounter counts the connections requests are sent on in relayer.RPCConnections, by
+// whether an idle one was reused or a new one opened
+type connReuseCounter struct {
+	next http.RoundTripper
+}
+
+func (t *connReuseCounter) RoundTrip(r *http.Request) (*http.Response, error) {
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			relayer.RPCConnections.WithLabelValues(strconv.FormatBool(info.Reused)).Inc()
+		},
+	}
+
+	return t.next.RoundTrip(r.WithContext(httptrace.WithClientTrace(r.Context(), trace)))
+}
@@ -0,0 +1,90 @@
+package failover
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newHTTPClient(t *testing.T) {
+	tests := []struct {
+		name                    string
+		opts                    TransportOpts
+		wantMaxIdleConnsPerHost int
+		wantIdleConnTimeout     time.Duration
+	}{
+		{"defaults", TransportOpts{}, DefaultMaxIdleConnsPerHost, DefaultIdleConnTimeout},
+		{"set", TransportOpts{MaxIdleConnsPerHost: 8, IdleConnTimeout: time.Minute}, 8, time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := newHTTPClient(tt.opts).Transport.(*connReuseCounter).next.(*http.Transport)
+
+			assert.Equal(t, tt.wantMaxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
+			assert.Equal(t, tt.wantIdleConnTimeout, transport.IdleConnTimeout)
+			assert.Equal(t, 0, transport.MaxIdleConns)
+		})
+	}
+}
+
+func Test_newHTTPClient_http2(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+
+	defer srv.Close()
+
+	tests := []struct {
+		name           string
+		disableHTTP2   bool
+		wantProtoMajor int
+	}{
+		{"enabled", false, 2},
+		{"disabled", true, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newHTTPClient(TransportOpts{DisableHTTP2: tt.disableHTTP2})
+
+			// trust the test server's certificate
+			transport := client.Transport.(*connReuseCounter).next.(*http.Transport)
+			transport.TLSClientConfig = srv.Client().Transport.(*http.Transport).TLSClientConfig.Clone()
+
+			res, err := client.Get(srv.URL)
+			assert.Nil(t, err)
+
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.wantProtoMajor, res.ProtoMajor)
+		})
+	}
+}
+
+func Test_Client_reusesConnections(t *testing.T) {
+	e := newTestEndpoint(t, 1)
+
+	c, err := Dial(context.Background(), DialOpts{URLs: []string{e.URL}})
+	assert.Nil(t, err)
+
+	defer c.Close()
+
+	reused := testutil.ToFloat64(relayer.RPCConnections.WithLabelValues("true"))
+	opened := testutil.ToFloat64(relayer.RPCConnections.WithLabelValues("false"))
+
+	for i := 0; i < 5; i++ {
+		_, err := c.BlockNumber(context.Background())
+		assert.Nil(t, err)
+	}
+
+	// the first request opens a connection, which the rest reuse
+	assert.Equal(t, float64(1), testutil.ToFloat64(relayer.RPCConnections.WithLabelValues("false"))-opened)
+	assert.Equal(t, float64(4), testutil.ToFloat64(relayer.RPCConnections.WithLabelValues("true"))-reused)
+}
@@ -0,0 +1,19 @@
+package relayer
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// FinalizedBlock is an execution block the chain's consensus has finalized
+type FinalizedBlock struct {
+	Number uint64
+	Hash   common.Hash
+}
+
+// FinalityOracle tells which execution block a chain's consensus has finalized, independently of
+// the execution node's own finalized tag
+type FinalityOracle interface {
+	FinalizedBlock(ctx context.Context) (*FinalizedBlock, error)
+}
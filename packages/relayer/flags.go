@@ -23,9 +23,85 @@ var (
 	FilterWatchMode             WatchMode = "filter"
 	SubscribeWatchMode          WatchMode = "subscribe"
 	FilterAndSubscribeWatchMode WatchMode = "filter-and-subscribe"
-	WatchModes                            = []WatchMode{FilterWatchMode, SubscribeWatchMode}
+	PollWatchMode               WatchMode = "poll"
+	WatchModes                            = []WatchMode{FilterWatchMode, SubscribeWatchMode, PollWatchMode}
+)
+
+type ExportFormat string
+
+var (
+	CSVExportFormat  ExportFormat = "csv"
+	JSONExportFormat ExportFormat = "json"
+	ExportFormats                 = []ExportFormat{CSVExportFormat, JSONExportFormat}
+)
+
+// KeySelection is how processMessage transactions are spread across the relayer's keys
+type KeySelection string
+
+var (
+	RoundRobinKeySelection KeySelection = "round-robin"
+	HashKeySelection       KeySelection = "hash"
+	KeySelections                       = []KeySelection{RoundRobinKeySelection, HashKeySelection}
+)
+
+// RetryReason is why processing a message failed, when retrying it later may succeed
+type RetryReason string
+
+var (
+	// RetryReasonNotSynced is the destination chain not having synced what the message's proof needs yet
+	RetryReasonNotSynced RetryReason = "not-synced"
+	// RetryReasonGasTooHigh is the transaction costing more than the message pays, or than the relayer
+	// keys can afford
+	RetryReasonGasTooHigh RetryReason = "gas-too-high"
+	// RetryReasonTransient is any other failure, i.e. an RPC erroring or timing out
+	RetryReasonTransient RetryReason = "transient"
+	RetryReasons                     = []RetryReason{RetryReasonNotSynced, RetryReasonGasTooHigh, RetryReasonTransient}
+)
+
+// RejectionReason is why a message can't be processed, as reported by the API and recorded by the
+// processor, for integrators to act on without matching on error strings
+type RejectionReason string
+
+var (
+	// RejectionReasonNotYetSynced is the destination chain not having synced the block the message's
+	// proof needs yet
+	RejectionReasonNotYetSynced RejectionReason = "not-yet-synced"
+	// RejectionReasonSignalNotSent is the source chain's SignalService having no record of the message
+	RejectionReasonSignalNotSent RejectionReason = "signal-not-sent"
+	// RejectionReasonStatePruned is the source node no longer having the state the proof needs
+	RejectionReasonStatePruned RejectionReason = "state-pruned"
+	// RejectionReasonOutOfScope is the message being for a destination chain that isn't relayed to
+	RejectionReasonOutOfScope RejectionReason = "out-of-scope"
+	// RejectionReasonExpired is the message having been new for longer than the max message age
+	RejectionReasonExpired RejectionReason = "expired"
+	// RejectionReasonBlocked is the message's sender or recipient being on the blocklist
+	RejectionReasonBlocked RejectionReason = "blocked"
+	RejectionReasons                       = []RejectionReason{
+		RejectionReasonNotYetSynced,
+		RejectionReasonSignalNotSent,
+		RejectionReasonStatePruned,
+		RejectionReasonOutOfScope,
+		RejectionReasonExpired,
+		RejectionReasonBlocked,
+	}
 )
 
 type HTTPOnly bool
 
 type ProfitableOnly bool
+
+type ProcessingPaused bool
+
+type OrderedDelivery bool
+
+// Role is whether an instance sends processMessage transactions, or indexes on standby
+// ready to take over, when leader election is on
+type Role string
+
+var (
+	// LeaderRole is the instance holding the leader lease, the only one sending transactions
+	LeaderRole Role = "leader"
+	// StandbyRole is an instance indexing without sending, until it takes the leader lease
+	StandbyRole Role = "standby"
+	Roles            = []Role{LeaderRole, StandbyRole}
+)
@@ -0,0 +1,16 @@
+package forwarder
+
+import "github.com/pkg/errors"
+
+var (
+	// ErrNoForwarderAddress is returned by New without the forwarder's address.
+	ErrNoForwarderAddress = errors.New("forwarder address is required")
+	// ErrNoBridgeAddress is returned by New without the destination bridge's address.
+	ErrNoBridgeAddress = errors.New("bridge address is required")
+	// ErrNoBackend is returned by New without a destination chain client.
+	ErrNoBackend = errors.New("backend is required")
+	// ErrNoECDSAKey is returned by New without the key to sign forward requests with.
+	ErrNoECDSAKey = errors.New("ecdsa key is required")
+	// ErrNoGasLimit is returned by ProcessMessage when the processor didn't set a gas limit.
+	ErrNoGasLimit = errors.New("gas limit is required to forward a call")
+)
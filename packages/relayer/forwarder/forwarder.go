@@ -0,0 +1,308 @@
+// Package forwarder sends processMessage transactions through an ERC-2771 forwarder, i.e.
+// OpenZeppelin's MinimalForwarder, so a gas sponsor can pay for them. The relayer signs a
+// ForwardRequest for the bridge call, and calls the forwarder's execute with it.
+package forwarder
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/pkg/errors"
+)
+
+// nolint: lll
+const forwarderABIJSON = `[{"inputs":[{"internalType":"address","name":"from","type":"address"}],"name":"getNonce","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[{"components":[{"internalType":"address","name":"from","type":"address"},{"internalType":"address","name":"to","type":"address"},{"internalType":"uint256","name":"value","type":"uint256"},{"internalType":"uint256","name":"gas","type":"uint256"},{"internalType":"uint256","name":"nonce","type":"uint256"},{"internalType":"bytes","name":"data","type":"bytes"}],"internalType":"struct MinimalForwarder.ForwardRequest","name":"req","type":"tuple"},{"internalType":"bytes","name":"signature","type":"bytes"}],"name":"execute","outputs":[{"internalType":"bool","name":"","type":"bool"},{"internalType":"bytes","name":"","type":"bytes"}],"stateMutability":"payable","type":"function"}]`
+
+const (
+	DefaultDomainName    = "MinimalForwarder"
+	DefaultDomainVersion = "0.0.1"
+
+	// gasOverhead is the gas execute uses on top of the call it forwards, for verifying the
+	// signature and bumping the nonce
+	gasOverhead = 50000
+)
+
+var (
+	forwarderABI = mustParseABI(forwarderABIJSON)
+	bridgeABI    = mustBridgeABI()
+)
+
+func mustParseABI(s string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(s))
+	if err != nil {
+		panic(err)
+	}
+
+	return parsed
+}
+
+func mustBridgeABI() abi.ABI {
+	parsed, err := bridge.BridgeMetaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+
+	return *parsed
+}
+
+// ForwardRequest is MinimalForwarder's ForwardRequest struct
+type ForwardRequest struct {
+	From  common.Address
+	To    common.Address
+	Value *big.Int
+	Gas   *big.Int
+	Nonce *big.Int
+	Data  []byte
+}
+
+type backend interface {
+	bind.ContractBackend
+	ChainID(ctx context.Context) (*big.Int, error)
+}
+
+type Forwarder struct {
+	address       common.Address
+	bridgeAddress common.Address
+	contract      *bind.BoundContract
+	backend       backend
+
+	ecdsaKey *ecdsa.PrivateKey
+	from     common.Address
+
+	domainName    string
+	domainVersion string
+
+	mu      sync.Mutex
+	chainID *big.Int
+	// nextNonce is the forward request nonce after the last one sent, like the processor's own
+	// nonce it runs ahead of the forwarder's while requests are in flight
+	nextNonce *big.Int
+}
+
+type NewOpts struct {
+	// Address is the forwarder's, on the destination chain
+	Address common.Address
+	// BridgeAddress is the destination bridge's, which requests are forwarded to
+	BridgeAddress common.Address
+	Backend       backend
+	// ECDSAKey signs forward requests, which come from its address
+	ECDSAKey *ecdsa.PrivateKey
+	// DomainName and DomainVersion are the forwarder's EIP-712 domain, MinimalForwarder's
+	// if empty
+	DomainName    string
+	DomainVersion string
+}
+
+func New(opts NewOpts) (*Forwarder, error) {
+	if opts.Address == (common.Address{}) {
+		return nil, ErrNoForwarderAddress
+	}
+
+	if opts.BridgeAddress == (common.Address{}) {
+		return nil, ErrNoBridgeAddress
+	}
+
+	if opts.Backend == nil {
+		return nil, ErrNoBackend
+	}
+
+	if opts.ECDSAKey == nil {
+		return nil, ErrNoECDSAKey
+	}
+
+	f := &Forwarder{
+		address:       opts.Address,
+		bridgeAddress: opts.BridgeAddress,
+		contract:      bind.NewBoundContract(opts.Address, forwarderABI, opts.Backend, opts.Backend, opts.Backend),
+		backend:       opts.Backend,
+		ecdsaKey:      opts.ECDSAKey,
+		from:          crypto.PubkeyToAddress(opts.ECDSAKey.PublicKey),
+		domainName:    opts.DomainName,
+		domainVersion: opts.DomainVersion,
+		nextNonce:     new(big.Int),
+	}
+
+	if f.domainName == "" {
+		f.domainName = DefaultDomainName
+	}
+
+	if f.domainVersion == "" {
+		f.domainVersion = DefaultDomainVersion
+	}
+
+	return f, nil
+}
+
+// ProcessMessage signs a ForwardRequest to call processMessage on the bridge with opts' gas
+// limit, and sends it to the forwarder's execute, with opts' nonce and fees and enough gas
+// for the forwarder on top.
+func (f *Forwarder) ProcessMessage(
+	opts *bind.TransactOpts,
+	message bridge.IBridgeMessage,
+	proof []byte,
+) (*types.Transaction, error) {
+	if opts.GasLimit == 0 {
+		return nil, ErrNoGasLimit
+	}
+
+	data, err := bridgeABI.Pack("processMessage", message, proof)
+	if err != nil {
+		return nil, errors.Wrap(err, "bridgeABI.Pack")
+	}
+
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	chainID, err := f.getChainID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "f.getChainID")
+	}
+
+	nonce, err := f.nonce(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "f.nonce")
+	}
+
+	req := ForwardRequest{
+		From:  f.from,
+		To:    f.bridgeAddress,
+		Value: new(big.Int),
+		Gas:   new(big.Int).SetUint64(opts.GasLimit),
+		Nonce: nonce,
+		Data:  data,
+	}
+
+	signature, err := f.Sign(chainID, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "f.Sign")
+	}
+
+	executeOpts := *opts
+	// execute only forwards 63/64ths of what's left to the call, EIP-150
+	executeOpts.GasLimit = opts.GasLimit + opts.GasLimit/63 + gasOverhead
+
+	tx, err := f.contract.Transact(&executeOpts, "execute", req, signature)
+	if err != nil {
+		return nil, errors.Wrap(err, "f.contract.Transact")
+	}
+
+	f.nextNonce = new(big.Int).Add(nonce, common.Big1)
+
+	return tx, nil
+}
+
+// ResetNonce forgets the requests sent, so the next one uses the forwarder's nonce, as a
+// request whose transaction was never mined leaves a gap the ones after it would be stuck behind.
+func (f *Forwarder) ResetNonce() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.nextNonce = new(big.Int)
+}
+
+// Sign returns the forwarder's EIP-712 signature of req, from the signing key.
+func (f *Forwarder) Sign(chainID *big.Int, req ForwardRequest) ([]byte, error) {
+	hash, _, err := apitypes.TypedDataAndHash(f.typedData(chainID, req))
+	if err != nil {
+		return nil, errors.Wrap(err, "apitypes.TypedDataAndHash")
+	}
+
+	signature, err := crypto.Sign(hash, f.ecdsaKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "crypto.Sign")
+	}
+
+	// ecrecover expects v to be 27 or 28
+	signature[crypto.RecoveryIDOffset] += 27
+
+	return signature, nil
+}
+
+func (f *Forwarder) typedData(chainID *big.Int, req ForwardRequest) apitypes.TypedData {
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"ForwardRequest": {
+				{Name: "from", Type: "address"},
+				{Name: "to", Type: "address"},
+				{Name: "value", Type: "uint256"},
+				{Name: "gas", Type: "uint256"},
+				{Name: "nonce", Type: "uint256"},
+				{Name: "data", Type: "bytes"},
+			},
+		},
+		PrimaryType: "ForwardRequest",
+		Domain: apitypes.TypedDataDomain{
+			Name:              f.domainName,
+			Version:           f.domainVersion,
+			ChainId:           (*math.HexOrDecimal256)(chainID),
+			VerifyingContract: f.address.Hex(),
+		},
+		Message: apitypes.TypedDataMessage{
+			"from":  req.From.Hex(),
+			"to":    req.To.Hex(),
+			"value": req.Value.String(),
+			"gas":   req.Gas.String(),
+			"nonce": req.Nonce.String(),
+			"data":  hexutil.Encode(req.Data),
+		},
+	}
+}
+
+func (f *Forwarder) getChainID(ctx context.Context) (*big.Int, error) {
+	if f.chainID != nil {
+		return f.chainID, nil
+	}
+
+	chainID, err := f.backend.ChainID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "f.backend.ChainID")
+	}
+
+	f.chainID = chainID
+
+	return chainID, nil
+}
+
+// nonce is the forwarder's nonce for the signing key, or the one after the last request sent
+// if that's ahead. f.mu must be held.
+func (f *Forwarder) nonce(ctx context.Context) (*big.Int, error) {
+	var out []interface{}
+
+	if err := f.contract.Call(&bind.CallOpts{Context: ctx}, &out, "getNonce", f.from); err != nil {
+		return nil, errors.Wrap(err, "f.contract.Call(getNonce)")
+	}
+
+	nonce, ok := out[0].(*big.Int)
+	if !ok {
+		return nil, errors.New("unexpected getNonce return type")
+	}
+
+	if f.nextNonce.Cmp(nonce) > 0 {
+		return new(big.Int).Set(f.nextNonce), nil
+	}
+
+	return nonce, nil
+}
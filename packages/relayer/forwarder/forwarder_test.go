@@ -0,0 +1,235 @@
+package forwarder
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	dummyEcdsaKey    = "8da4ef21b864d2cc526dbdb2a120bd2874c36c9d0a1fb7f8c63d7f7a8b41de8f"
+	forwarderAddress = common.HexToAddress("0x1000777700000000000000000000000000000009")
+	bridgeAddress    = common.HexToAddress("0x1000777700000000000000000000000000000004")
+	chainID          = big.NewInt(5167003)
+)
+
+// testBackend answers getNonce with nonce, and records the transactions sent
+type testBackend struct {
+	bind.ContractBackend
+
+	nonce int64
+	sent  []*types.Transaction
+}
+
+func (b *testBackend) ChainID(ctx context.Context) (*big.Int, error) {
+	return chainID, nil
+}
+
+func (b *testBackend) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{0x1}, nil
+}
+
+func (b *testBackend) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return forwarderABI.Methods["getNonce"].Outputs.Pack(big.NewInt(b.nonce))
+}
+
+func (b *testBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	b.sent = append(b.sent, tx)
+
+	return nil
+}
+
+func newTestForwarder(t *testing.T, backend *testBackend) (*Forwarder, *ecdsa.PrivateKey) {
+	key, err := crypto.HexToECDSA(dummyEcdsaKey)
+	assert.Nil(t, err)
+
+	f, err := New(NewOpts{
+		Address:       forwarderAddress,
+		BridgeAddress: bridgeAddress,
+		Backend:       backend,
+		ECDSAKey:      key,
+	})
+	assert.Nil(t, err)
+
+	return f, key
+}
+
+func Test_New(t *testing.T) {
+	key, _ := crypto.HexToECDSA(dummyEcdsaKey)
+
+	tests := []struct {
+		name    string
+		opts    NewOpts
+		wantErr error
+	}{
+		{
+			"success",
+			NewOpts{Address: forwarderAddress, BridgeAddress: bridgeAddress, Backend: &testBackend{}, ECDSAKey: key},
+			nil,
+		},
+		{
+			"noAddress",
+			NewOpts{BridgeAddress: bridgeAddress, Backend: &testBackend{}, ECDSAKey: key},
+			ErrNoForwarderAddress,
+		},
+		{
+			"noBridgeAddress",
+			NewOpts{Address: forwarderAddress, Backend: &testBackend{}, ECDSAKey: key},
+			ErrNoBridgeAddress,
+		},
+		{
+			"noBackend",
+			NewOpts{Address: forwarderAddress, BridgeAddress: bridgeAddress, ECDSAKey: key},
+			ErrNoBackend,
+		},
+		{
+			"noECDSAKey",
+			NewOpts{Address: forwarderAddress, BridgeAddress: bridgeAddress, Backend: &testBackend{}},
+			ErrNoECDSAKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.opts)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+// Test_Sign checks the signature against the digest MinimalForwarder verifies, built by hand
+func Test_Sign(t *testing.T) {
+	f, key := newTestForwarder(t, &testBackend{})
+
+	req := ForwardRequest{
+		From:  crypto.PubkeyToAddress(key.PublicKey),
+		To:    bridgeAddress,
+		Value: big.NewInt(0),
+		Gas:   big.NewInt(100000),
+		Nonce: big.NewInt(7),
+		Data:  []byte{0x1, 0x2, 0x3},
+	}
+
+	signature, err := f.Sign(chainID, req)
+	assert.Nil(t, err)
+	assert.Len(t, signature, 65)
+	assert.Contains(t, []byte{27, 28}, signature[64])
+
+	word := func(b []byte) []byte { return common.LeftPadBytes(b, 32) }
+
+	domainSeparator := crypto.Keccak256(
+		crypto.Keccak256([]byte("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)")),
+		crypto.Keccak256([]byte(DefaultDomainName)),
+		crypto.Keccak256([]byte(DefaultDomainVersion)),
+		word(chainID.Bytes()),
+		word(forwarderAddress.Bytes()),
+	)
+
+	structHash := crypto.Keccak256(
+		crypto.Keccak256([]byte("ForwardRequest(address from,address to,uint256 value,uint256 gas,uint256 nonce,bytes data)")),
+		word(req.From.Bytes()),
+		word(req.To.Bytes()),
+		word(req.Value.Bytes()),
+		word(req.Gas.Bytes()),
+		word(req.Nonce.Bytes()),
+		crypto.Keccak256(req.Data),
+	)
+
+	digest := crypto.Keccak256([]byte("\x19\x01"), domainSeparator, structHash)
+
+	sig := append([]byte{}, signature...)
+	sig[64] -= 27
+
+	pub, err := crypto.SigToPub(digest, sig)
+	assert.Nil(t, err)
+	assert.Equal(t, req.From, crypto.PubkeyToAddress(*pub))
+}
+
+func Test_ProcessMessage(t *testing.T) {
+	backend := &testBackend{nonce: 5}
+	f, key := newTestForwarder(t, backend)
+
+	message := bridge.IBridgeMessage{
+		Id:            big.NewInt(1),
+		SrcChainId:    big.NewInt(1),
+		DestChainId:   chainID,
+		DepositValue:  big.NewInt(0),
+		CallValue:     big.NewInt(0),
+		ProcessingFee: big.NewInt(0),
+		GasLimit:      big.NewInt(1),
+	}
+	proof := []byte{0x4}
+
+	send := func(nonce int64) *ForwardRequest {
+		auth, err := bind.NewKeyedTransactorWithChainID(key, chainID)
+		assert.Nil(t, err)
+
+		auth.Nonce = big.NewInt(nonce)
+		auth.GasLimit = 100000
+		auth.GasPrice = big.NewInt(1)
+
+		tx, err := f.ProcessMessage(auth, message, proof)
+		assert.Nil(t, err)
+
+		// sent to the forwarder with the processor's nonce, and gas for the forwarder on top
+		assert.Equal(t, forwarderAddress, *tx.To())
+		assert.Equal(t, uint64(nonce), tx.Nonce())
+		assert.Equal(t, uint64(100000+100000/63+gasOverhead), tx.Gas())
+		assert.Equal(t, tx, backend.sent[len(backend.sent)-1])
+
+		args, err := forwarderABI.Methods["execute"].Inputs.Unpack(tx.Data()[4:])
+		assert.Nil(t, err)
+
+		req := *abi.ConvertType(args[0], new(ForwardRequest)).(*ForwardRequest)
+
+		wantData, err := bridgeABI.Pack("processMessage", message, proof)
+		assert.Nil(t, err)
+
+		assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey), req.From)
+		assert.Equal(t, bridgeAddress, req.To)
+		assert.Equal(t, big.NewInt(100000), req.Gas)
+		assert.Equal(t, wantData, req.Data)
+
+		wantSignature, err := f.Sign(chainID, req)
+		assert.Nil(t, err)
+		assert.Equal(t, wantSignature, args[1])
+
+		return &req
+	}
+
+	assert.Equal(t, big.NewInt(5), send(1).Nonce)
+
+	// the first request hasn't been executed, so the next one is after it
+	assert.Equal(t, big.NewInt(6), send(2).Nonce)
+
+	// and once the forwarder's ahead, i.e. requests were sent by another relayer, it's used
+	backend.nonce = 10
+	assert.Equal(t, big.NewInt(10), send(3).Nonce)
+
+	// a request that's never mined leaves a gap, so once reset the forwarder's nonce is used again
+	assert.Equal(t, big.NewInt(11), send(4).Nonce)
+	f.ResetNonce()
+	assert.Equal(t, big.NewInt(10), send(5).Nonce)
+}
+
+func Test_ProcessMessage_noGasLimit(t *testing.T) {
+	backend := &testBackend{}
+	f, key := newTestForwarder(t, backend)
+
+	auth, err := bind.NewKeyedTransactorWithChainID(key, chainID)
+	assert.Nil(t, err)
+
+	_, err = f.ProcessMessage(auth, bridge.IBridgeMessage{}, nil)
+	assert.Equal(t, ErrNoGasLimit, err)
+	assert.Empty(t, backend.sent)
+}
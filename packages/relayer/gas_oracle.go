@@ -0,0 +1,11 @@
+package relayer
+
+import (
+	"context"
+	"math/big"
+)
+
+// GasOracle suggests the price per gas, in wei, a transaction needs to pay to be included soon
+type GasOracle interface {
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+}
@@ -0,0 +1,55 @@
+package relayer
+
+import (
+	"context"
+	"math/big"
+	"time"
+)
+
+// GasSpend is a database model recording the gas a mined processMessage transaction cost the relayer
+// on the chain with ChainID, whether it succeeded or reverted
+type GasSpend struct {
+	ID      int    `json:"id"`
+	ChainID int64  `json:"chainID"`
+	MsgHash string `json:"msgHash"`
+	TxHash  string `json:"txHash"`
+	GasUsed uint64 `json:"gasUsed"`
+	// GasSpent is GasUsed at the transaction's effective gas price, in wei
+	GasSpent string `json:"gasSpent"`
+	// CreatedAt is when the transaction's receipt was found
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// SaveGasSpendOpts is required to store a new GasSpend
+type SaveGasSpendOpts struct {
+	ChainID  *big.Int
+	MsgHash  string
+	TxHash   string
+	GasUsed  uint64
+	GasSpent *big.Int
+}
+
+// GasSpendRepository is used to interact with the gas spent processing messages in the store
+type GasSpendRepository interface {
+	Save(ctx context.Context, opts SaveGasSpendOpts) error
+	// SpentWithin is the gas, in wei, spent on chainID by the transactions recorded in the last window
+	SpentWithin(ctx context.Context, chainID *big.Int, window time.Duration) (*big.Int, error)
+}
+
+// Runway is how long the relayer keys' balance on a chain lasts at the rate gas was spent there over
+// a rolling window. Amounts are in wei.
+type Runway struct {
+	ChainID       int64   `json:"chainID"`
+	Balance       string  `json:"balance"`
+	WindowInHours float64 `json:"windowInHours"`
+	// GasSpentInWindow is what was spent in the window, and GasSpentPerHour its hourly average
+	GasSpentInWindow string `json:"gasSpentInWindow"`
+	GasSpentPerHour  string `json:"gasSpentPerHour"`
+	// HoursRemaining is how long the balance lasts at that rate, nil if nothing was spent in the window
+	HoursRemaining *float64 `json:"hoursRemaining"`
+}
+
+// RunwayReporter reports the runway of the relayer's balance on a chain
+type RunwayReporter interface {
+	Runway(ctx context.Context) (*Runway, error)
+}
@@ -0,0 +1,14 @@
+package gasoracle
+
+import "github.com/pkg/errors"
+
+var (
+	// ErrUnknownSource is returned by New for a source name it doesn't know.
+	ErrUnknownSource = errors.New("unknown gas oracle source")
+	// ErrNoSources is returned by New and NewMax when there's nothing to combine.
+	ErrNoSources = errors.New("no gas oracle sources")
+	// ErrInvalidFixedPrice is returned for a fixed source without a positive price.
+	ErrInvalidFixedPrice = errors.New("fixed gas price must be positive")
+	// ErrNoMxcL2 is returned for an mxcl2 source without an MxcL2 contract.
+	ErrNoMxcL2 = errors.New("mxcl2 gas oracle source needs the destination MxcL2 address")
+)
@@ -0,0 +1,23 @@
+package gasoracle
+
+import (
+	"context"
+	"math/big"
+)
+
+// Fixed always suggests the same price. Combined with other sources by Max, it's a floor.
+type Fixed struct {
+	price *big.Int
+}
+
+func NewFixed(price *big.Int) (*Fixed, error) {
+	if price == nil || price.Sign() <= 0 {
+		return nil, ErrInvalidFixedPrice
+	}
+
+	return &Fixed{price: new(big.Int).Set(price)}, nil
+}
+
+func (o *Fixed) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return new(big.Int).Set(o.price), nil
+}
@@ -0,0 +1,29 @@
+package gasoracle
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewFixed(t *testing.T) {
+	_, err := NewFixed(nil)
+	assert.Equal(t, ErrInvalidFixedPrice, err)
+
+	_, err = NewFixed(big.NewInt(0))
+	assert.Equal(t, ErrInvalidFixedPrice, err)
+
+	price := big.NewInt(5)
+
+	o, err := NewFixed(price)
+	assert.Nil(t, err)
+
+	// changing the caller's value doesn't change the oracle's
+	price.SetInt64(6)
+
+	got, err := o.SuggestGasPrice(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(5), got)
+}
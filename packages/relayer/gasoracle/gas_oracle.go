@@ -0,0 +1,82 @@
+package gasoracle
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/pkg/errors"
+)
+
+// Source names a GasOracle implementation for New
+type Source string
+
+var (
+	SourceNode  Source = "node"
+	SourceFixed Source = "fixed"
+	SourceMxcL2 Source = "mxcl2"
+)
+
+type client interface {
+	gasPricer
+	headerGetter
+}
+
+type NewOpts struct {
+	// Sources is a comma separated list of source names, combined by taking the highest price,
+	// i.e. "mxcl2,fixed" for the predicted basefee with a floor.
+	Sources string
+	// Client is the destination chain's, for the node and mxcl2 sources.
+	Client client
+	// FixedPrice is the fixed source's price, in wei.
+	FixedPrice *big.Int
+	// MxcL2 is the destination chain's MxcL2 contract, for the mxcl2 source. It is nil if the
+	// destination doesn't have one.
+	MxcL2 basefeePredictor
+}
+
+// New builds the GasOracle for opts.Sources, or nil if it's empty, so callers keep using
+// the node's suggested fees as is.
+func New(opts NewOpts) (relayer.GasOracle, error) {
+	if strings.TrimSpace(opts.Sources) == "" {
+		return nil, nil
+	}
+
+	sources := make([]relayer.GasOracle, 0)
+
+	for _, name := range strings.Split(opts.Sources, ",") {
+		var (
+			source relayer.GasOracle
+			err    error
+		)
+
+		switch Source(strings.ToLower(strings.TrimSpace(name))) {
+		case SourceNode:
+			source = NewNode(opts.Client)
+		case SourceFixed:
+			source, err = NewFixed(opts.FixedPrice)
+		case SourceMxcL2:
+			if opts.MxcL2 == nil {
+				return nil, ErrNoMxcL2
+			}
+
+			source, err = NewMxcL2(opts.MxcL2, opts.Client)
+		case "":
+			continue
+		default:
+			return nil, errors.Wrap(ErrUnknownSource, name)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		sources = append(sources, source)
+	}
+
+	if len(sources) == 1 {
+		return sources[0], nil
+	}
+
+	return NewMax(sources...)
+}
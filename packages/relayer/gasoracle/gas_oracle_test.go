@@ -0,0 +1,73 @@
+package gasoracle
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_New(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      NewOpts
+		wantPrice *big.Int
+		wantErr   error
+	}{
+		{
+			"unset",
+			NewOpts{},
+			nil,
+			nil,
+		},
+		{
+			"node",
+			NewOpts{Sources: "node", Client: &mock.EthClient{}},
+			big.NewInt(100),
+			nil,
+		},
+		{
+			"nodeWithFloor",
+			NewOpts{Sources: " Node, fixed ", Client: &mock.EthClient{}, FixedPrice: big.NewInt(200)},
+			big.NewInt(200),
+			nil,
+		},
+		{
+			"fixedWithoutPrice",
+			NewOpts{Sources: "fixed"},
+			nil,
+			ErrInvalidFixedPrice,
+		},
+		{
+			"mxcl2WithoutContract",
+			NewOpts{Sources: "mxcl2,fixed", Client: &mock.EthClient{}, FixedPrice: big.NewInt(1)},
+			nil,
+			ErrNoMxcL2,
+		},
+		{
+			"unknown",
+			NewOpts{Sources: "oracle"},
+			nil,
+			ErrUnknownSource,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o, err := New(tt.opts)
+			assert.Equal(t, tt.wantErr, errors.Cause(err))
+
+			if tt.wantPrice == nil {
+				assert.Nil(t, o)
+				return
+			}
+
+			price, err := o.SuggestGasPrice(context.Background())
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantPrice, price)
+		})
+	}
+}
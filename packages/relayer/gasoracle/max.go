@@ -0,0 +1,53 @@
+package gasoracle
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// Max suggests the highest price of its sources, i.e. the predicted basefee, but never below
+// a fixed floor. A source that fails is skipped, so one bad source doesn't stop
+// processing, and only if every source fails is an error returned.
+type Max struct {
+	sources []relayer.GasOracle
+}
+
+func NewMax(sources ...relayer.GasOracle) (*Max, error) {
+	if len(sources) == 0 {
+		return nil, ErrNoSources
+	}
+
+	return &Max{sources: sources}, nil
+}
+
+func (o *Max) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	var (
+		highest *big.Int
+		lastErr error
+	)
+
+	for _, source := range o.sources {
+		price, err := source.SuggestGasPrice(ctx)
+		if err != nil {
+			log.Warnf("gas oracle source %T: %v", source, err)
+
+			lastErr = err
+
+			continue
+		}
+
+		if highest == nil || price.Cmp(highest) > 0 {
+			highest = price
+		}
+	}
+
+	if highest == nil {
+		return nil, errors.Wrap(lastErr, "every gas oracle source failed")
+	}
+
+	return highest, nil
+}
@@ -0,0 +1,61 @@
+package gasoracle
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewMax(t *testing.T) {
+	_, err := NewMax()
+	assert.Equal(t, ErrNoSources, err)
+}
+
+func Test_Max(t *testing.T) {
+	floor, _ := NewFixed(big.NewInt(500))
+	ceiling, _ := NewFixed(big.NewInt(5000))
+
+	tests := []struct {
+		name    string
+		o       *Max
+		want    *big.Int
+		wantErr bool
+	}{
+		{
+			"floorBelow",
+			&Max{sources: []relayer.GasOracle{&mock.GasOracle{}, floor}},
+			mock.GasOraclePrice,
+			false,
+		},
+		{
+			"floorAbove",
+			&Max{sources: []relayer.GasOracle{&mock.GasOracle{}, ceiling}},
+			big.NewInt(5000),
+			false,
+		},
+		{
+			"failingSourceSkipped",
+			&Max{sources: []relayer.GasOracle{&mock.GasOracle{Fail: true}, floor}},
+			big.NewInt(500),
+			false,
+		},
+		{
+			"everySourceFails",
+			&Max{sources: []relayer.GasOracle{&mock.GasOracle{Fail: true}}},
+			nil,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.o.SuggestGasPrice(context.Background())
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
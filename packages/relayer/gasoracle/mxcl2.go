@@ -0,0 +1,70 @@
+package gasoracle
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+type basefeePredictor interface {
+	GetBasefee(opts *bind.CallOpts, timeSinceParent uint32, gasLimit uint64, parentGasUsed uint64) (*big.Int, error)
+}
+
+type headerGetter interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// MxcL2 predicts the next L2 block's basefee with MxcL2.getBasefee, the same calculation the
+// anchor transaction uses, rather than trusting the node's suggestion. The latest block is
+// taken to be the next block's parent.
+type MxcL2 struct {
+	mxcL2  basefeePredictor
+	client headerGetter
+	now    func() time.Time
+}
+
+func NewMxcL2(mxcL2 basefeePredictor, client headerGetter) (*MxcL2, error) {
+	if mxcL2 == nil {
+		return nil, ErrNoMxcL2
+	}
+
+	return &MxcL2{mxcL2: mxcL2, client: client, now: time.Now}, nil
+}
+
+func (o *MxcL2) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	parent, err := o.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "o.client.HeaderByNumber")
+	}
+
+	basefee, err := o.mxcL2.GetBasefee(
+		&bind.CallOpts{Context: ctx},
+		timeSince(parent.Time, o.now()),
+		parent.GasLimit,
+		parent.GasUsed,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "o.mxcL2.GetBasefee")
+	}
+
+	return basefee, nil
+}
+
+// timeSince is the seconds from a block timestamp to now, clamped to getBasefee's uint32.
+func timeSince(timestamp uint64, now time.Time) uint32 {
+	n := now.Unix()
+	if n < 0 || uint64(n) <= timestamp {
+		return 0
+	}
+
+	if elapsed := uint64(n) - timestamp; elapsed < math.MaxUint32 {
+		return uint32(elapsed)
+	}
+
+	return math.MaxUint32
+}
@@ -0,0 +1,78 @@
+package gasoracle
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type predictor struct {
+	timeSinceParent uint32
+	gasLimit        uint64
+	parentGasUsed   uint64
+	fail            bool
+}
+
+func (p *predictor) GetBasefee(
+	opts *bind.CallOpts,
+	timeSinceParent uint32,
+	gasLimit uint64,
+	parentGasUsed uint64,
+) (*big.Int, error) {
+	if p.fail {
+		return nil, errors.New("fail")
+	}
+
+	p.timeSinceParent, p.gasLimit, p.parentGasUsed = timeSinceParent, gasLimit, parentGasUsed
+
+	return big.NewInt(42), nil
+}
+
+type headClient struct {
+	head *types.Header
+}
+
+func (c *headClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return c.head, nil
+}
+
+func Test_NewMxcL2(t *testing.T) {
+	_, err := NewMxcL2(nil, &headClient{})
+	assert.Equal(t, ErrNoMxcL2, err)
+}
+
+func Test_MxcL2(t *testing.T) {
+	p := &predictor{}
+
+	o, err := NewMxcL2(p, &headClient{head: &types.Header{Time: 100, GasLimit: 30000000, GasUsed: 1000}})
+	assert.Nil(t, err)
+
+	o.now = func() time.Time { return time.Unix(112, 0) }
+
+	basefee, err := o.SuggestGasPrice(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(42), basefee)
+
+	// predicted for the block after the latest one
+	assert.Equal(t, uint32(12), p.timeSinceParent)
+	assert.Equal(t, uint64(30000000), p.gasLimit)
+	assert.Equal(t, uint64(1000), p.parentGasUsed)
+
+	p.fail = true
+
+	_, err = o.SuggestGasPrice(context.Background())
+	assert.NotNil(t, err)
+}
+
+func Test_timeSince(t *testing.T) {
+	assert.Equal(t, uint32(0), timeSince(100, time.Unix(90, 0)))
+	assert.Equal(t, uint32(10), timeSince(100, time.Unix(110, 0)))
+	assert.Equal(t, uint32(math.MaxUint32), timeSince(0, time.Unix(math.MaxUint32+10, 0)))
+}
@@ -0,0 +1,30 @@
+package gasoracle
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+type gasPricer interface {
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+}
+
+// Node is the node's own suggestion, from eth_gasPrice.
+type Node struct {
+	client gasPricer
+}
+
+func NewNode(client gasPricer) *Node {
+	return &Node{client: client}
+}
+
+func (o *Node) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	gasPrice, err := o.client.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "o.client.SuggestGasPrice")
+	}
+
+	return gasPrice, nil
+}
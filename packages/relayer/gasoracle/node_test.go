@@ -0,0 +1,16 @@
+package gasoracle
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Node(t *testing.T) {
+	got, err := NewNode(&mock.EthClient{}).SuggestGasPrice(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(100), got)
+}
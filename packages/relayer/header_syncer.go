@@ -8,4 +8,5 @@ import (
 
 type HeaderSyncer interface {
 	GetCrossChainBlockHash(opts *bind.CallOpts, number *big.Int) ([32]byte, error)
+	GetCrossChainSignalRoot(opts *bind.CallOpts, number *big.Int) ([32]byte, error)
 }
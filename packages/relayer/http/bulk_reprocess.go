@@ -0,0 +1,190 @@
+package http
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/cyberhorsey/webutils"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	reprocessModeDryRun = "dry-run"
+	reprocessModeApply  = "apply"
+
+	// maxBulkReprocess caps how many messages one request requeues, so a broad filter
+	// doesn't flood the processor. Larger backlogs are requeued over several requests.
+	maxBulkReprocess = 100
+
+	bulkReprocessPageSize = 1000
+)
+
+// bulkReprocessRequest filters the MessageSent events to reprocess. Status defaults to new,
+// the only status that can be reprocessed, and the other filters match every message when unset.
+type bulkReprocessRequest struct {
+	// Mode is dry-run, the default, or apply
+	Mode    string     `json:"mode"`
+	Status  string     `json:"status"`
+	ChainID *int64     `json:"chainID"`
+	From    *time.Time `json:"from"`
+	To      *time.Time `json:"to"`
+	// Limit is how many messages to requeue, at most maxBulkReprocess, which is the default
+	Limit int `json:"limit"`
+}
+
+type bulkReprocessMessage struct {
+	MsgHash   string    `json:"msgHash"`
+	ChainID   int64     `json:"chainID"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"createdAt"`
+	Error     string    `json:"error,omitempty"`
+}
+
+type bulkReprocessResponse struct {
+	DryRun bool `json:"dryRun"`
+	// Matched is how many messages match the filter, which can be more than are requeued
+	Matched int `json:"matched"`
+	// Messages are the ones requeued, or that would be in a dry run
+	Messages []bulkReprocessMessage `json:"messages"`
+	Requeued int                    `json:"requeued"`
+	// Failed are the messages that couldn't be requeued, with the reason
+	Failed []bulkReprocessMessage `json:"failed"`
+}
+
+// BulkReprocess requeues the messages matching the request body's filter, oldest first and
+// at most `limit` at a time. In dry-run mode it only reports how many match, and the ones
+// it would requeue.
+func (srv *Server) BulkReprocess(c echo.Context) error {
+	var req bulkReprocessRequest
+
+	if err := c.Bind(&req); err != nil {
+		return webutils.LogAndRenderErrors(c, http.StatusBadRequest, ErrInvalidReprocessRequest)
+	}
+
+	opts, err := req.findOpts()
+	if err != nil {
+		return webutils.LogAndRenderErrors(c, http.StatusUnprocessableEntity, err)
+	}
+
+	// reprocessing acts on the events' current status, which a lagging replica could have stale.
+	ctx := relayer.WithPrimaryReads(c.Request().Context())
+
+	resp := bulkReprocessResponse{
+		DryRun:   req.Mode != reprocessModeApply,
+		Messages: make([]bulkReprocessMessage, 0),
+		Failed:   make([]bulkReprocessMessage, 0),
+	}
+
+	matches := make([]*relayer.ExportedEvent, 0)
+
+	for {
+		events, err := srv.eventRepo.FindAllForExport(ctx, opts)
+		if err != nil {
+			return webutils.LogAndRenderErrors(c, http.StatusUnprocessableEntity, err)
+		}
+
+		resp.Matched += len(events)
+
+		for _, e := range events {
+			if len(matches) < req.Limit {
+				matches = append(matches, e)
+			}
+		}
+
+		if len(events) < bulkReprocessPageSize {
+			break
+		}
+
+		opts.AfterID = events[len(events)-1].ID
+	}
+
+	for _, e := range matches {
+		m := bulkReprocessMessage{
+			MsgHash:   e.MsgHash,
+			ChainID:   e.ChainID,
+			Status:    e.Status.String(),
+			CreatedAt: e.CreatedAt,
+		}
+
+		if resp.DryRun {
+			resp.Messages = append(resp.Messages, m)
+			continue
+		}
+
+		if err := srv.reprocess(ctx, &e.Event); err != nil {
+			m.Error = err.Error()
+			resp.Failed = append(resp.Failed, m)
+
+			continue
+		}
+
+		resp.Messages = append(resp.Messages, m)
+		resp.Requeued++
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (srv *Server) reprocess(ctx context.Context, e *relayer.Event) error {
+	reprocessor, ok := srv.messageReprocessors[e.ChainID]
+	if !ok {
+		return ErrNoMessageReprocessor
+	}
+
+	return reprocessor.ReprocessMessage(ctx, e)
+}
+
+// findOpts validates the request, defaulting its mode, status and limit, and returns the
+// filter to page through matching events with.
+func (req *bulkReprocessRequest) findOpts() (relayer.FindAllForExportOpts, error) {
+	opts := relayer.FindAllForExportOpts{
+		Name:  relayer.EventNameMessageSent,
+		Limit: bulkReprocessPageSize,
+	}
+
+	if req.Mode == "" {
+		req.Mode = reprocessModeDryRun
+	}
+
+	if req.Mode != reprocessModeDryRun && req.Mode != reprocessModeApply {
+		return opts, ErrInvalidReprocessMode
+	}
+
+	if req.Limit == 0 {
+		req.Limit = maxBulkReprocess
+	}
+
+	if req.Limit < 0 || req.Limit > maxBulkReprocess {
+		return opts, ErrInvalidLimit
+	}
+
+	status := relayer.EventStatusNew
+
+	if req.Status != "" {
+		s, err := relayer.ParseEventStatus(req.Status)
+		if err != nil {
+			return opts, ErrInvalidStatus
+		}
+
+		status = s
+	}
+
+	opts.Status = &status
+
+	if req.ChainID != nil {
+		opts.ChainID = big.NewInt(*req.ChainID)
+	}
+
+	if req.From != nil {
+		opts.From = *req.From
+	}
+
+	if req.To != nil {
+		opts.To = *req.To
+	}
+
+	return opts, nil
+}
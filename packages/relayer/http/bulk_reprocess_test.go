@@ -0,0 +1,172 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/cyberhorsey/webutils/testutils"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_BulkReprocess(t *testing.T) {
+	otherChainID := int64(1)
+
+	tests := []struct {
+		name            string
+		body            map[string]interface{}
+		apiKey          string
+		wantStatus      int
+		wantMatched     int
+		wantMessages    int
+		wantRequeued    int
+		wantFailed      int
+		wantReprocessed int
+	}{
+		{
+			"dryRunByDefault",
+			map[string]interface{}{},
+			testAdminAPIKey,
+			http.StatusOK,
+			3,
+			3,
+			0,
+			0,
+			0,
+		},
+		{
+			"applyWithLimit",
+			map[string]interface{}{"mode": reprocessModeApply, "chainID": mock.MockChainID.Int64(), "limit": 1},
+			testAdminAPIKey,
+			http.StatusOK,
+			2,
+			1,
+			1,
+			0,
+			1,
+		},
+		{
+			"applyWithoutReprocessor",
+			map[string]interface{}{"mode": reprocessModeApply},
+			testAdminAPIKey,
+			http.StatusOK,
+			3,
+			2,
+			2,
+			1,
+			2,
+		},
+		{
+			"otherStatus",
+			map[string]interface{}{"mode": reprocessModeApply, "status": "done"},
+			testAdminAPIKey,
+			http.StatusOK,
+			1,
+			0,
+			0,
+			1,
+			0,
+		},
+		{
+			"invalidMode",
+			map[string]interface{}{"mode": "sometimes"},
+			testAdminAPIKey,
+			http.StatusUnprocessableEntity,
+			0,
+			0,
+			0,
+			0,
+			0,
+		},
+		{
+			"invalidStatus",
+			map[string]interface{}{"status": "lost"},
+			testAdminAPIKey,
+			http.StatusUnprocessableEntity,
+			0,
+			0,
+			0,
+			0,
+			0,
+		},
+		{
+			"invalidLimit",
+			map[string]interface{}{"limit": maxBulkReprocess + 1},
+			testAdminAPIKey,
+			http.StatusUnprocessableEntity,
+			0,
+			0,
+			0,
+			0,
+			0,
+		},
+		{
+			"wrongAPIKey",
+			map[string]interface{}{"mode": reprocessModeApply},
+			"wrong",
+			http.StatusUnauthorized,
+			0,
+			0,
+			0,
+			0,
+			0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newTestServer("")
+
+			for i, e := range []struct {
+				chainID int64
+				status  relayer.EventStatus
+			}{
+				{mock.MockChainID.Int64(), relayer.EventStatusNew},
+				{mock.MockChainID.Int64(), relayer.EventStatusNew},
+				{mock.MockChainID.Int64(), relayer.EventStatusDone},
+				{otherChainID, relayer.EventStatusNew},
+			} {
+				_, err := srv.eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+					Name:    relayer.EventNameMessageSent,
+					Event:   relayer.EventNameMessageSent,
+					Data:    "{}",
+					ChainID: big.NewInt(e.chainID),
+					Status:  e.status,
+					MsgHash: fmt.Sprintf("0x%d", i),
+				})
+				assert.Nil(t, err)
+			}
+
+			req := testutils.NewAuthenticatedRequestWithJWT(tt.apiKey, echo.POST, "/admin/reprocess", tt.body)
+
+			rec := httptest.NewRecorder()
+
+			srv.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+
+			reprocessor := srv.messageReprocessors[mock.MockChainID.Int64()].(*mock.IndexerAdmin)
+			assert.Equal(t, tt.wantReprocessed, len(reprocessor.Reprocessed))
+
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var resp bulkReprocessResponse
+
+			assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Equal(t, tt.body["mode"] != reprocessModeApply, resp.DryRun)
+			assert.Equal(t, tt.wantMatched, resp.Matched)
+			assert.Equal(t, tt.wantMessages, len(resp.Messages))
+			assert.Equal(t, tt.wantRequeued, resp.Requeued)
+			assert.Equal(t, tt.wantFailed, len(resp.Failed))
+		})
+	}
+}
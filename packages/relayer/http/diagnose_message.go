@@ -0,0 +1,49 @@
+package http
+
+import (
+	"html"
+	"net/http"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/cyberhorsey/webutils"
+	"github.com/labstack/echo/v4"
+)
+
+// DiagnoseMessage explains why a message hasn't been processed, running the same checks
+// processing would against both chains, along with the statuses the bridges reported for it.
+func (srv *Server) DiagnoseMessage(c echo.Context) error {
+	msgHash := html.EscapeString(c.Param("msgHash"))
+
+	// a stuck message's status is what's being diagnosed, so don't read a lagging replica's.
+	ctx := relayer.WithPrimaryReads(c.Request().Context())
+
+	e, err := srv.eventRepo.FirstByEventAndMsgHash(
+		ctx,
+		relayer.EventNameMessageSent,
+		msgHash,
+	)
+	if err != nil {
+		return webutils.LogAndRenderErrors(c, http.StatusUnprocessableEntity, err)
+	}
+
+	if e == nil {
+		return webutils.LogAndRenderErrors(c, http.StatusNotFound, ErrEventNotFound)
+	}
+
+	diagnoser, ok := srv.messageDiagnosers[e.ChainID]
+	if !ok {
+		return webutils.LogAndRenderErrors(c, http.StatusUnprocessableEntity, ErrNoMessageDiagnoser)
+	}
+
+	diagnosis, err := diagnoser.DiagnoseMessage(ctx, e)
+	if err != nil {
+		return webutils.LogAndRenderErrors(c, http.StatusUnprocessableEntity, err)
+	}
+
+	diagnosis.BridgeStatusChanges, err = srv.bridgeStatusChangeRepo.FindAllByMsgHash(ctx, msgHash)
+	if err != nil {
+		return webutils.LogAndRenderErrors(c, http.StatusUnprocessableEntity, err)
+	}
+
+	return c.JSON(http.StatusOK, diagnosis)
+}
@@ -0,0 +1,117 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/cyberhorsey/webutils/testutils"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DiagnoseMessage(t *testing.T) {
+	srv := newTestServer("")
+
+	_, err := srv.eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+		Name:    relayer.EventNameMessageSent,
+		Event:   relayer.EventNameMessageSent,
+		Data:    "{}",
+		ChainID: mock.MockChainID,
+		Status:  relayer.EventStatusNew,
+		MsgHash: "0x1",
+	})
+	assert.Equal(t, nil, err)
+
+	_, err = srv.eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+		Name:    relayer.EventNameMessageSent,
+		Event:   relayer.EventNameMessageSent,
+		Data:    "{}",
+		ChainID: mock.MockChainID,
+		Status:  relayer.EventStatusNew,
+		MsgHash: "0x2",
+	})
+	assert.Equal(t, nil, err)
+
+	assert.Nil(t, srv.bridgeStatusChangeRepo.Save(context.Background(), relayer.SaveBridgeStatusChangeOpts{
+		MsgHash: "0x1",
+		ChainID: mock.MockChainID,
+		Status:  relayer.EventStatusRetriable,
+		TxHash:  "0xa",
+	}))
+
+	tests := []struct {
+		name          string
+		msgHash       string
+		diagnoser     relayer.MessageDiagnoser
+		wantStatus    int
+		wantDiagnosis string
+	}{
+		{
+			"success",
+			"0x1",
+			&mock.MessageDiagnoser{},
+			http.StatusOK,
+			"no problem found, the message is waiting to be processed",
+		},
+		{
+			"notFound",
+			"0x3",
+			&mock.MessageDiagnoser{},
+			http.StatusNotFound,
+			"",
+		},
+		{
+			"diagnoserFails",
+			"0x2",
+			&mock.MessageDiagnoser{Fail: true},
+			http.StatusUnprocessableEntity,
+			"",
+		},
+		{
+			"noDiagnoser",
+			"0x1",
+			nil,
+			http.StatusUnprocessableEntity,
+			"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delete(srv.messageDiagnosers, mock.MockChainID.Int64())
+
+			if tt.diagnoser != nil {
+				srv.messageDiagnosers[mock.MockChainID.Int64()] = tt.diagnoser
+			}
+
+			req := testutils.NewUnauthenticatedRequest(
+				echo.GET,
+				fmt.Sprintf("/messages/%v/diagnose", tt.msgHash),
+				nil,
+			)
+
+			rec := httptest.NewRecorder()
+
+			srv.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+
+			if tt.wantStatus == http.StatusOK {
+				var d relayer.MessageDiagnosis
+
+				assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &d))
+				assert.Equal(t, tt.msgHash, d.MsgHash)
+				assert.Equal(t, "new", d.Status)
+				assert.Equal(t, tt.wantDiagnosis, d.Diagnosis)
+				assert.Equal(t, 1, len(d.BridgeStatusChanges))
+				assert.Equal(t, relayer.EventStatusRetriable, d.BridgeStatusChanges[0].Status)
+			}
+		})
+	}
+}
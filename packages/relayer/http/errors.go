@@ -7,8 +7,60 @@ var (
 		"ERR_NO_HTTP_ENGINE",
 		"HTTP framework required",
 	)
+	ErrNoMessageReleaser = errors.Validation.NewWithKeyAndDetail(
+		"ERR_NO_MESSAGE_RELEASER",
+		"No message releaser for the message's source chain",
+	)
+	ErrNoMessageDiagnoser = errors.Validation.NewWithKeyAndDetail(
+		"ERR_NO_MESSAGE_DIAGNOSER",
+		"No message diagnoser for the message's source chain",
+	)
+	ErrNoMessageReprocessor = errors.Validation.NewWithKeyAndDetail(
+		"ERR_NO_MESSAGE_REPROCESSOR",
+		"No message reprocessor for the message's source chain",
+	)
+	ErrNoProcessingPauser = errors.NotFound.NewWithKeyAndDetail(
+		"ERR_NO_PROCESSING_PAUSER",
+		"No processor for the chain",
+	)
+	ErrInvalidChainID = errors.Validation.NewWithKeyAndDetail(
+		"ERR_INVALID_CHAIN_ID",
+		"chainID must be an integer",
+	)
+	ErrInvalidSender = errors.Validation.NewWithKeyAndDetail(
+		"ERR_INVALID_SENDER",
+		"sender must be an address",
+	)
+	ErrInvalidLimit = errors.Validation.NewWithKeyAndDetail(
+		"ERR_INVALID_LIMIT",
+		"limit must be an integer between 1 and 100",
+	)
+	ErrInvalidStatus = errors.Validation.NewWithKeyAndDetail(
+		"ERR_INVALID_STATUS",
+		"status must be a message status, i.e. new",
+	)
+	ErrInvalidReprocessMode = errors.Validation.NewWithKeyAndDetail(
+		"ERR_INVALID_REPROCESS_MODE",
+		"mode must be dry-run or apply",
+	)
+	ErrInvalidReprocessRequest = errors.Validation.NewWithKeyAndDetail(
+		"ERR_INVALID_REPROCESS_REQUEST",
+		"Request body must be a JSON reprocess filter",
+	)
+	ErrEventNotFound = errors.NotFound.NewWithKeyAndDetail(
+		"ERR_EVENT_NOT_FOUND",
+		"Event not found",
+	)
 	ErrNoRewarder = errors.Validation.NewWithKeyAndDetail(
 		"ERR_NO_REWARDER",
 		"Rewarder is required",
 	)
+	ErrNoBlocklist = errors.Validation.NewWithKeyAndDetail(
+		"ERR_NO_BLOCKLIST",
+		"No blocklist is configured",
+	)
+	ErrInvalidStatsBucket = errors.Validation.NewWithKeyAndDetail(
+		"ERR_INVALID_STATS_BUCKET",
+		"bucket must be day or hour",
+	)
 )
@@ -23,6 +23,29 @@ func (srv *Server) GetEventsByAddress(c echo.Context) error {
 
 	event := html.EscapeString(c.QueryParam("event"))
 
+	var sender *common.Address
+
+	if senderParam := c.QueryParam("sender"); senderParam != "" {
+		if !common.IsHexAddress(senderParam) {
+			return webutils.LogAndRenderErrors(c, http.StatusUnprocessableEntity, ErrInvalidSender)
+		}
+
+		s := common.HexToAddress(senderParam)
+
+		sender = &s
+	}
+
+	var destChainID *big.Int
+
+	if destChainIDParam := c.QueryParam("destChainID"); destChainIDParam != "" {
+		id, ok := new(big.Int).SetString(destChainIDParam, 10)
+		if !ok {
+			return webutils.LogAndRenderErrors(c, http.StatusUnprocessableEntity, ErrInvalidChainID)
+		}
+
+		destChainID = id
+	}
+
 	var eventType *relayer.EventType
 
 	if eventTypeParam != "" {
@@ -40,11 +63,13 @@ func (srv *Server) GetEventsByAddress(c echo.Context) error {
 		c.Request().Context(),
 		c.Request(),
 		relayer.FindAllByAddressOpts{
-			Address:   common.HexToAddress(address),
-			MsgHash:   &msgHash,
-			EventType: eventType,
-			ChainID:   chainID,
-			Event:     &event,
+			Address:     common.HexToAddress(address),
+			MsgHash:     &msgHash,
+			EventType:   eventType,
+			ChainID:     chainID,
+			Event:       &event,
+			Sender:      sender,
+			DestChainID: destChainID,
 		},
 	)
 	if err != nil {
@@ -77,3 +77,40 @@ func Test_GetEventsByAddress(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetEventsByAddress_invalidFilters(t *testing.T) {
+	srv := newTestServer("")
+
+	tests := []struct {
+		name                  string
+		query                 string
+		wantBodyRegexpMatches []string
+	}{
+		{
+			"invalidSender",
+			"sender=0x123",
+			[]string{`ERR_INVALID_SENDER`},
+		},
+		{
+			"invalidDestChainID",
+			"sender=0x0000000000000000000000000000000000000123&destChainID=abc",
+			[]string{`ERR_INVALID_CHAIN_ID`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := testutils.NewUnauthenticatedRequest(
+				echo.GET,
+				"/events?address=0x0000000000000000000000000000000000000123&"+tt.query,
+				nil,
+			)
+
+			rec := httptest.NewRecorder()
+
+			srv.ServeHTTP(rec, req)
+
+			testutils.AssertStatusAndBody(t, rec, http.StatusUnprocessableEntity, tt.wantBodyRegexpMatches)
+		})
+	}
+}
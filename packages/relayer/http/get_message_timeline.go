@@ -0,0 +1,104 @@
+package http
+
+import (
+	"html"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/cyberhorsey/webutils"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// timelineSourceRelayer marks what the relayer recorded of a message
+	timelineSourceRelayer = "relayer"
+	// timelineSourceBridge marks a status a bridge reported in a MessageStatusChanged event
+	timelineSourceBridge = "bridge"
+)
+
+// timelineEntry is one step in a message's life, as the relayer or a bridge saw it
+type timelineEntry struct {
+	Source      string `json:"source"`
+	Status      string `json:"status"`
+	ChainID     int64  `json:"chainID"`
+	BlockNumber uint64 `json:"blockNumber,omitempty"`
+	TxHash      string `json:"txHash,omitempty"`
+	// ByRelayer is whether the bridge's status change was made by the relayer's processMessage
+	// transaction
+	ByRelayer bool `json:"byRelayer"`
+	// Detail is the error the relayer's last attempt to process the message failed with
+	Detail string `json:"detail,omitempty"`
+	// IndexedAt is when a bridge's status change was indexed
+	IndexedAt *time.Time `json:"indexedAt,omitempty"`
+}
+
+type messageTimelineResponse struct {
+	MsgHash string          `json:"msgHash"`
+	Entries []timelineEntry `json:"entries"`
+}
+
+// GetMessageTimeline returns a message's timeline: its MessageSent event, each status the bridges
+// reported for it, in the order they were indexed, then its status in the relayer.
+func (srv *Server) GetMessageTimeline(c echo.Context) error {
+	msgHash := html.EscapeString(c.Param("msgHash"))
+
+	ctx := c.Request().Context()
+
+	e, err := srv.eventRepo.FirstByEventAndMsgHash(ctx, relayer.EventNameMessageSent, msgHash)
+	if err != nil {
+		return webutils.LogAndRenderErrors(c, http.StatusUnprocessableEntity, err)
+	}
+
+	if e == nil {
+		return webutils.LogAndRenderErrors(c, http.StatusNotFound, ErrEventNotFound)
+	}
+
+	changes, err := srv.bridgeStatusChangeRepo.FindAllByMsgHash(ctx, msgHash)
+	if err != nil {
+		return webutils.LogAndRenderErrors(c, http.StatusUnprocessableEntity, err)
+	}
+
+	return c.JSON(http.StatusOK, &messageTimelineResponse{
+		MsgHash: msgHash,
+		Entries: messageTimeline(e, changes),
+	})
+}
+
+func messageTimeline(e *relayer.Event, changes []*relayer.BridgeStatusChange) []timelineEntry {
+	sent := timelineEntry{
+		Source:      timelineSourceRelayer,
+		Status:      relayer.EventNameMessageSent,
+		ChainID:     e.ChainID,
+		BlockNumber: e.BlockNumber,
+	}
+
+	if e.TxHash != nil {
+		sent.TxHash = *e.TxHash
+	}
+
+	entries := []timelineEntry{sent}
+
+	for _, change := range changes {
+		indexedAt := change.CreatedAt
+
+		entries = append(entries, timelineEntry{
+			Source:      timelineSourceBridge,
+			Status:      change.Status.String(),
+			ChainID:     change.ChainID,
+			BlockNumber: change.BlockNumber,
+			TxHash:      change.TxHash,
+			ByRelayer:   e.ProcessingTxHash != "" && strings.EqualFold(change.TxHash, e.ProcessingTxHash),
+			IndexedAt:   &indexedAt,
+		})
+	}
+
+	return append(entries, timelineEntry{
+		Source:  timelineSourceRelayer,
+		Status:  e.Status.String(),
+		ChainID: e.DestChainID,
+		TxHash:  e.ProcessingTxHash,
+		Detail:  e.ProcessingError,
+	})
+}
@@ -0,0 +1,108 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/cyberhorsey/webutils/testutils"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetMessageTimeline(t *testing.T) {
+	srv := newTestServer("")
+
+	_, err := srv.eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+		Name:          relayer.EventNameMessageSent,
+		Event:         relayer.EventNameMessageSent,
+		Data:          "{}",
+		ChainID:       mock.MockChainID,
+		Status:        relayer.EventStatusNew,
+		MsgHash:       "0x1",
+		BlockNumber:   5,
+		TxHash:        "0xa",
+		DestChainID:   big.NewInt(2),
+		MessageSender: "0x3",
+	})
+	assert.Nil(t, err)
+
+	e, err := srv.eventRepo.FirstByEventAndMsgHash(context.Background(), relayer.EventNameMessageSent, "0x1")
+	assert.Nil(t, err)
+
+	e.Status = relayer.EventStatusDone
+	e.ProcessingTxHash = "0xC"
+
+	for _, opts := range []relayer.SaveBridgeStatusChangeOpts{
+		{MsgHash: "0x1", Status: relayer.EventStatusRetriable, BlockNumber: 8, TxHash: "0xb"},
+		{MsgHash: "0x1", Status: relayer.EventStatusDone, BlockNumber: 9, TxHash: "0xc"},
+		{MsgHash: "0x2", Status: relayer.EventStatusDone, BlockNumber: 9, TxHash: "0xc", LogIndex: 1},
+	} {
+		opts.ChainID = big.NewInt(2)
+		assert.Nil(t, srv.bridgeStatusChangeRepo.Save(context.Background(), opts))
+	}
+
+	tests := []struct {
+		name        string
+		msgHash     string
+		wantStatus  int
+		wantEntries []timelineEntry
+	}{
+		{
+			"success",
+			"0x1",
+			http.StatusOK,
+			[]timelineEntry{
+				{Source: "relayer", Status: "MessageSent", ChainID: mock.MockChainID.Int64(), BlockNumber: 5, TxHash: "0xa"},
+				{Source: "bridge", Status: "retriable", ChainID: 2, BlockNumber: 8, TxHash: "0xb"},
+				{Source: "bridge", Status: "done", ChainID: 2, BlockNumber: 9, TxHash: "0xc", ByRelayer: true},
+				{Source: "relayer", Status: "done", ChainID: 2, TxHash: "0xC"},
+			},
+		},
+		{
+			"notFound",
+			"0x2",
+			http.StatusNotFound,
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := testutils.NewUnauthenticatedRequest(
+				echo.GET,
+				fmt.Sprintf("/messages/%v/timeline", tt.msgHash),
+				nil,
+			)
+
+			rec := httptest.NewRecorder()
+
+			srv.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var resp messageTimelineResponse
+
+			assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Equal(t, tt.msgHash, resp.MsgHash)
+
+			// when the changes were indexed isn't known up front
+			for i := range resp.Entries {
+				assert.Equal(t, resp.Entries[i].Source == "bridge", resp.Entries[i].IndexedAt != nil)
+				resp.Entries[i].IndexedAt = nil
+			}
+
+			assert.Equal(t, tt.wantEntries, resp.Entries)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package http
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/cyberhorsey/webutils"
+	"github.com/labstack/echo/v4"
+)
+
+type relayerStatusResponse struct {
+	// Runway is how long the relayer's balance on each chain lasts at its recent gas spend, by
+	// chain ID, empty without a gas spend repository
+	Runway []*relayer.Runway `json:"runway"`
+}
+
+// GetRelayerStatus reports the relayer's gas runway on each chain it sends transactions to
+func (srv *Server) GetRelayerStatus(c echo.Context) error {
+	resp := relayerStatusResponse{
+		Runway: make([]*relayer.Runway, 0, len(srv.runwayReporters)),
+	}
+
+	for _, r := range srv.runwayReporters {
+		runway, err := r.Runway(c.Request().Context())
+		if err != nil {
+			return webutils.LogAndRenderErrors(c, http.StatusInternalServerError, err)
+		}
+
+		resp.Runway = append(resp.Runway, runway)
+	}
+
+	sort.Slice(resp.Runway, func(i, j int) bool { return resp.Runway[i].ChainID < resp.Runway[j].ChainID })
+
+	return c.JSON(http.StatusOK, resp)
+}
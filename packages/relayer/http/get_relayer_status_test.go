@@ -0,0 +1,83 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetRelayerStatus(t *testing.T) {
+	hoursRemaining := 50.0
+
+	tests := []struct {
+		name            string
+		runwayReporters map[int64]relayer.RunwayReporter
+		wantStatus      int
+		wantBody        string
+	}{
+		{
+			"noReporters",
+			nil,
+			http.StatusOK,
+			`{"runway":[]}`,
+		},
+		{
+			"success",
+			map[int64]relayer.RunwayReporter{
+				5: &mock.RunwayReporter{Result: &relayer.Runway{
+					ChainID:          5,
+					Balance:          "1000",
+					WindowInHours:    24,
+					GasSpentInWindow: "480",
+					GasSpentPerHour:  "20",
+					HoursRemaining:   &hoursRemaining,
+				}},
+				1: &mock.RunwayReporter{Result: &relayer.Runway{
+					ChainID:          1,
+					Balance:          "1000",
+					WindowInHours:    24,
+					GasSpentInWindow: "0",
+					GasSpentPerHour:  "0",
+				}},
+			},
+			http.StatusOK,
+			`{"runway":[` +
+				`{"chainID":1,"balance":"1000","windowInHours":24,"gasSpentInWindow":"0","gasSpentPerHour":"0",` +
+				`"hoursRemaining":null},` +
+				`{"chainID":5,"balance":"1000","windowInHours":24,"gasSpentInWindow":"480","gasSpentPerHour":"20",` +
+				`"hoursRemaining":50}]}`,
+		},
+		{
+			"error",
+			map[int64]relayer.RunwayReporter{
+				1: &mock.RunwayReporter{Err: errors.New("fail")},
+			},
+			http.StatusInternalServerError,
+			"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newTestServer("")
+			srv.runwayReporters = tt.runwayReporters
+
+			req := httptest.NewRequest(echo.GET, "/status/relayer", nil)
+			rec := httptest.NewRecorder()
+
+			srv.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, rec.Body.String())
+			}
+		})
+	}
+}
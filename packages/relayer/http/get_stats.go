@@ -0,0 +1,105 @@
+package http
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/cyberhorsey/webutils"
+	"github.com/labstack/echo/v4"
+)
+
+// DefaultStatsCacheTTL is how long a GET /stats answer is reused before the events are aggregated again
+const DefaultStatsCacheTTL = 30 * time.Second
+
+const defaultStatsBucket = "day"
+
+// statsBuckets are the periods GET /stats can aggregate the messages relayed over, by the bucket
+// param, each the current one so far, in UTC
+var statsBuckets = map[string]time.Duration{
+	"day":  24 * time.Hour,
+	"hour": time.Hour,
+}
+
+type statsResponse struct {
+	Bucket string `json:"bucket"`
+	// Since is when the current bucket started, which messages relayed are counted from
+	Since time.Time `json:"since"`
+	*relayer.EventStats
+}
+
+type cachedStats struct {
+	resp      statsResponse
+	fetchedAt time.Time
+}
+
+// statsCache keeps each bucket's stats for ttl, so a dashboard polling them doesn't aggregate the
+// events table on every request. Errors aren't cached.
+type statsCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu    sync.Mutex
+	stats map[string]cachedStats
+}
+
+func newStatsCache(ttl time.Duration) *statsCache {
+	return &statsCache{
+		ttl:   ttl,
+		now:   time.Now,
+		stats: make(map[string]cachedStats),
+	}
+}
+
+// get returns bucket's cached stats, unless they're older than the ttl or for a bucket before since
+func (c *statsCache) get(bucket string, since time.Time) (statsResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.stats[bucket]
+	if !ok || !cached.resp.Since.Equal(since) || c.now().Sub(cached.fetchedAt) >= c.ttl {
+		return statsResponse{}, false
+	}
+
+	return cached.resp, true
+}
+
+func (c *statsCache) put(resp statsResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.stats[resp.Bucket] = cachedStats{resp: resp, fetchedAt: c.now()}
+}
+
+// GetStats reports the messages relayed in the current `bucket`, day or hour, defaulting to day: how
+// many, how long they took on average and the value they bridged per token, and how many messages
+// are waiting to be processed now.
+func (srv *Server) GetStats(c echo.Context) error {
+	bucket := c.QueryParam("bucket")
+	if bucket == "" {
+		bucket = defaultStatsBucket
+	}
+
+	size, ok := statsBuckets[bucket]
+	if !ok {
+		return webutils.LogAndRenderErrors(c, http.StatusUnprocessableEntity, ErrInvalidStatsBucket)
+	}
+
+	since := srv.statsCache.now().UTC().Truncate(size)
+
+	if resp, ok := srv.statsCache.get(bucket, since); ok {
+		return c.JSON(http.StatusOK, resp)
+	}
+
+	stats, err := srv.eventRepo.Stats(c.Request().Context(), since)
+	if err != nil {
+		return webutils.LogAndRenderErrors(c, http.StatusUnprocessableEntity, err)
+	}
+
+	resp := statsResponse{Bucket: bucket, Since: since, EventStats: stats}
+
+	srv.statsCache.put(resp)
+
+	return c.JSON(http.StatusOK, resp)
+}
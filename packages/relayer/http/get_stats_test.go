@@ -0,0 +1,178 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/cyberhorsey/webutils/testutils"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// saveDoneEvent saves a message sent of amount of token, done at doneAt after timeToDone seconds
+func saveDoneEvent(
+	t *testing.T,
+	srv *Server,
+	msgHash string,
+	token string,
+	amount string,
+	doneAt time.Time,
+	timeToDone uint64,
+) {
+	_, err := srv.eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+		Name:                  relayer.EventNameMessageSent,
+		Event:                 relayer.EventNameMessageSent,
+		Data:                  "{}",
+		ChainID:               mock.MockChainID,
+		Status:                relayer.EventStatusDone,
+		MsgHash:               msgHash,
+		CanonicalTokenAddress: token,
+		Amount:                amount,
+	})
+	assert.Nil(t, err)
+
+	e, err := srv.eventRepo.FirstByMsgHash(context.Background(), msgHash)
+	assert.Nil(t, err)
+
+	assert.Nil(t, srv.eventRepo.UpdateTimeToDone(context.Background(), e.ID, 0, uint64(doneAt.Unix()), timeToDone))
+}
+
+func getStats(t *testing.T, srv *Server, url string) (*httptest.ResponseRecorder, statsResponse) {
+	req := testutils.NewUnauthenticatedRequest(echo.GET, url, nil)
+
+	rec := httptest.NewRecorder()
+
+	srv.ServeHTTP(rec, req)
+
+	var resp statsResponse
+
+	if rec.Code == http.StatusOK {
+		assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	}
+
+	return rec, resp
+}
+
+func Test_GetStats(t *testing.T) {
+	srv := newTestServer("")
+
+	now := time.Date(2026, 10, 16, 12, 30, 0, 0, time.UTC)
+	srv.statsCache.now = func() time.Time { return now }
+
+	native := "0x0000000000000000000000000000000000000000"
+	token := "0x00000000000000000000000000000000000000aa"
+
+	saveDoneEvent(t, srv, "0x1", native, "100", now.Add(-10*time.Minute), 10)
+	saveDoneEvent(t, srv, "0x2", native, "20000000000000000000", now.Add(-2*time.Hour), 20)
+	saveDoneEvent(t, srv, "0x3", token, "5", now.Add(-3*time.Hour), 60)
+	// yesterday's
+	saveDoneEvent(t, srv, "0x4", token, "1000", now.Add(-24*time.Hour), 1000)
+
+	for i, status := range []relayer.EventStatus{
+		relayer.EventStatusNew,
+		relayer.EventStatusRetriable,
+		relayer.EventStatusPendingSent,
+		relayer.EventStatusFailed,
+	} {
+		_, err := srv.eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+			Name:    relayer.EventNameMessageSent,
+			Event:   relayer.EventNameMessageSent,
+			Data:    "{}",
+			ChainID: mock.MockChainID,
+			Status:  status,
+			MsgHash: fmt.Sprintf("0x1%d", i),
+		})
+		assert.Nil(t, err)
+	}
+
+	tests := []struct {
+		name                string
+		url                 string
+		wantBucket          string
+		wantSince           time.Time
+		wantMessagesRelayed int64
+		wantAverage         float64
+		wantValueBridged    []*relayer.TokenValue
+	}{
+		{
+			"defaultDay",
+			"/stats",
+			"day",
+			time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC),
+			3,
+			30,
+			[]*relayer.TokenValue{
+				{ChainID: mock.MockChainID.Int64(), CanonicalTokenAddress: native, Amount: "20000000000000000100"},
+				{ChainID: mock.MockChainID.Int64(), CanonicalTokenAddress: token, Amount: "5"},
+			},
+		},
+		{
+			"hour",
+			"/stats?bucket=hour",
+			"hour",
+			time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC),
+			1,
+			10,
+			[]*relayer.TokenValue{
+				{ChainID: mock.MockChainID.Int64(), CanonicalTokenAddress: native, Amount: "100"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, resp := getStats(t, srv, tt.url)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tt.wantBucket, resp.Bucket)
+			assert.True(t, tt.wantSince.Equal(resp.Since))
+			assert.Equal(t, tt.wantMessagesRelayed, resp.MessagesRelayed)
+			assert.Equal(t, tt.wantAverage, *resp.AverageTimeToDoneInSeconds)
+			assert.Equal(t, tt.wantValueBridged, resp.ValueBridged)
+			assert.Equal(t, int64(3), resp.Backlog)
+		})
+	}
+}
+
+func Test_GetStats_invalidBucket(t *testing.T) {
+	srv := newTestServer("")
+
+	rec, _ := getStats(t, srv, "/stats?bucket=week")
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+}
+
+func Test_GetStats_cached(t *testing.T) {
+	srv := newTestServer("")
+
+	now := time.Date(2026, 10, 16, 12, 30, 0, 0, time.UTC)
+	srv.statsCache.now = func() time.Time { return now }
+
+	_, resp := getStats(t, srv, "/stats")
+	assert.Equal(t, int64(0), resp.MessagesRelayed)
+	assert.Nil(t, resp.AverageTimeToDoneInSeconds)
+
+	saveDoneEvent(t, srv, "0x1", "", "100", now, 10)
+
+	// within the ttl the cached stats are answered
+	now = now.Add(DefaultStatsCacheTTL - time.Second)
+
+	_, resp = getStats(t, srv, "/stats")
+	assert.Equal(t, int64(0), resp.MessagesRelayed)
+
+	// another bucket isn't answered from the day's
+	_, resp = getStats(t, srv, "/stats?bucket=hour")
+	assert.Equal(t, int64(1), resp.MessagesRelayed)
+
+	now = now.Add(time.Second)
+
+	_, resp = getStats(t, srv, "/stats")
+	assert.Equal(t, int64(1), resp.MessagesRelayed)
+}
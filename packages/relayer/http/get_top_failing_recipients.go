@@ -0,0 +1,36 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/cyberhorsey/webutils"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	defaultFailingRecipientsLimit = 10
+	maxFailingRecipientsLimit     = 100
+)
+
+// GetTopFailingRecipients lists the recipients with the most Retriable or Failed messages,
+// up to the `limit` query param, defaulting to 10.
+func (srv *Server) GetTopFailingRecipients(c echo.Context) error {
+	limit := defaultFailingRecipientsLimit
+
+	if l := c.QueryParam("limit"); l != "" {
+		var err error
+
+		limit, err = strconv.Atoi(l)
+		if err != nil || limit < 1 || limit > maxFailingRecipientsLimit {
+			return webutils.LogAndRenderErrors(c, http.StatusUnprocessableEntity, ErrInvalidLimit)
+		}
+	}
+
+	recipients, err := srv.eventRepo.FindTopFailingRecipients(c.Request().Context(), limit)
+	if err != nil {
+		return webutils.LogAndRenderErrors(c, http.StatusUnprocessableEntity, err)
+	}
+
+	return c.JSON(http.StatusOK, recipients)
+}
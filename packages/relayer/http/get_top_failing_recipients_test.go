@@ -0,0 +1,96 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/cyberhorsey/webutils/testutils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetTopFailingRecipients(t *testing.T) {
+	srv := newTestServer("")
+
+	for i, to := range []string{"0x2", "0x1", "0x2", "0x3"} {
+		_, err := srv.eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+			Name:      relayer.EventNameMessageSent,
+			Event:     relayer.EventNameMessageSent,
+			Data:      "{}",
+			ChainID:   mock.MockChainID,
+			Status:    relayer.EventStatusRetriable,
+			MsgHash:   fmt.Sprintf("0x%d", i),
+			MessageTo: to,
+		})
+		assert.Equal(t, nil, err)
+	}
+
+	tests := []struct {
+		name           string
+		url            string
+		apiKey         string
+		wantStatus     int
+		wantRecipients []*relayer.FailingRecipient
+	}{
+		{
+			"defaultLimit",
+			"/admin/recipients/failing",
+			testAdminAPIKey,
+			http.StatusOK,
+			[]*relayer.FailingRecipient{
+				{Recipient: common.HexToAddress("0x2").Hex(), Failures: 2},
+				{Recipient: common.HexToAddress("0x1").Hex(), Failures: 1},
+				{Recipient: common.HexToAddress("0x3").Hex(), Failures: 1},
+			},
+		},
+		{
+			"limit",
+			"/admin/recipients/failing?limit=1",
+			testAdminAPIKey,
+			http.StatusOK,
+			[]*relayer.FailingRecipient{
+				{Recipient: common.HexToAddress("0x2").Hex(), Failures: 2},
+			},
+		},
+		{
+			"invalidLimit",
+			"/admin/recipients/failing?limit=0",
+			testAdminAPIKey,
+			http.StatusUnprocessableEntity,
+			nil,
+		},
+		{
+			"wrongAPIKey",
+			"/admin/recipients/failing",
+			"wrong",
+			http.StatusUnauthorized,
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := testutils.NewAuthenticatedRequestWithJWT(tt.apiKey, echo.GET, tt.url, nil)
+
+			rec := httptest.NewRecorder()
+
+			srv.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+
+			if tt.wantRecipients != nil {
+				var recipients []*relayer.FailingRecipient
+
+				assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &recipients))
+				assert.Equal(t, tt.wantRecipients, recipients)
+			}
+		})
+	}
+}
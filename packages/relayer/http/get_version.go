@@ -0,0 +1,13 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/labstack/echo/v4"
+)
+
+// GetVersion returns which build of the relayer is running
+func (srv *Server) GetVersion(c echo.Context) error {
+	return c.JSON(http.StatusOK, relayer.CurrentBuildInfo())
+}
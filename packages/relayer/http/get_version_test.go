@@ -0,0 +1,27 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetVersion(t *testing.T) {
+	srv := newTestServer("")
+
+	req := httptest.NewRequest(echo.GET, "/version", nil)
+	rec := httptest.NewRecorder()
+
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(
+		t,
+		`{"version":"dev","gitCommit":"dev","goVersion":"`+runtime.Version()+`","buildDate":"dev"}`,
+		rec.Body.String(),
+	)
+}
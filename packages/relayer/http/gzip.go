@@ -0,0 +1,187 @@
+package http
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// DefaultGzipMinLength is the smallest response body, in bytes, that's gzipped. Below it the
+// compressed body isn't much smaller, and it costs the client a decompression.
+const DefaultGzipMinLength = 1024
+
+const gzipScheme = "gzip"
+
+// gzipAbove gzips a response when the client accepts it, and its body is at least minLength
+// bytes. The body is buffered until it reaches minLength, so a smaller response is sent as is,
+// with its Content-Length. A gzipped response has none, and is sent chunked.
+func gzipAbove(minLength int) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			res := c.Response()
+			res.Header().Add(echo.HeaderVary, echo.HeaderAcceptEncoding)
+
+			if !acceptsGzip(c.Request()) {
+				return next(c)
+			}
+
+			w := &gzipResponseWriter{ResponseWriter: res.Writer, minLength: minLength}
+			res.Writer = w
+
+			// restore the writer before returning, so the error handler writes an error uncompressed
+			defer func() {
+				res.Writer = w.ResponseWriter
+			}()
+
+			err := next(c)
+			if err != nil && !w.wroteHeader {
+				return err
+			}
+
+			if closeErr := w.close(); err == nil {
+				err = closeErr
+			}
+
+			return err
+		}
+	}
+}
+
+// acceptsGzip is whether the request's Accept-Encoding includes gzip, without it being
+// refused with a q of 0
+func acceptsGzip(r *http.Request) bool {
+	for _, encoding := range strings.Split(r.Header.Get(echo.HeaderAcceptEncoding), ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(encoding), ";")
+		if !strings.EqualFold(strings.TrimSpace(name), gzipScheme) {
+			continue
+		}
+
+		params = strings.TrimSpace(params)
+		if !strings.HasPrefix(params, "q=") {
+			return true
+		}
+
+		v, err := strconv.ParseFloat(strings.TrimPrefix(params, "q="), 64)
+
+		return err == nil && v > 0
+	}
+
+	return false
+}
+
+// gzipResponseWriter holds the status code and body back until the body reaches minLength, then
+// sends the rest gzipped. If it never does, close sends it uncompressed.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	minLength int
+
+	statusCode  int
+	wroteHeader bool
+	buf         bytes.Buffer
+	gz          *gzip.Writer
+	// passthrough is set once the buffered response was sent uncompressed, i.e. on a Flush
+	passthrough bool
+}
+
+func (w *gzipResponseWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+
+	w.statusCode = code
+	w.wroteHeader = true
+}
+
+func (w *gzipResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if w.gz != nil {
+		return w.gz.Write(b)
+	}
+
+	if w.passthrough {
+		return w.ResponseWriter.Write(b)
+	}
+
+	n, _ := w.buf.Write(b)
+
+	if w.buf.Len() >= w.minLength && w.Header().Get(echo.HeaderContentEncoding) == "" {
+		if err := w.startGzip(); err != nil {
+			return 0, err
+		}
+	}
+
+	return n, nil
+}
+
+// startGzip sends the header, and the body so far compressed
+func (w *gzipResponseWriter) startGzip() error {
+	w.Header().Set(echo.HeaderContentEncoding, gzipScheme)
+	w.Header().Del(echo.HeaderContentLength)
+	w.ResponseWriter.WriteHeader(w.statusCode)
+
+	w.gz = gzip.NewWriter(w.ResponseWriter)
+
+	_, err := w.gz.Write(w.buf.Bytes())
+	w.buf.Reset()
+
+	return err
+}
+
+// sendBuffered sends the header, and the body so far uncompressed
+func (w *gzipResponseWriter) sendBuffered() error {
+	w.passthrough = true
+
+	if !w.wroteHeader {
+		return nil
+	}
+
+	w.Header().Set(echo.HeaderContentLength, strconv.Itoa(w.buf.Len()))
+	w.ResponseWriter.WriteHeader(w.statusCode)
+
+	_, err := w.ResponseWriter.Write(w.buf.Bytes())
+	w.buf.Reset()
+
+	return err
+}
+
+// close finishes the gzip stream, or sends a body that stayed under minLength uncompressed
+func (w *gzipResponseWriter) close() error {
+	if w.gz != nil {
+		return w.gz.Close()
+	}
+
+	if w.passthrough {
+		return nil
+	}
+
+	return w.sendBuffered()
+}
+
+// Flush sends what's been written so far. A body still under minLength is sent uncompressed, as a
+// handler flushing wants it sent without waiting for more.
+func (w *gzipResponseWriter) Flush() {
+	switch {
+	case w.gz != nil:
+		_ = w.gz.Flush()
+	case !w.passthrough:
+		w.Header().Del(echo.HeaderContentLength)
+		w.passthrough = true
+
+		if w.wroteHeader {
+			w.ResponseWriter.WriteHeader(w.statusCode)
+			_, _ = w.ResponseWriter.Write(w.buf.Bytes())
+			w.buf.Reset()
+		}
+	}
+
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,122 @@
+package http
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/cyberhorsey/webutils/testutils"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func getEvents(t *testing.T, srv *Server, address string, acceptEncoding string) *httptest.ResponseRecorder {
+	req := testutils.NewUnauthenticatedRequest(echo.GET, fmt.Sprintf("/events?address=%v", address), nil)
+
+	if acceptEncoding != "" {
+		req.Header.Set(echo.HeaderAcceptEncoding, acceptEncoding)
+	}
+
+	rec := httptest.NewRecorder()
+
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	return rec
+}
+
+func Test_GetEventsByAddress_gzip(t *testing.T) {
+	srv := newTestServer("")
+
+	owner := "0x0000000000000000000000000000000000000456"
+
+	// a large message, as the message list of a big proof or calldata would be
+	_, err := srv.eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+		Name:    "name",
+		Data:    fmt.Sprintf(`{"Owner": "%v", "Data": "%v"}`, owner, strings.Repeat("0", 4*DefaultGzipMinLength)),
+		ChainID: big.NewInt(167001),
+		Status:  relayer.EventStatusNew,
+	})
+	assert.Nil(t, err)
+
+	uncompressed := getEvents(t, srv, owner, "")
+	assert.Equal(t, "", uncompressed.Header().Get(echo.HeaderContentEncoding))
+	assert.Greater(t, uncompressed.Body.Len(), DefaultGzipMinLength)
+
+	compressed := getEvents(t, srv, owner, "gzip, deflate")
+	assert.Equal(t, "gzip", compressed.Header().Get(echo.HeaderContentEncoding))
+	assert.Equal(t, "", compressed.Header().Get(echo.HeaderContentLength))
+	assert.Contains(t, compressed.Header().Values(echo.HeaderVary), echo.HeaderAcceptEncoding)
+	assert.Less(t, compressed.Body.Len(), uncompressed.Body.Len())
+
+	r, err := gzip.NewReader(compressed.Body)
+	assert.Nil(t, err)
+
+	decompressed, err := io.ReadAll(r)
+	assert.Nil(t, err)
+
+	assert.Equal(t, uncompressed.Body.String(), string(decompressed))
+}
+
+func Test_GetEventsByAddress_gzipBelowMinLength(t *testing.T) {
+	srv := newTestServer("")
+
+	rec := getEvents(t, srv, "0x0000000000000000000000000000000000000789", "gzip")
+
+	assert.Equal(t, "", rec.Header().Get(echo.HeaderContentEncoding))
+	assert.Equal(t, strconv.Itoa(rec.Body.Len()), rec.Header().Get(echo.HeaderContentLength))
+	assert.Contains(t, rec.Header().Values(echo.HeaderVary), echo.HeaderAcceptEncoding)
+	assert.Contains(t, rec.Body.String(), `"items":[]`)
+}
+
+func Test_gzipAbove_error(t *testing.T) {
+	e := echo.New()
+	e.GET("/", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusBadRequest, strings.Repeat("a", 100))
+	}, gzipAbove(10))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(echo.HeaderAcceptEncoding, "gzip")
+
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	// the error handler writes after the middleware returns, so it's sent uncompressed
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "", rec.Header().Get(echo.HeaderContentEncoding))
+	assert.Contains(t, rec.Body.String(), strings.Repeat("a", 100))
+}
+
+func Test_acceptsGzip(t *testing.T) {
+	tests := []struct {
+		acceptEncoding string
+		want           bool
+	}{
+		{"", false},
+		{"gzip", true},
+		{"deflate, gzip", true},
+		{"GZIP;q=0.5", true},
+		{"gzip;q=0", false},
+		{"br", false},
+		{"x-gzip", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.acceptEncoding, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(echo.HeaderAcceptEncoding, tt.acceptEncoding)
+
+			assert.Equal(t, tt.want, acceptsGzip(req))
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/cyberhorsey/webutils"
+	"github.com/labstack/echo/v4"
+)
+
+// PauseProcessing stops sending transactions for messages from the `chainID` query param's
+// chain, or every chain when it's not set. Indexing carries on.
+func (srv *Server) PauseProcessing(c echo.Context) error {
+	pausers, err := srv.processingPausersFor(c.QueryParam("chainID"))
+	if err != nil {
+		return webutils.LogAndRenderErrors(c, http.StatusUnprocessableEntity, err)
+	}
+
+	for _, p := range pausers {
+		p.PauseProcessing()
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// ResumeProcessing undoes PauseProcessing, processing the messages indexed while paused
+func (srv *Server) ResumeProcessing(c echo.Context) error {
+	pausers, err := srv.processingPausersFor(c.QueryParam("chainID"))
+	if err != nil {
+		return webutils.LogAndRenderErrors(c, http.StatusUnprocessableEntity, err)
+	}
+
+	for _, p := range pausers {
+		p.ResumeProcessing()
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (srv *Server) processingPausersFor(chainIDParam string) ([]relayer.ProcessingPauser, error) {
+	if chainIDParam == "" {
+		pausers := make([]relayer.ProcessingPauser, 0, len(srv.processingPausers))
+		for _, p := range srv.processingPausers {
+			pausers = append(pausers, p)
+		}
+
+		return pausers, nil
+	}
+
+	chainID, err := strconv.ParseInt(chainIDParam, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidChainID
+	}
+
+	p, ok := srv.processingPausers[chainID]
+	if !ok {
+		return nil, ErrNoProcessingPauser
+	}
+
+	return []relayer.ProcessingPauser{p}, nil
+}
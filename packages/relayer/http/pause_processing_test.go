@@ -0,0 +1,82 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/cyberhorsey/webutils/testutils"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PauseAndResumeProcessing(t *testing.T) {
+	srv := newTestServer("")
+
+	pauser := srv.processingPausers[mock.MockChainID.Int64()]
+
+	tests := []struct {
+		name       string
+		url        string
+		apiKey     string
+		wantStatus int
+		wantPaused bool
+	}{
+		{
+			"pauseChain",
+			"/admin/processing/pause?chainID=167001",
+			testAdminAPIKey,
+			http.StatusOK,
+			true,
+		},
+		{
+			"resumeAll",
+			"/admin/processing/resume",
+			testAdminAPIKey,
+			http.StatusOK,
+			false,
+		},
+		{
+			"pauseAll",
+			"/admin/processing/pause",
+			testAdminAPIKey,
+			http.StatusOK,
+			true,
+		},
+		{
+			"resumeUnknownChain",
+			"/admin/processing/resume?chainID=1",
+			testAdminAPIKey,
+			http.StatusUnprocessableEntity,
+			true,
+		},
+		{
+			"resumeInvalidChainID",
+			"/admin/processing/resume?chainID=l1",
+			testAdminAPIKey,
+			http.StatusUnprocessableEntity,
+			true,
+		},
+		{
+			"wrongAPIKey",
+			"/admin/processing/resume",
+			"wrong",
+			http.StatusUnauthorized,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := testutils.NewAuthenticatedRequestWithJWT(tt.apiKey, echo.POST, tt.url, nil)
+
+			rec := httptest.NewRecorder()
+
+			srv.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantPaused, pauser.ProcessingPaused())
+		})
+	}
+}
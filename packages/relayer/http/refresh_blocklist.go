@@ -0,0 +1,28 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/cyberhorsey/webutils"
+	"github.com/labstack/echo/v4"
+)
+
+type refreshBlocklistResponse struct {
+	// Addresses is how many addresses are on the blocklist once refreshed
+	Addresses int `json:"addresses"`
+}
+
+// RefreshBlocklist reloads the blocklist from its file, so changes to it take effect without
+// a restart. The old list is kept if the file can't be read.
+func (srv *Server) RefreshBlocklist(c echo.Context) error {
+	if srv.blocklistRefresher == nil {
+		return webutils.LogAndRenderErrors(c, http.StatusUnprocessableEntity, ErrNoBlocklist)
+	}
+
+	n, err := srv.blocklistRefresher.RefreshBlocklist(c.Request().Context())
+	if err != nil {
+		return webutils.LogAndRenderErrors(c, http.StatusUnprocessableEntity, err)
+	}
+
+	return c.JSON(http.StatusOK, refreshBlocklistResponse{Addresses: n})
+}
@@ -0,0 +1,81 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/cyberhorsey/webutils/testutils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RefreshBlocklist(t *testing.T) {
+	tests := []struct {
+		name          string
+		refresher     *mock.Blocklist
+		apiKey        string
+		wantStatus    int
+		wantBody      string
+		wantRefreshes int
+	}{
+		{
+			"success",
+			&mock.Blocklist{Addresses: map[common.Address]bool{common.HexToAddress("0x01"): true}},
+			testAdminAPIKey,
+			http.StatusOK,
+			`{"addresses":1}`,
+			1,
+		},
+		{
+			"refreshFails",
+			&mock.Blocklist{FailRefresh: true},
+			testAdminAPIKey,
+			http.StatusUnprocessableEntity,
+			"",
+			0,
+		},
+		{
+			"noBlocklist",
+			nil,
+			testAdminAPIKey,
+			http.StatusUnprocessableEntity,
+			"ERR_NO_BLOCKLIST",
+			0,
+		},
+		{
+			"wrongAPIKey",
+			&mock.Blocklist{},
+			"wrong",
+			http.StatusUnauthorized,
+			"",
+			0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newTestServer("")
+
+			srv.blocklistRefresher = nil
+			if tt.refresher != nil {
+				srv.blocklistRefresher = tt.refresher
+			}
+
+			req := testutils.NewAuthenticatedRequestWithJWT(tt.apiKey, echo.POST, "/admin/blocklist/refresh", nil)
+
+			rec := httptest.NewRecorder()
+
+			srv.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.wantBody)
+
+			if tt.refresher != nil {
+				assert.Equal(t, tt.wantRefreshes, tt.refresher.Refreshes)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package http
+
+import (
+	"html"
+	"net/http"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/cyberhorsey/webutils"
+	"github.com/labstack/echo/v4"
+)
+
+// ReleaseHeldMessage releases a message held for review back into processing
+func (srv *Server) ReleaseHeldMessage(c echo.Context) error {
+	msgHash := html.EscapeString(c.Param("msgHash"))
+
+	// releasing acts on the event's current status, which a lagging replica could have stale.
+	ctx := relayer.WithPrimaryReads(c.Request().Context())
+
+	e, err := srv.eventRepo.FirstByEventAndMsgHash(
+		ctx,
+		relayer.EventNameMessageSent,
+		msgHash,
+	)
+	if err != nil {
+		return webutils.LogAndRenderErrors(c, http.StatusUnprocessableEntity, err)
+	}
+
+	if e == nil {
+		return webutils.LogAndRenderErrors(c, http.StatusNotFound, ErrEventNotFound)
+	}
+
+	releaser, ok := srv.messageReleasers[e.ChainID]
+	if !ok {
+		return webutils.LogAndRenderErrors(c, http.StatusUnprocessableEntity, ErrNoMessageReleaser)
+	}
+
+	if err := releaser.ReleaseMessage(ctx, e); err != nil {
+		return webutils.LogAndRenderErrors(c, http.StatusUnprocessableEntity, err)
+	}
+
+	return c.NoContent(http.StatusOK)
+}
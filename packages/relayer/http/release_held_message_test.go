@@ -0,0 +1,88 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/cyberhorsey/webutils/testutils"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ReleaseHeldMessage(t *testing.T) {
+	srv := newTestServer("")
+
+	_, err := srv.eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+		Name:    relayer.EventNameMessageSent,
+		Event:   relayer.EventNameMessageSent,
+		Data:    "{}",
+		ChainID: mock.MockChainID,
+		Status:  relayer.EventStatusHeld,
+		MsgHash: "0x1",
+	})
+	assert.Equal(t, nil, err)
+
+	_, err = srv.eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+		Name:    relayer.EventNameMessageSent,
+		Event:   relayer.EventNameMessageSent,
+		Data:    "{}",
+		ChainID: mock.MockChainID,
+		Status:  relayer.EventStatusNew,
+		MsgHash: "0x2",
+	})
+	assert.Equal(t, nil, err)
+
+	tests := []struct {
+		name       string
+		msgHash    string
+		apiKey     string
+		wantStatus int
+	}{
+		{
+			"success",
+			"0x1",
+			testAdminAPIKey,
+			http.StatusOK,
+		},
+		{
+			"notHeld",
+			"0x2",
+			testAdminAPIKey,
+			http.StatusUnprocessableEntity,
+		},
+		{
+			"notFound",
+			"0x3",
+			testAdminAPIKey,
+			http.StatusNotFound,
+		},
+		{
+			"wrongAPIKey",
+			"0x1",
+			"wrong",
+			http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := testutils.NewAuthenticatedRequestWithJWT(
+				tt.apiKey,
+				echo.POST,
+				fmt.Sprintf("/admin/messages/%v/release", tt.msgHash),
+				nil,
+			)
+
+			rec := httptest.NewRecorder()
+
+			srv.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
@@ -1,9 +1,34 @@
 package http
 
+import (
+	"crypto/subtle"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
 func (srv *Server) configureRoutes() {
 	srv.echo.GET("/healthz", srv.Health)
 	srv.echo.GET("/", srv.Health)
+	srv.echo.GET("/version", srv.GetVersion)
+	srv.echo.GET("/status/relayer", srv.GetRelayerStatus)
+	srv.echo.GET("/stats", srv.GetStats)
 
-	srv.echo.GET("/events", srv.GetEventsByAddress)
+	srv.echo.GET("/events", srv.GetEventsByAddress, gzipAbove(srv.gzipMinLength))
 	srv.echo.GET("/blockInfo", srv.GetBlockInfo)
+	srv.echo.GET("/messages/:msgHash/diagnose", srv.DiagnoseMessage)
+	srv.echo.GET("/messages/:msgHash/timeline", srv.GetMessageTimeline)
+
+	if srv.adminAPIKey != "" {
+		admin := srv.echo.Group("/admin", middleware.KeyAuth(func(key string, c echo.Context) (bool, error) {
+			return subtle.ConstantTimeCompare([]byte(key), []byte(srv.adminAPIKey)) == 1, nil
+		}))
+
+		admin.POST("/messages/:msgHash/release", srv.ReleaseHeldMessage)
+		admin.POST("/reprocess", srv.BulkReprocess)
+		admin.POST("/processing/pause", srv.PauseProcessing)
+		admin.POST("/processing/resume", srv.ResumeProcessing)
+		admin.GET("/recipients/failing", srv.GetTopFailingRecipients)
+		admin.POST("/blocklist/refresh", srv.RefreshBlocklist)
+	}
 }
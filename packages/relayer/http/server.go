@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"sort"
+	"time"
 
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
 	"github.com/labstack/echo/v4/middleware"
@@ -19,6 +21,26 @@ type Server struct {
 	blockRepo   relayer.BlockRepository
 	l1EthClient relayer.EthClient
 	l2EthClient relayer.EthClient
+	// bridgeStatusChangeRepo is the bridges' MessageStatusChanged events, by message hash
+	bridgeStatusChangeRepo relayer.BridgeStatusChangeRepository
+
+	adminAPIKey       string
+	messageReleasers  map[int64]relayer.MessageReleaser
+	processingPausers map[int64]relayer.ProcessingPauser
+	messageDiagnosers map[int64]relayer.MessageDiagnoser
+	// messageReprocessors are keyed by the source chain ID of the messages they can reprocess
+	messageReprocessors map[int64]relayer.MessageReprocessor
+	// syncProgressReporters are keyed by the chain ID of the indexer they report on
+	syncProgressReporters map[int64]relayer.SyncProgressReporter
+	blocklistRefresher    relayer.BlocklistRefresher
+	// roleReporter is nil without leader election, when the instance is always the leader
+	roleReporter relayer.RoleReporter
+	// gzipMinLength is the smallest message list body gzipped for clients accepting it
+	gzipMinLength int
+	// runwayReporters are keyed by the chain ID whose gas runway they report
+	runwayReporters map[int64]relayer.RunwayReporter
+	// statsCache keeps GET /stats answers for a short while
+	statsCache *statsCache
 }
 
 type NewServerOpts struct {
@@ -28,6 +50,33 @@ type NewServerOpts struct {
 	CorsOrigins []string
 	L1EthClient relayer.EthClient
 	L2EthClient relayer.EthClient
+	// BridgeStatusChangeRepo is the bridges' MessageStatusChanged events, for message timelines
+	BridgeStatusChangeRepo relayer.BridgeStatusChangeRepository
+	// AdminAPIKey enables the /admin routes when set, requests must present it as a Bearer token
+	AdminAPIKey string
+	// MessageReleasers are keyed by the source chain ID of the messages they can release
+	MessageReleasers map[int64]relayer.MessageReleaser
+	// ProcessingPausers are keyed by the source chain ID of the messages they process
+	ProcessingPausers map[int64]relayer.ProcessingPauser
+	// MessageDiagnosers are keyed by the source chain ID of the messages they can diagnose
+	MessageDiagnosers map[int64]relayer.MessageDiagnoser
+	// MessageReprocessors are keyed by the source chain ID of the messages they can reprocess
+	MessageReprocessors map[int64]relayer.MessageReprocessor
+	// SyncProgressReporters are keyed by the chain ID of the indexer they report on
+	SyncProgressReporters map[int64]relayer.SyncProgressReporter
+	// BlocklistRefresher is optional, and reloads the blocklist on POST /admin/blocklist/refresh
+	BlocklistRefresher relayer.BlocklistRefresher
+	// RoleReporter is optional, and reports whether the instance is the leader with leader election on
+	RoleReporter relayer.RoleReporter
+	// GzipMinLength is the smallest message list body, in bytes, gzipped for clients accepting it.
+	// It defaults to DefaultGzipMinLength.
+	GzipMinLength int
+	// RunwayReporters are optional, and keyed by the chain ID whose gas runway they report on
+	// GET /status/relayer
+	RunwayReporters map[int64]relayer.RunwayReporter
+	// StatsCacheTTL is how long a GET /stats answer is reused before the events are aggregated again.
+	// It defaults to DefaultStatsCacheTTL.
+	StatsCacheTTL time.Duration
 }
 
 func (opts NewServerOpts) Validate() error {
@@ -55,6 +104,10 @@ func (opts NewServerOpts) Validate() error {
 		return relayer.ErrNoBlockRepository
 	}
 
+	if opts.BridgeStatusChangeRepo == nil {
+		return relayer.ErrNoBridgeStatusChangeRepository
+	}
+
 	return nil
 }
 
@@ -69,8 +122,33 @@ func NewServer(opts NewServerOpts) (*Server, error) {
 		eventRepo:   opts.EventRepo,
 		l1EthClient: opts.L1EthClient,
 		l2EthClient: opts.L2EthClient,
+
+		bridgeStatusChangeRepo: opts.BridgeStatusChangeRepo,
+
+		adminAPIKey:       opts.AdminAPIKey,
+		messageReleasers:  opts.MessageReleasers,
+		processingPausers: opts.ProcessingPausers,
+		messageDiagnosers: opts.MessageDiagnosers,
+
+		messageReprocessors:   opts.MessageReprocessors,
+		syncProgressReporters: opts.SyncProgressReporters,
+		blocklistRefresher:    opts.BlocklistRefresher,
+		roleReporter:          opts.RoleReporter,
+		gzipMinLength:         opts.GzipMinLength,
+		runwayReporters:       opts.RunwayReporters,
 	}
 
+	if srv.gzipMinLength <= 0 {
+		srv.gzipMinLength = DefaultGzipMinLength
+	}
+
+	statsCacheTTL := opts.StatsCacheTTL
+	if statsCacheTTL <= 0 {
+		statsCacheTTL = DefaultStatsCacheTTL
+	}
+
+	srv.statsCache = newStatsCache(statsCacheTTL)
+
 	corsOrigins := opts.CorsOrigins
 	if corsOrigins == nil {
 		corsOrigins = []string{"*"}
@@ -97,9 +175,53 @@ func (srv *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	srv.echo.ServeHTTP(w, r)
 }
 
-// Health endpoints for probes
+type healthResponse struct {
+	// Role is whether the instance sends transactions, or indexes on standby
+	Role             relayer.Role `json:"role"`
+	ProcessingPaused bool         `json:"processingPaused"`
+	// PausedChainIDs are the source chains whose messages aren't being processed
+	PausedChainIDs []int64 `json:"pausedChainIDs"`
+	// SyncProgress is how far each chain's indexer has got catching up with its head
+	SyncProgress []chainSyncProgress `json:"syncProgress"`
+}
+
+type chainSyncProgress struct {
+	ChainID int64 `json:"chainID"`
+	relayer.SyncProgress
+}
+
+// Health endpoints for probes. A paused processor, a standby instance, or an indexer still catching
+// up, is still healthy, so it only reports it.
 func (srv *Server) Health(c echo.Context) error {
-	return c.NoContent(http.StatusOK)
+	resp := healthResponse{
+		Role:           relayer.LeaderRole,
+		PausedChainIDs: make([]int64, 0),
+		SyncProgress:   make([]chainSyncProgress, 0),
+	}
+
+	if srv.roleReporter != nil {
+		resp.Role = srv.roleReporter.Role()
+	}
+
+	for chainID, p := range srv.processingPausers {
+		if p.ProcessingPaused() {
+			resp.PausedChainIDs = append(resp.PausedChainIDs, chainID)
+		}
+	}
+
+	sort.Slice(resp.PausedChainIDs, func(i, j int) bool { return resp.PausedChainIDs[i] < resp.PausedChainIDs[j] })
+
+	resp.ProcessingPaused = len(resp.PausedChainIDs) > 0
+
+	for chainID, r := range srv.syncProgressReporters {
+		resp.SyncProgress = append(resp.SyncProgress, chainSyncProgress{ChainID: chainID, SyncProgress: r.SyncProgress()})
+	}
+
+	sort.Slice(resp.SyncProgress, func(i, j int) bool {
+		return resp.SyncProgress[i].ChainID < resp.SyncProgress[j].ChainID
+	})
+
+	return c.JSON(http.StatusOK, resp)
 }
 
 func LogSkipper(c echo.Context) bool {
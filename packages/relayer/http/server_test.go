@@ -9,17 +9,47 @@ import (
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer/repo"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/joho/godotenv"
 	echo "github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 )
 
+var testAdminAPIKey = "admin-key"
+
 func newTestServer(url string) *Server {
 	_ = godotenv.Load("../.test.env")
 
 	srv := &Server{
 		echo:      echo.New(),
 		eventRepo: mock.NewEventRepository(),
+
+		bridgeStatusChangeRepo: mock.NewBridgeStatusChangeRepository(),
+
+		adminAPIKey: testAdminAPIKey,
+		messageReleasers: map[int64]relayer.MessageReleaser{
+			mock.MockChainID.Int64(): &mock.MessageReleaser{},
+		},
+		processingPausers: map[int64]relayer.ProcessingPauser{
+			mock.MockChainID.Int64(): &mock.IndexerAdmin{ChainID: mock.MockChainID.Int64()},
+		},
+		messageDiagnosers: map[int64]relayer.MessageDiagnoser{
+			mock.MockChainID.Int64(): &mock.MessageDiagnoser{},
+		},
+		messageReprocessors: map[int64]relayer.MessageReprocessor{
+			mock.MockChainID.Int64(): &mock.IndexerAdmin{ChainID: mock.MockChainID.Int64()},
+		},
+		syncProgressReporters: map[int64]relayer.SyncProgressReporter{
+			mock.MockChainID.Int64(): &mock.IndexerAdmin{
+				ChainID:  mock.MockChainID.Int64(),
+				Progress: relayer.SyncProgress{ProcessedHeight: 90, HeadHeight: 100, BlocksPerSecond: 2},
+			},
+		},
+		blocklistRefresher: &mock.Blocklist{
+			Addresses: map[common.Address]bool{common.HexToAddress("0x01"): true},
+		},
+		gzipMinLength: DefaultGzipMinLength,
+		statsCache:    newStatsCache(DefaultStatsCacheTTL),
 	}
 
 	srv.configureMiddleware([]string{"*"})
@@ -44,6 +74,8 @@ func Test_NewServer(t *testing.T) {
 				L1EthClient: &mock.EthClient{},
 				L2EthClient: &mock.EthClient{},
 				BlockRepo:   &mock.BlockRepository{},
+
+				BridgeStatusChangeRepo: mock.NewBridgeStatusChangeRepository(),
 			},
 			nil,
 		},
@@ -55,6 +87,8 @@ func Test_NewServer(t *testing.T) {
 				CorsOrigins: make([]string, 0),
 				L2EthClient: &mock.EthClient{},
 				BlockRepo:   &mock.BlockRepository{},
+
+				BridgeStatusChangeRepo: mock.NewBridgeStatusChangeRepository(),
 			},
 			relayer.ErrNoEthClient,
 		},
@@ -66,6 +100,8 @@ func Test_NewServer(t *testing.T) {
 				CorsOrigins: make([]string, 0),
 				L1EthClient: &mock.EthClient{},
 				BlockRepo:   &mock.BlockRepository{},
+
+				BridgeStatusChangeRepo: mock.NewBridgeStatusChangeRepository(),
 			},
 			relayer.ErrNoEthClient,
 		},
@@ -77,6 +113,8 @@ func Test_NewServer(t *testing.T) {
 				CorsOrigins: make([]string, 0),
 				L1EthClient: &mock.EthClient{},
 				L2EthClient: &mock.EthClient{},
+
+				BridgeStatusChangeRepo: mock.NewBridgeStatusChangeRepository(),
 			},
 			relayer.ErrNoBlockRepository,
 		},
@@ -88,6 +126,8 @@ func Test_NewServer(t *testing.T) {
 				L1EthClient: &mock.EthClient{},
 				L2EthClient: &mock.EthClient{},
 				BlockRepo:   &mock.BlockRepository{},
+
+				BridgeStatusChangeRepo: mock.NewBridgeStatusChangeRepository(),
 			},
 			relayer.ErrNoEventRepository,
 		},
@@ -99,6 +139,8 @@ func Test_NewServer(t *testing.T) {
 				L1EthClient: &mock.EthClient{},
 				L2EthClient: &mock.EthClient{},
 				BlockRepo:   &mock.BlockRepository{},
+
+				BridgeStatusChangeRepo: mock.NewBridgeStatusChangeRepository(),
 			},
 			relayer.ErrNoCORSOrigins,
 		},
@@ -110,9 +152,23 @@ func Test_NewServer(t *testing.T) {
 				L1EthClient: &mock.EthClient{},
 				L2EthClient: &mock.EthClient{},
 				BlockRepo:   &mock.BlockRepository{},
+
+				BridgeStatusChangeRepo: mock.NewBridgeStatusChangeRepository(),
 			},
 			ErrNoHTTPFramework,
 		},
+		{
+			"noBridgeStatusChangeRepo",
+			NewServerOpts{
+				Echo:        echo.New(),
+				EventRepo:   &repo.EventRepository{},
+				CorsOrigins: make([]string, 0),
+				L1EthClient: &mock.EthClient{},
+				L2EthClient: &mock.EthClient{},
+				BlockRepo:   &mock.BlockRepository{},
+			},
+			relayer.ErrNoBridgeStatusChangeRepository,
+		},
 	}
 
 	for _, tt := range tests {
@@ -132,6 +188,38 @@ func Test_Health(t *testing.T) {
 	if rec.Code != http.StatusOK {
 		t.Fatalf("Test_Health expected code %v, got %v", http.StatusOK, rec.Code)
 	}
+
+	assert.JSONEq(t, `{"role":"leader","processingPaused":false,"pausedChainIDs":[],"syncProgress":[`+
+		`{"chainID":167001,"processedHeight":90,"headHeight":100,"blocksPerSecond":2,"etaSeconds":null,"caughtUp":false}`+
+		`]}`, rec.Body.String())
+
+	srv.processingPausers[mock.MockChainID.Int64()].PauseProcessing()
+
+	rec = httptest.NewRecorder()
+
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"processingPaused":true,"pausedChainIDs":[167001]`)
+}
+
+type fixedRole relayer.Role
+
+func (r fixedRole) Role() relayer.Role {
+	return relayer.Role(r)
+}
+
+func Test_Health_standby(t *testing.T) {
+	srv := newTestServer("")
+	srv.roleReporter = fixedRole(relayer.StandbyRole)
+
+	req, _ := http.NewRequest(echo.GET, "/healthz", nil)
+	rec := httptest.NewRecorder()
+
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"role":"standby"`)
 }
 
 func Test_Root(t *testing.T) {
@@ -0,0 +1,33 @@
+package indexer
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	// confirmProcessedInterval is how often processed messages are checked for being confirmed
+	confirmProcessedInterval = 15 * time.Second
+)
+
+// confirmProcessedEvery has the processor re-check chainID's processed but unconfirmed messages,
+// now and then every interval, until ctx is done.
+func (svc *Service) confirmProcessedEvery(ctx context.Context, chainID *big.Int, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := svc.processor.ConfirmProcessed(ctx, chainID); err != nil {
+			log.Errorf("chain ID %v svc.processor.ConfirmProcessed: %v", chainID, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
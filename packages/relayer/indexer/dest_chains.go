@@ -0,0 +1,27 @@
+package indexer
+
+import (
+	"math/big"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+)
+
+// indexesDestChain is whether MessageSent events for messages to destChainID are indexed, every
+// destination's are without DestChainIDs set. The bridge doesn't index the destination chain ID in
+// the event's topics, so the node can't filter them out, and they're dropped once fetched.
+func (svc *Service) indexesDestChain(destChainID *big.Int) bool {
+	if len(svc.destChainIDs) == 0 {
+		return true
+	}
+
+	return destChainID != nil && svc.destChainIDs[destChainID.Int64()]
+}
+
+// countIndexed counts a saved MessageSent event by its message's destination chain
+func countIndexed(opts relayer.SaveEventOpts) {
+	if opts.DestChainID == nil {
+		return
+	}
+
+	relayer.MessageSentEventsIndexed.WithLabelValues(opts.DestChainID.String()).Inc()
+}
@@ -0,0 +1,76 @@
+package indexer
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_indexesDestChain(t *testing.T) {
+	tests := []struct {
+		name         string
+		destChainIDs map[int64]bool
+		destChainID  *big.Int
+		want         bool
+	}{
+		{"unset", nil, big.NewInt(2), true},
+		{"indexed", map[int64]bool{2: true, 3: true}, big.NewInt(3), true},
+		{"notIndexed", map[int64]bool{2: true}, big.NewInt(3), false},
+		{"noDestChainID", map[int64]bool{2: true}, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &Service{destChainIDs: tt.destChainIDs}
+
+			assert.Equal(t, tt.want, svc.indexesDestChain(tt.destChainID))
+		})
+	}
+}
+
+func Test_handleEvent_destChainNotIndexed(t *testing.T) {
+	svc, _ := newTestService()
+	svc.destChainIDs = map[int64]bool{5: true}
+
+	event := &bridge.BridgeMessageSent{
+		MsgHash: mock.SuccessMsgHash,
+		Message: bridge.IBridgeMessage{
+			Id:            big.NewInt(0),
+			Owner:         relayerAddr,
+			SrcChainId:    big.NewInt(1),
+			DestChainId:   big.NewInt(2),
+			DepositValue:  big.NewInt(0),
+			CallValue:     big.NewInt(0),
+			ProcessingFee: big.NewInt(0),
+			GasLimit:      big.NewInt(1),
+		},
+	}
+
+	assert.True(t, svc.skipEvent(context.Background(), event))
+	assert.Nil(t, svc.handleEvent(context.Background(), big.NewInt(1), event))
+
+	saved, err := svc.eventRepo.FirstByMsgHash(context.Background(), common.Hash(mock.SuccessMsgHash).Hex())
+	assert.Nil(t, err)
+	assert.Nil(t, saved)
+
+	// once its destination is indexed, it's saved
+	svc.destChainIDs[2] = true
+
+	assert.False(t, svc.skipEvent(context.Background(), event))
+}
+
+func Test_countIndexed(t *testing.T) {
+	before := testutil.ToFloat64(relayer.MessageSentEventsIndexed.WithLabelValues("9999"))
+
+	countIndexed(relayer.SaveEventOpts{DestChainID: big.NewInt(9999)})
+	countIndexed(relayer.SaveEventOpts{})
+
+	assert.Equal(t, before+1, testutil.ToFloat64(relayer.MessageSentEventsIndexed.WithLabelValues("9999")))
+}
@@ -0,0 +1,21 @@
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/pkg/errors"
+)
+
+// DiagnoseMessage explains why a message this service indexed hasn't been processed.
+func (svc *Service) DiagnoseMessage(ctx context.Context, e *relayer.Event) (*relayer.MessageDiagnosis, error) {
+	var event bridge.BridgeMessageSent
+
+	if err := json.Unmarshal(e.Data, &event); err != nil {
+		return nil, errors.Wrap(err, "json.Unmarshal")
+	}
+
+	return svc.processor.DiagnoseMessage(ctx, &event, e), nil
+}
@@ -0,0 +1,42 @@
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DiagnoseMessage(t *testing.T) {
+	svc, _ := newTestService()
+
+	data, err := json.Marshal(&bridge.BridgeMessageSent{
+		MsgHash: mock.SuccessMsgHash,
+		Message: bridge.IBridgeMessage{GasLimit: big.NewInt(1)},
+		Raw:     types.Log{Topics: []common.Hash{}, Data: []byte{}},
+	})
+	assert.Nil(t, err)
+
+	d, err := svc.DiagnoseMessage(context.Background(), &relayer.Event{
+		Status: relayer.EventStatusHeld,
+		Data:   data,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, common.Hash(mock.SuccessMsgHash).Hex(), d.MsgHash)
+	assert.Equal(t, "held", d.Status)
+	assert.Equal(t, "new", d.DestStatus.Detail)
+}
+
+func Test_DiagnoseMessage_invalidData(t *testing.T) {
+	svc, _ := newTestService()
+
+	_, err := svc.DiagnoseMessage(context.Background(), &relayer.Event{Data: []byte("{")})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,44 @@
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// originalEvent returns the MessageSent event already indexed for event's message from another
+// log, nil if there isn't one. Messages are keyed by their hash rather than the log they were
+// emitted in, so a message an upgraded bridge emits again, under whatever signature, is only
+// processed once. The same log indexed again, i.e. on resuming from the last processed block,
+// isn't a duplicate.
+func (svc *Service) originalEvent(ctx context.Context, event *bridge.BridgeMessageSent) (*relayer.Event, error) {
+	// a lagging replica could miss one saved moments ago
+	e, err := svc.eventRepo.FirstByEventAndMsgHash(
+		relayer.WithPrimaryReads(ctx),
+		relayer.EventNameMessageSent,
+		common.Hash(event.MsgHash).Hex(),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "svc.eventRepo.FirstByEventAndMsgHash")
+	}
+
+	if e == nil {
+		return nil, nil
+	}
+
+	var indexed bridge.BridgeMessageSent
+
+	if err := json.Unmarshal(e.Data, &indexed); err != nil {
+		return nil, errors.Wrap(err, "json.Unmarshal")
+	}
+
+	if indexed.Raw.TxHash == event.Raw.TxHash && indexed.Raw.Index == event.Raw.Index {
+		return nil, nil
+	}
+
+	return e, nil
+}
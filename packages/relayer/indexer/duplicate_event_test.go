@@ -0,0 +1,76 @@
+package indexer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_saveEvent_duplicate(t *testing.T) {
+	msgHash := [32]byte{0x1}
+	sender := common.HexToAddress("0x63FaC9201494f0bd17B9892B9fae4d52fe3BD377")
+
+	tests := []struct {
+		name          string
+		txHash        common.Hash
+		logIndex      uint
+		wantDuplicate bool
+	}{
+		{"sameLogIndexedAgain", common.Hash{0x1}, 0, false},
+		{"otherLogInSameTx", common.Hash{0x1}, 1, true},
+		{"otherTx", common.Hash{0x2}, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newTestService()
+			eventRepo := &mock.EventRepository{}
+			svc.eventRepo = eventRepo
+
+			original := orderedTestEvent(msgHash, sender)
+			original.Raw.TxHash = common.Hash{0x1}
+
+			_, status, err := svc.saveEvent(context.Background(), mock.MockChainID, original)
+			assert.Nil(t, err)
+			assert.NotEqual(t, relayer.EventStatusDuplicate, status)
+
+			saved, err := eventRepo.FirstByEventAndMsgHash(
+				context.Background(),
+				relayer.EventNameMessageSent,
+				common.Hash(msgHash).Hex(),
+			)
+			assert.Nil(t, err)
+
+			event := orderedTestEvent(msgHash, sender)
+			event.Raw.TxHash = tt.txHash
+			event.Raw.Index = tt.logIndex
+
+			_, status, err = svc.saveEvent(context.Background(), mock.MockChainID, event)
+			assert.Nil(t, err)
+
+			duplicates, err := eventRepo.FindAllByStatus(
+				context.Background(),
+				mock.MockChainID,
+				relayer.EventStatusDuplicate,
+			)
+			assert.Nil(t, err)
+
+			if !tt.wantDuplicate {
+				assert.NotEqual(t, relayer.EventStatusDuplicate, status)
+				assert.Empty(t, duplicates)
+
+				return
+			}
+
+			// saved linked to the first, and never processed
+			assert.Equal(t, relayer.EventStatusDuplicate, status)
+			assert.False(t, canProcessMessage(context.Background(), status, sender, svc.relayerAddr))
+			assert.Len(t, duplicates, 1)
+			assert.Equal(t, &saved.ID, duplicates[0].DuplicateOfEventID)
+		})
+	}
+}
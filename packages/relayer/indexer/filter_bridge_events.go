@@ -0,0 +1,130 @@
+package indexer
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	// bridgeLogParser decodes logs with the bridge's ABI. It never filters, so it's bound to no contract.
+	bridgeLogParser, _ = bridge.NewBridgeFilterer(common.Address{}, nil)
+
+	messageSentTopic          common.Hash
+	messageStatusChangedTopic common.Hash
+)
+
+func init() {
+	bridgeABI, err := bridge.BridgeMetaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+
+	messageSentTopic = bridgeABI.Events["MessageSent"].ID
+	messageStatusChangedTopic = bridgeABI.Events["MessageStatusChanged"].ID
+}
+
+// bridgeEvents are the events the indexer handles from a range of blocks, each in the order
+// they were emitted
+type bridgeEvents struct {
+	sent          []*bridge.BridgeMessageSent
+	statusChanged []*bridge.BridgeMessageStatusChanged
+	// unpackFailed are the logs matching one of the events that couldn't be unpacked, and were skipped
+	unpackFailed []unpackFailure
+}
+
+// filterBridgeEvents gets the bridge's MessageSent and MessageStatusChanged events from the blocks
+// start to end, inclusive, in a single eth_getLogs request with only those events' signatures as
+// its first topic, so the node doesn't send the bridge's other logs. With svc.fetchAllLogTopics,
+// every log the bridge emitted is fetched instead, and the others are discarded, for debugging.
+// A log that can't be unpacked is skipped, see quarantineLog, so it doesn't stall indexing.
+func (svc *Service) filterBridgeEvents(
+	ctx context.Context,
+	chainID *big.Int,
+	start uint64,
+	end uint64,
+) (*bridgeEvents, error) {
+	query := ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(start),
+		ToBlock:   new(big.Int).SetUint64(end),
+		Addresses: []common.Address{svc.bridgeAddress},
+	}
+
+	if !svc.fetchAllLogTopics {
+		query.Topics = [][]common.Hash{{messageSentTopic, messageStatusChangedTopic}}
+	}
+
+	logs, err := svc.logFilterer.FilterLogs(ctx, query)
+	if err != nil {
+		return nil, errors.Wrap(err, "svc.logFilterer.FilterLogs")
+	}
+
+	events := &bridgeEvents{
+		sent:          make([]*bridge.BridgeMessageSent, 0),
+		statusChanged: make([]*bridge.BridgeMessageStatusChanged, 0),
+		unpackFailed:  make([]unpackFailure, 0),
+	}
+
+	discarded := 0
+
+	for _, l := range logs {
+		if len(l.Topics) == 0 {
+			discarded++
+			continue
+		}
+
+		switch l.Topics[0] {
+		case messageSentTopic:
+			event, err := bridgeLogParser.ParseMessageSent(l)
+			if err != nil {
+				failure := unpackFailure{eventName: relayer.EventNameMessageSent, raw: l}
+				if err := svc.quarantineLog(ctx, chainID, failure, err); err != nil {
+					return nil, errors.Wrap(err, "bridgeLogParser.ParseMessageSent")
+				}
+
+				events.unpackFailed = append(events.unpackFailed, failure)
+
+				continue
+			}
+
+			events.sent = append(events.sent, event)
+		case messageStatusChangedTopic:
+			event, err := bridgeLogParser.ParseMessageStatusChanged(l)
+			if err != nil {
+				failure := unpackFailure{eventName: relayer.EventNameMessageStatusChanged, raw: l}
+				if err := svc.quarantineLog(ctx, chainID, failure, err); err != nil {
+					return nil, errors.Wrap(err, "bridgeLogParser.ParseMessageStatusChanged")
+				}
+
+				events.unpackFailed = append(events.unpackFailed, failure)
+
+				continue
+			}
+
+			events.statusChanged = append(events.statusChanged, event)
+		default:
+			discarded++
+		}
+	}
+
+	if svc.fetchAllLogTopics {
+		log.Debugf(
+			"blocks %v to %v: fetched %v logs, %v MessageSent, %v MessageStatusChanged, %v unpack failed, discarded %v",
+			start,
+			end,
+			len(logs),
+			len(events.sent),
+			len(events.statusChanged),
+			len(events.unpackFailed),
+			discarded,
+		)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,189 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingLogFilterer records the queries made to the bridge's logs
+type recordingLogFilterer struct {
+	bridge  *mock.Bridge
+	queries []ethereum.FilterQuery
+}
+
+func (f *recordingLogFilterer) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	f.queries = append(f.queries, q)
+
+	return f.bridge.FilterLogs(ctx, q)
+}
+
+func Test_filterBridgeEvents(t *testing.T) {
+	message := bridge.IBridgeMessage{
+		Id:            big.NewInt(0),
+		SrcChainId:    big.NewInt(1),
+		DestChainId:   big.NewInt(2),
+		Owner:         common.HexToAddress("0x1"),
+		DepositValue:  big.NewInt(0),
+		CallValue:     big.NewInt(0),
+		ProcessingFee: big.NewInt(0),
+		GasLimit:      big.NewInt(1),
+	}
+
+	txHash := common.HexToHash("0xabc")
+
+	// a log of an event the indexer doesn't handle
+	other := types.Log{Topics: []common.Hash{common.HexToHash("0x1234")}, BlockNumber: 5, TxHash: txHash, Index: 3}
+
+	tests := []struct {
+		name              string
+		fetchAllLogTopics bool
+		wantTopics        [][]common.Hash
+	}{
+		{"handledEvents", false, [][]common.Hash{{messageSentTopic, messageStatusChangedTopic}}},
+		{"allTopics", true, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, b := newTestService()
+
+			b.(*mock.Bridge).Logs = append(b.(*mock.Bridge).Logs,
+				mock.MessageSentLog(mock.SuccessMsgHash, message, 5, txHash, 0),
+				mock.MessageStatusChangedLog(mock.SuccessMsgHash, relayer.EventStatusDone, 5, txHash, 1),
+				mock.MessageSentLog([32]byte{0x5}, message, 6, txHash, 0),
+				other,
+				mock.MessageSentLog([32]byte{0x6}, message, 8, txHash, 0),
+			)
+
+			filterer := &recordingLogFilterer{bridge: b.(*mock.Bridge)}
+			svc.logFilterer = filterer
+			svc.bridgeAddress = common.HexToAddress("0x1000777700000000000000000000000000000004")
+			svc.fetchAllLogTopics = tt.fetchAllLogTopics
+
+			events, err := svc.filterBridgeEvents(context.Background(), big.NewInt(1), 5, 7)
+			assert.Nil(t, err)
+
+			// one request, for the bridge's logs
+			assert.Equal(t, 1, len(filterer.queries))
+			assert.Equal(t, []common.Address{svc.bridgeAddress}, filterer.queries[0].Addresses)
+			assert.Equal(t, tt.wantTopics, filterer.queries[0].Topics)
+			assert.Equal(t, big.NewInt(5), filterer.queries[0].FromBlock)
+			assert.Equal(t, big.NewInt(7), filterer.queries[0].ToBlock)
+
+			assert.Equal(t, 2, len(events.sent))
+			assert.Equal(t, mock.SuccessMsgHash, events.sent[0].MsgHash)
+			assert.Equal(t, [32]byte{0x5}, events.sent[1].MsgHash)
+
+			assert.Equal(t, 1, len(events.statusChanged))
+			assert.Equal(t, uint8(relayer.EventStatusDone), events.statusChanged[0].Status)
+		})
+	}
+}
+
+func Test_filterBridgeEvents_unpackFailure(t *testing.T) {
+	message := bridge.IBridgeMessage{
+		Id:            big.NewInt(0),
+		SrcChainId:    big.NewInt(1),
+		DestChainId:   big.NewInt(2),
+		Owner:         common.HexToAddress("0x1"),
+		DepositValue:  big.NewInt(0),
+		CallValue:     big.NewInt(0),
+		ProcessingFee: big.NewInt(0),
+		GasLimit:      big.NewInt(1),
+	}
+
+	txHash := common.HexToHash("0xabc")
+
+	// a MessageSent log whose data is laid out differently, as after a bridge upgrade
+	malformed := mock.MessageSentLog([32]byte{0x5}, message, 6, txHash, 1)
+	malformed.Data = malformed.Data[:3]
+
+	tests := []struct {
+		name               string
+		strictLogUnpacking bool
+		quarantine         bool
+		wantErr            bool
+		wantQuarantined    int
+	}{
+		{"skipped", false, false, false, 0},
+		{"quarantined", false, true, false, 1},
+		{"strict", true, true, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, b := newTestService()
+
+			b.(*mock.Bridge).Logs = append(b.(*mock.Bridge).Logs,
+				mock.MessageSentLog(mock.SuccessMsgHash, message, 5, txHash, 0),
+				malformed,
+				mock.MessageStatusChangedLog(mock.SuccessMsgHash, relayer.EventStatusDone, 7, txHash, 2),
+			)
+
+			quarantinedLogRepo := mock.NewQuarantinedLogRepository()
+
+			svc.strictLogUnpacking = tt.strictLogUnpacking
+			if tt.quarantine {
+				svc.quarantinedLogRepo = quarantinedLogRepo
+			}
+
+			events, err := svc.filterBridgeEvents(context.Background(), big.NewInt(1), 5, 7)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				assert.Nil(t, events)
+
+				return
+			}
+
+			assert.Nil(t, err)
+
+			// the rest of the batch is still indexed
+			assert.Equal(t, 1, len(events.sent))
+			assert.Equal(t, mock.SuccessMsgHash, events.sent[0].MsgHash)
+			assert.Equal(t, 1, len(events.statusChanged))
+
+			assert.Equal(t, 1, len(events.unpackFailed))
+			assert.Equal(t, relayer.EventNameMessageSent, events.unpackFailed[0].eventName)
+
+			quarantined := quarantinedLogRepo.Logs()
+			assert.Equal(t, tt.wantQuarantined, len(quarantined))
+
+			if tt.wantQuarantined > 0 {
+				assert.Equal(t, int64(1), quarantined[0].ChainID)
+				assert.Equal(t, uint64(6), quarantined[0].BlockNumber)
+				assert.Equal(t, txHash.Hex(), quarantined[0].TxHash)
+				assert.Equal(t, uint(1), quarantined[0].LogIndex)
+				assert.Equal(t, hexutil.Encode(malformed.Data), quarantined[0].Data)
+				assert.NotEqual(t, "", quarantined[0].Error)
+			}
+		})
+	}
+}
+
+func Test_quarantineLog_saveError(t *testing.T) {
+	svc, _ := newTestService()
+
+	quarantinedLogRepo := mock.NewQuarantinedLogRepository()
+	quarantinedLogRepo.Err = errors.New("db down")
+	svc.quarantinedLogRepo = quarantinedLogRepo
+
+	// a log that isn't quarantined isn't skipped
+	err := svc.quarantineLog(
+		context.Background(),
+		big.NewInt(1),
+		unpackFailure{eventName: relayer.EventNameMessageSent, raw: types.Log{}},
+		errors.New("abi: cannot marshal in to go type: length insufficient"),
+	)
+	assert.NotNil(t, err)
+}
@@ -3,9 +3,10 @@ package indexer
 import (
 	"context"
 	"fmt"
+	"math/big"
 
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
@@ -17,18 +18,67 @@ var (
 
 // FilterThenSubscribe gets the most recent block height that has been indexed, and works it's way
 // up to the latest block. As it goes, it tries to process messages.
-// When it catches up, it then starts to Subscribe to latest events as they come in.
+// When it catches up, it then starts to Subscribe to latest events as they come in, or
+// polls the head for them in relayer.PollWatchMode.
 func (svc *Service) FilterThenSubscribe(
 	ctx context.Context,
 	mode relayer.Mode,
 	watchMode relayer.WatchMode,
 ) error {
+	// the indexer reads back events it has just written, so it can't use a lagging replica.
+	ctx = relayer.WithPrimaryReads(ctx)
+
 	chainID, err := svc.ethClient.ChainID(ctx)
 	if err != nil {
 		return errors.Wrap(err, "svc.ethClient.ChainID()")
 	}
 
-	go scanBlocks(ctx, svc.ethClient, chainID)
+	// polling counts scanned blocks itself, and is for RPCs that can't subscribe to new heads.
+	if watchMode != relayer.PollWatchMode {
+		go scanBlocks(ctx, svc.ethClient, chainID)
+	}
+
+	// resolve transactions a previous run sent but didn't record the outcome of,
+	// before picking up new work that could send them again. A standby leaves them to the
+	// leader, whose transactions they could be, and resolves them when it's promoted.
+	if !svc.Standby() {
+		if err := svc.processor.ReconcilePendingSent(ctx, chainID); err != nil {
+			return errors.Wrap(err, "svc.processor.ReconcilePendingSent")
+		}
+	}
+
+	// each sender's messages are queued in order before any are retried, go stale or are indexed
+	if svc.orderedDelivery {
+		svc.requeueMessagesOnce.Do(func() {
+			err = svc.requeueMessages(ctx, chainID)
+		})
+
+		if err != nil {
+			return errors.Wrap(err, "svc.requeueMessages")
+		}
+	}
+
+	if svc.maxMessageAge > 0 {
+		svc.staleMessagesOnce.Do(func() {
+			go svc.markStaleMessagesEvery(ctx, chainID, staleMessagesInterval)
+		})
+	}
+
+	// even with no confirmations required, messages left unconfirmed by a run that did need them are
+	// still resolved
+	svc.confirmProcessedOnce.Do(func() {
+		go svc.confirmProcessedEvery(ctx, chainID, confirmProcessedInterval)
+	})
+
+	if svc.destReorgWindow > 0 {
+		svc.unfinalizeReorgedOutOnce.Do(func() {
+			go svc.unfinalizeReorgedOutEvery(ctx, chainID, unfinalizeReorgedOutInterval)
+		})
+	}
+
+	svc.retryMessagesOnce.Do(func() {
+		go svc.retryMessagesEvery(ctx, chainID, retryMessagesInterval)
+	})
 
 	// if subscribing to new events, skip filtering and subscribe
 	if watchMode == relayer.SubscribeWatchMode {
@@ -44,8 +94,15 @@ func (svc *Service) FilterThenSubscribe(
 		return errors.Wrap(err, "svc.ethClient.HeaderByNumber")
 	}
 
+	svc.syncProgress.setHead(header.Number.Uint64())
+
 	if svc.processingBlockHeight == header.Number.Uint64() {
+		if watchMode == relayer.PollWatchMode {
+			return svc.poll(ctx, chainID)
+		}
+
 		log.Infof("chain ID %v caught up, subscribing to new incoming events", chainID.Uint64())
+
 		return svc.subscribe(ctx, chainID)
 	}
 
@@ -64,81 +121,11 @@ func (svc *Service) FilterThenSubscribe(
 			end = header.Number.Uint64()
 		}
 
-		// filter exclusive of the end block.
-		// we use "end" as the next starting point of the batch, and
-		// process up to end - 1 for this batch.
-		filterEnd := end - 1
-
-		fmt.Printf("block batch from %v to %v", i, filterEnd)
-		fmt.Println()
-
-		filterOpts := &bind.FilterOpts{
-			Start:   svc.processingBlockHeight,
-			End:     &filterEnd,
-			Context: ctx,
-		}
-
-		messageStatusChangedEvents, err := svc.bridge.FilterMessageStatusChanged(filterOpts, nil)
-		if err != nil {
-			return errors.Wrap(err, "bridge.FilterMessageStatusChanged")
-		}
-
-		// we dont need to do anything with msgStatus events except save them to the DB.
-		// we dont need to process them. they are for exposing via the API.
-
-		err = svc.saveMessageStatusChangedEvents(ctx, chainID, messageStatusChangedEvents)
-		if err != nil {
-			return errors.Wrap(err, "bridge.saveMessageStatusChangedEvents")
-		}
-
-		messageSentEvents, err := svc.bridge.FilterMessageSent(filterOpts, nil)
-		if err != nil {
-			return errors.Wrap(err, "bridge.FilterMessageSent")
-		}
-
-		if !messageSentEvents.Next() || messageSentEvents.Event == nil {
-			// use "end" not "filterEnd" here, because it will be used as the start
-			// of the next batch.
-			if err := svc.handleNoEventsInBatch(ctx, chainID, int64(end)); err != nil {
-				return errors.Wrap(err, "svc.handleNoEventsInBatch")
-			}
-
-			continue
+		if err := svc.indexBatch(ctx, chainID, end); err != nil {
+			return errors.Wrap(err, "svc.indexBatch")
 		}
 
-		group, groupCtx := errgroup.WithContext(ctx)
-
-		group.SetLimit(svc.numGoroutines)
-
-		for {
-			event := messageSentEvents.Event
-
-			group.Go(func() error {
-				err := svc.handleEvent(groupCtx, chainID, event)
-				if err != nil {
-					relayer.ErrorEvents.Inc()
-					// log error but always return nil to keep other goroutines active
-					log.Error(err.Error())
-				}
-
-				return nil
-			})
-
-			// if there are no more events
-			if !messageSentEvents.Next() {
-				// wait for the last of the goroutines to finish
-				if err := group.Wait(); err != nil {
-					return errors.Wrap(err, "group.Wait")
-				}
-				// handle no events remaining, saving the processing block and restarting the for
-				// loop
-				if err := svc.handleNoEventsInBatch(ctx, chainID, int64(end)); err != nil {
-					return errors.Wrap(err, "svc.handleNoEventsInBatch")
-				}
-
-				break
-			}
-		}
+		svc.trackSyncProgress(ctx, chainID)
 	}
 
 	log.Infof(
@@ -160,5 +147,82 @@ func (svc *Service) FilterThenSubscribe(
 		return nil
 	}
 
+	if watchMode == relayer.PollWatchMode {
+		return svc.poll(ctx, chainID)
+	}
+
 	return svc.subscribe(ctx, chainID)
 }
+
+// indexBatch handles the events from svc.processingBlockHeight up to, but excluding, end,
+// then moves svc.processingBlockHeight on to end.
+func (svc *Service) indexBatch(ctx context.Context, chainID *big.Int, end uint64) error {
+	// filter exclusive of the end block.
+	// we use "end" as the next starting point of the batch, and
+	// process up to end - 1 for this batch.
+	filterEnd := end - 1
+
+	fmt.Printf("block batch from %v to %v", svc.processingBlockHeight, filterEnd)
+	fmt.Println()
+
+	bridgeEvents, err := svc.filterBridgeEvents(ctx, chainID, svc.processingBlockHeight, filterEnd)
+	if err != nil {
+		return errors.Wrap(err, "svc.filterBridgeEvents")
+	}
+
+	// we dont need to do anything with msgStatus events except save them to the DB.
+	// we dont need to process them. they are for exposing via the API.
+
+	err = svc.saveMessageStatusChangedEvents(ctx, chainID, bridgeEvents.statusChanged)
+	if err != nil {
+		return errors.Wrap(err, "bridge.saveMessageStatusChangedEvents")
+	}
+
+	events := bridgeEvents.sent
+
+	if len(events) > 0 && svc.writesEventBatches(events) {
+		if err := svc.writeEventBatch(ctx, chainID, events, end); err != nil {
+			return errors.Wrap(err, "svc.writeEventBatch")
+		}
+
+		return nil
+	}
+
+	if err := svc.handleEvents(ctx, chainID, events); err != nil {
+		return errors.Wrap(err, "svc.handleEvents")
+	}
+
+	// use "end" not "filterEnd" here, because it will be used as the start
+	// of the next batch.
+	if err := svc.handleNoEventsInBatch(ctx, chainID, int64(end)); err != nil {
+		return errors.Wrap(err, "svc.handleNoEventsInBatch")
+	}
+
+	return nil
+}
+
+// handleEvents handles a batch's MessageSent events concurrently, each saved and processed on its own
+func (svc *Service) handleEvents(ctx context.Context, chainID *big.Int, events []*bridge.BridgeMessageSent) error {
+	group, groupCtx := errgroup.WithContext(ctx)
+
+	group.SetLimit(svc.numGoroutines)
+
+	for _, event := range events {
+		// taken in the order events were emitted, so ordered delivery keeps to it
+		handle := svc.eventHandler(chainID, event)
+
+		group.Go(func() error {
+			err := handle(groupCtx)
+			if err != nil {
+				relayer.ErrorEvents.Inc()
+				// log error but always return nil to keep other goroutines active
+				log.Error(err.Error())
+			}
+
+			return nil
+		})
+	}
+
+	// wait for the last of the goroutines to finish
+	return group.Wait()
+}
@@ -7,9 +7,9 @@ import (
 
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/encoding"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
-	log "github.com/sirupsen/logrus"
 )
 
 // handleEvent handles an individual MessageSent event
@@ -18,38 +18,156 @@ func (svc *Service) handleEvent(
 	chainID *big.Int,
 	event *bridge.BridgeMessageSent,
 ) error {
-	raw := event.Raw
+	ctx = relayer.WithMessageLogger(ctx, common.Hash(event.MsgHash), chainID, event.Message.DestChainId)
 
-	log.Infof("event found for msgHash: %v, txHash: %v", common.Hash(event.MsgHash).Hex(), event.Raw.TxHash.Hex())
+	if svc.skipEvent(ctx, event) {
+		return nil
+	}
+
+	e, eventStatus, err := svc.saveEvent(ctx, chainID, event)
+	if err != nil {
+		return errors.Wrap(err, "svc.saveEvent")
+	}
+
+	if !canProcessMessage(ctx, eventStatus, event.Message.Owner, svc.relayerAddr) {
+		relayer.Logger(ctx).Warnf("cant process, eventStatus: %v", eventStatus)
+		return nil
+	}
+
+	// process the message
+	if err := svc.processMessage(ctx, event, e); err != nil {
+		return errors.Wrap(err, "svc.processMessage")
+	}
+
+	return nil
+}
+
+// skipEvent reports whether a MessageSent event should be neither saved nor processed, including
+// when its message is for a destination chain this instance doesn't index.
+func (svc *Service) skipEvent(ctx context.Context, event *bridge.BridgeMessageSent) bool {
+	relayer.Logger(ctx).Infof("event found in txHash: %v", event.Raw.TxHash.Hex())
 
 	// handle chain re-org by checking Removed property, no need to
 	// return error, just continue and do not process.
-	if raw.Removed {
-		log.Warnf("event msgHash was removed: %v", common.Hash(event.MsgHash).Hex())
-		return nil
+	if event.Raw.Removed {
+		relayer.Logger(ctx).Warn("event was removed")
+		return true
 	}
 
 	if event.MsgHash == relayer.ZeroHash {
-		log.Warn("Zero msgHash found. This is unexpected. Returning early")
-		return nil
+		relayer.Logger(ctx).Warn("Zero msgHash found. This is unexpected. Returning early")
+		return true
+	}
+
+	// the bridge indexes the event by its message's hash, so a mismatch means the bindings no
+	// longer decode the message the way the bridge encodes it
+	if hash, err := encoding.HashMessage(event.Message); err != nil || hash != common.Hash(event.MsgHash) {
+		relayer.Logger(ctx).Warnf("message doesn't hash to its msgHash, got %v, err: %v", hash.Hex(), err)
+	}
+
+	if !svc.indexesDestChain(event.Message.DestChainId) {
+		relayer.Logger(ctx).Debugf("not indexing, destination chain %v isn't indexed", event.Message.DestChainId)
+		return true
 	}
 
+	return false
+}
+
+// saveEvent saves a MessageSent event with the status it has on the destination chain,
+// without processing it.
+func (svc *Service) saveEvent(
+	ctx context.Context,
+	chainID *big.Int,
+	event *bridge.BridgeMessageSent,
+) (*relayer.Event, relayer.EventStatus, error) {
+	opts, err := svc.eventOpts(ctx, chainID, event)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "svc.eventOpts")
+	}
+
+	e, err := svc.eventRepo.Save(ctx, opts)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "svc.eventRepo.Save")
+	}
+
+	countIndexed(opts)
+	svc.publishEvent(opts)
+
+	return e, opts.Status, nil
+}
+
+// eventOpts is how a MessageSent event is saved, with the status it has on the destination chain
+func (svc *Service) eventOpts(
+	ctx context.Context,
+	chainID *big.Int,
+	event *bridge.BridgeMessageSent,
+) (relayer.SaveEventOpts, error) {
 	eventStatus, err := svc.eventStatusFromMsgHash(ctx, event.Message.GasLimit, event.MsgHash)
 	if err != nil {
-		return errors.Wrap(err, "svc.eventStatusFromMsgHash")
+		return relayer.SaveEventOpts{}, errors.Wrap(err, "svc.eventStatusFromMsgHash")
+	}
+
+	original, err := svc.originalEvent(ctx, event)
+	if err != nil {
+		return relayer.SaveEventOpts{}, errors.Wrap(err, "svc.originalEvent")
+	}
+
+	var duplicateOfEventID *int
+
+	if original != nil {
+		relayer.Logger(ctx).Warnf(
+			"txHash: %v log: %v is a duplicate of event %v, linking it without processing it",
+			event.Raw.TxHash.Hex(),
+			event.Raw.Index,
+			original.ID,
+		)
+
+		relayer.DuplicateMessageSentEvents.Inc()
+
+		eventStatus = relayer.EventStatusDuplicate
+		duplicateOfEventID = &original.ID
+	}
+
+	if eventStatus == relayer.EventStatusNew {
+		hold, err := svc.processor.ShouldHold(event)
+		if err != nil {
+			return relayer.SaveEventOpts{}, errors.Wrap(err, "svc.processor.ShouldHold")
+		}
+
+		if hold {
+			relayer.Logger(ctx).Warnf("owner: %v held for review, amount exceeds threshold", event.Message.Owner.Hex())
+
+			relayer.MessagesHeld.Inc()
+
+			eventStatus = relayer.EventStatusHeld
+		}
 	}
 
 	marshaled, err := json.Marshal(event)
 	if err != nil {
-		return errors.Wrap(err, "json.Marshal(event)")
+		return relayer.SaveEventOpts{}, errors.Wrap(err, "json.Marshal(event)")
+	}
+
+	// how old the message is, however long after it was sent it's indexed
+	header, err := svc.ethClient.HeaderByNumber(ctx, new(big.Int).SetUint64(event.Raw.BlockNumber))
+	if err != nil {
+		return relayer.SaveEventOpts{}, errors.Wrap(err, "svc.ethClient.HeaderByNumber")
 	}
 
 	eventType, canonicalToken, amount, err := relayer.DecodeMessageSentData(event)
 	if err != nil {
-		return errors.Wrap(err, "eventTypeAmountAndCanonicalTokenFromEvent(event)")
+		return relayer.SaveEventOpts{}, errors.Wrap(err, "eventTypeAmountAndCanonicalTokenFromEvent(event)")
+	}
+
+	var messageCallTo, messageCallSelector string
+
+	if eventType == relayer.EventTypeMessageCall {
+		call := relayer.DecodeMessageCall(event.Message)
+		messageCallTo = call.To.Hex()
+		messageCallSelector = call.Selector()
 	}
 
-	e, err := svc.eventRepo.Save(ctx, relayer.SaveEventOpts{
+	return relayer.SaveEventOpts{
 		Name:                   relayer.EventNameMessageSent,
 		Data:                   string(marshaled),
 		ChainID:                chainID,
@@ -63,22 +181,17 @@ func (svc *Service) handleEvent(
 		MsgHash:                common.Hash(event.MsgHash).Hex(),
 		MessageOwner:           event.Message.Owner.Hex(),
 		Event:                  relayer.EventNameMessageSent,
-	})
-	if err != nil {
-		return errors.Wrap(err, "svc.eventRepo.Save")
-	}
-
-	if !canProcessMessage(ctx, eventStatus, event.Message.Owner, svc.relayerAddr) {
-		log.Warnf("cant process msgHash: %v, eventStatus: %v", common.Hash(event.MsgHash).Hex(), eventStatus)
-		return nil
-	}
-
-	// process the message
-	if err := svc.processor.ProcessMessage(ctx, event, e); err != nil {
-		return errors.Wrap(err, "svc.processMessage")
-	}
-
-	return nil
+		MessageCallTo:          messageCallTo,
+		MessageCallSelector:    messageCallSelector,
+		DuplicateOfEventID:     duplicateOfEventID,
+		BlockNumber:            event.Raw.BlockNumber,
+		TxHash:                 event.Raw.TxHash.Hex(),
+		LogIndex:               event.Raw.Index,
+		MessageSender:          event.Message.Sender.Hex(),
+		DestChainID:            event.Message.DestChainId,
+		MessageTo:              event.Message.To.Hex(),
+		MessageSentTimestamp:   header.Time,
+	}, nil
 }
 
 func canProcessMessage(
@@ -90,7 +203,7 @@ func canProcessMessage(
 	// we can not process, exit early
 	if eventStatus == relayer.EventStatusNewOnlyOwner {
 		if messageOwner != relayerAddress {
-			log.Infof("gasLimit == 0 and owner is not the current relayer key, can not process. continuing loop")
+			relayer.Logger(ctx).Info("gasLimit == 0 and owner is not the current relayer key, can not process. continuing loop")
 			return false
 		}
 
@@ -2,10 +2,13 @@ package indexer
 
 import (
 	"context"
+	"encoding/json"
 	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
@@ -51,6 +54,13 @@ func Test_canProcessMessage(t *testing.T) {
 			relayerAddr,
 			false,
 		},
+		{
+			"cantProcess, eventStatusHeld",
+			relayer.EventStatusHeld,
+			relayerAddr,
+			relayerAddr,
+			false,
+		},
 		{
 			"cantProcess, eventStatusFailed",
 			relayer.EventStatusFailed,
@@ -147,3 +157,45 @@ func Test_eventStatusFromMsgHash(t *testing.T) {
 		})
 	}
 }
+
+func Test_eventOpts(t *testing.T) {
+	svc, _ := newTestService()
+
+	sender := common.HexToAddress("0x71C7656EC7ab88b098defB751B7401B5f6d8976F")
+	to := common.HexToAddress("0x165CD37b4C644C2921454429E7F9358d18A45e14")
+
+	event := &bridge.BridgeMessageSent{
+		MsgHash: mock.SuccessMsgHash,
+		Message: bridge.IBridgeMessage{
+			Id:            big.NewInt(0),
+			Sender:        sender,
+			To:            to,
+			SrcChainId:    big.NewInt(1),
+			DestChainId:   big.NewInt(2),
+			DepositValue:  big.NewInt(0),
+			CallValue:     big.NewInt(0),
+			ProcessingFee: big.NewInt(0),
+			GasLimit:      big.NewInt(1),
+		},
+	}
+
+	opts, err := svc.eventOpts(context.Background(), big.NewInt(1), event)
+	assert.Nil(t, err)
+	assert.Equal(t, sender.Hex(), opts.MessageSender)
+	assert.Equal(t, big.NewInt(2), opts.DestChainID)
+	assert.Equal(t, to.Hex(), opts.MessageTo)
+
+	// the migrations adding the columns backfilled them from where they are in the data
+	var data struct {
+		Message struct {
+			Sender      string
+			DestChainId int64
+			To          string
+		}
+	}
+
+	assert.Nil(t, json.Unmarshal([]byte(opts.Data), &data))
+	assert.Equal(t, strings.ToLower(sender.Hex()), data.Message.Sender)
+	assert.Equal(t, int64(2), data.Message.DestChainId)
+	assert.Equal(t, strings.ToLower(to.Hex()), data.Message.To)
+}
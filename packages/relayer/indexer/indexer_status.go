@@ -0,0 +1,48 @@
+package indexer
+
+import (
+	"context"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/pkg/errors"
+)
+
+// IndexerStatus returns the latest block the service has processed, the chain's head,
+// and how far the destination chain has synced it.
+func (svc *Service) IndexerStatus(ctx context.Context) (*relayer.IndexerStatus, error) {
+	chainID, err := svc.ethClient.ChainID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "svc.ethClient.ChainID")
+	}
+
+	latestProcessedBlock, err := svc.blockRepo.GetLatestBlockProcessedForEvent(
+		relayer.EventNameMessageSent,
+		chainID,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "svc.blockRepo.GetLatestBlockProcessedForEvent")
+	}
+
+	head, err := svc.ethClient.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "svc.ethClient.HeaderByNumber")
+	}
+
+	destChainID, err := svc.destEthClient.ChainID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "svc.destEthClient.ChainID")
+	}
+
+	latestSyncedHeight, err := svc.crossChainSyncRepo.LatestSyncedHeight(ctx, destChainID)
+	if err != nil {
+		return nil, errors.Wrap(err, "svc.crossChainSyncRepo.LatestSyncedHeight")
+	}
+
+	return &relayer.IndexerStatus{
+		ChainID:              chainID.Int64(),
+		LatestProcessedBlock: latestProcessedBlock,
+		HeadBlock:            head.Number.Uint64(),
+		LatestSyncedHeight:   latestSyncedHeight,
+		ProcessingPaused:     svc.processor.Paused(),
+	}, nil
+}
@@ -0,0 +1,67 @@
+package indexer
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_IndexerStatus(t *testing.T) {
+	svc, _ := newTestService()
+
+	assert.Nil(t, svc.crossChainSyncRepo.Save(context.Background(), relayer.SaveCrossChainSyncOpts{
+		ChainID:    mock.MockChainID,
+		SrcHeight:  8,
+		BlockHash:  common.Hash{0x1},
+		SignalRoot: common.Hash{0x2},
+		SyncedAt:   time.Now(),
+	}))
+
+	status, err := svc.IndexerStatus(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, &relayer.IndexerStatus{
+		ChainID:              mock.MockChainID.Int64(),
+		LatestProcessedBlock: mock.LatestBlock,
+		HeadBlock:            mock.LatestBlockNumber.Uint64(),
+		LatestSyncedHeight:   8,
+		ProcessingPaused:     false,
+	}, status)
+
+	svc.PauseProcessing()
+
+	status, err = svc.IndexerStatus(context.Background())
+	assert.Nil(t, err)
+	assert.True(t, status.ProcessingPaused)
+
+	svc.ResumeProcessing()
+
+	status, err = svc.IndexerStatus(context.Background())
+	assert.Nil(t, err)
+	assert.False(t, status.ProcessingPaused)
+}
+
+func Test_IndexerStatus_noCheckpoint(t *testing.T) {
+	svc, _ := newTestService()
+	svc.ethClient = &chainIDEthClient{chainID: mock.NoCheckpointChainID}
+
+	status, err := svc.IndexerStatus(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), status.LatestProcessedBlock.Height)
+	assert.Equal(t, uint64(0), status.LatestSyncedHeight)
+}
+
+// chainIDEthClient is a mock.EthClient on another chain
+type chainIDEthClient struct {
+	mock.EthClient
+	chainID *big.Int
+}
+
+func (c *chainIDEthClient) ChainID(ctx context.Context) (*big.Int, error) {
+	return c.chainID, nil
+}
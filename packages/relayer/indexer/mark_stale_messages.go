@@ -0,0 +1,56 @@
+package indexer
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	// staleMessagesInterval is how often messages are checked for going stale
+	staleMessagesInterval = 10 * time.Minute
+)
+
+// markStaleMessagesEvery marks chainID's messages that are still new after svc.maxMessageAge as
+// stale, now and then every interval, until ctx is done.
+func (svc *Service) markStaleMessagesEvery(ctx context.Context, chainID *big.Int, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := svc.markStaleMessages(ctx, chainID); err != nil {
+			log.Errorf("chain ID %v svc.markStaleMessages: %v", chainID, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// markStaleMessages marks chainID's messages sent more than svc.maxMessageAge ago that are still
+// new as stale, so they stop being processed. Many going stale is worth looking into, it's a sign
+// messages can't be processed at all, i.e. the source chain's state they need was pruned. Senders
+// with messages waiting their turn with ordered delivery on are left alone.
+func (svc *Service) markStaleMessages(ctx context.Context, chainID *big.Int) error {
+	marked, err := svc.eventRepo.MarkStale(ctx, chainID, time.Now().Add(-svc.maxMessageAge), svc.queuedSenders())
+	if err != nil {
+		return errors.Wrap(err, "svc.eventRepo.MarkStale")
+	}
+
+	if marked == 0 {
+		return nil
+	}
+
+	relayer.StaleMessages.WithLabelValues(chainID.String()).Add(float64(marked))
+
+	log.Warnf("chain ID %v marked %v messages still new after %v stale", chainID, marked, svc.maxMessageAge)
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_markStaleMessages(t *testing.T) {
+	svc, _ := newTestService()
+	svc.maxMessageAge = 24 * time.Hour
+	svc.orderedDelivery = true
+
+	// a sender whose messages are waiting their turn
+	queued := common.HexToAddress("0x1")
+	svc.enqueue(senderKey{sender: queued, destChainID: mock.MockChainID.String()})
+
+	eventRepo := mock.NewEventRepository()
+	svc.eventRepo = eventRepo
+
+	events := make([]*relayer.Event, 0)
+
+	for i, opts := range []struct {
+		status relayer.EventStatus
+		age    time.Duration
+		sender common.Address
+	}{
+		{relayer.EventStatusNew, 48 * time.Hour, common.Address{}},
+		{relayer.EventStatusNew, time.Hour, common.Address{}},
+		{relayer.EventStatusRetriable, 48 * time.Hour, common.Address{}},
+		{relayer.EventStatusNew, 48 * time.Hour, queued},
+	} {
+		msgHash := fmt.Sprintf("0x%d", i)
+
+		// indexed just now, however long ago it was sent
+		_, err := eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+			Name:                 relayer.EventNameMessageSent,
+			ChainID:              mock.MockChainID,
+			Status:               opts.status,
+			MsgHash:              msgHash,
+			MessageSender:        opts.sender.Hex(),
+			DestChainID:          mock.MockChainID,
+			MessageSentTimestamp: uint64(time.Now().Add(-opts.age).Unix()),
+		})
+		assert.Nil(t, err)
+
+		e, err := eventRepo.FirstByMsgHash(context.Background(), msgHash)
+		assert.Nil(t, err)
+
+		events = append(events, e)
+	}
+
+	stale := relayer.StaleMessages.WithLabelValues(mock.MockChainID.String())
+	before := testutil.ToFloat64(stale)
+
+	assert.Nil(t, svc.markStaleMessages(context.Background(), mock.MockChainID))
+
+	// only the message still new after the max age goes stale, unless it's waiting its turn
+	assert.Equal(t, relayer.EventStatusStale, events[0].Status)
+	assert.Equal(t, relayer.EventStatusNew, events[1].Status)
+	assert.Equal(t, relayer.EventStatusRetriable, events[2].Status)
+	assert.Equal(t, relayer.EventStatusNew, events[3].Status)
+	assert.Equal(t, before+1, testutil.ToFloat64(stale))
+
+	// and it's no longer processable
+	now := time.Now()
+
+	for _, e := range events {
+		e.NextRetryAt = &now
+	}
+
+	processable, err := eventRepo.FindProcessable(context.Background(), mock.MockChainID, 0, now, 10)
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []*relayer.Event{events[1], events[2], events[3]}, processable)
+}
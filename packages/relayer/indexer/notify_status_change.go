@@ -0,0 +1,58 @@
+package indexer
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/ethereum/go-ethereum/common"
+	log "github.com/sirupsen/logrus"
+)
+
+// notifyStatusChange tells the status change notifier, if there is one, about a
+// MessageStatusChanged event, and publishes it. Notifying retries with backoff, so it runs in the
+// background and failures are only logged, a slow webhook must never hold up indexing.
+func (svc *Service) notifyStatusChange(
+	ctx context.Context,
+	chainID *big.Int,
+	event *bridge.BridgeMessageStatusChanged,
+	messageOwner string,
+) {
+	if svc.statusChangeNotifier == nil && svc.eventPublisher == nil {
+		return
+	}
+
+	timestamp := time.Now().Unix()
+
+	header, err := svc.ethClient.HeaderByNumber(ctx, new(big.Int).SetUint64(event.Raw.BlockNumber))
+	if err != nil {
+		log.Warnf("msgHash: %v, svc.ethClient.HeaderByNumber: %v, using current time", common.Hash(event.MsgHash).Hex(), err)
+	} else {
+		timestamp = int64(header.Time)
+	}
+
+	change := relayer.MessageStatusChange{
+		MsgHash:      common.Hash(event.MsgHash).Hex(),
+		Status:       relayer.EventStatus(event.Status),
+		TxHash:       event.Raw.TxHash.Hex(),
+		ChainID:      chainID.Int64(),
+		MessageOwner: messageOwner,
+		Timestamp:    timestamp,
+	}
+
+	if svc.eventPublisher != nil {
+		svc.eventPublisher.PublishStatusChange(change)
+	}
+
+	if svc.statusChangeNotifier == nil {
+		return
+	}
+
+	go func() {
+		if err := svc.statusChangeNotifier.Notify(context.Background(), change); err != nil {
+			log.Errorf("msgHash: %v, svc.statusChangeNotifier.Notify: %v", change.MsgHash, err)
+		}
+	}()
+}
@@ -0,0 +1,68 @@
+package indexer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_notifyStatusChange(t *testing.T) {
+	svc, _ := newTestService()
+
+	notifier := &mock.StatusChangeNotifier{}
+	svc.statusChangeNotifier = notifier
+
+	svc.notifyStatusChange(context.Background(), mock.MockChainID, &bridge.BridgeMessageStatusChanged{
+		MsgHash: [32]byte{0x1},
+		Status:  uint8(relayer.EventStatusDone),
+		Raw: types.Log{
+			TxHash:      common.HexToHash("0x2"),
+			BlockNumber: 1,
+		},
+	}, dummyAddress)
+
+	assert.Eventually(t, func() bool {
+		return notifier.Len() == 1
+	}, time.Second, 10*time.Millisecond)
+
+	change := notifier.Changes[0]
+	assert.Equal(t, relayer.EventStatusDone, change.Status)
+	assert.Equal(t, common.HexToHash("0x2").Hex(), change.TxHash)
+	assert.Equal(t, dummyAddress, change.MessageOwner)
+	assert.Equal(t, mock.MockChainID.Int64(), change.ChainID)
+}
+
+func Test_notifyStatusChange_eventPublisher(t *testing.T) {
+	svc, _ := newTestService()
+
+	publisher := &mock.EventPublisher{}
+	svc.eventPublisher = publisher
+
+	svc.notifyStatusChange(context.Background(), mock.MockChainID, &bridge.BridgeMessageStatusChanged{
+		MsgHash: [32]byte{0x1},
+		Status:  uint8(relayer.EventStatusRetriable),
+		Raw: types.Log{
+			TxHash:      common.HexToHash("0x2"),
+			BlockNumber: 1,
+		},
+	}, dummyAddress)
+
+	// published straight away, without a notifier
+	assert.Equal(t, 1, len(publisher.StatusChanges))
+	assert.Equal(t, common.Hash([32]byte{0x1}).Hex(), publisher.StatusChanges[0].MsgHash)
+	assert.Equal(t, relayer.EventStatusRetriable, publisher.StatusChanges[0].Status)
+}
+
+func Test_notifyStatusChange_noNotifier(t *testing.T) {
+	svc, _ := newTestService()
+
+	// does nothing, and doesn't panic
+	svc.notifyStatusChange(context.Background(), mock.MockChainID, &bridge.BridgeMessageStatusChanged{}, dummyAddress)
+}
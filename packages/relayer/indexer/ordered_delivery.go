@@ -0,0 +1,469 @@
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+)
+
+var (
+	defaultBlockedSenderPollInterval = 30 * time.Second
+
+	// queuedStatuses are the statuses of the messages a sender's later messages wait on,
+	// requeued at startup
+	queuedStatuses = []relayer.EventStatus{
+		relayer.EventStatusNew,
+		relayer.EventStatusNewOnlyOwner,
+		relayer.EventStatusHeld,
+		relayer.EventStatusRetriable,
+		relayer.EventStatusPendingSent,
+	}
+)
+
+// senderKey identifies the messages delivered in order with ordered delivery on: those sent
+// by one sender to one destination chain.
+type senderKey struct {
+	sender      common.Address
+	destChainID string
+}
+
+// queuedMessage is a message indexed while its sender was blocked, left to be processed
+// once the messages sent before it are done.
+type queuedMessage struct {
+	event  *bridge.BridgeMessageSent
+	e      *relayer.Event
+	status relayer.EventStatus
+}
+
+// senderQueue handles one sender's messages one at a time, in the order they were sent.
+type senderQueue struct {
+	// tail is closed once the last message dispatched to the queue has been handled,
+	// guarded by svc.senderQueuesMu
+	tail chan struct{}
+
+	// mu is held while one of the sender's messages is handled
+	mu sync.Mutex
+	// blockedBy is the message the sender's later messages wait on to be done, nil if none
+	blockedBy *[32]byte
+	backlog   []queuedMessage
+	// polling is whether pollBlockedSender is waiting on blockedBy
+	polling bool
+}
+
+// eventHandler returns the function to handle a MessageSent event with. With ordered delivery
+// on, events have to be passed to it in the order they were emitted, which is the order each
+// sender's messages are then handled in, however the returned functions are scheduled.
+// Unrelated senders' messages are still handled concurrently.
+func (svc *Service) eventHandler(chainID *big.Int, event *bridge.BridgeMessageSent) func(ctx context.Context) error {
+	if !svc.orderedDelivery {
+		return func(ctx context.Context) error {
+			return svc.handleEvent(ctx, chainID, event)
+		}
+	}
+
+	key := senderKey{sender: event.Message.Sender, destChainID: event.Message.DestChainId.String()}
+
+	q, prev, done := svc.enqueue(key)
+
+	return func(ctx context.Context) error {
+		defer svc.dequeue(key, q, done)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-prev:
+		}
+
+		return svc.handleOrderedEvent(ctx, chainID, key, q, event)
+	}
+}
+
+// enqueue adds a message to key's queue, returning the queue, a channel closed once the message
+// sent before it has been handled, and the channel to close once it has been.
+func (svc *Service) enqueue(key senderKey) (*senderQueue, <-chan struct{}, chan struct{}) {
+	svc.senderQueuesMu.Lock()
+	defer svc.senderQueuesMu.Unlock()
+
+	if svc.senderQueues == nil {
+		svc.senderQueues = make(map[senderKey]*senderQueue)
+	}
+
+	q, ok := svc.senderQueues[key]
+	if !ok {
+		q = &senderQueue{}
+		svc.senderQueues[key] = q
+	}
+
+	prev := q.tail
+	if prev == nil {
+		closed := make(chan struct{})
+		close(closed)
+		prev = closed
+	}
+
+	done := make(chan struct{})
+	q.tail = done
+
+	return q, prev, done
+}
+
+// dequeue marks a message handled, and forgets key's queue if nothing is left waiting on it.
+func (svc *Service) dequeue(key senderKey, q *senderQueue, done chan struct{}) {
+	close(done)
+
+	svc.forgetIfIdle(key, q)
+}
+
+// forgetIfIdle forgets key's queue if no messages are waiting to be handled by it, and it isn't
+// blocked, so senders that stop sending don't accumulate.
+func (svc *Service) forgetIfIdle(key senderKey, q *senderQueue) {
+	svc.senderQueuesMu.Lock()
+	defer svc.senderQueuesMu.Unlock()
+
+	select {
+	case <-q.tail:
+	default:
+		return
+	}
+
+	// held by the poller, which forgets the queue itself once it's done
+	if !q.mu.TryLock() {
+		return
+	}
+	defer q.mu.Unlock()
+
+	if q.blockedBy == nil && !q.polling && svc.senderQueues[key] == q {
+		delete(svc.senderQueues, key)
+	}
+}
+
+// messageHandler returns the function to process a message already indexed as e with, e.g. when
+// it's retried, reprocessed or drained after pausing. With ordered delivery on, it's processed in
+// its turn in its sender's queue, like eventHandler's.
+func (svc *Service) messageHandler(e *relayer.Event, event *bridge.BridgeMessageSent) func(ctx context.Context) error {
+	if !svc.orderedDelivery {
+		return func(ctx context.Context) error {
+			return svc.processMessage(ctx, event, e)
+		}
+	}
+
+	key := senderKey{sender: event.Message.Sender, destChainID: event.Message.DestChainId.String()}
+
+	q, prev, done := svc.enqueue(key)
+
+	return func(ctx context.Context) error {
+		defer svc.dequeue(key, q, done)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-prev:
+		}
+
+		q.mu.Lock()
+		defer q.mu.Unlock()
+
+		return svc.handleQueued(ctx, key, q, queuedMessage{event: event, e: e, status: e.Status})
+	}
+}
+
+// handleOrderedEvent saves event, and processes it in its turn like handleQueued.
+func (svc *Service) handleOrderedEvent(
+	ctx context.Context,
+	chainID *big.Int,
+	key senderKey,
+	q *senderQueue,
+	event *bridge.BridgeMessageSent,
+) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	ctx = relayer.WithMessageLogger(ctx, common.Hash(event.MsgHash), chainID, event.Message.DestChainId)
+
+	if svc.skipEvent(ctx, event) {
+		return nil
+	}
+
+	e, eventStatus, err := svc.saveEvent(ctx, chainID, event)
+	if err != nil {
+		return errors.Wrap(err, "svc.saveEvent")
+	}
+
+	return svc.handleQueued(ctx, key, q, queuedMessage{event: event, e: e, status: eventStatus})
+}
+
+// handleQueued processes m straight away if none of the messages its sender sent before it are
+// waiting to be done, otherwise it's left new until they are. m may be queued already, when it's
+// retried, drained after pausing or indexed again. q.mu must be held.
+func (svc *Service) handleQueued(ctx context.Context, key senderKey, q *senderQueue, m queuedMessage) error {
+	switch {
+	case q.blockedBy != nil && *q.blockedBy == m.event.MsgHash:
+		// the sender's later messages are waiting on it, so it's its turn
+		q.blockedBy = nil
+
+		if err := svc.processQueued(ctx, key, q, m); err != nil {
+			return err
+		}
+
+		if err := svc.drainBacklog(ctx, key, q); err != nil {
+			return errors.Wrap(err, "svc.drainBacklog")
+		}
+
+		return nil
+	case q.backlogged(m.event.MsgHash):
+		return nil
+	}
+
+	if err := svc.drainBacklog(ctx, key, q); err != nil {
+		return errors.Wrap(err, "svc.drainBacklog")
+	}
+
+	if q.blockedBy == nil {
+		return svc.processQueued(ctx, key, q, m)
+	}
+
+	// even one we can't process waits its turn, since the sender's later messages wait on it
+	if m.status != relayer.EventStatusDone {
+		relayer.Logger(ctx).Infof(
+			"waiting for msgHash: %v sent before it by %v to be done",
+			common.Hash(*q.blockedBy).Hex(),
+			m.event.Message.Sender.Hex(),
+		)
+
+		q.backlog = append(q.backlog, m)
+	}
+
+	return nil
+}
+
+// backlogged reports whether the message with msgHash is in q's backlog. q.mu must be held.
+func (q *senderQueue) backlogged(msgHash [32]byte) bool {
+	for _, m := range q.backlog {
+		if m.event.MsgHash == msgHash {
+			return true
+		}
+	}
+
+	return false
+}
+
+// processQueued processes m unless it can't be or is done already, then blocks q's later messages
+// on it until it's done. q.mu must be held.
+func (svc *Service) processQueued(ctx context.Context, key senderKey, q *senderQueue, m queuedMessage) error {
+	defer svc.blockUnlessDone(ctx, key, q, m.event)
+
+	// it may have been processed some other way while it waited
+	done, err := svc.isMessageDone(ctx, m.event.MsgHash)
+	if err != nil {
+		return errors.Wrap(err, "svc.isMessageDone")
+	}
+
+	if done {
+		return nil
+	}
+
+	if !canProcessMessage(ctx, m.status, m.event.Message.Owner, svc.relayerAddr) {
+		relayer.Logger(ctx).Warnf("cant process, eventStatus: %v", m.status)
+		return nil
+	}
+
+	if err := svc.processMessage(ctx, m.event, m.e); err != nil {
+		return errors.Wrap(err, "svc.processMessage")
+	}
+
+	return nil
+}
+
+// drainBacklog processes the messages left new while q was blocked, in order, once the message
+// blocking them is done. It stops at the first that isn't done once processed, which blocks
+// the rest in turn. q.mu must be held.
+func (svc *Service) drainBacklog(ctx context.Context, key senderKey, q *senderQueue) error {
+	for q.blockedBy != nil {
+		done, err := svc.isMessageDone(ctx, *q.blockedBy)
+		if err != nil {
+			return errors.Wrap(err, "svc.isMessageDone")
+		}
+
+		if !done {
+			return nil
+		}
+
+		q.blockedBy = nil
+
+		if len(q.backlog) == 0 {
+			return nil
+		}
+
+		next := q.backlog[0]
+		q.backlog = q.backlog[1:]
+
+		ctx := relayer.WithMessageLogger(
+			ctx,
+			next.event.MsgHash,
+			next.event.Message.SrcChainId,
+			next.event.Message.DestChainId,
+		)
+
+		if err := svc.processQueued(ctx, key, q, next); err != nil {
+			relayer.ErrorEvents.Inc()
+			relayer.Logger(ctx).Errorf("svc.processQueued: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// queuedSenders are the senders with messages in a queue, waiting their turn or being handled
+func (svc *Service) queuedSenders() []common.Address {
+	svc.senderQueuesMu.Lock()
+	defer svc.senderQueuesMu.Unlock()
+
+	senders := make([]common.Address, 0, len(svc.senderQueues))
+
+	for key := range svc.senderQueues {
+		senders = append(senders, key.sender)
+	}
+
+	return senders
+}
+
+// requeueMessages rebuilds chainID's sender queues from the messages a previous run left waiting,
+// since the queues are only kept in memory. They're queued before anything's indexed, so the
+// messages indexed since wait their turn behind them, and processed in the background.
+func (svc *Service) requeueMessages(ctx context.Context, chainID *big.Int) error {
+	var events []*relayer.Event
+
+	for _, status := range queuedStatuses {
+		found, err := svc.eventRepo.FindAllByStatus(ctx, chainID, status)
+		if err != nil {
+			return errors.Wrap(err, "svc.eventRepo.FindAllByStatus")
+		}
+
+		events = append(events, found...)
+	}
+
+	// in the order they were indexed, which is the order each sender sent them in
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].ID < events[j].ID
+	})
+
+	handlers := make([]func() error, 0, len(events))
+
+	for _, e := range events {
+		if e.Name != relayer.EventNameMessageSent {
+			continue
+		}
+
+		var event bridge.BridgeMessageSent
+
+		if err := json.Unmarshal(e.Data, &event); err != nil {
+			log.Errorf("msgHash: %v, json.Unmarshal: %v", e.MsgHash, err)
+			continue
+		}
+
+		ctx := relayer.WithMessageLogger(ctx, event.MsgHash, event.Message.SrcChainId, event.Message.DestChainId)
+		handle := svc.messageHandler(e, &event)
+
+		handlers = append(handlers, func() error {
+			if err := handle(ctx); err != nil {
+				relayer.ErrorEvents.Inc()
+				relayer.Logger(ctx).Error(err.Error())
+			}
+
+			return nil
+		})
+	}
+
+	log.Infof("chain ID %v requeued %v messages left waiting in their senders' queues", chainID, len(handlers))
+
+	go func() {
+		group := new(errgroup.Group)
+
+		group.SetLimit(svc.numGoroutines)
+
+		for _, handle := range handlers {
+			group.Go(handle)
+		}
+
+		_ = group.Wait()
+	}()
+
+	return nil
+}
+
+// blockUnlessDone blocks q's later messages on event until it's done, polling for it to be if
+// nothing else is. q.mu must be held.
+func (svc *Service) blockUnlessDone(
+	ctx context.Context,
+	key senderKey,
+	q *senderQueue,
+	event *bridge.BridgeMessageSent,
+) {
+	if event.Raw.Removed || event.MsgHash == relayer.ZeroHash {
+		return
+	}
+
+	// if we can't tell, assume it isn't, rather than risk reordering
+	if done, err := svc.isMessageDone(ctx, event.MsgHash); err == nil && done {
+		return
+	}
+
+	msgHash := event.MsgHash
+	q.blockedBy = &msgHash
+
+	if !q.polling {
+		q.polling = true
+
+		go svc.pollBlockedSender(key, q)
+	}
+}
+
+// pollBlockedSender drains q's backlog as the messages blocking it are done, until it's
+// no longer blocked. It outlives the indexing that blocked q, so runs in the background.
+func (svc *Service) pollBlockedSender(key senderKey, q *senderQueue) {
+	t := time.NewTicker(svc.blockedSenderPollInterval)
+	defer t.Stop()
+
+	defer svc.forgetIfIdle(key, q)
+
+	for range t.C {
+		q.mu.Lock()
+
+		err := svc.drainBacklog(context.Background(), key, q)
+
+		blocked := q.blockedBy != nil
+		if !blocked {
+			q.polling = false
+		}
+
+		q.mu.Unlock()
+
+		if err != nil {
+			log.Errorf("svc.drainBacklog: %v", err)
+		}
+
+		if !blocked {
+			return
+		}
+	}
+}
+
+func (svc *Service) isMessageDone(ctx context.Context, msgHash [32]byte) (bool, error) {
+	messageStatus, err := svc.destBridge.GetMessageStatus(&bind.CallOpts{Context: ctx}, msgHash)
+	if err != nil {
+		return false, errors.Wrap(err, "svc.destBridge.GetMessageStatus")
+	}
+
+	return relayer.EventStatus(messageStatus) == relayer.EventStatusDone, nil
+}
@@ -0,0 +1,227 @@
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func orderedTestEvent(msgHash [32]byte, sender common.Address) *bridge.BridgeMessageSent {
+	return &bridge.BridgeMessageSent{
+		MsgHash: msgHash,
+		Message: bridge.IBridgeMessage{
+			Sender:      sender,
+			SrcChainId:  big.NewInt(1),
+			DestChainId: mock.MockChainID,
+			GasLimit:    big.NewInt(1),
+		},
+		Raw: types.Log{Topics: []common.Hash{}, Data: []byte{}},
+	}
+}
+
+// senderQueueState is the message blocking sender's queue and how many are waiting on it,
+// or ok false if the queue has been forgotten.
+func senderQueueState(svc *Service, event *bridge.BridgeMessageSent) (blockedBy *[32]byte, backlog int, ok bool) {
+	svc.senderQueuesMu.Lock()
+	q, ok := svc.senderQueues[senderKey{
+		sender:      event.Message.Sender,
+		destChainID: event.Message.DestChainId.String(),
+	}]
+	svc.senderQueuesMu.Unlock()
+
+	if !ok {
+		return nil, 0, false
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.blockedBy, len(q.backlog), true
+}
+
+func Test_eventHandler_orderedDelivery(t *testing.T) {
+	svc, b := newTestService()
+	svc.orderedDelivery = true
+	svc.blockedSenderPollInterval = 10 * time.Millisecond
+
+	// messages are left new, so never done unless we say so
+	svc.PauseProcessing()
+
+	destBridge := b.(*mock.Bridge)
+
+	first := orderedTestEvent([32]byte{0xa}, common.HexToAddress("0x1"))
+	second := orderedTestEvent([32]byte{0xb}, common.HexToAddress("0x1"))
+	other := orderedTestEvent([32]byte{0xc}, common.HexToAddress("0x2"))
+
+	for _, e := range []*bridge.BridgeMessageSent{first, second, other} {
+		destBridge.SetMessageStatus(e.MsgHash, relayer.EventStatusNew)
+	}
+
+	handleFirst := svc.eventHandler(mock.MockChainID, first)
+	handleSecond := svc.eventHandler(mock.MockChainID, second)
+	handleOther := svc.eventHandler(mock.MockChainID, other)
+
+	var wg sync.WaitGroup
+
+	// the second message is handled after the first, however they're scheduled
+	for _, handle := range []func(context.Context) error{handleSecond, handleOther, handleFirst} {
+		handle := handle
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			assert.Nil(t, handle(context.Background()))
+		}()
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	wg.Wait()
+
+	// the second message waits on the first, which isn't done
+	blockedBy, backlog, ok := senderQueueState(svc, first)
+	assert.True(t, ok)
+	assert.Equal(t, first.MsgHash, *blockedBy)
+	assert.Equal(t, 1, backlog)
+
+	// another sender's messages don't
+	blockedBy, backlog, ok = senderQueueState(svc, other)
+	assert.True(t, ok)
+	assert.Equal(t, other.MsgHash, *blockedBy)
+	assert.Equal(t, 0, backlog)
+
+	// once the first is done, the second is processed in turn
+	destBridge.SetMessageStatus(first.MsgHash, relayer.EventStatusDone)
+
+	assert.Eventually(t, func() bool {
+		blockedBy, backlog, ok := senderQueueState(svc, first)
+		return ok && *blockedBy == second.MsgHash && backlog == 0
+	}, time.Second, 10*time.Millisecond)
+
+	// and once it's done too, the sender's queue is forgotten
+	destBridge.SetMessageStatus(second.MsgHash, relayer.EventStatusDone)
+
+	assert.Eventually(t, func() bool {
+		_, _, ok := senderQueueState(svc, first)
+		return !ok
+	}, time.Second, 10*time.Millisecond)
+}
+
+func Test_eventHandler_unordered(t *testing.T) {
+	svc, _ := newTestService()
+
+	svc.PauseProcessing()
+
+	event := orderedTestEvent([32]byte{0xa}, common.HexToAddress("0x1"))
+
+	assert.Nil(t, svc.eventHandler(mock.MockChainID, event)(context.Background()))
+
+	_, _, ok := senderQueueState(svc, event)
+	assert.False(t, ok)
+}
+
+// saveOrderedTestEvent saves event as indexed with id and status, returning the saved event
+func saveOrderedTestEvent(
+	t *testing.T,
+	eventRepo *mock.EventRepository,
+	id int,
+	event *bridge.BridgeMessageSent,
+	status relayer.EventStatus,
+) *relayer.Event {
+	data, err := json.Marshal(event)
+	assert.Nil(t, err)
+
+	_, err = eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+		Name:    relayer.EventNameMessageSent,
+		Data:    string(data),
+		ChainID: mock.MockChainID,
+		Status:  status,
+		MsgHash: common.Hash(event.MsgHash).Hex(),
+	})
+	assert.Nil(t, err)
+
+	e, err := eventRepo.FirstByMsgHash(context.Background(), common.Hash(event.MsgHash).Hex())
+	assert.Nil(t, err)
+
+	// in the order they're saved, as they are in the database
+	e.ID = id
+
+	return e
+}
+
+func Test_requeueMessages(t *testing.T) {
+	svc, b := newTestService()
+	svc.orderedDelivery = true
+	svc.blockedSenderPollInterval = 10 * time.Millisecond
+
+	eventRepo := mock.NewEventRepository()
+	svc.eventRepo = eventRepo
+
+	svc.PauseProcessing()
+
+	destBridge := b.(*mock.Bridge)
+
+	first := orderedTestEvent([32]byte{0xa}, common.HexToAddress("0x1"))
+	second := orderedTestEvent([32]byte{0xb}, common.HexToAddress("0x1"))
+	held := orderedTestEvent([32]byte{0xc}, common.HexToAddress("0x2"))
+	afterHeld := orderedTestEvent([32]byte{0xd}, common.HexToAddress("0x2"))
+
+	for _, e := range []*bridge.BridgeMessageSent{first, second, held, afterHeld} {
+		destBridge.SetMessageStatus(e.MsgHash, relayer.EventStatusNew)
+	}
+
+	firstEvent := saveOrderedTestEvent(t, eventRepo, 1, first, relayer.EventStatusNew)
+	saveOrderedTestEvent(t, eventRepo, 2, held, relayer.EventStatusHeld)
+	saveOrderedTestEvent(t, eventRepo, 3, second, relayer.EventStatusNew)
+	saveOrderedTestEvent(t, eventRepo, 4, afterHeld, relayer.EventStatusNew)
+
+	assert.Nil(t, svc.requeueMessages(context.Background(), mock.MockChainID))
+
+	// a previous run's messages wait on the ones their sender sent before them, as they did then
+	assert.Eventually(t, func() bool {
+		blockedBy, backlog, ok := senderQueueState(svc, first)
+		return ok && *blockedBy == first.MsgHash && backlog == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// including ones that can't be processed yet
+	assert.Eventually(t, func() bool {
+		blockedBy, backlog, ok := senderQueueState(svc, held)
+		return ok && *blockedBy == held.MsgHash && backlog == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// draining what was left new while paused processes the message blocking the queue again,
+	// but not the one waiting on it
+	svc.pausedMu.Lock()
+	svc.pausedUpToID = 4
+	svc.pausedMu.Unlock()
+
+	assert.Nil(t, svc.drainPausedMessages(context.Background()))
+
+	svc.pausedMu.Lock()
+	assert.Equal(t, firstEvent.ID, svc.pausedUpToID)
+	svc.pausedMu.Unlock()
+
+	blockedBy, backlog, ok := senderQueueState(svc, first)
+	assert.True(t, ok)
+	assert.Equal(t, first.MsgHash, *blockedBy)
+	assert.Equal(t, 1, backlog)
+
+	// and once it's done, the one waiting on it is processed in turn
+	destBridge.SetMessageStatus(first.MsgHash, relayer.EventStatusDone)
+
+	assert.Eventually(t, func() bool {
+		blockedBy, backlog, ok := senderQueueState(svc, first)
+		return ok && *blockedBy == second.MsgHash && backlog == 0
+	}, time.Second, 10*time.Millisecond)
+}
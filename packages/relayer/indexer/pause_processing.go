@@ -0,0 +1,142 @@
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/message"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+)
+
+// PauseProcessing stops the service's processor sending transactions. Indexing carries on,
+// saving messages as new, and they're processed once ResumeProcessing is called.
+func (svc *Service) PauseProcessing() {
+	svc.processor.Pause()
+}
+
+// ResumeProcessing undoes PauseProcessing, and processes the messages indexed while paused
+// in the background.
+func (svc *Service) ResumeProcessing() {
+	svc.processor.Resume()
+
+	go func() {
+		if err := svc.drainPausedMessages(context.Background()); err != nil {
+			log.Errorf("svc.drainPausedMessages: %v", err)
+		}
+	}()
+}
+
+// ProcessingPaused reports whether PauseProcessing has been called without a ResumeProcessing since
+func (svc *Service) ProcessingPaused() bool {
+	return svc.processor.Paused()
+}
+
+// processMessage processes a message, or leaves it new to be drained on resume when
+// processing is paused. A failure is recorded on the event, for diagnosing stuck messages, and
+// the message retried later if that can help.
+func (svc *Service) processMessage(ctx context.Context, event *bridge.BridgeMessageSent, e *relayer.Event) error {
+	ctx = relayer.WithMessageLogger(ctx, event.MsgHash, event.Message.SrcChainId, event.Message.DestChainId)
+
+	err := svc.processor.ProcessMessage(ctx, event, e)
+	if !errors.Is(err, relayer.ErrProcessingPaused) {
+		if err != nil {
+			svc.recordProcessingError(ctx, e, err)
+		}
+
+		return err
+	}
+
+	relayer.Logger(ctx).Info("left new, processing is paused")
+
+	if e != nil {
+		svc.pausedMu.Lock()
+		if e.ID > svc.pausedUpToID {
+			svc.pausedUpToID = e.ID
+		}
+		svc.pausedMu.Unlock()
+	}
+
+	return nil
+}
+
+func (svc *Service) recordProcessingError(ctx context.Context, e *relayer.Event, processingErr error) {
+	if e == nil {
+		return
+	}
+
+	rejectionReason := message.RejectionReasonFor(processingErr)
+	if rejectionReason != "" {
+		relayer.Logger(ctx).Infof("rejected, reason: %v", rejectionReason)
+	}
+
+	if err := svc.eventRepo.UpdateProcessingError(ctx, e.ID, processingErr.Error(), rejectionReason); err != nil {
+		relayer.Logger(ctx).Errorf("svc.eventRepo.UpdateProcessingError: %v", err)
+	}
+
+	svc.scheduleRetry(ctx, e, processingErr)
+}
+
+// drainPausedMessages processes the new messages up to the last one left new while paused.
+// Later ones were indexed after resuming, and are already being processed.
+func (svc *Service) drainPausedMessages(ctx context.Context) error {
+	svc.pausedMu.Lock()
+	upToID := svc.pausedUpToID
+	svc.pausedUpToID = 0
+	svc.pausedMu.Unlock()
+
+	if upToID == 0 {
+		return nil
+	}
+
+	chainID, err := svc.ethClient.ChainID(ctx)
+	if err != nil {
+		return errors.Wrap(err, "svc.ethClient.ChainID")
+	}
+
+	// a lagging replica could still have messages processed since as new
+	events, err := svc.eventRepo.FindAllByStatus(relayer.WithPrimaryReads(ctx), chainID, relayer.EventStatusNew)
+	if err != nil {
+		return errors.Wrap(err, "svc.eventRepo.FindAllByStatus")
+	}
+
+	log.Infof("draining messages left new while processing was paused, up to event %v", upToID)
+
+	group := new(errgroup.Group)
+
+	group.SetLimit(svc.numGoroutines)
+
+	for _, e := range events {
+		if e.ID > upToID || e.Name != relayer.EventNameMessageSent {
+			continue
+		}
+
+		e := e
+
+		var event bridge.BridgeMessageSent
+
+		if err := json.Unmarshal(e.Data, &event); err != nil {
+			log.Errorf("msgHash: %v, json.Unmarshal: %v", e.MsgHash, err)
+			continue
+		}
+
+		// taken in the order they were indexed, so ordered delivery keeps to it
+		process := svc.messageHandler(e, &event)
+
+		group.Go(func() error {
+			ctx := relayer.WithMessageLogger(ctx, event.MsgHash, event.Message.SrcChainId, event.Message.DestChainId)
+
+			if err := process(ctx); err != nil {
+				relayer.ErrorEvents.Inc()
+				relayer.Logger(ctx).Errorf("svc.processMessage: %v", err)
+			}
+
+			return nil
+		})
+	}
+
+	return group.Wait()
+}
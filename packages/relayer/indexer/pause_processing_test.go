@@ -0,0 +1,121 @@
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_processMessage_paused(t *testing.T) {
+	svc, _ := newTestService()
+
+	svc.PauseProcessing()
+	assert.True(t, svc.ProcessingPaused())
+
+	event := &bridge.BridgeMessageSent{Message: bridge.IBridgeMessage{GasLimit: big.NewInt(1)}}
+
+	assert.Nil(t, svc.processMessage(context.Background(), event, nil))
+	assert.Equal(t, 0, svc.pausedUpToID)
+
+	assert.Nil(t, svc.processMessage(context.Background(), event, &relayer.Event{ID: 5}))
+	assert.Nil(t, svc.processMessage(context.Background(), event, &relayer.Event{ID: 3}))
+	assert.Equal(t, 5, svc.pausedUpToID)
+}
+
+func Test_processMessage_recordsError(t *testing.T) {
+	svc, _ := newTestService()
+
+	eventRepo := mock.NewEventRepository()
+	svc.eventRepo = eventRepo
+
+	_, err := eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+		Name:    relayer.EventNameMessageSent,
+		ChainID: mock.MockChainID,
+		Status:  relayer.EventStatusNew,
+	})
+	assert.Nil(t, err)
+
+	events, err := eventRepo.FindAllByStatus(context.Background(), mock.MockChainID, relayer.EventStatusNew)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(events))
+
+	err = svc.processMessage(context.Background(), &bridge.BridgeMessageSent{}, events[0])
+	assert.EqualError(t, err, "only user can process this, gasLimit set to 0")
+
+	assert.Equal(t, "only user can process this, gasLimit set to 0", events[0].ProcessingError)
+	// only its owner can process it, which isn't one of the rejection reasons
+	assert.Equal(t, relayer.RejectionReason(""), events[0].RejectionReason)
+}
+
+func Test_drainPausedMessages(t *testing.T) {
+	svc, _ := newTestService()
+
+	eventRepo := mock.NewEventRepository()
+	svc.eventRepo = eventRepo
+
+	data, err := json.Marshal(&bridge.BridgeMessageSent{
+		Message: bridge.IBridgeMessage{GasLimit: big.NewInt(1)},
+		Raw:     types.Log{Topics: []common.Hash{}, Data: []byte{}},
+	})
+	assert.Nil(t, err)
+
+	for _, name := range []string{
+		relayer.EventNameMessageSent,
+		relayer.EventNameMessageSent,
+		relayer.EventNameMessageStatusChanged,
+	} {
+		_, err := eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+			Name:    name,
+			Data:    string(data),
+			ChainID: mock.MockChainID,
+			Status:  relayer.EventStatusNew,
+		})
+		assert.Nil(t, err)
+	}
+
+	events, err := eventRepo.FindAllByStatus(context.Background(), mock.MockChainID, relayer.EventStatusNew)
+	assert.Nil(t, err)
+
+	var maxID, maxMessageSentID, minMessageSentID int
+
+	for _, e := range events {
+		if e.ID > maxID {
+			maxID = e.ID
+		}
+
+		if e.Name != relayer.EventNameMessageSent {
+			continue
+		}
+
+		if e.ID > maxMessageSentID {
+			maxMessageSentID = e.ID
+		}
+
+		if minMessageSentID == 0 || e.ID < minMessageSentID {
+			minMessageSentID = e.ID
+		}
+	}
+
+	// still paused, so every message drained is left new again, which records its ID
+	svc.PauseProcessing()
+
+	assert.Nil(t, svc.drainPausedMessages(context.Background()))
+	assert.Equal(t, 0, svc.pausedUpToID)
+
+	svc.pausedUpToID = maxID
+	assert.Nil(t, svc.drainPausedMessages(context.Background()))
+	assert.Equal(t, maxMessageSentID, svc.pausedUpToID)
+
+	// messages indexed after the last one left new aren't drained
+	svc.pausedUpToID = minMessageSentID
+	assert.Nil(t, svc.drainPausedMessages(context.Background()))
+	assert.Equal(t, minMessageSentID, svc.pausedUpToID)
+}
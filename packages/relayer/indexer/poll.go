@@ -0,0 +1,100 @@
+package indexer
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	// blockTimeSampleSize is how many blocks back from the head are looked at
+	// to infer the chain's block time.
+	blockTimeSampleSize uint64 = 10
+)
+
+// poll indexes new blocks by asking for the latest header every svc.headPollInterval,
+// for RPCs that can't push new heads to us. When behind, it indexes up to
+// svc.blockBatchSize blocks per request for the head, and moves straight on to the next
+// batch instead of waiting. Once caught up, it waits for the longer of svc.headPollInterval
+// and the chain's block time, since polling faster than blocks are produced only costs
+// requests.
+func (svc *Service) poll(ctx context.Context, chainID *big.Int) error {
+	log.Infof("chain ID %v polling for new blocks every %v", chainID.Uint64(), svc.headPollInterval)
+
+	var blockTime time.Duration
+
+	for {
+		header, err := svc.ethClient.HeaderByNumber(ctx, nil)
+		if err != nil {
+			log.Errorf("chain ID %v svc.ethClient.HeaderByNumber: %v", chainID.Uint64(), err)
+		} else {
+			relayer.BlocksScanned.Inc()
+
+			svc.syncProgress.setHead(header.Number.Uint64())
+
+			if svc.processingBlockHeight < header.Number.Uint64() {
+				end := svc.processingBlockHeight + svc.blockBatchSize
+				if end > header.Number.Uint64() {
+					end = header.Number.Uint64()
+				}
+
+				if err := svc.indexBatch(ctx, chainID, end); err != nil {
+					return errors.Wrap(err, "svc.indexBatch")
+				}
+
+				svc.trackSyncProgress(ctx, chainID)
+
+				// still behind, index the next batch without waiting
+				if svc.processingBlockHeight < header.Number.Uint64() {
+					continue
+				}
+			}
+
+			if blockTime == 0 {
+				blockTime = svc.inferBlockTime(ctx, header)
+				if blockTime > svc.headPollInterval {
+					log.Infof("chain ID %v caught up, polling every block time of %v", chainID.Uint64(), blockTime)
+				}
+			}
+		}
+
+		interval := svc.headPollInterval
+		if blockTime > interval {
+			interval = blockTime
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// inferBlockTime averages the time between the last blockTimeSampleSize blocks before head,
+// returning 0 if it can't be worked out.
+func (svc *Service) inferBlockTime(ctx context.Context, head *types.Header) time.Duration {
+	if head.Number.Uint64() < blockTimeSampleSize {
+		return 0
+	}
+
+	sample, err := svc.ethClient.HeaderByNumber(
+		ctx,
+		new(big.Int).SetUint64(head.Number.Uint64()-blockTimeSampleSize),
+	)
+	if err != nil {
+		log.Errorf("svc.ethClient.HeaderByNumber: %v", err)
+		return 0
+	}
+
+	if head.Time <= sample.Time {
+		return 0
+	}
+
+	return time.Duration(head.Time-sample.Time) * time.Second / time.Duration(blockTimeSampleSize)
+}
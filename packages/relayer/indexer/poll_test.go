@@ -0,0 +1,57 @@
+package indexer
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_poll(t *testing.T) {
+	svc, _ := newTestService()
+
+	svc.processingBlockHeight = 0
+	svc.blockBatchSize = 3
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	assert.Nil(t, svc.poll(ctx, mock.MockChainID))
+	assert.Equal(t, mock.LatestBlockNumber.Uint64(), svc.processingBlockHeight)
+}
+
+func Test_inferBlockTime(t *testing.T) {
+	tests := []struct {
+		name string
+		head *types.Header
+		want time.Duration
+	}{
+		{
+			"success",
+			&types.Header{Number: big.NewInt(20), Time: 20 * mock.BlockTime},
+			time.Duration(mock.BlockTime) * time.Second,
+		},
+		{
+			"tooFewBlocks",
+			&types.Header{Number: big.NewInt(5), Time: 5 * mock.BlockTime},
+			0,
+		},
+		{
+			"timeNotAdvanced",
+			&types.Header{Number: big.NewInt(20), Time: 0},
+			0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newTestService()
+
+			assert.Equal(t, tt.want, svc.inferBlockTime(context.Background(), tt.head))
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package indexer
+
+import (
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+)
+
+// publishEvent publishes a saved event, if there is an event publisher. It only buffers the
+// event, so it never holds up indexing.
+func (svc *Service) publishEvent(opts relayer.SaveEventOpts) {
+	if svc.eventPublisher == nil {
+		return
+	}
+
+	svc.eventPublisher.PublishEvent(opts)
+}
@@ -0,0 +1,72 @@
+package indexer
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+// unpackFailure is a log matching eventName's signature that couldn't be unpacked
+type unpackFailure struct {
+	eventName string
+	raw       types.Log
+}
+
+// quarantineLog handles a log that couldn't be unpacked, i.e. after a contract upgrade changed its
+// event's data layout. With svc.strictLogUnpacking it returns unpackErr, failing the batch, as it always
+// used to. Otherwise the log is counted and logged, and saved to svc.quarantinedLogRepo if there is one,
+// to be decoded again later, and it returns nil so the rest of the batch is indexed without it.
+func (svc *Service) quarantineLog(
+	ctx context.Context,
+	chainID *big.Int,
+	failure unpackFailure,
+	unpackErr error,
+) error {
+	relayer.LogUnpackFailures.WithLabelValues(chainID.String(), failure.eventName).Inc()
+
+	if svc.strictLogUnpacking {
+		return unpackErr
+	}
+
+	l := failure.raw
+
+	relayer.Logger(ctx).Errorf(
+		"chain ID %v: skipping %v log %v of tx %v in block %v, it couldn't be unpacked: %v",
+		chainID.Uint64(),
+		failure.eventName,
+		l.Index,
+		l.TxHash.Hex(),
+		l.BlockNumber,
+		unpackErr,
+	)
+
+	if svc.quarantinedLogRepo == nil {
+		return nil
+	}
+
+	topics := make([]string, 0, len(l.Topics))
+	for _, topic := range l.Topics {
+		topics = append(topics, topic.Hex())
+	}
+
+	if err := svc.quarantinedLogRepo.Save(ctx, relayer.SaveQuarantinedLogOpts{
+		ChainID:     chainID,
+		EventName:   failure.eventName,
+		BlockNumber: l.BlockNumber,
+		BlockHash:   l.BlockHash.Hex(),
+		TxHash:      l.TxHash.Hex(),
+		LogIndex:    l.Index,
+		Topics:      strings.Join(topics, ","),
+		Data:        hexutil.Encode(l.Data),
+		Error:       unpackErr.Error(),
+	}); err != nil {
+		return errors.Wrap(err, "svc.quarantinedLogRepo.Save")
+	}
+
+	return nil
+}
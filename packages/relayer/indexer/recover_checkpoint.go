@@ -0,0 +1,44 @@
+package indexer
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// recoverCheckpoint restores a lost checkpoint from the highest block an event was indexed from
+// for chainID, so the indexer carries on from there rather than indexing everything again.
+// That block is indexed again, as it is resuming from a stored checkpoint. ok is false if no
+// events have been indexed, and there's nothing to recover.
+func (svc *Service) recoverCheckpoint(ctx context.Context, chainID *big.Int) (height uint64, ok bool, err error) {
+	// a lagging replica would recover an older checkpoint than it could
+	height, err = svc.eventRepo.LatestBlockNumber(relayer.WithPrimaryReads(ctx), chainID)
+	if err != nil {
+		return 0, false, errors.Wrap(err, "svc.eventRepo.LatestBlockNumber")
+	}
+
+	if height == 0 {
+		return 0, false, nil
+	}
+
+	header, err := svc.ethClient.HeaderByNumber(ctx, new(big.Int).SetUint64(height))
+	if err != nil {
+		return 0, false, errors.Wrap(err, "svc.ethClient.HeaderByNumber")
+	}
+
+	if err := svc.blockRepo.Save(relayer.SaveBlockOpts{
+		Height:    height,
+		Hash:      header.Hash(),
+		ChainID:   chainID,
+		EventName: eventName,
+	}); err != nil {
+		return 0, false, errors.Wrap(err, "svc.blockRepo.Save")
+	}
+
+	log.Warnf("chain ID %v had no checkpoint, recovered it at block %v from the indexed events", chainID.Uint64(), height)
+
+	return height, true, nil
+}
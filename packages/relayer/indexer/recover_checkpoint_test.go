@@ -0,0 +1,57 @@
+package indexer
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_recoverCheckpoint(t *testing.T) {
+	svc, _ := newTestService()
+
+	_, ok, err := svc.recoverCheckpoint(context.Background(), mock.NoCheckpointChainID)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	for _, blockNumber := range []uint64{5, 42, 7} {
+		_, err := svc.eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+			ChainID:     mock.NoCheckpointChainID,
+			BlockNumber: blockNumber,
+		})
+		assert.Nil(t, err)
+	}
+
+	// another chain's events aren't this chain's checkpoint
+	_, err = svc.eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+		ChainID:     big.NewInt(1),
+		BlockNumber: 1000,
+	})
+	assert.Nil(t, err)
+
+	height, ok, err := svc.recoverCheckpoint(context.Background(), mock.NoCheckpointChainID)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(42), height)
+}
+
+func Test_SetInitialProcessingBlockByMode_recoversCheckpoint(t *testing.T) {
+	svc, _ := newTestService()
+	svc.startHeight = "1"
+
+	_, err := svc.eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+		ChainID:     mock.NoCheckpointChainID,
+		BlockNumber: 42,
+	})
+	assert.Nil(t, err)
+
+	assert.Nil(t, svc.setInitialProcessingBlockByMode(context.Background(), relayer.SyncMode, mock.NoCheckpointChainID))
+	assert.Equal(t, uint64(42), svc.processingBlockHeight)
+
+	// resyncing starts again from the configured start height
+	assert.Nil(t, svc.setInitialProcessingBlockByMode(context.Background(), relayer.ResyncMode, mock.NoCheckpointChainID))
+	assert.Equal(t, uint64(1), svc.processingBlockHeight)
+}
@@ -0,0 +1,192 @@
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+// ReindexedEvent is an event ReindexBlock removed or added, identified by the log it's from
+type ReindexedEvent struct {
+	Name     string
+	MsgHash  string
+	TxHash   common.Hash
+	LogIndex uint
+	Status   relayer.EventStatus
+}
+
+// ReindexBlockResult is what ReindexBlock changed: the events it removed, the ones it added,
+// and how many were indexed right and left alone
+type ReindexBlockResult struct {
+	Removed   []ReindexedEvent
+	Added     []ReindexedEvent
+	Unchanged int
+}
+
+// logKey identifies the log an event was indexed from, and the message it was for
+type logKey struct {
+	name      string
+	blockHash common.Hash
+	txHash    common.Hash
+	index     uint
+	msgHash   string
+}
+
+func newLogKey(name string, raw types.Log, msgHash [32]byte) logKey {
+	return logKey{
+		name:      name,
+		blockHash: raw.BlockHash,
+		txHash:    raw.TxHash,
+		index:     raw.Index,
+		msgHash:   common.Hash(msgHash).Hex(),
+	}
+}
+
+// ReindexBlock re-scans blockNumber for the bridge's MessageSent and MessageStatusChanged logs, and
+// brings the events indexed from it in line. Events the block has no log for, i.e. one a flaky node
+// answered with from another fork, or with another message, are deleted, and logs that weren't indexed
+// are saved the way indexing saves them. Events indexed right are left alone, statuses included, so it's
+// safe while the relayer is processing them, and running it again changes nothing. The processing
+// checkpoint isn't moved, and the messages it adds aren't processed.
+func (svc *Service) ReindexBlock(
+	ctx context.Context,
+	chainID *big.Int,
+	blockNumber uint64,
+) (*ReindexBlockResult, error) {
+	if blockNumber == 0 {
+		return nil, relayer.ErrInvalidBlockNumber
+	}
+
+	bridgeEvents, err := svc.filterBridgeEvents(ctx, chainID, blockNumber, blockNumber)
+	if err != nil {
+		return nil, errors.Wrap(err, "svc.filterBridgeEvents")
+	}
+
+	// kept in log order, so they're added in the order they were emitted
+	sent := make([]*bridge.BridgeMessageSent, 0)
+	statusChanged := make([]*bridge.BridgeMessageStatusChanged, 0)
+	inBlock := make(map[logKey]bool)
+
+	for _, event := range bridgeEvents.sent {
+		if svc.skipEvent(ctx, event) {
+			continue
+		}
+
+		sent = append(sent, event)
+		inBlock[newLogKey(relayer.EventNameMessageSent, event.Raw, event.MsgHash)] = true
+	}
+
+	for _, event := range bridgeEvents.statusChanged {
+		statusChanged = append(statusChanged, event)
+		inBlock[newLogKey(relayer.EventNameMessageStatusChanged, event.Raw, event.MsgHash)] = true
+	}
+
+	// an event indexed from a log that no longer unpacks is still in the block, and is left alone
+	for _, failure := range bridgeEvents.unpackFailed {
+		if len(failure.raw.Topics) > 1 {
+			inBlock[newLogKey(failure.eventName, failure.raw, failure.raw.Topics[1])] = true
+		}
+	}
+
+	// a lagging replica could miss events saved moments ago, and have them indexed twice
+	indexed, err := svc.eventRepo.FindAllByBlockNumber(relayer.WithPrimaryReads(ctx), chainID, blockNumber)
+	if err != nil {
+		return nil, errors.Wrap(err, "svc.eventRepo.FindAllByBlockNumber")
+	}
+
+	result := &ReindexBlockResult{
+		Removed: make([]ReindexedEvent, 0),
+		Added:   make([]ReindexedEvent, 0),
+	}
+
+	// the logs events are indexed right for
+	indexedLogs := make(map[logKey]bool)
+
+	// removing first, so a message re-added from another log isn't taken as a duplicate of the wrong one
+	for _, e := range indexed {
+		var data struct {
+			Raw types.Log
+		}
+
+		// an event whose log doesn't decode can't be one the block has
+		key := logKey{}
+		if err := json.Unmarshal(e.Data, &data); err == nil {
+			key = newLogKey(e.Name, data.Raw, common.HexToHash(e.MsgHash))
+		}
+
+		// the same log indexed twice is only kept once
+		if inBlock[key] && !indexedLogs[key] {
+			indexedLogs[key] = true
+			result.Unchanged++
+
+			continue
+		}
+
+		relayer.Logger(ctx).Warnf("reindexing block %v, removing event %v", blockNumber, e.ID)
+
+		if err := svc.eventRepo.Delete(ctx, e.ID); err != nil {
+			return nil, errors.Wrap(err, "svc.eventRepo.Delete")
+		}
+
+		result.Removed = append(result.Removed, ReindexedEvent{
+			Name:     e.Name,
+			MsgHash:  e.MsgHash,
+			TxHash:   data.Raw.TxHash,
+			LogIndex: data.Raw.Index,
+			Status:   e.Status,
+		})
+	}
+
+	// messages are saved before their status changes, which are only saved for indexed messages
+	for _, event := range sent {
+		if indexedLogs[newLogKey(relayer.EventNameMessageSent, event.Raw, event.MsgHash)] {
+			continue
+		}
+
+		ctx := relayer.WithMessageLogger(ctx, common.Hash(event.MsgHash), chainID, event.Message.DestChainId)
+
+		_, status, err := svc.saveEvent(ctx, chainID, event)
+		if err != nil {
+			return nil, errors.Wrap(err, "svc.saveEvent")
+		}
+
+		result.Added = append(result.Added, ReindexedEvent{
+			Name:     relayer.EventNameMessageSent,
+			MsgHash:  common.Hash(event.MsgHash).Hex(),
+			TxHash:   event.Raw.TxHash,
+			LogIndex: event.Raw.Index,
+			Status:   status,
+		})
+	}
+
+	for _, event := range statusChanged {
+		if indexedLogs[newLogKey(relayer.EventNameMessageStatusChanged, event.Raw, event.MsgHash)] {
+			continue
+		}
+
+		saved, err := svc.saveMessageStatusChangedEvent(ctx, chainID, event)
+		if err != nil {
+			return nil, errors.Wrap(err, "svc.saveMessageStatusChangedEvent")
+		}
+
+		if !saved {
+			continue
+		}
+
+		result.Added = append(result.Added, ReindexedEvent{
+			Name:     relayer.EventNameMessageStatusChanged,
+			MsgHash:  common.Hash(event.MsgHash).Hex(),
+			TxHash:   event.Raw.TxHash,
+			LogIndex: event.Raw.Index,
+			Status:   relayer.EventStatus(event.Status),
+		})
+	}
+
+	return result, nil
+}
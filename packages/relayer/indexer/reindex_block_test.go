@@ -0,0 +1,101 @@
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ReindexBlock(t *testing.T) {
+	svc, b := newTestService()
+
+	eventRepo := mock.NewEventRepository()
+	svc.eventRepo = eventRepo
+
+	message := bridge.IBridgeMessage{
+		Id:            big.NewInt(0),
+		SrcChainId:    big.NewInt(1),
+		DestChainId:   big.NewInt(2),
+		Owner:         common.HexToAddress("0x1"),
+		DepositValue:  big.NewInt(0),
+		CallValue:     big.NewInt(0),
+		ProcessingFee: big.NewInt(0),
+		GasLimit:      big.NewInt(1),
+	}
+
+	txHash := common.HexToHash("0xabc")
+	other := [32]byte{0x5}
+
+	b.(*mock.Bridge).Logs = append(b.(*mock.Bridge).Logs,
+		mock.MessageSentLog(mock.SuccessMsgHash, message, 5, txHash, 0),
+		mock.MessageSentLog(other, message, 5, txHash, 1),
+		mock.MessageStatusChangedLog(mock.SuccessMsgHash, relayer.EventStatusDone, 5, txHash, 2),
+		mock.MessageSentLog([32]byte{0x6}, message, 6, txHash, 0),
+	)
+
+	result, err := svc.ReindexBlock(context.Background(), big.NewInt(1), 5)
+	assert.Nil(t, err)
+	assert.Equal(t, []ReindexedEvent{
+		{relayer.EventNameMessageSent, common.Hash(mock.SuccessMsgHash).Hex(), txHash, 0, relayer.EventStatusNew},
+		{relayer.EventNameMessageSent, common.Hash(other).Hex(), txHash, 1, relayer.EventStatusDone},
+		{relayer.EventNameMessageStatusChanged, common.Hash(mock.SuccessMsgHash).Hex(), txHash, 2, relayer.EventStatusDone},
+	}, result.Added)
+	assert.Empty(t, result.Removed)
+
+	// again changes nothing
+	result, err = svc.ReindexBlock(context.Background(), big.NewInt(1), 5)
+	assert.Nil(t, err)
+	assert.Empty(t, result.Added)
+	assert.Empty(t, result.Removed)
+	assert.Equal(t, 3, result.Unchanged)
+
+	// a message indexed from another fork's block 5, and one from block 6
+	forked := mock.MessageSentLog([32]byte{0x7}, message, 5, common.HexToHash("0xdef"), 0)
+	forked.BlockHash = common.HexToHash("0xf00")
+
+	for _, raw := range []bridge.BridgeMessageSent{
+		{MsgHash: [32]byte{0x7}, Message: message, Raw: forked},
+		{MsgHash: [32]byte{0x8}, Message: message, Raw: mock.MessageSentLog([32]byte{0x8}, message, 6, txHash, 0)},
+	} {
+		data, err := json.Marshal(raw)
+		assert.Nil(t, err)
+
+		_, err = eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+			Name:        relayer.EventNameMessageSent,
+			Event:       relayer.EventNameMessageSent,
+			Data:        string(data),
+			ChainID:     big.NewInt(1),
+			Status:      relayer.EventStatusNew,
+			MsgHash:     common.Hash(raw.MsgHash).Hex(),
+			BlockNumber: raw.Raw.BlockNumber,
+		})
+		assert.Nil(t, err)
+	}
+
+	result, err = svc.ReindexBlock(context.Background(), big.NewInt(1), 5)
+	assert.Nil(t, err)
+	assert.Empty(t, result.Added)
+	assert.Equal(t, []ReindexedEvent{
+		{relayer.EventNameMessageSent, common.Hash([32]byte{0x7}).Hex(), forked.TxHash, 0, relayer.EventStatusNew},
+	}, result.Removed)
+	assert.Equal(t, 3, result.Unchanged)
+
+	// other blocks are left alone
+	events, err := eventRepo.FindAllByBlockNumber(context.Background(), big.NewInt(1), 6)
+	assert.Nil(t, err)
+	assert.Len(t, events, 1)
+}
+
+func Test_ReindexBlock_genesis(t *testing.T) {
+	svc, _ := newTestService()
+
+	_, err := svc.ReindexBlock(context.Background(), big.NewInt(1), 0)
+	assert.Equal(t, relayer.ErrInvalidBlockNumber, err)
+}
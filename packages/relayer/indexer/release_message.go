@@ -0,0 +1,66 @@
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/pkg/errors"
+)
+
+// ReleaseMessage moves a message held for review back to new, and processes it
+// in the background the same way a newly indexed message would be. Only one of
+// concurrent releases of the same message moves it, the others find it not held.
+func (svc *Service) ReleaseMessage(ctx context.Context, e *relayer.Event) error {
+	if e.Status != relayer.EventStatusHeld {
+		return relayer.ErrMessageNotHeld
+	}
+
+	var event bridge.BridgeMessageSent
+
+	if err := json.Unmarshal(e.Data, &event); err != nil {
+		return errors.Wrap(err, "json.Unmarshal")
+	}
+
+	released, err := svc.eventRepo.UpdateStatusIf(ctx, e.ID, relayer.EventStatusHeld, relayer.EventStatusNew)
+	if err != nil {
+		return errors.Wrap(err, "svc.eventRepo.UpdateStatusIf")
+	}
+
+	if !released {
+		return relayer.ErrMessageNotHeld
+	}
+
+	e.Status = relayer.EventStatusNew
+
+	logCtx := relayer.WithMessageLogger(
+		context.Background(),
+		event.MsgHash,
+		event.Message.SrcChainId,
+		event.Message.DestChainId,
+	)
+
+	relayer.Logger(logCtx).Info("released from review")
+
+	process := svc.messageHandler(e, &event)
+
+	go func() {
+		if err := process(logCtx); err != nil {
+			relayer.Logger(logCtx).Errorf("svc.processMessage: %v", err)
+			relayer.ErrorEvents.Inc()
+		}
+	}()
+
+	return nil
+}
+
+// ChainID returns the chain ID of the chain this service indexes
+func (svc *Service) ChainID(ctx context.Context) (int64, error) {
+	chainID, err := svc.ethClient.ChainID(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "svc.ethClient.ChainID")
+	}
+
+	return chainID.Int64(), nil
+}
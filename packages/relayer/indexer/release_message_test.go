@@ -0,0 +1,53 @@
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ReleaseMessage_onlyOnce(t *testing.T) {
+	svc, _ := newTestService()
+
+	eventRepo := mock.NewEventRepository()
+	svc.eventRepo = eventRepo
+
+	// leaves the released message new rather than processing it
+	svc.PauseProcessing()
+
+	data, err := json.Marshal(&bridge.BridgeMessageSent{
+		MsgHash: mock.SuccessMsgHash,
+		Message: bridge.IBridgeMessage{
+			SrcChainId:  mock.MockChainID,
+			DestChainId: big.NewInt(2),
+		},
+		Raw: types.Log{Topics: []common.Hash{}, Data: []byte{}},
+	})
+	assert.Nil(t, err)
+
+	_, err = eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+		Name:    relayer.EventNameMessageSent,
+		ChainID: mock.MockChainID,
+		Data:    string(data),
+		Status:  relayer.EventStatusHeld,
+	})
+	assert.Nil(t, err)
+
+	held, err := eventRepo.FindAllByStatus(context.Background(), mock.MockChainID, relayer.EventStatusHeld)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(held))
+
+	// both were read while the message was held
+	first, second := *held[0], *held[0]
+
+	assert.Nil(t, svc.ReleaseMessage(context.Background(), &first))
+	assert.Equal(t, relayer.ErrMessageNotHeld, svc.ReleaseMessage(context.Background(), &second))
+}
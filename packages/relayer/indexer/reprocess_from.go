@@ -0,0 +1,123 @@
+package indexer
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+// ReprocessedMessage is a message ReprocessFrom went through, with why it was skipped or failed
+type ReprocessedMessage struct {
+	MsgHash     string
+	BlockNumber uint64
+	Status      relayer.EventStatus
+	Error       string
+}
+
+// ReprocessFromResult is what ReprocessFrom did with each message
+type ReprocessFromResult struct {
+	// Processed are the messages processed again, with the status they ended up with on the destination
+	Processed []ReprocessedMessage
+	// Skipped are the messages that weren't new, indexed or on the destination bridge, or couldn't be checked
+	Skipped []ReprocessedMessage
+	// Failed are the messages whose processing errored
+	Failed []ReprocessedMessage
+}
+
+// ReprocessFrom processes the new messages indexed from chainID's blocks at or after fromBlock once
+// more, and waits for them. It only relays what's already indexed, it doesn't index the blocks again
+// or move the processing checkpoint.
+func (svc *Service) ReprocessFrom(
+	ctx context.Context,
+	chainID *big.Int,
+	fromBlock uint64,
+) (*ReprocessFromResult, error) {
+	// reprocessing acts on the events' current status
+	ctx = relayer.WithPrimaryReads(ctx)
+
+	events, err := svc.eventRepo.FindAllMessageSentFromBlock(ctx, chainID, fromBlock)
+	if err != nil {
+		return nil, errors.Wrap(err, "svc.eventRepo.FindAllMessageSentFromBlock")
+	}
+
+	// the outcome for each event, so the result keeps the order they were emitted in
+	messages := make([]ReprocessedMessage, len(events))
+	failed := make([]bool, len(events))
+
+	group, groupCtx := errgroup.WithContext(ctx)
+
+	group.SetLimit(svc.numGoroutines)
+
+	for i, e := range events {
+		i, e := i, e
+
+		messages[i] = ReprocessedMessage{MsgHash: e.MsgHash, BlockNumber: e.BlockNumber, Status: e.Status}
+
+		// checked in the order they were emitted, so ordered delivery queues them in it
+		event, err := svc.reprocessableMessage(e)
+		if err != nil {
+			messages[i].Error = err.Error()
+			continue
+		}
+
+		process := svc.messageHandler(e, event)
+
+		group.Go(func() error {
+			logCtx := relayer.WithMessageLogger(
+				groupCtx,
+				common.Hash(event.MsgHash),
+				event.Message.SrcChainId,
+				event.Message.DestChainId,
+			)
+
+			relayer.Logger(logCtx).Info("reprocessing")
+
+			if err := process(logCtx); err != nil {
+				relayer.ErrorEvents.Inc()
+				relayer.Logger(logCtx).Errorf("svc.processMessage: %v", err)
+
+				messages[i].Error = err.Error()
+				failed[i] = true
+
+				return nil
+			}
+
+			// the status it ended up with on the destination chain
+			messageStatus, err := svc.destBridge.GetMessageStatus(nil, event.MsgHash)
+			if err != nil {
+				return errors.Wrap(err, "svc.destBridge.GetMessageStatus")
+			}
+
+			messages[i].Status = relayer.EventStatus(messageStatus)
+
+			return nil
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return nil, errors.Wrap(err, "group.Wait")
+	}
+
+	result := &ReprocessFromResult{
+		Processed: make([]ReprocessedMessage, 0),
+		Skipped:   make([]ReprocessedMessage, 0),
+		Failed:    make([]ReprocessedMessage, 0),
+	}
+
+	for i, m := range messages {
+		switch {
+		case failed[i]:
+			result.Failed = append(result.Failed, m)
+		case m.Error != "":
+			result.Skipped = append(result.Skipped, m)
+		default:
+			result.Processed = append(result.Processed, m)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,89 @@
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ReprocessFrom(t *testing.T) {
+	svc, b := newTestService()
+
+	eventRepo := mock.NewEventRepository()
+	svc.eventRepo = eventRepo
+
+	message := bridge.IBridgeMessage{
+		Id:            big.NewInt(0),
+		SrcChainId:    big.NewInt(1),
+		DestChainId:   big.NewInt(2),
+		Owner:         common.HexToAddress("0x1"),
+		DepositValue:  big.NewInt(0),
+		CallValue:     big.NewInt(0),
+		ProcessingFee: big.NewInt(0),
+		GasLimit:      big.NewInt(1),
+	}
+
+	userOnly := message
+	userOnly.GasLimit = big.NewInt(0)
+
+	b.(*mock.Bridge).SetMessageStatus([32]byte{0x6}, relayer.EventStatusNew)
+
+	for i, opts := range []struct {
+		msgHash     [32]byte
+		message     bridge.IBridgeMessage
+		blockNumber uint64
+		status      relayer.EventStatus
+	}{
+		{mock.SuccessMsgHash, message, 4, relayer.EventStatusNew},
+		{mock.SuccessMsgHash, message, 5, relayer.EventStatusNew},
+		{mock.FailSignal, message, 6, relayer.EventStatusNew},
+		{[32]byte{0x6}, userOnly, 6, relayer.EventStatusNew},
+		{[32]byte{0x7}, message, 7, relayer.EventStatusRetriable},
+	} {
+		data, err := json.Marshal(bridge.BridgeMessageSent{
+			MsgHash: opts.msgHash,
+			Message: opts.message,
+			Raw:     mock.MessageSentLog(opts.msgHash, opts.message, opts.blockNumber, common.HexToHash("0xabc"), uint(i)),
+		})
+		assert.Nil(t, err)
+
+		_, err = eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+			Name:        relayer.EventNameMessageSent,
+			Event:       relayer.EventNameMessageSent,
+			Data:        string(data),
+			ChainID:     big.NewInt(1),
+			Status:      opts.status,
+			MsgHash:     common.Hash(opts.msgHash).Hex(),
+			BlockNumber: opts.blockNumber,
+		})
+		assert.Nil(t, err)
+	}
+
+	result, err := svc.ReprocessFrom(context.Background(), big.NewInt(1), 5)
+	assert.Nil(t, err)
+
+	assert.Equal(t, []ReprocessedMessage{
+		{MsgHash: common.Hash(mock.SuccessMsgHash).Hex(), BlockNumber: 5, Status: relayer.EventStatusNew},
+	}, result.Processed)
+
+	assert.Equal(t, []ReprocessedMessage{
+		{
+			MsgHash:     common.Hash([32]byte{0x6}).Hex(),
+			BlockNumber: 6,
+			Status:      relayer.EventStatusNew,
+			Error:       "only user can process this, gasLimit set to 0",
+		},
+	}, result.Failed)
+
+	assert.Equal(t, 2, len(result.Skipped))
+	assert.Equal(t, common.Hash(mock.FailSignal).Hex(), result.Skipped[0].MsgHash)
+	assert.Equal(t, relayer.ErrMessageNotReprocessable.Error(), result.Skipped[0].Error)
+	assert.Equal(t, relayer.EventStatusRetriable, result.Skipped[1].Status)
+}
@@ -0,0 +1,65 @@
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/pkg/errors"
+)
+
+// ReprocessMessage processes a new message again in the background, i.e. one whose processing
+// errored before a transaction was sent. The destination bridge must still see it as new,
+// retriable and failed messages can only be retried by their owner.
+func (svc *Service) ReprocessMessage(ctx context.Context, e *relayer.Event) error {
+	event, err := svc.reprocessableMessage(e)
+	if err != nil {
+		return err
+	}
+
+	logCtx := relayer.WithMessageLogger(
+		context.Background(),
+		event.MsgHash,
+		event.Message.SrcChainId,
+		event.Message.DestChainId,
+	)
+
+	relayer.Logger(logCtx).Info("reprocessing")
+
+	process := svc.messageHandler(e, event)
+
+	go func() {
+		if err := process(logCtx); err != nil {
+			relayer.Logger(logCtx).Errorf("svc.processMessage: %v", err)
+			relayer.ErrorEvents.Inc()
+		}
+	}()
+
+	return nil
+}
+
+// reprocessableMessage is the message e was indexed from, if both e and the destination bridge
+// still have it as new.
+func (svc *Service) reprocessableMessage(e *relayer.Event) (*bridge.BridgeMessageSent, error) {
+	if e.Status != relayer.EventStatusNew {
+		return nil, relayer.ErrMessageNotReprocessable
+	}
+
+	var event bridge.BridgeMessageSent
+
+	if err := json.Unmarshal(e.Data, &event); err != nil {
+		return nil, errors.Wrap(err, "json.Unmarshal")
+	}
+
+	messageStatus, err := svc.destBridge.GetMessageStatus(nil, event.MsgHash)
+	if err != nil {
+		return nil, errors.Wrap(err, "svc.destBridge.GetMessageStatus")
+	}
+
+	if relayer.EventStatus(messageStatus) != relayer.EventStatusNew {
+		return nil, relayer.ErrMessageNotReprocessable
+	}
+
+	return &event, nil
+}
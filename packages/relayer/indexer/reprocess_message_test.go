@@ -0,0 +1,60 @@
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ReprocessMessage(t *testing.T) {
+	svc, _ := newTestService()
+
+	tests := []struct {
+		name    string
+		status  relayer.EventStatus
+		msgHash [32]byte
+		wantErr error
+	}{
+		{
+			"success",
+			relayer.EventStatusNew,
+			mock.SuccessMsgHash,
+			nil,
+		},
+		{
+			"notNew",
+			relayer.EventStatusRetriable,
+			mock.SuccessMsgHash,
+			relayer.ErrMessageNotReprocessable,
+		},
+		{
+			"notNewOnDestination",
+			relayer.EventStatusNew,
+			mock.FailSignal,
+			relayer.ErrMessageNotReprocessable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(&bridge.BridgeMessageSent{
+				MsgHash: tt.msgHash,
+				Raw:     types.Log{Topics: []common.Hash{}, Data: []byte{}},
+			})
+			assert.Nil(t, err)
+
+			err = svc.ReprocessMessage(context.Background(), &relayer.Event{
+				Status: tt.status,
+				Data:   data,
+			})
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
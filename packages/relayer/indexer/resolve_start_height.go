@@ -0,0 +1,97 @@
+package indexer
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/pkg/errors"
+)
+
+var (
+	// StartHeightLatest starts indexing from the current head, skipping all historical messages.
+	StartHeightLatest = "latest"
+	// StartHeightDeployment starts indexing from the block the bridge was deployed in.
+	// Resolving it binary searches eth_getCode over historical state, so it requires an archive node.
+	StartHeightDeployment = "deployment"
+)
+
+// validateStartHeight checks the start height is empty, one of the named heights, or a block number
+func validateStartHeight(startHeight string) error {
+	if startHeight == "" || startHeight == StartHeightLatest || startHeight == StartHeightDeployment {
+		return nil
+	}
+
+	if _, err := strconv.ParseUint(startHeight, 10, 64); err != nil {
+		return relayer.ErrInvalidStartHeight
+	}
+
+	return nil
+}
+
+// resolveStartHeight turns the configured start height into a block number.
+// ok is false if no start height is configured.
+func (svc *Service) resolveStartHeight(ctx context.Context) (height uint64, ok bool, err error) {
+	switch svc.startHeight {
+	case "":
+		return 0, false, nil
+	case StartHeightLatest:
+		header, err := svc.ethClient.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return 0, false, errors.Wrap(err, "svc.ethClient.HeaderByNumber")
+		}
+
+		return header.Number.Uint64(), true, nil
+	case StartHeightDeployment:
+		height, err := svc.findDeploymentHeight(ctx)
+		if err != nil {
+			return 0, false, errors.Wrap(err, "svc.findDeploymentHeight")
+		}
+
+		return height, true, nil
+	default:
+		height, err := strconv.ParseUint(svc.startHeight, 10, 64)
+		if err != nil {
+			return 0, false, relayer.ErrInvalidStartHeight
+		}
+
+		return height, true, nil
+	}
+}
+
+// findDeploymentHeight binary searches for the first block the bridge has code at
+func (svc *Service) findDeploymentHeight(ctx context.Context) (uint64, error) {
+	header, err := svc.ethClient.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, errors.Wrap(err, "svc.ethClient.HeaderByNumber")
+	}
+
+	low, high := uint64(0), header.Number.Uint64()
+
+	code, err := svc.ethClient.CodeAt(ctx, svc.bridgeAddress, new(big.Int).SetUint64(high))
+	if err != nil {
+		return 0, errors.Wrap(err, "svc.ethClient.CodeAt")
+	}
+
+	if len(code) == 0 {
+		return 0, errors.Errorf("no code at bridge address %v", svc.bridgeAddress.Hex())
+	}
+
+	for low < high {
+		mid := low + (high-low)/2
+
+		code, err := svc.ethClient.CodeAt(ctx, svc.bridgeAddress, new(big.Int).SetUint64(mid))
+		if err != nil {
+			return 0, errors.Wrap(err, "svc.ethClient.CodeAt")
+		}
+
+		if len(code) > 0 {
+			high = mid
+		} else {
+			low = mid + 1
+		}
+	}
+
+	return low, nil
+}
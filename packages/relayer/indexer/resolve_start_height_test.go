@@ -0,0 +1,56 @@
+package indexer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_validateStartHeight(t *testing.T) {
+	tests := []struct {
+		startHeight string
+		wantErr     error
+	}{
+		{"", nil},
+		{StartHeightLatest, nil},
+		{StartHeightDeployment, nil},
+		{"100", nil},
+		{"-1", relayer.ErrInvalidStartHeight},
+		{"earliest", relayer.ErrInvalidStartHeight},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.startHeight, func(t *testing.T) {
+			assert.Equal(t, tt.wantErr, validateStartHeight(tt.startHeight))
+		})
+	}
+}
+
+func Test_resolveStartHeight(t *testing.T) {
+	tests := []struct {
+		name        string
+		startHeight string
+		wantHeight  uint64
+		wantOk      bool
+	}{
+		{"unset", "", 0, false},
+		{"latest", StartHeightLatest, mock.LatestBlockNumber.Uint64(), true},
+		{"deployment", StartHeightDeployment, mock.DeploymentBlockNumber.Uint64(), true},
+		{"number", "7", 7, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newTestService()
+			svc.startHeight = tt.startHeight
+
+			height, ok, err := svc.resolveStartHeight(context.Background())
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.wantHeight, height)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package indexer
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// resumeFromLastProcessedBlock indexes from the last processed block up to the head. Events
+// emitted while a dropped subscription is resubscribing, i.e. when its RPC endpoint failed and
+// it moved to another, are never delivered to the new subscription, so they're filtered for
+// instead, along with any MessageStatusChanged events the other subscription missed.
+// Only one resume runs at a time, since one already running will cover the gap.
+func (svc *Service) resumeFromLastProcessedBlock(ctx context.Context, chainID *big.Int) error {
+	if !svc.resumeMu.TryLock() {
+		return nil
+	}
+
+	defer svc.resumeMu.Unlock()
+
+	latestProcessedBlock, err := svc.blockRepo.GetLatestBlockProcessedForEvent(eventName, chainID)
+	if err != nil {
+		return errors.Wrap(err, "svc.blockRepo.GetLatestBlockProcessedForEvent")
+	}
+
+	// nothing processed yet, so there's nowhere to resume from
+	if latestProcessedBlock.Height == 0 {
+		return nil
+	}
+
+	header, err := svc.ethClient.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "svc.ethClient.HeaderByNumber")
+	}
+
+	log.Infof(
+		"chain ID %v resuming from last processed block %v to %v",
+		chainID.Uint64(),
+		latestProcessedBlock.Height,
+		header.Number.Uint64(),
+	)
+
+	svc.processingBlockHeight = latestProcessedBlock.Height
+
+	for svc.processingBlockHeight < header.Number.Uint64() {
+		end := svc.processingBlockHeight + svc.blockBatchSize
+		if end > header.Number.Uint64() {
+			end = header.Number.Uint64()
+		}
+
+		if err := svc.indexBatch(ctx, chainID, end); err != nil {
+			return errors.Wrap(err, "svc.indexBatch")
+		}
+	}
+
+	return nil
+}
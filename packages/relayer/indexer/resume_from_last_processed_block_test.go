@@ -0,0 +1,55 @@
+package indexer
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// aheadEthClient reports a head past mock.LatestBlock, as if blocks were produced
+// while a subscription was down.
+type aheadEthClient struct {
+	mock.EthClient
+	head *big.Int
+}
+
+func (c *aheadEthClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if number == nil {
+		number = c.head
+	}
+
+	return c.EthClient.HeaderByNumber(ctx, number)
+}
+
+func Test_resumeFromLastProcessedBlock(t *testing.T) {
+	svc, _ := newTestService()
+
+	head := big.NewInt(int64(mock.LatestBlock.Height) + 10)
+
+	svc.ethClient = &aheadEthClient{head: head}
+	svc.blockBatchSize = 3
+
+	assert.Nil(t, svc.resumeFromLastProcessedBlock(context.Background(), mock.MockChainID))
+	assert.Equal(t, head.Uint64(), svc.processingBlockHeight)
+}
+
+func Test_resumeFromLastProcessedBlock_noCheckpoint(t *testing.T) {
+	svc, _ := newTestService()
+
+	assert.Nil(t, svc.resumeFromLastProcessedBlock(context.Background(), mock.NoCheckpointChainID))
+	assert.Equal(t, uint64(0), svc.processingBlockHeight)
+}
+
+func Test_resumeFromLastProcessedBlock_alreadyResuming(t *testing.T) {
+	svc, _ := newTestService()
+
+	svc.resumeMu.Lock()
+	defer svc.resumeMu.Unlock()
+
+	assert.Nil(t, svc.resumeFromLastProcessedBlock(context.Background(), mock.MockChainID))
+	assert.Equal(t, uint64(0), svc.processingBlockHeight)
+}
@@ -0,0 +1,171 @@
+package indexer
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/message"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+)
+
+var (
+	// retryMessagesInterval is how often messages due to be retried are looked for
+	retryMessagesInterval = 5 * time.Second
+	// retryMessagesBatchSize is the most messages retried each interval
+	retryMessagesBatchSize = 100
+)
+
+// scheduleRetry records when e is next retried after processing it failed with processingErr, by
+// svc.retryPolicy's delay for why it failed. It isn't retried if retrying can't help.
+func (svc *Service) scheduleRetry(ctx context.Context, e *relayer.Event, processingErr error) {
+	reason, retry := message.RetryReasonFor(processingErr)
+	if !retry {
+		return
+	}
+
+	nextRetryAt, retry := svc.retryPolicy.NextRetryAt(reason, time.Now())
+	if !retry {
+		return
+	}
+
+	if nextRetryAt == nil {
+		relayer.Logger(ctx).Infof("retrying once the destination chain syncs, reason: %v", reason)
+	} else {
+		relayer.Logger(ctx).Infof("retrying at %v, reason: %v", nextRetryAt.Format(time.RFC3339), reason)
+	}
+
+	if err := svc.eventRepo.UpdateNextRetry(ctx, e.ID, reason, nextRetryAt); err != nil {
+		relayer.Logger(ctx).Errorf("svc.eventRepo.UpdateNextRetry: %v", err)
+	}
+}
+
+// retryMessagesEvery retries chainID's messages as they're due to be, checking every interval, and
+// as soon as the destination chain syncs a block, until ctx is done.
+func (svc *Service) retryMessagesEvery(ctx context.Context, chainID *big.Int, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var synced <-chan relayer.SyncedHeight
+
+	if svc.syncedHeights != nil {
+		sub := svc.syncedHeights.Subscribe(1)
+		defer sub.Unsubscribe()
+
+		synced = sub.C()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-synced:
+		}
+
+		if err := svc.retryWaitingOnSync(ctx, chainID); err != nil {
+			log.Errorf("chain ID %v svc.retryWaitingOnSync: %v", chainID, err)
+		}
+
+		if err := svc.retryDueMessages(ctx, chainID); err != nil {
+			log.Errorf("chain ID %v svc.retryDueMessages: %v", chainID, err)
+		}
+	}
+}
+
+// retryWaitingOnSync makes chainID's messages waiting for the destination chain to sync due, once
+// it has synced a block it hadn't the last time it was checked.
+func (svc *Service) retryWaitingOnSync(ctx context.Context, chainID *big.Int) error {
+	hash, err := svc.processor.LatestSyncedBlockHash(ctx)
+	if err != nil {
+		return errors.Wrap(err, "svc.processor.LatestSyncedBlockHash")
+	}
+
+	if hash == svc.lastSyncedBlockHash {
+		return nil
+	}
+
+	due, err := svc.eventRepo.RetryWaitingOnSync(ctx, chainID, time.Now())
+	if err != nil {
+		return errors.Wrap(err, "svc.eventRepo.RetryWaitingOnSync")
+	}
+
+	svc.lastSyncedBlockHash = hash
+
+	if due > 0 {
+		log.Infof("chain ID %v synced block %v, retrying %v messages waiting on it", chainID, hash.Hex(), due)
+	}
+
+	return nil
+}
+
+// retryDueMessages processes chainID's messages that are due to be retried again, from blocks the
+// destination chain has synced. Each is no longer due once it's been retried, a failure schedules
+// it again.
+func (svc *Service) retryDueMessages(ctx context.Context, chainID *big.Int) error {
+	syncedHeight, err := svc.processor.LatestSyncedHeight(ctx)
+	if err != nil {
+		return errors.Wrap(err, "svc.processor.LatestSyncedHeight")
+	}
+
+	events, err := svc.eventRepo.FindProcessable(
+		relayer.WithPrimaryReads(ctx),
+		chainID,
+		syncedHeight,
+		time.Now(),
+		retryMessagesBatchSize,
+	)
+	if err != nil {
+		return errors.Wrap(err, "svc.eventRepo.FindProcessable")
+	}
+
+	group := new(errgroup.Group)
+
+	group.SetLimit(svc.numGoroutines)
+
+	for _, e := range events {
+		e := e
+		reason := e.RetryReason
+
+		ctx := relayer.WithMessageLogger(
+			ctx,
+			common.HexToHash(e.MsgHash),
+			big.NewInt(e.ChainID),
+			big.NewInt(e.DestChainID),
+		)
+
+		if err := svc.eventRepo.UpdateNextRetry(ctx, e.ID, "", nil); err != nil {
+			relayer.Logger(ctx).Errorf("svc.eventRepo.UpdateNextRetry: %v", err)
+			continue
+		}
+
+		// processed some other way since, its MessageStatusChanged event updates it
+		event, err := svc.reprocessableMessage(e)
+		if err != nil {
+			relayer.Logger(ctx).Infof("not retrying: %v", err)
+			continue
+		}
+
+		relayer.RetriedMessages.WithLabelValues(chainID.String(), string(reason)).Inc()
+
+		// a message blocking its sender's later ones is retried in its queue
+		process := svc.messageHandler(e, event)
+
+		group.Go(func() error {
+			relayer.Logger(ctx).Infof("retrying, reason: %v", reason)
+
+			if err := process(ctx); err != nil {
+				relayer.ErrorEvents.Inc()
+				relayer.Logger(ctx).Errorf("svc.processMessage: %v", err)
+			}
+
+			return nil
+		})
+	}
+
+	return group.Wait()
+}
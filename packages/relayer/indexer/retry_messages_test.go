@@ -0,0 +1,167 @@
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/message"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// saveRetryTestEvents saves a new MessageSent event for each of nextRetryAts, returning them in order
+func saveRetryTestEvents(t *testing.T, eventRepo *mock.EventRepository, nextRetryAts ...*time.Time) []*relayer.Event {
+	data, err := json.Marshal(&bridge.BridgeMessageSent{
+		MsgHash: mock.SuccessMsgHash,
+		Message: bridge.IBridgeMessage{GasLimit: big.NewInt(1)},
+		Raw:     types.Log{Topics: []common.Hash{}, Data: []byte{}},
+	})
+	assert.Nil(t, err)
+
+	events := make([]*relayer.Event, 0, len(nextRetryAts))
+
+	for i, nextRetryAt := range nextRetryAts {
+		msgHash := fmt.Sprintf("0x%d", i)
+
+		_, err := eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+			Name:    relayer.EventNameMessageSent,
+			Data:    string(data),
+			ChainID: mock.MockChainID,
+			Status:  relayer.EventStatusNew,
+			MsgHash: msgHash,
+		})
+		assert.Nil(t, err)
+
+		e, err := eventRepo.FirstByMsgHash(context.Background(), msgHash)
+		assert.Nil(t, err)
+
+		e.RetryReason = relayer.RetryReasonTransient
+		e.NextRetryAt = nextRetryAt
+
+		events = append(events, e)
+	}
+
+	return events
+}
+
+func Test_scheduleRetry(t *testing.T) {
+	svc, _ := newTestService()
+
+	svc.retryPolicy = relayer.RetryPolicy{
+		relayer.RetryReasonNotSynced:  {UntilSynced: true},
+		relayer.RetryReasonGasTooHigh: {Delay: time.Minute},
+	}
+
+	tests := []struct {
+		name         string
+		err          error
+		wantReason   relayer.RetryReason
+		wantRetryDue bool
+	}{
+		{
+			"notSynced",
+			errors.Wrap(message.ErrMessageNotReceived, "p.ProcessMessage"),
+			relayer.RetryReasonNotSynced,
+			false,
+		},
+		{
+			"gasTooHigh",
+			errors.Wrap(relayer.ErrUnprofitable, "p.sendProcessMessageCall"),
+			relayer.RetryReasonGasTooHigh,
+			true,
+		},
+		{
+			"reasonNotInPolicy",
+			errors.New("connection refused"),
+			"",
+			false,
+		},
+		{
+			"notRetriable",
+			message.ErrOnlyOwnerCanProcess,
+			"",
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventRepo := mock.NewEventRepository()
+			svc.eventRepo = eventRepo
+
+			e := saveRetryTestEvents(t, eventRepo, nil)[0]
+			e.RetryReason = ""
+
+			svc.scheduleRetry(context.Background(), e, tt.err)
+
+			assert.Equal(t, tt.wantReason, e.RetryReason)
+			assert.Equal(t, tt.wantRetryDue, e.NextRetryAt != nil)
+
+			if tt.wantRetryDue {
+				assert.WithinDuration(t, time.Now().Add(time.Minute), *e.NextRetryAt, 5*time.Second)
+			}
+		})
+	}
+}
+
+func Test_retryWaitingOnSync(t *testing.T) {
+	svc, _ := newTestService()
+
+	eventRepo := mock.NewEventRepository()
+	svc.eventRepo = eventRepo
+
+	e := saveRetryTestEvents(t, eventRepo, nil)[0]
+	e.RetryReason = relayer.RetryReasonNotSynced
+
+	assert.Nil(t, svc.retryWaitingOnSync(context.Background(), mock.MockChainID))
+	assert.NotNil(t, e.NextRetryAt)
+	assert.Equal(t, common.Hash(mock.SuccessHeader), svc.lastSyncedBlockHash)
+
+	// nothing new synced since, so it keeps waiting
+	e.NextRetryAt = nil
+
+	assert.Nil(t, svc.retryWaitingOnSync(context.Background(), mock.MockChainID))
+	assert.Nil(t, e.NextRetryAt)
+}
+
+func Test_retryDueMessages(t *testing.T) {
+	svc, _ := newTestService()
+
+	eventRepo := mock.NewEventRepository()
+	svc.eventRepo = eventRepo
+
+	// paused, the due message is left new to drain on resume, rather than processed
+	svc.PauseProcessing()
+
+	past, future := time.Now().Add(-time.Second), time.Now().Add(time.Hour)
+
+	events := saveRetryTestEvents(t, eventRepo, &past, &future, nil, &past)
+
+	// from a block after mock.Header, the latest the destination chain synced
+	events[3].BlockNumber = mock.Header.Number.Uint64() + 1
+
+	retried := relayer.RetriedMessages.WithLabelValues(mock.MockChainID.String(), string(relayer.RetryReasonTransient))
+	before := testutil.ToFloat64(retried)
+
+	assert.Nil(t, svc.retryDueMessages(context.Background(), mock.MockChainID))
+
+	// only the due message from a synced block is retried, and no longer due
+	assert.Equal(t, events[0].ID, svc.pausedUpToID)
+	assert.Nil(t, events[0].NextRetryAt)
+	assert.Equal(t, relayer.RetryReason(""), events[0].RetryReason)
+	assert.Equal(t, before+1, testutil.ToFloat64(retried))
+
+	assert.Equal(t, &future, events[1].NextRetryAt)
+	assert.Nil(t, events[2].NextRetryAt)
+	assert.Equal(t, &past, events[3].NextRetryAt)
+}
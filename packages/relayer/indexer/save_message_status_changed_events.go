@@ -15,35 +15,46 @@ import (
 func (svc *Service) saveMessageStatusChangedEvents(
 	ctx context.Context,
 	chainID *big.Int,
-	events *bridge.BridgeMessageStatusChangedIterator,
+	events []*bridge.BridgeMessageStatusChanged,
 ) error {
-	if !events.Next() || events.Event == nil {
+	if len(events) == 0 {
 		log.Infof("no messageStatusChanged events")
 		return nil
 	}
 
-	for {
-		event := events.Event
+	for _, event := range events {
 		log.Infof("messageStatusChanged: %v", common.Hash(event.MsgHash).Hex())
 
-		if err := svc.saveMessageStatusChangedEvent(ctx, chainID, event); err != nil {
+		if _, err := svc.saveMessageStatusChangedEvent(ctx, chainID, event); err != nil {
 			return errors.Wrap(err, "svc.saveMessageStatusChangedEvent")
 		}
-
-		if !events.Next() {
-			return nil
-		}
 	}
+
+	return nil
 }
 
+// saveMessageStatusChangedEvent saves event, returning whether it did. Events for messages
+// that weren't indexed aren't saved, but every message's status changes are kept in its bridge
+// status history.
 func (svc *Service) saveMessageStatusChangedEvent(
 	ctx context.Context,
 	chainID *big.Int,
 	event *bridge.BridgeMessageStatusChanged,
-) error {
+) (bool, error) {
 	marshaled, err := json.Marshal(event)
 	if err != nil {
-		return errors.Wrap(err, "json.Marshal(event)")
+		return false, errors.Wrap(err, "json.Marshal(event)")
+	}
+
+	if err := svc.bridgeStatusChangeRepo.Save(ctx, relayer.SaveBridgeStatusChangeOpts{
+		MsgHash:     common.Hash(event.MsgHash).Hex(),
+		ChainID:     chainID,
+		Status:      relayer.EventStatus(event.Status),
+		BlockNumber: event.Raw.BlockNumber,
+		TxHash:      event.Raw.TxHash.Hex(),
+		LogIndex:    event.Raw.Index,
+	}); err != nil {
+		return false, errors.Wrap(err, "svc.bridgeStatusChangeRepo.Save")
 	}
 
 	// get the previous MessageSent event or other message status changed events,
@@ -51,14 +62,14 @@ func (svc *Service) saveMessageStatusChangedEvent(
 	// to save to the db.
 	e, err := svc.eventRepo.FirstByMsgHash(ctx, common.Hash(event.MsgHash).Hex())
 	if err != nil {
-		return errors.Wrap(err, "svc.eventRepo.FirstByMsgHash")
+		return false, errors.Wrap(err, "svc.eventRepo.FirstByMsgHash")
 	}
 
 	if e == nil || e.MsgHash == "" {
-		return nil
+		return false, nil
 	}
 
-	_, err = svc.eventRepo.Save(ctx, relayer.SaveEventOpts{
+	opts := relayer.SaveEventOpts{
 		Name:         relayer.EventNameMessageStatusChanged,
 		Data:         string(marshaled),
 		ChainID:      chainID,
@@ -66,10 +77,18 @@ func (svc *Service) saveMessageStatusChangedEvent(
 		MessageOwner: e.MessageOwner,
 		MsgHash:      common.Hash(event.MsgHash).Hex(),
 		Event:        relayer.EventNameMessageStatusChanged,
-	})
-	if err != nil {
-		return errors.Wrap(err, "svc.eventRepo.Save")
+		BlockNumber:  event.Raw.BlockNumber,
+		TxHash:       event.Raw.TxHash.Hex(),
+		LogIndex:     event.Raw.Index,
 	}
 
-	return nil
+	if _, err := svc.eventRepo.Save(ctx, opts); err != nil {
+		return false, errors.Wrap(err, "svc.eventRepo.Save")
+	}
+
+	svc.publishEvent(opts)
+
+	svc.notifyStatusChange(ctx, chainID, event, e.MessageOwner)
+
+	return true, nil
 }
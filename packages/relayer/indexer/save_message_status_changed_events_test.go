@@ -0,0 +1,60 @@
+package indexer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_saveMessageStatusChangedEvent(t *testing.T) {
+	svc, _ := newTestService()
+
+	eventRepo := mock.NewEventRepository()
+	svc.eventRepo = eventRepo
+
+	changeRepo := mock.NewBridgeStatusChangeRepository()
+	svc.bridgeStatusChangeRepo = changeRepo
+
+	indexed, notIndexed := common.Hash{0x1}, common.Hash{0x2}
+
+	_, err := eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+		Name:    relayer.EventNameMessageSent,
+		ChainID: mock.MockChainID,
+		Status:  relayer.EventStatusNew,
+		MsgHash: indexed.Hex(),
+	})
+	assert.Nil(t, err)
+
+	for i, msgHash := range []common.Hash{indexed, notIndexed} {
+		saved, err := svc.saveMessageStatusChangedEvent(context.Background(), mock.MockChainID,
+			&bridge.BridgeMessageStatusChanged{
+				MsgHash: msgHash,
+				Status:  uint8(relayer.EventStatusDone),
+				Raw: types.Log{
+					TxHash:      common.HexToHash("0x3"),
+					BlockNumber: 7,
+					Index:       uint(i),
+				},
+			})
+		assert.Nil(t, err)
+		assert.Equal(t, msgHash == indexed, saved)
+	}
+
+	// both are in the bridge's status history, indexed or not
+	for i, msgHash := range []common.Hash{indexed, notIndexed} {
+		changes, err := changeRepo.FindAllByMsgHash(context.Background(), msgHash.Hex())
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(changes))
+		assert.Equal(t, relayer.EventStatusDone, changes[0].Status)
+		assert.Equal(t, uint64(7), changes[0].BlockNumber)
+		assert.Equal(t, common.HexToHash("0x3").Hex(), changes[0].TxHash)
+		assert.Equal(t, uint(i), changes[0].LogIndex)
+		assert.Equal(t, mock.MockChainID.Int64(), changes[0].ChainID)
+	}
+}
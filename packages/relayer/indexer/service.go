@@ -4,6 +4,8 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"math/big"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
@@ -11,15 +13,15 @@ import (
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/icrosschainsync"
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/mxcl1"
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/tokenvault"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/eventbus"
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer/message"
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer/proof"
 	"github.com/cyberhorsey/errors"
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
-	"github.com/ethereum/go-ethereum/rpc"
 )
 
 var (
@@ -28,7 +30,27 @@ var (
 
 type ethClient interface {
 	ChainID(ctx context.Context) (*big.Int, error)
+	CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error)
 	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	BlockNumber(ctx context.Context) (uint64, error)
+	SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error)
+}
+
+// logFilterer is eth_getLogs
+type logFilterer interface {
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+}
+
+// Client is a chain's RPC connection, as the service and the contract bindings it makes use it,
+// satisfied by both *ethclient.Client and *failover.Client.
+type Client interface {
+	bind.ContractBackend
+	ethereum.TransactionReader
+	ChainID(ctx context.Context) (*big.Int, error)
+	BlockNumber(ctx context.Context) (uint64, error)
+	BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error)
+	HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error)
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
 	SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error)
 }
 
@@ -36,31 +58,105 @@ type Service struct {
 	eventRepo relayer.EventRepository
 	blockRepo relayer.BlockRepository
 	ethClient ethClient
-	destRPC   *rpc.Client
+	destRPC   relayer.Caller
 
-	processingBlockHeight uint64
+	destEthClient      ethClient
+	destHeaderSyncer   crossChainSyncedWatcher
+	crossChainSyncRepo relayer.CrossChainSyncRepository
+	// bridgeStatusChangeRepo mirrors the bridge's MessageStatusChanged events, by message hash
+	bridgeStatusChangeRepo relayer.BridgeStatusChangeRepository
 
-	bridge     relayer.Bridge
-	destBridge relayer.Bridge
+	processingBlockHeight uint64
+	startHeight           string
+	syncProgress          *syncProgressTracker
+	// confirmationStrategy is when blocks are final, the sync progress counts up to the latest
+	confirmationStrategy relayer.ConfirmationStrategy
+	// resumeMu is held while resuming from the last processed block after a subscription drops
+	resumeMu sync.Mutex
+
+	// maxMessageAge is how long after being sent a message still new is marked stale, never if 0
+	maxMessageAge time.Duration
+	// staleMessagesOnce starts marking stale messages once, however often indexing restarts
+	staleMessagesOnce sync.Once
+	// retryPolicy is how long messages whose processing failed wait to be retried, by why it failed
+	retryPolicy relayer.RetryPolicy
+	// retryMessagesOnce starts retrying messages once, however often indexing restarts
+	retryMessagesOnce sync.Once
+	// lastSyncedBlockHash is the latest source block the destination chain had synced when last checked
+	lastSyncedBlockHash common.Hash
+	// confirmProcessedOnce starts confirming processed messages once, however often indexing restarts
+	confirmProcessedOnce sync.Once
+	// destReorgWindow is how many of the destination chain's latest blocks are watched for reorgs, none if 0
+	destReorgWindow uint64
+	// unfinalizeReorgedOutOnce starts watching for destination reorgs once, however often indexing restarts
+	unfinalizeReorgedOutOnce sync.Once
+	// syncedHeights is where the source heights the destination chain syncs are published
+	syncedHeights *eventbus.Bus[relayer.SyncedHeight]
+
+	pausedMu sync.Mutex
+	// pausedUpToID is the highest event ID left new while processing was paused, 0 if none
+	pausedUpToID int
+
+	standbyMu sync.Mutex
+	// standby is whether another instance is the leader, see SetStandby
+	standby bool
+
+	bridge        relayer.Bridge
+	bridgeAddress common.Address
+	destBridge    relayer.Bridge
+	// logFilterer fetches the bridge's logs, with filterBridgeEvents
+	logFilterer logFilterer
+	// fetchAllLogTopics fetches every log the bridge emits, not just the events handled, for debugging
+	fetchAllLogTopics bool
+	// strictLogUnpacking fails a batch with a log that can't be unpacked, rather than skipping the log
+	strictLogUnpacking bool
+	// quarantinedLogRepo is optional, and stores the logs skipped for not unpacking
+	quarantinedLogRepo relayer.QuarantinedLogRepository
 
 	processor *message.Processor
 
+	// orderedDelivery is whether each sender's messages to a destination chain are processed
+	// one at a time, in the order they were sent
+	orderedDelivery           bool
+	senderQueuesMu            sync.Mutex
+	senderQueues              map[senderKey]*senderQueue
+	blockedSenderPollInterval time.Duration
+	// requeueMessagesOnce rebuilds the sender queues once, however often indexing restarts
+	requeueMessagesOnce sync.Once
+
+	statusChangeNotifier relayer.StatusChangeNotifier
+	eventPublisher       relayer.EventPublisher
+
 	relayerAddr common.Address
 
 	blockBatchSize      uint64
 	numGoroutines       int
 	subscriptionBackoff time.Duration
+	headPollInterval    time.Duration
+	// subscriptionHeartbeat is how long the subscriptions can go without a new head or event before
+	// they're re-established, 0 to infer it from the chain's block time
+	subscriptionHeartbeat time.Duration
+	// lastEventReceived is the unix nanoseconds the subscriptions last delivered a new head or event
+	lastEventReceived atomic.Int64
+	// eventWriteBatchSize is how many rows each insert of a batch's events has, when they're written
+	// together with its checkpoint; 0 writes them one at a time
+	eventWriteBatchSize int
 
 	mxcL1 *mxcl1.MxcL1
+
+	// destChainIDs are the destination chains whose messages are indexed, every one's if empty
+	destChainIDs map[int64]bool
 }
 
 type NewServiceOpts struct {
 	EventRepo                     relayer.EventRepository
 	BlockRepo                     relayer.BlockRepository
-	EthClient                     *ethclient.Client
-	DestEthClient                 *ethclient.Client
-	RPCClient                     *rpc.Client
-	DestRPCClient                 *rpc.Client
+	CrossChainSyncRepo            relayer.CrossChainSyncRepository
+	BridgeStatusChangeRepo        relayer.BridgeStatusChangeRepository
+	EthClient                     Client
+	DestEthClient                 Client
+	RPCClient                     relayer.Caller
+	DestRPCClient                 relayer.Caller
 	ECDSAKey                      string
 	BridgeAddress                 common.Address
 	DestBridgeAddress             common.Address
@@ -68,13 +164,114 @@ type NewServiceOpts struct {
 	DestMxcAddress                common.Address
 	DestTokenVaultAddress         common.Address
 	SrcSignalServiceAddress       common.Address
+	TreasuryAddress               common.Address
 	BlockBatchSize                uint64
 	NumGoroutines                 int
 	SubscriptionBackoff           time.Duration
+	HeadPollInterval              time.Duration
 	Confirmations                 uint64
 	ProfitableOnly                relayer.ProfitableOnly
 	HeaderSyncIntervalInSeconds   int64
 	ConfirmationsTimeoutInSeconds int64
+	ReceiptPollInterval           time.Duration
+	ReceiptTimeout                time.Duration
+	HoldTokenAmountThreshold      *big.Int
+	HoldETHAmountThreshold        *big.Int
+	MaxInFlightTxs                int
+	// ConfirmationStrategy is when source blocks are treated as final, Confirmations blocks if unset
+	ConfirmationStrategy relayer.ConfirmationStrategy
+	// ProcessorConcurrency is how many messages are processed at once, must be > 0
+	ProcessorConcurrency int
+	// MaxConcurrentProofs bounds how many signal proofs generate at once, unbounded if <= 0
+	MaxConcurrentProofs int
+	// FeeToken is the symbol of the source chain's native token, which processing fees are paid in
+	FeeToken string
+	// DestNativeToken is the symbol of the destination chain's native token, FeeToken if unset
+	DestNativeToken string
+	// PriceOracle is optional, and values fees and costs paid in different tokens in ETH
+	PriceOracle relayer.PriceOracle
+	// GasOracle is optional, and prices processMessage transactions instead of the destination
+	// node's suggested gas price
+	GasOracle relayer.GasOracle
+	// TxBuilder is optional, and sends processMessage transactions instead of calling the
+	// destination bridge directly
+	TxBuilder relayer.TxBuilder
+	// ClockDrift is optional, and corrects the time messages take to be done for the chains'
+	// clocks drifting apart
+	ClockDrift relayer.ClockDrift
+	// Blocklist is optional, and messages from or to an address on it aren't relayed
+	Blocklist relayer.Blocklist
+	// AccessLists attaches access lists, made with DestRPCClient's eth_createAccessList,
+	// to processMessage transactions they lower the gas of
+	AccessLists bool
+	// PrivateTxRelay is optional, and sends processMessage transactions privately instead of to
+	// the destination node's public mempool
+	PrivateTxRelay relayer.PrivateTxRelay
+	// StatusChangeNotifier is optional, and told about every MessageStatusChanged event
+	StatusChangeNotifier relayer.StatusChangeNotifier
+	// EventPublisher is optional, and publishes every event saved and MessageStatusChanged event
+	// to downstream consumers
+	EventPublisher relayer.EventPublisher
+	// StartHeight is where to start indexing when there is no stored checkpoint,
+	// either a block number, StartHeightLatest or StartHeightDeployment.
+	StartHeight string
+	// OrderedDelivery only processes a sender's message to a destination chain once the ones
+	// it sent there before are done, at the cost of throughput.
+	OrderedDelivery relayer.OrderedDelivery
+	// MaxMessageAge is how long after being sent a message still new is marked stale and no
+	// longer processed, never if 0
+	MaxMessageAge time.Duration
+	// EventWriteBatchSize writes the events of each batch of blocks indexed while catching up in
+	// multi-row inserts of this many rows, in the same transaction as the batch's checkpoint, rather than
+	// one at a time. 0 disables it.
+	EventWriteBatchSize int
+	// FetchAllLogTopics fetches every log the bridge emits while filtering blocks, rather than only
+	// its MessageSent and MessageStatusChanged events, and discards the rest. It's for debugging.
+	FetchAllLogTopics bool
+	// DestConfirmationsBeforeDone is how many blocks a processMessage transaction needs on top of it
+	// before its message is marked done, so a reorg on the destination chain can't undo it. Until
+	// then the message is ProcessedUnconfirmed. 0 marks it done as soon as it's mined.
+	DestConfirmationsBeforeDone uint64
+	// DestReorgWindow is how many of the destination chain's latest blocks are watched for reorgs, done
+	// messages whose processMessage transaction a reorg dropped being requeued. 0 doesn't watch.
+	DestReorgWindow uint64
+	// GasLimitFloors are optional, and are the least gas processMessage transactions to each recipient
+	// contract are sent with, for contracts eth_estimateGas underestimates
+	GasLimitFloors map[common.Address]uint64
+	// ECDSAKeyWeight is ECDSAKey's share of the messages against ExtraECDSAKeys' weights, 1 if unset
+	ECDSAKeyWeight int
+	// ExtraECDSAKeys are optional, and messages are sent from them too, each with its own nonces
+	ExtraECDSAKeys []message.RelayerKey
+	// KeySelection is how messages are spread across the keys, a weighted round robin if unset
+	KeySelection relayer.KeySelection
+	// KeyMinBalance is optional, and takes a key out of rotation while its balance on the
+	// destination chain is below it
+	KeyMinBalance *big.Int
+	// RetryPolicy is how long a message whose processing failed waits to be retried, by why it failed,
+	// relayer.DefaultRetryPolicy if nil
+	RetryPolicy relayer.RetryPolicy
+	// ProveFinalizedOnly only proves messages against source blocks the source chain has finalized,
+	// by FinalityOracle, or by the source node's finalized tag if FinalityOracle is nil
+	ProveFinalizedOnly bool
+	// FinalityOracle is optional, and is the source chain's consensus client
+	FinalityOracle relayer.FinalityOracle
+	// SyncedHeights is where the source heights the destination chain syncs are published, for other
+	// components to subscribe to, a new bus if nil
+	SyncedHeights *eventbus.Bus[relayer.SyncedHeight]
+	// SubscriptionHeartbeat is how long subscriptions can go without a new head or event before they're
+	// torn down and re-established, 0 to infer it from the chain's block time
+	SubscriptionHeartbeat time.Duration
+	// GasSpendRepo is optional, and records the gas each mined processMessage transaction cost, for
+	// the relayer's balance runway
+	GasSpendRepo relayer.GasSpendRepository
+	// DestChainIDs are optional, and only MessageSent events for messages to one of them are saved and
+	// processed, for an instance dedicated to those destinations. Empty indexes every destination's.
+	DestChainIDs []int64
+	// StrictLogUnpacking fails indexing a batch of blocks with a bridge log that can't be unpacked, until
+	// the relayer is upgraded to unpack it, rather than skipping the log and indexing the rest
+	StrictLogUnpacking bool
+	// QuarantinedLogRepo is optional, and stores the logs skipped for not unpacking, to decode them later
+	QuarantinedLogRepo relayer.QuarantinedLogRepository
 }
 
 func NewService(opts NewServiceOpts) (*Service, error) {
@@ -86,6 +283,14 @@ func NewService(opts NewServiceOpts) (*Service, error) {
 		return nil, relayer.ErrNoBlockRepository
 	}
 
+	if opts.CrossChainSyncRepo == nil {
+		return nil, relayer.ErrNoCrossChainSyncRepository
+	}
+
+	if opts.BridgeStatusChangeRepo == nil {
+		return nil, relayer.ErrNoBridgeStatusChangeRepository
+	}
+
 	if opts.EthClient == nil {
 		return nil, relayer.ErrNoEthClient
 	}
@@ -110,6 +315,14 @@ func NewService(opts NewServiceOpts) (*Service, error) {
 		return nil, relayer.ErrNoRPCClient
 	}
 
+	if opts.HeadPollInterval <= 0 {
+		return nil, relayer.ErrInvalidHeadPollInterval
+	}
+
+	if err := validateStartHeight(opts.StartHeight); err != nil {
+		return nil, err
+	}
+
 	privateKey, err := crypto.HexToECDSA(opts.ECDSAKey)
 	if err != nil {
 		return nil, errors.Wrap(err, "crypto.HexToECDSA")
@@ -139,6 +352,12 @@ func NewService(opts NewServiceOpts) (*Service, error) {
 		return nil, errors.Wrap(err, "proof.New")
 	}
 
+	prover = prover.WithMaxConcurrentProofs(opts.MaxConcurrentProofs)
+
+	if opts.ProveFinalizedOnly {
+		prover = prover.WithFinalizedOnly(opts.FinalityOracle)
+	}
+
 	destHeaderSyncer, err := icrosschainsync.NewICrossChainSync(opts.DestMxcAddress, opts.DestEthClient)
 	if err != nil {
 		return nil, errors.Wrap(err, "icrosschainsync.NewMxcL2")
@@ -157,6 +376,16 @@ func NewService(opts NewServiceOpts) (*Service, error) {
 		return nil, errors.Wrap(err, "tokenvault.NewTokenVault")
 	}
 
+	var accessListRPC relayer.Caller
+	if opts.AccessLists {
+		accessListRPC = opts.DestRPCClient
+	}
+
+	syncedHeights := opts.SyncedHeights
+	if syncedHeights == nil {
+		syncedHeights = eventbus.New[relayer.SyncedHeight]("synced_heights")
+	}
+
 	processor, err := message.NewProcessor(message.NewProcessorOpts{
 		Prover:                        prover,
 		ECDSAKey:                      privateKey,
@@ -171,29 +400,98 @@ func NewService(opts NewServiceOpts) (*Service, error) {
 		ProfitableOnly:                opts.ProfitableOnly,
 		HeaderSyncIntervalSeconds:     opts.HeaderSyncIntervalInSeconds,
 		SrcSignalServiceAddress:       opts.SrcSignalServiceAddress,
+		TreasuryAddress:               opts.TreasuryAddress,
 		ConfirmationsTimeoutInSeconds: opts.ConfirmationsTimeoutInSeconds,
+		ConfirmationStrategy:          opts.ConfirmationStrategy,
 		DestTokenVault:                destTokenVault,
+		ReceiptPollInterval:           opts.ReceiptPollInterval,
+		ReceiptTimeout:                opts.ReceiptTimeout,
+		CrossChainSyncRepo:            opts.CrossChainSyncRepo,
+		HoldTokenAmountThreshold:      opts.HoldTokenAmountThreshold,
+		HoldETHAmountThreshold:        opts.HoldETHAmountThreshold,
+		MaxInFlightTxs:                opts.MaxInFlightTxs,
+		Concurrency:                   opts.ProcessorConcurrency,
+		FeeToken:                      opts.FeeToken,
+		DestNativeToken:               opts.DestNativeToken,
+		PriceOracle:                   opts.PriceOracle,
+		GasOracle:                     opts.GasOracle,
+		TxBuilder:                     opts.TxBuilder,
+		ClockDrift:                    opts.ClockDrift,
+		Blocklist:                     opts.Blocklist,
+		AccessListRPC:                 accessListRPC,
+		PrivateTxRelay:                opts.PrivateTxRelay,
+		DestConfirmationsBeforeDone:   opts.DestConfirmationsBeforeDone,
+		DestReorgWindow:               opts.DestReorgWindow,
+		GasLimitFloors:                opts.GasLimitFloors,
+		SyncedHeights:                 syncedHeights,
+		ECDSAKeyWeight:                opts.ECDSAKeyWeight,
+		ExtraKeys:                     opts.ExtraECDSAKeys,
+		KeySelection:                  opts.KeySelection,
+		KeyMinBalance:                 opts.KeyMinBalance,
+		GasSpendRepo:                  opts.GasSpendRepo,
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "message.NewProcessor")
 	}
 
+	retryPolicy := opts.RetryPolicy
+	if retryPolicy == nil {
+		retryPolicy = relayer.DefaultRetryPolicy
+	}
+
+	destChainIDs := make(map[int64]bool, len(opts.DestChainIDs))
+	for _, id := range opts.DestChainIDs {
+		destChainIDs[id] = true
+	}
+
 	return &Service{
 		blockRepo: opts.BlockRepo,
 		eventRepo: opts.EventRepo,
 		ethClient: opts.EthClient,
 		destRPC:   opts.DestRPCClient,
 
-		bridge:     srcBridge,
-		destBridge: destBridge,
-		mxcL1:      mxcL1,
+		destEthClient:      opts.DestEthClient,
+		destHeaderSyncer:   destHeaderSyncer,
+		crossChainSyncRepo: opts.CrossChainSyncRepo,
+
+		bridgeStatusChangeRepo: opts.BridgeStatusChangeRepo,
+
+		bridge:        srcBridge,
+		bridgeAddress: opts.BridgeAddress,
+		destBridge:    destBridge,
+
+		logFilterer:        opts.EthClient,
+		fetchAllLogTopics:  opts.FetchAllLogTopics,
+		strictLogUnpacking: opts.StrictLogUnpacking,
+		quarantinedLogRepo: opts.QuarantinedLogRepo,
+		mxcL1:              mxcL1,
+
+		startHeight:          opts.StartHeight,
+		maxMessageAge:        opts.MaxMessageAge,
+		destReorgWindow:      opts.DestReorgWindow,
+		syncedHeights:        syncedHeights,
+		retryPolicy:          retryPolicy,
+		syncProgress:         newSyncProgressTracker(opts.Confirmations),
+		confirmationStrategy: opts.ConfirmationStrategy,
 
 		processor: processor,
 
+		orderedDelivery:           bool(opts.OrderedDelivery),
+		blockedSenderPollInterval: defaultBlockedSenderPollInterval,
+
+		statusChangeNotifier: opts.StatusChangeNotifier,
+		eventPublisher:       opts.EventPublisher,
+
 		relayerAddr: relayerAddr,
 
 		blockBatchSize:      opts.BlockBatchSize,
 		numGoroutines:       opts.NumGoroutines,
 		subscriptionBackoff: opts.SubscriptionBackoff,
+		headPollInterval:    opts.HeadPollInterval,
+		eventWriteBatchSize: opts.EventWriteBatchSize,
+
+		subscriptionHeartbeat: opts.SubscriptionHeartbeat,
+
+		destChainIDs: destChainIDs,
 	}, nil
 }
@@ -3,6 +3,7 @@ package indexer
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer/message"
@@ -26,11 +27,12 @@ func newTestService() (*Service, relayer.Bridge) {
 
 	prover, _ := proof.New(
 		&mock.Blocker{},
-		&rpc.Client{},
+		&mock.Caller{},
 	)
 
 	processor, _ := message.NewProcessor(message.NewProcessorOpts{
 		EventRepo:                     &mock.EventRepository{},
+		CrossChainSyncRepo:            mock.NewCrossChainSyncRepository(),
 		DestBridge:                    &mock.Bridge{},
 		SrcETHClient:                  &mock.EthClient{},
 		DestETHClient:                 &mock.EthClient{},
@@ -39,7 +41,12 @@ func newTestService() (*Service, relayer.Bridge) {
 		DestHeaderSyncer:              &mock.HeaderSyncer{},
 		Prover:                        prover,
 		RPCClient:                     &mock.Caller{},
+		Confirmations:                 1,
 		ConfirmationsTimeoutInSeconds: 900,
+		ReceiptPollInterval:           time.Second,
+		ReceiptTimeout:                time.Minute,
+		MaxInFlightTxs:                8,
+		Concurrency:                   4,
 	})
 
 	return &Service{
@@ -47,12 +54,20 @@ func newTestService() (*Service, relayer.Bridge) {
 		eventRepo:     &mock.EventRepository{},
 		bridge:        b,
 		destBridge:    b,
+		logFilterer:   b,
 		ethClient:     &mock.EthClient{},
 		numGoroutines: 10,
 
+		crossChainSyncRepo:     mock.NewCrossChainSyncRepository(),
+		bridgeStatusChangeRepo: mock.NewBridgeStatusChangeRepository(),
+		destHeaderSyncer:       &mock.HeaderSyncer{},
+		destEthClient:          &mock.EthClient{},
+
 		processingBlockHeight: 0,
+		syncProgress:          newSyncProgressTracker(1),
 		processor:             processor,
 		blockBatchSize:        100,
+		headPollInterval:      time.Second,
 	}, b
 }
 
@@ -67,6 +82,8 @@ func Test_NewService(t *testing.T) {
 			NewServiceOpts{
 				EventRepo:                     &repo.EventRepository{},
 				BlockRepo:                     &repo.BlockRepository{},
+				CrossChainSyncRepo:            &repo.CrossChainSyncRepository{},
+				BridgeStatusChangeRepo:        &repo.BridgeStatusChangeRepository{},
 				RPCClient:                     &rpc.Client{},
 				EthClient:                     &ethclient.Client{},
 				DestEthClient:                 &ethclient.Client{},
@@ -75,6 +92,11 @@ func Test_NewService(t *testing.T) {
 				DestBridgeAddress:             common.HexToAddress(dummyAddress),
 				Confirmations:                 1,
 				ConfirmationsTimeoutInSeconds: 900,
+				ReceiptPollInterval:           time.Second,
+				ReceiptTimeout:                time.Minute,
+				MaxInFlightTxs:                8,
+				ProcessorConcurrency:          4,
+				HeadPollInterval:              time.Second,
 			},
 			nil,
 		},
@@ -83,6 +105,8 @@ func Test_NewService(t *testing.T) {
 			NewServiceOpts{
 				EventRepo:                     &repo.EventRepository{},
 				BlockRepo:                     &repo.BlockRepository{},
+				CrossChainSyncRepo:            &repo.CrossChainSyncRepository{},
+				BridgeStatusChangeRepo:        &repo.BridgeStatusChangeRepository{},
 				RPCClient:                     &rpc.Client{},
 				EthClient:                     &ethclient.Client{},
 				DestEthClient:                 &ethclient.Client{},
@@ -91,6 +115,7 @@ func Test_NewService(t *testing.T) {
 				DestBridgeAddress:             common.HexToAddress(dummyAddress),
 				Confirmations:                 1,
 				ConfirmationsTimeoutInSeconds: 900,
+				HeadPollInterval:              time.Second,
 			},
 			errors.New("crypto.HexToECDSA: invalid hex character '>' in private key"),
 		},
@@ -99,6 +124,8 @@ func Test_NewService(t *testing.T) {
 			NewServiceOpts{
 				EventRepo:                     &repo.EventRepository{},
 				BlockRepo:                     &repo.BlockRepository{},
+				CrossChainSyncRepo:            &repo.CrossChainSyncRepository{},
+				BridgeStatusChangeRepo:        &repo.BridgeStatusChangeRepository{},
 				EthClient:                     &ethclient.Client{},
 				DestEthClient:                 &ethclient.Client{},
 				ECDSAKey:                      dummyEcdsaKey,
@@ -114,6 +141,8 @@ func Test_NewService(t *testing.T) {
 			NewServiceOpts{
 				EventRepo:                     &repo.EventRepository{},
 				BlockRepo:                     &repo.BlockRepository{},
+				CrossChainSyncRepo:            &repo.CrossChainSyncRepository{},
+				BridgeStatusChangeRepo:        &repo.BridgeStatusChangeRepository{},
 				EthClient:                     &ethclient.Client{},
 				DestEthClient:                 &ethclient.Client{},
 				ECDSAKey:                      dummyEcdsaKey,
@@ -129,6 +158,8 @@ func Test_NewService(t *testing.T) {
 			NewServiceOpts{
 				EventRepo:                     &repo.EventRepository{},
 				BlockRepo:                     &repo.BlockRepository{},
+				CrossChainSyncRepo:            &repo.CrossChainSyncRepository{},
+				BridgeStatusChangeRepo:        &repo.BridgeStatusChangeRepository{},
 				EthClient:                     &ethclient.Client{},
 				DestEthClient:                 &ethclient.Client{},
 				ECDSAKey:                      dummyEcdsaKey,
@@ -144,6 +175,8 @@ func Test_NewService(t *testing.T) {
 			NewServiceOpts{
 				EventRepo:                     &repo.EventRepository{},
 				BlockRepo:                     &repo.BlockRepository{},
+				CrossChainSyncRepo:            &repo.CrossChainSyncRepository{},
+				BridgeStatusChangeRepo:        &repo.BridgeStatusChangeRepository{},
 				RPCClient:                     &rpc.Client{},
 				EthClient:                     &ethclient.Client{},
 				DestEthClient:                 &ethclient.Client{},
@@ -158,6 +191,8 @@ func Test_NewService(t *testing.T) {
 			"noEventRepo",
 			NewServiceOpts{
 				BlockRepo:                     &repo.BlockRepository{},
+				CrossChainSyncRepo:            &repo.CrossChainSyncRepository{},
+				BridgeStatusChangeRepo:        &repo.BridgeStatusChangeRepository{},
 				EthClient:                     &ethclient.Client{},
 				ECDSAKey:                      dummyEcdsaKey,
 				DestEthClient:                 &ethclient.Client{},
@@ -169,6 +204,39 @@ func Test_NewService(t *testing.T) {
 			},
 			relayer.ErrNoEventRepository,
 		},
+		{
+			"noCrossChainSyncRepo",
+			NewServiceOpts{
+				EventRepo:                     &repo.EventRepository{},
+				BlockRepo:                     &repo.BlockRepository{},
+				EthClient:                     &ethclient.Client{},
+				ECDSAKey:                      dummyEcdsaKey,
+				RPCClient:                     &rpc.Client{},
+				DestEthClient:                 &ethclient.Client{},
+				BridgeAddress:                 common.HexToAddress(dummyAddress),
+				DestBridgeAddress:             common.HexToAddress(dummyAddress),
+				Confirmations:                 1,
+				ConfirmationsTimeoutInSeconds: 900,
+			},
+			relayer.ErrNoCrossChainSyncRepository,
+		},
+		{
+			"noBridgeStatusChangeRepo",
+			NewServiceOpts{
+				EventRepo:                     &repo.EventRepository{},
+				BlockRepo:                     &repo.BlockRepository{},
+				CrossChainSyncRepo:            &repo.CrossChainSyncRepository{},
+				EthClient:                     &ethclient.Client{},
+				ECDSAKey:                      dummyEcdsaKey,
+				RPCClient:                     &rpc.Client{},
+				DestEthClient:                 &ethclient.Client{},
+				BridgeAddress:                 common.HexToAddress(dummyAddress),
+				DestBridgeAddress:             common.HexToAddress(dummyAddress),
+				Confirmations:                 1,
+				ConfirmationsTimeoutInSeconds: 900,
+			},
+			relayer.ErrNoBridgeStatusChangeRepository,
+		},
 		{
 			"noBlockRepo",
 			NewServiceOpts{
@@ -189,6 +257,8 @@ func Test_NewService(t *testing.T) {
 			NewServiceOpts{
 				EventRepo:                     &repo.EventRepository{},
 				BlockRepo:                     &repo.BlockRepository{},
+				CrossChainSyncRepo:            &repo.CrossChainSyncRepository{},
+				BridgeStatusChangeRepo:        &repo.BridgeStatusChangeRepository{},
 				ECDSAKey:                      dummyEcdsaKey,
 				RPCClient:                     &rpc.Client{},
 				DestEthClient:                 &ethclient.Client{},
@@ -204,6 +274,8 @@ func Test_NewService(t *testing.T) {
 			NewServiceOpts{
 				EventRepo:                     &repo.EventRepository{},
 				BlockRepo:                     &repo.BlockRepository{},
+				CrossChainSyncRepo:            &repo.CrossChainSyncRepository{},
+				BridgeStatusChangeRepo:        &repo.BridgeStatusChangeRepository{},
 				ECDSAKey:                      dummyEcdsaKey,
 				EthClient:                     &ethclient.Client{},
 				RPCClient:                     &rpc.Client{},
@@ -214,6 +286,44 @@ func Test_NewService(t *testing.T) {
 			},
 			relayer.ErrNoEthClient,
 		},
+		{
+			"invalidStartHeight",
+			NewServiceOpts{
+				EventRepo:                     &repo.EventRepository{},
+				BlockRepo:                     &repo.BlockRepository{},
+				CrossChainSyncRepo:            &repo.CrossChainSyncRepository{},
+				BridgeStatusChangeRepo:        &repo.BridgeStatusChangeRepository{},
+				ECDSAKey:                      dummyEcdsaKey,
+				EthClient:                     &ethclient.Client{},
+				DestEthClient:                 &ethclient.Client{},
+				RPCClient:                     &rpc.Client{},
+				BridgeAddress:                 common.HexToAddress(dummyAddress),
+				DestBridgeAddress:             common.HexToAddress(dummyAddress),
+				Confirmations:                 1,
+				ConfirmationsTimeoutInSeconds: 900,
+				HeadPollInterval:              time.Second,
+				StartHeight:                   "earliest",
+			},
+			relayer.ErrInvalidStartHeight,
+		},
+		{
+			"invalidHeadPollInterval",
+			NewServiceOpts{
+				EventRepo:                     &repo.EventRepository{},
+				BlockRepo:                     &repo.BlockRepository{},
+				CrossChainSyncRepo:            &repo.CrossChainSyncRepository{},
+				BridgeStatusChangeRepo:        &repo.BridgeStatusChangeRepository{},
+				ECDSAKey:                      dummyEcdsaKey,
+				EthClient:                     &ethclient.Client{},
+				DestEthClient:                 &ethclient.Client{},
+				RPCClient:                     &rpc.Client{},
+				BridgeAddress:                 common.HexToAddress(dummyAddress),
+				DestBridgeAddress:             common.HexToAddress(dummyAddress),
+				Confirmations:                 1,
+				ConfirmationsTimeoutInSeconds: 900,
+			},
+			relayer.ErrInvalidHeadPollInterval,
+		},
 	}
 
 	for _, tt := range tests {
@@ -6,24 +6,25 @@ import (
 
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
 	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
 )
 
+// where the block indexing starts from came from, for logging
+const (
+	startHeightFromCheckpoint = "checkpoint"
+	startHeightFromConfig     = "config"
+	startHeightFromDerived    = "derived from the latest indexed event"
+	startHeightFromGenesis    = "genesis"
+)
+
+// setInitialProcessingBlockByMode picks the block to start indexing from. A stored checkpoint
+// always wins in sync mode, and without one it's recovered from the latest event indexed,
+// otherwise the configured start height is used, falling back to the genesis height.
 func (svc *Service) setInitialProcessingBlockByMode(
 	ctx context.Context,
 	mode relayer.Mode,
 	chainID *big.Int,
 ) error {
-	var startingBlock uint64 = 0
-
-	if svc.mxcL1 != nil {
-		stateVars, err := svc.mxcL1.GetStateVariables(nil)
-		if err != nil {
-			return errors.Wrap(err, "svc.mxcL1.GetStateVariables")
-		}
-
-		startingBlock = stateVars.GenesisHeight
-	}
-
 	switch mode {
 	case relayer.SyncMode:
 		// get most recently processed block height from the DB
@@ -35,17 +36,62 @@ func (svc *Service) setInitialProcessingBlockByMode(
 			return errors.Wrap(err, "svc.blockRepo.GetLatestBlock()")
 		}
 
-		if latestProcessedBlock.Height != 0 {
-			startingBlock = latestProcessedBlock.Height
+		if latestProcessedBlock.Height != 0 && latestProcessedBlock.Hash != "" {
+			svc.startProcessingAt(chainID, latestProcessedBlock.Height, startHeightFromCheckpoint)
+
+			return nil
+		}
+
+		recovered, ok, err := svc.recoverCheckpoint(ctx, chainID)
+		if err != nil {
+			return errors.Wrap(err, "svc.recoverCheckpoint")
 		}
 
-		svc.processingBlockHeight = startingBlock
+		if ok {
+			svc.startProcessingAt(chainID, recovered, startHeightFromDerived)
 
-		return nil
+			return nil
+		}
 	case relayer.ResyncMode:
-		svc.processingBlockHeight = startingBlock
-		return nil
 	default:
 		return relayer.ErrInvalidMode
 	}
+
+	startingBlock, source, err := svc.configuredStartingBlock(ctx)
+	if err != nil {
+		return err
+	}
+
+	svc.startProcessingAt(chainID, startingBlock, source)
+
+	return nil
+}
+
+// configuredStartingBlock is the configured start height, or the genesis height if there isn't one
+func (svc *Service) configuredStartingBlock(ctx context.Context) (uint64, string, error) {
+	startHeight, ok, err := svc.resolveStartHeight(ctx)
+	if err != nil {
+		return 0, "", errors.Wrap(err, "svc.resolveStartHeight")
+	}
+
+	if ok {
+		return startHeight, startHeightFromConfig, nil
+	}
+
+	if svc.mxcL1 == nil {
+		return 0, startHeightFromGenesis, nil
+	}
+
+	stateVars, err := svc.mxcL1.GetStateVariables(nil)
+	if err != nil {
+		return 0, "", errors.Wrap(err, "svc.mxcL1.GetStateVariables")
+	}
+
+	return stateVars.GenesisHeight, startHeightFromGenesis, nil
+}
+
+func (svc *Service) startProcessingAt(chainID *big.Int, height uint64, source string) {
+	log.Infof("chain ID %v indexing from block %v, start height source: %v", chainID.Uint64(), height, source)
+
+	svc.processingBlockHeight = height
 }
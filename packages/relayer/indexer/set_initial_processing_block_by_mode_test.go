@@ -12,16 +12,18 @@ import (
 
 func Test_SetInitialProcessingBlockByMode(t *testing.T) {
 	tests := []struct {
-		name       string
-		mode       relayer.Mode
-		chainID    *big.Int
-		wantErr    bool
-		wantHeight uint64
+		name        string
+		mode        relayer.Mode
+		chainID     *big.Int
+		startHeight string
+		wantErr     bool
+		wantHeight  uint64
 	}{
 		{
 			"resync",
 			relayer.ResyncMode,
 			mock.MockChainID,
+			"",
 			false,
 			0,
 		},
@@ -29,6 +31,7 @@ func Test_SetInitialProcessingBlockByMode(t *testing.T) {
 			"sync",
 			relayer.SyncMode,
 			mock.MockChainID,
+			"",
 			false,
 			mock.LatestBlock.Height,
 		},
@@ -36,6 +39,7 @@ func Test_SetInitialProcessingBlockByMode(t *testing.T) {
 			"sync error getting latest block",
 			relayer.SyncMode,
 			big.NewInt(328938),
+			"",
 			true,
 			0,
 		},
@@ -43,14 +47,40 @@ func Test_SetInitialProcessingBlockByMode(t *testing.T) {
 			"invalidMode",
 			relayer.Mode("fake"),
 			mock.MockChainID,
+			"",
 			true,
 			0,
 		},
+		{
+			"syncCheckpointIgnoresStartHeight",
+			relayer.SyncMode,
+			mock.MockChainID,
+			"latest",
+			false,
+			mock.LatestBlock.Height,
+		},
+		{
+			"syncNoCheckpointUsesStartHeight",
+			relayer.SyncMode,
+			mock.NoCheckpointChainID,
+			"latest",
+			false,
+			mock.LatestBlockNumber.Uint64(),
+		},
+		{
+			"resyncUsesStartHeight",
+			relayer.ResyncMode,
+			mock.MockChainID,
+			"deployment",
+			false,
+			mock.DeploymentBlockNumber.Uint64(),
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			svc, _ := newTestService()
+			svc.startHeight = tt.startHeight
 			err := svc.setInitialProcessingBlockByMode(
 				context.Background(),
 				tt.mode,
@@ -0,0 +1,63 @@
+package indexer
+
+import (
+	"context"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// SetStandby puts the service on standby while another instance is the leader, indexing
+// but leaving messages new for the leader to send, or promotes it. Demotion stops sending
+// straight away. Promotion first resolves the transactions the previous leader left
+// PendingSent, in the background, then sends again and processes the messages left new.
+func (svc *Service) SetStandby(standby bool) {
+	svc.standbyMu.Lock()
+	svc.standby = standby
+	svc.standbyMu.Unlock()
+
+	if standby {
+		svc.processor.SetStandby(true)
+		return
+	}
+
+	go func() {
+		if err := svc.promote(relayer.WithPrimaryReads(context.Background())); err != nil {
+			log.Errorf("svc.promote: %v", err)
+		}
+	}()
+}
+
+// Standby reports whether the service is on standby, see SetStandby
+func (svc *Service) Standby() bool {
+	svc.standbyMu.Lock()
+	defer svc.standbyMu.Unlock()
+
+	return svc.standby
+}
+
+func (svc *Service) promote(ctx context.Context) error {
+	chainID, err := svc.ethClient.ChainID(ctx)
+	if err != nil {
+		return errors.Wrap(err, "svc.ethClient.ChainID")
+	}
+
+	// failing to would leave no instance sending, so it's only logged; at worst a message
+	// whose transaction is still pending is sent again, and reverts as already processed
+	if err := svc.processor.ReconcilePendingSent(ctx, chainID); err != nil {
+		log.Errorf("svc.processor.ReconcilePendingSent: %v", err)
+	}
+
+	svc.standbyMu.Lock()
+	// demoted again while reconciling
+	if svc.standby {
+		svc.standbyMu.Unlock()
+		return nil
+	}
+
+	svc.processor.SetStandby(false)
+	svc.standbyMu.Unlock()
+
+	return svc.drainPausedMessages(ctx)
+}
@@ -0,0 +1,45 @@
+package indexer
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SetStandby(t *testing.T) {
+	svc, _ := newTestService()
+	svc.eventRepo = mock.NewEventRepository()
+
+	svc.SetStandby(true)
+	assert.True(t, svc.Standby())
+	// on standby, not paused
+	assert.False(t, svc.ProcessingPaused())
+
+	event := &bridge.BridgeMessageSent{Message: bridge.IBridgeMessage{GasLimit: big.NewInt(1)}}
+
+	assert.Nil(t, svc.processMessage(context.Background(), event, &relayer.Event{ID: 5}))
+	assert.Equal(t, 5, svc.pausedUpToID)
+
+	svc.standby = false
+	assert.Nil(t, svc.promote(context.Background()))
+	assert.False(t, svc.processor.Standby())
+	assert.Equal(t, 0, svc.pausedUpToID)
+}
+
+func Test_promote_demotedWhileReconciling(t *testing.T) {
+	svc, _ := newTestService()
+	svc.eventRepo = mock.NewEventRepository()
+
+	svc.SetStandby(true)
+	svc.pausedUpToID = 5
+
+	// demoted again before the promotion got to send
+	assert.Nil(t, svc.promote(context.Background()))
+	assert.True(t, svc.processor.Standby())
+	assert.Equal(t, 5, svc.pausedUpToID)
+}
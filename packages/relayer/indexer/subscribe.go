@@ -3,36 +3,107 @@ package indexer
 import (
 	"context"
 	"math/big"
+	"time"
 
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 )
 
-// subscribe subscribes to latest events
+// subscribe subscribes to latest events. A subscription can go silent without erroring, the
+// connection staying open but delivering nothing, so when no new head or event arrives within
+// the heartbeat they're all torn down and re-established, resuming from the last processed block.
 func (svc *Service) subscribe(ctx context.Context, chainID *big.Int) error {
 	log.Info("subscribing to new events")
 
-	errChan := make(chan error)
+	svc.syncProgress.markCaughtUp()
 
-	go svc.subscribeMessageSent(ctx, chainID, errChan)
+	heartbeat := svc.heartbeat(ctx)
 
-	go svc.subscribeMessageStatusChanged(ctx, chainID, errChan)
+	resume := false
+
+	for {
+		stalled, err := svc.subscribeUntilStalled(ctx, chainID, heartbeat, resume)
+		if !stalled {
+			return err
+		}
+
+		relayer.SubscriptionStalls.WithLabelValues(chainID.String()).Inc()
+
+		log.Warnf("chain ID %v subscriptions silent for %v, re-establishing them", chainID.Uint64(), heartbeat)
+
+		resume = true
+	}
+}
+
+// subscribeUntilStalled subscribes to latest events until ctx is done, a subscription fails, or
+// nothing has arrived for heartbeat, when it reports the subscriptions stalled. With resume, the
+// blocks missed since the last processed one are filtered for once subscribed again.
+func (svc *Service) subscribeUntilStalled(
+	ctx context.Context,
+	chainID *big.Int,
+	heartbeat time.Duration,
+	resume bool,
+) (bool, error) {
+	// cancelled to tear the subscriptions down when they stall
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// buffered so subscriptions failing as they're torn down don't block
+	errChan := make(chan error, 4)
+
+	heads := make(chan *types.Header)
+
+	headSub, err := svc.ethClient.SubscribeNewHead(subCtx, heads)
+	if err != nil {
+		return false, errors.Wrap(err, "svc.ethClient.SubscribeNewHead")
+	}
+
+	defer headSub.Unsubscribe()
+
+	go svc.subscribeMessageSent(subCtx, chainID, errChan)
+
+	go svc.subscribeMessageStatusChanged(subCtx, chainID, errChan)
+
+	go svc.subscribeCrossChainSynced(subCtx, errChan)
+
+	if resume {
+		go func() {
+			if err := svc.resumeFromLastProcessedBlock(ctx, chainID); err != nil {
+				log.Errorf("svc.subscribe, svc.resumeFromLastProcessedBlock: %v", err)
+			}
+		}()
+	}
+
+	svc.receivedEvent(chainID)
+
+	ticker := time.NewTicker(heartbeat / heartbeatChecks)
+	defer ticker.Stop()
 
-	// nolint: gosimple
 	for {
 		select {
 		case <-ctx.Done():
 			log.Info("context finished")
-			return nil
+			return false, nil
 		case err := <-errChan:
 			relayer.ErrorsEncounteredDuringSubscription.Inc()
 
-			return errors.Wrap(err, "errChan")
+			return false, errors.Wrap(err, "errChan")
+		case err := <-headSub.Err():
+			log.Errorf("chain ID %v new head subscription: %v", chainID.Uint64(), err)
+
+			return true, nil
+		case <-heads:
+			svc.receivedEvent(chainID)
+		case <-ticker.C:
+			if svc.sinceLastEvent() >= heartbeat {
+				return true, nil
+			}
 		}
 	}
 }
@@ -47,9 +118,21 @@ func (svc *Service) subscribeMessageSent(ctx context.Context, chainID *big.Int,
 
 		log.Info("resubscribing to WatchMessageSent events")
 
-		return svc.bridge.WatchMessageSent(&bind.WatchOpts{
+		sub, subErr := svc.bridge.WatchMessageSent(&bind.WatchOpts{
 			Context: ctx,
 		}, sink, nil)
+
+		// the dropped subscription missed whatever was sent until now, which the new one
+		// won't deliver, so fill the gap from the last processed block.
+		if err != nil && subErr == nil {
+			go func() {
+				if err := svc.resumeFromLastProcessedBlock(ctx, chainID); err != nil {
+					log.Errorf("svc.subscribe, svc.resumeFromLastProcessedBlock: %v", err)
+				}
+			}()
+		}
+
+		return sub, subErr
 	})
 
 	defer sub.Unsubscribe()
@@ -62,9 +145,13 @@ func (svc *Service) subscribeMessageSent(ctx context.Context, chainID *big.Int,
 		case err := <-sub.Err():
 			errChan <- errors.Wrap(err, "sub.Err()")
 		case event := <-sink:
+			svc.receivedEvent(chainID)
+
+			handle := svc.eventHandler(chainID, event)
+
 			go func() {
 				log.Infof("new message sent event %v from chainID %v", common.Hash(event.MsgHash).Hex(), chainID.String())
-				err := svc.handleEvent(ctx, chainID, event)
+				err := handle(ctx)
 
 				if err != nil {
 					log.Errorf("svc.subscribe, svc.handleEvent: %v", err)
@@ -120,9 +207,11 @@ func (svc *Service) subscribeMessageStatusChanged(ctx context.Context, chainID *
 		case err := <-sub.Err():
 			errChan <- errors.Wrap(err, "sub.Err()")
 		case event := <-sink:
+			svc.receivedEvent(chainID)
+
 			log.Infof("new message status changed event %v from chainID %v", common.Hash(event.MsgHash).Hex(), chainID.String())
 
-			if err := svc.saveMessageStatusChangedEvent(ctx, chainID, event); err != nil {
+			if _, err := svc.saveMessageStatusChangedEvent(ctx, chainID, event); err != nil {
 				log.Errorf("svc.subscribe, svc.saveMessageStatusChangedEvent: %v", err)
 			}
 		}
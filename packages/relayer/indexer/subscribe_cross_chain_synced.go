@@ -0,0 +1,103 @@
+package indexer
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/icrosschainsync"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+type crossChainSyncedWatcher interface {
+	WatchCrossChainSynced(
+		opts *bind.WatchOpts,
+		sink chan<- *icrosschainsync.ICrossChainSyncCrossChainSynced,
+		srcHeight []*big.Int,
+	) (event.Subscription, error)
+}
+
+// subscribeCrossChainSynced mirrors CrossChainSynced events emitted on the destination chain
+// into the CrossChainSyncRepository, so we have a queryable history of which
+// source heights have been synced, and when, and publishes them to svc.syncedHeights.
+func (svc *Service) subscribeCrossChainSynced(ctx context.Context, errChan chan error) {
+	destChainID, err := svc.destEthClient.ChainID(ctx)
+	if err != nil {
+		errChan <- errors.Wrap(err, "svc.destEthClient.ChainID")
+		return
+	}
+
+	sink := make(chan *icrosschainsync.ICrossChainSyncCrossChainSynced)
+
+	sub := event.ResubscribeErr(svc.subscriptionBackoff, func(ctx context.Context, err error) (event.Subscription, error) {
+		if err != nil {
+			log.Errorf("svc.destHeaderSyncer.WatchCrossChainSynced: %v", err)
+		}
+
+		log.Info("resubscribing to WatchCrossChainSynced events")
+
+		return svc.destHeaderSyncer.WatchCrossChainSynced(&bind.WatchOpts{
+			Context: ctx,
+		}, sink, nil)
+	})
+
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("context finished")
+			return
+		case err := <-sub.Err():
+			errChan <- errors.Wrap(err, "sub.Err()")
+		case event := <-sink:
+			log.Infof("new cross chain synced event, srcHeight %v on chainID %v", event.SrcHeight, destChainID.String())
+
+			if err := svc.saveCrossChainSynced(ctx, destChainID, event); err != nil {
+				log.Errorf("svc.subscribe, svc.saveCrossChainSynced: %v", err)
+			}
+		}
+	}
+}
+
+func (svc *Service) saveCrossChainSynced(
+	ctx context.Context,
+	destChainID *big.Int,
+	event *icrosschainsync.ICrossChainSyncCrossChainSynced,
+) error {
+	if event.Raw.Removed {
+		log.Warnf("cross chain synced event for srcHeight %v was removed", event.SrcHeight)
+		return nil
+	}
+
+	header, err := svc.destEthClient.HeaderByNumber(ctx, new(big.Int).SetUint64(event.Raw.BlockNumber))
+	if err != nil {
+		return errors.Wrap(err, "svc.destEthClient.HeaderByNumber")
+	}
+
+	if err := svc.crossChainSyncRepo.Save(ctx, relayer.SaveCrossChainSyncOpts{
+		ChainID:    destChainID,
+		SrcHeight:  event.SrcHeight.Uint64(),
+		BlockHash:  common.Hash(event.BlockHash),
+		SignalRoot: common.Hash(event.SignalRoot),
+		SyncedAt:   time.Unix(int64(header.Time), 0),
+		DestTxHash: event.Raw.TxHash,
+	}); err != nil {
+		return errors.Wrap(err, "svc.crossChainSyncRepo.Save")
+	}
+
+	if svc.syncedHeights != nil {
+		svc.syncedHeights.Publish(relayer.SyncedHeight{
+			ChainID:   destChainID,
+			SrcHeight: event.SrcHeight.Uint64(),
+			BlockHash: common.Hash(event.BlockHash),
+		})
+	}
+
+	return nil
+}
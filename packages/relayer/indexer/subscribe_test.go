@@ -23,4 +23,8 @@ func Test_subscribe(t *testing.T) {
 	assert.Equal(t, 1, b.MessagesSent)
 	assert.Equal(t, 1, b.MessageStatusesChanged)
 	assert.Equal(t, 2, b.ErrorsSent)
+
+	latestSynced, err := svc.crossChainSyncRepo.LatestSyncedHeight(context.Background(), mock.MockChainID)
+	assert.Nil(t, err)
+	assert.Equal(t, mock.SyncedSrcHeight, latestSynced)
 }
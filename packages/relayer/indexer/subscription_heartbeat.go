@@ -0,0 +1,56 @@
+package indexer
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	// heartbeatBlockTimes is how many block times subscriptions can go silent for, when the
+	// heartbeat is inferred from the chain's block time
+	heartbeatBlockTimes = 10
+	// minHeartbeat is the shortest inferred heartbeat, so a fast chain's late block isn't a stall
+	minHeartbeat = 30 * time.Second
+	// heartbeatChecks is how many times per heartbeat the subscriptions are checked for silence
+	heartbeatChecks time.Duration = 4
+)
+
+// heartbeat is how long subscriptions can go without a new head or event before they're taken
+// to have stalled: svc.subscriptionHeartbeat if set, otherwise heartbeatBlockTimes of the
+// chain's block time, and at least minHeartbeat.
+func (svc *Service) heartbeat(ctx context.Context) time.Duration {
+	if svc.subscriptionHeartbeat > 0 {
+		return svc.subscriptionHeartbeat
+	}
+
+	head, err := svc.ethClient.HeaderByNumber(ctx, nil)
+	if err != nil {
+		log.Errorf("svc.ethClient.HeaderByNumber: %v", err)
+		return minHeartbeat
+	}
+
+	heartbeat := time.Duration(heartbeatBlockTimes) * svc.inferBlockTime(ctx, head)
+	if heartbeat < minHeartbeat {
+		return minHeartbeat
+	}
+
+	return heartbeat
+}
+
+// receivedEvent records a new head or event arriving from the subscriptions
+func (svc *Service) receivedEvent(chainID *big.Int) {
+	now := time.Now()
+
+	svc.lastEventReceived.Store(now.UnixNano())
+
+	relayer.SubscriptionLastEventReceived.WithLabelValues(chainID.String()).Set(float64(now.Unix()))
+}
+
+// sinceLastEvent is how long it's been since the subscriptions delivered a new head or event
+func (svc *Service) sinceLastEvent() time.Duration {
+	return time.Since(time.Unix(0, svc.lastEventReceived.Load()))
+}
@@ -0,0 +1,65 @@
+package indexer
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_heartbeat(t *testing.T) {
+	svc, _ := newTestService()
+
+	// ten of the mock chain's 12 second blocks
+	assert.Equal(t, 120*time.Second, svc.heartbeat(context.Background()))
+
+	svc.subscriptionHeartbeat = 5 * time.Second
+	assert.Equal(t, 5*time.Second, svc.heartbeat(context.Background()))
+}
+
+// silentHeadsEthClient's new head subscriptions stay open but never deliver, as a stalled
+// websocket connection's would
+type silentHeadsEthClient struct {
+	mock.EthClient
+	subscribed atomic.Int32
+}
+
+func (c *silentHeadsEthClient) SubscribeNewHead(
+	ctx context.Context,
+	ch chan<- *types.Header,
+) (ethereum.Subscription, error) {
+	c.subscribed.Add(1)
+
+	return &mock.Subscription{}, nil
+}
+
+func Test_subscribe_reestablishesStalledSubscriptions(t *testing.T) {
+	svc, _ := newTestService()
+
+	ethClient := &silentHeadsEthClient{}
+	svc.ethClient = ethClient
+	svc.subscriptionHeartbeat = 200 * time.Millisecond
+
+	stalls := relayer.SubscriptionStalls.WithLabelValues(mock.MockChainID.String())
+	before := testutil.ToFloat64(stalls)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		_ = svc.subscribe(ctx, mock.MockChainID)
+	}()
+
+	assert.Eventually(t, func() bool {
+		return ethClient.subscribed.Load() >= 3
+	}, 2*time.Second, 10*time.Millisecond)
+
+	assert.GreaterOrEqual(t, testutil.ToFloat64(stalls)-before, float64(2))
+}
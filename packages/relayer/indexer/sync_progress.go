@@ -0,0 +1,246 @@
+package indexer
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	// syncProgressWindow is how far back the catch up rate is measured over
+	syncProgressWindow = 5 * time.Minute
+	// syncProgressHeadInterval is how often the chain's head is checked while catching up
+	syncProgressHeadInterval = 10 * time.Second
+	// syncProgressLogInterval is how often the ETA is logged while catching up
+	syncProgressLogInterval = 30 * time.Second
+)
+
+type syncProgressSample struct {
+	at        time.Time
+	processed uint64
+	head      uint64
+}
+
+// syncProgressTracker estimates when an indexer will catch up with its chain's head, from the
+// blocks it's processed and the blocks the head has moved on by over a rolling window.
+type syncProgressTracker struct {
+	mu sync.Mutex
+
+	// confirmations is how far behind the head blocks are final, the indexer is caught up
+	// once it's that close
+	confirmations uint64
+	now           func() time.Time
+
+	head          uint64
+	headCheckedAt time.Time
+	loggedAt      time.Time
+	// samples are oldest first, and cover the window up to the latest
+	samples []syncProgressSample
+}
+
+func newSyncProgressTracker(confirmations uint64) *syncProgressTracker {
+	return &syncProgressTracker{
+		confirmations: confirmations,
+		now:           time.Now,
+	}
+}
+
+// setHead records the chain's head
+func (t *syncProgressTracker) setHead(head uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.head = head
+	t.headCheckedAt = t.now()
+}
+
+// setConfirmedHeight records the latest final block, for strategies that don't count a fixed
+// number of blocks behind the head
+func (t *syncProgressTracker) setConfirmedHeight(confirmed uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.head > confirmed {
+		t.confirmations = t.head - confirmed
+	} else {
+		t.confirmations = 0
+	}
+}
+
+// headDue is whether the chain's head was last checked long enough ago to check it again
+func (t *syncProgressTracker) headDue() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.now().Sub(t.headCheckedAt) >= syncProgressHeadInterval
+}
+
+// record samples the block processed up to, against the head last set
+func (t *syncProgressTracker) record(processed uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+
+	t.samples = append(t.samples, syncProgressSample{at: now, processed: processed, head: t.head})
+
+	// keep the newest sample older than the window, so the window is always covered
+	i := 0
+	for i+1 < len(t.samples) && now.Sub(t.samples[i+1].at) >= syncProgressWindow {
+		i++
+	}
+
+	t.samples = t.samples[i:]
+}
+
+// markCaughtUp records the indexer has caught up with the head, i.e. once it subscribes to it
+func (t *syncProgressTracker) markCaughtUp() {
+	t.mu.Lock()
+	head := t.head
+	t.mu.Unlock()
+
+	t.record(head)
+}
+
+// logDue is whether the progress was last logged long enough ago to log it again, and if so
+// counts it as logged
+func (t *syncProgressTracker) logDue() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+
+	if now.Sub(t.loggedAt) < syncProgressLogInterval {
+		return false
+	}
+
+	t.loggedAt = now
+
+	return true
+}
+
+func (t *syncProgressTracker) progress() relayer.SyncProgress {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.samples) == 0 {
+		return relayer.SyncProgress{}
+	}
+
+	first := t.samples[0]
+	last := t.samples[len(t.samples)-1]
+
+	p := relayer.SyncProgress{
+		ProcessedHeight: last.processed,
+		HeadHeight:      last.head,
+	}
+
+	var gap uint64
+	if last.head > last.processed {
+		gap = last.head - last.processed
+	}
+
+	if elapsed := last.at.Sub(first.at).Seconds(); elapsed > 0 {
+		closed := (float64(last.processed) - float64(first.processed)) - (float64(last.head) - float64(first.head))
+		p.BlocksPerSecond = closed / elapsed
+	}
+
+	if gap <= t.confirmations {
+		p.CaughtUp = true
+
+		eta := float64(0)
+		p.ETASeconds = &eta
+
+		return p
+	}
+
+	if p.BlocksPerSecond > 0 {
+		eta := float64(gap-t.confirmations) / p.BlocksPerSecond
+		p.ETASeconds = &eta
+	}
+
+	return p
+}
+
+// SyncProgress is how far the indexer has got catching up with its chain's head
+func (svc *Service) SyncProgress() relayer.SyncProgress {
+	return svc.syncProgress.progress()
+}
+
+// trackSyncProgress records the block the indexer has processed up to, checking the chain's head
+// again when it's due, so the rate accounts for it moving on while catching up. It updates the
+// metrics, and logs the ETA every syncProgressLogInterval.
+func (svc *Service) trackSyncProgress(ctx context.Context, chainID *big.Int) {
+	if svc.syncProgress.headDue() {
+		header, err := svc.ethClient.HeaderByNumber(ctx, nil)
+		if err != nil {
+			log.Warnf("chain ID %v sync progress, svc.ethClient.HeaderByNumber: %v", chainID, err)
+		} else {
+			svc.syncProgress.setHead(header.Number.Uint64())
+
+			svc.trackConfirmedHeight(ctx, chainID)
+		}
+	}
+
+	svc.syncProgress.record(svc.processingBlockHeight)
+
+	p := svc.syncProgress.progress()
+
+	relayer.IndexerBlocksBehindHead.WithLabelValues(chainID.String()).Set(
+		float64(p.HeadHeight) - float64(p.ProcessedHeight),
+	)
+
+	if p.ETASeconds != nil {
+		relayer.IndexerCatchUpETA.WithLabelValues(chainID.String()).Set(*p.ETASeconds)
+	} else {
+		relayer.IndexerCatchUpETA.WithLabelValues(chainID.String()).Set(-1)
+	}
+
+	if !svc.syncProgress.logDue() {
+		return
+	}
+
+	switch {
+	case p.CaughtUp:
+		log.Infof("chain ID %v caught up, at block %v of %v", chainID, p.ProcessedHeight, p.HeadHeight)
+	case p.ETASeconds == nil:
+		log.Warnf(
+			"chain ID %v catching up, at block %v of %v, not gaining on the head at %.2f blocks/s",
+			chainID,
+			p.ProcessedHeight,
+			p.HeadHeight,
+			p.BlocksPerSecond,
+		)
+	default:
+		log.Infof(
+			"chain ID %v catching up, at block %v of %v, %.2f blocks/s net of the head moving, ETA %v",
+			chainID,
+			p.ProcessedHeight,
+			p.HeadHeight,
+			p.BlocksPerSecond,
+			(time.Duration(*p.ETASeconds) * time.Second).Round(time.Second),
+		)
+	}
+}
+
+// trackConfirmedHeight records the latest final block when it's tagged by the node, rather than
+// a fixed number of blocks behind the head.
+func (svc *Service) trackConfirmedHeight(ctx context.Context, chainID *big.Int) {
+	if svc.confirmationStrategy.Tag == "" {
+		return
+	}
+
+	confirmed, ok, err := svc.confirmationStrategy.ConfirmedHeight(ctx, svc.ethClient)
+	if err != nil {
+		log.Warnf("chain ID %v sync progress, svc.confirmationStrategy.ConfirmedHeight: %v", chainID, err)
+		return
+	}
+
+	if ok {
+		svc.syncProgress.setConfirmedHeight(confirmed)
+	}
+}
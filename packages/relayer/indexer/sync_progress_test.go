@@ -0,0 +1,99 @@
+package indexer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_syncProgressTracker(t *testing.T) {
+	now := time.Unix(0, 0)
+
+	tracker := newSyncProgressTracker(10)
+	tracker.now = func() time.Time { return now }
+
+	assert.False(t, tracker.progress().CaughtUp)
+	assert.Nil(t, tracker.progress().ETASeconds)
+
+	tracker.setHead(1000)
+	tracker.record(0)
+
+	// processed 200 blocks in 10 seconds, while the head moved on by 50
+	now = now.Add(10 * time.Second)
+
+	tracker.setHead(1050)
+	tracker.record(200)
+
+	p := tracker.progress()
+	assert.Equal(t, uint64(200), p.ProcessedHeight)
+	assert.Equal(t, uint64(1050), p.HeadHeight)
+	assert.Equal(t, float64(15), p.BlocksPerSecond)
+	assert.False(t, p.CaughtUp)
+	assert.Equal(t, float64(840)/15, *p.ETASeconds)
+
+	// the head outpacing the indexer has no ETA
+	now = now.Add(10 * time.Second)
+
+	tracker.setHead(1500)
+	tracker.record(300)
+
+	p = tracker.progress()
+	assert.Less(t, p.BlocksPerSecond, float64(0))
+	assert.Nil(t, p.ETASeconds)
+
+	// within the confirmation depth of the head is caught up
+	now = now.Add(10 * time.Second)
+
+	tracker.record(1495)
+
+	p = tracker.progress()
+	assert.True(t, p.CaughtUp)
+	assert.Equal(t, float64(0), *p.ETASeconds)
+}
+
+func Test_syncProgressTracker_confirmedHeight(t *testing.T) {
+	tracker := newSyncProgressTracker(10)
+
+	tracker.setHead(1000)
+	tracker.setConfirmedHeight(900)
+	tracker.record(850)
+	assert.False(t, tracker.progress().CaughtUp)
+
+	// up to the finalized block is caught up, though it's further than 10 blocks behind the head
+	tracker.record(900)
+	assert.True(t, tracker.progress().CaughtUp)
+}
+
+func Test_syncProgressTracker_window(t *testing.T) {
+	now := time.Unix(0, 0)
+
+	tracker := newSyncProgressTracker(0)
+	tracker.now = func() time.Time { return now }
+
+	tracker.setHead(100000)
+
+	for i := 0; i < 10; i++ {
+		tracker.record(uint64(i * 1000))
+
+		now = now.Add(time.Minute)
+	}
+
+	// samples older than the window, but the newest of them, are dropped
+	assert.Len(t, tracker.samples, 6)
+	assert.Equal(t, float64(1000)/60, tracker.progress().BlocksPerSecond)
+}
+
+func Test_trackSyncProgress(t *testing.T) {
+	svc, _ := newTestService()
+
+	svc.processingBlockHeight = 10
+
+	svc.trackSyncProgress(context.Background(), mock.MockChainID)
+
+	p := svc.SyncProgress()
+	assert.Equal(t, uint64(10), p.ProcessedHeight)
+	assert.Equal(t, mock.LatestBlockNumber.Uint64(), p.HeadHeight)
+}
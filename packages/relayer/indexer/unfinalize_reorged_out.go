@@ -0,0 +1,33 @@
+package indexer
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	// unfinalizeReorgedOutInterval is how often the destination chain is checked for reorgs
+	unfinalizeReorgedOutInterval = 15 * time.Second
+)
+
+// unfinalizeReorgedOutEvery has the processor check the destination chain for reorgs dropping the
+// transactions of chainID's done messages, now and then every interval, until ctx is done.
+func (svc *Service) unfinalizeReorgedOutEvery(ctx context.Context, chainID *big.Int, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := svc.processor.UnfinalizeReorgedOut(ctx, chainID); err != nil {
+			log.Errorf("chain ID %v svc.processor.UnfinalizeReorgedOut: %v", chainID, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package indexer
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+)
+
+// writesEventBatches is whether a batch's events are written together with its checkpoint. Ordered
+// delivery handles each sender's messages in turn as they're saved, and a message emitted twice in
+// the batch has to be saved after the first to be linked to it as a duplicate, so those batches are
+// written an event at a time.
+func (svc *Service) writesEventBatches(events []*bridge.BridgeMessageSent) bool {
+	if svc.eventWriteBatchSize <= 0 || svc.orderedDelivery {
+		return false
+	}
+
+	seen := make(map[[32]byte]bool, len(events))
+
+	for _, event := range events {
+		if seen[event.MsgHash] {
+			return false
+		}
+
+		seen[event.MsgHash] = true
+	}
+
+	return true
+}
+
+// writeEventBatch saves the MessageSent events of the blocks up to, but excluding, end in multi-row
+// inserts in one transaction, processes the ones that can be, then moves the checkpoint and
+// svc.processingBlockHeight on to end. While catching up, saving a row at a time is a transaction per
+// event. Like handling them one at a time, an event that can't be saved is logged and skipped. A crash
+// before the checkpoint has the blocks indexed again, and the messages still new processed then.
+func (svc *Service) writeEventBatch(
+	ctx context.Context,
+	chainID *big.Int,
+	events []*bridge.BridgeMessageSent,
+	end uint64,
+) error {
+	// nil for the events skipped
+	opts := make([]*relayer.SaveEventOpts, len(events))
+
+	group, groupCtx := errgroup.WithContext(ctx)
+
+	group.SetLimit(svc.numGoroutines)
+
+	for i, event := range events {
+		i, event := i, event
+
+		group.Go(func() error {
+			ctx := relayer.WithMessageLogger(groupCtx, common.Hash(event.MsgHash), chainID, event.Message.DestChainId)
+
+			if svc.skipEvent(ctx, event) {
+				return nil
+			}
+
+			o, err := svc.eventOpts(ctx, chainID, event)
+			if err != nil {
+				relayer.ErrorEvents.Inc()
+				relayer.Logger(ctx).Errorf("svc.eventOpts: %v", err)
+
+				return nil
+			}
+
+			opts[i] = &o
+
+			return nil
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return errors.Wrap(err, "group.Wait")
+	}
+
+	toSave := make([]relayer.SaveEventOpts, 0, len(events))
+	saving := make([]*bridge.BridgeMessageSent, 0, len(events))
+
+	for i, o := range opts {
+		if o != nil {
+			toSave = append(toSave, *o)
+			saving = append(saving, events[i])
+		}
+	}
+
+	saved, err := svc.eventRepo.SaveBatch(ctx, toSave, svc.eventWriteBatchSize)
+	if err != nil {
+		return errors.Wrap(err, "svc.eventRepo.SaveBatch")
+	}
+
+	for _, o := range toSave {
+		countIndexed(o)
+		svc.publishEvent(o)
+	}
+
+	log.Infof("saved %v events up to block %v", len(saved), end)
+
+	group, groupCtx = errgroup.WithContext(ctx)
+
+	group.SetLimit(svc.numGoroutines)
+
+	for i, e := range saved {
+		e, event := e, saving[i]
+
+		ctx := relayer.WithMessageLogger(groupCtx, common.Hash(event.MsgHash), chainID, event.Message.DestChainId)
+
+		if !canProcessMessage(ctx, e.Status, event.Message.Owner, svc.relayerAddr) {
+			relayer.Logger(ctx).Warnf("cant process, eventStatus: %v", e.Status)
+			continue
+		}
+
+		group.Go(func() error {
+			if err := svc.processMessage(ctx, event, e); err != nil {
+				relayer.ErrorEvents.Inc()
+				// log error but always return nil to keep other goroutines active
+				relayer.Logger(ctx).Errorf("svc.processMessage: %v", err)
+			}
+
+			return nil
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return errors.Wrap(err, "group.Wait")
+	}
+
+	if err := svc.handleNoEventsInBatch(ctx, chainID, int64(end)); err != nil {
+		return errors.Wrap(err, "svc.handleNoEventsInBatch")
+	}
+
+	return nil
+}
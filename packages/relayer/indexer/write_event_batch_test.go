@@ -0,0 +1,129 @@
+package indexer
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_indexBatch_writeEventBatch(t *testing.T) {
+	svc, b := newTestService()
+
+	eventRepo := mock.NewEventRepository()
+	svc.eventRepo = eventRepo
+	svc.eventWriteBatchSize = 10
+
+	blockRepo := &mock.BlockRepository{}
+	svc.blockRepo = blockRepo
+
+	publisher := &mock.EventPublisher{}
+	svc.eventPublisher = publisher
+
+	message := bridge.IBridgeMessage{
+		Id:            big.NewInt(0),
+		SrcChainId:    big.NewInt(1),
+		DestChainId:   big.NewInt(2),
+		Owner:         common.HexToAddress("0x1"),
+		DepositValue:  big.NewInt(0),
+		CallValue:     big.NewInt(0),
+		ProcessingFee: big.NewInt(0),
+		GasLimit:      big.NewInt(1),
+	}
+
+	txHash := common.HexToHash("0xabc")
+
+	b.(*mock.Bridge).Logs = append(b.(*mock.Bridge).Logs,
+		mock.MessageSentLog([32]byte{0x5}, message, 2, txHash, 0),
+		mock.MessageSentLog([32]byte{0x6}, message, 3, common.HexToHash("0xdef"), 0),
+	)
+
+	svc.processingBlockHeight = 0
+
+	assert.Nil(t, svc.indexBatch(context.Background(), big.NewInt(1), 10))
+	assert.Equal(t, uint64(10), svc.processingBlockHeight)
+
+	events, err := eventRepo.FindAllByBlockNumber(context.Background(), big.NewInt(1), 2)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, txHash.Hex(), *events[0].TxHash)
+	assert.Equal(t, uint(0), *events[0].LogIndex)
+
+	// the checkpoint's saved once the batch's messages have been handled
+	assert.Equal(t, 1, len(blockRepo.Saved()))
+	assert.Equal(t, uint64(10), blockRepo.Saved()[0].Height)
+	assert.Equal(t, relayer.EventNameMessageSent, blockRepo.Saved()[0].EventName)
+
+	assert.Equal(t, 2, len(publisher.Events))
+	assert.Equal(t, common.Hash([32]byte{0x5}).Hex(), publisher.Events[0].MsgHash)
+
+	// written again, e.g. after a restart, the events are already saved
+	svc.processingBlockHeight = 0
+
+	assert.Nil(t, svc.indexBatch(context.Background(), big.NewInt(1), 10))
+
+	events, err = eventRepo.FindAllByBlockNumber(context.Background(), big.NewInt(1), 3)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, 2, len(blockRepo.Saved()))
+}
+
+func Test_writesEventBatches(t *testing.T) {
+	tests := []struct {
+		name                string
+		eventWriteBatchSize int
+		orderedDelivery     bool
+		msgHashes           [][32]byte
+		want                bool
+	}{
+		{
+			"unset",
+			0,
+			false,
+			[][32]byte{{0x1}, {0x2}},
+			false,
+		},
+		{
+			"batched",
+			10,
+			false,
+			[][32]byte{{0x1}, {0x2}},
+			true,
+		},
+		{
+			"orderedDelivery",
+			10,
+			true,
+			[][32]byte{{0x1}, {0x2}},
+			false,
+		},
+		{
+			"repeatedMsgHash",
+			10,
+			false,
+			[][32]byte{{0x1}, {0x2}, {0x1}},
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newTestService()
+			svc.eventWriteBatchSize = tt.eventWriteBatchSize
+			svc.orderedDelivery = tt.orderedDelivery
+
+			events := make([]*bridge.BridgeMessageSent, 0, len(tt.msgHashes))
+
+			for _, msgHash := range tt.msgHashes {
+				events = append(events, &bridge.BridgeMessageSent{MsgHash: msgHash})
+			}
+
+			assert.Equal(t, tt.want, svc.writesEventBatches(events))
+		})
+	}
+}
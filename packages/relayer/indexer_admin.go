@@ -0,0 +1,34 @@
+package relayer
+
+import "context"
+
+// IndexerStatus is how far an indexer has got, and whether its processor is paused
+type IndexerStatus struct {
+	ChainID              int64
+	LatestProcessedBlock *Block
+	HeadBlock            uint64
+	// LatestSyncedHeight is the latest block of this chain the destination chain has synced
+	LatestSyncedHeight uint64
+	ProcessingPaused   bool
+}
+
+// ProcessingPauser stops and restarts a processor sending transactions, while its indexer
+// carries on indexing
+type ProcessingPauser interface {
+	PauseProcessing()
+	ResumeProcessing()
+	ProcessingPaused() bool
+}
+
+// MessageReprocessor processes a message the destination bridge still sees as new again
+type MessageReprocessor interface {
+	ReprocessMessage(ctx context.Context, e *Event) error
+}
+
+// IndexerAdmin inspects and controls a running indexer and the processor relaying
+// the messages it finds
+type IndexerAdmin interface {
+	ProcessingPauser
+	MessageReprocessor
+	IndexerStatus(ctx context.Context) (*IndexerStatus, error)
+}
@@ -0,0 +1,83 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// testAnvil starts an anvil node on a free port, skipping the test unless RELAYER_INTEGRATION=1
+// and anvil is available. The node is killed when the test finishes.
+func testAnvil(t *testing.T) (*ethclient.Client, *rpc.Client) {
+	if os.Getenv("RELAYER_INTEGRATION") != "1" {
+		t.Skip("skipping anvil integration test, set RELAYER_INTEGRATION=1 to run it")
+	}
+
+	bin := os.Getenv("ANVIL_PATH")
+	if bin == "" {
+		bin = "anvil"
+	}
+
+	path, err := exec.LookPath(bin)
+	if err != nil {
+		t.Skipf("skipping, anvil not found: %v", err)
+	}
+
+	port, err := freePort()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(path, "--port", fmt.Sprint(port), "--silent")
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	})
+
+	url := fmt.Sprintf("http://127.0.0.1:%v", port)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	for {
+		rpcClient, err := rpc.DialContext(ctx, url)
+		if err == nil {
+			ethClient := ethclient.NewClient(rpcClient)
+			if _, err := ethClient.ChainID(ctx); err == nil {
+				t.Cleanup(rpcClient.Close)
+
+				return ethClient, rpcClient
+			}
+
+			rpcClient.Close()
+		}
+
+		select {
+		case <-ctx.Done():
+			t.Fatalf("anvil did not start: %v", ctx.Err())
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+
+	defer l.Close()
+
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
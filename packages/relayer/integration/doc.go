@@ -0,0 +1,6 @@
+// Package integration exercises the relayer against a real EVM node instead of mocks.
+// The tests start a local anvil node. They only run with RELAYER_INTEGRATION=1, and are skipped
+// when the anvil binary can't be found, so `go test ./...` still passes offline.
+//
+// Install anvil with foundryup (https://book.getfoundry.sh), or point ANVIL_PATH at the binary.
+package integration
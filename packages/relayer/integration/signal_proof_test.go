@@ -0,0 +1,252 @@
+package integration
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/encoding"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/proof"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/assert"
+)
+
+// anvilKey is the first of anvil's default, pre-funded dev accounts
+const anvilKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+var signalProofT, _ = abi.NewType("tuple", "", []abi.ArgumentMarshaling{
+	{Name: "height", Type: "uint256"},
+	{Name: "proof", Type: "bytes"},
+})
+
+var (
+	addressT, _ = abi.NewType("address", "", nil)
+	bytes32T, _ = abi.NewType("bytes32", "", nil)
+	bytesT, _   = abi.NewType("bytes", "", nil)
+)
+
+func TestIntegration_EncodedSignalProof(t *testing.T) {
+	ethClient, rpcClient := testAnvil(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	key, err := crypto.HexToECDSA(anvilKey)
+	assert.Nil(t, err)
+
+	app := crypto.PubkeyToAddress(key.PublicKey)
+
+	receipt := sendTx(ctx, t, ethClient, key, nil, deployCode(signalServiceStubRuntime()))
+	signalService := receipt.ContractAddress
+
+	chainID, err := ethClient.ChainID(ctx)
+	assert.Nil(t, err)
+
+	assert.Nil(t, proof.VerifySignalSlotLayout(ctx, rpcClient, signalService, chainID.Uint64()))
+
+	signal := crypto.Keccak256Hash([]byte("integration"))
+
+	receipt = sendTx(
+		ctx,
+		t,
+		ethClient,
+		key,
+		&signalService,
+		append(crypto.Keccak256([]byte("sendSignal(bytes32)"))[:4], signal[:]...),
+	)
+
+	prover, err := proof.New(ethClient, rpcClient)
+	assert.Nil(t, err)
+
+	encoded, err := prover.EncodedSignalProof(
+		ctx,
+		rpcClient,
+		proof.SignalService{Address: signalService},
+		app,
+		signal,
+		receipt.BlockHash,
+	)
+	assert.Nil(t, err)
+
+	out, err := abi.Arguments{{Type: signalProofT}}.Unpack(encoded)
+	assert.Nil(t, err)
+
+	assert.Equal(t, uint64(relayer.EventStatusDone), processMessage(ctx, t, ethClient, key, signalService, app, signal, encoded))
+
+	signalProof := *abi.ConvertType(out[0], new(encoding.SignalProof)).(*encoding.SignalProof)
+	assert.Equal(t, receipt.BlockNumber, signalProof.Height)
+
+	var storageProof [][]byte
+
+	assert.Nil(t, rlp.DecodeBytes(signalProof.Proof, &storageProof))
+
+	header, err := ethClient.HeaderByHash(ctx, receipt.BlockHash)
+	assert.Nil(t, err)
+
+	storageRoot := accountStorageRoot(ctx, t, rpcClient, header, signalService)
+
+	slot := proof.SignalSlot(app, signal)
+
+	value, err := trie.VerifyProof(storageRoot, crypto.Keccak256(slot[:]), proofDB(storageProof))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0x01}, value)
+
+	_, err = prover.EncodedSignalProof(
+		ctx,
+		rpcClient,
+		proof.SignalService{Address: signalService},
+		app,
+		[32]byte{0x1},
+		receipt.BlockHash,
+	)
+	assert.ErrorIs(t, err, proof.ErrSignalNotSent)
+
+	steps, err := proof.VerifySignalProof(ctx, rpcClient, proof.VerifySignalProofOpts{
+		SignalServiceAddress: signalService,
+		App:                  app,
+		Signal:               signal,
+		EncodedProof:         encoded,
+	})
+	assert.Nil(t, err)
+	assert.Len(t, steps, 5)
+
+	_, err = proof.VerifySignalProof(ctx, rpcClient, proof.VerifySignalProofOpts{
+		SignalServiceAddress: signalService,
+		App:                  app,
+		Signal:               [32]byte{0x1},
+		EncodedProof:         encoded,
+	})
+	assert.ErrorIs(t, err, proof.ErrSignalNotSet)
+}
+
+// processMessage relays the message whose hash is the signal app sent with encodedProof to a
+// bridge stub deployed for signalService, and returns the message's status on it afterwards.
+func processMessage(
+	ctx context.Context,
+	t *testing.T,
+	c *ethclient.Client,
+	key *ecdsa.PrivateKey,
+	signalService common.Address,
+	app common.Address,
+	msgHash common.Hash,
+	encodedProof []byte,
+) uint64 {
+	t.Helper()
+
+	bridge := sendTx(ctx, t, c, key, nil, deployCode(bridgeStubRuntime(signalService))).ContractAddress
+
+	args, err := abi.Arguments{{Type: addressT}, {Type: bytes32T}, {Type: bytesT}}.Pack(app, msgHash, encodedProof)
+	assert.Nil(t, err)
+
+	sendTx(ctx, t, c, key, &bridge, append(crypto.Keccak256([]byte("processMessage(address,bytes32,bytes)"))[:4], args...))
+
+	status, err := c.CallContract(ctx, ethereum.CallMsg{
+		To:   &bridge,
+		Data: append(crypto.Keccak256([]byte("getMessageStatus(bytes32)"))[:4], msgHash[:]...),
+	}, nil)
+	assert.Nil(t, err)
+
+	return new(big.Int).SetBytes(status).Uint64()
+}
+
+// accountStorageRoot proves the account at addr against the header's state root,
+// and returns its storage root.
+func accountStorageRoot(
+	ctx context.Context,
+	t *testing.T,
+	c relayer.Caller,
+	header *types.Header,
+	addr common.Address,
+) common.Hash {
+	var ethProof proof.StorageProof
+
+	err := c.CallContext(ctx, &ethProof, "eth_getProof", addr, []string{}, hexutil.EncodeBig(header.Number))
+	assert.Nil(t, err)
+
+	encodedAccount, err := trie.VerifyProof(header.Root, crypto.Keccak256(addr[:]), proofDB(ethProof.AccountProof))
+	assert.Nil(t, err)
+
+	var account types.StateAccount
+
+	assert.Nil(t, rlp.DecodeBytes(encodedAccount, &account))
+	assert.Equal(t, ethProof.StorageHash, account.Root)
+
+	return account.Root
+}
+
+func proofDB(nodes [][]byte) *memorydb.Database {
+	db := memorydb.New()
+
+	for _, node := range nodes {
+		_ = db.Put(crypto.Keccak256(node), node)
+	}
+
+	return db
+}
+
+// sendTx signs and sends a legacy tx, creating a contract if to is nil,
+// and waits for it to be mined successfully.
+func sendTx(
+	ctx context.Context,
+	t *testing.T,
+	c *ethclient.Client,
+	key *ecdsa.PrivateKey,
+	to *common.Address,
+	data []byte,
+) *types.Receipt {
+	t.Helper()
+
+	chainID, err := c.ChainID(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nonce, err := c.PendingNonceAt(ctx, crypto.PubkeyToAddress(key.PublicKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gasPrice, err := c.SuggestGasPrice(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(chainID), &types.LegacyTx{
+		Nonce:    nonce,
+		To:       to,
+		Gas:      1000000,
+		GasPrice: gasPrice,
+		Data:     data,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.SendTransaction(ctx, tx); err != nil {
+		t.Fatal(err)
+	}
+
+	receipt, err := bind.WaitMined(ctx, c, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		t.Fatalf("tx %v reverted", tx.Hash().Hex())
+	}
+
+	return receipt
+}
@@ -0,0 +1,183 @@
+package integration
+
+import (
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// opcodes used by the stub
+const (
+	opStop         = 0x00
+	opEq           = 0x14
+	opIsZero       = 0x15
+	opShl          = 0x1b
+	opShr          = 0x1c
+	opSha3         = 0x20
+	opCaller       = 0x33
+	opCallDataLoad = 0x35
+	opCodeCopy     = 0x39
+	opMStore       = 0x52
+	opSLoad        = 0x54
+	opSStore       = 0x55
+	opJumpI        = 0x57
+	opMLoad        = 0x51
+	opJumpDest     = 0x5b
+	opGas          = 0x5a
+	opPush1        = 0x60
+	opPush2        = 0x61
+	opPush4        = 0x63
+	opPush20       = 0x73
+	opDup1         = 0x80
+	opSwap1        = 0x90
+	opReturn       = 0xf3
+	opStaticCall   = 0xfa
+	opRevert       = 0xfd
+)
+
+// assembler builds EVM bytecode with forward jumps to labels
+type assembler struct {
+	code   []byte
+	labels map[string]int
+	jumps  map[int]string
+}
+
+func newAssembler() *assembler {
+	return &assembler{
+		labels: make(map[string]int),
+		jumps:  make(map[int]string),
+	}
+}
+
+func (a *assembler) op(ops ...byte) *assembler {
+	a.code = append(a.code, ops...)
+	return a
+}
+
+func (a *assembler) push1(b byte) *assembler {
+	return a.op(opPush1, b)
+}
+
+// jumpITo jumps to label if the top of the stack is non zero
+func (a *assembler) jumpITo(label string) *assembler {
+	a.op(opPush2)
+	a.jumps[len(a.code)] = label
+
+	return a.op(0, 0, opJumpI)
+}
+
+func (a *assembler) label(name string) *assembler {
+	a.labels[name] = len(a.code)
+	return a.op(opJumpDest)
+}
+
+func (a *assembler) bytes() []byte {
+	for at, label := range a.jumps {
+		dest := a.labels[label]
+		a.code[at] = byte(dest >> 8)
+		a.code[at+1] = byte(dest)
+	}
+
+	return a.code
+}
+
+// signalServiceStubRuntime is a minimal SignalService, storing signals at the same
+// keccak256(abi.encodePacked(app, signal)) slot as the real contract:
+//
+//	sendSignal(bytes32 signal)                        stores 1 at the slot for (msg.sender, signal)
+//	isSignalSent(address app, bytes32 signal) bool    whether the slot for (app, signal) is set
+//	getSignalSlot(address app, bytes32 signal) bytes32
+func signalServiceStubRuntime() []byte {
+	a := newAssembler()
+
+	// selector := calldataload(0) >> 224
+	a.push1(0).op(opCallDataLoad).push1(0xe0).op(opShr)
+
+	for _, fn := range []string{"sendSignal(bytes32)", "isSignalSent(address,bytes32)", "getSignalSlot(address,bytes32)"} {
+		a.op(opDup1, opPush4).op(crypto.Keccak256([]byte(fn))[:4]...).op(opEq).jumpITo(fn)
+	}
+
+	a.push1(0).op(opDup1, opRevert)
+
+	// mstore(0, msg.sender << 96), mstore(20, signal), sstore(keccak256(0, 52), 1)
+	a.label("sendSignal(bytes32)").
+		op(opCaller).push1(0x60).op(opShl).push1(0).op(opMStore).
+		push1(0x04).op(opCallDataLoad).push1(0x14).op(opMStore).
+		push1(0x34).push1(0).op(opSha3).
+		push1(1).op(opSwap1, opSStore, opStop)
+
+	// mstore(0, app << 96), mstore(20, signal), return sload(keccak256(0, 52)) != 0
+	a.label("isSignalSent(address,bytes32)").
+		push1(0x04).op(opCallDataLoad).push1(0x60).op(opShl).push1(0).op(opMStore).
+		push1(0x24).op(opCallDataLoad).push1(0x14).op(opMStore).
+		push1(0x34).push1(0).op(opSha3).
+		op(opSLoad, opIsZero, opIsZero).push1(0).op(opMStore).
+		push1(0x20).push1(0).op(opReturn)
+
+	// mstore(0, app << 96), mstore(20, signal), return keccak256(0, 52)
+	a.label("getSignalSlot(address,bytes32)").
+		push1(0x04).op(opCallDataLoad).push1(0x60).op(opShl).push1(0).op(opMStore).
+		push1(0x24).op(opCallDataLoad).push1(0x14).op(opMStore).
+		push1(0x34).push1(0).op(opSha3).
+		push1(0).op(opMStore).
+		push1(0x20).push1(0).op(opReturn)
+
+	return a.bytes()
+}
+
+// bridgeStubRuntime is a minimal Bridge recording a message done once the signalService
+// stub has its signal, the message hash, sent by app:
+//
+//	processMessage(address app, bytes32 msgHash, bytes proof)  reverts unless isSignalSent(app, msgHash),
+//	                                                            stores Done at msgHash otherwise
+//	getMessageStatus(bytes32 msgHash) uint256
+//
+// The proof isn't verified on-chain, that's checked against the state root in Go.
+func bridgeStubRuntime(signalService common.Address) []byte {
+	a := newAssembler()
+
+	a.push1(0).op(opCallDataLoad).push1(0xe0).op(opShr)
+
+	for _, fn := range []string{"processMessage(address,bytes32,bytes)", "getMessageStatus(bytes32)"} {
+		a.op(opDup1, opPush4).op(crypto.Keccak256([]byte(fn))[:4]...).op(opEq).jumpITo(fn)
+	}
+
+	a.label("revert").push1(0).op(opDup1, opRevert)
+
+	// mstore(0, isSignalSent selector << 224), mstore(4, app), mstore(36, msgHash),
+	// staticcall(gas, signalService, 0, 68, 0, 32), reverting unless it succeeds and returns true
+	a.label("processMessage(address,bytes32,bytes)").
+		op(opPush4).op(crypto.Keccak256([]byte("isSignalSent(address,bytes32)"))[:4]...).
+		push1(0xe0).op(opShl).push1(0).op(opMStore).
+		push1(0x04).op(opCallDataLoad).push1(0x04).op(opMStore).
+		push1(0x24).op(opCallDataLoad).push1(0x24).op(opMStore).
+		push1(0x20).push1(0).push1(0x44).push1(0).
+		op(opPush20).op(signalService.Bytes()...).
+		op(opGas, opStaticCall, opIsZero).jumpITo("revert").
+		push1(0).op(opMLoad, opIsZero).jumpITo("revert").
+		push1(byte(relayer.EventStatusDone)).push1(0x24).op(opCallDataLoad, opSStore, opStop)
+
+	// return sload(msgHash)
+	a.label("getMessageStatus(bytes32)").
+		push1(0x04).op(opCallDataLoad, opSLoad).push1(0).op(opMStore).
+		push1(0x20).push1(0).op(opReturn)
+
+	return a.bytes()
+}
+
+// deployCode wraps runtime in init code that copies it into memory and returns it
+func deployCode(runtime []byte) []byte {
+	const initLen = 11
+
+	init := []byte{
+		opPush1, byte(len(runtime)),
+		opDup1,
+		opPush1, initLen,
+		opPush1, 0,
+		opCodeCopy,
+		opPush1, 0,
+		opReturn,
+	}
+
+	return append(init, runtime...)
+}
@@ -0,0 +1,8 @@
+package kafka
+
+import "github.com/pkg/errors"
+
+var (
+	ErrNoBrokers = errors.New("kafka: at least one broker is required")
+	ErrNoTopics  = errors.New("kafka: an events or status changes topic is required")
+)
@@ -0,0 +1,254 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	kafkago "github.com/segmentio/kafka-go"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+)
+
+var (
+	DefaultBufferSize = 1000
+
+	defaultWriteTimeout = 10 * time.Second
+	// maxBatchSize is the most buffered messages written at once
+	maxBatchSize = 100
+)
+
+// Writer writes messages to their topics, as kafka-go's Writer does
+type Writer interface {
+	WriteMessages(ctx context.Context, msgs ...kafkago.Message) error
+	Close() error
+}
+
+// EventMessage is the JSON value published for each event the indexer saves
+type EventMessage struct {
+	Name         string          `json:"name"`
+	MsgHash      string          `json:"msgHash"`
+	ChainID      int64           `json:"chainID"`
+	Status       string          `json:"status"`
+	EventType    string          `json:"eventType"`
+	MessageOwner string          `json:"messageOwner"`
+	BlockNumber  uint64          `json:"blockNumber"`
+	TxHash       string          `json:"txHash"`
+	LogIndex     uint            `json:"logIndex"`
+	Data         json.RawMessage `json:"data"`
+}
+
+// StatusChangeMessage is the JSON value published for each message status change
+type StatusChangeMessage struct {
+	IdempotencyKey string `json:"idempotencyKey"`
+	MsgHash        string `json:"msgHash"`
+	Status         string `json:"status"`
+	TxHash         string `json:"txHash"`
+	ChainID        int64  `json:"chainID"`
+	MessageOwner   string `json:"messageOwner"`
+	Timestamp      int64  `json:"timestamp"`
+}
+
+// Producer publishes events and status changes to Kafka, keyed by their message hash, so a
+// message's are in one partition, in order. Publishing only buffers them: a background loop
+// writes them, and they're dropped when the buffer is full or the write fails, so a slow or
+// unavailable cluster never holds up indexing.
+type Producer struct {
+	writer             Writer
+	eventsTopic        string
+	statusChangesTopic string
+	buffer             chan kafkago.Message
+	writeTimeout       time.Duration
+}
+
+type NewProducerOpts struct {
+	// Brokers is a comma separated list of host:port addresses
+	Brokers string
+	// EventsTopic receives every event indexed, and StatusChangesTopic every message status change.
+	// Either can be empty to not publish them, but not both.
+	EventsTopic        string
+	StatusChangesTopic string
+	// BufferSize is how many messages can wait to be written before more are dropped
+	BufferSize int
+	// Writer is optional, and written to instead of Brokers
+	Writer Writer
+}
+
+// New builds a Producer, or returns nil if opts.Brokers is empty, so nothing is published.
+func New(opts NewProducerOpts) (*Producer, error) {
+	if strings.TrimSpace(opts.Brokers) == "" && opts.Writer == nil {
+		return nil, nil
+	}
+
+	return NewProducer(opts)
+}
+
+func NewProducer(opts NewProducerOpts) (*Producer, error) {
+	if opts.EventsTopic == "" && opts.StatusChangesTopic == "" {
+		return nil, ErrNoTopics
+	}
+
+	writer := opts.Writer
+	if writer == nil {
+		brokers := make([]string, 0)
+
+		for _, broker := range strings.Split(opts.Brokers, ",") {
+			if broker = strings.TrimSpace(broker); broker != "" {
+				brokers = append(brokers, broker)
+			}
+		}
+
+		if len(brokers) == 0 {
+			return nil, ErrNoBrokers
+		}
+
+		writer = &kafkago.Writer{
+			Addr: kafkago.TCP(brokers...),
+			// the same key is always written to the same partition
+			Balancer:     &kafkago.Hash{},
+			RequiredAcks: kafkago.RequireOne,
+			// the loop batches what's buffered, so don't wait for more
+			BatchTimeout: 10 * time.Millisecond,
+		}
+	}
+
+	bufferSize := opts.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+
+	return &Producer{
+		writer:             writer,
+		eventsTopic:        opts.EventsTopic,
+		statusChangesTopic: opts.StatusChangesTopic,
+		buffer:             make(chan kafkago.Message, bufferSize),
+		writeTimeout:       defaultWriteTimeout,
+	}, nil
+}
+
+// PublishEvent buffers opts for the events topic, if there is one
+func (p *Producer) PublishEvent(opts relayer.SaveEventOpts) {
+	var chainID int64
+	if opts.ChainID != nil {
+		chainID = opts.ChainID.Int64()
+	}
+
+	data := json.RawMessage(opts.Data)
+	if !json.Valid(data) {
+		data = nil
+	}
+
+	p.publish(p.eventsTopic, opts.MsgHash, EventMessage{
+		Name:         opts.Name,
+		MsgHash:      opts.MsgHash,
+		ChainID:      chainID,
+		Status:       opts.Status.String(),
+		EventType:    opts.EventType.String(),
+		MessageOwner: opts.MessageOwner,
+		BlockNumber:  opts.BlockNumber,
+		TxHash:       opts.TxHash,
+		LogIndex:     opts.LogIndex,
+		Data:         data,
+	})
+}
+
+// PublishStatusChange buffers change for the status changes topic, if there is one
+func (p *Producer) PublishStatusChange(change relayer.MessageStatusChange) {
+	p.publish(p.statusChangesTopic, change.MsgHash, StatusChangeMessage{
+		IdempotencyKey: change.IdempotencyKey(),
+		MsgHash:        change.MsgHash,
+		Status:         change.Status.String(),
+		TxHash:         change.TxHash,
+		ChainID:        change.ChainID,
+		MessageOwner:   change.MessageOwner,
+		Timestamp:      change.Timestamp,
+	})
+}
+
+func (p *Producer) publish(topic string, key string, value interface{}) {
+	if topic == "" {
+		return
+	}
+
+	marshaled, err := json.Marshal(value)
+	if err != nil {
+		relayer.KafkaPublishFailures.WithLabelValues(topic).Inc()
+		log.Errorf("kafka: msgHash: %v, json.Marshal: %v", key, err)
+
+		return
+	}
+
+	select {
+	case p.buffer <- kafkago.Message{Topic: topic, Key: []byte(key), Value: marshaled}:
+	default:
+		relayer.KafkaPublishFailures.WithLabelValues(topic).Inc()
+		log.Warnf("kafka: msgHash: %v, buffer full, dropping message for topic %v", key, topic)
+	}
+}
+
+// Start writes buffered messages until ctx is done, then writes what's left and closes the writer
+func (p *Producer) Start(ctx context.Context) {
+	defer func() {
+		if err := p.writer.Close(); err != nil {
+			log.Errorf("kafka: p.writer.Close: %v", err)
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			for batch := p.drain(nil); len(batch) > 0; batch = p.drain(nil) {
+				p.write(batch)
+			}
+
+			return
+		case msg := <-p.buffer:
+			p.write(p.drain([]kafkago.Message{msg}))
+		}
+	}
+}
+
+// drain adds the messages already buffered to batch, up to maxBatchSize
+func (p *Producer) drain(batch []kafkago.Message) []kafkago.Message {
+	for len(batch) < maxBatchSize {
+		select {
+		case msg := <-p.buffer:
+			batch = append(batch, msg)
+		default:
+			return batch
+		}
+	}
+
+	return batch
+}
+
+// write writes batch, counting each message that can't be written as a failure
+func (p *Producer) write(batch []kafkago.Message) {
+	// not the loop's context, so what's left is still written once it's done
+	ctx, cancel := context.WithTimeout(context.Background(), p.writeTimeout)
+	defer cancel()
+
+	err := p.writer.WriteMessages(ctx, batch...)
+	if err == nil {
+		return
+	}
+
+	// the writer reports which messages failed, when only some did
+	writeErrors, partial := err.(kafkago.WriteErrors)
+
+	failed := 0
+
+	for i, msg := range batch {
+		if partial && writeErrors[i] == nil {
+			continue
+		}
+
+		failed++
+
+		relayer.KafkaPublishFailures.WithLabelValues(msg.Topic).Inc()
+	}
+
+	log.Errorf("kafka: dropping %v of %v messages, p.writer.WriteMessages: %v", failed, len(batch), err)
+}
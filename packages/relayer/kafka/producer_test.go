@@ -0,0 +1,202 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+)
+
+type fakeWriter struct {
+	mu       sync.Mutex
+	messages []kafkago.Message
+	err      error
+	closed   bool
+}
+
+func (w *fakeWriter) WriteMessages(ctx context.Context, msgs ...kafkago.Message) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.err != nil {
+		return w.err
+	}
+
+	w.messages = append(w.messages, msgs...)
+
+	return nil
+}
+
+func (w *fakeWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.closed = true
+
+	return nil
+}
+
+func (w *fakeWriter) written() []kafkago.Message {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return append([]kafkago.Message{}, w.messages...)
+}
+
+func Test_New(t *testing.T) {
+	p, err := New(NewProducerOpts{EventsTopic: "events"})
+	assert.Nil(t, err)
+	assert.Nil(t, p)
+
+	_, err = New(NewProducerOpts{Brokers: "localhost:9092"})
+	assert.Equal(t, ErrNoTopics, err)
+
+	_, err = NewProducer(NewProducerOpts{Brokers: " , ", EventsTopic: "events"})
+	assert.Equal(t, ErrNoBrokers, err)
+
+	p, err = New(NewProducerOpts{Brokers: "localhost:9092,localhost:9093", StatusChangesTopic: "status"})
+	assert.Nil(t, err)
+	assert.NotNil(t, p)
+	assert.Equal(t, DefaultBufferSize, cap(p.buffer))
+}
+
+func Test_Producer(t *testing.T) {
+	writer := &fakeWriter{}
+
+	p, err := New(NewProducerOpts{
+		EventsTopic:        "events",
+		StatusChangesTopic: "status",
+		Writer:             writer,
+	})
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+
+	go func() {
+		p.Start(ctx)
+		close(done)
+	}()
+
+	p.PublishEvent(relayer.SaveEventOpts{
+		Name:        relayer.EventNameMessageSent,
+		MsgHash:     "0x1",
+		ChainID:     big.NewInt(5),
+		Status:      relayer.EventStatusNew,
+		Data:        `{"MsgHash":"0x1"}`,
+		BlockNumber: 10,
+		TxHash:      "0xabc",
+		LogIndex:    2,
+	})
+
+	p.PublishStatusChange(relayer.MessageStatusChange{
+		MsgHash: "0x1",
+		Status:  relayer.EventStatusDone,
+		ChainID: 6,
+	})
+
+	assert.Eventually(t, func() bool {
+		return len(writer.written()) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	written := writer.written()
+
+	assert.Equal(t, "events", written[0].Topic)
+	assert.Equal(t, []byte("0x1"), written[0].Key)
+
+	var event EventMessage
+
+	assert.Nil(t, json.Unmarshal(written[0].Value, &event))
+	assert.Equal(t, EventMessage{
+		Name:        relayer.EventNameMessageSent,
+		MsgHash:     "0x1",
+		ChainID:     5,
+		Status:      "new",
+		EventType:   "sendETH",
+		BlockNumber: 10,
+		TxHash:      "0xabc",
+		LogIndex:    2,
+		Data:        json.RawMessage(`{"MsgHash":"0x1"}`),
+	}, event)
+
+	assert.Equal(t, "status", written[1].Topic)
+	assert.Equal(t, []byte("0x1"), written[1].Key)
+
+	var change StatusChangeMessage
+
+	assert.Nil(t, json.Unmarshal(written[1].Value, &change))
+	assert.Equal(t, "0x1:done", change.IdempotencyKey)
+	assert.Equal(t, "done", change.Status)
+	assert.Equal(t, int64(6), change.ChainID)
+
+	cancel()
+	<-done
+
+	assert.True(t, writer.closed)
+}
+
+func Test_Producer_noTopic(t *testing.T) {
+	p, err := New(NewProducerOpts{StatusChangesTopic: "status", Writer: &fakeWriter{}})
+	assert.Nil(t, err)
+
+	p.PublishEvent(relayer.SaveEventOpts{MsgHash: "0x1"})
+
+	assert.Equal(t, 0, len(p.buffer))
+}
+
+func Test_Producer_bufferFull(t *testing.T) {
+	p, err := New(NewProducerOpts{EventsTopic: "events", BufferSize: 1, Writer: &fakeWriter{}})
+	assert.Nil(t, err)
+
+	// not started, so nothing is written and the second is dropped without blocking
+	p.PublishEvent(relayer.SaveEventOpts{MsgHash: "0x1"})
+	p.PublishEvent(relayer.SaveEventOpts{MsgHash: "0x2"})
+
+	assert.Equal(t, 1, len(p.buffer))
+	assert.Equal(t, []byte("0x1"), (<-p.buffer).Key)
+}
+
+func Test_Producer_flushesOnStop(t *testing.T) {
+	writer := &fakeWriter{}
+
+	p, err := New(NewProducerOpts{EventsTopic: "events", Writer: writer})
+	assert.Nil(t, err)
+
+	for i := 0; i < maxBatchSize+1; i++ {
+		p.PublishEvent(relayer.SaveEventOpts{MsgHash: "0x1"})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	p.Start(ctx)
+
+	assert.Equal(t, maxBatchSize+1, len(writer.written()))
+	assert.True(t, writer.closed)
+}
+
+func Test_Producer_writeFails(t *testing.T) {
+	writer := &fakeWriter{err: errors.New("leader not available")}
+
+	p, err := New(NewProducerOpts{EventsTopic: "events", Writer: writer})
+	assert.Nil(t, err)
+
+	p.PublishEvent(relayer.SaveEventOpts{MsgHash: "0x1"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// dropped, and doesn't block
+	p.Start(ctx)
+
+	assert.Empty(t, writer.written())
+}
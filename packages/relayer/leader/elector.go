@@ -0,0 +1,208 @@
+package leader
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	// DefaultLeaseName is the lease instances sharing a database compete for, unless given another
+	DefaultLeaseName = "relayer"
+	// releaseTimeout bounds releasing the lease on shutdown, so a standby can take over
+	// without waiting for it to expire
+	releaseTimeout = 5 * time.Second
+)
+
+// Elector keeps one of the instances sharing a lease the leader. It renews the lease every
+// renew interval while it has it, and tries to take it at the same interval while it doesn't,
+// so a standby takes over within the lease TTL plus a renew interval of the leader going away.
+type Elector struct {
+	leaseRepo     relayer.LeaseRepository
+	name          string
+	holder        string
+	ttl           time.Duration
+	renewInterval time.Duration
+	onPromote     func()
+	onDemote      func()
+
+	leader atomic.Bool
+	// renewedAt is when the last successful TryAcquire started, the lease lasting at least
+	// ttl from then
+	renewedAt time.Time
+}
+
+type NewElectorOpts struct {
+	LeaseRepo relayer.LeaseRepository
+	// Name is the lease to compete for, DefaultLeaseName if empty
+	Name string
+	// Holder tells this instance apart from the others, the hostname and pid if empty
+	Holder string
+	TTL    time.Duration
+	// RenewInterval is how often the lease is renewed or tried for, a third of TTL if zero
+	RenewInterval time.Duration
+	// OnPromote is called when this instance becomes the leader
+	OnPromote func()
+	// OnDemote is called when this instance stops being the leader, before another can take over
+	OnDemote func()
+}
+
+func NewElector(opts NewElectorOpts) (*Elector, error) {
+	if opts.LeaseRepo == nil {
+		return nil, ErrNoLeaseRepository
+	}
+
+	if opts.TTL <= 0 {
+		return nil, ErrInvalidTTL
+	}
+
+	name := opts.Name
+	if name == "" {
+		name = DefaultLeaseName
+	}
+
+	holder := opts.Holder
+	if holder == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, ErrNoHolder
+		}
+
+		holder = fmt.Sprintf("%v-%v", hostname, os.Getpid())
+	}
+
+	renewInterval := opts.RenewInterval
+	if renewInterval == 0 {
+		renewInterval = opts.TTL / 3
+	}
+
+	// the leader steps down a renew interval before its lease could expire, which needs time to renew first
+	if renewInterval <= 0 || 2*renewInterval >= opts.TTL {
+		return nil, ErrInvalidRenewInterval
+	}
+
+	onPromote, onDemote := opts.OnPromote, opts.OnDemote
+	if onPromote == nil {
+		onPromote = func() {}
+	}
+
+	if onDemote == nil {
+		onDemote = func() {}
+	}
+
+	return &Elector{
+		leaseRepo:     opts.LeaseRepo,
+		name:          name,
+		holder:        holder,
+		ttl:           opts.TTL,
+		renewInterval: renewInterval,
+		onPromote:     onPromote,
+		onDemote:      onDemote,
+	}, nil
+}
+
+// Start campaigns for the lease straight away, then every renew interval until ctx is done,
+// when it steps down and releases the lease if it has it.
+func (e *Elector) Start(ctx context.Context) {
+	ticker := time.NewTicker(e.renewInterval)
+	defer ticker.Stop()
+
+	for {
+		e.campaign(ctx)
+
+		select {
+		case <-ctx.Done():
+			e.stepDown()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Role reports whether this instance is the leader, or on standby
+func (e *Elector) Role() relayer.Role {
+	if e.leader.Load() {
+		return relayer.LeaderRole
+	}
+
+	return relayer.StandbyRole
+}
+
+// Holder is what this instance is known as in the lease
+func (e *Elector) Holder() string {
+	return e.holder
+}
+
+// campaign takes or renews the lease. When it can't be renewed, because another instance
+// has it or the database can't be reached, the leader is demoted once its lease could be
+// about to expire, a renew interval early so it has stopped sending before another takes over.
+func (e *Elector) campaign(ctx context.Context) {
+	startedAt := time.Now()
+
+	// a hanging query mustn't keep the leader from stepping down in time
+	ctx, cancel := context.WithTimeout(ctx, e.renewInterval)
+	defer cancel()
+
+	acquired, err := e.leaseRepo.TryAcquire(ctx, e.name, e.holder, e.ttl)
+	if err != nil {
+		log.Errorf("leader election, e.leaseRepo.TryAcquire: %v", err)
+
+		if e.leader.Load() && time.Since(e.renewedAt) >= e.ttl-e.renewInterval {
+			e.demote("lease could not be renewed before it expires")
+		}
+
+		return
+	}
+
+	if !acquired {
+		if e.leader.Load() {
+			e.demote("lease taken by another instance")
+		}
+
+		return
+	}
+
+	e.renewedAt = startedAt
+
+	if e.leader.CompareAndSwap(false, true) {
+		relayer.Leader.Set(1)
+
+		log.Infof("leader election, %v promoted to leader", e.holder)
+
+		e.onPromote()
+	}
+}
+
+func (e *Elector) demote(reason string) {
+	if !e.leader.CompareAndSwap(true, false) {
+		return
+	}
+
+	relayer.Leader.Set(0)
+
+	log.Warnf("leader election, %v demoted to standby: %v", e.holder, reason)
+
+	e.onDemote()
+}
+
+// stepDown demotes this instance, then releases the lease so a standby can take over
+// without waiting for it to expire
+func (e *Elector) stepDown() {
+	if !e.leader.Load() {
+		return
+	}
+
+	e.demote("shutting down")
+
+	ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+	defer cancel()
+
+	if err := e.leaseRepo.Release(ctx, e.name, e.holder); err != nil {
+		log.Errorf("leader election, e.leaseRepo.Release: %v", err)
+	}
+}
@@ -0,0 +1,152 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewElector(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    NewElectorOpts
+		wantErr error
+	}{
+		{
+			"success",
+			NewElectorOpts{LeaseRepo: mock.NewLeaseRepository(), TTL: 15 * time.Second},
+			nil,
+		},
+		{
+			"noLeaseRepo",
+			NewElectorOpts{TTL: 15 * time.Second},
+			ErrNoLeaseRepository,
+		},
+		{
+			"invalidTTL",
+			NewElectorOpts{LeaseRepo: mock.NewLeaseRepository()},
+			ErrInvalidTTL,
+		},
+		{
+			"renewIntervalTooLong",
+			NewElectorOpts{LeaseRepo: mock.NewLeaseRepository(), TTL: 15 * time.Second, RenewInterval: 10 * time.Second},
+			ErrInvalidRenewInterval,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewElector(tt.opts)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func newTestElector(t *testing.T, repo relayer.LeaseRepository, holder string, promoted, demoted *int) *Elector {
+	e, err := NewElector(NewElectorOpts{
+		LeaseRepo:     repo,
+		Holder:        holder,
+		TTL:           300 * time.Millisecond,
+		RenewInterval: 50 * time.Millisecond,
+		OnPromote:     func() { *promoted++ },
+		OnDemote:      func() { *demoted++ },
+	})
+	assert.Nil(t, err)
+
+	return e
+}
+
+func Test_campaign_onlyOneLeader(t *testing.T) {
+	repo := mock.NewLeaseRepository()
+
+	var aPromoted, aDemoted, bPromoted, bDemoted int
+
+	a := newTestElector(t, repo, "a", &aPromoted, &aDemoted)
+	b := newTestElector(t, repo, "b", &bPromoted, &bDemoted)
+
+	a.campaign(context.Background())
+	b.campaign(context.Background())
+
+	assert.Equal(t, relayer.LeaderRole, a.Role())
+	assert.Equal(t, relayer.StandbyRole, b.Role())
+
+	// renewing doesn't promote again
+	a.campaign(context.Background())
+	assert.Equal(t, 1, aPromoted)
+	assert.Equal(t, 0, bPromoted)
+
+	// b takes over once a steps down
+	a.stepDown()
+	b.campaign(context.Background())
+
+	assert.Equal(t, relayer.StandbyRole, a.Role())
+	assert.Equal(t, relayer.LeaderRole, b.Role())
+	assert.Equal(t, 1, aDemoted)
+	assert.Equal(t, 1, bPromoted)
+
+	// a sees b has the lease without demoting again
+	a.campaign(context.Background())
+	assert.Equal(t, relayer.StandbyRole, a.Role())
+	assert.Equal(t, 1, aDemoted)
+}
+
+func Test_campaign_demotesBeforeLeaseExpires(t *testing.T) {
+	repo := mock.NewLeaseRepository()
+
+	var promoted, demoted int
+
+	e := newTestElector(t, repo, "a", &promoted, &demoted)
+
+	e.campaign(context.Background())
+	assert.Equal(t, relayer.LeaderRole, e.Role())
+
+	repo.Err = errors.New("database unreachable")
+
+	// still leader while the lease has longer than a renew interval left
+	e.campaign(context.Background())
+	assert.Equal(t, relayer.LeaderRole, e.Role())
+
+	time.Sleep(260 * time.Millisecond)
+
+	e.campaign(context.Background())
+	assert.Equal(t, relayer.StandbyRole, e.Role())
+	assert.Equal(t, 1, demoted)
+
+	// and it hasn't expired yet, so no other instance can have started sending
+	assert.Equal(t, "a", repo.Holder(DefaultLeaseName))
+}
+
+func Test_Start_promotesStandbyWhenLeaderGoesAway(t *testing.T) {
+	repo := mock.NewLeaseRepository()
+
+	var aPromoted, aDemoted, bPromoted, bDemoted int
+
+	a := newTestElector(t, repo, "a", &aPromoted, &aDemoted)
+	a.campaign(context.Background())
+
+	b := newTestElector(t, repo, "b", &bPromoted, &bDemoted)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		b.Start(ctx)
+		close(done)
+	}()
+
+	// a stops renewing without releasing, as if it crashed
+	assert.Eventually(t, func() bool {
+		return b.Role() == relayer.LeaderRole
+	}, 300*time.Millisecond+200*time.Millisecond, 10*time.Millisecond)
+
+	cancel()
+	<-done
+
+	assert.Equal(t, relayer.StandbyRole, b.Role())
+	assert.Equal(t, "", repo.Holder(DefaultLeaseName))
+}
@@ -0,0 +1,10 @@
+package leader
+
+import "github.com/pkg/errors"
+
+var (
+	ErrNoLeaseRepository    = errors.New("leader: lease repository is required")
+	ErrNoHolder             = errors.New("leader: holder is required")
+	ErrInvalidTTL           = errors.New("leader: lease TTL must be positive")
+	ErrInvalidRenewInterval = errors.New("leader: renew interval must be positive and less than half the lease TTL")
+)
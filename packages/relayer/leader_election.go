@@ -0,0 +1,28 @@
+package relayer
+
+import (
+	"context"
+	"time"
+)
+
+// Lease is a database model for a named lease, held by Holder until ExpiresAt unless renewed
+type Lease struct {
+	Name      string    `json:"name"`
+	Holder    string    `json:"holder"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// LeaseRepository hands out named leases, each held by one holder at a time. Expiry is
+// measured by the store's clock, so holders' clocks drifting apart doesn't matter.
+type LeaseRepository interface {
+	// TryAcquire takes the lease called name for holder for ttl if it's free or expired, or renews
+	// it if holder already has it, and reports whether holder has it
+	TryAcquire(ctx context.Context, name string, holder string, ttl time.Duration) (bool, error)
+	// Release expires the lease called name if holder has it, so another can take it straight away
+	Release(ctx context.Context, name string, holder string) error
+}
+
+// RoleReporter reports whether an instance is the leader, or on standby
+type RoleReporter interface {
+	Role() Role
+}
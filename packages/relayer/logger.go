@@ -0,0 +1,32 @@
+package relayer
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	log "github.com/sirupsen/logrus"
+)
+
+type loggerKey struct{}
+
+// WithMessageLogger attaches a logger to ctx carrying a message's hash and its source and
+// destination chain IDs, so every line logged for the message through Logger shares them
+// and it can be followed from indexing to processing by filtering on msgHash.
+func WithMessageLogger(ctx context.Context, msgHash common.Hash, srcChainID, destChainID *big.Int) context.Context {
+	return context.WithValue(ctx, loggerKey{}, Logger(ctx).WithFields(log.Fields{
+		"msgHash":     msgHash.Hex(),
+		"srcChainID":  srcChainID.String(),
+		"destChainID": destChainID.String(),
+	}))
+}
+
+// Logger returns the logger attached to ctx with WithMessageLogger, or the standard logger
+// if there isn't one.
+func Logger(ctx context.Context) *log.Entry {
+	if logger, ok := ctx.Value(loggerKey{}).(*log.Entry); ok {
+		return logger
+	}
+
+	return log.NewEntry(log.StandardLogger())
+}
@@ -0,0 +1,44 @@
+package relayer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Logger(t *testing.T) {
+	assert.Equal(t, log.StandardLogger(), Logger(context.Background()).Logger)
+	assert.Empty(t, Logger(context.Background()).Data)
+
+	var buf bytes.Buffer
+
+	out, formatter := log.StandardLogger().Out, log.StandardLogger().Formatter
+
+	log.SetOutput(&buf)
+	log.SetFormatter(&log.JSONFormatter{})
+
+	defer func() {
+		log.SetOutput(out)
+		log.SetFormatter(formatter)
+	}()
+
+	msgHash := common.HexToHash("0x1")
+
+	ctx := WithMessageLogger(context.Background(), msgHash, big.NewInt(1), big.NewInt(2))
+
+	Logger(ctx).Info("processing")
+
+	var line map[string]interface{}
+
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &line))
+	assert.Equal(t, msgHash.Hex(), line["msgHash"])
+	assert.Equal(t, "1", line["srcChainID"])
+	assert.Equal(t, "2", line["destChainID"])
+	assert.Equal(t, "processing", line["msg"])
+}
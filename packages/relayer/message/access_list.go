@@ -0,0 +1,174 @@
+package message
+
+import (
+	"context"
+	"strings"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+)
+
+// methodNotFoundErrors are substrings of the errors nodes answer with when they don't have
+// a method, i.e. eth_createAccessList on a node that predates EIP-2930.
+var methodNotFoundErrors = []string{
+	"does not exist/is not available",
+	"method not found",
+	"not supported",
+}
+
+// createdAccessList is eth_createAccessList's result
+type createdAccessList struct {
+	AccessList types.AccessList `json:"accessList"`
+	GasUsed    hexutil.Uint64   `json:"gasUsed"`
+	// Error is why the transaction would revert, even with the access list
+	Error string `json:"error,omitempty"`
+}
+
+// withAccessList has the transaction auth signs carry an access list, made with the destination
+// node's eth_createAccessList, when that lowers its estimated gas. Access lists are only for
+// type-2 transactions, so ones priced with a legacy gas price are left as they are. Whatever
+// sends the transaction signs it with auth, so the access list is made for the call that's
+// really sent, through a forwarder or not.
+func (p *Processor) withAccessList(ctx context.Context, auth *bind.TransactOpts) {
+	if p.accessListRPC == nil || p.accessListUnsupported.Load() || auth.GasTipCap == nil {
+		return
+	}
+
+	signer := auth.Signer
+
+	auth.Signer = func(from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		if tx.Type() == types.DynamicFeeTxType && len(tx.AccessList()) == 0 {
+			tx = p.attachAccessList(ctx, from, tx)
+		}
+
+		return signer(from, tx)
+	}
+}
+
+// attachAccessList returns tx with the access list the destination node makes for it, if the
+// node estimates it uses less gas with it than without, otherwise tx as it is. The gas it
+// saves is logged and counted, to tell if they're worth it.
+func (p *Processor) attachAccessList(
+	ctx context.Context,
+	from common.Address,
+	tx *types.Transaction,
+) *types.Transaction {
+	var created createdAccessList
+
+	if err := p.accessListRPC.CallContext(ctx, &created, "eth_createAccessList", callArg(from, tx, nil)); err != nil {
+		if isMethodNotFoundError(err) {
+			relayer.Logger(ctx).Warnf("destination node doesn't support eth_createAccessList, sending without: %v", err)
+
+			p.accessListUnsupported.Store(true)
+
+			relayer.AccessLists.WithLabelValues("unsupported").Inc()
+		} else {
+			relayer.Logger(ctx).Errorf("eth_createAccessList: %v", err)
+
+			relayer.AccessLists.WithLabelValues("error").Inc()
+		}
+
+		return tx
+	}
+
+	if created.Error != "" || len(created.AccessList) == 0 {
+		relayer.AccessLists.WithLabelValues("not_lower").Inc()
+
+		return tx
+	}
+
+	var without, with hexutil.Uint64
+
+	if err := p.accessListRPC.CallContext(ctx, &without, "eth_estimateGas", callArg(from, tx, nil)); err != nil {
+		relayer.Logger(ctx).Errorf("eth_estimateGas: %v", err)
+		relayer.AccessLists.WithLabelValues("error").Inc()
+
+		return tx
+	}
+
+	if err := p.accessListRPC.CallContext(
+		ctx,
+		&with,
+		"eth_estimateGas",
+		callArg(from, tx, created.AccessList),
+	); err != nil {
+		relayer.Logger(ctx).Errorf("eth_estimateGas: %v", err)
+		relayer.AccessLists.WithLabelValues("error").Inc()
+
+		return tx
+	}
+
+	if with >= without {
+		relayer.Logger(ctx).Infof(
+			"access list doesn't lower estimated gas, %v with it, %v without",
+			uint64(with),
+			uint64(without),
+		)
+
+		relayer.AccessLists.WithLabelValues("not_lower").Inc()
+
+		return tx
+	}
+
+	relayer.Logger(ctx).Infof(
+		"access list lowers estimated gas by %v, from %v to %v, over %v addresses",
+		uint64(without-with),
+		uint64(without),
+		uint64(with),
+		len(created.AccessList),
+	)
+
+	relayer.AccessLists.WithLabelValues("used").Inc()
+	relayer.AccessListGasSaved.Add(float64(without - with))
+
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:    tx.ChainId(),
+		Nonce:      tx.Nonce(),
+		GasTipCap:  tx.GasTipCap(),
+		GasFeeCap:  tx.GasFeeCap(),
+		Gas:        tx.Gas(),
+		To:         tx.To(),
+		Value:      tx.Value(),
+		Data:       tx.Data(),
+		AccessList: created.AccessList,
+	})
+}
+
+// callArg is tx as an eth_call style argument, from from, with accessList if it's not nil
+func callArg(from common.Address, tx *types.Transaction, accessList types.AccessList) map[string]interface{} {
+	arg := map[string]interface{}{
+		"from":                 from,
+		"to":                   tx.To(),
+		"data":                 hexutil.Bytes(tx.Data()),
+		"value":                (*hexutil.Big)(tx.Value()),
+		"maxFeePerGas":         (*hexutil.Big)(tx.GasFeeCap()),
+		"maxPriorityFeePerGas": (*hexutil.Big)(tx.GasTipCap()),
+	}
+
+	if accessList != nil {
+		arg["accessList"] = accessList
+	}
+
+	return arg
+}
+
+// isMethodNotFoundError reports whether err is a node saying it doesn't have the method called
+func isMethodNotFoundError(err error) bool {
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == -32601 {
+		return true
+	}
+
+	for _, s := range methodNotFoundErrors {
+		if strings.Contains(strings.ToLower(err.Error()), s) {
+			return true
+		}
+	}
+
+	return false
+}
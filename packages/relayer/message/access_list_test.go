@@ -0,0 +1,182 @@
+package message
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var testAccessList = types.AccessList{
+	{Address: common.HexToAddress("0x01"), StorageKeys: []common.Hash{common.HexToHash("0x02")}},
+}
+
+// accessListCaller answers eth_createAccessList with testAccessList, and eth_estimateGas with
+// gasWith or gasWithout, whether it's asked about the call with an access list or not.
+type accessListCaller struct {
+	createErr  error
+	gasWith    uint64
+	gasWithout uint64
+	calls      int
+}
+
+func (c *accessListCaller) CallContext(
+	ctx context.Context,
+	result interface{},
+	method string,
+	args ...interface{},
+) error {
+	c.calls++
+
+	switch method {
+	case "eth_createAccessList":
+		if c.createErr != nil {
+			return c.createErr
+		}
+
+		b, _ := json.Marshal(createdAccessList{AccessList: testAccessList, GasUsed: hexutil.Uint64(c.gasWith)})
+
+		return json.Unmarshal(b, result)
+	case "eth_estimateGas":
+		gas := c.gasWithout
+		if _, ok := args[0].(map[string]interface{})["accessList"]; ok {
+			gas = c.gasWith
+		}
+
+		b, _ := json.Marshal(hexutil.Uint64(gas))
+
+		return json.Unmarshal(b, result)
+	}
+
+	return nil
+}
+
+type methodNotFoundError struct{}
+
+func (e *methodNotFoundError) Error() string {
+	return "the method eth_createAccessList does not exist/is not available"
+}
+
+func (e *methodNotFoundError) ErrorCode() int { return -32601 }
+
+func Test_withAccessList(t *testing.T) {
+	tests := []struct {
+		name            string
+		caller          *accessListCaller
+		legacy          bool
+		wantAccessList  types.AccessList
+		wantUnsupported bool
+		wantCalls       int
+	}{
+		{
+			"lowersGas",
+			&accessListCaller{gasWith: 90000, gasWithout: 100000},
+			false,
+			testAccessList,
+			false,
+			6,
+		},
+		{
+			"doesntLowerGas",
+			&accessListCaller{gasWith: 100000, gasWithout: 100000},
+			false,
+			nil,
+			false,
+			6,
+		},
+		{
+			"unsupported",
+			&accessListCaller{createErr: &methodNotFoundError{}},
+			false,
+			nil,
+			true,
+			1,
+		},
+		{
+			"otherError",
+			&accessListCaller{createErr: errors.New("timeout")},
+			false,
+			nil,
+			false,
+			2,
+		},
+		{
+			"legacyGasPrice",
+			&accessListCaller{gasWith: 90000, gasWithout: 100000},
+			true,
+			nil,
+			false,
+			0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestProcessor(true)
+			p.accessListRPC = tt.caller
+
+			to := common.HexToAddress("0x03")
+
+			// sending twice shows whether an unsupported node is asked again
+			for i := 0; i < 2; i++ {
+				auth, err := bind.NewKeyedTransactorWithChainID(p.keys.keys[0].key, big.NewInt(1))
+				assert.Nil(t, err)
+
+				var tx *types.Transaction
+
+				if tt.legacy {
+					auth.GasPrice = big.NewInt(1)
+					tx = types.NewTx(&types.LegacyTx{To: &to, Gas: 200000, GasPrice: auth.GasPrice})
+				} else {
+					auth.GasTipCap = big.NewInt(1)
+					tx = types.NewTx(&types.DynamicFeeTx{
+						ChainID:   big.NewInt(1),
+						To:        &to,
+						Gas:       200000,
+						GasTipCap: auth.GasTipCap,
+						GasFeeCap: big.NewInt(2),
+					})
+				}
+
+				p.withAccessList(context.Background(), auth)
+
+				signed, err := auth.Signer(auth.From, tx)
+				assert.Nil(t, err)
+
+				assert.Equal(t, len(tt.wantAccessList), len(signed.AccessList()))
+
+				if len(tt.wantAccessList) > 0 {
+					assert.Equal(t, tt.wantAccessList, signed.AccessList())
+				}
+				assert.Equal(t, tx.Gas(), signed.Gas())
+			}
+
+			assert.Equal(t, tt.wantUnsupported, p.accessListUnsupported.Load())
+			assert.Equal(t, tt.wantCalls, tt.caller.calls)
+		})
+	}
+}
+
+func Test_withAccessList_disabled(t *testing.T) {
+	p := newTestProcessor(true)
+
+	auth, err := bind.NewKeyedTransactorWithChainID(p.keys.keys[0].key, big.NewInt(1))
+	assert.Nil(t, err)
+
+	auth.GasTipCap = big.NewInt(1)
+
+	to := common.HexToAddress("0x03")
+
+	p.withAccessList(context.Background(), auth)
+
+	signed, err := auth.Signer(auth.From, types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1), To: &to}))
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(signed.AccessList()))
+}
@@ -0,0 +1,344 @@
+package message
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// newBackendProcessor is a processor whose processMessage transactions are sent to, and mined by,
+// a mock.Backend with pendingNonce as the relayer's pending nonce
+func newBackendProcessor(pendingNonce uint64) (*Processor, *mock.Backend) {
+	p := newTestProcessor(false)
+
+	backend := mock.NewBackend(mock.MockChainID)
+	backend.SetPendingNonce(pendingNonce)
+
+	p.destEthClient = backend
+	p.txBuilder = backend
+	p.receiptTimeout = 50 * time.Millisecond
+	p.receiptPollInterval = time.Millisecond
+
+	return p, backend
+}
+
+func backendTestEvent() *bridge.BridgeMessageSent {
+	return &bridge.BridgeMessageSent{
+		Message: bridge.IBridgeMessage{
+			Id:            big.NewInt(0),
+			SrcChainId:    mock.MockChainID,
+			DestChainId:   mock.MockChainID,
+			DepositValue:  big.NewInt(0),
+			CallValue:     big.NewInt(0),
+			ProcessingFee: big.NewInt(1000000000),
+			GasLimit:      big.NewInt(1),
+		},
+		MsgHash: mock.SuccessMsgHash,
+	}
+}
+
+// sendAndWait sends event's processMessage transaction and waits for it, like ProcessMessage does
+// once the message can be proven
+func sendAndWait(p *Processor, event *bridge.BridgeMessageSent) error {
+	tx, err := p.sendProcessMessageCall(context.Background(), p.keys.keys[0], event, []byte{})
+	if err != nil {
+		return err
+	}
+
+	_, err = p.waitReceipt(context.Background(), tx, nil)
+
+	return err
+}
+
+func Test_sendProcessMessageCall_backend(t *testing.T) {
+	tests := []struct {
+		name          string
+		outcomes      []mock.TxOutcome
+		wantSent      int
+		wantRevertErr string
+	}{
+		{
+			"mined",
+			[]mock.TxOutcome{mock.TxSucceeds},
+			1,
+			"",
+		},
+		{
+			"pendingThenMined",
+			[]mock.TxOutcome{mock.TxPendingFor(3)},
+			1,
+			"",
+		},
+		{
+			"reverted",
+			[]mock.TxOutcome{mock.TxReverts("B:notReceived")},
+			1,
+			"B:notReceived",
+		},
+		{
+			"neverMinedReplaced",
+			[]mock.TxOutcome{mock.TxNeverMined, mock.TxNeverMined, mock.TxPendingFor(1)},
+			3,
+			"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, backend := newBackendProcessor(5)
+			backend.Script(tt.outcomes...)
+
+			err := sendAndWait(p, backendTestEvent())
+
+			if tt.wantRevertErr != "" {
+				var revertErr *RevertError
+				assert.True(t, errors.As(err, &revertErr))
+				assert.Equal(t, tt.wantRevertErr, revertErr.Reason)
+			} else {
+				assert.Nil(t, err)
+			}
+
+			sent := backend.Sent()
+			assert.Equal(t, tt.wantSent, len(sent))
+
+			// replacements reuse the nonce, and are signed for the destination chain too
+			for _, tx := range sent {
+				assert.Equal(t, uint64(5), tx.Nonce())
+				assert.Equal(t, mock.MockChainID, tx.ChainId())
+			}
+
+			assert.Equal(t, uint64(6), p.keys.keys[0].nonce)
+		})
+	}
+}
+
+func Test_waitReceipt_backendGasBumping(t *testing.T) {
+	p, backend := newBackendProcessor(0)
+	backend.Script(mock.TxNeverMined, mock.TxSucceeds)
+
+	assert.Nil(t, sendAndWait(p, backendTestEvent()))
+
+	sent := backend.Sent()
+	assert.Equal(t, 2, len(sent))
+
+	// bumped by gasBumpPercentage, enough for the node to accept the replacement
+	assert.Equal(t, bumpGas(sent[0].GasTipCap()), sent[1].GasTipCap())
+	assert.Equal(t, bumpGas(sent[0].GasFeeCap()), sent[1].GasFeeCap())
+	assert.Equal(t, sent[0].Data(), sent[1].Data())
+
+	// a replacement that doesn't raise the fees enough is rejected
+	gasBumpPercentage = 5
+
+	defer func() {
+		gasBumpPercentage = 20
+	}()
+
+	p, backend = newBackendProcessor(0)
+	backend.Script(mock.TxNeverMined)
+
+	err := sendAndWait(p, backendTestEvent())
+	assert.True(t, errors.Is(err, mock.ErrReplaceUnderpriced))
+	assert.Equal(t, 1, len(backend.Sent()))
+}
+
+func Test_sendProcessMessageCall_backendNonces(t *testing.T) {
+	p, backend := newBackendProcessor(3)
+
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, sendAndWait(p, backendTestEvent()))
+	}
+
+	sent := backend.Sent()
+	assert.Equal(t, 3, len(sent))
+
+	for i, tx := range sent {
+		assert.Equal(t, uint64(3+i), tx.Nonce())
+	}
+
+	// a transaction sent again after it's been mined is rejected rather than replayed
+	assert.Equal(t, mock.ErrNonceTooLow, backend.SendTransaction(context.Background(), sent[0]))
+}
+
+func Test_sendProcessMessageCall_backendWrongChain(t *testing.T) {
+	p, backend := newBackendProcessor(0)
+
+	// a processor that took another chain for the destination signs for that chain
+	p.destChainID = big.NewInt(1)
+
+	event := backendTestEvent()
+	event.Message.DestChainId = big.NewInt(1)
+
+	err := sendAndWait(p, event)
+	assert.True(t, errors.Is(err, mock.ErrInvalidSender))
+	assert.Empty(t, backend.Sent())
+}
+
+func Test_ProcessMessage_backendNonceReset(t *testing.T) {
+	tests := []struct {
+		name               string
+		outcomes           []mock.TxOutcome
+		wantDestNonce      uint64
+		wantTxBuilderReset int
+	}{
+		{
+			// a reverted transaction still used its nonce
+			"reverted",
+			[]mock.TxOutcome{mock.TxReverts("B:notReceived")},
+			6,
+			0,
+		},
+		{
+			// one that was never mined leaves a gap, so the node's pending nonce is used next,
+			// and the TxBuilder's, i.e. a forwarder's, is reset too
+			"neverMined",
+			[]mock.TxOutcome{mock.TxNeverMined, mock.TxNeverMined, mock.TxNeverMined, mock.TxNeverMined},
+			0,
+			1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, backend := newBackendProcessor(5)
+			backend.Script(tt.outcomes...)
+
+			err := p.ProcessMessage(context.Background(), backendTestEvent(), &relayer.Event{})
+			assert.NotNil(t, err)
+			assert.Equal(t, tt.wantDestNonce, p.keys.keys[0].nonce)
+			assert.Equal(t, tt.wantTxBuilderReset, backend.NonceResets())
+		})
+	}
+}
+
+func Test_ProcessMessage_backendSettlesPendingSent(t *testing.T) {
+	tests := []struct {
+		name         string
+		outcomes     []mock.TxOutcome
+		bridgeStatus relayer.EventStatus
+		wantStatus   relayer.EventStatus
+	}{
+		{
+			// a reverted transaction leaves the message new on the bridge
+			"reverted",
+			[]mock.TxOutcome{mock.TxReverts("B:notReceived")},
+			relayer.EventStatusNew,
+			relayer.EventStatusNew,
+		},
+		{
+			// one that's never mined times out, and the bridge says what became of the message
+			"timedOut",
+			[]mock.TxOutcome{mock.TxNeverMined, mock.TxNeverMined, mock.TxNeverMined, mock.TxNeverMined},
+			relayer.EventStatusRetriable,
+			relayer.EventStatusRetriable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, backend := newBackendProcessor(5)
+			backend.Script(tt.outcomes...)
+			p.destBridge.(*mock.Bridge).SetMessageStatus(mock.SuccessMsgHash, tt.bridgeStatus)
+
+			eventRepo := mock.NewEventRepository()
+			p.eventRepo = eventRepo
+
+			_, err := eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+				ChainID: mock.MockChainID,
+				Status:  relayer.EventStatusNew,
+				MsgHash: common.Hash(mock.SuccessMsgHash).Hex(),
+			})
+			assert.Nil(t, err)
+
+			e, err := eventRepo.FirstByMsgHash(context.Background(), common.Hash(mock.SuccessMsgHash).Hex())
+			assert.Nil(t, err)
+
+			assert.NotNil(t, p.ProcessMessage(context.Background(), backendTestEvent(), e))
+			assert.NotEmpty(t, backend.Sent())
+			assert.Equal(t, tt.wantStatus, e.Status)
+		})
+	}
+}
+
+func Test_ProcessMessage_backendLeavesStale(t *testing.T) {
+	p, backend := newBackendProcessor(5)
+	backend.Script(mock.TxSucceeds)
+	p.destBridge.(*mock.Bridge).SetMessageStatus(mock.SuccessMsgHash, relayer.EventStatusDone)
+
+	eventRepo := mock.NewEventRepository()
+	p.eventRepo = eventRepo
+
+	_, err := eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+		ChainID: mock.MockChainID,
+		Status:  relayer.EventStatusNew,
+		MsgHash: common.Hash(mock.SuccessMsgHash).Hex(),
+	})
+	assert.Nil(t, err)
+
+	e, err := eventRepo.FirstByMsgHash(context.Background(), common.Hash(mock.SuccessMsgHash).Hex())
+	assert.Nil(t, err)
+
+	// marked stale while it was being processed, before its transaction was recorded
+	e.Status = relayer.EventStatusStale
+
+	assert.Nil(t, p.ProcessMessage(context.Background(), backendTestEvent(), e))
+	assert.NotEmpty(t, backend.Sent())
+
+	// the processing result doesn't overwrite it
+	assert.Equal(t, relayer.EventStatusStale, e.Status)
+	assert.Equal(t, "", e.ProcessingTxHash)
+}
+
+func Test_sendProcessMessageCall_directions(t *testing.T) {
+	l1ChainID, l2ChainID := big.NewInt(1), big.NewInt(167001)
+
+	tests := []struct {
+		name        string
+		srcChainID  *big.Int
+		destChainID *big.Int
+	}{
+		{"l1ToL2", l1ChainID, l2ChainID},
+		{"l2ToL1", l2ChainID, l1ChainID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestProcessor(false)
+
+			// the processor for a direction sends to that direction's destination node
+			backend := mock.NewBackend(tt.destChainID)
+			backend.SetPendingNonce(0)
+
+			p.destEthClient = backend
+			p.txBuilder = backend
+			p.receiptTimeout = 50 * time.Millisecond
+			p.receiptPollInterval = time.Millisecond
+
+			event := backendTestEvent()
+			event.Message.SrcChainId = tt.srcChainID
+			event.Message.DestChainId = tt.destChainID
+
+			assert.Nil(t, sendAndWait(p, event))
+
+			sent := backend.Sent()
+			assert.Equal(t, 1, len(sent))
+			assert.Equal(t, tt.destChainID, sent[0].ChainId())
+			assert.Equal(t, mock.BackendBridgeAddress, *sent[0].To())
+
+			// a message going the other way is for the source chain, which this processor doesn't send to
+			reverse := backendTestEvent()
+			reverse.Message.SrcChainId = tt.destChainID
+			reverse.Message.DestChainId = tt.srcChainID
+
+			assert.True(t, errors.Is(sendAndWait(p, reverse), relayer.ErrChainIDMismatch))
+			assert.Equal(t, 1, len(backend.Sent()))
+		})
+	}
+}
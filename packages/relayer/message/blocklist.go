@@ -0,0 +1,96 @@
+package message
+
+import (
+	"context"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/tokenvault"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// blockIfBlocklisted marks the message EventStatusBlocked, and reports it's not to be relayed,
+// if its sender or recipient is on the blocklist.
+func (p *Processor) blockIfBlocklisted(
+	ctx context.Context,
+	event *bridge.BridgeMessageSent,
+	e *relayer.Event,
+) (bool, error) {
+	if p.blocklist == nil {
+		return false, nil
+	}
+
+	senders, recipients := messageParties(event.Message)
+
+	match, address, blocked := "sender", common.Address{}, false
+
+	for _, a := range senders {
+		if p.blocklist.Blocked(a) {
+			address, blocked = a, true
+			break
+		}
+	}
+
+	if !blocked {
+		match = "recipient"
+
+		for _, a := range recipients {
+			if p.blocklist.Blocked(a) {
+				address, blocked = a, true
+				break
+			}
+		}
+	}
+
+	if !blocked {
+		return false, nil
+	}
+
+	relayer.Logger(ctx).Warnf("not relaying, %v %v is on the blocklist", match, address.Hex())
+
+	relayer.BlockedMessages.WithLabelValues(match).Inc()
+
+	// unless it was e.g. marked stale in the meantime
+	if _, err := p.eventRepo.UpdateStatusIf(ctx, e.ID, e.Status, relayer.EventStatusBlocked); err != nil {
+		return true, errors.Wrap(err, "p.eventRepo.UpdateStatusIf")
+	}
+
+	return true, nil
+}
+
+// messageParties returns the addresses a message is sent from and to. For ERC20 transfers,
+// that includes who the tokens are from and to, as well as the token vaults.
+func messageParties(message bridge.IBridgeMessage) (senders []common.Address, recipients []common.Address) {
+	senders = []common.Address{message.Sender, message.Owner}
+	recipients = []common.Address{message.To}
+
+	if len(message.Data) < 4 {
+		return senders, recipients
+	}
+
+	tokenVaultABI, err := tokenvault.TokenVaultMetaData.GetAbi()
+	if err != nil {
+		return senders, recipients
+	}
+
+	method, err := tokenVaultABI.MethodById(message.Data[:4])
+	if err != nil || method.Name != "receiveERC20" {
+		return senders, recipients
+	}
+
+	inputs := make(map[string]interface{})
+	if err := method.Inputs.UnpackIntoMap(inputs, message.Data[4:]); err != nil {
+		return senders, recipients
+	}
+
+	if from, ok := inputs["from"].(common.Address); ok {
+		senders = append(senders, from)
+	}
+
+	if to, ok := inputs["to"].(common.Address); ok {
+		recipients = append(recipients, to)
+	}
+
+	return senders, recipients
+}
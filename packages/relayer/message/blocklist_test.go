@@ -0,0 +1,112 @@
+package message
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	// erc20Transfer is a receiveERC20 call, sending tokens from and to tokenHolder
+	// nolint: lll
+	erc20Transfer = common.Hex2Bytes("0c6fab8200000000000000000000000000000000000000000000000000000000000000800000000000000000000000004ec242468812b6ffc8be8ff423af7bd23108d9910000000000000000000000004ec242468812b6ffc8be8ff423af7bd23108d99100000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000007a68000000000000000000000000e4337137828c93d0046212ebda8a82a24356b67b000000000000000000000000000000000000000000000000000000000000001200000000000000000000000000000000000000000000000000000000000000a000000000000000000000000000000000000000000000000000000000000000e00000000000000000000000000000000000000000000000000000000000000004544553540000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000095465737445524332300000000000000000000000000000000000000000000000")
+	tokenHolder   = common.HexToAddress("0x4ec242468812b6ffc8be8ff423af7bd23108d991")
+	vault         = common.HexToAddress("0x01")
+	sender        = common.HexToAddress("0x02")
+	recipient     = common.HexToAddress("0x03")
+)
+
+func Test_messageParties(t *testing.T) {
+	senders, recipients := messageParties(bridge.IBridgeMessage{Sender: sender, Owner: sender, To: recipient})
+	assert.Equal(t, []common.Address{sender, sender}, senders)
+	assert.Equal(t, []common.Address{recipient}, recipients)
+
+	senders, recipients = messageParties(bridge.IBridgeMessage{
+		Sender: vault,
+		Owner:  sender,
+		To:     vault,
+		Data:   erc20Transfer,
+	})
+	assert.Equal(t, []common.Address{vault, sender, tokenHolder}, senders)
+	assert.Equal(t, []common.Address{vault, tokenHolder}, recipients)
+}
+
+func Test_blockIfBlocklisted(t *testing.T) {
+	tests := []struct {
+		name      string
+		blocklist relayer.Blocklist
+		message   bridge.IBridgeMessage
+		want      bool
+	}{
+		{
+			"noBlocklist",
+			nil,
+			bridge.IBridgeMessage{Sender: sender, To: recipient},
+			false,
+		},
+		{
+			"notBlocked",
+			&mock.Blocklist{Addresses: map[common.Address]bool{tokenHolder: true}},
+			bridge.IBridgeMessage{Sender: sender, To: recipient},
+			false,
+		},
+		{
+			"blockedSender",
+			&mock.Blocklist{Addresses: map[common.Address]bool{sender: true}},
+			bridge.IBridgeMessage{Sender: sender, To: recipient},
+			true,
+		},
+		{
+			"blockedRecipient",
+			&mock.Blocklist{Addresses: map[common.Address]bool{recipient: true}},
+			bridge.IBridgeMessage{Sender: sender, To: recipient},
+			true,
+		},
+		{
+			"blockedTokenHolder",
+			&mock.Blocklist{Addresses: map[common.Address]bool{tokenHolder: true}},
+			bridge.IBridgeMessage{Sender: vault, Owner: sender, To: vault, Data: erc20Transfer},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestProcessor(true)
+			p.blocklist = tt.blocklist
+
+			blocked, err := p.blockIfBlocklisted(
+				context.Background(),
+				&bridge.BridgeMessageSent{Message: tt.message},
+				&relayer.Event{},
+			)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, blocked)
+		})
+	}
+}
+
+func Test_ProcessMessage_blocked(t *testing.T) {
+	p := newTestProcessor(true)
+	p.blocklist = &mock.Blocklist{Addresses: map[common.Address]bool{sender: true}}
+
+	err := p.ProcessMessage(context.Background(), &bridge.BridgeMessageSent{
+		Message: bridge.IBridgeMessage{
+			Sender:        sender,
+			GasLimit:      big.NewInt(1),
+			DestChainId:   mock.MockChainID,
+			ProcessingFee: big.NewInt(1000000000),
+			SrcChainId:    mock.MockChainID,
+		},
+		MsgHash: mock.SuccessMsgHash,
+	}, &relayer.Event{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), p.keys.keys[0].nonce)
+}
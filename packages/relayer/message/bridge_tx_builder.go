@@ -0,0 +1,13 @@
+package message
+
+import (
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+)
+
+// bridgeTxBuilder calls processMessage on the destination bridge directly, whose transactions
+// only have the processor's nonces, so there's none of its own to reset.
+type bridgeTxBuilder struct {
+	relayer.Bridge
+}
+
+func (b bridgeTxBuilder) ResetNonce() {}
@@ -0,0 +1,38 @@
+package message
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/proof"
+)
+
+// checkSignalRoot compares the SignalService storage root encodedSignalProof is against with the
+// signal root the destination chain synced for the proof's block, which the bridge verifies it
+// against. If they differ, we're proving against the wrong block, and processMessage would revert.
+func (p *Processor) checkSignalRoot(ctx context.Context, encodedSignalProof []byte) error {
+	height, storageRoot, err := proof.SignalProofStorageRoot(encodedSignalProof)
+	if err != nil {
+		return errors.Wrap(err, "proof.SignalProofStorageRoot")
+	}
+
+	signalRoot, err := p.destHeaderSyncer.GetCrossChainSignalRoot(&bind.CallOpts{Context: ctx}, height)
+	if err != nil {
+		return errors.Wrap(err, "p.destHeaderSyncer.GetCrossChainSignalRoot")
+	}
+
+	if common.Hash(signalRoot) != storageRoot {
+		return errors.Wrapf(
+			proof.ErrSignalRootMismatch,
+			"destination synced signal root %v for block %v, but the proof is against storage root %v",
+			common.Hash(signalRoot).Hex(),
+			height,
+			storageRoot.Hex(),
+		)
+	}
+
+	return nil
+}
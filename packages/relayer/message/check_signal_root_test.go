@@ -0,0 +1,59 @@
+package message
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/proof"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ProcessMessage_signalRootMismatch(t *testing.T) {
+	p := newTestProcessor(true)
+
+	// the destination synced another root for the block, as if we proved against the wrong one
+	p.destHeaderSyncer = &mock.HeaderSyncer{SignalRoot: [32]byte{0x2}}
+
+	err := p.ProcessMessage(context.Background(), &bridge.BridgeMessageSent{
+		Message: bridge.IBridgeMessage{
+			GasLimit:      big.NewInt(1),
+			DestChainId:   mock.MockChainID,
+			ProcessingFee: big.NewInt(1000000000),
+			SrcChainId:    mock.MockChainID,
+		},
+		MsgHash: mock.SuccessMsgHash,
+	}, &relayer.Event{})
+
+	assert.True(t, errors.Is(err, proof.ErrSignalRootMismatch))
+	assert.Contains(t, err.Error(), "destination synced signal root 0x02")
+
+	// nothing was sent
+	assert.Equal(t, uint64(0), p.keys.keys[0].nonce)
+}
+
+func Test_checkSignalRoot(t *testing.T) {
+	p := newTestProcessor(true)
+
+	encodedSignalProof, err := p.prover.EncodedSignalProof(
+		context.Background(),
+		p.rpc,
+		proof.SignalService{Address: srcSignalService},
+		common.Address{},
+		mock.SuccessMsgHash,
+		mock.SuccessHeader,
+	)
+	assert.Nil(t, err)
+
+	assert.Nil(t, p.checkSignalRoot(context.Background(), encodedSignalProof))
+
+	p.destHeaderSyncer = &mock.HeaderSyncer{Fail: true}
+	assert.NotNil(t, p.checkSignalRoot(context.Background(), encodedSignalProof))
+
+	assert.True(t, errors.Is(p.checkSignalRoot(context.Background(), []byte{0x1}), proof.ErrMalformedProof))
+}
@@ -0,0 +1,130 @@
+package message
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+// ConfirmProcessed re-checks srcChainID's events left ProcessedUnconfirmed, marking those whose
+// processMessage transaction is now p.destConfirmationsBeforeDone blocks deep done, and requeueing
+// those whose transaction a reorg took off the destination chain, so they're processed again rather
+// than left done when they aren't.
+func (p *Processor) ConfirmProcessed(ctx context.Context, srcChainID *big.Int) error {
+	// the processor has just written them, which a lagging replica may not have yet
+	events, err := p.eventRepo.FindAllByStatus(
+		relayer.WithPrimaryReads(ctx),
+		srcChainID,
+		relayer.EventStatusProcessedUnconfirmed,
+	)
+	if err != nil {
+		return errors.Wrap(err, "p.eventRepo.FindAllByStatus")
+	}
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	destChainID, err := p.destNodeChainID(ctx)
+	if err != nil {
+		return errors.Wrap(err, "p.destNodeChainID")
+	}
+
+	head, err := p.destEthClient.BlockNumber(ctx)
+	if err != nil {
+		return errors.Wrap(err, "p.destEthClient.BlockNumber")
+	}
+
+	for _, e := range events {
+		ctx := relayer.WithMessageLogger(ctx, common.HexToHash(e.MsgHash), srcChainID, destChainID)
+
+		if err := p.confirmProcessed(ctx, e, head); err != nil {
+			return errors.Wrapf(err, "p.confirmProcessed(msgHash: %v)", e.MsgHash)
+		}
+	}
+
+	return nil
+}
+
+// confirmProcessed marks e done once its transaction has enough confirmations at head, or requeues
+// it if the transaction is no longer mined, or was mined again and reverted.
+func (p *Processor) confirmProcessed(ctx context.Context, e *relayer.Event, head uint64) error {
+	txHash := common.HexToHash(e.ProcessingTxHash)
+
+	receipt, err := p.destEthClient.TransactionReceipt(ctx, txHash)
+	if err != nil && !errors.Is(err, ethereum.NotFound) {
+		return errors.Wrap(err, "p.destEthClient.TransactionReceipt")
+	}
+
+	if errors.Is(err, ethereum.NotFound) || receipt.Status != types.ReceiptStatusSuccessful {
+		relayer.Logger(ctx).Warnf("txHash: %v was reorged out, the message is requeued", txHash.Hex())
+
+		relayer.ReorgedOutProcessedMessages.WithLabelValues(strconv.FormatInt(e.ChainID, 10)).Inc()
+
+		if err := p.requeueReorgedOut(ctx, e); err != nil {
+			return errors.Wrap(err, "p.requeueReorgedOut")
+		}
+
+		return nil
+	}
+
+	if head < receipt.BlockNumber.Uint64()+p.destConfirmationsBeforeDone {
+		return nil
+	}
+
+	relayer.Logger(ctx).Infof(
+		"txHash: %v has %v confirmations, the message is done",
+		txHash.Hex(),
+		head-receipt.BlockNumber.Uint64(),
+	)
+
+	// a reorg may have mined the transaction again in another block since it was processed
+	if err := p.eventRepo.UpdateProcessingBlock(ctx, e.ID, receipt.BlockNumber.Uint64()); err != nil {
+		return errors.Wrap(err, "p.eventRepo.UpdateProcessingBlock")
+	}
+
+	if err := p.eventRepo.UpdateStatus(ctx, e.ID, relayer.EventStatusDone); err != nil {
+		return errors.Wrap(err, "p.eventRepo.UpdateStatus")
+	}
+
+	relayer.DoneEvents.Inc()
+
+	// failing to record the time to done should not fail an otherwise confirmed message
+	var event bridge.BridgeMessageSent
+	if err := json.Unmarshal(e.Data, &event); err != nil {
+		relayer.Logger(ctx).Errorf("json.Unmarshal: %v", err)
+
+		return nil
+	}
+
+	if err := p.recordTimeToDone(ctx, &event, receipt, e); err != nil {
+		relayer.Logger(ctx).Errorf("p.recordTimeToDone: %v", err)
+	}
+
+	return nil
+}
+
+// requeueReorgedOut makes e new again, and due to be retried now, so the retry loop processes it
+// again. Its message's status on the destination bridge went back to new with the transaction.
+func (p *Processor) requeueReorgedOut(ctx context.Context, e *relayer.Event) error {
+	if err := p.eventRepo.UpdateStatus(ctx, e.ID, relayer.EventStatusNew); err != nil {
+		return errors.Wrap(err, "p.eventRepo.UpdateStatus")
+	}
+
+	now := time.Now()
+
+	if err := p.eventRepo.UpdateNextRetry(ctx, e.ID, relayer.RetryReasonTransient, &now); err != nil {
+		return errors.Wrap(err, "p.eventRepo.UpdateNextRetry")
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package message
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// processedUnconfirmed saves a message for event, sends and mines its processMessage transaction
+// on backend and marks it processed but unconfirmed, like ProcessMessage does
+func processedUnconfirmed(t *testing.T, p *Processor, eventRepo *mock.EventRepository) (*relayer.Event, common.Hash) {
+	event := backendTestEvent()
+	event.Raw.Topics = []common.Hash{}
+	event.Raw.BlockHash = common.HexToHash("0x1")
+
+	data, err := json.Marshal(event)
+	assert.Nil(t, err)
+
+	_, err = eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+		ChainID: mock.MockChainID,
+		Status:  relayer.EventStatusNew,
+		MsgHash: common.Hash(event.MsgHash).Hex(),
+		Data:    string(data),
+	})
+	assert.Nil(t, err)
+
+	e, err := eventRepo.FirstByMsgHash(context.Background(), common.Hash(event.MsgHash).Hex())
+	assert.Nil(t, err)
+
+	tx, err := p.sendProcessMessageCall(context.Background(), p.keys.keys[0], event, []byte{})
+	assert.Nil(t, err)
+
+	assert.Nil(t, eventRepo.MarkPendingSent(context.Background(), e.ID, tx.Hash()))
+
+	receipt, err := p.waitReceipt(context.Background(), tx, nil)
+	assert.Nil(t, err)
+
+	assert.Nil(t, eventRepo.MarkProcessedUnconfirmed(context.Background(), e.ID, receipt.TxHash))
+
+	return e, receipt.TxHash
+}
+
+func Test_ConfirmProcessed(t *testing.T) {
+	tests := []struct {
+		name       string
+		mine       uint64
+		reorg      bool
+		wantStatus relayer.EventStatus
+	}{
+		{
+			"notDeepEnough",
+			2,
+			false,
+			relayer.EventStatusProcessedUnconfirmed,
+		},
+		{
+			"confirmed",
+			3,
+			false,
+			relayer.EventStatusDone,
+		},
+		{
+			"reorgedOut",
+			0,
+			true,
+			relayer.EventStatusNew,
+		},
+		{
+			// a reorg after the transaction is deep enough is for the destination chain's finality to rule out
+			"reorgedOutAfterConfirmed",
+			3,
+			true,
+			relayer.EventStatusNew,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, backend := newBackendProcessor(0)
+			p.destConfirmationsBeforeDone = 3
+
+			eventRepo := mock.NewEventRepository()
+			p.eventRepo = eventRepo
+
+			e, txHash := processedUnconfirmed(t, p, eventRepo)
+
+			backend.Mine(tt.mine)
+
+			if tt.reorg {
+				backend.Reorg(txHash)
+			}
+
+			assert.Nil(t, p.ConfirmProcessed(context.Background(), mock.MockChainID))
+			assert.Equal(t, tt.wantStatus, e.Status)
+
+			if tt.wantStatus == relayer.EventStatusDone {
+				assert.NotZero(t, e.DoneTimestamp)
+			}
+
+			// requeued, it's due to be retried straight away
+			if tt.reorg {
+				assert.Equal(t, relayer.RetryReasonTransient, e.RetryReason)
+				assert.NotNil(t, e.NextRetryAt)
+				assert.False(t, e.NextRetryAt.After(time.Now()))
+			}
+		})
+	}
+}
+
+func Test_ConfirmProcessed_noConfirmations(t *testing.T) {
+	p, _ := newBackendProcessor(0)
+
+	eventRepo := mock.NewEventRepository()
+	p.eventRepo = eventRepo
+
+	// left unconfirmed by a run that needed confirmations
+	e, _ := processedUnconfirmed(t, p, eventRepo)
+
+	assert.Nil(t, p.ConfirmProcessed(context.Background(), mock.MockChainID))
+	assert.Equal(t, relayer.EventStatusDone, e.Status)
+}
+
+func Test_ProcessMessage_destConfirmationsBeforeDone(t *testing.T) {
+	p, backend := newBackendProcessor(0)
+	p.destConfirmationsBeforeDone = 2
+	p.destBridge.(*mock.Bridge).SetMessageStatus(mock.SuccessMsgHash, relayer.EventStatusDone)
+
+	eventRepo := mock.NewEventRepository()
+	p.eventRepo = eventRepo
+
+	_, err := eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+		ChainID: mock.MockChainID,
+		Status:  relayer.EventStatusNew,
+		MsgHash: common.Hash(mock.SuccessMsgHash).Hex(),
+	})
+	assert.Nil(t, err)
+
+	e, err := eventRepo.FirstByMsgHash(context.Background(), common.Hash(mock.SuccessMsgHash).Hex())
+	assert.Nil(t, err)
+
+	assert.Nil(t, p.ProcessMessage(context.Background(), backendTestEvent(), e))
+	assert.Equal(t, relayer.EventStatusProcessedUnconfirmed, e.Status)
+	assert.Equal(t, backend.Sent()[0].Hash().Hex(), e.ProcessingTxHash)
+	assert.NotNil(t, e.ProcessingBlockNumber)
+
+	// the destination chain reorgs the transaction out before it's confirmed
+	backend.Mine(1)
+	backend.Reorg(backend.Sent()[0].Hash())
+
+	assert.Nil(t, p.ConfirmProcessed(context.Background(), mock.MockChainID))
+	assert.Equal(t, relayer.EventStatusNew, e.Status)
+
+	// and when it's retried, it's sent again
+	assert.Nil(t, p.ProcessMessage(context.Background(), backendTestEvent(), e))
+	assert.Equal(t, 2, len(backend.Sent()))
+	assert.Equal(t, relayer.EventStatusProcessedUnconfirmed, e.Status)
+	assert.Equal(t, backend.Sent()[1].Hash().Hex(), e.ProcessingTxHash)
+}
@@ -0,0 +1,231 @@
+package message
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/proof"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DiagnoseMessage runs the checks ProcessMessage would, without waiting or sending anything,
+// to explain why a message hasn't been processed. A check that can't be run fails with the
+// error as its detail, so the others are still reported.
+func (p *Processor) DiagnoseMessage(
+	ctx context.Context,
+	event *bridge.BridgeMessageSent,
+	e *relayer.Event,
+) *relayer.MessageDiagnosis {
+	d := &relayer.MessageDiagnosis{
+		MsgHash:             common.Hash(event.MsgHash).Hex(),
+		Status:              e.Status.String(),
+		LastProcessingError: e.ProcessingError,
+	}
+
+	latestSyncedHeader, synced := p.diagnoseHeaderSynced(ctx, event)
+	d.HeaderSynced = synced
+
+	signalService, err := p.signalServiceFor(ctx, event)
+	if err != nil {
+		d.SignalSent = relayer.DiagnosisCheck{Detail: fmt.Sprintf("resolving the SignalService: %v", err)}
+		d.ProofVerifies = relayer.DiagnosisCheck{Detail: "not checked, the SignalService couldn't be resolved"}
+	} else {
+		d.SignalSent = p.diagnoseSignalSent(ctx, event, signalService)
+
+		if synced.Passed {
+			d.ProofVerifies = p.diagnoseProof(ctx, event, signalService, latestSyncedHeader)
+		} else {
+			d.ProofVerifies = relayer.DiagnosisCheck{Detail: "not checked, the block isn't synced yet"}
+		}
+	}
+
+	destStatus, err := p.destBridge.GetMessageStatus(&bind.CallOpts{Context: ctx}, event.MsgHash)
+	if err != nil {
+		d.DestStatus = relayer.DiagnosisCheck{Detail: fmt.Sprintf("calling getMessageStatus: %v", err)}
+	} else {
+		d.DestStatus = relayer.DiagnosisCheck{
+			Passed: relayer.EventStatus(destStatus) == relayer.EventStatusNew,
+			Detail: relayer.EventStatus(destStatus).String(),
+		}
+	}
+
+	text, rejectionReason := diagnosis(d, event, e, err == nil)
+	d.Diagnosis = text
+	d.Rejection = relayer.NewRejection(rejectionReason)
+
+	return d
+}
+
+// diagnoseHeaderSynced checks the destination chain has synced the block the message was sent
+// in, and returns the latest synced header to prove against.
+func (p *Processor) diagnoseHeaderSynced(
+	ctx context.Context,
+	event *bridge.BridgeMessageSent,
+) (common.Hash, relayer.DiagnosisCheck) {
+	latestSyncedHeader, err := p.destHeaderSyncer.GetCrossChainBlockHash(&bind.CallOpts{Context: ctx}, big.NewInt(0))
+	if err != nil {
+		return common.Hash{}, relayer.DiagnosisCheck{Detail: fmt.Sprintf("getting the latest synced header: %v", err)}
+	}
+
+	header, err := p.srcEthClient.HeaderByHash(ctx, latestSyncedHeader)
+	if err != nil {
+		return common.Hash{}, relayer.DiagnosisCheck{
+			Detail: fmt.Sprintf("getting synced header %v: %v", common.Hash(latestSyncedHeader).Hex(), err),
+		}
+	}
+
+	check := relayer.DiagnosisCheck{
+		Passed: header.Number.Uint64() >= event.Raw.BlockNumber,
+		Detail: fmt.Sprintf("sent in block %v, latest synced block is %v", event.Raw.BlockNumber, header.Number),
+	}
+
+	if !check.Passed {
+		check.Rejection = relayer.RejectionReasonNotYetSynced
+	}
+
+	return latestSyncedHeader, check
+}
+
+func (p *Processor) diagnoseSignalSent(
+	ctx context.Context,
+	event *bridge.BridgeMessageSent,
+	signalService proof.SignalService,
+) relayer.DiagnosisCheck {
+	sent, err := p.prover.IsSignalSent(
+		ctx,
+		p.rpc,
+		signalService.Address,
+		event.Raw.Address,
+		event.MsgHash,
+		new(big.Int).SetUint64(event.Raw.BlockNumber),
+	)
+	if err != nil {
+		return relayer.DiagnosisCheck{
+			Detail:    fmt.Sprintf("calling isSignalSent: %v", err),
+			Rejection: RejectionReasonFor(err),
+		}
+	}
+
+	check := relayer.DiagnosisCheck{
+		Passed: sent,
+		Detail: fmt.Sprintf(
+			"isSignalSent on SignalService %v at block %v is %v",
+			signalService.Address.Hex(),
+			event.Raw.BlockNumber,
+			sent,
+		),
+	}
+
+	if !sent {
+		check.Rejection = relayer.RejectionReasonSignalNotSent
+	}
+
+	return check
+}
+
+// diagnoseProof generates the proof ProcessMessage would send, and verifies it locally.
+func (p *Processor) diagnoseProof(
+	ctx context.Context,
+	event *bridge.BridgeMessageSent,
+	signalService proof.SignalService,
+	latestSyncedHeader common.Hash,
+) relayer.DiagnosisCheck {
+	provingBlock, err := p.provingBlockHash(ctx, event, latestSyncedHeader)
+	if err != nil {
+		return relayer.DiagnosisCheck{
+			Detail:    fmt.Sprintf("choosing the block to prove against: %v", err),
+			Rejection: RejectionReasonFor(err),
+		}
+	}
+
+	encodedSignalProof, err := p.prover.EncodedSignalProof(
+		ctx,
+		p.rpc,
+		signalService,
+		event.Raw.Address,
+		event.MsgHash,
+		provingBlock,
+	)
+	if err != nil {
+		return relayer.DiagnosisCheck{
+			Detail:    fmt.Sprintf("generating the proof: %v", err),
+			Rejection: RejectionReasonFor(err),
+		}
+	}
+
+	steps, err := proof.VerifySignalProof(ctx, p.rpc, proof.VerifySignalProofOpts{
+		SignalServiceAddress: signalService.Address,
+		SignalServiceVersion: signalService.Version,
+		ChainID:              signalService.ChainID,
+		App:                  event.Raw.Address,
+		Signal:               event.MsgHash,
+		EncodedProof:         encodedSignalProof,
+	})
+	if err != nil {
+		return relayer.DiagnosisCheck{Detail: fmt.Sprintf("failed after %v verification steps: %v", len(steps), err)}
+	}
+
+	return relayer.DiagnosisCheck{
+		Passed: true,
+		Detail: fmt.Sprintf("verified in %v steps, %v", len(steps), steps[len(steps)-1].Detail),
+	}
+}
+
+// diagnosis picks the most likely reason the message is stuck, in the order processing
+// would run into them, and the rejection reason it is, if it's one of them.
+func diagnosis(
+	d *relayer.MessageDiagnosis,
+	event *bridge.BridgeMessageSent,
+	e *relayer.Event,
+	destStatusKnown bool,
+) (string, relayer.RejectionReason) {
+	switch {
+	case e.Status == relayer.EventStatusDone:
+		return "the message has been processed", ""
+	case e.Status == relayer.EventStatusProcessedUnconfirmed:
+		return fmt.Sprintf(
+			"processMessage transaction %v was mined, waiting for enough confirmations to mark it done",
+			e.ProcessingTxHash,
+		), ""
+	case destStatusKnown && !d.DestStatus.Passed:
+		return fmt.Sprintf("the message is already %v on the destination chain, "+
+			"its status change hasn't been indexed yet", d.DestStatus.Detail), ""
+	case e.Status == relayer.EventStatusOutOfScope:
+		return fmt.Sprintf(
+			"the message is for chain ID %v, which isn't served, it won't be relayed",
+			event.Message.DestChainId,
+		), relayer.RejectionReasonOutOfScope
+	case e.Status == relayer.EventStatusStale:
+		return "the message was still new after the max message age, it's stale and won't be relayed",
+			relayer.RejectionReasonExpired
+	case e.Status == relayer.EventStatusBlocked:
+		return "the message's sender or recipient is on the blocklist, it won't be relayed",
+			relayer.RejectionReasonBlocked
+	case e.Status == relayer.EventStatusHeld:
+		return "the message is held for review, release it with POST /admin/messages/:msgHash/release", ""
+	case e.Status == relayer.EventStatusNewOnlyOwner ||
+		event.Message.GasLimit == nil || event.Message.GasLimit.Sign() == 0:
+		return "the message's gas limit is 0, only its owner can process it", ""
+	case e.Status == relayer.EventStatusPendingSent:
+		return fmt.Sprintf("processMessage transaction %v was sent, waiting on its receipt", e.ProcessingTxHash), ""
+	case !d.SignalSent.Passed:
+		return fmt.Sprintf("the signal isn't sent on the source chain: %v", d.SignalSent.Detail), d.SignalSent.Rejection
+	case !d.HeaderSynced.Passed:
+		return fmt.Sprintf(
+			"waiting for the block to be synced to the destination chain: %v",
+			d.HeaderSynced.Detail,
+		), d.HeaderSynced.Rejection
+	case !d.ProofVerifies.Passed:
+		return fmt.Sprintf("the signal proof doesn't verify: %v", d.ProofVerifies.Detail), d.ProofVerifies.Rejection
+	case !d.DestStatus.Passed:
+		return fmt.Sprintf("the destination bridge's status couldn't be checked: %v", d.DestStatus.Detail), ""
+	case e.ProcessingError != "":
+		return fmt.Sprintf("processing failed: %v", e.ProcessingError), e.RejectionReason
+	default:
+		return "no problem found, the message is waiting to be processed", ""
+	}
+}
@@ -0,0 +1,181 @@
+package message
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DiagnoseMessage(t *testing.T) {
+	tests := []struct {
+		name              string
+		msgHash           [32]byte
+		blockNumber       uint64
+		status            relayer.EventStatus
+		tamperedProof     bool
+		wantHeaderSynced  bool
+		wantProofVerifies bool
+		wantDestStatus    string
+		wantDiagnosis     string
+		wantRejection     relayer.RejectionReason
+	}{
+		{
+			"proofDoesntVerify",
+			mock.SuccessMsgHash,
+			1,
+			relayer.EventStatusNew,
+			true,
+			true,
+			false,
+			"new",
+			"the signal proof doesn't verify",
+			"",
+		},
+		{
+			"notSynced",
+			mock.SuccessMsgHash,
+			2,
+			relayer.EventStatusNew,
+			false,
+			false,
+			false,
+			"new",
+			"waiting for the block to be synced to the destination chain",
+			relayer.RejectionReasonNotYetSynced,
+		},
+		{
+			"doneOnDest",
+			[32]byte{0x3},
+			1,
+			relayer.EventStatusNew,
+			false,
+			true,
+			true,
+			"done",
+			"the message is already done on the destination chain",
+			"",
+		},
+		{
+			"blocked",
+			mock.SuccessMsgHash,
+			1,
+			relayer.EventStatusBlocked,
+			false,
+			true,
+			true,
+			"new",
+			"the message's sender or recipient is on the blocklist",
+			relayer.RejectionReasonBlocked,
+		},
+		{
+			"outOfScope",
+			mock.SuccessMsgHash,
+			1,
+			relayer.EventStatusOutOfScope,
+			false,
+			true,
+			true,
+			"new",
+			"the message is for chain ID",
+			relayer.RejectionReasonOutOfScope,
+		},
+		{
+			"stale",
+			mock.SuccessMsgHash,
+			1,
+			relayer.EventStatusStale,
+			false,
+			true,
+			true,
+			"new",
+			"the message was still new after the max message age",
+			relayer.RejectionReasonExpired,
+		},
+		{
+			"held",
+			mock.SuccessMsgHash,
+			1,
+			relayer.EventStatusHeld,
+			false,
+			true,
+			true,
+			"new",
+			"the message is held for review",
+			"",
+		},
+		{
+			"processed",
+			[32]byte{0x3},
+			1,
+			relayer.EventStatusDone,
+			false,
+			true,
+			true,
+			"done",
+			"the message has been processed",
+			"",
+		},
+		{
+			"processedUnconfirmed",
+			[32]byte{0x3},
+			1,
+			relayer.EventStatusProcessedUnconfirmed,
+			false,
+			true,
+			true,
+			"done",
+			"processMessage transaction",
+			"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestProcessor(true)
+			p.rpc = &mock.Caller{TamperedProof: tt.tamperedProof}
+
+			event := &bridge.BridgeMessageSent{
+				MsgHash: tt.msgHash,
+				Message: bridge.IBridgeMessage{GasLimit: big.NewInt(1), SrcChainId: mock.MockChainID},
+				Raw:     types.Log{BlockNumber: tt.blockNumber, Address: common.HexToAddress("0x1")},
+			}
+
+			d := p.DiagnoseMessage(context.Background(), event, &relayer.Event{
+				Status:          tt.status,
+				ProcessingError: "message not received",
+			})
+
+			assert.Equal(t, common.Hash(tt.msgHash).Hex(), d.MsgHash)
+			assert.Equal(t, tt.status.String(), d.Status)
+			assert.Equal(t, tt.wantHeaderSynced, d.HeaderSynced.Passed)
+			assert.True(t, d.SignalSent.Passed)
+			assert.Equal(t, tt.wantProofVerifies, d.ProofVerifies.Passed, d.ProofVerifies.Detail)
+			assert.Equal(t, tt.wantDestStatus, d.DestStatus.Detail)
+			assert.Equal(t, "message not received", d.LastProcessingError)
+			assert.True(t, strings.HasPrefix(d.Diagnosis, tt.wantDiagnosis), d.Diagnosis)
+			assert.Equal(t, relayer.NewRejection(tt.wantRejection), d.Rejection)
+		})
+	}
+}
+
+func Test_DiagnoseMessage_headerSyncerFails(t *testing.T) {
+	p := newTestProcessor(true)
+	p.destHeaderSyncer = &mock.HeaderSyncer{Fail: true}
+
+	d := p.DiagnoseMessage(context.Background(), &bridge.BridgeMessageSent{
+		MsgHash: mock.SuccessMsgHash,
+		Message: bridge.IBridgeMessage{GasLimit: big.NewInt(1)},
+	}, &relayer.Event{Status: relayer.EventStatusNew})
+
+	assert.False(t, d.HeaderSynced.Passed)
+	assert.Equal(t, "getting the latest synced header: fail", d.HeaderSynced.Detail)
+	assert.Equal(t, "not checked, the block isn't synced yet", d.ProofVerifies.Detail)
+}
@@ -13,6 +13,16 @@ var (
 		"Method eth_maxPriorityFeePerGas not found",
 	)
 
+	// ErrOnlyOwnerCanProcess is returned for a message with no gas limit, which only its owner can process
+	ErrOnlyOwnerCanProcess = errors.New("only user can process this, gasLimit set to 0")
+
+	// ErrMessageNotReceived is returned when the destination bridge doesn't see a message as received
+	// with the proof generated for it, i.e. the proof is against a block it hasn't synced
+	ErrMessageNotReceived = errors.New("message not received")
+
+	// ErrRelayerKeyCantPay is returned when the key picked to send a message from can't afford to
+	ErrRelayerKeyCantPay = errors.New("relayer key can't pay for the transaction")
+
 	// FallbackGasTipCap is the default fallback gasTipCap used when we are
 	// unable to query an L1 backend for a suggested gasTipCap.
 	FallbackGasTipCap = big.NewInt(1500000000)
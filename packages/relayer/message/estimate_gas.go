@@ -9,9 +9,15 @@ import (
 	"github.com/pkg/errors"
 )
 
+// estimateGas estimates processMessage's gas and cost when it's sent from key
 func (p *Processor) estimateGas(
-	ctx context.Context, message bridge.IBridgeMessage, proof []byte) (uint64, *big.Int, error) {
-	auth, err := bind.NewKeyedTransactorWithChainID(p.ecdsaKey, message.DestChainId)
+	ctx context.Context, key *relayerKey, message bridge.IBridgeMessage, proof []byte) (uint64, *big.Int, error) {
+	chainID, err := p.signerChainID(ctx, message.DestChainId)
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "p.signerChainID")
+	}
+
+	auth, err := bind.NewKeyedTransactorWithChainID(key.key, chainID)
 	if err != nil {
 		return 0, nil, errors.Wrap(err, "bind.NewKeyedTransactorWithChainID")
 	}
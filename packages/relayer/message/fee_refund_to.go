@@ -0,0 +1,50 @@
+package message
+
+import (
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// bridgeSupportsFeeRefundTo reports whether the Bridge we are built against lets the
+// relayer choose who receives the processing fee when calling processMessage.
+func bridgeSupportsFeeRefundTo() (bool, error) {
+	bridgeABI, err := bridge.BridgeMetaData.GetAbi()
+	if err != nil {
+		return false, errors.Wrap(err, "bridge.BridgeMetaData.GetAbi")
+	}
+
+	method, ok := bridgeABI.Methods["processMessage"]
+	if !ok {
+		return false, nil
+	}
+
+	for _, input := range method.Inputs {
+		if input.Name == "feeRefundTo" {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// warnIfTreasuryAddressUnsupported logs a warning if a treasury address is configured, but
+// the bridge always pays the processing fee to msg.sender. We ignore the setting rather than
+// failing startup, the fees just stay with the relayer key.
+func warnIfTreasuryAddressUnsupported(treasuryAddress common.Address) {
+	if treasuryAddress == relayer.ZeroAddress {
+		return
+	}
+
+	supported, err := bridgeSupportsFeeRefundTo()
+	if err != nil || !supported {
+		log.Warnf(
+			"treasury address %v is set, but processMessage does not support feeRefundTo, "+
+				"ignoring it and processing fees will be paid to the relayer address. err: %v",
+			treasuryAddress.Hex(),
+			err,
+		)
+	}
+}
@@ -0,0 +1,13 @@
+package message
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_bridgeSupportsFeeRefundTo(t *testing.T) {
+	supported, err := bridgeSupportsFeeRefundTo()
+	assert.Nil(t, err)
+	assert.False(t, supported)
+}
@@ -0,0 +1,49 @@
+package message
+
+import (
+	"context"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+)
+
+var (
+	// messageCallGasMultiplierPercent pads the gas limit of arbitrary calls. Unlike our own
+	// TokenVault, the target contract's state can change between estimating and executing
+	// in ways we can't predict.
+	messageCallGasMultiplierPercent uint64 = 150
+
+	// messageCallBaseGasLimit covers processMessage's own overhead when we are unable to
+	// estimate a message call, the message's gasLimit is added on top of it for the call itself.
+	messageCallBaseGasLimit uint64 = 500000
+)
+
+// gasLimitFor returns the gas limit to send processMessage with. The message's gasLimit is
+// the gas the bridge forwards to the call, so it is used as a floor for our estimate.
+func gasLimitFor(eventType relayer.EventType, estimate uint64, message bridge.IBridgeMessage) uint64 {
+	gas := estimate
+
+	call := relayer.DecodeMessageCall(message)
+	if call.GasLimit.IsUint64() && call.GasLimit.Uint64() > gas {
+		gas = call.GasLimit.Uint64()
+	}
+
+	if eventType == relayer.EventTypeMessageCall {
+		gas = gas * messageCallGasMultiplierPercent / 100
+	}
+
+	return gas
+}
+
+// withGasLimitFloor raises gas to the floor configured for the message's recipient, for contracts
+// whose gas use eth_estimateGas underestimates.
+func (p *Processor) withGasLimitFloor(ctx context.Context, message bridge.IBridgeMessage, gas uint64) uint64 {
+	floor, ok := p.gasLimitFloors[message.To]
+	if !ok || floor <= gas {
+		return gas
+	}
+
+	relayer.Logger(ctx).Infof("raising gas limit from %v to %v, the floor for recipient %v", gas, floor, message.To.Hex())
+
+	return floor
+}
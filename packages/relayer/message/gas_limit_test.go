@@ -0,0 +1,87 @@
+package message
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_gasLimitFor(t *testing.T) {
+	tests := []struct {
+		name      string
+		eventType relayer.EventType
+		estimate  uint64
+		gasLimit  *big.Int
+		want      uint64
+	}{
+		{
+			"estimateAboveMessageGasLimit",
+			relayer.EventTypeSendETH,
+			300000,
+			big.NewInt(100000),
+			300000,
+		},
+		{
+			"messageGasLimitIsFloor",
+			relayer.EventTypeSendERC20,
+			300000,
+			big.NewInt(400000),
+			400000,
+		},
+		{
+			"messageCallIsPadded",
+			relayer.EventTypeMessageCall,
+			300000,
+			big.NewInt(100000),
+			450000,
+		},
+		{
+			"messageCallFloorIsPadded",
+			relayer.EventTypeMessageCall,
+			300000,
+			big.NewInt(400000),
+			600000,
+		},
+		{
+			"nilMessageGasLimit",
+			relayer.EventTypeSendETH,
+			300000,
+			nil,
+			300000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := gasLimitFor(tt.eventType, tt.estimate, bridge.IBridgeMessage{GasLimit: tt.gasLimit})
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_withGasLimitFloor(t *testing.T) {
+	recipient := common.HexToAddress("0x1000777700000000000000000000000000000009")
+
+	p := newTestProcessor(false)
+	p.gasLimitFloors = map[common.Address]uint64{recipient: 800000}
+
+	assert.Equal(t, uint64(800000), p.withGasLimitFloor(context.Background(), bridge.IBridgeMessage{To: recipient}, 300000))
+	assert.Equal(t, uint64(900000), p.withGasLimitFloor(context.Background(), bridge.IBridgeMessage{To: recipient}, 900000))
+	assert.Equal(t, uint64(300000), p.withGasLimitFloor(context.Background(), bridge.IBridgeMessage{}, 300000))
+}
+
+func Test_sendProcessMessageCall_gasLimitFloor(t *testing.T) {
+	p, _ := newBackendProcessor(0)
+
+	event := backendTestEvent()
+	p.gasLimitFloors = map[common.Address]uint64{event.Message.To: 2000000}
+
+	tx, err := p.sendProcessMessageCall(context.Background(), p.keys.keys[0], event, []byte{})
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2000000), tx.Gas())
+}
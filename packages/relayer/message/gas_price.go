@@ -0,0 +1,43 @@
+package message
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+// suggestGasPrice is the gas oracle's price if one is configured, or the node's suggestion.
+func (p *Processor) suggestGasPrice(ctx context.Context) (*big.Int, error) {
+	if p.gasOracle != nil {
+		gasPrice, err := p.gasOracle.SuggestGasPrice(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "p.gasOracle.SuggestGasPrice")
+		}
+
+		return gasPrice, nil
+	}
+
+	gasPrice, err := p.destEthClient.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "p.destEthClient.SuggestGasPrice")
+	}
+
+	return gasPrice, nil
+}
+
+// gasFeeCap is the fee cap for a dynamic fee transaction with gasTipCap, leaving room for the
+// oracle's price to double like bind does for the basefee. It's nil without a gas oracle,
+// so bind caps fees from the latest header as it always has.
+func (p *Processor) gasFeeCap(ctx context.Context, gasTipCap *big.Int) (*big.Int, error) {
+	if p.gasOracle == nil {
+		return nil, nil
+	}
+
+	gasPrice, err := p.suggestGasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).Add(gasTipCap, new(big.Int).Mul(gasPrice, big.NewInt(2))), nil
+}
@@ -0,0 +1,44 @@
+package message
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_suggestGasPrice(t *testing.T) {
+	p := newTestProcessor(true)
+
+	gasPrice, err := p.suggestGasPrice(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(100), gasPrice)
+
+	p.gasOracle = &mock.GasOracle{}
+
+	gasPrice, err = p.suggestGasPrice(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, mock.GasOraclePrice, gasPrice)
+
+	p.gasOracle = &mock.GasOracle{Fail: true}
+
+	_, err = p.suggestGasPrice(context.Background())
+	assert.NotNil(t, err)
+}
+
+func Test_gasFeeCap(t *testing.T) {
+	p := newTestProcessor(true)
+
+	// bind caps fees itself without an oracle
+	gasFeeCap, err := p.gasFeeCap(context.Background(), big.NewInt(10))
+	assert.Nil(t, err)
+	assert.Nil(t, gasFeeCap)
+
+	p.gasOracle = &mock.GasOracle{}
+
+	gasFeeCap, err = p.gasFeeCap(context.Background(), big.NewInt(10))
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(2010), gasFeeCap)
+}
@@ -7,17 +7,19 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 )
 
-func (p *Processor) getLatestNonce(ctx context.Context, auth *bind.TransactOpts) error {
-	pendingNonce, err := p.destEthClient.PendingNonceAt(ctx, p.relayerAddr)
+// getLatestNonce sets auth's nonce to key's next nonce, or the node's pending nonce for it if
+// that's higher. key.mu must be held.
+func (p *Processor) getLatestNonce(ctx context.Context, auth *bind.TransactOpts, key *relayerKey) error {
+	pendingNonce, err := p.destEthClient.PendingNonceAt(ctx, key.addr)
 	if err != nil {
 		return err
 	}
 
-	if pendingNonce > p.destNonce {
-		p.setLatestNonce(pendingNonce)
+	if pendingNonce > key.nonce {
+		key.nonce = pendingNonce
 	}
 
-	auth.Nonce = big.NewInt(int64(p.destNonce))
+	auth.Nonce = big.NewInt(int64(key.nonce))
 
 	return nil
 }
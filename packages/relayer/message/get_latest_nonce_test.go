@@ -12,8 +12,8 @@ import (
 func Test_getLatestNonce(t *testing.T) {
 	p := newTestProcessor(true)
 
-	err := p.getLatestNonce(context.Background(), &bind.TransactOpts{})
+	err := p.getLatestNonce(context.Background(), &bind.TransactOpts{}, p.keys.keys[0])
 	assert.Nil(t, err)
 
-	assert.Equal(t, p.destNonce, mock.PendingNonce)
+	assert.Equal(t, p.keys.keys[0].nonce, mock.PendingNonce)
 }
@@ -0,0 +1,29 @@
+package message
+
+import (
+	"context"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+)
+
+// acquireInFlightSlot blocks until fewer than maxInFlightTxs of key's transactions are
+// unconfirmed. It must be held from before a nonce is assigned until the transaction
+// is mined or given up on, and released with releaseInFlightSlot.
+func (p *Processor) acquireInFlightSlot(ctx context.Context, key *relayerKey) error {
+	select {
+	case key.inFlight <- struct{}{}:
+		relayer.InFlightTransactions.Inc()
+		relayer.RelayerKeyInFlightTransactions.WithLabelValues(p.keys.labels(key)...).Inc()
+
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *Processor) releaseInFlightSlot(key *relayerKey) {
+	<-key.inFlight
+
+	relayer.InFlightTransactions.Dec()
+	relayer.RelayerKeyInFlightTransactions.WithLabelValues(p.keys.labels(key)...).Dec()
+}
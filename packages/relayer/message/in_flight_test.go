@@ -0,0 +1,29 @@
+package message
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_acquireInFlightSlot(t *testing.T) {
+	p := newTestProcessor(true)
+	key := p.keys.keys[0]
+	key.inFlight = make(chan struct{}, 2)
+
+	assert.Nil(t, p.acquireInFlightSlot(context.Background(), key))
+	assert.Nil(t, p.acquireInFlightSlot(context.Background(), key))
+
+	// both slots are taken, so the next acquire blocks until the context is done
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.Equal(t, context.DeadlineExceeded, p.acquireInFlightSlot(ctx, key))
+
+	p.releaseInFlightSlot(key)
+
+	assert.Nil(t, p.acquireInFlightSlot(context.Background(), key))
+	assert.Equal(t, 2, len(key.inFlight))
+}
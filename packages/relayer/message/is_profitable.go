@@ -3,11 +3,16 @@ package message
 import (
 	"context"
 	"math/big"
+	"strings"
 
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
-	log "github.com/sirupsen/logrus"
+	"github.com/pkg/errors"
 )
 
+// isProfitable reports whether message's processing fee, paid in p.feeToken, covers cost, paid in
+// p.destNativeToken. When they're different tokens, both are valued in ETH with p.priceOracle,
+// and compared as is without one.
 func (p *Processor) isProfitable(
 	ctx context.Context, message bridge.IBridgeMessage, cost *big.Int) (bool, error) {
 	processingFee := message.ProcessingFee
@@ -16,12 +21,31 @@ func (p *Processor) isProfitable(
 		return false, nil
 	}
 
-	shouldProcess := processingFee.Cmp(cost) == 1
+	fee, costs := new(big.Rat).SetInt(processingFee), new(big.Rat).SetInt(cost)
 
-	log.Infof(
-		"processingFee: %v, cost: %v, process: %v",
-		processingFee.Uint64(),
+	if p.priceOracle != nil && !strings.EqualFold(p.feeToken, p.destNativeToken) {
+		feePrice, err := p.priceOracle.PriceInETH(ctx, p.feeToken)
+		if err != nil {
+			return false, errors.Wrap(err, "p.priceOracle.PriceInETH(feeToken)")
+		}
+
+		costPrice, err := p.priceOracle.PriceInETH(ctx, p.destNativeToken)
+		if err != nil {
+			return false, errors.Wrap(err, "p.priceOracle.PriceInETH(destNativeToken)")
+		}
+
+		fee.Mul(fee, feePrice)
+		costs.Mul(costs, costPrice)
+	}
+
+	shouldProcess := fee.Cmp(costs) == 1
+
+	relayer.Logger(ctx).Infof(
+		"processingFee: %v %v, cost: %v %v, process: %v",
+		processingFee,
+		p.feeToken,
 		cost,
+		p.destNativeToken,
 		shouldProcess,
 	)
 
@@ -71,3 +71,70 @@ func Test_isProfitable(t *testing.T) {
 		})
 	}
 }
+
+func Test_isProfitable_priceOracle(t *testing.T) {
+	tests := []struct {
+		name            string
+		destNativeToken string
+		oracle          *mock.PriceOracle
+		processingFee   *big.Int
+		cost            *big.Int
+		wantProfitable  bool
+		wantErr         bool
+	}{
+		{
+			"feeWorthMoreInETH",
+			"ETH",
+			&mock.PriceOracle{Prices: map[string]*big.Rat{"MXC": big.NewRat(1, 1000), "ETH": big.NewRat(1, 1)}},
+			big.NewInt(2000),
+			big.NewInt(1),
+			true,
+			false,
+		},
+		{
+			"feeWorthLessInETH",
+			"ETH",
+			&mock.PriceOracle{Prices: map[string]*big.Rat{"MXC": big.NewRat(1, 1000), "ETH": big.NewRat(1, 1)}},
+			big.NewInt(500),
+			big.NewInt(1),
+			false,
+			false,
+		},
+		{
+			"sameTokenNotValued",
+			"MXC",
+			&mock.PriceOracle{Fail: true},
+			big.NewInt(2),
+			big.NewInt(1),
+			true,
+			false,
+		},
+		{
+			"oracleFails",
+			"ETH",
+			&mock.PriceOracle{Fail: true},
+			big.NewInt(2000),
+			big.NewInt(1),
+			false,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestProcessor(true)
+			p.feeToken = "MXC"
+			p.destNativeToken = tt.destNativeToken
+			p.priceOracle = tt.oracle
+
+			profitable, err := p.isProfitable(
+				context.Background(),
+				bridge.IBridgeMessage{ProcessingFee: tt.processingFee},
+				tt.cost,
+			)
+
+			assert.Equal(t, tt.wantProfitable, profitable)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
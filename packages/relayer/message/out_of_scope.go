@@ -0,0 +1,56 @@
+package message
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/pkg/errors"
+)
+
+// skipIfOutOfScope marks the message EventStatusOutOfScope, and reports it's not to be relayed,
+// if it's for a destination chain we don't serve. That's expected whenever the bridge supports
+// more chains than we relay to, so it's only logged at debug level, and counted by chain ID
+// to show the demand for supporting them.
+func (p *Processor) skipIfOutOfScope(
+	ctx context.Context,
+	event *bridge.BridgeMessageSent,
+	e *relayer.Event,
+) (bool, error) {
+	// a message without one can't be sent either, signing it fails with ErrChainIDMismatch
+	if event.Message.DestChainId == nil {
+		return false, nil
+	}
+
+	served, err := p.servesDestChain(ctx, event.Message.DestChainId)
+	if err != nil {
+		return false, errors.Wrap(err, "p.servesDestChain")
+	}
+
+	if served {
+		return false, nil
+	}
+
+	relayer.Logger(ctx).Debugf("not relaying, destChainId %v isn't served", event.Message.DestChainId)
+
+	relayer.OutOfScopeMessages.WithLabelValues(event.Message.DestChainId.String()).Inc()
+
+	// unless it was e.g. marked stale in the meantime
+	if _, err := p.eventRepo.UpdateStatusIf(ctx, e.ID, e.Status, relayer.EventStatusOutOfScope); err != nil {
+		return true, errors.Wrap(err, "p.eventRepo.UpdateStatusIf")
+	}
+
+	return true, nil
+}
+
+// servesDestChain reports whether the message is for the chain our destination node is on,
+// the only chain the processor relays to.
+func (p *Processor) servesDestChain(ctx context.Context, destChainID *big.Int) (bool, error) {
+	chainID, err := p.destNodeChainID(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return destChainID.Cmp(chainID) == 0, nil
+}
@@ -0,0 +1,59 @@
+package message
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_skipIfOutOfScope(t *testing.T) {
+	tests := []struct {
+		name        string
+		destChainID *big.Int
+		want        bool
+	}{
+		{"served", mock.MockChainID, false},
+		{"notServed", big.NewInt(1), true},
+		{"noChainID", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestProcessor(true)
+
+			skipped, err := p.skipIfOutOfScope(
+				context.Background(),
+				&bridge.BridgeMessageSent{Message: bridge.IBridgeMessage{DestChainId: tt.destChainID}},
+				&relayer.Event{},
+			)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, skipped)
+		})
+	}
+}
+
+func Test_ProcessMessage_outOfScope(t *testing.T) {
+	p := newTestProcessor(true)
+
+	before := testutil.ToFloat64(relayer.OutOfScopeMessages.WithLabelValues("5"))
+
+	err := p.ProcessMessage(context.Background(), &bridge.BridgeMessageSent{
+		Message: bridge.IBridgeMessage{
+			GasLimit:      big.NewInt(1),
+			DestChainId:   big.NewInt(5),
+			ProcessingFee: big.NewInt(1000000000),
+			SrcChainId:    mock.MockChainID,
+		},
+		MsgHash: mock.SuccessMsgHash,
+	}, &relayer.Event{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), p.keys.keys[0].nonce)
+	assert.Equal(t, before+1, testutil.ToFloat64(relayer.OutOfScopeMessages.WithLabelValues("5")))
+}
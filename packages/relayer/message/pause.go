@@ -0,0 +1,34 @@
+package message
+
+import (
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	log "github.com/sirupsen/logrus"
+)
+
+// Pause stops the processor sending processMessage transactions. ProcessMessage returns
+// relayer.ErrProcessingPaused instead, before sending, until Resume is called.
+func (p *Processor) Pause() {
+	if !p.paused.CompareAndSwap(false, true) {
+		return
+	}
+
+	relayer.PausedProcessors.Inc()
+
+	log.Info("processing paused")
+}
+
+// Resume undoes Pause
+func (p *Processor) Resume() {
+	if !p.paused.CompareAndSwap(true, false) {
+		return
+	}
+
+	relayer.PausedProcessors.Dec()
+
+	log.Info("processing resumed")
+}
+
+// Paused reports whether Pause has been called without a Resume since
+func (p *Processor) Paused() bool {
+	return p.paused.Load()
+}
@@ -0,0 +1,33 @@
+package message
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Pause(t *testing.T) {
+	p := newTestProcessor(true)
+
+	assert.False(t, p.Paused())
+
+	p.Pause()
+	p.Pause()
+	assert.True(t, p.Paused())
+
+	err := p.ProcessMessage(context.Background(), &bridge.BridgeMessageSent{
+		Message: bridge.IBridgeMessage{
+			GasLimit: big.NewInt(1),
+		},
+	}, &relayer.Event{})
+	assert.True(t, errors.Is(err, relayer.ErrProcessingPaused), "got %v", err)
+
+	p.Resume()
+	p.Resume()
+	assert.False(t, p.Paused())
+}
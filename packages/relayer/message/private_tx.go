@@ -0,0 +1,73 @@
+package message
+
+import (
+	"context"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+// sendTransaction sends the signed tx through the private relay when there is one,
+// otherwise to the destination node's public mempool.
+func (p *Processor) sendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if p.privateTxRelay != nil {
+		if err := p.privateTxRelay.SendTransaction(ctx, tx); err != nil {
+			return errors.Wrap(err, "p.privateTxRelay.SendTransaction")
+		}
+
+		return nil
+	}
+
+	if err := p.destEthClient.SendTransaction(ctx, tx); err != nil {
+		return errors.Wrap(err, "p.destEthClient.SendTransaction")
+	}
+
+	return nil
+}
+
+// waitPrivateTx waits for txHash, sent to the private relay before a restart, to be mined or
+// dropped. Public nodes don't see it while it's pending, so we can't tell it apart from one
+// that was dropped until the relay's inclusion window is over: it was sent at or before the
+// current head, so it can't be included after MaxBlocks blocks from here.
+func (p *Processor) waitPrivateTx(ctx context.Context, txHash common.Hash) error {
+	start, err := p.destEthClient.BlockNumber(ctx)
+	if err != nil {
+		return errors.Wrap(err, "p.destEthClient.BlockNumber")
+	}
+
+	relayer.Logger(ctx).Infof(
+		"waiting up to %v blocks for private txHash: %v sent before restart",
+		p.privateTxRelay.MaxBlocks(),
+		txHash.Hex(),
+	)
+
+	ticker := time.NewTicker(p.receiptPollInterval)
+	defer ticker.Stop()
+
+	for {
+		head, err := p.destEthClient.BlockNumber(ctx)
+		if err != nil {
+			return errors.Wrap(err, "p.destEthClient.BlockNumber")
+		}
+
+		// checked after the head, so a receipt in the last block it can be included in is seen
+		if _, err := p.destEthClient.TransactionReceipt(ctx, txHash); err == nil {
+			return nil
+		}
+
+		if head >= start+p.privateTxRelay.MaxBlocks() {
+			relayer.Logger(ctx).Infof("private txHash: %v wasn't mined, dropped by the relay", txHash.Hex())
+
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
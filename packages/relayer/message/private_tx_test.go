@@ -0,0 +1,80 @@
+package message
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_sendProcessMessageCall_privateTxRelay(t *testing.T) {
+	p := newTestProcessor(true)
+	relay := &mock.PrivateTxRelay{}
+	p.privateTxRelay = relay
+
+	tx, err := p.sendProcessMessageCall(
+		context.Background(),
+		p.keys.keys[0],
+		&bridge.BridgeMessageSent{
+			Message: bridge.IBridgeMessage{
+				DestChainId:   mock.MockChainID,
+				ProcessingFee: new(big.Int).Add(mock.ProcessMessageTx.Cost(), big.NewInt(1)),
+			},
+		}, []byte{})
+	assert.Nil(t, err)
+
+	assert.Equal(t, []*types.Transaction{tx}, relay.Sent())
+	assert.Equal(t, p.keys.keys[0].nonce, mock.PendingNonce+1)
+}
+
+func Test_replaceTransaction_privateTxRelay(t *testing.T) {
+	p := newTestProcessor(true)
+	relay := &mock.PrivateTxRelay{}
+	p.privateTxRelay = relay
+
+	replaced, err := p.replaceTransaction(context.Background(), mock.NeverMinedTx)
+	assert.Nil(t, err)
+	assert.Equal(t, []*types.Transaction{replaced}, relay.Sent())
+}
+
+func Test_ReconcilePendingSent_privateTxDropped(t *testing.T) {
+	p := newTestProcessor(true)
+	p.privateTxRelay = &mock.PrivateTxRelay{}
+
+	eventRepo := mock.NewEventRepository()
+	p.eventRepo = eventRepo
+
+	msgHash := common.Hash(mock.SuccessMsgHash)
+
+	_, err := eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+		ChainID: mock.MockChainID,
+		Status:  relayer.EventStatusNew,
+		MsgHash: msgHash.Hex(),
+	})
+	assert.Nil(t, err)
+
+	e, err := eventRepo.FirstByMsgHash(context.Background(), msgHash.Hex())
+	assert.Nil(t, err)
+	assert.Nil(t, eventRepo.MarkPendingSent(context.Background(), e.ID, mock.NotFoundTxHash))
+
+	// with no blocks to be included in, it's dropped as soon as it's not mined
+	assert.Nil(t, p.ReconcilePendingSent(context.Background(), mock.MockChainID))
+	assert.Equal(t, relayer.EventStatusNew, e.Status)
+}
+
+func Test_waitPrivateTx_canceled(t *testing.T) {
+	p := newTestProcessor(true)
+	p.privateTxRelay = &mock.PrivateTxRelay{Blocks: 25}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// the head never moves, so only the context ends the wait
+	assert.ErrorIs(t, p.waitPrivateTx(ctx, mock.NotFoundTxHash), context.Canceled)
+}
@@ -6,19 +6,21 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
-	"time"
 
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/proof"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/pkg/errors"
-	log "github.com/sirupsen/logrus"
 )
 
+// messageCallFailedReason labels MessageRecipientFailures when processMessage was mined, but
+// the call to the recipient failed and left the message Retriable.
+const messageCallFailedReason = "message call failed"
+
 // Process prepares and calls `processMessage` on the bridge.
 // the proof must be generated from the gethclient's eth_getProof via the Prover,
 // then rlp-encoded and combined as a singular byte slice,
@@ -30,7 +32,19 @@ func (p *Processor) ProcessMessage(
 	e *relayer.Event,
 ) error {
 	if event.Message.GasLimit == nil || event.Message.GasLimit.Cmp(common.Big0) == 0 {
-		return errors.New("only user can process this, gasLimit set to 0")
+		return ErrOnlyOwnerCanProcess
+	}
+
+	if p.sendingStopped() {
+		return relayer.ErrProcessingPaused
+	}
+
+	if skipped, err := p.skipIfOutOfScope(ctx, event, e); skipped || err != nil {
+		return err
+	}
+
+	if blocked, err := p.blockIfBlocklisted(ctx, event, e); blocked || err != nil {
+		return err
 	}
 
 	if err := p.waitForConfirmations(ctx, event.Raw.TxHash, event.Raw.BlockNumber); err != nil {
@@ -41,6 +55,14 @@ func (p *Processor) ProcessMessage(
 		return errors.Wrap(err, "p.waitHeaderSynced")
 	}
 
+	// waiting on the chains above doesn't hold a worker, so slow confirmations don't starve the
+	// messages that are ready to be proven and sent
+	if err := p.acquireWorker(ctx); err != nil {
+		return errors.Wrap(err, "p.acquireWorker")
+	}
+
+	defer p.releaseWorker()
+
 	// get latest synced header since not every header is synced from L1 => L2,
 	// and later blocks still have the storage trie proof from previous blocks.
 	latestSyncedHeader, err := p.destHeaderSyncer.GetCrossChainBlockHash(&bind.CallOpts{}, big.NewInt(0))
@@ -48,27 +70,41 @@ func (p *Processor) ProcessMessage(
 		return errors.Wrap(err, "mxc.GetSyncedHeader")
 	}
 
-	hashed := crypto.Keccak256(
-		event.Raw.Address.Bytes(),
-		event.MsgHash[:],
-	)
+	provingBlock, err := p.provingBlockHash(ctx, event, latestSyncedHeader)
+	if err != nil {
+		return errors.Wrap(err, "p.provingBlockHash")
+	}
 
-	key := hex.EncodeToString(hashed)
+	signalService, err := p.signalServiceFor(ctx, event)
+	if err != nil {
+		return errors.Wrap(err, "p.signalServiceFor")
+	}
 
-	encodedSignalProof, err := p.prover.EncodedSignalProof(ctx, p.rpc, p.srcSignalServiceAddress, key, latestSyncedHeader)
+	encodedSignalProof, err := p.prover.EncodedSignalProof(
+		ctx,
+		p.rpc,
+		signalService,
+		event.Raw.Address,
+		event.MsgHash,
+		provingBlock,
+	)
 	if err != nil {
-		log.Errorf("srcChainID: %v, destChainID: %v, txHash: %v: msgHash: %v, from: %v encountered signalProofError %v",
-			event.Message.SrcChainId,
-			event.Message.DestChainId,
+		relayer.Logger(ctx).Errorf("txHash: %v, from: %v encountered signalProofError %v, retriable: %v",
 			event.Raw.TxHash.Hex(),
-			common.Hash(event.MsgHash).Hex(),
 			event.Message.Owner.Hex(),
 			err,
+			proof.IsRetriable(err),
 		)
 
 		return errors.Wrap(err, "p.prover.GetEncodedSignalProof")
 	}
 
+	if err := p.checkSignalRoot(ctx, encodedSignalProof); err != nil {
+		relayer.Logger(ctx).Errorf("txHash: %v, p.checkSignalRoot: %v", event.Raw.TxHash.Hex(), err)
+
+		return errors.Wrap(err, "p.checkSignalRoot")
+	}
+
 	// check if message is received first. if not, it will definitely fail,
 	// so we can exit early on this one. there is most likely
 	// an issue with the signal generation.
@@ -81,46 +117,100 @@ func (p *Processor) ProcessMessage(
 
 	// message will fail when we try to process it
 	if !received {
-		log.Warnf(
-			"msgHash: %v, srcChainId: %v, encodedSignalProof: %v not received on dest chain",
-			common.Hash(event.MsgHash).Hex(),
-			event.Message.SrcChainId,
+		relayer.Logger(ctx).Warnf(
+			"encodedSignalProof: %v not received on dest chain",
 			hex.EncodeToString(encodedSignalProof),
 		)
 
 		relayer.MessagesNotReceivedOnDestChain.Inc()
 
-		return errors.New("message not received")
+		return ErrMessageNotReceived
 	}
 
-	tx, err := p.sendProcessMessageCall(ctx, event, encodedSignalProof)
+	key, err := p.pickRelayerKey(ctx, event.MsgHash)
 	if err != nil {
+		return errors.Wrap(err, "p.pickRelayerKey")
+	}
+
+	// hold a slot until the tx is confirmed, so we don't flood the mempool
+	// with more unconfirmed transactions than the node will accept from one sender.
+	if err := p.acquireInFlightSlot(ctx, key); err != nil {
+		return errors.Wrap(err, "p.acquireInFlightSlot")
+	}
+
+	// confirmations and header syncing can take a while, so check again we weren't
+	// paused or demoted in the meantime, right before sending.
+	if p.sendingStopped() {
+		p.releaseInFlightSlot(key)
+		return relayer.ErrProcessingPaused
+	}
+
+	// the blocklist may have been refreshed in the meantime too
+	if blocked, err := p.blockIfBlocklisted(ctx, event, e); blocked || err != nil {
+		p.releaseInFlightSlot(key)
+		return err
+	}
+
+	tx, err := p.sendProcessMessageCall(ctx, key, event, encodedSignalProof)
+	if err != nil {
+		p.releaseInFlightSlot(key)
 		return errors.Wrap(err, "p.sendProcessMessageCall")
 	}
 
 	relayer.EventsProcessed.Inc()
 
-	ctx, cancel := context.WithTimeout(ctx, 4*time.Minute)
+	// record the tx before waiting on it, so if we restart before its outcome is stored
+	// we check on it instead of sending the message again.
+	p.markPendingSent(ctx, e, tx)
+
+	receipt, err := p.waitReceipt(ctx, tx, func(replacement *types.Transaction) {
+		p.markPendingSent(ctx, e, replacement)
+	})
 
-	defer cancel()
+	p.releaseInFlightSlot(key)
+
+	// failing to record the gas only leaves it out of the balance runway
+	if receipt != nil {
+		if err := p.recordGasSpent(ctx, event.MsgHash, tx, receipt); err != nil {
+			relayer.Logger(ctx).Errorf("p.recordGasSpent: %v", err)
+		}
+	}
 
-	receipt, err := relayer.WaitReceipt(ctx, p.destEthClient, tx.Hash())
 	if err != nil {
-		return errors.Wrap(err, "relayer.WaitReceipt")
+		// a reverted tx still used its nonce, but one that was never mined leaves a gap
+		// our next nonce would be stuck behind, so start again from the node's pending nonce.
+		var revertErr *RevertError
+		if errors.As(err, &revertErr) {
+			relayer.MessageRecipientFailures.WithLabelValues(event.Message.To.Hex(), revertErr.Reason).Inc()
+		} else {
+			p.resetNonce(key)
+		}
+
+		// left pendingSent, only a restart's reconciling would pick the message up again
+		if err := p.settlePendingSent(ctx, e); err != nil {
+			relayer.Logger(ctx).Errorf("p.settlePendingSent: %v", err)
+		}
+
+		return errors.Wrap(err, "p.waitReceipt")
 	}
 
 	if err := p.saveMessageStatusChangedEvent(ctx, receipt, e, event); err != nil {
 		return errors.Wrap(err, "p.saveMEssageStatusChangedEvent")
 	}
 
-	log.Infof("Mined tx %s", hex.EncodeToString(tx.Hash().Bytes()))
+	// failing to record the block only leaves the message out of UnfinalizeReorgedOut's checks
+	if err := p.eventRepo.UpdateProcessingBlock(ctx, e.ID, receipt.BlockNumber.Uint64()); err != nil {
+		relayer.Logger(ctx).Errorf("p.eventRepo.UpdateProcessingBlock: %v", err)
+	}
+
+	relayer.Logger(ctx).Infof("Mined tx %s", hex.EncodeToString(tx.Hash().Bytes()))
 
 	messageStatus, err := p.destBridge.GetMessageStatus(&bind.CallOpts{}, event.MsgHash)
 	if err != nil {
 		return errors.Wrap(err, "p.destBridge.GetMessageStatus")
 	}
 
-	log.Infof(
+	relayer.Logger(ctx).Infof(
 		"updating message status to: %v for txHash: %v, processed in txHash: %v",
 		relayer.EventStatus(messageStatus).String(),
 		event.Raw.TxHash.Hex(),
@@ -129,34 +219,69 @@ func (p *Processor) ProcessMessage(
 
 	if messageStatus == uint8(relayer.EventStatusRetriable) {
 		relayer.RetriableEvents.Inc()
-	} else if messageStatus == uint8(relayer.EventStatusDone) {
-		relayer.DoneEvents.Inc()
+		relayer.MessageRecipientFailures.WithLabelValues(event.Message.To.Hex(), messageCallFailedReason).Inc()
+	}
+
+	// a reorg on the destination chain could still undo the transaction, so the message is only
+	// marked done once ConfirmProcessed finds it deep enough
+	if messageStatus == uint8(relayer.EventStatusDone) && p.destConfirmationsBeforeDone > 0 {
+		if err := p.eventRepo.MarkProcessedUnconfirmed(ctx, e.ID, receipt.TxHash); err != nil {
+			return errors.Wrap(err, "p.eventRepo.MarkProcessedUnconfirmed")
+		}
+
+		return nil
+	}
+
+	// update message status, unless it was e.g. marked stale before the transaction was recorded
+	updated, err := p.eventRepo.UpdateStatusIf(
+		ctx,
+		e.ID,
+		relayer.EventStatusPendingSent,
+		relayer.EventStatus(messageStatus),
+	)
+	if err != nil {
+		return errors.Wrap(err, "s.eventRepo.UpdateStatusIf")
 	}
 
-	// update message status
-	if err := p.eventRepo.UpdateStatus(ctx, e.ID, relayer.EventStatus(messageStatus)); err != nil {
-		return errors.Wrap(err, "s.eventRepo.UpdateStatus")
+	if !updated {
+		relayer.Logger(ctx).Warnf("no longer pendingSent, left status: %v", relayer.EventStatus(messageStatus).String())
+	}
+
+	// failing to record the time to done should not fail an otherwise processed message
+	if messageStatus == uint8(relayer.EventStatusDone) {
+		relayer.DoneEvents.Inc()
+
+		if err := p.recordTimeToDone(ctx, event, receipt, e); err != nil {
+			relayer.Logger(ctx).Errorf("p.recordTimeToDone: %v", err)
+		}
 	}
 
 	return nil
 }
 
+// sendProcessMessageCall sends event's processMessage transaction from key, with key's next nonce
 func (p *Processor) sendProcessMessageCall(
 	ctx context.Context,
+	key *relayerKey,
 	event *bridge.BridgeMessageSent,
 	proof []byte,
 ) (*types.Transaction, error) {
-	auth, err := bind.NewKeyedTransactorWithChainID(p.ecdsaKey, event.Message.DestChainId)
+	chainID, err := p.signerChainID(ctx, event.Message.DestChainId)
+	if err != nil {
+		return nil, errors.Wrap(err, "p.signerChainID")
+	}
+
+	auth, err := bind.NewKeyedTransactorWithChainID(key.key, chainID)
 	if err != nil {
 		return nil, errors.Wrap(err, "bind.NewKeyedTransactorWithChainID")
 	}
 
 	auth.Context = ctx
 
-	p.mu.Lock()
-	defer p.mu.Unlock()
+	key.mu.Lock()
+	defer key.mu.Unlock()
 
-	err = p.getLatestNonce(ctx, auth)
+	err = p.getLatestNonce(ctx, auth, key)
 	if err != nil {
 		return nil, errors.New("p.getLatestNonce")
 	}
@@ -194,7 +319,7 @@ func (p *Processor) sendProcessMessageCall(
 		auth.GasLimit = 3000000
 	} else {
 		// otherwise we can estimate gas
-		gas, cost, err = p.estimateGas(ctx, event.Message, proof)
+		gas, cost, err = p.estimateGas(ctx, key, event.Message, proof)
 		// and if gas estimation failed, we just try to hardcore a value no matter what type of event,
 		// or whether the contract is deployed.
 		if err != nil || gas == 0 {
@@ -202,17 +327,21 @@ func (p *Processor) sendProcessMessageCall(
 			if err != nil {
 				return nil, errors.Wrap(err, "p.hardcodeGasLimit")
 			}
+		} else {
+			auth.GasLimit = gasLimitFor(eventType, gas, event.Message)
 		}
 	}
 
+	auth.GasLimit = p.withGasLimitFloor(ctx, event.Message, auth.GasLimit)
+
 	gasTipCap, err := p.destEthClient.SuggestGasTipCap(ctx)
 	if err != nil {
 		if IsMaxPriorityFeePerGasNotFoundError(err) {
 			auth.GasTipCap = FallbackGasTipCap
 		} else {
-			gasPrice, err := p.destEthClient.SuggestGasPrice(context.Background())
+			gasPrice, err := p.suggestGasPrice(ctx)
 			if err != nil {
-				return nil, errors.Wrap(err, "p.destBridge.SuggestGasPrice")
+				return nil, errors.Wrap(err, "p.suggestGasPrice")
 			}
 
 			auth.GasPrice = gasPrice
@@ -221,20 +350,51 @@ func (p *Processor) sendProcessMessageCall(
 		auth.GasTipCap = gasTipCap
 	}
 
+	if auth.GasTipCap != nil {
+		auth.GasFeeCap, err = p.gasFeeCap(ctx, auth.GasTipCap)
+		if err != nil {
+			return nil, errors.Wrap(err, "p.gasFeeCap")
+		}
+	}
+
+	// the cost is estimated for this key, so another key may still be able to pay for the message
+	if cost != nil && !p.keys.canPay(ctx, key, cost) {
+		return nil, errors.Wrapf(ErrRelayerKeyCantPay, "relayer key %v, costing %v", key.addr.Hex(), cost)
+	}
+
 	if bool(p.profitableOnly) {
 		profitable, err := p.isProfitable(ctx, event.Message, cost)
-		if err != nil || !profitable {
+		if err != nil {
+			return nil, errors.Wrap(err, "p.isProfitable")
+		}
+
+		if !profitable {
 			return nil, relayer.ErrUnprofitable
 		}
 	}
 
+	p.withAccessList(ctx, auth)
+
+	// a transaction for the private relay is only signed, we send it ourselves
+	auth.NoSend = p.privateTxRelay != nil
+
 	// process the message on the destination bridge.
-	tx, err := p.destBridge.ProcessMessage(auth, event.Message, proof)
+	tx, err := p.txBuilder.ProcessMessage(auth, event.Message, proof)
 	if err != nil {
-		return nil, errors.Wrap(err, "p.destBridge.ProcessMessage")
+		return nil, errors.Wrap(err, "p.txBuilder.ProcessMessage")
 	}
 
-	p.setLatestNonce(tx.Nonce())
+	if auth.NoSend {
+		if err := p.sendTransaction(ctx, tx); err != nil {
+			return nil, errors.Wrap(err, "p.sendTransaction")
+		}
+	}
+
+	// assign the next nonce ourselves rather than waiting for the node's pending
+	// nonce to catch up, so concurrent in-flight transactions get contiguous nonces.
+	key.nonce = tx.Nonce() + 1
+
+	p.keys.spend(ctx, key, tx.Cost())
 
 	return tx, nil
 }
@@ -252,7 +412,11 @@ func (p *Processor) hardcodeGasLimit(
 	eventType relayer.EventType,
 	canonicalToken *relayer.CanonicalToken,
 ) (*big.Int, error) {
-	if eventType == relayer.EventTypeSendETH {
+	if eventType == relayer.EventTypeMessageCall {
+		// the call itself can use up to the message's gasLimit on top of processMessage's overhead.
+		call := relayer.DecodeMessageCall(event.Message)
+		auth.GasLimit = gasLimitFor(eventType, messageCallBaseGasLimit+call.GasLimit.Uint64(), event.Message)
+	} else if eventType == relayer.EventTypeSendETH {
 		// eth bridges take much less gas, from 250k to 450k.
 		auth.GasLimit = 500000
 	} else {
@@ -273,16 +437,23 @@ func (p *Processor) hardcodeGasLimit(
 		}
 	}
 
-	gasPrice, err := p.destEthClient.SuggestGasPrice(ctx)
+	gasPrice, err := p.suggestGasPrice(ctx)
 	if err != nil {
-		return nil, errors.Wrap(err, "p.destEthClient.SuggestGasPrice")
+		return nil, errors.Wrap(err, "p.suggestGasPrice")
 	}
 
 	return new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(auth.GasLimit)), nil
 }
 
-func (p *Processor) setLatestNonce(nonce uint64) {
-	p.destNonce = nonce
+// resetNonce forgets key's locally assigned nonce, so its next transaction
+// uses the node's pending nonce, and the txBuilder's own, i.e. a forwarder's.
+func (p *Processor) resetNonce(key *relayerKey) {
+	key.mu.Lock()
+	defer key.mu.Unlock()
+
+	key.nonce = 0
+
+	p.txBuilder.ResetNonce()
 }
 
 func (p *Processor) saveMessageStatusChangedEvent(
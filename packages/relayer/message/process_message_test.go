@@ -17,6 +17,7 @@ func Test_sendProcessMessageCall(t *testing.T) {
 
 	_, err := p.sendProcessMessageCall(
 		context.Background(),
+		p.keys.keys[0],
 		&bridge.BridgeMessageSent{
 			Message: bridge.IBridgeMessage{
 				DestChainId:   mock.MockChainID,
@@ -26,7 +27,7 @@ func Test_sendProcessMessageCall(t *testing.T) {
 
 	assert.Nil(t, err)
 
-	assert.Equal(t, p.destNonce, mock.PendingNonce)
+	assert.Equal(t, p.keys.keys[0].nonce, mock.PendingNonce+1)
 }
 
 func Test_ProcessMessage_messageNotReceived(t *testing.T) {
@@ -56,7 +57,12 @@ func Test_ProcessMessage_noChainId(t *testing.T) {
 		},
 		MsgHash: mock.SuccessMsgHash,
 	}, &relayer.Event{})
-	assert.EqualError(t, err, "p.sendProcessMessageCall: bind.NewKeyedTransactorWithChainID: no chain id specified")
+	assert.EqualError(
+		t,
+		err,
+		"p.sendProcessMessageCall: p.signerChainID: message has no chain ID, destination node is chain ID 167001: "+
+			"ERR_CHAIN_ID_MISMATCH: Message's destination chain ID is not the destination node's chain ID",
+	)
 }
 
 func Test_ProcessMessage(t *testing.T) {
@@ -5,29 +5,40 @@ import (
 	"crypto/ecdsa"
 	"math/big"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/eventbus"
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer/proof"
 )
 
 type ethClient interface {
+	ChainID(ctx context.Context) (*big.Int, error)
 	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
 	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+	TransactionByHash(ctx context.Context, hash common.Hash) (tx *types.Transaction, isPending bool, err error)
 	BlockNumber(ctx context.Context) (uint64, error)
 	HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
 	SuggestGasPrice(ctx context.Context) (*big.Int, error)
 	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+	CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
 }
 
 type Processor struct {
-	eventRepo     relayer.EventRepository
-	srcEthClient  ethClient
-	destEthClient ethClient
-	rpc           relayer.Caller
-	ecdsaKey      *ecdsa.PrivateKey
+	eventRepo          relayer.EventRepository
+	crossChainSyncRepo relayer.CrossChainSyncRepository
+	srcEthClient       ethClient
+	destEthClient      ethClient
+	rpc                relayer.Caller
 
 	destBridge       relayer.Bridge
 	destHeaderSyncer relayer.HeaderSyncer
@@ -35,17 +46,82 @@ type Processor struct {
 
 	prover *proof.Prover
 
-	mu *sync.Mutex
+	// keys are the relayer keys processMessage transactions are sent from, each with its own nonces
+	keys *keyPool
 
-	destNonce               uint64
-	relayerAddr             common.Address
+	destChainIDMu sync.Mutex
+	// destChainID is the chain ID the destination node reports, cached by signerChainID
+	destChainID             *big.Int
 	srcSignalServiceAddress common.Address
-	confirmations           uint64
+	// signalServiceVersions caches each SignalService address's proof.SignalServiceVersion
+	signalServiceVersions sync.Map
+	// signalServiceImplementations caches the implementation behind each SignalService address,
+	// the zero address if it isn't a proxy
+	signalServiceImplementations sync.Map
+	// confirmationStrategy is when a message's source block is final enough to prove it
+	confirmationStrategy relayer.ConfirmationStrategy
 
 	profitableOnly            relayer.ProfitableOnly
 	headerSyncIntervalSeconds int64
+	// syncedHeights wakes messages waiting on the destination chain to sync as soon as it has, if set
+	syncedHeights *eventbus.Bus[relayer.SyncedHeight]
 
 	confTimeoutInSeconds int64
+
+	receiptPollInterval time.Duration
+	receiptTimeout      time.Duration
+	// destConfirmationsBeforeDone is how many blocks a processMessage transaction needs on top of it
+	// before its message is marked done, see ConfirmProcessed
+	destConfirmationsBeforeDone uint64
+	// destReorgWindow is how many of the destination chain's latest blocks are watched for reorgs, see
+	// UnfinalizeReorgedOut, and destBlockHashes their hashes by number, as of the last check
+	destReorgWindow uint64
+	destBlockHashes map[uint64]common.Hash
+
+	holdTokenAmountThreshold *big.Int
+	holdETHAmountThreshold   *big.Int
+
+	// gasLimitFloors are the least gas processMessage transactions to each recipient are sent with
+	gasLimitFloors map[common.Address]uint64
+
+	// feeToken is the symbol of the source chain's native token, which processing fees are paid in
+	feeToken string
+	// destNativeToken is the symbol of the destination chain's native token, which gas is paid in
+	destNativeToken string
+	// priceOracle values fees and costs paid in different tokens in ETH, and is optional
+	priceOracle relayer.PriceOracle
+
+	// gasOracle prices transactions instead of the node's suggestion, and is optional
+	gasOracle relayer.GasOracle
+
+	// txBuilder sends processMessage transactions, destBridge unless they go through a forwarder
+	txBuilder relayer.TxBuilder
+
+	// clockDrift corrects time to done for the chains' clocks drifting apart, and is optional
+	clockDrift relayer.ClockDrift
+	// blocklist is the senders and recipients whose messages aren't relayed, and is optional
+	blocklist relayer.Blocklist
+
+	// accessListRPC makes access lists for processMessage transactions, and is optional
+	accessListRPC relayer.Caller
+	// accessListUnsupported is set once the destination node turns out not to have
+	// eth_createAccessList, so it's not asked again
+	accessListUnsupported atomic.Bool
+
+	// privateTxRelay sends processMessage transactions instead of the destination node's
+	// public mempool, and is optional
+	privateTxRelay relayer.PrivateTxRelay
+
+	// gasSpendRepo records what each processMessage transaction cost, and is optional
+	gasSpendRepo relayer.GasSpendRepository
+
+	// workers is a semaphore, with a slot held for each message being processed
+	workers chan struct{}
+
+	// paused stops transactions being sent, see Pause
+	paused atomic.Bool
+	// standby stops transactions being sent while another instance is the leader, see SetStandby
+	standby atomic.Bool
 }
 
 type NewProcessorOpts struct {
@@ -56,14 +132,80 @@ type NewProcessorOpts struct {
 	DestETHClient                 ethClient
 	DestBridge                    relayer.Bridge
 	EventRepo                     relayer.EventRepository
+	CrossChainSyncRepo            relayer.CrossChainSyncRepository
 	DestHeaderSyncer              relayer.HeaderSyncer
 	DestTokenVault                relayer.TokenVault
 	RelayerAddress                common.Address
 	SrcSignalServiceAddress       common.Address
+	TreasuryAddress               common.Address
 	Confirmations                 uint64
 	ProfitableOnly                relayer.ProfitableOnly
 	HeaderSyncIntervalSeconds     int64
 	ConfirmationsTimeoutInSeconds int64
+	ReceiptPollInterval           time.Duration
+	ReceiptTimeout                time.Duration
+	HoldTokenAmountThreshold      *big.Int
+	HoldETHAmountThreshold        *big.Int
+	MaxInFlightTxs                int
+	// Concurrency is how many messages are processed at once, the rest wait their turn
+	Concurrency int
+	// ConfirmationStrategy is when a message's source block is final enough to prove it,
+	// Confirmations blocks if unset
+	ConfirmationStrategy relayer.ConfirmationStrategy
+	// FeeToken is the symbol of the source chain's native token, which processing fees are paid in
+	FeeToken string
+	// DestNativeToken is the symbol of the destination chain's native token, which the gas for
+	// processMessage transactions is paid in, FeeToken if unset
+	DestNativeToken string
+	// PriceOracle is optional, and values processing fees and the cost of processing them in ETH
+	// when they're paid in different tokens, to decide whether a message is profitable
+	PriceOracle relayer.PriceOracle
+	// GasOracle is optional, and prices transactions instead of the destination node's
+	// suggested gas price when set
+	GasOracle relayer.GasOracle
+	// TxBuilder is optional, and sends processMessage transactions some other way than calling
+	// DestBridge directly, i.e. through a gas sponsoring forwarder
+	TxBuilder relayer.TxBuilder
+	// ClockDrift is optional, and corrects the time messages take to be done for the source and
+	// destination chains' block timestamps drifting apart
+	ClockDrift relayer.ClockDrift
+	// Blocklist is optional, and messages from or to an address on it are marked
+	// EventStatusBlocked instead of being relayed
+	Blocklist relayer.Blocklist
+	// AccessListRPC is optional, and is the destination node whose eth_createAccessList makes
+	// access lists for processMessage transactions, attached when they lower their gas
+	AccessListRPC relayer.Caller
+	// PrivateTxRelay is optional, and sends processMessage transactions privately instead of
+	// to the destination node's public mempool, so they can't be front-run
+	PrivateTxRelay relayer.PrivateTxRelay
+	// DestConfirmationsBeforeDone is how many blocks a processMessage transaction needs on top of
+	// it before its message is marked done, rather than as soon as it's mined, so a reorg on the
+	// destination chain can't leave a message marked done that isn't
+	DestConfirmationsBeforeDone uint64
+	// DestReorgWindow is how many of the destination chain's latest blocks are watched for reorgs,
+	// done messages whose processMessage transaction a reorg of them dropped being made retriable
+	// again. 0 doesn't watch.
+	DestReorgWindow uint64
+	// GasLimitFloors are optional, and are the least gas processMessage transactions to each
+	// recipient contract are sent with, for contracts eth_estimateGas underestimates
+	GasLimitFloors map[common.Address]uint64
+	// SyncedHeights is optional, and is where the source heights the destination chain syncs are
+	// published, so messages waiting on a sync are processed as soon as it happens rather than at
+	// the next HeaderSyncIntervalSeconds poll
+	SyncedHeights *eventbus.Bus[relayer.SyncedHeight]
+	// ECDSAKeyWeight is ECDSAKey's share of the messages against ExtraKeys' weights, 1 if unset
+	ECDSAKeyWeight int
+	// ExtraKeys are optional, and are sent messages too, each with its own nonces and
+	// MaxInFlightTxs, so more transactions can be pending at once than one account's nonces allow
+	ExtraKeys []RelayerKey
+	// KeySelection is how messages are spread across the keys, a weighted round robin if unset
+	KeySelection relayer.KeySelection
+	// KeyMinBalance is optional, and a key whose balance on the destination chain is below it is
+	// taken out of rotation until it's topped up
+	KeyMinBalance *big.Int
+	// GasSpendRepo is optional, and records the gas each mined processMessage transaction cost, to
+	// work out how long the relayer's balance lasts
+	GasSpendRepo relayer.GasSpendRepository
 }
 
 func NewProcessor(opts NewProcessorOpts) (*Processor, error) {
@@ -95,6 +237,10 @@ func NewProcessor(opts NewProcessorOpts) (*Processor, error) {
 		return nil, relayer.ErrNoEventRepository
 	}
 
+	if opts.CrossChainSyncRepo == nil {
+		return nil, relayer.ErrNoCrossChainSyncRepository
+	}
+
 	if opts.DestHeaderSyncer == nil {
 		return nil, relayer.ErrNoMxcL2
 	}
@@ -107,11 +253,67 @@ func NewProcessor(opts NewProcessorOpts) (*Processor, error) {
 		return nil, relayer.ErrInvalidConfirmationsTimeoutInSeconds
 	}
 
+	confirmationStrategy := opts.ConfirmationStrategy
+	if confirmationStrategy == (relayer.ConfirmationStrategy{}) {
+		confirmationStrategy = relayer.ConfirmationStrategy{Blocks: opts.Confirmations}
+	}
+
+	if opts.ReceiptPollInterval == 0 {
+		return nil, relayer.ErrInvalidReceiptPollInterval
+	}
+
+	if opts.ReceiptTimeout == 0 {
+		return nil, relayer.ErrInvalidReceiptTimeout
+	}
+
+	if opts.MaxInFlightTxs <= 0 {
+		return nil, relayer.ErrInvalidMaxInFlightTxs
+	}
+
+	if opts.Concurrency <= 0 {
+		return nil, relayer.ErrInvalidProcessorConcurrency
+	}
+
+	if opts.KeySelection != "" &&
+		opts.KeySelection != relayer.RoundRobinKeySelection &&
+		opts.KeySelection != relayer.HashKeySelection {
+		return nil, relayer.ErrInvalidKeySelection
+	}
+
+	// forward requests from different senders could be mined out of nonce order, and revert
+	if opts.TxBuilder != nil && len(opts.ExtraKeys) > 0 {
+		return nil, relayer.ErrForwarderWithExtraKeys
+	}
+
+	keys := []*relayerKey{
+		newRelayerKey(opts.ECDSAKey, opts.RelayerAddress, opts.ECDSAKeyWeight, opts.MaxInFlightTxs),
+	}
+
+	for _, k := range opts.ExtraKeys {
+		if k.Key == nil {
+			return nil, relayer.ErrNoECDSAKey
+		}
+
+		keys = append(keys, newRelayerKey(k.Key, crypto.PubkeyToAddress(k.Key.PublicKey), k.Weight, opts.MaxInFlightTxs))
+	}
+
+	warnIfTreasuryAddressUnsupported(opts.TreasuryAddress)
+
+	destNativeToken := opts.DestNativeToken
+	if destNativeToken == "" {
+		destNativeToken = opts.FeeToken
+	}
+
+	txBuilder := opts.TxBuilder
+	if txBuilder == nil {
+		txBuilder = bridgeTxBuilder{opts.DestBridge}
+	}
+
 	return &Processor{
-		eventRepo: opts.EventRepo,
-		prover:    opts.Prover,
-		ecdsaKey:  opts.ECDSAKey,
-		rpc:       opts.RPCClient,
+		eventRepo:          opts.EventRepo,
+		crossChainSyncRepo: opts.CrossChainSyncRepo,
+		prover:             opts.Prover,
+		rpc:                opts.RPCClient,
 
 		srcEthClient: opts.SrcETHClient,
 
@@ -120,15 +322,46 @@ func NewProcessor(opts NewProcessorOpts) (*Processor, error) {
 		destHeaderSyncer: opts.DestHeaderSyncer,
 		destTokenVault:   opts.DestTokenVault,
 
-		mu: &sync.Mutex{},
+		keys: newKeyPool(keys, opts.KeySelection, opts.KeyMinBalance),
 
-		destNonce:               0,
-		relayerAddr:             opts.RelayerAddress,
 		srcSignalServiceAddress: opts.SrcSignalServiceAddress,
-		confirmations:           opts.Confirmations,
+		confirmationStrategy:    confirmationStrategy,
 
 		profitableOnly:            opts.ProfitableOnly,
 		headerSyncIntervalSeconds: opts.HeaderSyncIntervalSeconds,
+		syncedHeights:             opts.SyncedHeights,
 		confTimeoutInSeconds:      opts.ConfirmationsTimeoutInSeconds,
+
+		receiptPollInterval: opts.ReceiptPollInterval,
+		receiptTimeout:      opts.ReceiptTimeout,
+
+		destConfirmationsBeforeDone: opts.DestConfirmationsBeforeDone,
+		destReorgWindow:             opts.DestReorgWindow,
+		destBlockHashes:             make(map[uint64]common.Hash),
+
+		holdTokenAmountThreshold: opts.HoldTokenAmountThreshold,
+		holdETHAmountThreshold:   opts.HoldETHAmountThreshold,
+
+		gasLimitFloors: opts.GasLimitFloors,
+
+		feeToken:        opts.FeeToken,
+		destNativeToken: destNativeToken,
+		priceOracle:     opts.PriceOracle,
+
+		gasOracle: opts.GasOracle,
+
+		txBuilder: txBuilder,
+
+		clockDrift: opts.ClockDrift,
+
+		blocklist: opts.Blocklist,
+
+		accessListRPC: opts.AccessListRPC,
+
+		privateTxRelay: opts.PrivateTxRelay,
+
+		gasSpendRepo: opts.GasSpendRepo,
+
+		workers: make(chan struct{}, opts.Concurrency),
 	}, nil
 }
@@ -2,8 +2,8 @@ package message
 
 import (
 	"crypto/ecdsa"
-	"sync"
 	"testing"
+	"time"
 
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
@@ -11,37 +11,57 @@ import (
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer/proof"
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer/repo"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
 	"gopkg.in/go-playground/assert.v1"
 )
 
-var dummyEcdsaKey = "8da4ef21b864d2cc526dbdb2a120bd2874c36c9d0a1fb7f8c63d7f7a8b41de8f"
+var (
+	dummyEcdsaKey = "8da4ef21b864d2cc526dbdb2a120bd2874c36c9d0a1fb7f8c63d7f7a8b41de8f"
+	// srcSignalService is the configured source SignalService, used when the bridge can't resolve one
+	srcSignalService = common.HexToAddress("0x1B80c7bCCEF6D6BEfB62Db052b3E3D782c566a4c")
+)
 
 func newTestProcessor(profitableOnly relayer.ProfitableOnly) *Processor {
 	privateKey, _ := crypto.HexToECDSA(dummyEcdsaKey)
 
 	prover, _ := proof.New(
 		&mock.Blocker{},
+		&mock.Caller{},
 	)
 
+	destBridge := &mock.Bridge{}
+
+	caller := &mock.Caller{}
+
 	return &Processor{
 		eventRepo:                 &mock.EventRepository{},
-		destBridge:                &mock.Bridge{},
+		crossChainSyncRepo:        mock.NewCrossChainSyncRepository(),
+		destBridge:                destBridge,
+		txBuilder:                 bridgeTxBuilder{destBridge},
 		srcEthClient:              &mock.EthClient{},
 		destEthClient:             &mock.EthClient{},
 		destTokenVault:            &mock.TokenVault{},
-		mu:                        &sync.Mutex{},
-		ecdsaKey:                  privateKey,
-		destHeaderSyncer:          &mock.HeaderSyncer{},
+		keys:                      newTestKeyPool(privateKey),
+		destHeaderSyncer:          &mock.HeaderSyncer{Caller: caller},
 		prover:                    prover,
-		rpc:                       &mock.Caller{},
+		rpc:                       caller,
+		srcSignalServiceAddress:   srcSignalService,
 		profitableOnly:            profitableOnly,
 		headerSyncIntervalSeconds: 1,
 		confTimeoutInSeconds:      900,
+		receiptPollInterval:       10 * time.Millisecond,
+		receiptTimeout:            time.Second,
 	}
 }
+
+// newTestKeyPool is a pool of just key, the way a processor without ExtraKeys has
+func newTestKeyPool(key *ecdsa.PrivateKey) *keyPool {
+	return newKeyPool([]*relayerKey{newRelayerKey(key, crypto.PubkeyToAddress(key.PublicKey), 1, 8)}, "", nil)
+}
+
 func Test_NewProcessor(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -58,24 +78,105 @@ func Test_NewProcessor(t *testing.T) {
 				DestETHClient:                 &ethclient.Client{},
 				DestBridge:                    &bridge.Bridge{},
 				EventRepo:                     &repo.EventRepository{},
+				CrossChainSyncRepo:            &repo.CrossChainSyncRepository{},
 				DestHeaderSyncer:              &icrosschainsync.ICrossChainSync{},
 				Confirmations:                 1,
 				ConfirmationsTimeoutInSeconds: 900,
+				ReceiptPollInterval:           time.Second,
+				ReceiptTimeout:                time.Minute,
+				MaxInFlightTxs:                8,
+				Concurrency:                   4,
 			},
 			nil,
 		},
+		{
+			"errNoReceiptPollInterval",
+			NewProcessorOpts{
+				Prover:                        &proof.Prover{},
+				ECDSAKey:                      &ecdsa.PrivateKey{},
+				RPCClient:                     &rpc.Client{},
+				SrcETHClient:                  &ethclient.Client{},
+				DestETHClient:                 &ethclient.Client{},
+				DestBridge:                    &bridge.Bridge{},
+				EventRepo:                     &repo.EventRepository{},
+				CrossChainSyncRepo:            &repo.CrossChainSyncRepository{},
+				DestHeaderSyncer:              &icrosschainsync.ICrossChainSync{},
+				Confirmations:                 1,
+				ConfirmationsTimeoutInSeconds: 900,
+				ReceiptTimeout:                time.Minute,
+			},
+			relayer.ErrInvalidReceiptPollInterval,
+		},
+		{
+			"errNoReceiptTimeout",
+			NewProcessorOpts{
+				Prover:                        &proof.Prover{},
+				ECDSAKey:                      &ecdsa.PrivateKey{},
+				RPCClient:                     &rpc.Client{},
+				SrcETHClient:                  &ethclient.Client{},
+				DestETHClient:                 &ethclient.Client{},
+				DestBridge:                    &bridge.Bridge{},
+				EventRepo:                     &repo.EventRepository{},
+				CrossChainSyncRepo:            &repo.CrossChainSyncRepository{},
+				DestHeaderSyncer:              &icrosschainsync.ICrossChainSync{},
+				Confirmations:                 1,
+				ConfirmationsTimeoutInSeconds: 900,
+				ReceiptPollInterval:           time.Second,
+			},
+			relayer.ErrInvalidReceiptTimeout,
+		},
+		{
+			"errInvalidMaxInFlightTxs",
+			NewProcessorOpts{
+				Prover:                        &proof.Prover{},
+				ECDSAKey:                      &ecdsa.PrivateKey{},
+				RPCClient:                     &rpc.Client{},
+				SrcETHClient:                  &ethclient.Client{},
+				DestETHClient:                 &ethclient.Client{},
+				DestBridge:                    &bridge.Bridge{},
+				EventRepo:                     &repo.EventRepository{},
+				CrossChainSyncRepo:            &repo.CrossChainSyncRepository{},
+				DestHeaderSyncer:              &icrosschainsync.ICrossChainSync{},
+				Confirmations:                 1,
+				ConfirmationsTimeoutInSeconds: 900,
+				ReceiptPollInterval:           time.Second,
+				ReceiptTimeout:                time.Minute,
+			},
+			relayer.ErrInvalidMaxInFlightTxs,
+		},
+		{
+			"errInvalidConcurrency",
+			NewProcessorOpts{
+				Prover:                        &proof.Prover{},
+				ECDSAKey:                      &ecdsa.PrivateKey{},
+				RPCClient:                     &rpc.Client{},
+				SrcETHClient:                  &ethclient.Client{},
+				DestETHClient:                 &ethclient.Client{},
+				DestBridge:                    &bridge.Bridge{},
+				EventRepo:                     &repo.EventRepository{},
+				CrossChainSyncRepo:            &repo.CrossChainSyncRepository{},
+				DestHeaderSyncer:              &icrosschainsync.ICrossChainSync{},
+				Confirmations:                 1,
+				ConfirmationsTimeoutInSeconds: 900,
+				ReceiptPollInterval:           time.Second,
+				ReceiptTimeout:                time.Minute,
+				MaxInFlightTxs:                8,
+			},
+			relayer.ErrInvalidProcessorConcurrency,
+		},
 		{
 			"errNoConfirmationsTimeoutInSeconds",
 			NewProcessorOpts{
-				Prover:           &proof.Prover{},
-				ECDSAKey:         &ecdsa.PrivateKey{},
-				RPCClient:        &rpc.Client{},
-				SrcETHClient:     &ethclient.Client{},
-				DestETHClient:    &ethclient.Client{},
-				DestBridge:       &bridge.Bridge{},
-				EventRepo:        &repo.EventRepository{},
-				DestHeaderSyncer: &icrosschainsync.ICrossChainSync{},
-				Confirmations:    1,
+				Prover:             &proof.Prover{},
+				ECDSAKey:           &ecdsa.PrivateKey{},
+				RPCClient:          &rpc.Client{},
+				SrcETHClient:       &ethclient.Client{},
+				DestETHClient:      &ethclient.Client{},
+				DestBridge:         &bridge.Bridge{},
+				EventRepo:          &repo.EventRepository{},
+				CrossChainSyncRepo: &repo.CrossChainSyncRepository{},
+				DestHeaderSyncer:   &icrosschainsync.ICrossChainSync{},
+				Confirmations:      1,
 			},
 			relayer.ErrInvalidConfirmationsTimeoutInSeconds,
 		},
@@ -89,6 +190,7 @@ func Test_NewProcessor(t *testing.T) {
 				DestETHClient:                 &ethclient.Client{},
 				DestBridge:                    &bridge.Bridge{},
 				EventRepo:                     &repo.EventRepository{},
+				CrossChainSyncRepo:            &repo.CrossChainSyncRepository{},
 				DestHeaderSyncer:              &icrosschainsync.ICrossChainSync{},
 				ConfirmationsTimeoutInSeconds: 900,
 			},
@@ -103,6 +205,7 @@ func Test_NewProcessor(t *testing.T) {
 				DestETHClient:                 &ethclient.Client{},
 				DestBridge:                    &bridge.Bridge{},
 				EventRepo:                     &repo.EventRepository{},
+				CrossChainSyncRepo:            &repo.CrossChainSyncRepository{},
 				DestHeaderSyncer:              &icrosschainsync.ICrossChainSync{},
 				Confirmations:                 1,
 				ConfirmationsTimeoutInSeconds: 900,
@@ -118,6 +221,7 @@ func Test_NewProcessor(t *testing.T) {
 				DestETHClient:                 &ethclient.Client{},
 				DestBridge:                    &bridge.Bridge{},
 				EventRepo:                     &repo.EventRepository{},
+				CrossChainSyncRepo:            &repo.CrossChainSyncRepository{},
 				Confirmations:                 1,
 				DestHeaderSyncer:              &icrosschainsync.ICrossChainSync{},
 				ConfirmationsTimeoutInSeconds: 900,
@@ -134,6 +238,7 @@ func Test_NewProcessor(t *testing.T) {
 				DestETHClient:                 &ethclient.Client{},
 				DestBridge:                    &bridge.Bridge{},
 				EventRepo:                     &repo.EventRepository{},
+				CrossChainSyncRepo:            &repo.CrossChainSyncRepository{},
 				DestHeaderSyncer:              &icrosschainsync.ICrossChainSync{},
 				Confirmations:                 1,
 				ConfirmationsTimeoutInSeconds: 900,
@@ -149,6 +254,7 @@ func Test_NewProcessor(t *testing.T) {
 				DestETHClient:                 &ethclient.Client{},
 				DestBridge:                    &bridge.Bridge{},
 				EventRepo:                     &repo.EventRepository{},
+				CrossChainSyncRepo:            &repo.CrossChainSyncRepository{},
 				DestHeaderSyncer:              &icrosschainsync.ICrossChainSync{},
 				Confirmations:                 1,
 				ConfirmationsTimeoutInSeconds: 900,
@@ -164,6 +270,7 @@ func Test_NewProcessor(t *testing.T) {
 				SrcETHClient:                  &ethclient.Client{},
 				DestBridge:                    &bridge.Bridge{},
 				EventRepo:                     &repo.EventRepository{},
+				CrossChainSyncRepo:            &repo.CrossChainSyncRepository{},
 				DestHeaderSyncer:              &icrosschainsync.ICrossChainSync{},
 				Confirmations:                 1,
 				ConfirmationsTimeoutInSeconds: 900,
@@ -179,6 +286,7 @@ func Test_NewProcessor(t *testing.T) {
 				SrcETHClient:                  &ethclient.Client{},
 				DestETHClient:                 &ethclient.Client{},
 				EventRepo:                     &repo.EventRepository{},
+				CrossChainSyncRepo:            &repo.CrossChainSyncRepository{},
 				DestHeaderSyncer:              &icrosschainsync.ICrossChainSync{},
 				Confirmations:                 1,
 				ConfirmationsTimeoutInSeconds: 900,
@@ -209,12 +317,80 @@ func Test_NewProcessor(t *testing.T) {
 				SrcETHClient:                  &ethclient.Client{},
 				DestETHClient:                 &ethclient.Client{},
 				EventRepo:                     &repo.EventRepository{},
+				CrossChainSyncRepo:            &repo.CrossChainSyncRepository{},
 				DestBridge:                    &bridge.Bridge{},
 				Confirmations:                 1,
 				ConfirmationsTimeoutInSeconds: 900,
 			},
 			relayer.ErrNoMxcL2,
 		},
+		{
+			"errInvalidKeySelection",
+			NewProcessorOpts{
+				Prover:                        &proof.Prover{},
+				ECDSAKey:                      &ecdsa.PrivateKey{},
+				RPCClient:                     &rpc.Client{},
+				SrcETHClient:                  &ethclient.Client{},
+				DestETHClient:                 &ethclient.Client{},
+				DestBridge:                    &bridge.Bridge{},
+				EventRepo:                     &repo.EventRepository{},
+				CrossChainSyncRepo:            &repo.CrossChainSyncRepository{},
+				DestHeaderSyncer:              &icrosschainsync.ICrossChainSync{},
+				Confirmations:                 1,
+				ConfirmationsTimeoutInSeconds: 900,
+				ReceiptPollInterval:           time.Second,
+				ReceiptTimeout:                time.Minute,
+				MaxInFlightTxs:                8,
+				Concurrency:                   4,
+				KeySelection:                  "random",
+			},
+			relayer.ErrInvalidKeySelection,
+		},
+		{
+			"errNoExtraKey",
+			NewProcessorOpts{
+				Prover:                        &proof.Prover{},
+				ECDSAKey:                      &ecdsa.PrivateKey{},
+				RPCClient:                     &rpc.Client{},
+				SrcETHClient:                  &ethclient.Client{},
+				DestETHClient:                 &ethclient.Client{},
+				DestBridge:                    &bridge.Bridge{},
+				EventRepo:                     &repo.EventRepository{},
+				CrossChainSyncRepo:            &repo.CrossChainSyncRepository{},
+				DestHeaderSyncer:              &icrosschainsync.ICrossChainSync{},
+				Confirmations:                 1,
+				ConfirmationsTimeoutInSeconds: 900,
+				ReceiptPollInterval:           time.Second,
+				ReceiptTimeout:                time.Minute,
+				MaxInFlightTxs:                8,
+				Concurrency:                   4,
+				ExtraKeys:                     []RelayerKey{{Weight: 1}},
+			},
+			relayer.ErrNoECDSAKey,
+		},
+		{
+			"errForwarderWithExtraKeys",
+			NewProcessorOpts{
+				Prover:                        &proof.Prover{},
+				ECDSAKey:                      &ecdsa.PrivateKey{},
+				RPCClient:                     &rpc.Client{},
+				SrcETHClient:                  &ethclient.Client{},
+				DestETHClient:                 &ethclient.Client{},
+				DestBridge:                    &bridge.Bridge{},
+				EventRepo:                     &repo.EventRepository{},
+				CrossChainSyncRepo:            &repo.CrossChainSyncRepository{},
+				DestHeaderSyncer:              &icrosschainsync.ICrossChainSync{},
+				Confirmations:                 1,
+				ConfirmationsTimeoutInSeconds: 900,
+				ReceiptPollInterval:           time.Second,
+				ReceiptTimeout:                time.Minute,
+				MaxInFlightTxs:                8,
+				Concurrency:                   4,
+				ExtraKeys:                     []RelayerKey{{Key: &ecdsa.PrivateKey{}, Weight: 1}},
+				TxBuilder:                     mock.NewBackend(mock.MockChainID),
+			},
+			relayer.ErrForwarderWithExtraKeys,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,83 @@
+package message
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/proof"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// provingBlockHash is the hash of the synced source block event's signal is proven against, the latest
+// synced one. When the prover only proves against finalized blocks, and that's past the finalized
+// block, it's the latest synced block at or below the finalized one instead, from the mirrored
+// CrossChainSynced events, or else the finalized block itself if the destination chain synced it.
+// Until one at or past event's block is, it fails with proof.ErrBlockNotFinalized.
+func (p *Processor) provingBlockHash(
+	ctx context.Context,
+	event *bridge.BridgeMessageSent,
+	latestSynced common.Hash,
+) (common.Hash, error) {
+	if !p.prover.FinalizedOnly() {
+		return latestSynced, nil
+	}
+
+	latestSyncedNumber, err := p.prover.BlockNumberByHash(ctx, latestSynced)
+	if err != nil {
+		return common.Hash{}, errors.Wrap(err, "p.prover.BlockNumberByHash")
+	}
+
+	finalized, err := p.prover.FinalizedBlock(ctx, p.rpc)
+	if err != nil {
+		return common.Hash{}, errors.Wrap(err, "p.prover.FinalizedBlock")
+	}
+
+	if latestSyncedNumber.Uint64() <= finalized.Number {
+		return latestSynced, nil
+	}
+
+	if event.Raw.BlockNumber > finalized.Number {
+		return common.Hash{}, errors.Wrapf(
+			proof.ErrBlockNotFinalized,
+			"message in block %v, finalized %v",
+			event.Raw.BlockNumber,
+			finalized.Number,
+		)
+	}
+
+	sync, err := p.crossChainSyncRepo.FindLatestSyncBetween(
+		ctx,
+		event.Message.DestChainId,
+		event.Raw.BlockNumber,
+		finalized.Number,
+	)
+	if err != nil {
+		return common.Hash{}, errors.Wrap(err, "p.crossChainSyncRepo.FindLatestSyncBetween")
+	}
+
+	if sync != nil {
+		return common.HexToHash(sync.BlockHash), nil
+	}
+
+	hash, err := p.destHeaderSyncer.GetCrossChainBlockHash(
+		&bind.CallOpts{Context: ctx},
+		new(big.Int).SetUint64(finalized.Number),
+	)
+	if err != nil {
+		return common.Hash{}, errors.Wrap(err, "p.destHeaderSyncer.GetCrossChainBlockHash")
+	}
+
+	if hash == ([32]byte{}) {
+		return common.Hash{}, errors.Wrapf(
+			proof.ErrBlockNotFinalized,
+			"no block from %v to finalized %v synced",
+			event.Raw.BlockNumber,
+			finalized.Number,
+		)
+	}
+
+	return common.Hash(hash), nil
+}
@@ -0,0 +1,75 @@
+package message
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/proof"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+// genesisFinalized is a consensus client that has only finalized genesis, so the mock chain's latest
+// synced block, 1, isn't finalized yet
+type genesisFinalized struct{}
+
+func (genesisFinalized) FinalizedBlock(ctx context.Context) (*relayer.FinalizedBlock, error) {
+	return &relayer.FinalizedBlock{Number: 0, Hash: crypto.Keccak256Hash([]byte(hexutil.EncodeUint64(0)))}, nil
+}
+
+func provingBlockEvent(blockNumber uint64) *bridge.BridgeMessageSent {
+	return &bridge.BridgeMessageSent{
+		Message: bridge.IBridgeMessage{DestChainId: mock.MockChainID},
+		Raw:     types.Log{BlockNumber: blockNumber},
+	}
+}
+
+func Test_provingBlockHash(t *testing.T) {
+	latestSynced := common.Hash(mock.SuccessHeader)
+
+	p := newTestProcessor(true)
+
+	hash, err := p.provingBlockHash(context.Background(), provingBlockEvent(1), latestSynced)
+	assert.Nil(t, err)
+	assert.Equal(t, latestSynced, hash)
+
+	// the node's finalized block is past the latest synced one
+	p.prover.WithFinalizedOnly(nil)
+
+	hash, err = p.provingBlockHash(context.Background(), provingBlockEvent(1), latestSynced)
+	assert.Nil(t, err)
+	assert.Equal(t, latestSynced, hash)
+}
+
+func Test_provingBlockHash_latestSyncedNotFinalized(t *testing.T) {
+	latestSynced := common.HexToHash("0x1234")
+
+	p := newTestProcessor(true)
+	p.prover.WithFinalizedOnly(genesisFinalized{})
+
+	_, err := p.provingBlockHash(context.Background(), provingBlockEvent(1), latestSynced)
+	assert.ErrorIs(t, err, proof.ErrBlockNotFinalized)
+
+	// the destination chain synced the finalized block, but it isn't mirrored
+	hash, err := p.provingBlockHash(context.Background(), provingBlockEvent(0), latestSynced)
+	assert.Nil(t, err)
+	assert.Equal(t, common.Hash(mock.SuccessHeader), hash)
+
+	synced := common.HexToHash("0x5678")
+
+	assert.Nil(t, p.crossChainSyncRepo.Save(context.Background(), relayer.SaveCrossChainSyncOpts{
+		ChainID:   mock.MockChainID,
+		SrcHeight: 0,
+		BlockHash: synced,
+	}))
+
+	hash, err = p.provingBlockHash(context.Background(), provingBlockEvent(0), latestSynced)
+	assert.Nil(t, err)
+	assert.Equal(t, synced, hash)
+}
@@ -0,0 +1,130 @@
+package message
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+// markPendingSent records tx as the processMessage transaction for e. Failing to record it
+// only loses the restart protection, so it's logged rather than failing an already sent message.
+func (p *Processor) markPendingSent(ctx context.Context, e *relayer.Event, tx *types.Transaction) {
+	if err := p.eventRepo.MarkPendingSent(ctx, e.ID, tx.Hash()); err != nil {
+		relayer.Logger(ctx).Errorf("eventID: %v, txHash: %v, p.eventRepo.MarkPendingSent: %v", e.ID, tx.Hash().Hex(), err)
+	}
+}
+
+// settlePendingSent takes e's status from the bridge once waiting for its transaction failed, i.e. it
+// reverted or was never mined, so the message is new or retriable again rather than left pendingSent.
+// It's only changed if it's still pendingSent.
+func (p *Processor) settlePendingSent(ctx context.Context, e *relayer.Event) error {
+	messageStatus, err := p.destBridge.GetMessageStatus(&bind.CallOpts{Context: ctx}, common.HexToHash(e.MsgHash))
+	if err != nil {
+		return errors.Wrap(err, "p.destBridge.GetMessageStatus")
+	}
+
+	status := relayer.EventStatus(messageStatus)
+
+	updated, err := p.eventRepo.UpdateStatusIf(ctx, e.ID, relayer.EventStatusPendingSent, status)
+	if err != nil {
+		return errors.Wrap(err, "p.eventRepo.UpdateStatusIf")
+	}
+
+	if updated {
+		relayer.Logger(ctx).Infof("processMessage transaction failed, settled pendingSent to: %v", status.String())
+	}
+
+	return nil
+}
+
+// ReconcilePendingSent resolves the events for srcChainID a previous run left PendingSent,
+// having sent a processMessage transaction but not lived to store its outcome.
+// It must run before new messages are processed, so one whose transaction is still
+// pending isn't sent again and reverted as already processed.
+func (p *Processor) ReconcilePendingSent(ctx context.Context, srcChainID *big.Int) error {
+	events, err := p.eventRepo.FindAllByStatus(relayer.WithPrimaryReads(ctx), srcChainID, relayer.EventStatusPendingSent)
+	if err != nil {
+		return errors.Wrap(err, "p.eventRepo.FindAllByStatus")
+	}
+
+	for _, e := range events {
+		if err := p.reconcilePendingSent(ctx, e); err != nil {
+			return errors.Wrapf(err, "p.reconcilePendingSent(msgHash: %v)", e.MsgHash)
+		}
+	}
+
+	return nil
+}
+
+// reconcilePendingSent waits for e's transaction if it's still pending, then takes the
+// message status from the bridge, which is right whichever of our transactions was mined,
+// or if the transaction was dropped and the message is still new.
+func (p *Processor) reconcilePendingSent(ctx context.Context, e *relayer.Event) error {
+	destChainID, err := p.destNodeChainID(ctx)
+	if err != nil {
+		return errors.Wrap(err, "p.destNodeChainID")
+	}
+
+	ctx = relayer.WithMessageLogger(ctx, common.HexToHash(e.MsgHash), big.NewInt(e.ChainID), destChainID)
+
+	txHash := common.HexToHash(e.ProcessingTxHash)
+
+	_, err = p.destEthClient.TransactionReceipt(ctx, txHash)
+	if err != nil && !errors.Is(err, ethereum.NotFound) {
+		return errors.Wrap(err, "p.destEthClient.TransactionReceipt")
+	}
+
+	if errors.Is(err, ethereum.NotFound) {
+		tx, isPending, err := p.destEthClient.TransactionByHash(ctx, txHash)
+		if err != nil && !errors.Is(err, ethereum.NotFound) {
+			return errors.Wrap(err, "p.destEthClient.TransactionByHash")
+		}
+
+		if err == nil && isPending {
+			relayer.Logger(ctx).Infof("waiting for pending txHash: %v sent before restart", txHash.Hex())
+
+			receipt, err := p.waitReceipt(ctx, tx, func(replacement *types.Transaction) {
+				p.markPendingSent(ctx, e, replacement)
+			})
+
+			if receipt != nil {
+				if err := p.recordGasSpent(ctx, common.HexToHash(e.MsgHash), tx, receipt); err != nil {
+					relayer.Logger(ctx).Errorf("p.recordGasSpent: %v", err)
+				}
+			}
+
+			var revertErr *RevertError
+			if err != nil && !errors.As(err, &revertErr) {
+				return errors.Wrap(err, "p.waitReceipt")
+			}
+		}
+
+		// the public node never sees a pending private transaction
+		if errors.Is(err, ethereum.NotFound) && p.privateTxRelay != nil {
+			if err := p.waitPrivateTx(ctx, txHash); err != nil {
+				return errors.Wrap(err, "p.waitPrivateTx")
+			}
+		}
+	}
+
+	messageStatus, err := p.destBridge.GetMessageStatus(&bind.CallOpts{Context: ctx}, common.HexToHash(e.MsgHash))
+	if err != nil {
+		return errors.Wrap(err, "p.destBridge.GetMessageStatus")
+	}
+
+	status := relayer.EventStatus(messageStatus)
+
+	relayer.Logger(ctx).Infof("txHash: %v, reconciled pendingSent to: %v", txHash.Hex(), status.String())
+
+	if err := p.eventRepo.UpdateStatus(ctx, e.ID, status); err != nil {
+		return errors.Wrap(err, "p.eventRepo.UpdateStatus")
+	}
+
+	return nil
+}
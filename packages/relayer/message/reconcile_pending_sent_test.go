@@ -0,0 +1,88 @@
+package message
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ReconcilePendingSent(t *testing.T) {
+	tests := []struct {
+		name       string
+		msgHash    common.Hash
+		txHash     common.Hash
+		wantStatus relayer.EventStatus
+	}{
+		{
+			"minedAndDone",
+			common.Hash{0x4},
+			mock.SucceedTxHash,
+			relayer.EventStatusDone,
+		},
+		{
+			"droppedAndStillNew",
+			mock.SuccessMsgHash,
+			mock.NotFoundTxHash,
+			relayer.EventStatusNew,
+		},
+		{
+			"pendingThenReplacedAndDone",
+			common.Hash{0x3},
+			mock.NeverMinedTx.Hash(),
+			relayer.EventStatusDone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestProcessor(true)
+			eventRepo := mock.NewEventRepository()
+			p.eventRepo = eventRepo
+
+			_, err := eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+				ChainID: mock.MockChainID,
+				Status:  relayer.EventStatusNew,
+				MsgHash: tt.msgHash.Hex(),
+			})
+			assert.Nil(t, err)
+
+			e, err := eventRepo.FirstByMsgHash(context.Background(), tt.msgHash.Hex())
+			assert.Nil(t, err)
+			assert.Nil(t, eventRepo.MarkPendingSent(context.Background(), e.ID, tt.txHash))
+
+			assert.Nil(t, p.ReconcilePendingSent(context.Background(), mock.MockChainID))
+			assert.Equal(t, tt.wantStatus, e.Status)
+
+			pending, err := eventRepo.FindAllByStatus(context.Background(), mock.MockChainID, relayer.EventStatusPendingSent)
+			assert.Nil(t, err)
+			assert.Empty(t, pending)
+		})
+	}
+}
+
+func Test_markPendingSent(t *testing.T) {
+	p := newTestProcessor(true)
+	eventRepo := mock.NewEventRepository()
+	p.eventRepo = eventRepo
+
+	msgHash := common.Hash(mock.SuccessMsgHash).Hex()
+
+	_, err := eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+		ChainID: mock.MockChainID,
+		Status:  relayer.EventStatusNew,
+		MsgHash: msgHash,
+	})
+	assert.Nil(t, err)
+
+	e, err := eventRepo.FirstByMsgHash(context.Background(), msgHash)
+	assert.Nil(t, err)
+
+	p.markPendingSent(context.Background(), e, mock.NeverMinedTx)
+
+	assert.Equal(t, relayer.EventStatusPendingSent, e.Status)
+	assert.Equal(t, mock.NeverMinedTx.Hash().Hex(), e.ProcessingTxHash)
+}
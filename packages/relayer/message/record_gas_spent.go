@@ -0,0 +1,49 @@
+package message
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+// recordGasSpent stores what a mined processMessage transaction for msgHash cost, reverted or not,
+// for the relayer's balance runway. A receipt without an effective gas price, from a node that
+// predates it, is priced at tx's gas price, which for a dynamic fee transaction is its fee cap.
+func (p *Processor) recordGasSpent(
+	ctx context.Context,
+	msgHash common.Hash,
+	tx *types.Transaction,
+	receipt *types.Receipt,
+) error {
+	if p.gasSpendRepo == nil {
+		return nil
+	}
+
+	chainID, err := p.destNodeChainID(ctx)
+	if err != nil {
+		return errors.Wrap(err, "p.destNodeChainID")
+	}
+
+	price := receipt.EffectiveGasPrice
+	if price == nil {
+		price = tx.GasPrice()
+	}
+
+	spent := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), price)
+
+	if err := p.gasSpendRepo.Save(ctx, relayer.SaveGasSpendOpts{
+		ChainID:  chainID,
+		MsgHash:  msgHash.Hex(),
+		TxHash:   receipt.TxHash.Hex(),
+		GasUsed:  receipt.GasUsed,
+		GasSpent: spent,
+	}); err != nil {
+		return errors.Wrap(err, "p.gasSpendRepo.Save")
+	}
+
+	return nil
+}
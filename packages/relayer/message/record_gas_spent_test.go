@@ -0,0 +1,75 @@
+package message
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_recordGasSpent(t *testing.T) {
+	tx := types.NewTransaction(0, common.HexToAddress("0x01"), common.Big0, 100000, big.NewInt(30), nil)
+
+	tests := []struct {
+		name          string
+		receipt       *types.Receipt
+		wantGasSpent  string
+		wantGasUsed   uint64
+		wantTxHashHex string
+	}{
+		{
+			"effectiveGasPrice",
+			&types.Receipt{TxHash: common.HexToHash("0xa"), GasUsed: 21000, EffectiveGasPrice: big.NewInt(10)},
+			"210000",
+			21000,
+			common.HexToHash("0xa").Hex(),
+		},
+		{
+			"noEffectiveGasPrice",
+			&types.Receipt{TxHash: common.HexToHash("0xb"), GasUsed: 21000},
+			"630000",
+			21000,
+			common.HexToHash("0xb").Hex(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestProcessor(true)
+			gasSpendRepo := mock.NewGasSpendRepository()
+			p.gasSpendRepo = gasSpendRepo
+
+			assert.Nil(t, p.recordGasSpent(context.Background(), common.HexToHash("0x1"), tx, tt.receipt))
+
+			spends := gasSpendRepo.Spends()
+			assert.Equal(t, 1, len(spends))
+			assert.Equal(t, tt.wantGasSpent, spends[0].GasSpent)
+			assert.Equal(t, tt.wantGasUsed, spends[0].GasUsed)
+			assert.Equal(t, tt.wantTxHashHex, spends[0].TxHash)
+			assert.Equal(t, mock.MockChainID.Int64(), spends[0].ChainID)
+			assert.Equal(t, common.HexToHash("0x1").Hex(), spends[0].MsgHash)
+		})
+	}
+}
+
+func Test_recordGasSpent_noRepo(t *testing.T) {
+	p := newTestProcessor(true)
+
+	receipt := &types.Receipt{GasUsed: 21000, EffectiveGasPrice: big.NewInt(10)}
+
+	assert.Nil(t, p.recordGasSpent(context.Background(), common.HexToHash("0x1"), mock.ProcessMessageTx, receipt))
+}
+
+func Test_recordGasSpent_error(t *testing.T) {
+	p := newTestProcessor(true)
+	p.gasSpendRepo = &mock.GasSpendRepository{Err: errors.New("dummy")}
+
+	receipt := &types.Receipt{GasUsed: 21000, EffectiveGasPrice: big.NewInt(10)}
+
+	assert.NotNil(t, p.recordGasSpent(context.Background(), common.HexToHash("0x1"), mock.ProcessMessageTx, receipt))
+}
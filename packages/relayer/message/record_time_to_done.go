@@ -0,0 +1,73 @@
+package message
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+// recordTimeToDone measures how long a message took from being sent to being marked Done.
+// Block timestamps from both chains are used rather than wall-clock time, since the
+// relayer may have picked the message up long after it was emitted.
+func (p *Processor) recordTimeToDone(
+	ctx context.Context,
+	event *bridge.BridgeMessageSent,
+	receipt *types.Receipt,
+	e *relayer.Event,
+) error {
+	srcHeader, err := p.srcEthClient.HeaderByHash(ctx, event.Raw.BlockHash)
+	if err != nil {
+		return errors.Wrap(err, "p.srcEthClient.HeaderByHash")
+	}
+
+	destHeader, err := p.destEthClient.HeaderByHash(ctx, receipt.BlockHash)
+	if err != nil {
+		return errors.Wrap(err, "p.destEthClient.HeaderByHash")
+	}
+
+	elapsed := time.Duration(int64(destHeader.Time)-int64(srcHeader.Time)) * time.Second
+	elapsed -= p.clockSkew(event.Message.SrcChainId, event.Message.DestChainId)
+
+	var timeToDone uint64
+
+	// never a negative duration, however far the clocks drift
+	if elapsed > 0 {
+		timeToDone = uint64(elapsed / time.Second)
+	}
+
+	relayer.Logger(ctx).Infof("took %v seconds from being sent to being marked done", timeToDone)
+
+	relayer.MessageTimeToDone.Observe(float64(timeToDone))
+	relayer.MessageTimeToDonePercentiles.Observe(float64(timeToDone))
+
+	if err := p.eventRepo.UpdateTimeToDone(ctx, e.ID, srcHeader.Time, destHeader.Time, timeToDone); err != nil {
+		return errors.Wrap(err, "p.eventRepo.UpdateTimeToDone")
+	}
+
+	return nil
+}
+
+// clockSkew is how far ahead of the source chain's block timestamps the destination chain's are,
+// beyond the time that really passed, or 0 if that isn't known for both.
+func (p *Processor) clockSkew(srcChainID *big.Int, destChainID *big.Int) time.Duration {
+	if p.clockDrift == nil || srcChainID == nil || destChainID == nil {
+		return 0
+	}
+
+	srcDrift, ok := p.clockDrift.Drift(srcChainID)
+	if !ok {
+		return 0
+	}
+
+	destDrift, ok := p.clockDrift.Drift(destChainID)
+	if !ok {
+		return 0
+	}
+
+	return destDrift - srcDrift
+}
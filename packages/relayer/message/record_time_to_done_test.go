@@ -0,0 +1,108 @@
+package message
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_recordTimeToDone(t *testing.T) {
+	p := newTestProcessor(true)
+
+	tests := []struct {
+		name    string
+		event   *bridge.BridgeMessageSent
+		receipt *types.Receipt
+		wantErr bool
+	}{
+		{
+			"success",
+			&bridge.BridgeMessageSent{
+				Raw: types.Log{
+					BlockHash: common.HexToHash("0x123"),
+				},
+			},
+			&types.Receipt{
+				BlockHash: common.HexToHash("0x456"),
+			},
+			false,
+		},
+		{
+			"srcHeaderNotFound",
+			&bridge.BridgeMessageSent{},
+			&types.Receipt{
+				BlockHash: common.HexToHash("0x456"),
+			},
+			true,
+		},
+		{
+			"destHeaderNotFound",
+			&bridge.BridgeMessageSent{
+				Raw: types.Log{
+					BlockHash: common.HexToHash("0x123"),
+				},
+			},
+			&types.Receipt{},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := p.recordTimeToDone(context.Background(), tt.event, tt.receipt, &relayer.Event{})
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+type fixedClockDrift map[int64]time.Duration
+
+func (d fixedClockDrift) Drift(chainID *big.Int) (time.Duration, bool) {
+	drift, ok := d[chainID.Int64()]
+	return drift, ok
+}
+
+func Test_clockSkew(t *testing.T) {
+	tests := []struct {
+		name       string
+		clockDrift relayer.ClockDrift
+		want       time.Duration
+	}{
+		{
+			"noClockDrift",
+			nil,
+			0,
+		},
+		{
+			"destAhead",
+			fixedClockDrift{1: -6 * time.Second, 2: 30 * time.Second},
+			36 * time.Second,
+		},
+		{
+			"destBehind",
+			fixedClockDrift{1: 10 * time.Second, 2: -2 * time.Second},
+			-12 * time.Second,
+		},
+		{
+			"destUnknown",
+			fixedClockDrift{1: 10 * time.Second},
+			0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestProcessor(true)
+			p.clockDrift = tt.clockDrift
+
+			assert.Equal(t, tt.want, p.clockSkew(big.NewInt(1), big.NewInt(2)))
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package message
+
+import (
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/proof"
+	"github.com/pkg/errors"
+)
+
+// RejectionReasonFor is the reason processing a message failing with err means it can't be
+// processed, empty if err isn't one of them. Messages rejected by their status, out of scope,
+// stale or blocked, aren't processed to fail at all, see relayer.RejectionReasonForStatus.
+func RejectionReasonFor(err error) relayer.RejectionReason {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, ErrMessageNotReceived),
+		errors.Is(err, proof.ErrSignalRootMismatch),
+		errors.Is(err, proof.ErrBlockNotFinalized):
+		return relayer.RejectionReasonNotYetSynced
+	case errors.Is(err, proof.ErrSignalNotSent),
+		errors.Is(err, proof.ErrSignalNotSet):
+		return relayer.RejectionReasonSignalNotSent
+	case errors.Is(err, proof.ErrStateRootPruned):
+		return relayer.RejectionReasonStatePruned
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,40 @@
+package message
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/proof"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RejectionReasonFor(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want relayer.RejectionReason
+	}{
+		{"nil", nil, ""},
+		{"notReceived", errors.Wrap(ErrMessageNotReceived, "p.processMessage"), relayer.RejectionReasonNotYetSynced},
+		{"blockNotFinalized", proof.ErrBlockNotFinalized, relayer.RejectionReasonNotYetSynced},
+		{"signalNotSent", errors.Wrap(proof.ErrSignalNotSent, "p.prover"), relayer.RejectionReasonSignalNotSent},
+		{"statePruned", errors.Wrap(proof.ErrStateRootPruned, "c.CallContext"), relayer.RejectionReasonStatePruned},
+		{"unprofitable", relayer.ErrUnprofitable, ""},
+		{"unknown", errors.New("connection refused"), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, RejectionReasonFor(tt.err))
+		})
+	}
+}
+
+func Test_RejectionReasonFor_prunedIsntRetried(t *testing.T) {
+	err := errors.Wrap(errors.Wrapf(proof.ErrStateRootPruned, "block %v", big.NewInt(1)), "p.prover")
+
+	_, retry := RetryReasonFor(err)
+	assert.False(t, retry)
+}
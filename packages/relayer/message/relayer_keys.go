@@ -0,0 +1,308 @@
+package message
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/pkg/errors"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+)
+
+// keyBalanceCheckInterval is how long a relayer key's balance is trusted before it's fetched again
+var keyBalanceCheckInterval = time.Minute
+
+// RelayerKey is a key processMessage transactions are sent from, besides the processor's ECDSAKey.
+// Each key is sent a share of the messages proportional to its Weight.
+type RelayerKey struct {
+	Key    *ecdsa.PrivateKey
+	Weight int
+}
+
+// relayerKey is a key in rotation, with its own nonces and in-flight transactions, so sending from
+// one doesn't wait on the others
+type relayerKey struct {
+	key    *ecdsa.PrivateKey
+	addr   common.Address
+	weight int
+
+	// mu is held while a transaction is built and sent from the key, so its nonces are assigned
+	// one at a time
+	mu sync.Mutex
+	// nonce is the next nonce to use, unless the node's pending nonce is higher
+	nonce uint64
+
+	// inFlight is a semaphore, with a slot held for each of the key's sent but unconfirmed transactions
+	inFlight chan struct{}
+
+	// the rest are guarded by the keyPool's mu
+
+	// balance is the key's balance on the destination chain, less the cost of what it sent since
+	// balanceCheckedAt, nil until it's first checked
+	balance          *big.Int
+	balanceCheckedAt time.Time
+	// lowBalance takes the key out of rotation until its balance is checked again and is enough
+	lowBalance bool
+	// currentWeight is the key's running weight in the smooth weighted round robin
+	currentWeight int
+}
+
+func newRelayerKey(key *ecdsa.PrivateKey, addr common.Address, weight int, maxInFlightTxs int) *relayerKey {
+	if weight <= 0 {
+		weight = 1
+	}
+
+	return &relayerKey{
+		key:      key,
+		addr:     addr,
+		weight:   weight,
+		inFlight: make(chan struct{}, maxInFlightTxs),
+	}
+}
+
+// keyPool is the relayer keys processMessage transactions are spread across
+type keyPool struct {
+	mu sync.Mutex
+
+	// keys are never empty, the first is the processor's ECDSAKey
+	keys      []*relayerKey
+	selection relayer.KeySelection
+	// minBalance takes a key out of rotation while its balance is below it, nil only takes out a
+	// key that can't pay for a transaction
+	minBalance *big.Int
+
+	// chainID labels the keys' metrics, set once the destination node's chain ID is known
+	chainID string
+}
+
+func newKeyPool(keys []*relayerKey, selection relayer.KeySelection, minBalance *big.Int) *keyPool {
+	if selection == "" {
+		selection = relayer.RoundRobinKeySelection
+	}
+
+	return &keyPool{
+		keys:       keys,
+		selection:  selection,
+		minBalance: minBalance,
+	}
+}
+
+// pick returns the key to send msgHash's processMessage transaction from, among those in rotation
+func (pool *keyPool) pick(msgHash common.Hash) (*relayerKey, error) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	available := make([]*relayerKey, 0, len(pool.keys))
+
+	for _, k := range pool.keys {
+		if !k.lowBalance {
+			available = append(available, k)
+		}
+	}
+
+	if len(available) == 0 {
+		return nil, relayer.ErrNoRelayerKeyAvailable
+	}
+
+	if pool.selection == relayer.HashKeySelection {
+		return pickByHash(available, msgHash), nil
+	}
+
+	return pickRoundRobin(available), nil
+}
+
+// pickRoundRobin is a smooth weighted round robin, sending from each key in turn as often as its
+// weight, without bursts of the heavier keys
+func pickRoundRobin(keys []*relayerKey) *relayerKey {
+	var (
+		picked *relayerKey
+		total  int
+	)
+
+	for _, k := range keys {
+		k.currentWeight += k.weight
+		total += k.weight
+
+		if picked == nil || k.currentWeight > picked.currentWeight {
+			picked = k
+		}
+	}
+
+	picked.currentWeight -= total
+
+	return picked
+}
+
+// pickByHash picks a key by msgHash, weighted, so a message retried later is sent from the same
+// key while the keys in rotation don't change
+func pickByHash(keys []*relayerKey, msgHash common.Hash) *relayerKey {
+	total := 0
+	for _, k := range keys {
+		total += k.weight
+	}
+
+	n := int(new(big.Int).Mod(new(big.Int).SetBytes(msgHash.Bytes()), big.NewInt(int64(total))).Int64())
+
+	for _, k := range keys {
+		if n < k.weight {
+			return k
+		}
+
+		n -= k.weight
+	}
+
+	return keys[len(keys)-1]
+}
+
+// claimBalanceCheck reports whether k's balance is due to be checked, and if so, marks it checked
+// now, so concurrent messages don't all fetch it
+func (pool *keyPool) claimBalanceCheck(k *relayerKey, now time.Time) bool {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if now.Sub(k.balanceCheckedAt) < keyBalanceCheckInterval {
+		return false
+	}
+
+	k.balanceCheckedAt = now
+
+	return true
+}
+
+// setBalance records k's balance, putting it back in rotation if it's enough
+func (pool *keyPool) setBalance(ctx context.Context, k *relayerKey, balance *big.Int) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	k.balance = balance
+
+	relayer.RelayerKeyBalance.WithLabelValues(pool.chainID, k.addr.Hex()).Set(weiToFloat(balance))
+
+	pool.setLowBalance(ctx, k, pool.minBalance != nil && balance.Cmp(pool.minBalance) < 0)
+}
+
+// spend takes cost off k's balance, taking it out of rotation if what's left is below the
+// minimum, rather than waiting for its next check
+func (pool *keyPool) spend(ctx context.Context, k *relayerKey, cost *big.Int) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if k.balance == nil {
+		return
+	}
+
+	k.balance = new(big.Int).Sub(k.balance, cost)
+
+	if pool.minBalance != nil && k.balance.Cmp(pool.minBalance) < 0 {
+		pool.setLowBalance(ctx, k, true)
+	}
+}
+
+// canPay reports whether k's balance covers cost, taking it out of rotation until its next check
+// if not. A key whose balance isn't known yet is assumed to.
+func (pool *keyPool) canPay(ctx context.Context, k *relayerKey, cost *big.Int) bool {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if k.balance == nil || k.balance.Cmp(cost) >= 0 {
+		return true
+	}
+
+	pool.setLowBalance(ctx, k, true)
+
+	return false
+}
+
+func (pool *keyPool) setLowBalance(ctx context.Context, k *relayerKey, low bool) {
+	if low && !k.lowBalance {
+		relayer.Logger(ctx).Warnf("relayer key %v is low on gas, balance %v, taking it out of rotation",
+			k.addr.Hex(),
+			k.balance,
+		)
+	}
+
+	if !low && k.lowBalance {
+		relayer.Logger(ctx).Infof("relayer key %v is back in rotation, balance %v", k.addr.Hex(), k.balance)
+	}
+
+	k.lowBalance = low
+
+	inRotation := 1.0
+	if low {
+		inRotation = 0
+	}
+
+	relayer.RelayerKeyInRotation.WithLabelValues(pool.chainID, k.addr.Hex()).Set(inRotation)
+}
+
+// setChainID labels the keys' metrics with the destination chain's ID
+func (pool *keyPool) setChainID(chainID *big.Int) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	pool.chainID = chainID.String()
+}
+
+// labels are k's metrics' labels
+func (pool *keyPool) labels(k *relayerKey) []string {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	return []string{pool.chainID, k.addr.Hex()}
+}
+
+// signerOf returns the key tx was signed with, or the processor's ECDSAKey if it wasn't signed by
+// any of them, like a transaction from before the keys changed
+func (pool *keyPool) signerOf(tx *types.Transaction) *relayerKey {
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err == nil {
+		for _, k := range pool.keys {
+			if k.addr == from {
+				return k
+			}
+		}
+	}
+
+	return pool.keys[0]
+}
+
+// pickRelayerKey checks the balances of the keys that are due a check, then picks the key to
+// send msgHash's processMessage transaction from
+func (p *Processor) pickRelayerKey(ctx context.Context, msgHash common.Hash) (*relayerKey, error) {
+	chainID, err := p.destNodeChainID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "p.destNodeChainID")
+	}
+
+	p.keys.setChainID(chainID)
+
+	now := time.Now()
+
+	for _, k := range p.keys.keys {
+		if !p.keys.claimBalanceCheck(k, now) {
+			continue
+		}
+
+		// the key keeps its last known balance, it's checked again next interval
+		balance, err := p.destEthClient.BalanceAt(ctx, k.addr, nil)
+		if err != nil {
+			relayer.Logger(ctx).Warnf("relayer key %v: p.destEthClient.BalanceAt: %v", k.addr.Hex(), err)
+			continue
+		}
+
+		p.keys.setBalance(ctx, k, balance)
+	}
+
+	return p.keys.pick(msgHash)
+}
+
+func weiToFloat(wei *big.Int) float64 {
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt(wei), big.NewFloat(params.Ether)).Float64()
+	return f
+}
@@ -0,0 +1,160 @@
+package message
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRelayerKeys(t *testing.T, weights ...int) []*relayerKey {
+	keys := make([]*relayerKey, 0, len(weights))
+
+	for _, weight := range weights {
+		key, err := crypto.GenerateKey()
+		assert.Nil(t, err)
+
+		keys = append(keys, newRelayerKey(key, crypto.PubkeyToAddress(key.PublicKey), weight, 8))
+	}
+
+	return keys
+}
+
+func Test_keyPool_pickRoundRobin(t *testing.T) {
+	keys := newTestRelayerKeys(t, 2, 1)
+	pool := newKeyPool(keys, "", nil)
+
+	picked := make([]*relayerKey, 0)
+
+	for i := 0; i < 6; i++ {
+		k, err := pool.pick(mock.SuccessMsgHash)
+		assert.Nil(t, err)
+
+		picked = append(picked, k)
+	}
+
+	// spread out by weight, rather than in bursts of the heavier key
+	assert.Equal(t, []*relayerKey{keys[0], keys[1], keys[0], keys[0], keys[1], keys[0]}, picked)
+}
+
+func Test_keyPool_pickByHash(t *testing.T) {
+	keys := newTestRelayerKeys(t, 3, 1)
+	pool := newKeyPool(keys, relayer.HashKeySelection, nil)
+
+	counts := make(map[*relayerKey]int)
+
+	for i := 0; i < 400; i++ {
+		msgHash := common.BigToHash(big.NewInt(int64(i)))
+
+		k, err := pool.pick(msgHash)
+		assert.Nil(t, err)
+
+		// a message is sent from the same key every time
+		again, err := pool.pick(msgHash)
+		assert.Nil(t, err)
+		assert.Equal(t, k, again)
+
+		counts[k]++
+	}
+
+	assert.Equal(t, 300, counts[keys[0]])
+	assert.Equal(t, 100, counts[keys[1]])
+}
+
+func Test_keyPool_lowBalance(t *testing.T) {
+	keys := newTestRelayerKeys(t, 1, 1)
+	pool := newKeyPool(keys, "", big.NewInt(100))
+
+	pool.setBalance(context.Background(), keys[0], big.NewInt(99))
+	pool.setBalance(context.Background(), keys[1], big.NewInt(1000))
+
+	for i := 0; i < 3; i++ {
+		k, err := pool.pick(mock.SuccessMsgHash)
+		assert.Nil(t, err)
+		assert.Equal(t, keys[1], k)
+	}
+
+	// spending below the minimum takes the key out without waiting for its next check
+	pool.spend(context.Background(), keys[1], big.NewInt(901))
+
+	_, err := pool.pick(mock.SuccessMsgHash)
+	assert.Equal(t, relayer.ErrNoRelayerKeyAvailable, err)
+
+	// topped up, it's back in rotation
+	pool.setBalance(context.Background(), keys[0], big.NewInt(100))
+
+	k, err := pool.pick(mock.SuccessMsgHash)
+	assert.Nil(t, err)
+	assert.Equal(t, keys[0], k)
+}
+
+func Test_keyPool_canPay(t *testing.T) {
+	keys := newTestRelayerKeys(t, 1)
+	pool := newKeyPool(keys, "", nil)
+
+	// a key whose balance isn't known yet is assumed to pay
+	assert.True(t, pool.canPay(context.Background(), keys[0], big.NewInt(10)))
+
+	pool.setBalance(context.Background(), keys[0], big.NewInt(5))
+
+	assert.False(t, pool.canPay(context.Background(), keys[0], big.NewInt(10)))
+
+	_, err := pool.pick(mock.SuccessMsgHash)
+	assert.Equal(t, relayer.ErrNoRelayerKeyAvailable, err)
+
+	// without a minimum, the next check puts it back
+	pool.setBalance(context.Background(), keys[0], big.NewInt(5))
+
+	_, err = pool.pick(mock.SuccessMsgHash)
+	assert.Nil(t, err)
+}
+
+func Test_keyPool_signerOf(t *testing.T) {
+	keys := newTestRelayerKeys(t, 1, 1)
+	pool := newKeyPool(keys, "", nil)
+
+	signer := types.LatestSignerForChainID(mock.MockChainID)
+
+	tx, err := types.SignNewTx(keys[1].key, signer, &types.DynamicFeeTx{ChainID: mock.MockChainID, Nonce: 1})
+	assert.Nil(t, err)
+
+	assert.Equal(t, keys[1], pool.signerOf(tx))
+
+	// an unsigned transaction is taken to be the ECDSAKey's
+	assert.Equal(t, keys[0], pool.signerOf(mock.NeverMinedTx))
+}
+
+func Test_pickRelayerKey(t *testing.T) {
+	p := newTestProcessor(true)
+	p.keys.keys = append(p.keys.keys, newTestRelayerKeys(t, 1)...)
+	p.keys.minBalance = new(big.Int).Add(mock.Balance, common.Big1)
+
+	// the balances are checked first, and both keys are below the minimum
+	_, err := p.pickRelayerKey(context.Background(), mock.SuccessMsgHash)
+	assert.Equal(t, relayer.ErrNoRelayerKeyAvailable, err)
+
+	for _, k := range p.keys.keys {
+		assert.Equal(t, mock.Balance, k.balance)
+	}
+
+	// and aren't checked again until keyBalanceCheckInterval has passed
+	p.keys.minBalance = mock.Balance
+
+	_, err = p.pickRelayerKey(context.Background(), mock.SuccessMsgHash)
+	assert.Equal(t, relayer.ErrNoRelayerKeyAvailable, err)
+
+	for _, k := range p.keys.keys {
+		k.balanceCheckedAt = time.Now().Add(-keyBalanceCheckInterval)
+	}
+
+	k, err := p.pickRelayerKey(context.Background(), mock.SuccessMsgHash)
+	assert.Nil(t, err)
+	assert.Equal(t, p.keys.keys[0], k)
+}
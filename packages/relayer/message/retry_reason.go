@@ -0,0 +1,34 @@
+package message
+
+import (
+	"context"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/proof"
+	"github.com/pkg/errors"
+)
+
+// RetryReasonFor is why processing a message failed with err, and false if retrying it can't help,
+// i.e. it's rejected for a reason retrying won't change, like its proof never being possible to
+// generate, or the processor was stopped.
+func RetryReasonFor(err error) (relayer.RetryReason, bool) {
+	switch rejection := RejectionReasonFor(err); {
+	case rejection == relayer.RejectionReasonNotYetSynced:
+		return relayer.RetryReasonNotSynced, true
+	case rejection != "" && !relayer.NewRejection(rejection).Retriable:
+		return "", false
+	case errors.Is(err, context.Canceled),
+		errors.Is(err, ErrOnlyOwnerCanProcess),
+		errors.Is(err, relayer.ErrProcessingPaused),
+		errors.Is(err, relayer.ErrChainIDMismatch):
+		return "", false
+	case errors.Is(err, relayer.ErrUnprofitable),
+		errors.Is(err, relayer.ErrNoRelayerKeyAvailable),
+		errors.Is(err, ErrRelayerKeyCantPay):
+		return relayer.RetryReasonGasTooHigh, true
+	case !proof.IsRetriable(err):
+		return "", false
+	default:
+		return relayer.RetryReasonTransient, true
+	}
+}
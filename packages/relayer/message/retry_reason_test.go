@@ -0,0 +1,95 @@
+package message
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/proof"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RetryReasonFor(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantReason relayer.RetryReason
+		wantRetry  bool
+	}{
+		{
+			"notReceived",
+			ErrMessageNotReceived,
+			relayer.RetryReasonNotSynced,
+			true,
+		},
+		{
+			"signalRootMismatch",
+			errors.Wrap(errors.Wrap(proof.ErrSignalRootMismatch, "detail"), "p.checkSignalRoot"),
+			relayer.RetryReasonNotSynced,
+			true,
+		},
+		{
+			"blockNotFinalized",
+			errors.Wrap(proof.ErrBlockNotFinalized, "p.provingBlockHash"),
+			relayer.RetryReasonNotSynced,
+			true,
+		},
+		{
+			"unprofitable",
+			errors.Wrap(relayer.ErrUnprofitable, "p.sendProcessMessageCall"),
+			relayer.RetryReasonGasTooHigh,
+			true,
+		},
+		{
+			"keyCantPay",
+			errors.Wrap(errors.Wrap(ErrRelayerKeyCantPay, "relayer key"), "p.sendProcessMessageCall"),
+			relayer.RetryReasonGasTooHigh,
+			true,
+		},
+		{
+			"noKeyAvailable",
+			errors.Wrap(relayer.ErrNoRelayerKeyAvailable, "p.pickRelayerKey"),
+			relayer.RetryReasonGasTooHigh,
+			true,
+		},
+		{
+			"rpc",
+			errors.Wrap(errors.New("connection refused"), "p.destBridge.IsMessageReceived"),
+			relayer.RetryReasonTransient,
+			true,
+		},
+		{
+			"blockNotFound",
+			errors.Wrap(proof.ErrBlockNotFound, "p.prover.GetEncodedSignalProof"),
+			relayer.RetryReasonTransient,
+			true,
+		},
+		{
+			"stateRootPruned",
+			errors.Wrap(proof.ErrStateRootPruned, "p.prover.GetEncodedSignalProof"),
+			"",
+			false,
+		},
+		{
+			"onlyOwner",
+			ErrOnlyOwnerCanProcess,
+			"",
+			false,
+		},
+		{
+			"canceled",
+			errors.Wrap(context.Canceled, "p.waitForConfirmations"),
+			"",
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, retry := RetryReasonFor(tt.err)
+			assert.Equal(t, tt.wantReason, reason)
+			assert.Equal(t, tt.wantRetry, retry)
+		})
+	}
+}
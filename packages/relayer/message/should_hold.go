@@ -0,0 +1,57 @@
+package message
+
+import (
+	"math/big"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/pkg/errors"
+)
+
+// ShouldHold determines whether a message transfers more than the configured
+// threshold for its type, in which case it must be manually reviewed and released
+// before we relay it. ERC20 transfers are compared against the token amount threshold,
+// which is in whole tokens so it means the same for tokens with different decimals,
+// everything else against the ETH threshold in wei using deposit value plus call value.
+// A nil threshold disables holding for that type.
+func (p *Processor) ShouldHold(event *bridge.BridgeMessageSent) (bool, error) {
+	eventType, canonicalToken, amount, err := relayer.DecodeMessageSentData(event)
+	if err != nil {
+		return false, errors.Wrap(err, "relayer.DecodeMessageSentData")
+	}
+
+	if eventType == relayer.EventTypeSendERC20 {
+		return exceedsThreshold(amount, tokenUnits(p.holdTokenAmountThreshold, canonicalToken.Decimals)), nil
+	}
+
+	value := new(big.Int)
+
+	if event.Message.DepositValue != nil {
+		value.Add(value, event.Message.DepositValue)
+	}
+
+	if event.Message.CallValue != nil {
+		value.Add(value, event.Message.CallValue)
+	}
+
+	return exceedsThreshold(value, p.holdETHAmountThreshold), nil
+}
+
+func exceedsThreshold(amount *big.Int, threshold *big.Int) bool {
+	if threshold == nil || amount == nil {
+		return false
+	}
+
+	return amount.Cmp(threshold) > 0
+}
+
+// tokenUnits is wholeTokens in the smallest unit of a token with decimals
+func tokenUnits(wholeTokens *big.Int, decimals uint8) *big.Int {
+	if wholeTokens == nil {
+		return nil
+	}
+
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+
+	return new(big.Int).Mul(wholeTokens, scale)
+}
@@ -0,0 +1,89 @@
+package message
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ShouldHold(t *testing.T) {
+	tests := []struct {
+		name                 string
+		tokenAmountThreshold *big.Int
+		ethAmountThreshold   *big.Int
+		message              bridge.IBridgeMessage
+		wantHold             bool
+	}{
+		{
+			"noThreshold",
+			nil,
+			nil,
+			bridge.IBridgeMessage{
+				DepositValue: big.NewInt(100),
+			},
+			false,
+		},
+		{
+			"belowThreshold",
+			nil,
+			big.NewInt(100),
+			bridge.IBridgeMessage{
+				DepositValue: big.NewInt(50),
+				CallValue:    big.NewInt(50),
+			},
+			false,
+		},
+		{
+			"aboveThreshold",
+			nil,
+			big.NewInt(100),
+			bridge.IBridgeMessage{
+				DepositValue: big.NewInt(50),
+				CallValue:    big.NewInt(51),
+			},
+			true,
+		},
+		{
+			// the message sends 1 of the 18 decimals token's smallest unit
+			"tokenAboveThreshold",
+			big.NewInt(0),
+			nil,
+			bridge.IBridgeMessage{
+				// nolint: lll
+				Data: common.Hex2Bytes("0c6fab8200000000000000000000000000000000000000000000000000000000000000800000000000000000000000004ec242468812b6ffc8be8ff423af7bd23108d9910000000000000000000000004ec242468812b6ffc8be8ff423af7bd23108d99100000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000007a68000000000000000000000000e4337137828c93d0046212ebda8a82a24356b67b000000000000000000000000000000000000000000000000000000000000001200000000000000000000000000000000000000000000000000000000000000a000000000000000000000000000000000000000000000000000000000000000e00000000000000000000000000000000000000000000000000000000000000004544553540000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000095465737445524332300000000000000000000000000000000000000000000000"),
+			},
+			true,
+		},
+		{
+			"tokenBelowThreshold",
+			big.NewInt(1),
+			nil,
+			bridge.IBridgeMessage{
+				// nolint: lll
+				Data: common.Hex2Bytes("0c6fab8200000000000000000000000000000000000000000000000000000000000000800000000000000000000000004ec242468812b6ffc8be8ff423af7bd23108d9910000000000000000000000004ec242468812b6ffc8be8ff423af7bd23108d99100000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000007a68000000000000000000000000e4337137828c93d0046212ebda8a82a24356b67b000000000000000000000000000000000000000000000000000000000000001200000000000000000000000000000000000000000000000000000000000000a000000000000000000000000000000000000000000000000000000000000000e00000000000000000000000000000000000000000000000000000000000000004544553540000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000095465737445524332300000000000000000000000000000000000000000000000"),
+			},
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestProcessor(true)
+			p.holdTokenAmountThreshold = tt.tokenAmountThreshold
+			p.holdETHAmountThreshold = tt.ethAmountThreshold
+
+			hold, err := p.ShouldHold(&bridge.BridgeMessageSent{Message: tt.message})
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantHold, hold)
+		})
+	}
+}
+
+func Test_tokenUnits(t *testing.T) {
+	assert.Nil(t, tokenUnits(nil, 18))
+	assert.Equal(t, big.NewInt(5_000_000), tokenUnits(big.NewInt(5), 6))
+	assert.Equal(t, new(big.Int).Mul(big.NewInt(5), big.NewInt(1e18)), tokenUnits(big.NewInt(5), 18))
+}
@@ -0,0 +1,108 @@
+package message
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/proof"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// signalServiceFor is the SignalService the message's signal was sent with. During a migration
+// the old and new deployments run side by side, so it's resolved from the bridge that sent
+// the message, at the block it was sent in. If the bridge can't resolve one, the configured
+// source SignalService is used.
+func (p *Processor) signalServiceFor(
+	ctx context.Context,
+	event *bridge.BridgeMessageSent,
+) (proof.SignalService, error) {
+	address, err := proof.ResolveSignalService(
+		ctx,
+		p.rpc,
+		event.Raw.Address,
+		new(big.Int).SetUint64(event.Raw.BlockNumber),
+	)
+	if err != nil || address == relayer.ZeroAddress {
+		if p.srcSignalServiceAddress == relayer.ZeroAddress {
+			return proof.SignalService{}, errors.Wrap(err, "proof.ResolveSignalService")
+		}
+
+		relayer.Logger(ctx).Warnf(
+			"bridge %v couldn't resolve its SignalService: %v, using %v",
+			event.Raw.Address.Hex(),
+			err,
+			p.srcSignalServiceAddress.Hex(),
+		)
+
+		address = p.srcSignalServiceAddress
+	}
+
+	var chainID uint64
+	if event.Message.SrcChainId != nil {
+		chainID = event.Message.SrcChainId.Uint64()
+	}
+
+	version, err := p.signalServiceVersion(ctx, address, chainID)
+	if err != nil {
+		return proof.SignalService{}, err
+	}
+
+	return proof.SignalService{
+		Address:        address,
+		Implementation: p.signalServiceImplementation(ctx, address),
+		Version:        version,
+		ChainID:        chainID,
+	}, nil
+}
+
+// signalServiceImplementation looks up the implementation behind a SignalService once, and logs it
+// so operators can tell the proxy signals are proven against from the implementation some tooling
+// shows. It's only informational, so failing to look it up is logged and tried again next time.
+func (p *Processor) signalServiceImplementation(ctx context.Context, address common.Address) common.Address {
+	if implementation, ok := p.signalServiceImplementations.Load(address); ok {
+		return implementation.(common.Address)
+	}
+
+	implementation, err := proof.Implementation(ctx, p.rpc, address)
+	if err != nil {
+		relayer.Logger(ctx).Warnf("SignalService %v proof.Implementation: %v", address.Hex(), err)
+
+		return common.Address{}
+	}
+
+	if implementation != relayer.ZeroAddress {
+		relayer.Logger(ctx).Infof(
+			"SignalService %v is a proxy for implementation %v, proving against the proxy's storage",
+			address.Hex(),
+			implementation.Hex(),
+		)
+	}
+
+	p.signalServiceImplementations.Store(address, implementation)
+
+	return implementation
+}
+
+// signalServiceVersion detects a SignalService's version once, a deployment's layout doesn't change
+// without a new address.
+func (p *Processor) signalServiceVersion(
+	ctx context.Context,
+	address common.Address,
+	chainID uint64,
+) (proof.SignalServiceVersion, error) {
+	if version, ok := p.signalServiceVersions.Load(address); ok {
+		return version.(proof.SignalServiceVersion), nil
+	}
+
+	version, err := proof.DetectSignalServiceVersion(ctx, p.rpc, address, chainID)
+	if err != nil {
+		return 0, errors.Wrap(err, "proof.DetectSignalServiceVersion")
+	}
+
+	p.signalServiceVersions.Store(address, version)
+
+	return version, nil
+}
@@ -0,0 +1,116 @@
+package message
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/proof"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	oldBridge          = common.HexToAddress("0xBb7a150E1247Da7B4c339f0997Bb18A4038147Af")
+	newBridge          = common.HexToAddress("0x1000777700000000000000000000000000000004")
+	newSignalService   = common.HexToAddress("0x1000777700000000000000000000000000000007")
+	unresolvableBridge = common.HexToAddress("0x63FaC9201494f0bd17B9892B9fae4d52fe3BD377")
+	proxiedBridge      = common.HexToAddress("0x1000777700000000000000000000000000000005")
+	proxySignalService = common.HexToAddress("0x1000777700000000000000000000000000000008")
+	implSignalService  = common.HexToAddress("0x2000777700000000000000000000000000000008")
+)
+
+func Test_signalServiceFor(t *testing.T) {
+	tests := []struct {
+		name       string
+		bridge     common.Address
+		configured common.Address
+		want       proof.SignalService
+		wantErr    bool
+	}{
+		{
+			"oldDeployment",
+			oldBridge,
+			srcSignalService,
+			proof.SignalService{Address: srcSignalService, Version: proof.SignalServiceV1, ChainID: mock.MockChainID.Uint64()},
+			false,
+		},
+		{
+			"newDeployment",
+			newBridge,
+			srcSignalService,
+			proof.SignalService{Address: newSignalService, Version: proof.SignalServiceV2, ChainID: mock.MockChainID.Uint64()},
+			false,
+		},
+		{
+			// the proxy is proven against, the implementation is only looked up to log it
+			"behindProxy",
+			proxiedBridge,
+			srcSignalService,
+			proof.SignalService{
+				Address:        proxySignalService,
+				Implementation: implSignalService,
+				Version:        proof.SignalServiceV1,
+				ChainID:        mock.MockChainID.Uint64(),
+			},
+			false,
+		},
+		{
+			"unresolvableFallsBackToConfigured",
+			unresolvableBridge,
+			srcSignalService,
+			proof.SignalService{Address: srcSignalService, Version: proof.SignalServiceV1, ChainID: mock.MockChainID.Uint64()},
+			false,
+		},
+		{
+			"unresolvableWithoutConfigured",
+			unresolvableBridge,
+			common.Address{},
+			proof.SignalService{},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestProcessor(true)
+			p.srcSignalServiceAddress = tt.configured
+			p.rpc = &mock.Caller{
+				V2SignalServices: []common.Address{newSignalService},
+				SignalServices: map[common.Address]common.Address{
+					oldBridge:     srcSignalService,
+					newBridge:     newSignalService,
+					proxiedBridge: proxySignalService,
+				},
+				Implementations: map[common.Address]common.Address{proxySignalService: implSignalService},
+			}
+
+			got, err := p.signalServiceFor(context.Background(), &bridge.BridgeMessageSent{
+				Message: bridge.IBridgeMessage{SrcChainId: mock.MockChainID},
+				Raw:     types.Log{Address: tt.bridge, BlockNumber: 1},
+			})
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_signalServiceVersion_cached(t *testing.T) {
+	p := newTestProcessor(true)
+
+	version, err := p.signalServiceVersion(context.Background(), srcSignalService, mock.MockChainID.Uint64())
+	assert.Nil(t, err)
+	assert.Equal(t, proof.SignalServiceV1, version)
+
+	// once detected, a layout change isn't noticed without a new address
+	p.rpc = &mock.Caller{WrongSignalSlot: true}
+
+	version, err = p.signalServiceVersion(context.Background(), srcSignalService, mock.MockChainID.Uint64())
+	assert.Nil(t, err)
+	assert.Equal(t, proof.SignalServiceV1, version)
+
+	_, err = p.signalServiceVersion(context.Background(), newSignalService, mock.MockChainID.Uint64())
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,57 @@
+package message
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/pkg/errors"
+)
+
+// signerChainID returns the chain ID to sign a message's transactions with, the one the
+// destination node reports, so a wrong chain ID can't produce signatures the node rejects
+// with "invalid sender". It fails fast when the message is for another chain, since that
+// means we're connected to the wrong destination node.
+func (p *Processor) signerChainID(ctx context.Context, destChainID *big.Int) (*big.Int, error) {
+	chainID, err := p.destNodeChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if destChainID == nil {
+		return nil, errors.Wrapf(
+			relayer.ErrChainIDMismatch,
+			"message has no chain ID, destination node is chain ID %v",
+			chainID,
+		)
+	}
+
+	if destChainID.Cmp(chainID) != 0 {
+		return nil, errors.Wrapf(
+			relayer.ErrChainIDMismatch,
+			"message is for chain ID %v, destination node is chain ID %v",
+			destChainID,
+			chainID,
+		)
+	}
+
+	return chainID, nil
+}
+
+// destNodeChainID fetches the destination node's chain ID the first time it's needed,
+// and caches it after that.
+func (p *Processor) destNodeChainID(ctx context.Context) (*big.Int, error) {
+	p.destChainIDMu.Lock()
+	defer p.destChainIDMu.Unlock()
+
+	if p.destChainID == nil {
+		chainID, err := p.destEthClient.ChainID(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "p.destEthClient.ChainID")
+		}
+
+		p.destChainID = chainID
+	}
+
+	return p.destChainID, nil
+}
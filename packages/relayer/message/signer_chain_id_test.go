@@ -0,0 +1,30 @@
+package message
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/pkg/errors"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func Test_signerChainID(t *testing.T) {
+	p := newTestProcessor(true)
+
+	chainID, err := p.signerChainID(context.Background(), mock.MockChainID)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, mock.MockChainID, chainID)
+	assert.Equal(t, mock.MockChainID, p.destChainID)
+}
+
+func Test_signerChainID_mismatch(t *testing.T) {
+	p := newTestProcessor(true)
+
+	_, err := p.signerChainID(context.Background(), big.NewInt(5))
+	assert.Equal(t, true, errors.Is(err, relayer.ErrChainIDMismatch))
+	assert.Equal(t, true, strings.Contains(err.Error(), "message is for chain ID 5, destination node is chain ID 167001"))
+}
@@ -0,0 +1,31 @@
+package message
+
+import (
+	log "github.com/sirupsen/logrus"
+)
+
+// SetStandby stops the processor sending processMessage transactions while another instance
+// is the leader, or lets it send again once this one is. ProcessMessage returns
+// relayer.ErrProcessingPaused on standby, the same as when paused, but the two are kept apart
+// so a pause outlives being promoted.
+func (p *Processor) SetStandby(standby bool) {
+	if p.standby.Swap(standby) == standby {
+		return
+	}
+
+	if standby {
+		log.Info("processor on standby")
+	} else {
+		log.Info("processor promoted from standby")
+	}
+}
+
+// Standby reports whether the processor is on standby, see SetStandby
+func (p *Processor) Standby() bool {
+	return p.standby.Load()
+}
+
+// sendingStopped reports whether messages are to be left new rather than sent
+func (p *Processor) sendingStopped() bool {
+	return p.Paused() || p.Standby()
+}
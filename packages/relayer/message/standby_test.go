@@ -0,0 +1,38 @@
+package message
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SetStandby(t *testing.T) {
+	p := newTestProcessor(true)
+
+	assert.False(t, p.Standby())
+
+	p.SetStandby(true)
+	assert.True(t, p.Standby())
+	// a standby isn't paused, so a pause is still in place after promotion
+	assert.False(t, p.Paused())
+
+	err := p.ProcessMessage(context.Background(), &bridge.BridgeMessageSent{
+		Message: bridge.IBridgeMessage{
+			GasLimit: big.NewInt(1),
+		},
+	}, &relayer.Event{})
+	assert.True(t, errors.Is(err, relayer.ErrProcessingPaused), "got %v", err)
+
+	p.Pause()
+	p.SetStandby(false)
+	assert.False(t, p.Standby())
+	assert.True(t, p.sendingStopped())
+
+	p.Resume()
+	assert.False(t, p.sendingStopped())
+}
@@ -0,0 +1,188 @@
+package message
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+// UnfinalizeReorgedOut checks whether the destination chain reorged any of its latest
+// p.destReorgWindow blocks since it was last called, by their hashes no longer being the new head's
+// ancestors', and makes srcChainID's done messages whose processMessage transaction was in a reorged
+// out block, and isn't mined anymore, new again and due to be retried, so they're processed again.
+// It's only called from one goroutine.
+func (p *Processor) UnfinalizeReorgedOut(ctx context.Context, srcChainID *big.Int) error {
+	if p.destReorgWindow == 0 {
+		return nil
+	}
+
+	head, err := p.destEthClient.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "p.destEthClient.HeaderByNumber")
+	}
+
+	from, to, reorged, err := p.trackDestHead(ctx, head)
+	if err != nil {
+		return errors.Wrap(err, "p.trackDestHead")
+	}
+
+	if !reorged {
+		return nil
+	}
+
+	destChainID, err := p.destNodeChainID(ctx)
+	if err != nil {
+		return errors.Wrap(err, "p.destNodeChainID")
+	}
+
+	events, err := p.eventRepo.FindDoneProcessedBetween(relayer.WithPrimaryReads(ctx), srcChainID, from, to)
+	if err != nil {
+		return errors.Wrap(err, "p.eventRepo.FindDoneProcessedBetween")
+	}
+
+	relayer.Logger(ctx).Warnf(
+		"destination chain %v reorged blocks %v to %v, re-checking %v done messages processed in them",
+		destChainID,
+		from,
+		to,
+		len(events),
+	)
+
+	for _, e := range events {
+		ctx := relayer.WithMessageLogger(ctx, common.HexToHash(e.MsgHash), srcChainID, destChainID)
+
+		if err := p.unfinalizeIfReorgedOut(ctx, e); err != nil {
+			return errors.Wrapf(err, "p.unfinalizeIfReorgedOut(msgHash: %v)", e.MsgHash)
+		}
+	}
+
+	return nil
+}
+
+// trackDestHead records head and the ancestors it has in the window since the last call, walking
+// back by parent hash until one matches the hash recorded for its number. The blocks from and to,
+// inclusive, were reorged out if their recorded hashes no longer match. A head behind the blocks
+// already recorded is skipped, as that's more likely a lagging node than a reorg.
+func (p *Processor) trackDestHead(ctx context.Context, head *types.Header) (uint64, uint64, bool, error) {
+	headNumber := head.Number.Uint64()
+
+	var lowest, lowestRecorded, highestRecorded uint64
+
+	if headNumber > p.destReorgWindow {
+		lowest = headNumber - p.destReorgWindow
+	}
+
+	first := true
+
+	for n := range p.destBlockHashes {
+		if first || n < lowestRecorded {
+			lowestRecorded = n
+		}
+
+		if first || n > highestRecorded {
+			highestRecorded = n
+		}
+
+		first = false
+	}
+
+	if headNumber < highestRecorded {
+		return 0, 0, false, nil
+	}
+
+	canonical := make(map[uint64]common.Hash)
+
+	for h := head; ; {
+		n := h.Number.Uint64()
+		canonical[n] = h.Hash()
+
+		if n == 0 || n-1 < lowest {
+			break
+		}
+
+		recorded, ok := p.destBlockHashes[n-1]
+		if ok && recorded == h.ParentHash {
+			break
+		}
+
+		// walk back over blocks not recorded yet only to reach the ones that are
+		if !ok && (len(p.destBlockHashes) == 0 || n-1 < lowestRecorded) {
+			break
+		}
+
+		parent, err := p.destEthClient.HeaderByHash(ctx, h.ParentHash)
+		if err != nil {
+			return 0, 0, false, errors.Wrap(err, "p.destEthClient.HeaderByHash")
+		}
+
+		h = parent
+	}
+
+	var from, to uint64
+
+	reorged := false
+
+	for n, recorded := range p.destBlockHashes {
+		hash, ok := canonical[n]
+		if !ok || hash == recorded {
+			continue
+		}
+
+		if !reorged || n < from {
+			from = n
+		}
+
+		if !reorged || n > to {
+			to = n
+		}
+
+		reorged = true
+	}
+
+	for n, hash := range canonical {
+		p.destBlockHashes[n] = hash
+	}
+
+	for n := range p.destBlockHashes {
+		if n < lowest {
+			delete(p.destBlockHashes, n)
+		}
+	}
+
+	return from, to, reorged, nil
+}
+
+// unfinalizeIfReorgedOut requeues e if its transaction is no longer mined, or was mined again
+// and reverted, and records the block it's in now otherwise.
+func (p *Processor) unfinalizeIfReorgedOut(ctx context.Context, e *relayer.Event) error {
+	txHash := common.HexToHash(e.ProcessingTxHash)
+
+	receipt, err := p.destEthClient.TransactionReceipt(ctx, txHash)
+	if err != nil && !errors.Is(err, ethereum.NotFound) {
+		return errors.Wrap(err, "p.destEthClient.TransactionReceipt")
+	}
+
+	if errors.Is(err, ethereum.NotFound) || receipt.Status != types.ReceiptStatusSuccessful {
+		relayer.Logger(ctx).Warnf("txHash: %v was reorged out, the done message is requeued", txHash.Hex())
+
+		relayer.UnfinalizedMessages.WithLabelValues(strconv.FormatInt(e.ChainID, 10)).Inc()
+
+		if err := p.requeueReorgedOut(ctx, e); err != nil {
+			return errors.Wrap(err, "p.requeueReorgedOut")
+		}
+
+		return nil
+	}
+
+	if err := p.eventRepo.UpdateProcessingBlock(ctx, e.ID, receipt.BlockNumber.Uint64()); err != nil {
+		return errors.Wrap(err, "p.eventRepo.UpdateProcessingBlock")
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package message
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// reorgingChain is a Backend whose headers link to their parents by hash, so it can reorg
+type reorgingChain struct {
+	*mock.Backend
+	headers   map[common.Hash]*types.Header
+	canonical []*types.Header
+}
+
+func newReorgingChain(backend *mock.Backend, head int) *reorgingChain {
+	c := &reorgingChain{Backend: backend, headers: make(map[common.Hash]*types.Header)}
+	c.extend(head+1, "a")
+
+	return c
+}
+
+// extend adds n blocks to the chain, fork telling the blocks of different forks apart
+func (c *reorgingChain) extend(n int, fork string) {
+	for i := 0; i < n; i++ {
+		h := &types.Header{Number: big.NewInt(int64(len(c.canonical))), Extra: []byte(fork)}
+
+		if len(c.canonical) > 0 {
+			h.ParentHash = c.canonical[len(c.canonical)-1].Hash()
+		}
+
+		c.headers[h.Hash()] = h
+		c.canonical = append(c.canonical, h)
+	}
+}
+
+// reorg replaces the blocks from number on with n blocks of fork
+func (c *reorgingChain) reorg(number uint64, n int, fork string) {
+	c.canonical = c.canonical[:number]
+	c.extend(n, fork)
+}
+
+func (c *reorgingChain) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if number == nil {
+		return c.canonical[len(c.canonical)-1], nil
+	}
+
+	return c.canonical[number.Uint64()], nil
+}
+
+func (c *reorgingChain) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	return c.headers[hash], nil
+}
+
+func Test_UnfinalizeReorgedOut(t *testing.T) {
+	p, backend := newBackendProcessor(0)
+	p.destReorgWindow = 5
+	p.destBlockHashes = make(map[uint64]common.Hash)
+
+	chain := newReorgingChain(backend, 20)
+	p.destEthClient = chain
+
+	eventRepo := mock.NewEventRepository()
+	p.eventRepo = eventRepo
+
+	// reorgedOut's transaction is dropped by the reorg, reMined's mined again in another block, and
+	// outside's block isn't reorged
+	reorgedOut, reorgedOutTx := processedUnconfirmed(t, p, eventRepo)
+	reorgedOut.MsgHash = "0x1"
+	reMined, _ := processedUnconfirmed(t, p, eventRepo)
+	reMined.MsgHash = "0x2"
+	outside, _ := processedUnconfirmed(t, p, eventRepo)
+	outside.MsgHash = "0x3"
+
+	for e, block := range map[*relayer.Event]uint64{reorgedOut: 24, reMined: 23, outside: 22} {
+		assert.Nil(t, eventRepo.UpdateStatus(context.Background(), e.ID, relayer.EventStatusDone))
+		assert.Nil(t, eventRepo.UpdateProcessingBlock(context.Background(), e.ID, block))
+	}
+
+	// the first check only records the head
+	assert.Nil(t, p.UnfinalizeReorgedOut(context.Background(), mock.MockChainID))
+
+	chain.extend(5, "a")
+	assert.Nil(t, p.UnfinalizeReorgedOut(context.Background(), mock.MockChainID))
+	assert.Equal(t, 6, len(p.destBlockHashes))
+
+	unfinalized := relayer.UnfinalizedMessages.WithLabelValues(mock.MockChainID.String())
+	before := testutil.ToFloat64(unfinalized)
+
+	// blocks 23 to 25 are replaced by a longer fork
+	chain.reorg(23, 4, "b")
+	backend.Reorg(reorgedOutTx)
+
+	// a node lagging behind the blocks recorded isn't taken for a reorg
+	chain.canonical = chain.canonical[:25]
+	assert.Nil(t, p.UnfinalizeReorgedOut(context.Background(), mock.MockChainID))
+	assert.Equal(t, relayer.EventStatusDone, reorgedOut.Status)
+
+	chain.reorg(23, 4, "b")
+	assert.Nil(t, p.UnfinalizeReorgedOut(context.Background(), mock.MockChainID))
+
+	assert.Equal(t, relayer.EventStatusNew, reorgedOut.Status)
+	assert.NotNil(t, reorgedOut.NextRetryAt)
+	assert.Equal(t, before+1, testutil.ToFloat64(unfinalized))
+
+	assert.Equal(t, relayer.EventStatusDone, reMined.Status)
+	assert.NotEqual(t, uint64(23), *reMined.ProcessingBlockNumber)
+
+	assert.Equal(t, relayer.EventStatusDone, outside.Status)
+	assert.Equal(t, uint64(22), *outside.ProcessingBlockNumber)
+
+	assert.Equal(t, chain.canonical[26].Hash(), p.destBlockHashes[26])
+	assert.Equal(t, chain.canonical[23].Hash(), p.destBlockHashes[23])
+
+	// blocks that fell out of the window are no longer recorded
+	_, ok := p.destBlockHashes[20]
+	assert.False(t, ok)
+
+	// and when the requeued message is retried, it's sent again
+	sent := len(backend.Sent())
+
+	assert.Nil(t, p.ProcessMessage(context.Background(), backendTestEvent(), reorgedOut))
+	assert.Equal(t, sent+1, len(backend.Sent()))
+	assert.Equal(t, backend.Sent()[sent].Hash().Hex(), reorgedOut.ProcessingTxHash)
+}
+
+func Test_UnfinalizeReorgedOut_disabled(t *testing.T) {
+	p, _ := newBackendProcessor(0)
+
+	assert.Nil(t, p.UnfinalizeReorgedOut(context.Background(), mock.MockChainID))
+	assert.Equal(t, 0, len(p.destBlockHashes))
+}
@@ -14,13 +14,13 @@ func (p *Processor) waitForConfirmations(ctx context.Context, txHash common.Hash
 
 	defer cancelFunc()
 
-	if err := relayer.WaitConfirmations(
+	if err := relayer.WaitConfirmed(
 		ctx,
 		p.srcEthClient,
-		p.confirmations,
+		p.confirmationStrategy,
 		txHash,
 	); err != nil {
-		return errors.Wrap(err, "relayer.WaitConfirmations")
+		return errors.Wrap(err, "relayer.WaitConfirmed")
 	}
 
 	return nil
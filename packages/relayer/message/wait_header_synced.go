@@ -5,28 +5,70 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
-	log "github.com/sirupsen/logrus"
 )
 
+// waitHeaderSynced waits for the destination chain to sync the block event occurred in, checking
+// every p.headerSyncIntervalSeconds, and as soon as a sync is published to p.syncedHeights.
 func (p *Processor) waitHeaderSynced(ctx context.Context, event *bridge.BridgeMessageSent) error {
 	ticker := time.NewTicker(time.Duration(p.headerSyncIntervalSeconds) * time.Second)
 	defer ticker.Stop()
 
+	var synced <-chan relayer.SyncedHeight
+
+	if p.syncedHeights != nil {
+		sub := p.syncedHeights.Subscribe(1)
+		defer sub.Unsubscribe()
+
+		synced = sub.C()
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
+		case h := <-synced:
+			if h.ChainID == nil || h.ChainID.Cmp(event.Message.DestChainId) != 0 || h.SrcHeight < event.Raw.BlockNumber {
+				continue
+			}
+
+			relayer.Logger(ctx).Infof(
+				"txHash: %v is processable. occurred in block %v, synced block %v",
+				event.Raw.TxHash.Hex(),
+				event.Raw.BlockNumber,
+				h.SrcHeight,
+			)
+
+			return nil
 		case <-ticker.C:
-			log.Infof(
-				"msgHash: %v, txHash: %v is waiting to be processable. occurred in block %v",
-				common.Hash(event.MsgHash).Hex(),
+			relayer.Logger(ctx).Infof(
+				"txHash: %v is waiting to be processable. occurred in block %v",
 				event.Raw.TxHash.Hex(),
 				event.Raw.BlockNumber,
 			)
+
+			// the indexer mirrors CrossChainSynced events into the repo, so check there first
+			// and only fall back to a live call if it hasn't seen our block synced yet.
+			latestSyncedHeight, err := p.crossChainSyncRepo.LatestSyncedHeight(ctx, event.Message.DestChainId)
+			if err != nil {
+				return errors.Wrap(err, "p.crossChainSyncRepo.LatestSyncedHeight")
+			}
+
+			if latestSyncedHeight >= event.Raw.BlockNumber {
+				relayer.Logger(ctx).Infof(
+					"txHash: %v is processable. occurred in block %v, latestSynced is block %v",
+					event.Raw.TxHash.Hex(),
+					event.Raw.BlockNumber,
+					latestSyncedHeight,
+				)
+
+				return nil
+			}
+
 			// get latest synced header since not every header is synced from L1 => L2,
 			// and later blocks still have the storage trie proof from previous blocks.
 			latestSyncedHeader, err := p.destHeaderSyncer.GetCrossChainBlockHash(&bind.CallOpts{}, big.NewInt(0))
@@ -41,9 +83,8 @@ func (p *Processor) waitHeaderSynced(ctx context.Context, event *bridge.BridgeMe
 
 			// header is caught up and processible
 			if header.Number.Uint64() >= event.Raw.BlockNumber {
-				log.Infof(
-					"msgHash: %v, txHash: %v is processable. occurred in block %v, latestSynced is block %v",
-					common.Hash(event.MsgHash).Hex(),
+				relayer.Logger(ctx).Infof(
+					"txHash: %v is processable. occurred in block %v, latestSynced is block %v",
 					event.Raw.TxHash.Hex(),
 					event.Raw.BlockNumber,
 					header.Number.Uint64(),
@@ -52,9 +93,8 @@ func (p *Processor) waitHeaderSynced(ctx context.Context, event *bridge.BridgeMe
 				return nil
 			}
 
-			log.Infof(
-				"msgHash: %v, txHash: %v is waiting to be processable. occurred in block %v, latestSynced is block %v",
-				common.Hash(event.MsgHash).Hex(),
+			relayer.Logger(ctx).Infof(
+				"txHash: %v is waiting to be processable. occurred in block %v, latestSynced is block %v",
 				event.Raw.TxHash.Hex(),
 				event.Raw.BlockNumber,
 				header.Number.Uint64(),
@@ -62,3 +102,28 @@ func (p *Processor) waitHeaderSynced(ctx context.Context, event *bridge.BridgeMe
 		}
 	}
 }
+
+// LatestSyncedBlockHash is the hash of the latest source chain block the destination chain has synced
+func (p *Processor) LatestSyncedBlockHash(ctx context.Context) (common.Hash, error) {
+	hash, err := p.destHeaderSyncer.GetCrossChainBlockHash(&bind.CallOpts{Context: ctx}, big.NewInt(0))
+	if err != nil {
+		return common.Hash{}, errors.Wrap(err, "p.destHeaderSyncer.GetCrossChainBlockHash")
+	}
+
+	return common.Hash(hash), nil
+}
+
+// LatestSyncedHeight is the number of the latest source chain block the destination chain has synced
+func (p *Processor) LatestSyncedHeight(ctx context.Context) (uint64, error) {
+	hash, err := p.LatestSyncedBlockHash(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "p.LatestSyncedBlockHash")
+	}
+
+	header, err := p.srcEthClient.HeaderByHash(ctx, hash)
+	if err != nil {
+		return 0, errors.Wrap(err, "p.srcEthClient.HeaderByHash")
+	}
+
+	return header.Number.Uint64(), nil
+}
@@ -2,9 +2,14 @@ package message
 
 import (
 	"context"
+	"math/big"
 	"testing"
+	"time"
 
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/eventbus"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -19,3 +24,56 @@ func Test_waitHeaderSynced(t *testing.T) {
 	})
 	assert.Nil(t, err)
 }
+
+func Test_waitHeaderSynced_fromCrossChainSyncRepo(t *testing.T) {
+	p := newTestProcessor(true)
+
+	// the live header syncer would fail, so this only succeeds by reading the repo
+	p.destHeaderSyncer = &mock.HeaderSyncer{Fail: true}
+
+	err := p.crossChainSyncRepo.Save(context.Background(), relayer.SaveCrossChainSyncOpts{
+		ChainID:   mock.MockChainID,
+		SrcHeight: 5,
+	})
+	assert.Nil(t, err)
+
+	err = p.waitHeaderSynced(context.TODO(), &bridge.BridgeMessageSent{
+		Message: bridge.IBridgeMessage{
+			DestChainId: mock.MockChainID,
+		},
+		Raw: types.Log{
+			BlockNumber: 5,
+		},
+	})
+	assert.Nil(t, err)
+}
+
+func Test_waitHeaderSynced_fromSyncedHeights(t *testing.T) {
+	p := newTestProcessor(true)
+
+	// it'd be an hour before polling, so this only succeeds by the sync being published
+	p.headerSyncIntervalSeconds = 3600
+	p.syncedHeights = eventbus.New[relayer.SyncedHeight]("test_synced_heights")
+
+	// another chain's sync, and a height too low, don't wake it
+	p.syncedHeights.Publish(relayer.SyncedHeight{ChainID: big.NewInt(1), SrcHeight: 10})
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		p.syncedHeights.Publish(relayer.SyncedHeight{ChainID: mock.MockChainID, SrcHeight: 4})
+		p.syncedHeights.Publish(relayer.SyncedHeight{ChainID: mock.MockChainID, SrcHeight: 5})
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := p.waitHeaderSynced(ctx, &bridge.BridgeMessageSent{
+		Message: bridge.IBridgeMessage{
+			DestChainId: mock.MockChainID,
+		},
+		Raw: types.Log{
+			BlockNumber: 5,
+		},
+	})
+	assert.Nil(t, err)
+}
@@ -0,0 +1,236 @@
+package message
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+)
+
+var (
+	// maxTxReplacements is how many times we will resubmit a transaction
+	// with bumped fees before giving up on it.
+	maxTxReplacements = 3
+
+	// gasBumpPercentage is how much we increase fees by when replacing a transaction,
+	// nodes require at least a 10% increase to accept a replacement.
+	gasBumpPercentage int64 = 20
+
+	errReceiptTimeout = errors.New("timed out waiting for transaction receipt")
+)
+
+// RevertError is returned when a transaction was mined, but reverted.
+type RevertError struct {
+	TxHash common.Hash
+	Reason string
+}
+
+func (e *RevertError) Error() string {
+	return fmt.Sprintf("transaction reverted, hash: %v, reason: %v", e.TxHash.Hex(), e.Reason)
+}
+
+// waitReceipt polls for the receipt of tx every p.receiptPollInterval. If it has not been
+// mined within p.receiptTimeout, the transaction is replaced by one with the same nonce and
+// higher fees, and we keep waiting for whichever of them gets mined first.
+// onReplaced, if not nil, is called with each replacement once it's sent. A reverted
+// transaction's receipt is returned along with its RevertError, as it still cost gas.
+func (p *Processor) waitReceipt(
+	ctx context.Context,
+	tx *types.Transaction,
+	onReplaced func(replacement *types.Transaction),
+) (*types.Receipt, error) {
+	txs := []*types.Transaction{tx}
+
+	for i := 0; ; i++ {
+		receipt, err := p.pollReceipt(ctx, txs)
+		if err == nil {
+			if receipt.Status != types.ReceiptStatusSuccessful {
+				return receipt, &RevertError{
+					TxHash: receipt.TxHash,
+					Reason: p.revertReason(ctx, tx, receipt),
+				}
+			}
+
+			return receipt, nil
+		}
+
+		if err != errReceiptTimeout || i >= maxTxReplacements {
+			return nil, err
+		}
+
+		relayer.Logger(ctx).Warnf("txHash: %v not mined after %v, replacing", tx.Hash().Hex(), p.receiptTimeout)
+
+		tx, err = p.replaceTransaction(ctx, tx)
+		if err != nil {
+			return nil, errors.Wrap(err, "p.replaceTransaction")
+		}
+
+		if onReplaced != nil {
+			onReplaced(tx)
+		}
+
+		txs = append(txs, tx)
+	}
+}
+
+// pollReceipt returns the first receipt found for any of txs, or errReceiptTimeout
+// if none of them was mined within p.receiptTimeout.
+func (p *Processor) pollReceipt(ctx context.Context, txs []*types.Transaction) (*types.Receipt, error) {
+	ticker := time.NewTicker(p.receiptPollInterval)
+	defer ticker.Stop()
+
+	timeout := time.NewTimer(p.receiptTimeout)
+	defer timeout.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timeout.C:
+			return nil, errReceiptTimeout
+		case <-ticker.C:
+			for _, tx := range txs {
+				receipt, err := p.destEthClient.TransactionReceipt(ctx, tx.Hash())
+				if err != nil {
+					continue
+				}
+
+				relayer.Logger(ctx).Infof("transaction receipt found for txHash %v", tx.Hash().Hex())
+
+				return receipt, nil
+			}
+		}
+	}
+}
+
+// replaceTransaction re-signs and sends tx with the same nonce, bumping its fees
+// by gasBumpPercentage, or to the currently suggested fees if those are higher.
+// It's signed with the destination node's chain ID and by the key tx was, like tx was.
+func (p *Processor) replaceTransaction(
+	ctx context.Context,
+	tx *types.Transaction,
+) (*types.Transaction, error) {
+	chainID, err := p.destNodeChainID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "p.destNodeChainID")
+	}
+
+	auth, err := bind.NewKeyedTransactorWithChainID(p.keys.signerOf(tx).key, chainID)
+	if err != nil {
+		return nil, errors.Wrap(err, "bind.NewKeyedTransactorWithChainID")
+	}
+
+	var replacement types.TxData
+
+	if tx.Type() == types.DynamicFeeTxType {
+		gasTipCap, err := p.destEthClient.SuggestGasTipCap(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "p.destEthClient.SuggestGasTipCap")
+		}
+
+		gasTipCap = maxBig(bumpGas(tx.GasTipCap()), gasTipCap)
+
+		gasFeeCap := maxBig(bumpGas(tx.GasFeeCap()), gasTipCap)
+
+		oracleFeeCap, err := p.gasFeeCap(ctx, gasTipCap)
+		if err != nil {
+			return nil, errors.Wrap(err, "p.gasFeeCap")
+		}
+
+		if oracleFeeCap != nil {
+			gasFeeCap = maxBig(gasFeeCap, oracleFeeCap)
+		}
+
+		replacement = &types.DynamicFeeTx{
+			ChainID:    chainID,
+			Nonce:      tx.Nonce(),
+			GasTipCap:  gasTipCap,
+			GasFeeCap:  gasFeeCap,
+			Gas:        tx.Gas(),
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+		}
+	} else {
+		gasPrice, err := p.suggestGasPrice(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "p.suggestGasPrice")
+		}
+
+		replacement = &types.LegacyTx{
+			Nonce:    tx.Nonce(),
+			GasPrice: maxBig(bumpGas(tx.GasPrice()), gasPrice),
+			Gas:      tx.Gas(),
+			To:       tx.To(),
+			Value:    tx.Value(),
+			Data:     tx.Data(),
+		}
+	}
+
+	signed, err := auth.Signer(auth.From, types.NewTx(replacement))
+	if err != nil {
+		return nil, errors.Wrap(err, "auth.Signer")
+	}
+
+	if err := p.sendTransaction(ctx, signed); err != nil {
+		return nil, errors.Wrap(err, "p.sendTransaction")
+	}
+
+	relayer.Logger(ctx).Infof("replaced txHash %v with txHash %v", tx.Hash().Hex(), signed.Hash().Hex())
+
+	return signed, nil
+}
+
+// revertReason replays tx at the block it was mined in to recover the revert reason,
+// since receipts don't include it.
+func (p *Processor) revertReason(ctx context.Context, tx *types.Transaction, receipt *types.Receipt) string {
+	_, err := p.destEthClient.CallContract(ctx, ethereum.CallMsg{
+		From:  p.keys.signerOf(tx).addr,
+		To:    tx.To(),
+		Gas:   tx.Gas(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}, receipt.BlockNumber)
+	if err == nil {
+		return "unknown"
+	}
+
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if data, ok := dataErr.ErrorData().(string); ok {
+			if reason, err := abi.UnpackRevert(common.FromHex(data)); err == nil {
+				return reason
+			}
+		}
+	}
+
+	return err.Error()
+}
+
+func bumpGas(v *big.Int) *big.Int {
+	if v == nil {
+		return big.NewInt(0)
+	}
+
+	bumped := new(big.Int).Mul(v, big.NewInt(100+gasBumpPercentage))
+
+	return bumped.Div(bumped, big.NewInt(100))
+}
+
+func maxBig(a *big.Int, b *big.Int) *big.Int {
+	if a.Cmp(b) >= 0 {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,91 @@
+package message
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_waitReceipt(t *testing.T) {
+	tests := []struct {
+		name          string
+		tx            *types.Transaction
+		wantReplaced  bool
+		wantRevertErr *RevertError
+	}{
+		{
+			"mined",
+			mock.ProcessMessageTx,
+			false,
+			nil,
+		},
+		{
+			"reverted",
+			mock.RevertedTx,
+			false,
+			&RevertError{
+				TxHash: mock.RevertedTx.Hash(),
+				Reason: mock.RevertReason,
+			},
+		},
+		{
+			"timeoutReplaced",
+			mock.NeverMinedTx,
+			true,
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestProcessor(true)
+
+			receipt, err := p.waitReceipt(context.Background(), tt.tx, nil)
+			if tt.wantRevertErr != nil {
+				assert.Equal(t, tt.wantRevertErr, err)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+		})
+	}
+}
+
+func Test_replaceTransaction(t *testing.T) {
+	p := newTestProcessor(true)
+
+	replaced, err := p.replaceTransaction(context.Background(), mock.NeverMinedTx)
+	assert.Nil(t, err)
+	assert.Equal(t, mock.NeverMinedTx.Nonce(), replaced.Nonce())
+	assert.Equal(t, big.NewInt(120), replaced.GasPrice())
+	assert.NotEqual(t, mock.NeverMinedTx.Hash(), replaced.Hash())
+}
+
+func Test_replaceTransaction_keepsAccessList(t *testing.T) {
+	p := newTestProcessor(true)
+
+	to := common.HexToAddress("0x1000777700000000000000000000000000000004")
+	accessList := types.AccessList{{Address: to, StorageKeys: []common.Hash{{0x1}}}}
+
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:    mock.MockChainID,
+		Nonce:      2,
+		GasTipCap:  big.NewInt(10),
+		GasFeeCap:  big.NewInt(100),
+		Gas:        100,
+		To:         &to,
+		Value:      big.NewInt(0),
+		AccessList: accessList,
+	})
+
+	replaced, err := p.replaceTransaction(context.Background(), tx)
+	assert.Nil(t, err)
+	assert.Equal(t, tx.Nonce(), replaced.Nonce())
+	assert.Equal(t, accessList, replaced.AccessList())
+}
@@ -0,0 +1,36 @@
+package message
+
+import (
+	"context"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+)
+
+// acquireWorker blocks until fewer than the processor's concurrency of messages are being
+// processed, or ctx is done. Once acquired it must be released with releaseWorker.
+func (p *Processor) acquireWorker(ctx context.Context) error {
+	if p.workers == nil {
+		return nil
+	}
+
+	relayer.ProcessorQueueDepth.Inc()
+	defer relayer.ProcessorQueueDepth.Dec()
+
+	select {
+	case p.workers <- struct{}{}:
+		relayer.ProcessorWorkersActive.Inc()
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *Processor) releaseWorker() {
+	if p.workers == nil {
+		return
+	}
+
+	<-p.workers
+
+	relayer.ProcessorWorkersActive.Dec()
+}
@@ -0,0 +1,28 @@
+package message
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_acquireWorker(t *testing.T) {
+	p := newTestProcessor(true)
+	p.workers = make(chan struct{}, 2)
+
+	assert.Nil(t, p.acquireWorker(context.Background()))
+	assert.Nil(t, p.acquireWorker(context.Background()))
+
+	// both workers are busy, so the next message waits until the context is done
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.Equal(t, context.DeadlineExceeded, p.acquireWorker(ctx))
+
+	p.releaseWorker()
+
+	assert.Nil(t, p.acquireWorker(context.Background()))
+	assert.Equal(t, 2, len(p.workers))
+}
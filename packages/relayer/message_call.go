@@ -0,0 +1,40 @@
+package relayer
+
+import (
+	"math/big"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// MessageCall is the arbitrary call a bridge message makes on the destination chain
+type MessageCall struct {
+	To       common.Address
+	Data     []byte
+	GasLimit *big.Int
+}
+
+// DecodeMessageCall pulls the call a message will make out of it
+func DecodeMessageCall(m bridge.IBridgeMessage) MessageCall {
+	gasLimit := m.GasLimit
+	if gasLimit == nil {
+		gasLimit = big.NewInt(0)
+	}
+
+	return MessageCall{
+		To:       m.To,
+		Data:     m.Data,
+		GasLimit: gasLimit,
+	}
+}
+
+// Selector is the hex encoded 4 byte function selector being called,
+// or empty if the call has no data.
+func (c MessageCall) Selector() string {
+	if len(c.Data) < 4 {
+		return ""
+	}
+
+	return hexutil.Encode(c.Data[:4])
+}
@@ -0,0 +1,49 @@
+package relayer
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DecodeMessageCall(t *testing.T) {
+	to := common.HexToAddress("0x63FaC9201494f0bd17B9892B9fae4d52fe3BD377")
+
+	tests := []struct {
+		name         string
+		message      bridge.IBridgeMessage
+		wantGasLimit *big.Int
+		wantSelector string
+	}{
+		{
+			"call",
+			bridge.IBridgeMessage{
+				To:       to,
+				Data:     common.Hex2Bytes("a9059cbb0000"),
+				GasLimit: big.NewInt(100000),
+			},
+			big.NewInt(100000),
+			"0xa9059cbb",
+		},
+		{
+			"noDataOrGasLimit",
+			bridge.IBridgeMessage{
+				To: to,
+			},
+			big.NewInt(0),
+			"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			call := DecodeMessageCall(tt.message)
+			assert.Equal(t, to, call.To)
+			assert.Equal(t, tt.wantGasLimit, call.GasLimit)
+			assert.Equal(t, tt.wantSelector, call.Selector())
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package relayer
+
+import "context"
+
+// DiagnosisCheck is the outcome of one check on a message that hasn't been processed
+type DiagnosisCheck struct {
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail"`
+	// Rejection is why the message can't be processed, when the check failing says so
+	Rejection RejectionReason `json:"rejection,omitempty"`
+}
+
+// MessageDiagnosis explains why a message hasn't been processed, for support to act on
+// without piecing it together from logs and block explorers
+type MessageDiagnosis struct {
+	MsgHash string `json:"msgHash"`
+	// Status is the message's status in the database
+	Status string `json:"status"`
+	// HeaderSynced checks the block the message was sent in is synced to the destination chain
+	HeaderSynced DiagnosisCheck `json:"headerSynced"`
+	// SignalSent checks the message's signal is stored in the source chain's SignalService
+	SignalSent DiagnosisCheck `json:"signalSent"`
+	// ProofVerifies checks the signal proof we'd send verifies against the source chain
+	ProofVerifies DiagnosisCheck `json:"proofVerifies"`
+	// DestStatus is the message's status on the destination bridge, and passes while it's still new there
+	DestStatus DiagnosisCheck `json:"destStatus"`
+	// LastProcessingError is the error the last attempt to process the message failed with
+	LastProcessingError string `json:"lastProcessingError"`
+	// BridgeStatusChanges are the statuses the bridges reported for the message, in the order indexed
+	BridgeStatusChanges []*BridgeStatusChange `json:"bridgeStatusChanges"`
+	// Diagnosis is the most likely reason the message is stuck
+	Diagnosis string `json:"diagnosis"`
+	// Rejection is the diagnosis as a structured reason, with a retry hint, nil if it isn't one of them
+	Rejection *Rejection `json:"rejection"`
+}
+
+// MessageDiagnoser explains why a message it would process hasn't been
+type MessageDiagnoser interface {
+	DiagnoseMessage(ctx context.Context, e *Event) (*MessageDiagnosis, error)
+}
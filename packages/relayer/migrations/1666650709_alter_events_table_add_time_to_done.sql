@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE `events`
+    ADD COLUMN `message_sent_timestamp` BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    ADD COLUMN `done_timestamp` BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    ADD COLUMN `time_to_done_in_seconds` BIGINT UNSIGNED NOT NULL DEFAULT 0;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE `events`
+    DROP COLUMN `message_sent_timestamp`,
+    DROP COLUMN `done_timestamp`,
+    DROP COLUMN `time_to_done_in_seconds`;
+-- +goose StatementEnd
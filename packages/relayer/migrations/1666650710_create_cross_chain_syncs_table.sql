@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS cross_chain_syncs (
+    id int NOT NULL PRIMARY KEY AUTO_INCREMENT,
+    chain_id int NOT NULL,
+    src_height BIGINT UNSIGNED NOT NULL,
+    block_hash VARCHAR(255) NOT NULL,
+    signal_root VARCHAR(255) NOT NULL,
+    synced_at DATETIME NOT NULL,
+    dest_tx_hash VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY `chain_id_src_height_unique` (`chain_id`, `src_height`)
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE cross_chain_syncs;
+-- +goose StatementEnd
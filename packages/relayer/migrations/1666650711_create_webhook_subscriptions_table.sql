@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id int NOT NULL PRIMARY KEY AUTO_INCREMENT,
+    message_owner VARCHAR(255) NOT NULL,
+    url VARCHAR(2048) NOT NULL,
+    statuses VARCHAR(255) NOT NULL DEFAULT "",
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX `message_owner_index` (`message_owner`)
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE webhook_subscriptions;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE `events`
+    ADD COLUMN `message_call_to` VARCHAR(255) NOT NULL DEFAULT "",
+    ADD COLUMN `message_call_selector` VARCHAR(10) NOT NULL DEFAULT "";
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE `events`
+    DROP COLUMN `message_call_to`,
+    DROP COLUMN `message_call_selector`;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE `events`
+    ADD COLUMN `processing_tx_hash` VARCHAR(66) NOT NULL DEFAULT "",
+    ADD INDEX `chain_id_status_index` (`chain_id`, `status`);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE `events`
+    DROP INDEX `chain_id_status_index`,
+    DROP COLUMN `processing_tx_hash`;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id int NOT NULL PRIMARY KEY AUTO_INCREMENT,
+    idempotency_key VARCHAR(255) NOT NULL,
+    url VARCHAR(2048) NOT NULL,
+    url_hash CHAR(64) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ,
+    UNIQUE KEY `idempotency_key_url_hash_unique` (`idempotency_key`, `url_hash`)
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE webhook_deliveries;
+-- +goose StatementEnd
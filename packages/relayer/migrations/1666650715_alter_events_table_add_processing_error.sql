@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE `events`
+    ADD COLUMN `processing_error` TEXT;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE `events`
+    DROP COLUMN `processing_error`;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE `events`
+    ADD COLUMN `duplicate_of_event_id` int;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE `events`
+    DROP COLUMN `duplicate_of_event_id`;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE `events`
+    ADD COLUMN `block_number` BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    ADD INDEX `chain_id_block_number_index` (`chain_id`, `block_number`);
+
+-- +goose StatementEnd
+-- +goose StatementBegin
+-- events indexed before now have their block number in the log they were saved with
+UPDATE `events`
+    SET `block_number` = CONV(SUBSTRING(JSON_UNQUOTE(JSON_EXTRACT(`data`, '$.Raw.blockNumber')), 3), 16, 10)
+    WHERE JSON_EXTRACT(`data`, '$.Raw.blockNumber') IS NOT NULL;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE `events`
+    DROP INDEX `chain_id_block_number_index`,
+    DROP COLUMN `block_number`;
+-- +goose StatementEnd
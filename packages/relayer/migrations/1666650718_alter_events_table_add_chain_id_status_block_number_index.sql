@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE `events` ADD INDEX `chain_id_status_block_number_index` (`chain_id`, `status`, `block_number`);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX chain_id_status_block_number_index on events;
+-- +goose StatementEnd
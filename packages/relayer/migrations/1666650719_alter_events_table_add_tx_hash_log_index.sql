@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the log an event was indexed from, so indexing the same log again is caught by the unique index.
+-- events saved before now are left null, rows with null never conflict.
+ALTER TABLE `events`
+    ADD COLUMN `tx_hash` VARCHAR(66) NULL DEFAULT NULL,
+    ADD COLUMN `log_index` INT UNSIGNED NULL DEFAULT NULL,
+    ADD UNIQUE INDEX `chain_id_name_tx_hash_log_index_unique` (`chain_id`, `name`, `tx_hash`, `log_index`);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE `events`
+    DROP INDEX `chain_id_name_tx_hash_log_index_unique`,
+    DROP COLUMN `log_index`,
+    DROP COLUMN `tx_hash`;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the message's sender and destination chain, so filtering on them doesn't scan every event's data.
+-- senders are lower case, as addresses are in the data they're backfilled from.
+ALTER TABLE `events`
+    ADD COLUMN `message_sender` VARCHAR(42) NOT NULL DEFAULT "",
+    ADD COLUMN `dest_chain_id` BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    ADD INDEX `message_sender_chain_id_index` (`message_sender`, `chain_id`),
+    ADD INDEX `chain_id_dest_chain_id_index` (`chain_id`, `dest_chain_id`);
+
+-- +goose StatementEnd
+-- +goose StatementBegin
+-- MessageSent events indexed before now have them in the message they were saved with
+UPDATE `events`
+    SET `message_sender` = LOWER(JSON_UNQUOTE(JSON_EXTRACT(`data`, '$.Message.Sender'))),
+        `dest_chain_id` = CAST(JSON_EXTRACT(`data`, '$.Message.DestChainId') AS UNSIGNED)
+    WHERE `name` = 'MessageSent' AND JSON_EXTRACT(`data`, '$.Message.Sender') IS NOT NULL;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE `events`
+    DROP INDEX `chain_id_dest_chain_id_index`,
+    DROP INDEX `message_sender_chain_id_index`,
+    DROP COLUMN `dest_chain_id`,
+    DROP COLUMN `message_sender`;
+-- +goose StatementEnd
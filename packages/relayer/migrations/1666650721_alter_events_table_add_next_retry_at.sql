@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- why a message's last processing attempt failed, and when it's next retried. a message waiting on the
+-- destination chain to sync has a retry reason but no next retry until it does.
+ALTER TABLE `events`
+    ADD COLUMN `retry_reason` VARCHAR(32) NOT NULL DEFAULT "",
+    ADD COLUMN `next_retry_at` DATETIME NULL DEFAULT NULL,
+    ADD INDEX `chain_id_next_retry_at_index` (`chain_id`, `next_retry_at`);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE `events`
+    DROP INDEX `chain_id_next_retry_at_index`,
+    DROP COLUMN `next_retry_at`,
+    DROP COLUMN `retry_reason`;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+-- a message's statuses as the bridge reported them, in MessageStatusChanged events, by message hash
+CREATE TABLE IF NOT EXISTS bridge_status_changes (
+    id int NOT NULL PRIMARY KEY AUTO_INCREMENT,
+    msg_hash VARCHAR(255) NOT NULL,
+    chain_id int NOT NULL,
+    status int NOT NULL,
+    block_number BIGINT UNSIGNED NOT NULL,
+    tx_hash VARCHAR(66) NOT NULL,
+    log_index INT UNSIGNED NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY `chain_id_tx_hash_log_index_unique` (`chain_id`, `tx_hash`, `log_index`),
+    INDEX `msg_hash_index` (`msg_hash`)
+);
+
+-- +goose StatementEnd
+-- +goose StatementBegin
+-- MessageStatusChanged events indexed before now, that were recorded with the log they came from
+INSERT IGNORE INTO bridge_status_changes (msg_hash, chain_id, status, block_number, tx_hash, log_index, created_at)
+    SELECT `msg_hash`, `chain_id`, `status`, `block_number`, `tx_hash`, `log_index`, `created_at`
+    FROM `events`
+    WHERE `name` = 'MessageStatusChanged' AND `tx_hash` IS NOT NULL AND `log_index` IS NOT NULL
+    ORDER BY `id` ASC;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE bridge_status_changes;
+-- +goose StatementEnd
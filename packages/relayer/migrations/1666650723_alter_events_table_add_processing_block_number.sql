@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the destination block a message's processMessage transaction was mined in, so messages whose
+-- transaction a destination reorg dropped can be found and processed again.
+ALTER TABLE `events`
+    ADD COLUMN `processing_block_number` BIGINT NULL DEFAULT NULL,
+    ADD INDEX `chain_id_processing_block_number_index` (`chain_id`, `processing_block_number`);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE `events`
+    DROP INDEX `chain_id_processing_block_number_index`,
+    DROP COLUMN `processing_block_number`;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- why the last attempt to process a message found it can't be processed, if it's a known reason,
+-- so integrators can act on it without matching on processing_error.
+ALTER TABLE `events`
+    ADD COLUMN `rejection_reason` VARCHAR(32) NOT NULL DEFAULT '';
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE `events`
+    DROP COLUMN `rejection_reason`;
+-- +goose StatementEnd
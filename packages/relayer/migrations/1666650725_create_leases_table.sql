@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- leases elect the one relayer instance sending transactions when several run for availability,
+-- the others indexing on standby until the lease expires.
+CREATE TABLE IF NOT EXISTS leases (
+    name VARCHAR(64) NOT NULL PRIMARY KEY,
+    holder VARCHAR(255) NOT NULL,
+    expires_at DATETIME(3) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE leases;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the gas each mined processMessage transaction cost, to work out how long the relayer's balance lasts
+CREATE TABLE IF NOT EXISTS gas_spends (
+    id int NOT NULL PRIMARY KEY AUTO_INCREMENT,
+    chain_id int NOT NULL,
+    msg_hash VARCHAR(255) NOT NULL,
+    tx_hash VARCHAR(66) NOT NULL,
+    gas_used BIGINT UNSIGNED NOT NULL,
+    gas_spent DECIMAL(65, 0) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY `chain_id_tx_hash_unique` (`chain_id`, `tx_hash`),
+    INDEX `chain_id_created_at_index` (`chain_id`, `created_at`)
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE gas_spends;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the bridge logs the indexer couldn't unpack, kept raw so they can be decoded again
+CREATE TABLE IF NOT EXISTS quarantined_logs (
+    id int NOT NULL PRIMARY KEY AUTO_INCREMENT,
+    chain_id int NOT NULL,
+    event_name VARCHAR(255) NOT NULL,
+    block_number BIGINT UNSIGNED NOT NULL,
+    block_hash VARCHAR(66) NOT NULL,
+    tx_hash VARCHAR(66) NOT NULL,
+    log_index int UNSIGNED NOT NULL,
+    topics TEXT NOT NULL,
+    data MEDIUMTEXT NOT NULL,
+    error TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY `chain_id_block_hash_log_index_unique` (`chain_id`, `block_hash`, `log_index`)
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE quarantined_logs;
+-- +goose StatementEnd
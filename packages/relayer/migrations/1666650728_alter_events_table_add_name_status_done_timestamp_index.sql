@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE `events` ADD INDEX `name_status_done_timestamp_index` (`name`, `status`, `done_timestamp`);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX name_status_done_timestamp_index on events;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the message's recipient, whichever contract or account it's sent to, so failures can be counted by it
+-- like message_recipient_failures_ops_total does. lower case, as addresses are in the data it's backfilled from.
+ALTER TABLE `events`
+    ADD COLUMN `message_to` VARCHAR(42) NOT NULL DEFAULT "",
+    ADD INDEX `name_status_message_to_index` (`name`, `status`, `message_to`);
+
+-- +goose StatementEnd
+-- +goose StatementBegin
+-- MessageSent events indexed before now have it in the message they were saved with
+UPDATE `events`
+    SET `message_to` = LOWER(JSON_UNQUOTE(JSON_EXTRACT(`data`, '$.Message.To')))
+    WHERE `name` = 'MessageSent' AND JSON_EXTRACT(`data`, '$.Message.To') IS NOT NULL;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE `events`
+    DROP INDEX `name_status_message_to_index`,
+    DROP COLUMN `message_to`;
+-- +goose StatementEnd
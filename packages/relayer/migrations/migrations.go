@@ -0,0 +1,206 @@
+// Package migrations embeds the relayer's versioned schema migrations, and applies them with goose.
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+
+	"github.com/pkg/errors"
+	"github.com/pressly/goose/v3"
+	log "github.com/sirupsen/logrus"
+)
+
+//go:embed *.sql
+var fsys embed.FS
+
+const (
+	// tableName records the applied migrations' versions
+	tableName = "schema_migrations"
+	// legacyTableName is where the goose binary recorded them, before migrations ran at startup
+	legacyTableName = "goose_db_version"
+
+	// lockName serializes relayers migrating the same database at once
+	lockName             = "relayer_schema_migrations"
+	lockTimeoutInSeconds = 60
+)
+
+var (
+	// ErrDatabaseAhead is returned when the database has had a migration this binary doesn't know,
+	// i.e. a newer relayer migrated it, and this one could misread the schema.
+	ErrDatabaseAhead = errors.New("database is ahead of the known migrations")
+	// ErrLockTimeout is returned when another relayer is still migrating the database.
+	ErrLockTimeout = errors.New("timed out waiting for the migration lock")
+)
+
+func init() {
+	goose.SetBaseFS(fsys)
+	goose.SetTableName(tableName)
+	goose.SetLogger(log.StandardLogger())
+
+	if err := goose.SetDialect("mysql"); err != nil {
+		panic(err)
+	}
+}
+
+// Up applies every migration the database hasn't had yet, in version order.
+func Up(ctx context.Context, db *sql.DB) error {
+	return withLock(ctx, db, func() error {
+		if err := goose.Up(db, "."); err != nil {
+			return errors.Wrap(err, "goose.Up")
+		}
+
+		return nil
+	})
+}
+
+// Down rolls back the latest applied migration.
+func Down(ctx context.Context, db *sql.DB) error {
+	return withLock(ctx, db, func() error {
+		if err := goose.Down(db, "."); err != nil {
+			return errors.Wrap(err, "goose.Down")
+		}
+
+		return nil
+	})
+}
+
+// LatestVersion is the version of the newest migration this binary knows.
+func LatestVersion() (int64, error) {
+	migrations, err := goose.CollectMigrations(".", 0, goose.MaxVersion)
+	if err != nil {
+		return 0, errors.Wrap(err, "goose.CollectMigrations")
+	}
+
+	latest, err := migrations.Last()
+	if err != nil {
+		return 0, errors.Wrap(err, "migrations.Last")
+	}
+
+	return latest.Version, nil
+}
+
+// Version is the latest migration applied to db, 0 if none have been. Unlike Up, it never changes
+// the database, so one only migrated by the goose binary, whose version table Up adopts, reads as 0.
+func Version(ctx context.Context, db *sql.DB) (int64, error) {
+	exists, err := tableExists(ctx, db, tableName)
+	if err != nil {
+		return 0, errors.Wrap(err, "tableExists")
+	}
+
+	if !exists {
+		return 0, nil
+	}
+
+	version, err := goose.GetDBVersion(db)
+	if err != nil {
+		return 0, errors.Wrap(err, "goose.GetDBVersion")
+	}
+
+	return version, nil
+}
+
+// withLock runs migrate holding a MySQL named lock, after adopting the legacy version table
+// and checking the database isn't ahead of the known migrations.
+func withLock(ctx context.Context, db *sql.DB, migrate func() error) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return errors.Wrap(err, "db.Conn")
+	}
+
+	defer conn.Close()
+
+	var locked sql.NullInt64
+
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", lockName, lockTimeoutInSeconds).
+		Scan(&locked); err != nil {
+		return errors.Wrap(err, "GET_LOCK")
+	}
+
+	if locked.Int64 != 1 {
+		return ErrLockTimeout
+	}
+
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), "SELECT RELEASE_LOCK(?)", lockName); err != nil {
+			log.Errorf("RELEASE_LOCK: %v", err)
+		}
+	}()
+
+	if err := adoptLegacyTable(ctx, conn); err != nil {
+		return errors.Wrap(err, "adoptLegacyTable")
+	}
+
+	dbVersion, err := goose.EnsureDBVersion(db)
+	if err != nil {
+		return errors.Wrap(err, "goose.EnsureDBVersion")
+	}
+
+	latest, err := LatestVersion()
+	if err != nil {
+		return errors.Wrap(err, "LatestVersion")
+	}
+
+	if err := checkNotAhead(dbVersion, latest); err != nil {
+		return err
+	}
+
+	return migrate()
+}
+
+// checkNotAhead returns ErrDatabaseAhead if dbVersion is newer than the latest known migration.
+func checkNotAhead(dbVersion int64, latest int64) error {
+	if dbVersion > latest {
+		return errors.Wrapf(
+			ErrDatabaseAhead,
+			"database is at version %v, the latest known migration is %v, run a newer relayer",
+			dbVersion,
+			latest,
+		)
+	}
+
+	return nil
+}
+
+// adoptLegacyTable renames the goose binary's version table to ours, so databases migrated with
+// it aren't migrated again from scratch.
+func adoptLegacyTable(ctx context.Context, conn *sql.Conn) error {
+	current, err := tableExists(ctx, conn, tableName)
+	if err != nil {
+		return errors.Wrap(err, "tableExists(tableName)")
+	}
+
+	legacy, err := tableExists(ctx, conn, legacyTableName)
+	if err != nil {
+		return errors.Wrap(err, "tableExists(legacyTableName)")
+	}
+
+	if current || !legacy {
+		return nil
+	}
+
+	log.Infof("adopting %v as %v", legacyTableName, tableName)
+
+	if _, err := conn.ExecContext(ctx, "RENAME TABLE "+legacyTableName+" TO "+tableName); err != nil {
+		return errors.Wrap(err, "RENAME TABLE")
+	}
+
+	return nil
+}
+
+// rowQuerier is a *sql.DB or *sql.Conn
+type rowQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+func tableExists(ctx context.Context, q rowQuerier, table string) (bool, error) {
+	var n int
+
+	err := q.QueryRowContext(
+		ctx,
+		"SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = ?",
+		table,
+	).Scan(&n)
+
+	return n > 0, err
+}
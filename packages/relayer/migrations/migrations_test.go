@@ -0,0 +1,66 @@
+package migrations
+
+import (
+	"io/fs"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_embeddedMigrations(t *testing.T) {
+	names, err := fs.Glob(fsys, "*.sql")
+	assert.Nil(t, err)
+	assert.NotEmpty(t, names)
+
+	for _, name := range names {
+		b, err := fs.ReadFile(fsys, name)
+		assert.Nil(t, err)
+
+		assert.Contains(t, string(b), "-- +goose Up", name)
+		assert.Contains(t, string(b), "-- +goose Down", name)
+	}
+
+	latest, err := LatestVersion()
+	assert.Nil(t, err)
+
+	want, err := strconv.ParseInt(strings.SplitN(names[len(names)-1], "_", 2)[0], 10, 64)
+	assert.Nil(t, err)
+	assert.Equal(t, want, latest)
+}
+
+func Test_checkNotAhead(t *testing.T) {
+	tests := []struct {
+		name      string
+		dbVersion int64
+		latest    int64
+		wantErr   error
+	}{
+		{
+			"behind",
+			1,
+			2,
+			nil,
+		},
+		{
+			"atLatest",
+			2,
+			2,
+			nil,
+		},
+		{
+			"ahead",
+			3,
+			2,
+			ErrDatabaseAhead,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkNotAhead(tt.dbVersion, tt.latest)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,394 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	// the errors a node rejects transactions with, which reach us as just their message over RPC
+	ErrNonceTooLow        = errors.New("nonce too low")
+	ErrReplaceUnderpriced = errors.New("replacement transaction underpriced")
+	ErrInvalidSender      = errors.New("invalid sender")
+
+	// BackendBridgeAddress is the destination bridge the Backend's processMessage transactions call
+	BackendBridgeAddress = common.HexToAddress("0x1000777700000000000000000000000000000004")
+)
+
+const (
+	// replacementBumpPercentage is how much a node requires a replacement's fees to be raised by
+	replacementBumpPercentage = 10
+
+	backendGasEstimate uint64 = 100000
+)
+
+// TxOutcome is what happens to a transaction sent to the Backend
+type TxOutcome struct {
+	// PendingPolls is how many times its receipt isn't found before it's mined
+	PendingPolls int
+	// NeverMined transactions stay pending, until one replacing them is mined
+	NeverMined bool
+	// RevertReason, if set, is what the transaction reverts with when it's mined
+	RevertReason string
+}
+
+// TxSucceeds is mined, and succeeds, the first time its receipt is asked for
+var TxSucceeds = TxOutcome{}
+
+// TxNeverMined stays pending
+var TxNeverMined = TxOutcome{NeverMined: true}
+
+// TxReverts is mined, but reverts with reason
+func TxReverts(reason string) TxOutcome {
+	return TxOutcome{RevertReason: reason}
+}
+
+// TxPendingFor is mined, and succeeds, once its receipt has been asked for polls times
+func TxPendingFor(polls int) TxOutcome {
+	return TxOutcome{PendingPolls: polls}
+}
+
+type backendTx struct {
+	tx      *types.Transaction
+	outcome TxOutcome
+	polls   int
+	receipt *types.Receipt
+}
+
+// Backend is a destination node for the processor's send and confirm path. processMessage
+// transactions sent through it, as the processor's TxBuilder or with SendTransaction, are
+// checked like a node would check them, recorded, and mined as scripted with Script. It
+// keeps the account's pending nonce, so the processor's nonce management can be tested.
+type Backend struct {
+	mu sync.Mutex
+
+	chainID *big.Int
+	// GasPrice and GasTipCap are the fees suggested, 100 wei if nil
+	GasPrice  *big.Int
+	GasTipCap *big.Int
+
+	head         uint64
+	pendingNonce uint64
+	// minedNonces are the nonces of the transactions mined, which can't be used again
+	minedNonces map[uint64]bool
+
+	outcomes []TxOutcome
+	sent     []*types.Transaction
+	txs      map[common.Hash]*backendTx
+
+	nonceResets int
+}
+
+// NewBackend is a Backend for chainID, at block 1 with nothing sent
+func NewBackend(chainID *big.Int) *Backend {
+	return &Backend{
+		chainID:     chainID,
+		head:        1,
+		minedNonces: make(map[uint64]bool),
+		txs:         make(map[common.Hash]*backendTx),
+	}
+}
+
+// Script sets what happens to the next transactions sent, in order. Transactions sent after
+// the scripted ones succeed.
+func (b *Backend) Script(outcomes ...TxOutcome) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.outcomes = append(b.outcomes, outcomes...)
+}
+
+// SetPendingNonce sets the account's pending nonce, as if transactions had been sent before
+func (b *Backend) SetPendingNonce(nonce uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.pendingNonce = nonce
+}
+
+// Sent are the transactions the Backend accepted, in the order they were sent
+func (b *Backend) Sent() []*types.Transaction {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return append([]*types.Transaction{}, b.sent...)
+}
+
+// Mine adds n empty blocks to the chain, confirming the transactions mined before them
+func (b *Backend) Mine(n uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.head += n
+}
+
+// Reorg replaces the block txHash was mined in with one without it. The transaction's nonce can be
+// used again, and the transaction itself is dropped, so it won't be mined again.
+func (b *Backend) Reorg(txHash common.Hash) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	t, ok := b.txs[txHash]
+	if !ok || t.receipt == nil {
+		return
+	}
+
+	delete(b.minedNonces, t.tx.Nonce())
+
+	t.receipt = nil
+	t.outcome = TxNeverMined
+}
+
+// ProcessMessage calls processMessage on BackendBridgeAddress, with the Backend as the transactor,
+// so the transaction is assembled, signed and sent the way the destination bridge binding does
+func (b *Backend) ProcessMessage(
+	opts *bind.TransactOpts,
+	message bridge.IBridgeMessage,
+	proof []byte,
+) (*types.Transaction, error) {
+	transactor, err := bridge.NewBridgeTransactor(BackendBridgeAddress, b)
+	if err != nil {
+		return nil, err
+	}
+
+	return transactor.ProcessMessage(opts, message, proof)
+}
+
+// ResetNonce counts the times the processor reset the TxBuilder's nonce, as calling the bridge
+// directly the Backend has none of its own.
+func (b *Backend) ResetNonce() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nonceResets++
+}
+
+// NonceResets is the number of times ResetNonce was called
+func (b *Backend) NonceResets() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.nonceResets
+}
+
+// SendTransaction accepts tx if it's signed for the Backend's chain, its nonce hasn't been mined,
+// and, replacing a pending transaction, it raises the fees by at least 10%.
+func (b *Backend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if _, err := types.Sender(types.LatestSignerForChainID(b.chainID), tx); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSender, err)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.minedNonces[tx.Nonce()] {
+		return ErrNonceTooLow
+	}
+
+	for _, pending := range b.txs {
+		if pending.receipt == nil && pending.tx.Nonce() == tx.Nonce() && !isBumped(pending.tx, tx) {
+			return ErrReplaceUnderpriced
+		}
+	}
+
+	outcome := TxSucceeds
+
+	if len(b.outcomes) > 0 {
+		outcome, b.outcomes = b.outcomes[0], b.outcomes[1:]
+	}
+
+	b.sent = append(b.sent, tx)
+	b.txs[tx.Hash()] = &backendTx{tx: tx, outcome: outcome}
+
+	if tx.Nonce() >= b.pendingNonce {
+		b.pendingNonce = tx.Nonce() + 1
+	}
+
+	return nil
+}
+
+// isBumped is whether replacement's fees are enough higher than tx's for a node to replace it
+func isBumped(tx *types.Transaction, replacement *types.Transaction) bool {
+	bumped := func(was *big.Int, is *big.Int) bool {
+		threshold := new(big.Int).Mul(was, big.NewInt(100+replacementBumpPercentage))
+
+		return new(big.Int).Mul(is, big.NewInt(100)).Cmp(threshold) >= 0
+	}
+
+	return bumped(tx.GasFeeCap(), replacement.GasFeeCap()) && bumped(tx.GasTipCap(), replacement.GasTipCap())
+}
+
+// TransactionReceipt mines the transaction, if it's pending and scripted to be mined by now
+func (b *Backend) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	t, ok := b.txs[txHash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+
+	if t.receipt != nil {
+		return t.receipt, nil
+	}
+
+	// replaced by a transaction that was mined
+	if b.minedNonces[t.tx.Nonce()] || t.outcome.NeverMined {
+		return nil, ethereum.NotFound
+	}
+
+	if t.polls < t.outcome.PendingPolls {
+		t.polls++
+		return nil, ethereum.NotFound
+	}
+
+	b.head++
+	b.minedNonces[t.tx.Nonce()] = true
+
+	status := types.ReceiptStatusSuccessful
+	if t.outcome.RevertReason != "" {
+		status = types.ReceiptStatusFailed
+	}
+
+	t.receipt = &types.Receipt{
+		Type:              t.tx.Type(),
+		Status:            status,
+		CumulativeGasUsed: t.tx.Gas(),
+		GasUsed:           t.tx.Gas(),
+		TxHash:            txHash,
+		BlockNumber:       new(big.Int).SetUint64(b.head),
+		BlockHash:         common.BigToHash(new(big.Int).SetUint64(b.head)),
+	}
+
+	return t.receipt, nil
+}
+
+// TransactionByHash is pending until the transaction's been mined
+func (b *Backend) TransactionByHash(
+	ctx context.Context,
+	hash common.Hash,
+) (tx *types.Transaction, isPending bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	t, ok := b.txs[hash]
+	if !ok {
+		return nil, false, ethereum.NotFound
+	}
+
+	return t.tx, t.receipt == nil, nil
+}
+
+// revertError is how a node reports a call reverting, with the ABI encoded reason as its data
+type revertError struct {
+	reason string
+}
+
+func (e *revertError) Error() string {
+	return "execution reverted: " + e.reason
+}
+
+func (e *revertError) ErrorCode() int {
+	return 3
+}
+
+func (e *revertError) ErrorData() interface{} {
+	// Error(string)
+	selector := []byte{0x08, 0xc3, 0x79, 0xa0}
+
+	stringT, _ := abi.NewType("string", "", nil)
+
+	data, _ := abi.Arguments{{Type: stringT}}.Pack(e.reason)
+
+	return hexutil.Encode(append(selector, data...))
+}
+
+// CallContract replaying a transaction that reverted, at the block it was mined in, reverts
+// with its reason. Other calls succeed, returning nothing.
+func (b *Backend) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, t := range b.txs {
+		if t.receipt != nil && t.receipt.BlockNumber.Cmp(blockNumber) == 0 && t.outcome.RevertReason != "" {
+			return nil, &revertError{reason: t.outcome.RevertReason}
+		}
+	}
+
+	return nil, nil
+}
+
+func (b *Backend) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	return []byte{0x1}, nil
+}
+
+func (b *Backend) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	return backendGasEstimate, nil
+}
+
+func (b *Backend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.pendingNonce, nil
+}
+
+func (b *Backend) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return Balance, nil
+}
+
+func (b *Backend) ChainID(ctx context.Context) (*big.Int, error) {
+	return b.chainID, nil
+}
+
+func (b *Backend) BlockNumber(ctx context.Context) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.head, nil
+}
+
+func (b *Backend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if number == nil {
+		head, _ := b.BlockNumber(ctx)
+		number = new(big.Int).SetUint64(head)
+	}
+
+	// london is active, so the bridge binding sends dynamic fee transactions
+	return &types.Header{
+		Number:  number,
+		Time:    number.Uint64() * BlockTime,
+		BaseFee: big.NewInt(1),
+	}, nil
+}
+
+func (b *Backend) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	return b.HeaderByNumber(ctx, hash.Big())
+}
+
+func (b *Backend) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	if b.GasPrice == nil {
+		return big.NewInt(100), nil
+	}
+
+	return b.GasPrice, nil
+}
+
+func (b *Backend) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	if b.GasTipCap == nil {
+		return big.NewInt(100), nil
+	}
+
+	return b.GasTipCap, nil
+}
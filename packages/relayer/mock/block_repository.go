@@ -3,6 +3,7 @@ package mock
 import (
 	"errors"
 	"math/big"
+	"sync"
 
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
 )
@@ -13,16 +14,38 @@ var (
 		Hash:    "0x",
 		ChainID: MockChainID.Int64(),
 	}
+	// NoCheckpointChainID has never had a block processed
+	NoCheckpointChainID = big.NewInt(167002)
 )
 
 type BlockRepository struct {
+	mu sync.Mutex
+	// saved are the blocks saved as processed
+	saved []relayer.SaveBlockOpts
 }
 
 func (r *BlockRepository) Save(opts relayer.SaveBlockOpts) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.saved = append(r.saved, opts)
+
 	return nil
 }
 
+// Saved are the blocks saved as processed, in order
+func (r *BlockRepository) Saved() []relayer.SaveBlockOpts {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.saved
+}
+
 func (r *BlockRepository) GetLatestBlockProcessedForEvent(eventName string, chainID *big.Int) (*relayer.Block, error) {
+	if chainID.Int64() == NoCheckpointChainID.Int64() {
+		return &relayer.Block{}, nil
+	}
+
 	if chainID.Int64() != MockChainID.Int64() {
 		return nil, errors.New("error getting latest block processed for event")
 	}
@@ -2,10 +2,10 @@ package mock
 
 import (
 	"context"
-	"errors"
 	"math/big"
 
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 )
@@ -36,7 +36,7 @@ type Blocker struct {
 
 func (b *Blocker) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
 	if hash == relayer.ZeroHash {
-		return nil, errors.New("cant find block")
+		return nil, ethereum.NotFound
 	}
 
 	return types.NewBlockWithHeader(Header), nil
@@ -0,0 +1,29 @@
+package mock
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Blocklist blocks Addresses, and counts how many times it's refreshed.
+type Blocklist struct {
+	Addresses   map[common.Address]bool
+	FailRefresh bool
+	Refreshes   int
+}
+
+func (b *Blocklist) Blocked(address common.Address) bool {
+	return b.Addresses[address]
+}
+
+func (b *Blocklist) RefreshBlocklist(ctx context.Context) (int, error) {
+	if b.FailRefresh {
+		return 0, errors.New("fail")
+	}
+
+	b.Refreshes++
+
+	return len(b.Addresses), nil
+}
@@ -1,12 +1,15 @@
 package mock
 
 import (
+	"context"
 	"errors"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -33,6 +36,25 @@ type Bridge struct {
 	MessagesSent           int
 	MessageStatusesChanged int
 	ErrorsSent             int
+	// Logs are the bridge's logs FilterLogs, FilterMessageSent and FilterMessageStatusChanged find,
+	// i.e. from MessageSentLog
+	Logs []types.Log
+
+	mu sync.Mutex
+	// messageStatuses override the status GetMessageStatus answers with
+	messageStatuses map[[32]byte]relayer.EventStatus
+}
+
+// SetMessageStatus makes GetMessageStatus answer status for msgHash
+func (b *Bridge) SetMessageStatus(msgHash [32]byte, status relayer.EventStatus) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.messageStatuses == nil {
+		b.messageStatuses = make(map[[32]byte]relayer.EventStatus)
+	}
+
+	b.messageStatuses[msgHash] = status
 }
 
 type Subscription struct {
@@ -46,6 +68,118 @@ func (s *Subscription) Err() <-chan error {
 
 func (s *Subscription) Unsubscribe() {}
 
+// emptyFilterer never finds any logs, so the iterators filtered through it are empty,
+// but safe to call Next on.
+type emptyFilterer struct{}
+
+func (f *emptyFilterer) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	return nil, nil
+}
+
+func (f *emptyFilterer) SubscribeFilterLogs(
+	ctx context.Context,
+	q ethereum.FilterQuery,
+	ch chan<- types.Log,
+) (ethereum.Subscription, error) {
+	return &Subscription{errChan: make(chan error)}, nil
+}
+
+// logsFilterer finds the logs in the queried block range with one of the queried event signatures
+type logsFilterer struct {
+	emptyFilterer
+	logs []types.Log
+}
+
+func (f *logsFilterer) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	logs := make([]types.Log, 0)
+
+	for _, l := range f.logs {
+		if q.FromBlock != nil && l.BlockNumber < q.FromBlock.Uint64() {
+			continue
+		}
+
+		if q.ToBlock != nil && l.BlockNumber > q.ToBlock.Uint64() {
+			continue
+		}
+
+		if len(q.Topics) > 0 && len(q.Topics[0]) > 0 && !hasTopic(q.Topics[0], l.Topics[0]) {
+			continue
+		}
+
+		logs = append(logs, l)
+	}
+
+	return logs, nil
+}
+
+func hasTopic(topics []common.Hash, topic common.Hash) bool {
+	for _, t := range topics {
+		if t == topic {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MessageSentLog is the log the bridge emits sending message, in the given block, tx and index
+func MessageSentLog(
+	msgHash [32]byte,
+	message bridge.IBridgeMessage,
+	blockNumber uint64,
+	txHash common.Hash,
+	index uint,
+) types.Log {
+	return bridgeLog("MessageSent", []common.Hash{msgHash}, blockNumber, txHash, index, message)
+}
+
+// MessageStatusChangedLog is the log the bridge emits changing msgHash's status, in the given block, tx and index
+func MessageStatusChangedLog(
+	msgHash [32]byte,
+	status relayer.EventStatus,
+	blockNumber uint64,
+	txHash common.Hash,
+	index uint,
+) types.Log {
+	return bridgeLog(
+		"MessageStatusChanged",
+		[]common.Hash{msgHash},
+		blockNumber,
+		txHash,
+		index,
+		uint8(status),
+		common.Address{},
+	)
+}
+
+func bridgeLog(
+	name string,
+	indexed []common.Hash,
+	blockNumber uint64,
+	txHash common.Hash,
+	index uint,
+	args ...interface{},
+) types.Log {
+	bridgeABI, err := bridge.BridgeMetaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+
+	data, err := bridgeABI.Events[name].Inputs.NonIndexed().Pack(args...)
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Log{
+		Topics:      append([]common.Hash{bridgeABI.Events[name].ID}, indexed...),
+		Data:        data,
+		BlockNumber: blockNumber,
+		BlockHash:   common.BigToHash(new(big.Int).SetUint64(blockNumber)),
+		TxHash:      txHash,
+		Index:       index,
+	}
+}
+
 func (b *Bridge) WatchMessageSent(
 	opts *bind.WatchOpts,
 	sink chan<- *bridge.BridgeMessageSent,
@@ -78,11 +212,21 @@ func (b *Bridge) WatchMessageSent(
 	return s, nil
 }
 
+// FilterLogs finds the bridge's Logs matching q, as eth_getLogs would
+func (b *Bridge) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	return (&logsFilterer{logs: b.Logs}).FilterLogs(ctx, q)
+}
+
 func (b *Bridge) FilterMessageSent(
 	opts *bind.FilterOpts,
 	signal [][32]byte,
 ) (*bridge.BridgeMessageSentIterator, error) {
-	return &bridge.BridgeMessageSentIterator{}, nil
+	filterer, err := bridge.NewBridgeFilterer(common.Address{}, &logsFilterer{logs: b.Logs})
+	if err != nil {
+		return nil, err
+	}
+
+	return filterer.FilterMessageSent(opts, signal)
 }
 
 func (b *Bridge) WatchMessageStatusChanged(
@@ -117,10 +261,23 @@ func (b *Bridge) FilterMessageStatusChanged(
 	opts *bind.FilterOpts,
 	signal [][32]byte,
 ) (*bridge.BridgeMessageStatusChangedIterator, error) {
-	return &bridge.BridgeMessageStatusChangedIterator{}, nil
+	filterer, err := bridge.NewBridgeFilterer(common.Address{}, &logsFilterer{logs: b.Logs})
+	if err != nil {
+		return nil, err
+	}
+
+	return filterer.FilterMessageStatusChanged(opts, signal)
 }
 
 func (b *Bridge) GetMessageStatus(opts *bind.CallOpts, msgHash [32]byte) (uint8, error) {
+	b.mu.Lock()
+	status, ok := b.messageStatuses[msgHash]
+	b.mu.Unlock()
+
+	if ok {
+		return uint8(status), nil
+	}
+
 	if msgHash == SuccessMsgHash {
 		return uint8(relayer.EventStatusNew), nil
 	}
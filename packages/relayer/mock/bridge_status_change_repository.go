@@ -0,0 +1,54 @@
+package mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+)
+
+type BridgeStatusChangeRepository struct {
+	changes []*relayer.BridgeStatusChange
+}
+
+func NewBridgeStatusChangeRepository() *BridgeStatusChangeRepository {
+	return &BridgeStatusChangeRepository{
+		changes: make([]*relayer.BridgeStatusChange, 0),
+	}
+}
+
+func (r *BridgeStatusChangeRepository) Save(ctx context.Context, opts relayer.SaveBridgeStatusChangeOpts) error {
+	for _, c := range r.changes {
+		if c.ChainID == opts.ChainID.Int64() && c.TxHash == opts.TxHash && c.LogIndex == opts.LogIndex {
+			return nil
+		}
+	}
+
+	r.changes = append(r.changes, &relayer.BridgeStatusChange{
+		ID:          len(r.changes) + 1,
+		MsgHash:     opts.MsgHash,
+		ChainID:     opts.ChainID.Int64(),
+		Status:      opts.Status,
+		BlockNumber: opts.BlockNumber,
+		TxHash:      opts.TxHash,
+		LogIndex:    opts.LogIndex,
+		CreatedAt:   time.Now(),
+	})
+
+	return nil
+}
+
+func (r *BridgeStatusChangeRepository) FindAllByMsgHash(
+	ctx context.Context,
+	msgHash string,
+) ([]*relayer.BridgeStatusChange, error) {
+	changes := make([]*relayer.BridgeStatusChange, 0)
+
+	for _, c := range r.changes {
+		if c.MsgHash == msgHash {
+			changes = append(changes, c)
+		}
+	}
+
+	return changes, nil
+}
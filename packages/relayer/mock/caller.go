@@ -1,21 +1,447 @@
 package mock
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
 )
 
+var (
+	NotSentSignal = [32]byte{0xff}
+
+	// FinalizedBlockNumber is the block the finalized and safe tags resolve to
+	FinalizedBlockNumber uint64 = 100
+	// HeadBlockNumber is the block eth_blockNumber reports
+	HeadBlockNumber uint64 = 200
+
+	getSignalSlotSelector   = crypto.Keccak256([]byte("getSignalSlot(address,bytes32)"))[:4]
+	getSignalSlotV2Selector = crypto.Keccak256([]byte("getSignalSlot(uint64,address,bytes32)"))[:4]
+	resolveSelector         = crypto.Keccak256([]byte("resolve(bytes32,bool)"))[:4]
+
+	eip1967ImplementationSlot = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+)
+
+// ProofRequest is an eth_getProof call the Caller answered
+type ProofRequest struct {
+	Address common.Address
+	Key     string
+}
+
 type Caller struct {
+	// WrongSignalSlot makes getSignalSlot answer as if the SignalService storage layout changed
+	WrongSignalSlot bool
+	// BlockTagUnsupported makes eth_getBlockByNumber reject the finalized and safe tags,
+	// like nodes that predate them do
+	BlockTagUnsupported bool
+	// V2SignalServices have the chain namespaced storage layout, their getSignalSlot takes a chain id
+	V2SignalServices []common.Address
+	// SignalServices is the SignalService each app resolves, apps not in it can't resolve one
+	SignalServices map[common.Address]common.Address
+	// StatePruned makes eth_getProof fail like a node that no longer has the block's state
+	StatePruned bool
+	// EmptyProof makes eth_getProof answer with an empty result, like some pruned nodes do
+	EmptyProof bool
+	// ProofResponse is what eth_getProof answers with when set, i.e. a response captured from a client
+	ProofResponse json.RawMessage
+	// StateRoot is the state root eth_getBlockByNumber answers with when set, otherwise it's the root
+	// of the state eth_getProof proves against, where every slot proven so far is set
+	StateRoot common.Hash
+	// TamperedProof makes eth_getProof answer with a storage proof and storageHash from other storage
+	// than the account proof resolves to, like a node lying about the SignalService's storage
+	TamperedProof bool
+	// Receipts are what eth_getTransactionReceipt answers with, by transaction hash, so a block can
+	// have several transactions, each sending several signals. Unknown transactions have no receipt.
+	Receipts map[common.Hash]*types.Receipt
+	// Implementations are the contracts behind EIP-1967 proxies, by proxy, which eth_getStorageAt
+	// answers the implementation slot with. Other slots are empty.
+	Implementations map[common.Address]common.Address
+
+	mu            sync.Mutex
+	proofRequests []ProofRequest
+	// provenSlots are the slots set in the state eth_getProof proves against, by account: those of
+	// the signals isSignalSent reported sent, and those proven
+	provenSlots map[common.Address]map[common.Hash]bool
+	// lastProven is the account eth_getProof last proved a slot of
+	lastProven common.Address
 }
 
+// SignalRoot is the storage root of the account eth_getProof last proved a slot of, what the
+// destination chain would have synced as the signal root of the block proven against
+func (c *Caller) SignalRoot() [32]byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.storage(c.lastProven).Hash()
+}
+
+// ProofRequests are the eth_getProof calls answered so far
+func (c *Caller) ProofRequests() []ProofRequest {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]ProofRequest{}, c.proofRequests...)
+}
+
+// rpcError is a JSON-RPC error response, as returned by the node rather than the transport
+type rpcError struct {
+	msg string
+}
+
+func (e *rpcError) Error() string { return e.msg }
+
+func (e *rpcError) ErrorCode() int { return -32602 }
+
 func (c *Caller) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
 	if method == "eth_getProof" {
-		b := hexutil.MustDecode("0x01")
-		return json.Unmarshal(json.RawMessage([]byte(fmt.Sprintf(`{"storageProof": [{"value": "%x"}]}`, b))), result)
+		c.recordProofRequest(args...)
+
+		if c.StatePruned {
+			return &rpcError{msg: "missing trie node 1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347 (path )"}
+		}
+
+		if c.EmptyProof {
+			return json.Unmarshal([]byte("null"), result)
+		}
+
+		if c.ProofResponse != nil {
+			return json.Unmarshal(c.ProofResponse, result)
+		}
+
+		return c.proof(result, args...)
+	}
+
+	if method == "eth_call" {
+		return c.ethCall(result, args...)
+	}
+
+	if method == "eth_getBlockByHash" {
+		return json.Unmarshal([]byte(fmt.Sprintf(`{"number": "%v"}`, hexutil.EncodeBig(Header.Number))), result)
+	}
+
+	if method == "eth_blockNumber" {
+		return json.Unmarshal([]byte(fmt.Sprintf(`"%v"`, hexutil.EncodeUint64(HeadBlockNumber))), result)
+	}
+
+	if method == "eth_getBlockByNumber" {
+		return c.blockByNumber(result, args...)
+	}
+
+	if method == "eth_getTransactionReceipt" {
+		return c.transactionReceipt(result, args...)
+	}
+
+	if method == "eth_getStorageAt" {
+		return c.storageAt(result, args...)
 	}
 
 	return nil
 }
+
+func (c *Caller) recordProofRequest(args ...interface{}) {
+	address, _ := args[0].(common.Address)
+
+	var key string
+
+	if keys, ok := args[1].([]string); ok && len(keys) > 0 {
+		key = keys[0]
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.proofRequests = append(c.proofRequests, ProofRequest{Address: address, Key: key})
+}
+
+func (c *Caller) isV2SignalService(address common.Address) bool {
+	for _, a := range c.V2SignalServices {
+		if a == address {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ethCall answers `getSignalSlot` calls with each layout's slot, `resolve` calls from
+// SignalServices, and `isSignalSent` calls, reporting every signal as sent except
+// NotSentSignal, which is the last 32 bytes of the calldata.
+func (c *Caller) ethCall(result interface{}, args ...interface{}) error {
+	msg, ok := args[0].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("unexpected eth_call arg %v", args[0])
+	}
+
+	data, ok := msg["data"].(hexutil.Bytes)
+	if !ok || len(data) < 32 {
+		return fmt.Errorf("unexpected eth_call data %v", msg["data"])
+	}
+
+	to, _ := msg["to"].(common.Address)
+
+	if bytes.Equal(data[:4], resolveSelector) {
+		signalService, ok := c.SignalServices[to]
+		if !ok {
+			return &rpcError{msg: "execution reverted: RESOLVER_ZERO_ADDR"}
+		}
+
+		encoded := hexutil.Encode(common.LeftPadBytes(signalService.Bytes(), 32))
+
+		return json.Unmarshal([]byte(fmt.Sprintf(`"%v"`, encoded)), result)
+	}
+
+	var slot []byte
+
+	switch {
+	case bytes.Equal(data[:4], getSignalSlotSelector) && len(data) == 68:
+		if c.isV2SignalService(to) {
+			return &rpcError{msg: "execution reverted"}
+		}
+
+		slot = crypto.Keccak256(data[16:36], data[36:68])
+	case bytes.Equal(data[:4], getSignalSlotV2Selector) && len(data) == 100:
+		if !c.isV2SignalService(to) {
+			return &rpcError{msg: "execution reverted"}
+		}
+
+		// abi.encodePacked("SIGNAL", uint64 chainId, app, signal)
+		slot = crypto.Keccak256([]byte("SIGNAL"), data[28:36], data[48:68], data[68:100])
+	}
+
+	if slot != nil {
+		if c.WrongSignalSlot {
+			slot = crypto.Keccak256(slot)
+		}
+
+		return json.Unmarshal([]byte(fmt.Sprintf(`"%v"`, hexutil.Encode(slot))), result)
+	}
+
+	sent := common.Big1
+
+	if common.BytesToHash(data[len(data)-32:]) == common.Hash(NotSentSignal) {
+		sent = common.Big0
+	}
+
+	// so the block's state root already covers it, however its proof and block are fetched
+	if sent.Sign() != 0 && len(data) == 68 {
+		c.setSignalSlot(to, data[16:36], data[36:68])
+	}
+
+	return json.Unmarshal([]byte(fmt.Sprintf(`"%v"`, hexutil.Encode(common.BigToHash(sent).Bytes()))), result)
+}
+
+// storageAt answers eth_getStorageAt with the implementation of the proxies in Implementations
+func (c *Caller) storageAt(result interface{}, args ...interface{}) error {
+	address, _ := args[0].(common.Address)
+	slot, _ := args[1].(common.Hash)
+
+	var value common.Hash
+
+	if implementation, ok := c.Implementations[address]; ok && slot == eip1967ImplementationSlot {
+		value = common.BytesToHash(implementation.Bytes())
+	}
+
+	return json.Unmarshal([]byte(fmt.Sprintf(`"%v"`, value.Hex())), result)
+}
+
+// transactionReceipt answers eth_getTransactionReceipt from Receipts, with null if it isn't in them.
+func (c *Caller) transactionReceipt(result interface{}, args ...interface{}) error {
+	txHash, ok := args[0].(common.Hash)
+	if !ok {
+		return fmt.Errorf("unexpected eth_getTransactionReceipt arg %v", args[0])
+	}
+
+	receipt, ok := c.Receipts[txHash]
+	if !ok {
+		return json.Unmarshal([]byte("null"), result)
+	}
+
+	b, err := json.Marshal(receipt)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, result)
+}
+
+// blockByNumber answers eth_getBlockByNumber with a block whose hash is derived from its number,
+// the finalized and safe tags resolve to FinalizedBlockNumber.
+func (c *Caller) blockByNumber(result interface{}, args ...interface{}) error {
+	number, ok := args[0].(string)
+	if !ok {
+		return fmt.Errorf("unexpected eth_getBlockByNumber arg %v", args[0])
+	}
+
+	if number == "finalized" || number == "safe" {
+		if c.BlockTagUnsupported {
+			return &rpcError{msg: fmt.Sprintf("invalid argument 0: hex string without 0x prefix: %v", number)}
+		}
+
+		number = hexutil.EncodeUint64(FinalizedBlockNumber)
+	}
+
+	stateRoot := c.StateRoot
+	if stateRoot == (common.Hash{}) {
+		c.mu.Lock()
+		stateRoot = c.state().Hash()
+		c.mu.Unlock()
+	}
+
+	return json.Unmarshal([]byte(fmt.Sprintf(
+		`{"number": "%v", "hash": "%v", "stateRoot": "%v"}`,
+		number,
+		crypto.Keccak256Hash([]byte(number)).Hex(),
+		stateRoot.Hex(),
+	)), result)
+}
+
+// proofNodes collects the nodes trie.Prove writes, in order from the root
+type proofNodes []hexutil.Bytes
+
+func (n *proofNodes) Put(key []byte, value []byte) error {
+	*n = append(*n, value)
+
+	return nil
+}
+
+func (n *proofNodes) Delete(key []byte) error {
+	return nil
+}
+
+// proof answers eth_getProof with a proof of the requested slot being set to 1, against a state
+// with every slot proven so far set, so eth_getBlockByNumber's state root verifies it.
+func (c *Caller) proof(result interface{}, args ...interface{}) error {
+	address, _ := args[0].(common.Address)
+
+	var slot *common.Hash
+
+	if keys, ok := args[1].([]string); ok && len(keys) > 0 {
+		s := common.HexToHash(keys[0])
+		slot = &s
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if slot != nil {
+		c.setSlot(address, *slot)
+		c.lastProven = address
+	}
+
+	storage := c.storage(address)
+	if c.TamperedProof {
+		storage = newTrie()
+		storageTrieUpdate(storage, common.Hash{0x7a, 0x3e})
+
+		if slot != nil {
+			storageTrieUpdate(storage, *slot)
+		}
+	}
+
+	var accountProof proofNodes
+	if err := c.state().Prove(crypto.Keccak256(address.Bytes()), 0, &accountProof); err != nil {
+		return err
+	}
+
+	storageProof := []map[string]interface{}{}
+
+	if slot != nil {
+		var nodes proofNodes
+		if err := storage.Prove(crypto.Keccak256(slot.Bytes()), 0, &nodes); err != nil {
+			return err
+		}
+
+		storageProof = append(storageProof, map[string]interface{}{
+			"key":   slot.Hex(),
+			"value": "0x1",
+			"proof": nodes,
+		})
+	}
+
+	b, err := json.Marshal(map[string]interface{}{
+		"address":      address,
+		"accountProof": accountProof,
+		"balance":      "0x0",
+		"codeHash":     types.EmptyCodeHash,
+		"nonce":        "0x0",
+		"storageHash":  storage.Hash(),
+		"storageProof": storageProof,
+	})
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, result)
+}
+
+// setSignalSlot sets the slots SignalServices at address store signal sent by app at: the
+// prover picks the layout, so both the v1 slot and the v2 slot on MockChainID are set.
+func (c *Caller) setSignalSlot(address common.Address, app []byte, signal []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.setSlot(address, crypto.Keccak256Hash(app, signal))
+	c.setSlot(address, crypto.Keccak256Hash([]byte("SIGNAL"), common.LeftPadBytes(MockChainID.Bytes(), 8), app, signal))
+}
+
+// setSlot sets slot in address's storage. c.mu must be held.
+func (c *Caller) setSlot(address common.Address, slot common.Hash) {
+	if c.provenSlots == nil {
+		c.provenSlots = make(map[common.Address]map[common.Hash]bool)
+	}
+
+	if c.provenSlots[address] == nil {
+		c.provenSlots[address] = make(map[common.Hash]bool)
+	}
+
+	c.provenSlots[address][slot] = true
+}
+
+// state is the state trie eth_getProof proves against, with an account for each address
+// a slot has been proven for. c.mu must be held.
+func (c *Caller) state() *trie.Trie {
+	state := newTrie()
+
+	for address := range c.provenSlots {
+		account, _ := rlp.EncodeToBytes(&types.StateAccount{
+			Balance:  common.Big0,
+			Root:     c.storage(address).Hash(),
+			CodeHash: types.EmptyCodeHash.Bytes(),
+		})
+
+		_ = state.TryUpdate(crypto.Keccak256(address.Bytes()), account)
+	}
+
+	return state
+}
+
+// storage is the storage trie of address, with every slot proven for it set to 1. c.mu must be held.
+func (c *Caller) storage(address common.Address) *trie.Trie {
+	storage := newTrie()
+
+	for slot := range c.provenSlots[address] {
+		storageTrieUpdate(storage, slot)
+	}
+
+	return storage
+}
+
+func newTrie() *trie.Trie {
+	return trie.NewEmpty(trie.NewDatabase(rawdb.NewMemoryDatabase()))
+}
+
+// storageTrieUpdate sets slot to 1 in storage
+func storageTrieUpdate(storage *trie.Trie, slot common.Hash) {
+	value, _ := rlp.EncodeToBytes([]byte{0x1})
+
+	_ = storage.TryUpdate(crypto.Keccak256(slot.Bytes()), value)
+}
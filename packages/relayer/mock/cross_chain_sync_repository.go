@@ -0,0 +1,87 @@
+package mock
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+)
+
+type CrossChainSyncRepository struct {
+	syncs []*relayer.CrossChainSync
+}
+
+func NewCrossChainSyncRepository() *CrossChainSyncRepository {
+	return &CrossChainSyncRepository{
+		syncs: make([]*relayer.CrossChainSync, 0),
+	}
+}
+
+func (r *CrossChainSyncRepository) Save(ctx context.Context, opts relayer.SaveCrossChainSyncOpts) error {
+	s := &relayer.CrossChainSync{
+		ChainID:    opts.ChainID.Int64(),
+		SrcHeight:  opts.SrcHeight,
+		BlockHash:  opts.BlockHash.Hex(),
+		SignalRoot: opts.SignalRoot.Hex(),
+		SyncedAt:   opts.SyncedAt,
+		DestTxHash: opts.DestTxHash.Hex(),
+	}
+
+	for i, existing := range r.syncs {
+		if existing.ChainID == s.ChainID && existing.SrcHeight == s.SrcHeight {
+			r.syncs[i] = s
+			return nil
+		}
+	}
+
+	r.syncs = append(r.syncs, s)
+
+	return nil
+}
+
+func (r *CrossChainSyncRepository) LatestSyncedHeight(ctx context.Context, chainID *big.Int) (uint64, error) {
+	var height uint64
+
+	for _, s := range r.syncs {
+		if s.ChainID == chainID.Int64() && s.SrcHeight > height {
+			height = s.SrcHeight
+		}
+	}
+
+	return height, nil
+}
+
+func (r *CrossChainSyncRepository) FindSyncByHeight(
+	ctx context.Context,
+	chainID *big.Int,
+	srcHeight uint64,
+) (*relayer.CrossChainSync, error) {
+	for _, s := range r.syncs {
+		if s.ChainID == chainID.Int64() && s.SrcHeight == srcHeight {
+			return s, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *CrossChainSyncRepository) FindLatestSyncBetween(
+	ctx context.Context,
+	chainID *big.Int,
+	fromHeight uint64,
+	toHeight uint64,
+) (*relayer.CrossChainSync, error) {
+	var latest *relayer.CrossChainSync
+
+	for _, s := range r.syncs {
+		if s.ChainID != chainID.Int64() || s.SrcHeight < fromHeight || s.SrcHeight > toHeight {
+			continue
+		}
+
+		if latest == nil || s.SrcHeight > latest.SrcHeight {
+			latest = s
+		}
+	}
+
+	return latest, nil
+}
@@ -19,7 +19,17 @@ var (
 	SucceedTxHash            = common.HexToHash("0x456")
 	FailTxHash               = common.HexToHash("0x789")
 	BlockNum                 = 10
+	BlockTime         uint64 = 12
 	PendingNonce      uint64 = 10
+
+	// RevertedTx is mined with a failed receipt, and NeverMinedTx never gets a receipt,
+	// so its replacement is the one that gets mined.
+	RevertedTx   = types.NewTransaction(1, common.Address{}, big.NewInt(0), 100, big.NewInt(10), nil)
+	NeverMinedTx = types.NewTransaction(2, common.Address{}, big.NewInt(0), 100, big.NewInt(100), nil)
+	RevertReason = "execution reverted: B:notReceived"
+	Balance      = big.NewInt(1000000000000000000)
+
+	DeploymentBlockNumber = big.NewInt(4)
 )
 
 type EthClient struct {
@@ -44,6 +54,7 @@ func (c *EthClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types
 
 	return &types.Header{
 		Number: number,
+		Time:   number.Uint64() * BlockTime,
 	}, nil
 }
 
@@ -52,14 +63,15 @@ func (c *EthClient) PendingNonceAt(ctx context.Context, account common.Address)
 }
 
 func (c *EthClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
-	if txHash == NotFoundTxHash {
+	if txHash == NotFoundTxHash || txHash == NeverMinedTx.Hash() {
 		return nil, ethereum.NotFound
 	}
 
-	if txHash == FailTxHash {
+	if txHash == FailTxHash || txHash == RevertedTx.Hash() {
 		return &types.Receipt{
 			Status:      types.ReceiptStatusFailed,
 			BlockNumber: big.NewInt(1),
+			TxHash:      txHash,
 		}, nil
 	}
 
@@ -69,6 +81,39 @@ func (c *EthClient) TransactionReceipt(ctx context.Context, txHash common.Hash)
 	}, nil
 }
 
+// TransactionByHash reports NeverMinedTx as pending, and doesn't know any other transaction
+func (c *EthClient) TransactionByHash(
+	ctx context.Context,
+	hash common.Hash,
+) (tx *types.Transaction, isPending bool, err error) {
+	if hash == NeverMinedTx.Hash() {
+		return NeverMinedTx, true, nil
+	}
+
+	return nil, false, ethereum.NotFound
+}
+
+func (c *EthClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	return nil
+}
+
+func (c *EthClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return nil, errors.New(RevertReason)
+}
+
+// CodeAt only has code from DeploymentBlockNumber onwards
+func (c *EthClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return Balance, nil
+}
+
+func (c *EthClient) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	if blockNumber != nil && blockNumber.Cmp(DeploymentBlockNumber) < 0 {
+		return []byte{}, nil
+	}
+
+	return []byte{0x1}, nil
+}
+
 func (c *EthClient) BlockNumber(ctx context.Context) (uint64, error) {
 	return uint64(BlockNum), nil
 }
@@ -0,0 +1,27 @@
+package mock
+
+import (
+	"sync"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+)
+
+type EventPublisher struct {
+	mu            sync.Mutex
+	Events        []relayer.SaveEventOpts
+	StatusChanges []relayer.MessageStatusChange
+}
+
+func (p *EventPublisher) PublishEvent(opts relayer.SaveEventOpts) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.Events = append(p.Events, opts)
+}
+
+func (p *EventPublisher) PublishStatusChange(change relayer.MessageStatusChange) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.StatusChanges = append(p.StatusChanges, change)
+}
@@ -3,16 +3,23 @@ package mock
 import (
 	"context"
 	"encoding/json"
+	"math/big"
 	"math/rand"
 	"net/http"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/morkid/paginate"
 	"gorm.io/datatypes"
 )
 
 type EventRepository struct {
 	events []*relayer.Event
+	// createdAt is when each event was saved, by ID
+	createdAt map[int]time.Time
 }
 
 func NewEventRepository() *EventRepository {
@@ -21,8 +28,12 @@ func NewEventRepository() *EventRepository {
 	}
 }
 func (r *EventRepository) Save(ctx context.Context, opts relayer.SaveEventOpts) (*relayer.Event, error) {
-	r.events = append(r.events, &relayer.Event{
-		ID:           rand.Int(), // nolint: gosec
+	id := rand.Int() // nolint: gosec
+
+	r.SetCreatedAt(id, time.Now())
+
+	e := &relayer.Event{
+		ID:           id,
 		Data:         datatypes.JSON(opts.Data),
 		Status:       opts.Status,
 		ChainID:      opts.ChainID.Int64(),
@@ -30,11 +41,99 @@ func (r *EventRepository) Save(ctx context.Context, opts relayer.SaveEventOpts)
 		MessageOwner: opts.MessageOwner,
 		MsgHash:      opts.MsgHash,
 		EventType:    opts.EventType,
-	})
+		Event:        opts.Event,
+
+		CanonicalTokenAddress:  opts.CanonicalTokenAddress,
+		CanonicalTokenSymbol:   opts.CanonicalTokenSymbol,
+		CanonicalTokenDecimals: opts.CanonicalTokenDecimals,
+		Amount:                 opts.Amount,
+
+		MessageCallTo:       opts.MessageCallTo,
+		MessageCallSelector: opts.MessageCallSelector,
+
+		DuplicateOfEventID: opts.DuplicateOfEventID,
+
+		BlockNumber:          opts.BlockNumber,
+		MessageTo:            strings.ToLower(opts.MessageTo),
+		MessageSentTimestamp: opts.MessageSentTimestamp,
+	}
+
+	if opts.TxHash != "" {
+		e.TxHash, e.LogIndex = &opts.TxHash, &opts.LogIndex
+	}
+
+	if opts.DestChainID != nil {
+		e.MessageSender, e.DestChainID = strings.ToLower(opts.MessageSender), opts.DestChainID.Int64()
+	}
+
+	r.events = append(r.events, e)
 
 	return nil, nil
 }
 
+func (r *EventRepository) SaveBatch(
+	ctx context.Context,
+	opts []relayer.SaveEventOpts,
+	batchSize int,
+) ([]*relayer.Event, error) {
+	saved := make([]*relayer.Event, 0, len(opts))
+
+	for _, o := range opts {
+		e := r.findByLog(o)
+
+		if e == nil {
+			id := rand.Int() // nolint: gosec
+
+			r.SetCreatedAt(id, time.Now())
+
+			txHash, logIndex := o.TxHash, o.LogIndex
+
+			e = &relayer.Event{
+				ID:           id,
+				Data:         datatypes.JSON(o.Data),
+				Status:       o.Status,
+				ChainID:      o.ChainID.Int64(),
+				Name:         o.Name,
+				MessageOwner: o.MessageOwner,
+				MsgHash:      o.MsgHash,
+				EventType:    o.EventType,
+				Event:        o.Event,
+
+				MessageCallTo:       o.MessageCallTo,
+				MessageCallSelector: o.MessageCallSelector,
+
+				DuplicateOfEventID:   o.DuplicateOfEventID,
+				BlockNumber:          o.BlockNumber,
+				TxHash:               &txHash,
+				LogIndex:             &logIndex,
+				MessageTo:            strings.ToLower(o.MessageTo),
+				MessageSentTimestamp: o.MessageSentTimestamp,
+			}
+
+			if o.DestChainID != nil {
+				e.MessageSender, e.DestChainID = strings.ToLower(o.MessageSender), o.DestChainID.Int64()
+			}
+
+			r.events = append(r.events, e)
+		}
+
+		saved = append(saved, e)
+	}
+
+	return saved, nil
+}
+
+func (r *EventRepository) findByLog(opts relayer.SaveEventOpts) *relayer.Event {
+	for _, e := range r.events {
+		if e.ChainID == opts.ChainID.Int64() && e.Name == opts.Name &&
+			e.TxHash != nil && *e.TxHash == opts.TxHash && e.LogIndex != nil && *e.LogIndex == opts.LogIndex {
+			return e
+		}
+	}
+
+	return nil
+}
+
 func (r *EventRepository) UpdateStatus(ctx context.Context, id int, status relayer.EventStatus) error {
 	var event *relayer.Event
 
@@ -60,6 +159,41 @@ func (r *EventRepository) UpdateStatus(ctx context.Context, id int, status relay
 	return nil
 }
 
+func (r *EventRepository) UpdateStatusIf(
+	ctx context.Context,
+	id int,
+	from relayer.EventStatus,
+	status relayer.EventStatus,
+) (bool, error) {
+	for _, e := range r.events {
+		if e.ID == id && e.Status == from {
+			e.Status = status
+
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (r *EventRepository) UpdateTimeToDone(
+	ctx context.Context,
+	id int,
+	messageSentTimestamp uint64,
+	doneTimestamp uint64,
+	timeToDoneInSeconds uint64,
+) error {
+	for _, e := range r.events {
+		if e.ID == id {
+			e.MessageSentTimestamp = messageSentTimestamp
+			e.DoneTimestamp = doneTimestamp
+			e.TimeToDoneInSeconds = timeToDoneInSeconds
+		}
+	}
+
+	return nil
+}
+
 func (r *EventRepository) FindAllByAddress(
 	ctx context.Context,
 	req *http.Request,
@@ -120,6 +254,335 @@ func (r *EventRepository) FirstByEventAndMsgHash(
 	return nil, nil
 }
 
+// FindAllForExport ignores From and To, since the mock doesn't track when events were saved
+func (r *EventRepository) FindAllForExport(
+	ctx context.Context,
+	opts relayer.FindAllForExportOpts,
+) ([]*relayer.ExportedEvent, error) {
+	events := make([]*relayer.ExportedEvent, 0)
+
+	for _, e := range r.events {
+		if e.ID <= opts.AfterID {
+			continue
+		}
+
+		if opts.ChainID != nil && e.ChainID != opts.ChainID.Int64() {
+			continue
+		}
+
+		if opts.Status != nil && e.Status != *opts.Status {
+			continue
+		}
+
+		if opts.Name != "" && e.Name != opts.Name {
+			continue
+		}
+
+		if opts.MessageOwner != "" && e.MessageOwner != opts.MessageOwner {
+			continue
+		}
+
+		events = append(events, &relayer.ExportedEvent{Event: *e})
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].ID < events[j].ID
+	})
+
+	if opts.Limit > 0 && len(events) > opts.Limit {
+		events = events[:opts.Limit]
+	}
+
+	return events, nil
+}
+
+func (r *EventRepository) FindAllByStatus(
+	ctx context.Context,
+	chainID *big.Int,
+	status relayer.EventStatus,
+) ([]*relayer.Event, error) {
+	events := make([]*relayer.Event, 0)
+
+	for _, e := range r.events {
+		if e.ChainID == chainID.Int64() && e.Status == status {
+			events = append(events, e)
+		}
+	}
+
+	return events, nil
+}
+
+func (r *EventRepository) FindAllByBlockNumber(
+	ctx context.Context,
+	chainID *big.Int,
+	blockNumber uint64,
+) ([]*relayer.Event, error) {
+	events := make([]*relayer.Event, 0)
+
+	for _, e := range r.events {
+		if e.ChainID == chainID.Int64() && e.BlockNumber == blockNumber {
+			events = append(events, e)
+		}
+	}
+
+	return events, nil
+}
+
+func (r *EventRepository) FindAllMessageSentFromBlock(
+	ctx context.Context,
+	chainID *big.Int,
+	fromBlock uint64,
+) ([]*relayer.Event, error) {
+	events := make([]*relayer.Event, 0)
+
+	for _, e := range r.events {
+		if e.ChainID == chainID.Int64() && e.Name == relayer.EventNameMessageSent && e.BlockNumber >= fromBlock {
+			events = append(events, e)
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].BlockNumber < events[j].BlockNumber
+	})
+
+	return events, nil
+}
+
+func (r *EventRepository) FindProcessable(
+	ctx context.Context,
+	chainID *big.Int,
+	syncedHeight uint64,
+	now time.Time,
+	limit int,
+) ([]*relayer.Event, error) {
+	events := make([]*relayer.Event, 0)
+
+	for _, e := range r.events {
+		if e.ChainID != chainID.Int64() || e.Name != relayer.EventNameMessageSent || e.BlockNumber > syncedHeight {
+			continue
+		}
+
+		if e.Status != relayer.EventStatusNew && e.Status != relayer.EventStatusRetriable {
+			continue
+		}
+
+		if e.NextRetryAt != nil && !e.NextRetryAt.After(now) {
+			events = append(events, e)
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].NextRetryAt.Equal(*events[j].NextRetryAt) {
+			return events[i].NextRetryAt.Before(*events[j].NextRetryAt)
+		}
+
+		return events[i].ID < events[j].ID
+	})
+
+	if len(events) > limit {
+		events = events[:limit]
+	}
+
+	return events, nil
+}
+
+func (r *EventRepository) MarkStale(
+	ctx context.Context,
+	chainID *big.Int,
+	sentBefore time.Time,
+	exceptSenders []common.Address,
+) (int64, error) {
+	var marked int64
+
+	except := make(map[string]bool)
+	for _, sender := range exceptSenders {
+		except[strings.ToLower(sender.Hex())] = true
+	}
+
+	for _, e := range r.events {
+		if e.ChainID != chainID.Int64() || e.Name != relayer.EventNameMessageSent || e.Status != relayer.EventStatusNew {
+			continue
+		}
+
+		if e.ProcessingTxHash != "" || except[e.MessageSender] {
+			continue
+		}
+
+		sentAt := r.createdAt[e.ID]
+		if e.MessageSentTimestamp > 0 {
+			sentAt = time.Unix(int64(e.MessageSentTimestamp), 0)
+		}
+
+		if sentAt.Before(sentBefore) {
+			e.Status = relayer.EventStatusStale
+			marked++
+		}
+	}
+
+	return marked, nil
+}
+
+// SetCreatedAt sets when the event with id was saved
+func (r *EventRepository) SetCreatedAt(id int, at time.Time) {
+	if r.createdAt == nil {
+		r.createdAt = make(map[int]time.Time)
+	}
+
+	r.createdAt[id] = at
+}
+
+func (r *EventRepository) FindTopFailingRecipients(
+	ctx context.Context,
+	limit int,
+) ([]*relayer.FailingRecipient, error) {
+	failures := make(map[string]int)
+
+	for _, e := range r.events {
+		if e.Name != relayer.EventNameMessageSent || e.MessageTo == "" {
+			continue
+		}
+
+		if e.Status != relayer.EventStatusRetriable && e.Status != relayer.EventStatusFailed {
+			continue
+		}
+
+		failures[common.HexToAddress(e.MessageTo).Hex()]++
+	}
+
+	recipients := make([]*relayer.FailingRecipient, 0, len(failures))
+	for recipient, n := range failures {
+		recipients = append(recipients, &relayer.FailingRecipient{Recipient: recipient, Failures: n})
+	}
+
+	sort.Slice(recipients, func(i, j int) bool {
+		if recipients[i].Failures != recipients[j].Failures {
+			return recipients[i].Failures > recipients[j].Failures
+		}
+
+		return recipients[i].Recipient < recipients[j].Recipient
+	})
+
+	if len(recipients) > limit {
+		recipients = recipients[:limit]
+	}
+
+	return recipients, nil
+}
+
+func (r *EventRepository) MarkPendingSent(ctx context.Context, id int, txHash common.Hash) error {
+	for _, e := range r.events {
+		if e.ID == id && isProcessing(e.Status) {
+			e.Status = relayer.EventStatusPendingSent
+			e.ProcessingTxHash = txHash.Hex()
+		}
+	}
+
+	return nil
+}
+
+func (r *EventRepository) MarkProcessedUnconfirmed(ctx context.Context, id int, txHash common.Hash) error {
+	for _, e := range r.events {
+		if e.ID == id && e.Status == relayer.EventStatusPendingSent {
+			e.Status = relayer.EventStatusProcessedUnconfirmed
+			e.ProcessingTxHash = txHash.Hex()
+		}
+	}
+
+	return nil
+}
+
+func isProcessing(status relayer.EventStatus) bool {
+	for _, s := range relayer.ProcessingStatuses {
+		if s == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *EventRepository) UpdateProcessingError(
+	ctx context.Context,
+	id int,
+	processingError string,
+	rejectionReason relayer.RejectionReason,
+) error {
+	for _, e := range r.events {
+		if e.ID == id {
+			e.ProcessingError = processingError
+			e.RejectionReason = rejectionReason
+		}
+	}
+
+	return nil
+}
+
+func (r *EventRepository) UpdateNextRetry(
+	ctx context.Context,
+	id int,
+	reason relayer.RetryReason,
+	nextRetryAt *time.Time,
+) error {
+	for _, e := range r.events {
+		if e.ID == id {
+			e.RetryReason = reason
+			e.NextRetryAt = nextRetryAt
+		}
+	}
+
+	return nil
+}
+
+func (r *EventRepository) RetryWaitingOnSync(ctx context.Context, chainID *big.Int, now time.Time) (int64, error) {
+	var due int64
+
+	for _, e := range r.events {
+		if e.ChainID != chainID.Int64() || e.Name != relayer.EventNameMessageSent || e.Status != relayer.EventStatusNew {
+			continue
+		}
+
+		if e.RetryReason == relayer.RetryReasonNotSynced && e.NextRetryAt == nil {
+			at := now
+			e.NextRetryAt = &at
+			due++
+		}
+	}
+
+	return due, nil
+}
+
+func (r *EventRepository) UpdateProcessingBlock(ctx context.Context, id int, blockNumber uint64) error {
+	for _, e := range r.events {
+		if e.ID == id {
+			n := blockNumber
+			e.ProcessingBlockNumber = &n
+		}
+	}
+
+	return nil
+}
+
+func (r *EventRepository) FindDoneProcessedBetween(
+	ctx context.Context,
+	chainID *big.Int,
+	fromBlock uint64,
+	toBlock uint64,
+) ([]*relayer.Event, error) {
+	events := make([]*relayer.Event, 0)
+
+	for _, e := range r.events {
+		if e.ChainID != chainID.Int64() || e.Status != relayer.EventStatusDone || e.ProcessingBlockNumber == nil {
+			continue
+		}
+
+		if *e.ProcessingBlockNumber >= fromBlock && *e.ProcessingBlockNumber <= toBlock {
+			events = append(events, e)
+		}
+	}
+
+	return events, nil
+}
+
 func (r *EventRepository) Delete(
 	ctx context.Context,
 	id int,
@@ -132,3 +595,100 @@ func (r *EventRepository) Delete(
 
 	return nil
 }
+
+func (r *EventRepository) LatestMessageSent(ctx context.Context, chainID *big.Int) (*relayer.Event, error) {
+	var latest *relayer.Event
+
+	for _, e := range r.events {
+		if e.ChainID != chainID.Int64() || e.Event != relayer.EventNameMessageSent {
+			continue
+		}
+
+		if latest == nil || e.BlockNumber >= latest.BlockNumber {
+			latest = e
+		}
+	}
+
+	return latest, nil
+}
+
+func (r *EventRepository) LatestBlockNumber(ctx context.Context, chainID *big.Int) (uint64, error) {
+	var blockNumber uint64
+
+	for _, e := range r.events {
+		if e.ChainID == chainID.Int64() && e.BlockNumber > blockNumber {
+			blockNumber = e.BlockNumber
+		}
+	}
+
+	return blockNumber, nil
+}
+
+func (r *EventRepository) Stats(ctx context.Context, since time.Time) (*relayer.EventStats, error) {
+	type tokenKey struct {
+		chainID  int64
+		address  string
+		symbol   string
+		decimals uint8
+	}
+
+	stats := &relayer.EventStats{ValueBridged: make([]*relayer.TokenValue, 0)}
+
+	var totalTimeToDone uint64
+
+	amounts := make(map[tokenKey]*big.Int)
+
+	for _, e := range r.events {
+		if e.Name != relayer.EventNameMessageSent {
+			continue
+		}
+
+		for _, status := range relayer.BacklogStatuses {
+			if e.Status == status {
+				stats.Backlog++
+			}
+		}
+
+		if e.Status != relayer.EventStatusDone || e.DoneTimestamp < uint64(since.Unix()) {
+			continue
+		}
+
+		stats.MessagesRelayed++
+		totalTimeToDone += e.TimeToDoneInSeconds
+
+		key := tokenKey{e.ChainID, e.CanonicalTokenAddress, e.CanonicalTokenSymbol, e.CanonicalTokenDecimals}
+		if amounts[key] == nil {
+			amounts[key] = new(big.Int)
+		}
+
+		amount, _ := new(big.Int).SetString(e.Amount, 10)
+		if amount != nil {
+			amounts[key].Add(amounts[key], amount)
+		}
+	}
+
+	if stats.MessagesRelayed > 0 {
+		average := float64(totalTimeToDone) / float64(stats.MessagesRelayed)
+		stats.AverageTimeToDoneInSeconds = &average
+	}
+
+	for key, amount := range amounts {
+		stats.ValueBridged = append(stats.ValueBridged, &relayer.TokenValue{
+			ChainID:                key.chainID,
+			CanonicalTokenAddress:  key.address,
+			CanonicalTokenSymbol:   key.symbol,
+			CanonicalTokenDecimals: key.decimals,
+			Amount:                 amount.String(),
+		})
+	}
+
+	sort.Slice(stats.ValueBridged, func(i, j int) bool {
+		if stats.ValueBridged[i].ChainID != stats.ValueBridged[j].ChainID {
+			return stats.ValueBridged[i].ChainID < stats.ValueBridged[j].ChainID
+		}
+
+		return stats.ValueBridged[i].CanonicalTokenAddress < stats.ValueBridged[j].CanonicalTokenAddress
+	})
+
+	return stats, nil
+}
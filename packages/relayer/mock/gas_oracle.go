@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"math/big"
+)
+
+var GasOraclePrice = big.NewInt(1000)
+
+type GasOracle struct {
+	Fail bool
+}
+
+func (o *GasOracle) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	if o.Fail {
+		return nil, errors.New("fail")
+	}
+
+	return GasOraclePrice, nil
+}
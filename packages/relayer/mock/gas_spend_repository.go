@@ -0,0 +1,84 @@
+package mock
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+)
+
+type GasSpendRepository struct {
+	mu     sync.Mutex
+	spends []*relayer.GasSpend
+	// Err is returned by every call when set
+	Err error
+}
+
+func NewGasSpendRepository() *GasSpendRepository {
+	return &GasSpendRepository{
+		spends: make([]*relayer.GasSpend, 0),
+	}
+}
+
+func (r *GasSpendRepository) Save(ctx context.Context, opts relayer.SaveGasSpendOpts) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.Err != nil {
+		return r.Err
+	}
+
+	for _, s := range r.spends {
+		if s.ChainID == opts.ChainID.Int64() && s.TxHash == opts.TxHash {
+			return nil
+		}
+	}
+
+	r.spends = append(r.spends, &relayer.GasSpend{
+		ID:        len(r.spends) + 1,
+		ChainID:   opts.ChainID.Int64(),
+		MsgHash:   opts.MsgHash,
+		TxHash:    opts.TxHash,
+		GasUsed:   opts.GasUsed,
+		GasSpent:  opts.GasSpent.String(),
+		CreatedAt: time.Now(),
+	})
+
+	return nil
+}
+
+func (r *GasSpendRepository) SpentWithin(
+	ctx context.Context,
+	chainID *big.Int,
+	window time.Duration,
+) (*big.Int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.Err != nil {
+		return nil, r.Err
+	}
+
+	spent := new(big.Int)
+
+	for _, s := range r.spends {
+		if s.ChainID != chainID.Int64() || time.Since(s.CreatedAt) > window {
+			continue
+		}
+
+		v, _ := new(big.Int).SetString(s.GasSpent, 10)
+		spent.Add(spent, v)
+	}
+
+	return spent, nil
+}
+
+// Spends are the gas spends saved, in the order they were
+func (r *GasSpendRepository) Spends() []*relayer.GasSpend {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]*relayer.GasSpend{}, r.spends...)
+}
@@ -3,14 +3,22 @@ package mock
 import (
 	"errors"
 	"math/big"
+	"time"
 
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/icrosschainsync"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
 )
 
 var SuccessHeader = [32]byte{0x1}
 
 type HeaderSyncer struct {
 	Fail bool
+	// Caller, when set, is where the signal root synced for every block comes from, the storage
+	// root its eth_getProof last proved against, so its proofs match. SignalRoot is synced otherwise.
+	Caller     *Caller
+	SignalRoot [32]byte
 }
 
 func (h *HeaderSyncer) GetCrossChainBlockHash(opts *bind.CallOpts, number *big.Int) ([32]byte, error) {
@@ -20,3 +28,42 @@ func (h *HeaderSyncer) GetCrossChainBlockHash(opts *bind.CallOpts, number *big.I
 
 	return SuccessHeader, nil
 }
+
+func (h *HeaderSyncer) GetCrossChainSignalRoot(opts *bind.CallOpts, number *big.Int) ([32]byte, error) {
+	if h.Fail {
+		return [32]byte{}, errors.New("fail")
+	}
+
+	if h.Caller != nil {
+		return h.Caller.SignalRoot(), nil
+	}
+
+	return h.SignalRoot, nil
+}
+
+var SyncedSrcHeight uint64 = 5
+
+func (h *HeaderSyncer) WatchCrossChainSynced(
+	opts *bind.WatchOpts,
+	sink chan<- *icrosschainsync.ICrossChainSyncCrossChainSynced,
+	srcHeight []*big.Int,
+) (event.Subscription, error) {
+	s := &Subscription{
+		errChan: make(chan error),
+	}
+
+	go func(sink chan<- *icrosschainsync.ICrossChainSyncCrossChainSynced) {
+		<-time.After(2 * time.Second)
+
+		sink <- &icrosschainsync.ICrossChainSyncCrossChainSynced{
+			SrcHeight:  new(big.Int).SetUint64(SyncedSrcHeight),
+			BlockHash:  SuccessHeader,
+			SignalRoot: SuccessHeader,
+			Raw: types.Log{
+				BlockNumber: uint64(BlockNum),
+			},
+		}
+	}(sink)
+
+	return s, nil
+}
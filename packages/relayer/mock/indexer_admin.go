@@ -0,0 +1,55 @@
+package mock
+
+import (
+	"context"
+	"errors"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+)
+
+type IndexerAdmin struct {
+	ChainID     int64
+	Paused      bool
+	Reprocessed []*relayer.Event
+	Fail        bool
+	Progress    relayer.SyncProgress
+}
+
+func (i *IndexerAdmin) IndexerStatus(ctx context.Context) (*relayer.IndexerStatus, error) {
+	if i.Fail {
+		return nil, errors.New("fail")
+	}
+
+	return &relayer.IndexerStatus{
+		ChainID:              i.ChainID,
+		LatestProcessedBlock: LatestBlock,
+		HeadBlock:            LatestBlockNumber.Uint64(),
+		ProcessingPaused:     i.Paused,
+	}, nil
+}
+
+func (i *IndexerAdmin) ReprocessMessage(ctx context.Context, e *relayer.Event) error {
+	if e.Status != relayer.EventStatusNew {
+		return relayer.ErrMessageNotReprocessable
+	}
+
+	i.Reprocessed = append(i.Reprocessed, e)
+
+	return nil
+}
+
+func (i *IndexerAdmin) PauseProcessing() {
+	i.Paused = true
+}
+
+func (i *IndexerAdmin) ResumeProcessing() {
+	i.Paused = false
+}
+
+func (i *IndexerAdmin) ProcessingPaused() bool {
+	return i.Paused
+}
+
+func (i *IndexerAdmin) SyncProgress() relayer.SyncProgress {
+	return i.Progress
+}
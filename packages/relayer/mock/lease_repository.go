@@ -0,0 +1,63 @@
+package mock
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+)
+
+type LeaseRepository struct {
+	mu     sync.Mutex
+	leases map[string]*relayer.Lease
+	// Err is returned by TryAcquire when set, as if the database were unreachable
+	Err error
+}
+
+func NewLeaseRepository() *LeaseRepository {
+	return &LeaseRepository{
+		leases: make(map[string]*relayer.Lease),
+	}
+}
+
+func (r *LeaseRepository) TryAcquire(ctx context.Context, name string, holder string, ttl time.Duration) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.Err != nil {
+		return false, r.Err
+	}
+
+	l, ok := r.leases[name]
+	if ok && l.Holder != holder && time.Now().Before(l.ExpiresAt) {
+		return false, nil
+	}
+
+	r.leases[name] = &relayer.Lease{Name: name, Holder: holder, ExpiresAt: time.Now().Add(ttl)}
+
+	return true, nil
+}
+
+func (r *LeaseRepository) Release(ctx context.Context, name string, holder string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if l, ok := r.leases[name]; ok && l.Holder == holder {
+		l.ExpiresAt = time.Now()
+	}
+
+	return nil
+}
+
+// Holder returns who holds the lease called name, or "" if nobody does
+func (r *LeaseRepository) Holder(name string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if l, ok := r.leases[name]; ok && time.Now().Before(l.ExpiresAt) {
+		return l.Holder
+	}
+
+	return ""
+}
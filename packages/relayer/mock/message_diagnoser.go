@@ -0,0 +1,24 @@
+package mock
+
+import (
+	"context"
+	"errors"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+)
+
+type MessageDiagnoser struct {
+	Fail bool
+}
+
+func (d *MessageDiagnoser) DiagnoseMessage(ctx context.Context, e *relayer.Event) (*relayer.MessageDiagnosis, error) {
+	if d.Fail {
+		return nil, errors.New("fail")
+	}
+
+	return &relayer.MessageDiagnosis{
+		MsgHash:   e.MsgHash,
+		Status:    e.Status.String(),
+		Diagnosis: "no problem found, the message is waiting to be processed",
+	}, nil
+}
@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+)
+
+type MessageReleaser struct {
+	Released []*relayer.Event
+}
+
+func (r *MessageReleaser) ReleaseMessage(ctx context.Context, e *relayer.Event) error {
+	if e.Status != relayer.EventStatusHeld {
+		return relayer.ErrMessageNotHeld
+	}
+
+	r.Released = append(r.Released, e)
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"math/big"
+)
+
+// PriceOracle values tokens at Prices, by symbol
+type PriceOracle struct {
+	Prices map[string]*big.Rat
+	Fail   bool
+}
+
+func (o *PriceOracle) PriceInETH(ctx context.Context, token string) (*big.Rat, error) {
+	if o.Fail {
+		return nil, errors.New("fail")
+	}
+
+	price, ok := o.Prices[token]
+	if !ok {
+		return nil, errors.New("no price")
+	}
+
+	return price, nil
+}
@@ -0,0 +1,38 @@
+package mock
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// PrivateTxRelay records the transactions sent to it, which are dropped if they aren't mined
+// within Blocks blocks
+type PrivateTxRelay struct {
+	Blocks uint64
+
+	mu   sync.Mutex
+	sent []*types.Transaction
+}
+
+func (r *PrivateTxRelay) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sent = append(r.sent, tx)
+
+	return nil
+}
+
+func (r *PrivateTxRelay) MaxBlocks() uint64 {
+	return r.Blocks
+}
+
+// Sent returns the transactions sent so far
+func (r *PrivateTxRelay) Sent() []*types.Transaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]*types.Transaction(nil), r.sent...)
+}
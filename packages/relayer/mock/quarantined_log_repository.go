@@ -0,0 +1,61 @@
+package mock
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+)
+
+type QuarantinedLogRepository struct {
+	mu   sync.Mutex
+	logs []*relayer.QuarantinedLog
+	// Err is returned by every call when set
+	Err error
+}
+
+func NewQuarantinedLogRepository() *QuarantinedLogRepository {
+	return &QuarantinedLogRepository{
+		logs: make([]*relayer.QuarantinedLog, 0),
+	}
+}
+
+func (r *QuarantinedLogRepository) Save(ctx context.Context, opts relayer.SaveQuarantinedLogOpts) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.Err != nil {
+		return r.Err
+	}
+
+	for _, l := range r.logs {
+		if l.ChainID == opts.ChainID.Int64() && l.BlockHash == opts.BlockHash && l.LogIndex == opts.LogIndex {
+			return nil
+		}
+	}
+
+	r.logs = append(r.logs, &relayer.QuarantinedLog{
+		ID:          len(r.logs) + 1,
+		ChainID:     opts.ChainID.Int64(),
+		EventName:   opts.EventName,
+		BlockNumber: opts.BlockNumber,
+		BlockHash:   opts.BlockHash,
+		TxHash:      opts.TxHash,
+		LogIndex:    opts.LogIndex,
+		Topics:      opts.Topics,
+		Data:        opts.Data,
+		Error:       opts.Error,
+		CreatedAt:   time.Now(),
+	})
+
+	return nil
+}
+
+// Logs are the logs quarantined, in the order they were
+func (r *QuarantinedLogRepository) Logs() []*relayer.QuarantinedLog {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]*relayer.QuarantinedLog{}, r.logs...)
+}
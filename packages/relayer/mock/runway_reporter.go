@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+)
+
+// RunwayReporter reports Result, or fails with Err when it's set
+type RunwayReporter struct {
+	Result *relayer.Runway
+	Err    error
+}
+
+func (r *RunwayReporter) Runway(ctx context.Context) (*relayer.Runway, error) {
+	if r.Err != nil {
+		return nil, r.Err
+	}
+
+	return r.Result, nil
+}
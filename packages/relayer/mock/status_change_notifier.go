@@ -0,0 +1,29 @@
+package mock
+
+import (
+	"context"
+	"sync"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+)
+
+type StatusChangeNotifier struct {
+	mu      sync.Mutex
+	Changes []relayer.MessageStatusChange
+}
+
+func (n *StatusChangeNotifier) Notify(ctx context.Context, change relayer.MessageStatusChange) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.Changes = append(n.Changes, change)
+
+	return nil
+}
+
+func (n *StatusChangeNotifier) Len() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return len(n.Changes)
+}
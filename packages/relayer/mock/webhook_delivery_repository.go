@@ -0,0 +1,33 @@
+package mock
+
+import (
+	"context"
+	"sync"
+)
+
+type WebhookDeliveryRepository struct {
+	mu        sync.Mutex
+	delivered map[string]bool
+}
+
+func NewWebhookDeliveryRepository() *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{
+		delivered: make(map[string]bool),
+	}
+}
+
+func (r *WebhookDeliveryRepository) IsDelivered(ctx context.Context, idempotencyKey string, url string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.delivered[idempotencyKey+" "+url], nil
+}
+
+func (r *WebhookDeliveryRepository) SaveDelivered(ctx context.Context, idempotencyKey string, url string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.delivered[idempotencyKey+" "+url] = true
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type WebhookSubscriptionRepository struct {
+	subs []*relayer.WebhookSubscription
+}
+
+func NewWebhookSubscriptionRepository() *WebhookSubscriptionRepository {
+	return &WebhookSubscriptionRepository{
+		subs: make([]*relayer.WebhookSubscription, 0),
+	}
+}
+
+func (r *WebhookSubscriptionRepository) Save(
+	ctx context.Context,
+	opts relayer.SaveWebhookSubscriptionOpts,
+) (*relayer.WebhookSubscription, error) {
+	s := &relayer.WebhookSubscription{
+		ID:           len(r.subs) + 1,
+		MessageOwner: opts.MessageOwner.Hex(),
+		URL:          opts.URL,
+		Statuses:     relayer.JoinEventStatuses(opts.Statuses),
+	}
+
+	r.subs = append(r.subs, s)
+
+	return s, nil
+}
+
+func (r *WebhookSubscriptionRepository) FindAllByMessageOwner(
+	ctx context.Context,
+	messageOwner string,
+) ([]*relayer.WebhookSubscription, error) {
+	subs := make([]*relayer.WebhookSubscription, 0)
+
+	for _, s := range r.subs {
+		if s.MessageOwner == common.HexToAddress(messageOwner).Hex() {
+			subs = append(subs, s)
+		}
+	}
+
+	return subs, nil
+}
@@ -0,0 +1,13 @@
+package relayer
+
+import (
+	"context"
+	"math/big"
+)
+
+// PriceOracle values tokens in ETH, so amounts paid in different tokens can be compared
+type PriceOracle interface {
+	// PriceInETH is how much ETH one of token, by its symbol, is worth. Amounts of both are taken
+	// to have 18 decimals, as native tokens do, so it also converts amounts in their smallest units.
+	PriceInETH(ctx context.Context, token string) (*big.Rat, error)
+}
@@ -0,0 +1,59 @@
+package priceoracle
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+)
+
+type cachedPrice struct {
+	price     *big.Rat
+	fetchedAt time.Time
+}
+
+// Cached keeps the prices its source answers with for ttl, so a burst of messages doesn't read
+// the same price feed for each one. Errors aren't cached.
+type Cached struct {
+	source relayer.PriceOracle
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu     sync.Mutex
+	prices map[string]cachedPrice
+}
+
+func NewCached(source relayer.PriceOracle, ttl time.Duration) *Cached {
+	return &Cached{
+		source: source,
+		ttl:    ttl,
+		now:    time.Now,
+		prices: make(map[string]cachedPrice),
+	}
+}
+
+func (o *Cached) PriceInETH(ctx context.Context, token string) (*big.Rat, error) {
+	token = strings.ToUpper(token)
+
+	o.mu.Lock()
+	cached, ok := o.prices[token]
+	o.mu.Unlock()
+
+	if ok && o.now().Sub(cached.fetchedAt) < o.ttl {
+		return new(big.Rat).Set(cached.price), nil
+	}
+
+	price, err := o.source.PriceInETH(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	o.mu.Lock()
+	o.prices[token] = cachedPrice{price: price, fetchedAt: o.now()}
+	o.mu.Unlock()
+
+	return new(big.Rat).Set(price), nil
+}
@@ -0,0 +1,60 @@
+package priceoracle
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countingOracle values every token at price, counting the lookups
+type countingOracle struct {
+	price   *big.Rat
+	fail    bool
+	lookups int
+}
+
+func (o *countingOracle) PriceInETH(ctx context.Context, token string) (*big.Rat, error) {
+	o.lookups++
+
+	if o.fail {
+		return nil, errors.New("fail")
+	}
+
+	return o.price, nil
+}
+
+func Test_Cached(t *testing.T) {
+	source := &countingOracle{price: big.NewRat(1, 2)}
+
+	now := time.Unix(1000, 0)
+
+	o := NewCached(source, time.Minute)
+	o.now = func() time.Time { return now }
+
+	for _, token := range []string{"MXC", "mxc"} {
+		price, err := o.PriceInETH(context.Background(), token)
+		assert.Nil(t, err)
+		assert.Equal(t, big.NewRat(1, 2), price)
+	}
+
+	assert.Equal(t, 1, source.lookups)
+
+	// expired, and the source's failure isn't cached
+	now = now.Add(time.Minute)
+	source.fail = true
+
+	_, err := o.PriceInETH(context.Background(), "MXC")
+	assert.NotNil(t, err)
+
+	source.fail = false
+	source.price = big.NewRat(1, 3)
+
+	price, err := o.PriceInETH(context.Background(), "MXC")
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewRat(1, 3), price)
+	assert.Equal(t, 3, source.lookups)
+}
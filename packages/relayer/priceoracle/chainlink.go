@@ -0,0 +1,119 @@
+package priceoracle
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// nolint: lll
+const aggregatorABIJSON = `[{"inputs":[],"name":"decimals","outputs":[{"internalType":"uint8","name":"","type":"uint8"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"latestRoundData","outputs":[{"internalType":"uint80","name":"roundId","type":"uint80"},{"internalType":"int256","name":"answer","type":"int256"},{"internalType":"uint256","name":"startedAt","type":"uint256"},{"internalType":"uint256","name":"updatedAt","type":"uint256"},{"internalType":"uint80","name":"answeredInRound","type":"uint80"}],"stateMutability":"view","type":"function"}]`
+
+var aggregatorABI = mustParseABI(aggregatorABIJSON)
+
+func mustParseABI(s string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(s))
+	if err != nil {
+		panic(err)
+	}
+
+	return parsed
+}
+
+// Chainlink values tokens with the latest answer of their Chainlink price feed, an
+// AggregatorV3Interface quoting the token in ETH, i.e. MXC / ETH.
+type Chainlink struct {
+	client bind.ContractCaller
+	// feeds are the price feeds' addresses, by upper cased symbol
+	feeds map[string]common.Address
+	// decimals caches each feed's decimals, which never change
+	decimals sync.Map
+}
+
+// NewChainlink reads the price feed in feeds for each token, by its symbol, case insensitively,
+// with client
+func NewChainlink(client bind.ContractCaller, feeds map[string]common.Address) (*Chainlink, error) {
+	if client == nil {
+		return nil, ErrNoClient
+	}
+
+	o := &Chainlink{client: client, feeds: make(map[string]common.Address, len(feeds))}
+
+	for token, feed := range feeds {
+		o.feeds[strings.ToUpper(token)] = feed
+	}
+
+	return o, nil
+}
+
+func (o *Chainlink) PriceInETH(ctx context.Context, token string) (*big.Rat, error) {
+	feed, ok := o.feeds[strings.ToUpper(token)]
+	if !ok {
+		return nil, errors.Wrap(ErrNoPrice, token)
+	}
+
+	contract := bind.NewBoundContract(feed, aggregatorABI, o.client, nil, nil)
+
+	decimals, err := o.feedDecimals(ctx, feed, contract)
+	if err != nil {
+		return nil, errors.Wrapf(err, "o.feedDecimals(%v)", token)
+	}
+
+	var out []interface{}
+	if err := contract.Call(&bind.CallOpts{Context: ctx}, &out, "latestRoundData"); err != nil {
+		return nil, errors.Wrapf(err, "contract.Call(latestRoundData, %v)", token)
+	}
+
+	answer := abi.ConvertType(out[1], new(big.Int)).(*big.Int)
+	if answer.Sign() <= 0 {
+		return nil, errors.Wrapf(ErrInvalidPrice, "%v feed answered %v", token, answer)
+	}
+
+	return new(big.Rat).SetFrac(answer, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)), nil
+}
+
+func (o *Chainlink) feedDecimals(ctx context.Context, feed common.Address, contract *bind.BoundContract) (uint8, error) {
+	if decimals, ok := o.decimals.Load(feed); ok {
+		return decimals.(uint8), nil
+	}
+
+	var out []interface{}
+	if err := contract.Call(&bind.CallOpts{Context: ctx}, &out, "decimals"); err != nil {
+		return 0, errors.Wrap(err, "contract.Call(decimals)")
+	}
+
+	decimals := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	o.decimals.Store(feed, decimals)
+
+	return decimals, nil
+}
+
+// ParseFeeds parses a comma separated list of SYMBOL=address pairs, i.e. "MXC=0x1234...".
+func ParseFeeds(s string) (map[string]common.Address, error) {
+	feeds := make(map[string]common.Address)
+
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		token, address, ok := strings.Cut(pair, "=")
+		address = strings.TrimSpace(address)
+
+		if !ok || strings.TrimSpace(token) == "" || !common.IsHexAddress(address) {
+			return nil, errors.Wrap(ErrInvalidFeeds, pair)
+		}
+
+		feeds[strings.TrimSpace(token)] = common.HexToAddress(address)
+	}
+
+	return feeds, nil
+}
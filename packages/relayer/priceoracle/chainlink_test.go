@@ -0,0 +1,127 @@
+package priceoracle
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+var mxcFeed = common.HexToAddress("0x00000000000000000000000000000000000000fe")
+
+// feedCaller answers AggregatorV3Interface calls to mxcFeed with answer and decimals
+type feedCaller struct {
+	answer        *big.Int
+	decimals      uint8
+	fail          bool
+	decimalsCalls int
+}
+
+func (c *feedCaller) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{0x1}, nil
+}
+
+func (c *feedCaller) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if c.fail {
+		return nil, errors.New("fail")
+	}
+
+	if *call.To != mxcFeed {
+		return nil, errors.New("not a feed")
+	}
+
+	if bytes.Equal(call.Data[:4], aggregatorABI.Methods["decimals"].ID) {
+		c.decimalsCalls++
+
+		return aggregatorABI.Methods["decimals"].Outputs.Pack(c.decimals)
+	}
+
+	return aggregatorABI.Methods["latestRoundData"].Outputs.Pack(
+		big.NewInt(1),
+		c.answer,
+		big.NewInt(0),
+		big.NewInt(0),
+		big.NewInt(1),
+	)
+}
+
+func Test_NewChainlink_noClient(t *testing.T) {
+	_, err := NewChainlink(nil, map[string]common.Address{"MXC": mxcFeed})
+	assert.Equal(t, ErrNoClient, err)
+}
+
+func Test_Chainlink(t *testing.T) {
+	tests := []struct {
+		name    string
+		caller  *feedCaller
+		token   string
+		want    *big.Rat
+		wantErr error
+	}{
+		{
+			"price",
+			&feedCaller{answer: big.NewInt(400000), decimals: 8},
+			"mxc",
+			big.NewRat(1, 250),
+			nil,
+		},
+		{
+			"noFeed",
+			&feedCaller{answer: big.NewInt(400000), decimals: 8},
+			"USDC",
+			nil,
+			ErrNoPrice,
+		},
+		{
+			"negativeAnswer",
+			&feedCaller{answer: big.NewInt(-1), decimals: 8},
+			"MXC",
+			nil,
+			ErrInvalidPrice,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o, err := NewChainlink(tt.caller, map[string]common.Address{"MXC": mxcFeed})
+			assert.Nil(t, err)
+
+			got, err := o.PriceInETH(context.Background(), tt.token)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_Chainlink_decimalsCached(t *testing.T) {
+	caller := &feedCaller{answer: big.NewInt(4), decimals: 4}
+
+	o, err := NewChainlink(caller, map[string]common.Address{"MXC": mxcFeed})
+	assert.Nil(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, err := o.PriceInETH(context.Background(), "MXC")
+		assert.Nil(t, err)
+	}
+
+	assert.Equal(t, 1, caller.decimalsCalls)
+
+	caller.fail = true
+
+	_, err = o.PriceInETH(context.Background(), "MXC")
+	assert.NotNil(t, err)
+}
+
+func Test_ParseFeeds(t *testing.T) {
+	feeds, err := ParseFeeds("MXC=" + mxcFeed.Hex() + ", ")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]common.Address{"MXC": mxcFeed}, feeds)
+
+	_, err = ParseFeeds("MXC=0x1234")
+	assert.ErrorIs(t, err, ErrInvalidFeeds)
+}
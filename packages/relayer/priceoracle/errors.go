@@ -0,0 +1,16 @@
+package priceoracle
+
+import "github.com/pkg/errors"
+
+var (
+	// ErrNoPrice is returned for a token the oracle has no price for.
+	ErrNoPrice = errors.New("no price for token")
+	// ErrInvalidPrice is returned for a static price, or a feed's answer, that isn't positive.
+	ErrInvalidPrice = errors.New("price must be positive")
+	// ErrInvalidStaticPrices is returned by ParseStaticPrices for an entry that isn't SYMBOL=price.
+	ErrInvalidStaticPrices = errors.New("static prices must be SYMBOL=price pairs")
+	// ErrInvalidFeeds is returned by ParseFeeds for an entry that isn't SYMBOL=address.
+	ErrInvalidFeeds = errors.New("feeds must be SYMBOL=address pairs")
+	// ErrNoClient is returned for feeds without a client to read them with.
+	ErrNoClient = errors.New("feeds need a client")
+)
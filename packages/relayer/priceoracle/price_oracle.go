@@ -0,0 +1,83 @@
+package priceoracle
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// ETH is always worth one ETH, without being configured
+const ETH = "ETH"
+
+type NewOpts struct {
+	// StaticPrices are fixed prices in ETH, by token symbol
+	StaticPrices map[string]*big.Rat
+	// Feeds are Chainlink price feeds quoting tokens in ETH, by token symbol
+	Feeds map[string]common.Address
+	// Client is the chain the feeds are on
+	Client bind.ContractCaller
+	// CacheTTL is how long prices are kept before they're read again, not at all if 0
+	CacheTTL time.Duration
+}
+
+// New builds the PriceOracle for opts, or nil if it has no prices or feeds, so callers keep
+// comparing amounts as is. A token with a feed is valued with it, and one without with its
+// static price.
+func New(opts NewOpts) (relayer.PriceOracle, error) {
+	if len(opts.StaticPrices) == 0 && len(opts.Feeds) == 0 {
+		return nil, nil
+	}
+
+	static := map[string]*big.Rat{ETH: big.NewRat(1, 1)}
+	for token, price := range opts.StaticPrices {
+		static[token] = price
+	}
+
+	staticOracle, err := NewStatic(static)
+	if err != nil {
+		return nil, err
+	}
+
+	sources := []relayer.PriceOracle{staticOracle}
+
+	if len(opts.Feeds) > 0 {
+		chainlink, err := NewChainlink(opts.Client, opts.Feeds)
+		if err != nil {
+			return nil, err
+		}
+
+		sources = []relayer.PriceOracle{chainlink, staticOracle}
+	}
+
+	var oracle relayer.PriceOracle = &First{sources: sources}
+
+	if opts.CacheTTL > 0 {
+		oracle = NewCached(oracle, opts.CacheTTL)
+	}
+
+	return oracle, nil
+}
+
+// First values a token with the first of its sources with a price for it. A source that has
+// one but fails to read it fails the lookup, rather than falling back to a different price.
+type First struct {
+	sources []relayer.PriceOracle
+}
+
+func (o *First) PriceInETH(ctx context.Context, token string) (*big.Rat, error) {
+	for _, source := range o.sources {
+		price, err := source.PriceInETH(ctx, token)
+		if errors.Is(err, ErrNoPrice) {
+			continue
+		}
+
+		return price, err
+	}
+
+	return nil, errors.Wrap(ErrNoPrice, token)
+}
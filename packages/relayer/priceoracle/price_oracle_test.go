@@ -0,0 +1,52 @@
+package priceoracle
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_New_unset(t *testing.T) {
+	o, err := New(NewOpts{})
+	assert.Nil(t, err)
+	assert.Nil(t, o)
+}
+
+func Test_New_feedsWithoutClient(t *testing.T) {
+	_, err := New(NewOpts{Feeds: map[string]common.Address{"MXC": mxcFeed}})
+	assert.Equal(t, ErrNoClient, err)
+}
+
+func Test_New(t *testing.T) {
+	o, err := New(NewOpts{
+		StaticPrices: map[string]*big.Rat{"MXC": big.NewRat(1, 10), "USDC": big.NewRat(1, 1800)},
+		Feeds:        map[string]common.Address{"MXC": mxcFeed},
+		Client:       &feedCaller{answer: big.NewInt(4), decimals: 3},
+		CacheTTL:     time.Minute,
+	})
+	assert.Nil(t, err)
+
+	tests := []struct {
+		token   string
+		want    *big.Rat
+		wantErr error
+	}{
+		// the feed takes precedence over the static price
+		{"MXC", big.NewRat(1, 250), nil},
+		{"USDC", big.NewRat(1, 1800), nil},
+		{"eth", big.NewRat(1, 1), nil},
+		{"DAI", nil, ErrNoPrice},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			got, err := o.PriceInETH(context.Background(), tt.token)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
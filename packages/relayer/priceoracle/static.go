@@ -0,0 +1,66 @@
+package priceoracle
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Static values tokens at prices set in config, i.e. for a token without a price feed, or one
+// pegged to ETH.
+type Static struct {
+	prices map[string]*big.Rat
+}
+
+// NewStatic values each token in prices, by its symbol, case insensitively
+func NewStatic(prices map[string]*big.Rat) (*Static, error) {
+	o := &Static{prices: make(map[string]*big.Rat, len(prices))}
+
+	for token, price := range prices {
+		if price == nil || price.Sign() <= 0 {
+			return nil, errors.Wrap(ErrInvalidPrice, token)
+		}
+
+		o.prices[strings.ToUpper(token)] = new(big.Rat).Set(price)
+	}
+
+	return o, nil
+}
+
+func (o *Static) PriceInETH(ctx context.Context, token string) (*big.Rat, error) {
+	price, ok := o.prices[strings.ToUpper(token)]
+	if !ok {
+		return nil, errors.Wrap(ErrNoPrice, token)
+	}
+
+	return new(big.Rat).Set(price), nil
+}
+
+// ParseStaticPrices parses a comma separated list of SYMBOL=price pairs, with prices in ETH
+// as decimals or fractions, i.e. "MXC=0.0004,USDC=1/1800".
+func ParseStaticPrices(s string) (map[string]*big.Rat, error) {
+	prices := make(map[string]*big.Rat)
+
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		token, value, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(token) == "" {
+			return nil, errors.Wrap(ErrInvalidStaticPrices, pair)
+		}
+
+		price, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+		if !ok {
+			return nil, errors.Wrap(ErrInvalidStaticPrices, pair)
+		}
+
+		prices[strings.TrimSpace(token)] = price
+	}
+
+	return prices, nil
+}
@@ -0,0 +1,68 @@
+package priceoracle
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewStatic_invalidPrice(t *testing.T) {
+	_, err := NewStatic(map[string]*big.Rat{"MXC": big.NewRat(0, 1)})
+	assert.ErrorIs(t, err, ErrInvalidPrice)
+}
+
+func Test_Static(t *testing.T) {
+	o, err := NewStatic(map[string]*big.Rat{"mxc": big.NewRat(1, 2500)})
+	assert.Nil(t, err)
+
+	price, err := o.PriceInETH(context.Background(), "MXC")
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewRat(1, 2500), price)
+
+	_, err = o.PriceInETH(context.Background(), "USDC")
+	assert.ErrorIs(t, err, ErrNoPrice)
+}
+
+func Test_ParseStaticPrices(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    map[string]*big.Rat
+		wantErr error
+	}{
+		{
+			"empty",
+			"",
+			map[string]*big.Rat{},
+			nil,
+		},
+		{
+			"decimalsAndFractions",
+			" MXC=0.0004, USDC=1/1800,",
+			map[string]*big.Rat{"MXC": big.NewRat(1, 2500), "USDC": big.NewRat(1, 1800)},
+			nil,
+		},
+		{
+			"noPrice",
+			"MXC",
+			nil,
+			ErrInvalidStaticPrices,
+		},
+		{
+			"notANumber",
+			"MXC=cheap",
+			nil,
+			ErrInvalidStaticPrices,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseStaticPrices(tt.s)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package relayer
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// PrivateTxRelay sends signed transactions to block builders privately, instead of through the
+// public mempool where they can be front-run. They aren't seen by public nodes until they're
+// mined, and are dropped if they aren't within MaxBlocks blocks of being sent.
+type PrivateTxRelay interface {
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+	MaxBlocks() uint64
+}
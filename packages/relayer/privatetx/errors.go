@@ -0,0 +1,12 @@
+package privatetx
+
+import "github.com/pkg/errors"
+
+var (
+	// ErrNoURL is returned by New without the relay's URL.
+	ErrNoURL = errors.New("relay url is required")
+	// ErrNoBackend is returned by New without a destination chain client.
+	ErrNoBackend = errors.New("backend is required")
+	// ErrNoECDSAKey is returned by New without the key to sign relay requests with.
+	ErrNoECDSAKey = errors.New("ecdsa key is required")
+)
@@ -0,0 +1,183 @@
+// Package privatetx sends processMessage transactions to a Flashbots-style private relay with
+// eth_sendPrivateTransaction, instead of the public mempool, so they can't be front-run or
+// sandwiched while they're pending. The relay only includes a transaction for a number of blocks
+// after it's sent, then drops it, and the transaction is never seen by the public nodes until
+// it's mined.
+package privatetx
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"io"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultMaxBlocks is how many blocks after the current one a transaction can be included in,
+	// Flashbots' own default
+	DefaultMaxBlocks = 25
+
+	// signatureHeader authenticates requests to Flashbots relays
+	signatureHeader = "X-Flashbots-Signature"
+)
+
+type backend interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+type caller interface {
+	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
+}
+
+type Relay struct {
+	client  caller
+	backend backend
+
+	maxBlocks uint64
+	fast      bool
+}
+
+type NewOpts struct {
+	// URL is the relay's JSON-RPC endpoint, i.e. https://relay.flashbots.net
+	URL string
+	// Backend is the destination chain's client, for the block transactions are sent at
+	Backend backend
+	// ECDSAKey signs requests to the relay, which identifies senders by its address
+	ECDSAKey *ecdsa.PrivateKey
+	// MaxBlocks is how many blocks after the current one a transaction can be included in
+	// before the relay drops it, DefaultMaxBlocks if 0
+	MaxBlocks uint64
+	// Fast asks the relay to share transactions with every builder it knows, for faster
+	// inclusion, rather than only the ones it trusts most
+	Fast bool
+}
+
+func New(opts NewOpts) (*Relay, error) {
+	if opts.URL == "" {
+		return nil, ErrNoURL
+	}
+
+	if opts.Backend == nil {
+		return nil, ErrNoBackend
+	}
+
+	if opts.ECDSAKey == nil {
+		return nil, ErrNoECDSAKey
+	}
+
+	client, err := rpc.DialHTTPWithClient(opts.URL, &http.Client{
+		Transport: &signingTransport{key: opts.ECDSAKey, next: http.DefaultTransport},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "rpc.DialHTTPWithClient")
+	}
+
+	maxBlocks := opts.MaxBlocks
+	if maxBlocks == 0 {
+		maxBlocks = DefaultMaxBlocks
+	}
+
+	return &Relay{
+		client:    client,
+		backend:   opts.Backend,
+		maxBlocks: maxBlocks,
+		fast:      opts.Fast,
+	}, nil
+}
+
+// sendPrivateTransactionArgs is eth_sendPrivateTransaction's argument
+type sendPrivateTransactionArgs struct {
+	Tx             hexutil.Bytes  `json:"tx"`
+	MaxBlockNumber hexutil.Uint64 `json:"maxBlockNumber"`
+	Preferences    preferences    `json:"preferences"`
+}
+
+type preferences struct {
+	Fast bool `json:"fast"`
+}
+
+// SendTransaction sends the signed tx to the relay, to be included within MaxBlocks blocks
+// of the destination chain's current one.
+func (r *Relay) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	head, err := r.backend.BlockNumber(ctx)
+	if err != nil {
+		return errors.Wrap(err, "r.backend.BlockNumber")
+	}
+
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "tx.MarshalBinary")
+	}
+
+	var hash common.Hash
+
+	if err := r.client.CallContext(ctx, &hash, "eth_sendPrivateTransaction", sendPrivateTransactionArgs{
+		Tx:             raw,
+		MaxBlockNumber: hexutil.Uint64(head + r.maxBlocks),
+		Preferences:    preferences{Fast: r.fast},
+	}); err != nil {
+		return errors.Wrap(err, "r.client.CallContext(eth_sendPrivateTransaction)")
+	}
+
+	return nil
+}
+
+// MaxBlocks is how many blocks after being sent a transaction can be included in, after which
+// the relay drops it
+func (r *Relay) MaxBlocks() uint64 {
+	return r.maxBlocks
+}
+
+// signingTransport signs each request body with key, Flashbots style: the header is the key's
+// address and its signature of the hex keccak256 hash of the body, as an EIP-191 message.
+type signingTransport struct {
+	key  *ecdsa.PrivateKey
+	next http.RoundTripper
+}
+
+func (t *signingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+
+	if req.Body != nil {
+		var err error
+
+		body, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, errors.Wrap(err, "io.ReadAll")
+		}
+
+		req.Body.Close()
+	}
+
+	signature, err := sign(t.key, body)
+	if err != nil {
+		return nil, err
+	}
+
+	// a RoundTripper mustn't modify the request it's given
+	signed := req.Clone(req.Context())
+	signed.Body = io.NopCloser(bytes.NewReader(body))
+	signed.Header.Set(signatureHeader, signature)
+
+	return t.next.RoundTrip(signed)
+}
+
+func sign(key *ecdsa.PrivateKey, body []byte) (string, error) {
+	hash := crypto.Keccak256Hash(body).Hex()
+
+	signature, err := crypto.Sign(accounts.TextHash([]byte(hash)), key)
+	if err != nil {
+		return "", errors.Wrap(err, "crypto.Sign")
+	}
+
+	return crypto.PubkeyToAddress(key.PublicKey).Hex() + ":" + hexutil.Encode(signature), nil
+}
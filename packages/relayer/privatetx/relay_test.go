@@ -0,0 +1,136 @@
+package privatetx
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+var dummyEcdsaKey = "8da4ef21b864d2cc526dbdb2a120bd2874c36c9d0a1fb7f8c63d7f7a8b41de8f"
+
+type blockNumberer uint64
+
+func (b blockNumberer) BlockNumber(ctx context.Context) (uint64, error) {
+	return uint64(b), nil
+}
+
+func Test_New(t *testing.T) {
+	key, _ := crypto.HexToECDSA(dummyEcdsaKey)
+
+	tests := []struct {
+		name    string
+		opts    NewOpts
+		wantErr error
+	}{
+		{"success", NewOpts{URL: "http://localhost:8545", Backend: blockNumberer(1), ECDSAKey: key}, nil},
+		{"noURL", NewOpts{Backend: blockNumberer(1), ECDSAKey: key}, ErrNoURL},
+		{"noBackend", NewOpts{URL: "http://localhost:8545", ECDSAKey: key}, ErrNoBackend},
+		{"noECDSAKey", NewOpts{URL: "http://localhost:8545", Backend: blockNumberer(1)}, ErrNoECDSAKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := New(tt.opts)
+			assert.Equal(t, tt.wantErr, err)
+
+			if tt.wantErr == nil {
+				assert.Equal(t, uint64(DefaultMaxBlocks), r.MaxBlocks())
+			}
+		})
+	}
+}
+
+func Test_SendTransaction(t *testing.T) {
+	key, _ := crypto.HexToECDSA(dummyEcdsaKey)
+
+	tx, err := types.SignTx(
+		types.NewTransaction(1, common.Address{0x1}, common.Big0, 21000, common.Big1, nil),
+		types.HomesteadSigner{},
+		key,
+	)
+	assert.Nil(t, err)
+
+	var (
+		signer common.Address
+		args   sendPrivateTransactionArgs
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		// the header is the signer's address and its signature of the body's hash
+		header := strings.SplitN(r.Header.Get(signatureHeader), ":", 2)
+		assert.Equal(t, 2, len(header))
+
+		signature := hexutil.MustDecode(header[1])
+
+		pub, err := crypto.SigToPub(accounts.TextHash([]byte(crypto.Keccak256Hash(body).Hex())), signature)
+		assert.Nil(t, err)
+
+		signer = crypto.PubkeyToAddress(*pub)
+		assert.Equal(t, common.HexToAddress(header[0]), signer)
+
+		var req struct {
+			ID     json.RawMessage              `json:"id"`
+			Method string                       `json:"method"`
+			Params []sendPrivateTransactionArgs `json:"params"`
+		}
+
+		assert.Nil(t, json.Unmarshal(body, &req))
+		assert.Equal(t, "eth_sendPrivateTransaction", req.Method)
+
+		args = req.Params[0]
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":` + string(req.ID) + `,"result":"` + tx.Hash().Hex() + `"}`))
+	}))
+	defer srv.Close()
+
+	r, err := New(NewOpts{
+		URL:       srv.URL,
+		Backend:   blockNumberer(100),
+		ECDSAKey:  key,
+		MaxBlocks: 5,
+		Fast:      true,
+	})
+	assert.Nil(t, err)
+
+	assert.Nil(t, r.SendTransaction(context.Background(), tx))
+
+	raw, _ := tx.MarshalBinary()
+
+	assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey), signer)
+	assert.Equal(t, hexutil.Bytes(raw), args.Tx)
+	assert.Equal(t, hexutil.Uint64(105), args.MaxBlockNumber)
+	assert.True(t, args.Preferences.Fast)
+}
+
+func Test_SendTransaction_rejected(t *testing.T) {
+	key, _ := crypto.HexToECDSA(dummyEcdsaKey)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"invalid signature"}}`))
+	}))
+	defer srv.Close()
+
+	r, err := New(NewOpts{URL: srv.URL, Backend: blockNumberer(100), ECDSAKey: key})
+	assert.Nil(t, err)
+
+	err = r.SendTransaction(
+		context.Background(),
+		types.NewTransaction(1, common.Address{0x1}, common.Big0, 21000, common.Big1, nil),
+	)
+	assert.Contains(t, err.Error(), "invalid signature")
+}
@@ -38,8 +38,154 @@ var (
 		Name: "messages_not_received_on_dest_chain_opts_total",
 		Help: "The total number of messages that were not received on the destination chain",
 	})
+	MessagesHeld = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "messages_held_ops_total",
+		Help: "The total number of messages held for manual review for exceeding an amount threshold",
+	})
+	DuplicateMessageSentEvents = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "duplicate_message_sent_events_ops_total",
+		Help: "The total number of MessageSent events not processed for being a message already indexed from another log",
+	})
+	RPCFailovers = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "rpc_failovers_ops_total",
+		Help: "The total number of times an rpc endpoint failed and requests moved to another endpoint",
+	})
+	RPCConnections = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rpc_connections_ops_total",
+		Help: "The total number of connections rpc requests were sent on, by whether an idle one was reused",
+	}, []string{"reused"})
 	ErrorsEncounteredDuringSubscription = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "errors_encountered_during_subscription_opts_total",
 		Help: "The total number of errors that occurred during active subscription",
 	})
+	SubscriptionStalls = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "subscription_stalls_ops_total",
+		Help: "The total number of times subscriptions went silent past the heartbeat and were re-established",
+	}, []string{"chain_id"})
+	SubscriptionLastEventReceived = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "subscription_last_event_received_timestamp_seconds",
+		Help: "Unix time subscriptions last delivered a new head or event, by chain",
+	}, []string{"chain_id"})
+	InFlightTransactions = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "in_flight_transactions",
+		Help: "The number of processMessage transactions sent but not yet confirmed",
+	})
+	RelayerKeyInFlightTransactions = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "relayer_key_in_flight_transactions",
+		Help: "The number of processMessage transactions each relayer key sent to the chain but not yet confirmed",
+	}, []string{"chain_id", "address"})
+	RelayerKeyBalance = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "relayer_key_balance",
+		Help: "Each relayer key's balance on the chain in its native token, as of its last check",
+	}, []string{"chain_id", "address"})
+	RelayerKeyInRotation = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "relayer_key_in_rotation",
+		Help: "1 if the relayer key is sending transactions to the chain, 0 if its balance is below the minimum",
+	}, []string{"chain_id", "address"})
+	ProcessorQueueDepth = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "processor_queue_depth",
+		Help: "The number of messages waiting for a worker to process them",
+	})
+	ProcessorWorkersActive = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "processor_workers_active",
+		Help: "The number of messages being processed",
+	})
+	ProofQueueDepth = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "proof_queue_depth",
+		Help: "The number of signal proofs waiting for a worker to generate them",
+	})
+	ProofWorkersActive = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "proof_workers_active",
+		Help: "The number of signal proofs being generated",
+	})
+	PausedProcessors = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "paused_processors",
+		Help: "The number of processors paused, sending no processMessage transactions",
+	})
+	Leader = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "relayer_leader",
+		Help: "1 while this instance holds the leader lease and sends transactions, 0 while on standby",
+	})
+	MessageRecipientFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "message_recipient_failures_ops_total",
+		Help: "The total number of messages whose processing failed, by recipient contract and revert reason",
+	}, []string{"recipient", "reason"})
+	IndexerBlocksBehindHead = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "indexer_blocks_behind_head",
+		Help: "The number of blocks between the last the indexer processed and the chain's head",
+	}, []string{"chain_id"})
+	IndexerCatchUpETA = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "indexer_catch_up_eta_seconds",
+		Help: "Seconds until the indexer catches up with the chain's head, -1 if it isn't gaining on it",
+	}, []string{"chain_id"})
+	BlockedMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "blocked_messages_ops_total",
+		Help: "The total number of messages not relayed because their sender or recipient is on the blocklist",
+	}, []string{"match"})
+	OutOfScopeMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "out_of_scope_messages_ops_total",
+		Help: "The total number of messages not relayed because their destination chain isn't served",
+	}, []string{"dest_chain_id"})
+	MessageSentEventsIndexed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "message_sent_events_indexed_ops_total",
+		Help: "The total number of MessageSent events saved, by their message's destination chain",
+	}, []string{"dest_chain_id"})
+	LogUnpackFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "log_unpack_failures_ops_total",
+		Help: "The total number of bridge logs matching a handled event's signature that couldn't be unpacked",
+	}, []string{"chain_id", "event"})
+	StaleMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "stale_messages_ops_total",
+		Help: "The total number of messages marked stale for still being new after the max message age",
+	}, []string{"chain_id"})
+	RetriedMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "retried_messages_ops_total",
+		Help: "The total number of messages retried after their processing failed, by why it failed",
+	}, []string{"chain_id", "reason"})
+	ReorgedOutProcessedMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "reorged_out_processed_messages_ops_total",
+		Help: "The total number of processed messages requeued for a reorg undoing their transaction",
+	}, []string{"chain_id"})
+	UnfinalizedMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "unfinalized_messages_ops_total",
+		Help: "The total number of done messages requeued for a destination reorg dropping their transaction",
+	}, []string{"chain_id"})
+	EventBusDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "event_bus_dropped_ops_total",
+		Help: "The total number of in-process events dropped for a subscriber too slow to take them, by topic",
+	}, []string{"topic"})
+	KafkaPublishFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "kafka_publish_failures_ops_total",
+		Help: "The total number of events and status changes dropped instead of published to Kafka, by topic",
+	}, []string{"topic"})
+	AccessLists = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "process_message_access_lists_ops_total",
+		Help: "The total number of processMessage transactions an access list was made for, by whether it was used",
+	}, []string{"result"})
+	AccessListGasSaved = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "process_message_access_list_gas_saved_total",
+		Help: "The total estimated gas access lists saved processMessage transactions",
+	})
+	RelayerBuildInfo = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "relayer_build_info",
+		Help: "Always 1, labelled with the running build's version, git commit, go version and build date",
+	}, []string{"version", "git_commit", "go_version", "build_date"})
+	MessageTimeToDone = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "message_time_to_done_seconds",
+		Help:    "Seconds between the source MessageSent block and the destination block the message was marked Done in",
+		Buckets: []float64{30, 60, 120, 300, 600, 900, 1800, 3600, 7200, 14400},
+	})
+	MessageTimeToDonePercentiles = promauto.NewSummary(prometheus.SummaryOpts{
+		Name:       "message_time_to_done_percentiles_seconds",
+		Help:       "Percentiles of seconds between the source MessageSent block and the block it was marked Done in",
+		Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+	})
+	ChainClockDrift = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "chain_clock_drift_seconds",
+		Help: "Seconds the chain's latest block timestamp is ahead of the local clock, negative when behind",
+	}, []string{"chain_id"})
+	GasRunwayHours = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "relayer_gas_runway_hours",
+		Help: "Hours the relayer keys' balance on the chain lasts at its recent gas spend, +Inf with none spent",
+	}, []string{"chain_id"})
 )
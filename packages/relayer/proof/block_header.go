@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer/encoding"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
 )
@@ -13,6 +14,10 @@ import (
 func (p *Prover) blockHeader(ctx context.Context, blockHash common.Hash) (encoding.BlockHeader, error) {
 	h, err := p.blocker.BlockByHash(ctx, blockHash)
 	if err != nil {
+		if errors.Is(err, ethereum.NotFound) {
+			return encoding.BlockHeader{}, errors.Wrapf(ErrBlockNotFound, "hash: %v", blockHash.Hex())
+		}
+
 		return encoding.BlockHeader{}, errors.Wrap(err, "p.ethClient.GetBlockByNumber")
 	}
 
@@ -8,6 +8,7 @@ import (
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
 	"gopkg.in/go-playground/assert.v1"
 )
 
@@ -24,4 +25,5 @@ func Test_blockHeader_cantFindBlock(t *testing.T) {
 
 	_, err := p.blockHeader(context.Background(), common.HexToHash("0x"))
 	assert.NotEqual(t, err, nil)
+	assert.Equal(t, errors.Is(err, ErrBlockNotFound), true)
 }
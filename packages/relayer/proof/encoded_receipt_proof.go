@@ -0,0 +1,167 @@
+package proof
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/encoding"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/pkg/errors"
+)
+
+// EncodedReceiptProof abi encodes a ReceiptProof of txHash's receipt being included in its
+// block, for verifying the logs a transaction emitted rather than a storage signal. The proof
+// is built from all the block's receipts, and verified against the block's receipts root
+// before it's returned.
+func (p *Prover) EncodedReceiptProof(ctx context.Context, txHash common.Hash) ([]byte, error) {
+	if err := p.acquireWorker(ctx); err != nil {
+		return nil, errors.Wrap(err, "p.acquireWorker")
+	}
+
+	defer p.releaseWorker()
+
+	receipt, err := p.transactionReceipt(ctx, txHash)
+	if err != nil {
+		return nil, errors.Wrap(err, "p.transactionReceipt")
+	}
+
+	block, err := p.blocker.BlockByHash(ctx, receipt.BlockHash)
+	if err != nil {
+		if errors.Is(err, ethereum.NotFound) {
+			return nil, errors.Wrapf(ErrBlockNotFound, "hash: %v", receipt.BlockHash.Hex())
+		}
+
+		return nil, errors.Wrap(err, "p.blocker.BlockByHash")
+	}
+
+	receipts := make(types.Receipts, 0, len(block.Transactions()))
+
+	for _, tx := range block.Transactions() {
+		r, err := p.transactionReceipt(ctx, tx.Hash())
+		if err != nil {
+			return nil, errors.Wrap(err, "p.transactionReceipt")
+		}
+
+		receipts = append(receipts, r)
+	}
+
+	index := receipt.TransactionIndex
+	if int(index) >= len(receipts) || receipts[index].TxHash != txHash {
+		return nil, errors.Wrapf(
+			ErrReceiptProofInvalid,
+			"%v is not transaction %v of block %v",
+			txHash.Hex(),
+			index,
+			receipt.BlockHash.Hex(),
+		)
+	}
+
+	nodes, err := receiptProof(receipts, index, block.ReceiptHash())
+	if err != nil {
+		return nil, err
+	}
+
+	encodedNodes, err := rlp.EncodeToBytes(nodes)
+	if err != nil {
+		return nil, errors.Wrap(err, "rlp.EncodeToBytes(nodes)")
+	}
+
+	encodedReceiptProof, err := encoding.EncodeReceiptProof(encoding.ReceiptProof{
+		Header:       encoding.BlockToBlockHeader(block),
+		ReceiptsRoot: block.ReceiptHash(),
+		Index:        uint64(index),
+		Proof:        encodedNodes,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "encoding.EncodeReceiptProof")
+	}
+
+	return encodedReceiptProof, nil
+}
+
+func (p *Prover) transactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return transactionReceipt(ctx, p.rpcClient, txHash)
+}
+
+func transactionReceipt(ctx context.Context, c relayer.Caller, txHash common.Hash) (*types.Receipt, error) {
+	var receipt *types.Receipt
+
+	if err := c.CallContext(ctx, &receipt, "eth_getTransactionReceipt", txHash); err != nil {
+		return nil, errors.Wrap(err, "c.CallContext")
+	}
+
+	// a null result leaves the receipt nil
+	if receipt == nil {
+		return nil, errors.Wrapf(ErrReceiptNotFound, "txHash: %v", txHash.Hex())
+	}
+
+	return receipt, nil
+}
+
+// receiptProof builds the receipts trie, checks it has the block's receiptsRoot, and returns
+// the nodes from the root to the receipt at index, once they're verified to prove it.
+func receiptProof(receipts types.Receipts, index uint, receiptsRoot common.Hash) ([][]byte, error) {
+	tr := trie.NewEmpty(trie.NewDatabase(rawdb.NewMemoryDatabase()))
+
+	encoded := make([][]byte, len(receipts))
+
+	for i := range receipts {
+		var buf bytes.Buffer
+
+		receipts.EncodeIndex(i, &buf)
+		encoded[i] = buf.Bytes()
+
+		if err := tr.TryUpdate(rlp.AppendUint64(nil, uint64(i)), encoded[i]); err != nil {
+			return nil, errors.Wrap(err, "tr.TryUpdate")
+		}
+	}
+
+	if root := tr.Hash(); root != receiptsRoot {
+		return nil, errors.Wrapf(
+			ErrReceiptsRootMismatch,
+			"%v receipts build root %v, the block's is %v",
+			len(receipts),
+			root.Hex(),
+			receiptsRoot.Hex(),
+		)
+	}
+
+	key := rlp.AppendUint64(nil, uint64(index))
+
+	nodes := &proofNodes{}
+	if err := tr.Prove(key, 0, nodes); err != nil {
+		return nil, errors.Wrap(err, "tr.Prove")
+	}
+
+	value, err := trie.VerifyProof(receiptsRoot, key, proofDB(nodes.nodes))
+	if err != nil {
+		return nil, errors.Wrap(ErrReceiptProofInvalid, err.Error())
+	}
+
+	if !bytes.Equal(value, encoded[index]) {
+		return nil, errors.Wrapf(ErrReceiptProofInvalid, "proof doesn't prove receipt %v", index)
+	}
+
+	return nodes.nodes, nil
+}
+
+// proofNodes collects the nodes trie.Prove writes, in order from the root.
+type proofNodes struct {
+	nodes [][]byte
+}
+
+func (n *proofNodes) Put(key []byte, value []byte) error {
+	n.nodes = append(n.nodes, common.CopyBytes(value))
+
+	return nil
+}
+
+func (n *proofNodes) Delete(key []byte) error {
+	return nil
+}
@@ -0,0 +1,141 @@
+package proof
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/encoding"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// receiptsChain answers BlockByHash and eth_getTransactionReceipt for a single block
+type receiptsChain struct {
+	block    *types.Block
+	receipts map[common.Hash]*types.Receipt
+}
+
+func newReceiptsChain(numTxs int) *receiptsChain {
+	txs := make(types.Transactions, 0, numTxs)
+	receipts := make(types.Receipts, 0, numTxs)
+
+	for i := 0; i < numTxs; i++ {
+		tx := types.NewTransaction(uint64(i), common.Address{0x1}, big.NewInt(1), 21000, big.NewInt(1), nil)
+
+		receipt := &types.Receipt{
+			Status:            types.ReceiptStatusSuccessful,
+			CumulativeGasUsed: uint64(21000 * (i + 1)),
+			Logs: []*types.Log{{
+				Address: common.Address{0x2},
+				Topics:  []common.Hash{{byte(i)}},
+				Data:    []byte{byte(i)},
+			}},
+			TxHash:           tx.Hash(),
+			GasUsed:          21000,
+			TransactionIndex: uint(i),
+		}
+		receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+
+		txs = append(txs, tx)
+		receipts = append(receipts, receipt)
+	}
+
+	block := types.NewBlock(&types.Header{Number: big.NewInt(1)}, txs, nil, receipts, trie.NewStackTrie(nil))
+
+	c := &receiptsChain{block: block, receipts: make(map[common.Hash]*types.Receipt)}
+
+	for _, r := range receipts {
+		r.BlockHash = block.Hash()
+		r.BlockNumber = block.Number()
+		c.receipts[r.TxHash] = r
+	}
+
+	return c
+}
+
+func (c *receiptsChain) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	if hash != c.block.Hash() {
+		return nil, ethereum.NotFound
+	}
+
+	return c.block, nil
+}
+
+func (c *receiptsChain) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	receipt, ok := c.receipts[args[0].(common.Hash)]
+	if !ok {
+		return json.Unmarshal([]byte("null"), result)
+	}
+
+	b, err := json.Marshal(receipt)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, result)
+}
+
+func Test_EncodedReceiptProof(t *testing.T) {
+	c := newReceiptsChain(20)
+
+	p, err := New(c, c)
+	assert.Nil(t, err)
+
+	for _, index := range []int{0, 7, 19} {
+		tx := c.block.Transactions()[index]
+
+		encoded, err := p.EncodedReceiptProof(context.Background(), tx.Hash())
+		assert.Nil(t, err)
+
+		receiptProof, err := encoding.DecodeReceiptProof(encoded)
+		assert.Nil(t, err)
+
+		assert.Equal(t, c.block.ReceiptHash(), common.Hash(receiptProof.ReceiptsRoot))
+		assert.Equal(t, common.Hash(receiptProof.Header.ReceiptsRoot), common.Hash(receiptProof.ReceiptsRoot))
+		assert.Equal(t, uint64(index), receiptProof.Index)
+
+		var nodes [][]byte
+		assert.Nil(t, rlp.DecodeBytes(receiptProof.Proof, &nodes))
+
+		value, err := trie.VerifyProof(c.block.ReceiptHash(), rlp.AppendUint64(nil, uint64(index)), proofDB(nodes))
+		assert.Nil(t, err)
+
+		want, err := c.receipts[tx.Hash()].MarshalBinary()
+		assert.Nil(t, err)
+		assert.True(t, bytes.Equal(want, value))
+	}
+}
+
+func Test_EncodedReceiptProof_receiptNotFound(t *testing.T) {
+	c := newReceiptsChain(2)
+
+	p, err := New(c, c)
+	assert.Nil(t, err)
+
+	_, err = p.EncodedReceiptProof(context.Background(), common.Hash{0x1})
+	assert.True(t, errors.Is(err, ErrReceiptNotFound))
+}
+
+func Test_EncodedReceiptProof_receiptsRootMismatch(t *testing.T) {
+	c := newReceiptsChain(3)
+
+	for _, r := range c.receipts {
+		if r.TransactionIndex == 1 {
+			r.CumulativeGasUsed++
+		}
+	}
+
+	p, err := New(c, c)
+	assert.Nil(t, err)
+
+	_, err = p.EncodedReceiptProof(context.Background(), c.block.Transactions()[0].Hash())
+	assert.True(t, errors.Is(err, ErrReceiptsRootMismatch))
+}
@@ -1,27 +1,34 @@
 package proof
 
 import (
+	"bytes"
 	"context"
-	"fmt"
+	"encoding/hex"
 	"math/big"
+	"strings"
 
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer/encoding"
-	"github.com/labstack/gommon/log"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
 	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
 )
 
 // EncodedSignalProof rlp and abi encodes the SignalProof struct expected by LibBridgeSignal
-// in our contracts
+// in our contracts, for the slot signalService's version stores `signal` at. It returns
+// ErrSignalNotSent if the SignalService has no record of `signal` being sent by `app` at the given block.
 func (p *Prover) EncodedSignalProof(
 	ctx context.Context,
 	caller relayer.Caller,
-	signalServiceAddress common.Address,
-	key string,
+	signalService SignalService,
+	app common.Address,
+	signal [32]byte,
 	blockHash common.Hash,
 ) ([]byte, error) {
 	//blockHeader, err := p.blockHeader(ctx, blockHash)
@@ -30,10 +37,45 @@ func (p *Prover) EncodedSignalProof(
 	//}
 	blockNumber, err := p.BlockNumberByHash(ctx, blockHash)
 	if err != nil {
-		fmt.Println(blockHash.String())
-		return nil, errors.Wrap(err, "p.blockHeader")
+		return nil, errors.Wrap(err, "p.BlockNumberByHash")
+	}
+
+	return p.encodedSignalProofAtBlock(ctx, caller, signalService, app, signal, blockNumber)
+}
+
+// encodedSignalProofAtBlock generates the encoded SignalProof for a block we already know the number of,
+// queueing while the max concurrent proofs are generating.
+func (p *Prover) encodedSignalProofAtBlock(
+	ctx context.Context,
+	caller relayer.Caller,
+	signalService SignalService,
+	app common.Address,
+	signal [32]byte,
+	blockNumber *big.Int,
+) ([]byte, error) {
+	if err := p.acquireWorker(ctx); err != nil {
+		return nil, errors.Wrap(err, "p.acquireWorker")
+	}
+
+	defer p.releaseWorker()
+
+	if err := p.checkFinalized(ctx, caller, blockNumber); err != nil {
+		return nil, errors.Wrap(err, "p.checkFinalized")
+	}
+
+	sent, err := p.IsSignalSent(ctx, caller, signalService.Address, app, signal, blockNumber)
+	if err != nil {
+		return nil, errors.Wrap(err, "p.IsSignalSent")
 	}
-	encodedStorageProof, err := p.encodedStorageProof(ctx, caller, signalServiceAddress, key, blockNumber.Int64())
+
+	if !sent {
+		return nil, ErrSignalNotSent
+	}
+
+	slot := signalService.Slot(app, signal)
+	key := hex.EncodeToString(slot[:])
+
+	encodedStorageProof, err := p.encodedStorageProof(ctx, caller, signalService.Address, key, blockNumber.Int64())
 	if err != nil {
 		return nil, errors.Wrap(err, "p.getEncodedStorageProof")
 	}
@@ -61,25 +103,67 @@ func (p *Prover) encodedStorageProof(
 	key string,
 	blockNumber int64,
 ) ([]byte, error) {
-	var ethProof StorageProof
+	relayer.Logger(ctx).Infof("getting proof for: %v, key: %v, blockNum: %v", signalServiceAddress, key, blockNumber)
 
-	log.Infof("getting proof for: %v, key: %v, blockNum: %v", signalServiceAddress, key, blockNumber)
-
-	err := c.CallContext(ctx,
-		&ethProof,
-		"eth_getProof",
-		signalServiceAddress,
-		[]string{key},
-		hexutil.EncodeBig(new(big.Int).SetInt64(blockNumber)),
+	var (
+		ethProof *StorageProof
+		block    *taggedBlock
+		height   = big.NewInt(blockNumber)
 	)
-	if err != nil {
-		return nil, errors.Wrap(err, "c.CallContext")
+
+	// the proof and the state root it's verified against are independent requests, so we make
+	// them concurrently; either failing cancels the other
+	group, groupCtx := errgroup.WithContext(ctx)
+
+	group.Go(func() error {
+		var err error
+
+		ethProof, err = getProof(groupCtx, c, signalServiceAddress, []string{key}, height)
+		if err != nil {
+			return errors.Wrap(wrapGetProofError(err, height), "getProof")
+		}
+
+		return nil
+	})
+
+	group.Go(func() error {
+		var err error
+
+		block, err = blockByNumber(groupCtx, c, hexutil.EncodeBig(height))
+		if err != nil {
+			return errors.Wrap(err, "blockByNumber")
+		}
+
+		if block == nil {
+			return errors.Wrapf(ErrBlockNotFound, "number: %v", blockNumber)
+		}
+
+		return nil
+	})
+
+	if err := group.Wait(); err != nil {
+		return nil, err
 	}
 
-	log.Infof("proof: %v", new(big.Int).SetBytes(ethProof.StorageProof[0].Value).Int64())
+	// a node without the block's state can answer with an empty result rather than an error
+	if len(ethProof.StorageProof) == 0 && len(ethProof.AccountProof) == 0 {
+		return nil, stateRootPrunedError("eth_getProof returned an empty proof", height)
+	}
+
+	if len(ethProof.StorageProof) == 0 {
+		return nil, errors.Wrap(ErrProofVerificationFailed, "no storageProof returned")
+	}
+
+	value := new(big.Int).SetBytes(ethProof.StorageProof[0].Value).Int64()
 
-	if new(big.Int).SetBytes(ethProof.StorageProof[0].Value).Int64() != int64(1) {
-		return nil, errors.New("proof will not be valid, expected storageProof to be 1 but was not")
+	relayer.Logger(ctx).Infof("proof: %v", value)
+
+	if value != int64(1) {
+		return nil, errors.Wrap(ErrProofVerificationFailed, "expected storageProof to be 1 but was not")
+	}
+
+	if err := verifyStorageProof(ethProof, block.StateRoot, signalServiceAddress, key, blockNumber); err != nil {
+		return nil, err
 	}
 
 	rlpEncodedStorageProof, err := rlp.EncodeToBytes(ethProof.StorageProof[0].Proof)
@@ -89,3 +173,74 @@ func (p *Prover) encodedStorageProof(
 
 	return rlpEncodedStorageProof, nil
 }
+
+// verifyStorageProof checks an eth_getProof response before we trust it: the account proof has to
+// resolve against the block's state root to an account whose storage root is the storageHash the
+// storage proof is against, and the storage proof has to prove `key` is set to 1. A node answering
+// with a proof from other state would otherwise only be caught by the bridge reverting on chain.
+func verifyStorageProof(
+	ethProof *StorageProof,
+	stateRoot common.Hash,
+	signalServiceAddress common.Address,
+	key string,
+	blockNumber int64,
+) error {
+	encodedAccount, err := trie.VerifyProof(
+		stateRoot,
+		crypto.Keccak256(signalServiceAddress.Bytes()),
+		proofDB(ethProof.AccountProof),
+	)
+	if err != nil {
+		return errors.Wrapf(
+			ErrProofVerificationFailed,
+			"accountProof against state root %v: %v",
+			stateRoot.Hex(),
+			err,
+		)
+	}
+
+	if encodedAccount == nil {
+		return errors.Wrapf(
+			ErrProofVerificationFailed,
+			"no account at %v in block %v",
+			signalServiceAddress.Hex(),
+			blockNumber,
+		)
+	}
+
+	var account types.StateAccount
+	if err := rlp.DecodeBytes(encodedAccount, &account); err != nil {
+		return errors.Wrapf(ErrProofVerificationFailed, "accountProof account: %v", err)
+	}
+
+	if account.Root != ethProof.StorageHash {
+		return errors.Wrapf(
+			ErrProofVerificationFailed,
+			"accountProof storage root is %v, but storageHash is %v",
+			account.Root.Hex(),
+			ethProof.StorageHash.Hex(),
+		)
+	}
+
+	slot, err := hex.DecodeString(strings.TrimPrefix(key, "0x"))
+	if err != nil {
+		return errors.Wrapf(ErrProofVerificationFailed, "key %v: %v", key, err)
+	}
+
+	value, err := trie.VerifyProof(account.Root, crypto.Keccak256(slot), proofDB(ethProof.StorageProof[0].Proof))
+	if err != nil {
+		return errors.Wrapf(
+			ErrProofVerificationFailed,
+			"storageProof against storage root %v: %v",
+			account.Root.Hex(),
+			err,
+		)
+	}
+
+	var stored []byte
+	if err := rlp.DecodeBytes(value, &stored); err != nil || !bytes.Equal(stored, []byte{0x1}) {
+		return errors.Wrapf(ErrProofVerificationFailed, "storageProof proves key %v is %v, not 1", key, hexutil.Encode(value))
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package proof
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+)
+
+// BlockTag names a block for eth_getBlockByNumber to resolve, rather than a specific hash.
+type BlockTag string
+
+const (
+	BlockTagFinalized BlockTag = "finalized"
+	BlockTagSafe      BlockTag = "safe"
+)
+
+// defaultTagFallbackDepth is how many blocks behind head we prove against when the node
+// doesn't support the requested tag, two epochs on ethereum mainnet.
+const defaultTagFallbackDepth = 64
+
+type EncodedSignalProofAtTagOpts struct {
+	Tag BlockTag
+	// FallbackDepth is how many blocks behind head to prove against when the node
+	// doesn't support Tag, defaults to 64.
+	FallbackDepth uint64
+}
+
+// TaggedSignalProof is an encoded signal proof along with the block it was resolved to
+// and proves against.
+type TaggedSignalProof struct {
+	BlockNumber  *big.Int
+	BlockHash    common.Hash
+	EncodedProof []byte
+}
+
+type taggedBlock struct {
+	Number    *hexutil.Big `json:"number"`
+	Hash      common.Hash  `json:"hash"`
+	StateRoot common.Hash  `json:"stateRoot"`
+}
+
+// EncodedSignalProofAtTag generates the same proof as EncodedSignalProof, against the block
+// the node currently considers finalized or safe, so callers don't have to track block hashes.
+func (p *Prover) EncodedSignalProofAtTag(
+	ctx context.Context,
+	caller relayer.Caller,
+	signalService SignalService,
+	app common.Address,
+	signal [32]byte,
+	opts EncodedSignalProofAtTagOpts,
+) (*TaggedSignalProof, error) {
+	if opts.Tag != BlockTagFinalized && opts.Tag != BlockTagSafe {
+		return nil, errors.Wrapf(ErrInvalidBlockTag, "tag: %v", opts.Tag)
+	}
+
+	block, err := resolveBlockTag(ctx, caller, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "resolveBlockTag")
+	}
+
+	blockNumber := block.Number.ToInt()
+
+	encoded, err := p.encodedSignalProofAtBlock(ctx, caller, signalService, app, signal, blockNumber)
+	if err != nil {
+		return nil, errors.Wrap(err, "p.encodedSignalProofAtBlock")
+	}
+
+	return &TaggedSignalProof{
+		BlockNumber:  blockNumber,
+		BlockHash:    block.Hash,
+		EncodedProof: encoded,
+	}, nil
+}
+
+// resolveBlockTag looks up the block opts.Tag currently points to. Nodes that don't support
+// the tag answer with an RPC error or null, so we fall back to head minus opts.FallbackDepth.
+func resolveBlockTag(ctx context.Context, c relayer.Caller, opts EncodedSignalProofAtTagOpts) (*taggedBlock, error) {
+	block, err := blockByNumber(ctx, c, string(opts.Tag))
+	if err == nil && block != nil {
+		return block, nil
+	}
+
+	var rpcErr rpc.Error
+	if err != nil && !errors.As(err, &rpcErr) {
+		return nil, err
+	}
+
+	var head hexutil.Uint64
+	if err := c.CallContext(ctx, &head, "eth_blockNumber"); err != nil {
+		return nil, errors.Wrap(err, "c.CallContext(eth_blockNumber)")
+	}
+
+	depth := opts.FallbackDepth
+	if depth == 0 {
+		depth = defaultTagFallbackDepth
+	}
+
+	var number uint64
+	if uint64(head) > depth {
+		number = uint64(head) - depth
+	}
+
+	block, err = blockByNumber(ctx, c, hexutil.EncodeUint64(number))
+	if err != nil {
+		return nil, err
+	}
+
+	if block == nil {
+		return nil, errors.Wrapf(ErrBlockNotFound, "number: %v", number)
+	}
+
+	return block, nil
+}
+
+func blockByNumber(ctx context.Context, c relayer.Caller, number string) (*taggedBlock, error) {
+	var block *taggedBlock
+
+	if err := c.CallContext(ctx, &block, "eth_getBlockByNumber", number, false); err != nil {
+		return nil, errors.Wrap(err, "c.CallContext(eth_getBlockByNumber)")
+	}
+
+	// a null result, or one without a number, means the node doesn't know the block
+	if block == nil || block.Number == nil {
+		return nil, nil
+	}
+
+	return block, nil
+}
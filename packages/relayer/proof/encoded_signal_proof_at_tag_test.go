@@ -0,0 +1,95 @@
+package proof
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_EncodedSignalProofAtTag(t *testing.T) {
+	tests := []struct {
+		name            string
+		caller          *mock.Caller
+		opts            EncodedSignalProofAtTagOpts
+		wantBlockNumber uint64
+		wantErr         error
+	}{
+		{
+			"finalized",
+			&mock.Caller{},
+			EncodedSignalProofAtTagOpts{Tag: BlockTagFinalized},
+			mock.FinalizedBlockNumber,
+			nil,
+		},
+		{
+			"safe",
+			&mock.Caller{},
+			EncodedSignalProofAtTagOpts{Tag: BlockTagSafe},
+			mock.FinalizedBlockNumber,
+			nil,
+		},
+		{
+			"unsupportedFallsBackToDefaultDepth",
+			&mock.Caller{BlockTagUnsupported: true},
+			EncodedSignalProofAtTagOpts{Tag: BlockTagFinalized},
+			mock.HeadBlockNumber - defaultTagFallbackDepth,
+			nil,
+		},
+		{
+			"unsupportedFallsBackToDepth",
+			&mock.Caller{BlockTagUnsupported: true},
+			EncodedSignalProofAtTagOpts{Tag: BlockTagFinalized, FallbackDepth: 10},
+			mock.HeadBlockNumber - 10,
+			nil,
+		},
+		{
+			"unsupportedDepthPastGenesis",
+			&mock.Caller{BlockTagUnsupported: true},
+			EncodedSignalProofAtTagOpts{Tag: BlockTagSafe, FallbackDepth: mock.HeadBlockNumber + 1},
+			0,
+			nil,
+		},
+		{
+			"invalidTag",
+			&mock.Caller{},
+			EncodedSignalProofAtTagOpts{Tag: "latest"},
+			0,
+			ErrInvalidBlockTag,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestProver()
+
+			tagged, err := p.EncodedSignalProofAtTag(
+				context.Background(),
+				tt.caller,
+				SignalService{},
+				common.Address{},
+				[32]byte{0x1},
+				tt.opts,
+			)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+				assert.False(t, IsRetriable(err))
+
+				return
+			}
+
+			assert.Nil(t, err)
+
+			number := hexutil.EncodeUint64(tt.wantBlockNumber)
+
+			assert.Equal(t, tt.wantBlockNumber, tagged.BlockNumber.Uint64())
+			assert.Equal(t, crypto.Keccak256Hash([]byte(number)), tagged.BlockHash)
+			assert.NotEmpty(t, tagged.EncodedProof)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package proof
+
+import (
+	"context"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+// messageSentTopic is the MessageSent event's id, the first topic of the logs it emits
+var messageSentTopic = mustBridgeEventID("MessageSent")
+
+func mustBridgeEventID(name string) common.Hash {
+	parsed, err := bridge.BridgeMetaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+
+	return parsed.Events[name].ID
+}
+
+// EncodedSignalProofForLog generates the same proof as EncodedSignalProof, for the signal the
+// MessageSent log at logIndex in txHash's receipt sent, so a transaction that sent several
+// messages can have each of them proved. The app is the address that emitted the log.
+func (p *Prover) EncodedSignalProofForLog(
+	ctx context.Context,
+	caller relayer.Caller,
+	signalService SignalService,
+	txHash common.Hash,
+	logIndex uint,
+	blockHash common.Hash,
+) ([]byte, error) {
+	receipt, err := transactionReceipt(ctx, caller, txHash)
+	if err != nil {
+		return nil, errors.Wrap(err, "transactionReceipt")
+	}
+
+	app, signal, err := signalFromReceipt(receipt, logIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.EncodedSignalProof(ctx, caller, signalService, app, signal, blockHash)
+}
+
+// signalFromReceipt returns the app and signal of the MessageSent log at logIndex in receipt,
+// ErrSignalLogNotFound if there isn't one.
+func signalFromReceipt(receipt *types.Receipt, logIndex uint) (common.Address, [32]byte, error) {
+	for _, l := range receipt.Logs {
+		if l.Index != logIndex {
+			continue
+		}
+
+		if len(l.Topics) < 2 || l.Topics[0] != messageSentTopic {
+			return common.Address{}, [32]byte{}, errors.Wrapf(
+				ErrSignalLogNotFound,
+				"log %v of %v isn't a MessageSent log",
+				logIndex,
+				receipt.TxHash.Hex(),
+			)
+		}
+
+		// the msgHash, which is the signal the bridge sends
+		return l.Address, l.Topics[1], nil
+	}
+
+	return common.Address{}, [32]byte{}, errors.Wrapf(
+		ErrSignalLogNotFound,
+		"%v has no log %v",
+		receipt.TxHash.Hex(),
+		logIndex,
+	)
+}
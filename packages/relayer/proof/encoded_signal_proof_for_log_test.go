@@ -0,0 +1,126 @@
+package proof
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// newMultiSignalCaller answers for a transaction that sent a message per signal, with a log
+// that isn't a MessageSent between them.
+func newMultiSignalCaller(txHash common.Hash, app common.Address, signals ...[32]byte) *mock.Caller {
+	logs := []*types.Log{}
+
+	for _, signal := range signals {
+		logs = append(logs, &types.Log{
+			Address: app,
+			Topics:  []common.Hash{messageSentTopic, signal},
+			TxHash:  txHash,
+			Index:   uint(len(logs)),
+		})
+
+		logs = append(logs, &types.Log{
+			Address: app,
+			Topics:  []common.Hash{{0x1}},
+			TxHash:  txHash,
+			Index:   uint(len(logs)),
+		})
+	}
+
+	return &mock.Caller{
+		Receipts: map[common.Hash]*types.Receipt{
+			txHash: {
+				Status:      types.ReceiptStatusSuccessful,
+				Logs:        logs,
+				TxHash:      txHash,
+				BlockHash:   mock.Header.TxHash,
+				BlockNumber: mock.Header.Number,
+			},
+		},
+	}
+}
+
+func Test_EncodedSignalProofForLog(t *testing.T) {
+	app := common.HexToAddress("0x63FaC9201494f0bd17B9892B9fae4d52fe3BD377")
+	txHash := common.Hash{0x5}
+	signals := [][32]byte{{0x1}, {0x2}, {0x3}}
+
+	tests := []struct {
+		name     string
+		txHash   common.Hash
+		logIndex uint
+		wantKey  [32]byte
+		wantErr  error
+	}{
+		{
+			"first",
+			txHash,
+			0,
+			SignalSlot(app, signals[0]),
+			nil,
+		},
+		{
+			"middle",
+			txHash,
+			2,
+			SignalSlot(app, signals[1]),
+			nil,
+		},
+		{
+			"notMessageSent",
+			txHash,
+			3,
+			[32]byte{},
+			ErrSignalLogNotFound,
+		},
+		{
+			"noSuchLog",
+			txHash,
+			6,
+			[32]byte{},
+			ErrSignalLogNotFound,
+		},
+		{
+			"noReceipt",
+			common.Hash{0x6},
+			0,
+			[32]byte{},
+			ErrReceiptNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestProver()
+			caller := newMultiSignalCaller(txHash, app, signals...)
+
+			encoded, err := p.EncodedSignalProofForLog(
+				context.Background(),
+				caller,
+				SignalService{},
+				tt.txHash,
+				tt.logIndex,
+				mock.Header.TxHash,
+			)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+				assert.Empty(t, caller.ProofRequests())
+
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.NotEmpty(t, encoded)
+
+			// the proof is for the slot of the log's own signal, not the others sent in the block
+			assert.Equal(t, []mock.ProofRequest{{
+				Key: common.Bytes2Hex(tt.wantKey[:]),
+			}}, caller.ProofRequests())
+		})
+	}
+}
@@ -2,23 +2,273 @@ package proof
 
 import (
 	"context"
+	"io"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 )
 
 var (
 	// nolint: lll
-	wantEncoded = "0x0000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000000100000000000000000000000000000000000000000000000000000000000000400000000000000000000000000000000000000000000000000000000000000001c000000000000000000000000000000000000000000000000000000000000000"
+	wantEncoded = "0x000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000040000000000000000000000000000000000000000000000000000000000000007bf879b853f851a05821064d4f0690a2d5649928291eb8475a8298fd4a144daa228c2a63c07004a18080808080808080a08e88c14cebc98b3737c1f84612843534d71789900fbc42acb1091e3ee927332680808080808080a3e2a03c4162b5fca27f523570339562f6ec86b9f64fc40d1406b48008bec95cd0c8d6010000000000"
 )
 
 func Test_EncodedSignalProof(t *testing.T) {
 	p := newTestProver()
 
-	encoded, err := p.EncodedSignalProof(context.Background(), &mock.Caller{}, common.Address{}, "1", mock.Header.TxHash)
+	encoded, err := p.EncodedSignalProof(
+		context.Background(),
+		&mock.Caller{},
+		SignalService{},
+		common.Address{},
+		[32]byte{0x1},
+		mock.Header.TxHash,
+	)
 	assert.Nil(t, err)
 	assert.Equal(t, hexutil.Encode(encoded), wantEncoded)
 }
+
+func Test_EncodedSignalProof_signalNotSent(t *testing.T) {
+	p := newTestProver()
+
+	_, err := p.EncodedSignalProof(
+		context.Background(),
+		&mock.Caller{},
+		SignalService{},
+		common.Address{},
+		mock.NotSentSignal,
+		mock.Header.TxHash,
+	)
+	assert.True(t, errors.Is(err, ErrSignalNotSent))
+	assert.False(t, IsRetriable(err))
+}
+
+func Test_EncodedSignalProof_unverifiedProof(t *testing.T) {
+	tests := []struct {
+		name       string
+		caller     *mock.Caller
+		wantDetail string
+	}{
+		{"storageHashNotAccountStorageRoot", &mock.Caller{TamperedProof: true}, "but storageHash is"},
+		{"accountProofNotStateRoot", &mock.Caller{StateRoot: common.Hash{0x1}}, "accountProof against state root"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestProver()
+
+			_, err := p.EncodedSignalProof(
+				context.Background(),
+				tt.caller,
+				SignalService{},
+				common.Address{},
+				[32]byte{0x1},
+				mock.Header.TxHash,
+			)
+			assert.True(t, errors.Is(err, ErrProofVerificationFailed), "got %v", err)
+			assert.Contains(t, err.Error(), tt.wantDetail)
+		})
+	}
+}
+
+func Test_EncodedSignalProof_statePruned(t *testing.T) {
+	tests := []struct {
+		name   string
+		caller *mock.Caller
+	}{
+		{"missingTrieNode", &mock.Caller{StatePruned: true}},
+		{"emptyProof", &mock.Caller{EmptyProof: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestProver()
+
+			_, err := p.EncodedSignalProof(
+				context.Background(),
+				tt.caller,
+				SignalService{},
+				common.Address{},
+				[32]byte{0x1},
+				mock.Header.TxHash,
+			)
+			assert.True(t, errors.Is(err, ErrStateRootPruned))
+			assert.False(t, IsRetriable(err))
+			assert.Contains(t, err.Error(), "requires archive state, use an archive node")
+		})
+	}
+}
+
+func Test_EncodedSignalProof_eachDeployment(t *testing.T) {
+	app := common.HexToAddress("0x63FaC9201494f0bd17B9892B9fae4d52fe3BD377")
+	signal := [32]byte{0x1}
+	chainID := mock.MockChainID.Uint64()
+
+	tests := []struct {
+		name          string
+		signalService SignalService
+		wantKey       [32]byte
+	}{
+		{
+			"v1",
+			SignalService{Address: v1SignalService, Version: SignalServiceV1, ChainID: chainID},
+			SignalSlot(app, signal),
+		},
+		{
+			"v2",
+			SignalService{Address: v2SignalService, Version: SignalServiceV2, ChainID: chainID},
+			SignalSlotV2(chainID, app, signal),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestProver()
+			caller := &mock.Caller{}
+
+			_, err := p.EncodedSignalProof(context.Background(), caller, tt.signalService, app, signal, mock.Header.TxHash)
+			assert.Nil(t, err)
+
+			// the proof is for the deployment's account, at the slot its layout stores the signal at
+			assert.Equal(t, []mock.ProofRequest{{
+				Address: tt.signalService.Address,
+				Key:     common.Bytes2Hex(tt.wantKey[:]),
+			}}, caller.ProofRequests())
+		})
+	}
+}
+
+// latencyCaller answers like Caller, after latency, or with err if one is set for the method
+type latencyCaller struct {
+	relayer.Caller
+	latency map[string]time.Duration
+	err     map[string]error
+
+	mu       sync.Mutex
+	canceled []string
+}
+
+func (c *latencyCaller) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	if err, ok := c.err[method]; ok {
+		return err
+	}
+
+	select {
+	case <-time.After(c.latency[method]):
+	case <-ctx.Done():
+		c.mu.Lock()
+		c.canceled = append(c.canceled, method)
+		c.mu.Unlock()
+
+		return ctx.Err()
+	}
+
+	return c.Caller.CallContext(ctx, result, method, args...)
+}
+
+func Test_EncodedSignalProof_cancellation(t *testing.T) {
+	t.Run("getProofFailing", func(t *testing.T) {
+		caller := &latencyCaller{
+			Caller:  &mock.Caller{},
+			latency: map[string]time.Duration{"eth_getBlockByNumber": time.Minute},
+			err:     map[string]error{"eth_getProof": errors.New("getProof failed")},
+		}
+
+		_, err := newTestProver().EncodedSignalProof(
+			context.Background(),
+			caller,
+			SignalService{},
+			common.Address{},
+			[32]byte{0x1},
+			mock.Header.TxHash,
+		)
+		assert.Contains(t, err.Error(), "getProof failed")
+		assert.Equal(t, []string{"eth_getBlockByNumber"}, caller.canceled)
+	})
+
+	t.Run("contextCanceled", func(t *testing.T) {
+		caller := &latencyCaller{
+			Caller: &mock.Caller{},
+			latency: map[string]time.Duration{
+				"eth_getProof":         time.Minute,
+				"eth_getBlockByNumber": time.Minute,
+			},
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err := newTestProver().EncodedSignalProof(
+			ctx,
+			caller,
+			SignalService{},
+			common.Address{},
+			[32]byte{0x1},
+			mock.Header.TxHash,
+		)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded), "got %v", err)
+		assert.ElementsMatch(t, []string{"eth_getProof", "eth_getBlockByNumber"}, caller.canceled)
+	})
+}
+
+// BenchmarkEncodedSignalProof measures the cost of proving a signal against the mock backend, which
+// answers instantly, so what's left is decoding, verifying and encoding the proof.
+func BenchmarkEncodedSignalProof(b *testing.B) {
+	p := newTestProver()
+	caller := &mock.Caller{}
+
+	out := log.StandardLogger().Out
+	log.SetOutput(io.Discard)
+
+	defer log.SetOutput(out)
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := p.EncodedSignalProof(
+			context.Background(),
+			caller,
+			SignalService{},
+			common.Address{},
+			[32]byte{0x1},
+			mock.Header.TxHash,
+		); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkEncodedSignalProof_latency proves a signal against nodes taking 10ms to answer each request. Fetching
+// the proof and the block concurrently takes it from ~30ms (eth_call, eth_getProof, eth_getBlockByNumber) to ~20ms.
+func BenchmarkEncodedSignalProof_latency(b *testing.B) {
+	p := newTestProver()
+	caller := &latencyCaller{
+		Caller: &mock.Caller{},
+		latency: map[string]time.Duration{
+			"eth_call":             10 * time.Millisecond,
+			"eth_getProof":         10 * time.Millisecond,
+			"eth_getBlockByNumber": 10 * time.Millisecond,
+		},
+	}
+
+	for i := 0; i < b.N; i++ {
+		if _, err := p.EncodedSignalProof(
+			context.Background(),
+			caller,
+			SignalService{},
+			common.Address{},
+			[32]byte{0x1},
+			mock.Header.TxHash,
+		); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package proof
+
+import (
+	"math/big"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrBlockNotFound is returned when the node doesn't know the block we want to prove against,
+	// usually because it hasn't caught up to it yet.
+	ErrBlockNotFound = errors.New("block not found")
+	// ErrStateRootPruned is returned when the node no longer has the state for the block
+	// we want to prove against, proving it requires an archive node.
+	ErrStateRootPruned = errors.New("state root pruned")
+	// ErrProofVerificationFailed is returned when the proof returned by the node
+	// does not prove the signal slot is set.
+	ErrProofVerificationFailed = errors.New("proof verification failed")
+	// ErrSignalNotSent is returned when the SignalService has no record of the signal being sent.
+	ErrSignalNotSent = errors.New("signal not sent")
+	// ErrSignalSlotMismatch is returned when the SignalService computes signal storage slots
+	// differently to us, meaning any proof we generate would be for the wrong slot.
+	ErrSignalSlotMismatch = errors.New("signal slot mismatch")
+	// ErrInvalidBlockTag is returned when asked to prove against a block tag other than
+	// BlockTagFinalized or BlockTagSafe.
+	ErrInvalidBlockTag = errors.New("invalid block tag")
+	// ErrMalformedProof is returned when a proof can't be decoded as an encoded SignalProof.
+	ErrMalformedProof = errors.New("malformed proof")
+	// ErrStateRootMismatch is returned when a proof was generated against a different state
+	// than the block it's being verified against.
+	ErrStateRootMismatch = errors.New("state root mismatch")
+	// ErrAccountProofInvalid is returned when the SignalService account can't be proven
+	// against the block's state root.
+	ErrAccountProofInvalid = errors.New("account proof invalid")
+	// ErrStorageProofInvalid is returned when a storage proof doesn't verify against
+	// the SignalService storage root.
+	ErrStorageProofInvalid = errors.New("storage proof invalid")
+	// ErrSignalNotSet is returned when a storage proof verifies, but proves the signal's
+	// slot isn't set.
+	ErrSignalNotSet = errors.New("signal not set")
+	// ErrReceiptNotFound is returned when the node has no receipt for a transaction we want to
+	// prove, usually because it hasn't been mined or the node hasn't caught up to it.
+	ErrReceiptNotFound = errors.New("receipt not found")
+	// ErrReceiptsRootMismatch is returned when the receipts the node returns for a block don't
+	// build the block's receipts root.
+	ErrReceiptsRootMismatch = errors.New("receipts root mismatch")
+	// ErrReceiptProofInvalid is returned when a receipt proof doesn't prove the receipt under
+	// the block's receipts root.
+	ErrReceiptProofInvalid = errors.New("receipt proof invalid")
+	// ErrSignalLogNotFound is returned when a transaction has no MessageSent log at the index
+	// whose signal we were asked to prove.
+	ErrSignalLogNotFound = errors.New("signal log not found")
+	// ErrSignalRootMismatch is returned when the signal root the destination chain synced for a
+	// block isn't the SignalService storage root a proof is against, meaning the proof is against
+	// the wrong block. It's retriable, proving against the next synced block may match.
+	ErrSignalRootMismatch = errors.New("signal root mismatch")
+	// ErrBlockNotFinalized is returned when proving only against finalized blocks, for a block
+	// newer than the finalized one. It's retriable, the block is finalized eventually.
+	ErrBlockNotFinalized = errors.New("block not finalized")
+	// ErrFinalizedBlockMismatch is returned when the execution node's block at the number the
+	// consensus client finalized has another hash, meaning the two don't follow the same chain.
+	ErrFinalizedBlockMismatch = errors.New("finalized block mismatch")
+	// ErrFinalizedTagUnsupported is returned when proving only against finalized blocks without a
+	// consensus client, and the execution node doesn't support the finalized tag.
+	ErrFinalizedTagUnsupported = errors.New("finalized tag unsupported")
+)
+
+// IsRetriable reports whether err is expected to resolve itself if proving is retried later.
+// ErrBlockNotFound and ErrReceiptNotFound are retriable since the node may simply be behind, the other proof
+// errors will fail the same way no matter how many times we retry.
+func IsRetriable(err error) bool {
+	switch {
+	case errors.Is(err, ErrBlockNotFound),
+		errors.Is(err, ErrReceiptNotFound),
+		errors.Is(err, ErrBlockNotFinalized):
+		return true
+	case errors.Is(err, ErrStateRootPruned),
+		errors.Is(err, ErrProofVerificationFailed),
+		errors.Is(err, ErrSignalNotSent),
+		errors.Is(err, ErrSignalSlotMismatch),
+		errors.Is(err, ErrInvalidBlockTag),
+		errors.Is(err, ErrMalformedProof),
+		errors.Is(err, ErrStateRootMismatch),
+		errors.Is(err, ErrAccountProofInvalid),
+		errors.Is(err, ErrStorageProofInvalid),
+		errors.Is(err, ErrSignalNotSet),
+		errors.Is(err, ErrReceiptsRootMismatch),
+		errors.Is(err, ErrReceiptProofInvalid),
+		errors.Is(err, ErrSignalLogNotFound),
+		errors.Is(err, ErrFinalizedBlockMismatch),
+		errors.Is(err, ErrFinalizedTagUnsupported):
+		return false
+	default:
+		return true
+	}
+}
+
+// wrapGetProofError maps a node's eth_getProof error for blockNumber onto our typed errors where possible
+func wrapGetProofError(err error, blockNumber *big.Int) error {
+	if relayer.IsStatePrunedError(err) {
+		return stateRootPrunedError(err.Error(), blockNumber)
+	}
+
+	return err
+}
+
+// stateRootPrunedError is ErrStateRootPruned with why we think the node's state for blockNumber
+// is pruned, and what's needed to prove against it.
+func stateRootPrunedError(reason string, blockNumber *big.Int) error {
+	return errors.Wrapf(
+		ErrStateRootPruned,
+		"%v: proving against block %v requires archive state, use an archive node",
+		reason,
+		blockNumber,
+	)
+}
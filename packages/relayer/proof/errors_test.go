@@ -0,0 +1,53 @@
+package proof
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_IsRetriable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"blockNotFound", errors.Wrap(ErrBlockNotFound, "p.blockHeader"), true},
+		{"stateRootPruned", errors.Wrap(ErrStateRootPruned, "c.CallContext"), false},
+		{"proofVerificationFailed", errors.Wrap(ErrProofVerificationFailed, "no storageProof returned"), false},
+		{"signalNotSent", ErrSignalNotSent, false},
+		{"storageProofInvalid", errors.Wrap(ErrStorageProofInvalid, "trie.VerifyProof"), false},
+		{"receiptNotFound", errors.Wrap(ErrReceiptNotFound, "p.transactionReceipt"), true},
+		{"receiptsRootMismatch", ErrReceiptsRootMismatch, false},
+		{"blockNotFinalized", errors.Wrap(ErrBlockNotFinalized, "p.checkFinalized"), true},
+		{"finalizedBlockMismatch", ErrFinalizedBlockMismatch, false},
+		{"unknown", errors.New("connection refused"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsRetriable(tt.err))
+		})
+	}
+}
+
+func Test_wrapGetProofError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantPruned bool
+	}{
+		{"missingTrieNode", errors.New("missing trie node 1dcc4de8 (path )"), true},
+		{"historicalStateUnavailable", errors.New("required historical state unavailable"), true},
+		{"other", errors.New("connection refused"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := wrapGetProofError(tt.err, big.NewInt(1))
+			assert.Equal(t, tt.wantPruned, errors.Is(err, ErrStateRootPruned))
+		})
+	}
+}
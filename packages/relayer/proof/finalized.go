@@ -0,0 +1,91 @@
+package proof
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+)
+
+// WithFinalizedOnly only proves against blocks the chain has finalized, by finality's finalized
+// block, checked against the node's block at its number, or by the node's finalized tag if finality
+// is nil. Proving against a later block fails with ErrBlockNotFinalized.
+func (p *Prover) WithFinalizedOnly(finality relayer.FinalityOracle) *Prover {
+	p.finalizedOnly = true
+	p.finality = finality
+
+	return p
+}
+
+// FinalizedOnly is whether only finalized blocks are proven against
+func (p *Prover) FinalizedOnly() bool {
+	return p.finalizedOnly
+}
+
+// FinalizedBlock is the latest block the chain caller is connected to has finalized, the ceiling of
+// the blocks proven against when FinalizedOnly.
+func (p *Prover) FinalizedBlock(ctx context.Context, caller relayer.Caller) (*relayer.FinalizedBlock, error) {
+	if p.finality == nil {
+		block, err := blockByNumber(ctx, caller, string(BlockTagFinalized))
+
+		var rpcErr rpc.Error
+		if err != nil && !errors.As(err, &rpcErr) {
+			return nil, err
+		}
+
+		if block == nil {
+			return nil, ErrFinalizedTagUnsupported
+		}
+
+		return &relayer.FinalizedBlock{Number: block.Number.ToInt().Uint64(), Hash: block.Hash}, nil
+	}
+
+	finalized, err := p.finality.FinalizedBlock(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "p.finality.FinalizedBlock")
+	}
+
+	block, err := blockByNumber(ctx, caller, hexutil.EncodeUint64(finalized.Number))
+	if err != nil {
+		return nil, err
+	}
+
+	// the node hasn't caught up to the consensus client yet
+	if block == nil {
+		return nil, errors.Wrapf(ErrBlockNotFound, "finalized number: %v", finalized.Number)
+	}
+
+	if block.Hash != finalized.Hash {
+		return nil, errors.Wrapf(
+			ErrFinalizedBlockMismatch,
+			"block %v is %v, the consensus client finalized %v",
+			finalized.Number,
+			block.Hash.Hex(),
+			finalized.Hash.Hex(),
+		)
+	}
+
+	return finalized, nil
+}
+
+// checkFinalized fails with ErrBlockNotFinalized if only finalized blocks are proven against, and
+// blockNumber isn't yet.
+func (p *Prover) checkFinalized(ctx context.Context, caller relayer.Caller, blockNumber *big.Int) error {
+	if !p.finalizedOnly {
+		return nil
+	}
+
+	finalized, err := p.FinalizedBlock(ctx, caller)
+	if err != nil {
+		return errors.Wrap(err, "p.FinalizedBlock")
+	}
+
+	if blockNumber.Uint64() > finalized.Number {
+		return errors.Wrapf(ErrBlockNotFinalized, "block %v, finalized %v", blockNumber, finalized.Number)
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package proof
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+// finalityOracle finalizes block, or fails if it's nil
+type finalityOracle struct {
+	block *relayer.FinalizedBlock
+}
+
+func (o *finalityOracle) FinalizedBlock(ctx context.Context) (*relayer.FinalizedBlock, error) {
+	if o.block == nil {
+		return nil, errors.New("beacon API responded 503")
+	}
+
+	return o.block, nil
+}
+
+// mockBlock is the block the mock caller answers eth_getBlockByNumber for number with
+func mockBlock(number uint64) *relayer.FinalizedBlock {
+	return &relayer.FinalizedBlock{
+		Number: number,
+		Hash:   crypto.Keccak256Hash([]byte(hexutil.EncodeUint64(number))),
+	}
+}
+
+func Test_FinalizedBlock(t *testing.T) {
+	tests := []struct {
+		name     string
+		caller   *mock.Caller
+		finality relayer.FinalityOracle
+		want     *relayer.FinalizedBlock
+		wantErr  error
+	}{
+		{
+			"finalizedTag",
+			&mock.Caller{},
+			nil,
+			mockBlock(mock.FinalizedBlockNumber),
+			nil,
+		},
+		{
+			"finalizedTagUnsupported",
+			&mock.Caller{BlockTagUnsupported: true},
+			nil,
+			nil,
+			ErrFinalizedTagUnsupported,
+		},
+		{
+			"consensusClient",
+			&mock.Caller{BlockTagUnsupported: true},
+			&finalityOracle{block: mockBlock(50)},
+			mockBlock(50),
+			nil,
+		},
+		{
+			"consensusClientOnAnotherChain",
+			&mock.Caller{},
+			&finalityOracle{block: &relayer.FinalizedBlock{Number: 50, Hash: common.HexToHash("0x1")}},
+			nil,
+			ErrFinalizedBlockMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestProver().WithFinalizedOnly(tt.finality)
+
+			block, err := p.FinalizedBlock(context.Background(), tt.caller)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, block)
+		})
+	}
+}
+
+func Test_FinalizedBlock_consensusClientFails(t *testing.T) {
+	p := newTestProver().WithFinalizedOnly(&finalityOracle{})
+
+	_, err := p.FinalizedBlock(context.Background(), &mock.Caller{})
+	assert.ErrorContains(t, err, "beacon API responded 503")
+}
+
+func Test_checkFinalized(t *testing.T) {
+	p := newTestProver()
+
+	// anything goes unless only finalized blocks are proven against
+	assert.Nil(t, p.checkFinalized(context.Background(), &mock.Caller{}, big.NewInt(1000)))
+
+	p = p.WithFinalizedOnly(&finalityOracle{block: mockBlock(50)})
+	assert.True(t, p.FinalizedOnly())
+
+	assert.Nil(t, p.checkFinalized(context.Background(), &mock.Caller{}, big.NewInt(50)))
+	assert.ErrorIs(t, p.checkFinalized(context.Background(), &mock.Caller{}, big.NewInt(51)), ErrBlockNotFinalized)
+}
+
+func Test_EncodedSignalProofAtTag_finalizedOnly(t *testing.T) {
+	p := newTestProver().WithFinalizedOnly(&finalityOracle{block: mockBlock(50)})
+
+	// the node's finalized block is past the consensus client's
+	_, err := p.EncodedSignalProofAtTag(
+		context.Background(),
+		&mock.Caller{},
+		SignalService{},
+		common.Address{},
+		[32]byte{0x1},
+		EncodedSignalProofAtTagOpts{Tag: BlockTagFinalized},
+	)
+	assert.ErrorIs(t, err, ErrBlockNotFinalized)
+}
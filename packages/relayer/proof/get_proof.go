@@ -0,0 +1,145 @@
+package proof
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+// getProof calls eth_getProof for address's storage at keys, as of blockNumber. A null result,
+// as some pruned nodes answer with, decodes to an empty proof.
+func getProof(
+	ctx context.Context,
+	c relayer.Caller,
+	address common.Address,
+	keys []string,
+	blockNumber *big.Int,
+) (*StorageProof, error) {
+	var raw json.RawMessage
+
+	if err := c.CallContext(ctx, &raw, "eth_getProof", address, keys, hexutil.EncodeBig(blockNumber)); err != nil {
+		return nil, err
+	}
+
+	return decodeProof(raw)
+}
+
+// decodeProof decodes an eth_getProof result, whichever client it came from. Geth and Erigon
+// differ for empty accounts and slots: Erigon can answer with null or "0x" where Geth answers
+// with "0x0", the empty code and storage hashes, or empty proof arrays.
+func decodeProof(raw json.RawMessage) (*StorageProof, error) {
+	// most answers are already Geth shaped, and decode without normalizing them first
+	var direct StorageProof
+	if err := json.Unmarshal(raw, &direct); err == nil && isNormalized(&direct) {
+		return &direct, nil
+	}
+
+	var fields map[string]interface{}
+
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, errors.Wrap(err, "json.Unmarshal")
+	}
+
+	var ethProof StorageProof
+
+	if fields == nil {
+		return &ethProof, nil
+	}
+
+	normalizeProof(fields)
+
+	normalized, err := json.Marshal(fields)
+	if err != nil {
+		return nil, errors.Wrap(err, "json.Marshal")
+	}
+
+	if err := json.Unmarshal(normalized, &ethProof); err != nil {
+		return nil, errors.Wrap(err, "json.Unmarshal")
+	}
+
+	return &ethProof, nil
+}
+
+// isNormalized is whether ethProof has every field normalizeProof would otherwise fill in. Empty
+// values that don't decode are caught by decoding failing.
+func isNormalized(ethProof *StorageProof) bool {
+	if ethProof.Balance == nil ||
+		ethProof.CodeHash == (common.Hash{}) ||
+		ethProof.StorageHash == (common.Hash{}) ||
+		ethProof.AccountProof == nil ||
+		ethProof.StorageProof == nil {
+		return false
+	}
+
+	for _, sp := range ethProof.StorageProof {
+		if sp.Value == nil || sp.Proof == nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// normalizeProof rewrites the fields of an eth_getProof result the way Geth answers them
+func normalizeProof(fields map[string]interface{}) {
+	for _, k := range []string{"balance", "nonce"} {
+		if isEmptyValue(fields[k]) {
+			fields[k] = "0x0"
+		}
+	}
+
+	if isEmptyValue(fields["codeHash"]) {
+		fields["codeHash"] = types.EmptyCodeHash.Hex()
+	}
+
+	if isEmptyValue(fields["storageHash"]) {
+		fields["storageHash"] = types.EmptyRootHash.Hex()
+	}
+
+	if isEmptyValue(fields["stateRoot"]) {
+		delete(fields, "stateRoot")
+	}
+
+	if fields["accountProof"] == nil {
+		fields["accountProof"] = []interface{}{}
+	}
+
+	storageProofs, _ := fields["storageProof"].([]interface{})
+	if storageProofs == nil {
+		storageProofs = []interface{}{}
+	}
+
+	for _, sp := range storageProofs {
+		result, ok := sp.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if isEmptyValue(result["value"]) {
+			result["value"] = "0x0"
+		}
+
+		if result["proof"] == nil {
+			result["proof"] = []interface{}{}
+		}
+	}
+
+	fields["storageProof"] = storageProofs
+}
+
+// isEmptyValue is whether v is how some client answers with an empty value: null, "" or "0x"
+func isEmptyValue(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+
+	s, ok := v.(string)
+
+	return ok && (s == "" || s == "0x")
+}
@@ -0,0 +1,125 @@
+package proof
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	// fixtureStateRoot is the state root the signal_sent fixtures' account proofs resolve against
+	fixtureStateRoot     = common.HexToHash("0x5b2a4859a8be7c6398f53340ad71d783ec70d44a434547ef55edb843f486cbc4")
+	fixtureSignalService = common.HexToAddress("0x1000777700000000000000000000000000000007")
+	fixtureKey           = "7d5b2fda7b5e2a0d34b4c1d3e1c5a4d7c2f2fd0b7a1bc2b0e4f6a3f4f8e9d1c2"
+)
+
+// readProofFixture reads an eth_getProof result from testdata/eth_getProof, in the shape the
+// named client answers with
+func readProofFixture(t *testing.T, name string) []byte {
+	b, err := os.ReadFile(filepath.Join("testdata", "eth_getProof", name+".json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b
+}
+
+func Test_decodeProof(t *testing.T) {
+	for _, fixture := range []string{"signal_sent", "empty_slot"} {
+		t.Run(fixture, func(t *testing.T) {
+			geth, err := decodeProof(readProofFixture(t, "geth_"+fixture))
+			assert.Nil(t, err)
+
+			erigon, err := decodeProof(readProofFixture(t, "erigon_"+fixture))
+			assert.Nil(t, err)
+
+			assert.Equal(t, geth, erigon)
+		})
+	}
+}
+
+// Test_isNormalized checks Geth's answers take the fast path, and Erigon's empty values don't
+func Test_isNormalized(t *testing.T) {
+	for _, fixture := range []string{"signal_sent", "empty_slot", "empty_account"} {
+		t.Run(fixture, func(t *testing.T) {
+			var geth StorageProof
+
+			assert.Nil(t, json.Unmarshal(readProofFixture(t, "geth_"+fixture), &geth))
+			assert.True(t, isNormalized(&geth))
+		})
+	}
+
+	var erigon StorageProof
+
+	err := json.Unmarshal(readProofFixture(t, "erigon_empty_account"), &erigon)
+	assert.False(t, err == nil && isNormalized(&erigon))
+}
+
+func Test_decodeProof_emptyAccount(t *testing.T) {
+	for _, client := range []string{"geth", "erigon"} {
+		t.Run(client, func(t *testing.T) {
+			ethProof, err := decodeProof(readProofFixture(t, client+"_empty_account"))
+			assert.Nil(t, err)
+
+			assert.Equal(t, types.EmptyCodeHash, ethProof.CodeHash)
+			assert.Equal(t, types.EmptyRootHash, ethProof.StorageHash)
+			assert.Equal(t, uint64(0), uint64(ethProof.Nonce))
+			assert.Equal(t, int64(0), ethProof.Balance.ToInt().Int64())
+		})
+	}
+}
+
+func Test_decodeProof_null(t *testing.T) {
+	ethProof, err := decodeProof([]byte("null"))
+	assert.Nil(t, err)
+	assert.Equal(t, &StorageProof{}, ethProof)
+}
+
+func Test_encodedStorageProof_clients(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+		wantErr error
+	}{
+		{"signalSent", "signal_sent", nil},
+		{"emptySlot", "empty_slot", ErrProofVerificationFailed},
+		{"emptyAccount", "empty_account", ErrProofVerificationFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var encoded [][]byte
+
+			for _, client := range []string{"geth", "erigon"} {
+				p := newTestProver()
+
+				caller := &mock.Caller{
+					ProofResponse: readProofFixture(t, client+"_"+tt.fixture),
+					StateRoot:     fixtureStateRoot,
+				}
+
+				proof, err := p.encodedStorageProof(context.Background(), caller, fixtureSignalService, fixtureKey, 1)
+				if tt.wantErr != nil {
+					assert.True(t, errors.Is(err, tt.wantErr), client)
+					continue
+				}
+
+				assert.Nil(t, err, client)
+
+				encoded = append(encoded, proof)
+			}
+
+			if tt.wantErr == nil {
+				assert.Equal(t, encoded[0], encoded[1])
+			}
+		})
+	}
+}
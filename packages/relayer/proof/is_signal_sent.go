@@ -0,0 +1,71 @@
+package proof
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+// nolint: lll
+const signalServiceABIJSON = `[{"inputs":[{"internalType":"address","name":"app","type":"address"},{"internalType":"bytes32","name":"signal","type":"bytes32"}],"name":"isSignalSent","outputs":[{"internalType":"bool","name":"","type":"bool"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"app","type":"address"},{"internalType":"bytes32","name":"signal","type":"bytes32"}],"name":"getSignalSlot","outputs":[{"internalType":"bytes32","name":"signalSlot","type":"bytes32"}],"stateMutability":"pure","type":"function"}]`
+
+var signalServiceABI = mustParseABI(signalServiceABIJSON)
+
+func mustParseABI(s string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(s))
+	if err != nil {
+		panic(err)
+	}
+
+	return parsed
+}
+
+// IsSignalSent calls `isSignalSent(app, signal)` on the source chain's SignalService
+// at the given block, so we don't bother generating a proof for a signal
+// that was never stored, and to diagnose messages stuck on one.
+func (p *Prover) IsSignalSent(
+	ctx context.Context,
+	c relayer.Caller,
+	signalServiceAddress common.Address,
+	app common.Address,
+	signal [32]byte,
+	blockNumber *big.Int,
+) (bool, error) {
+	data, err := signalServiceABI.Pack("isSignalSent", app, signal)
+	if err != nil {
+		return false, errors.Wrap(err, "signalServiceABI.Pack")
+	}
+
+	var result hexutil.Bytes
+
+	err = c.CallContext(ctx,
+		&result,
+		"eth_call",
+		map[string]interface{}{
+			"to":   signalServiceAddress,
+			"data": hexutil.Bytes(data),
+		},
+		hexutil.EncodeBig(blockNumber),
+	)
+	if err != nil {
+		return false, errors.Wrap(err, "c.CallContext")
+	}
+
+	out, err := signalServiceABI.Unpack("isSignalSent", result)
+	if err != nil {
+		return false, errors.Wrap(err, "signalServiceABI.Unpack")
+	}
+
+	sent, ok := out[0].(bool)
+	if !ok {
+		return false, errors.New("unexpected isSignalSent return type")
+	}
+
+	return sent, nil
+}
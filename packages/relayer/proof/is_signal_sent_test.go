@@ -0,0 +1,47 @@
+package proof
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_IsSignalSent(t *testing.T) {
+	tests := []struct {
+		name     string
+		signal   [32]byte
+		wantSent bool
+	}{
+		{
+			"sent",
+			[32]byte{0x1},
+			true,
+		},
+		{
+			"notSent",
+			mock.NotSentSignal,
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestProver()
+
+			sent, err := p.IsSignalSent(
+				context.Background(),
+				&mock.Caller{},
+				common.Address{},
+				common.Address{},
+				tt.signal,
+				big.NewInt(1),
+			)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantSent, sent)
+		})
+	}
+}
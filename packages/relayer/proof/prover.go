@@ -2,12 +2,12 @@ package proof
 
 import (
 	"context"
-	"github.com/ethereum/go-ethereum/rpc"
 	"math/big"
 
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
 )
 
 type blocker interface {
@@ -15,10 +15,17 @@ type blocker interface {
 }
 type Prover struct {
 	blocker   blocker
-	rpcClient *rpc.Client
+	rpcClient relayer.Caller
+	// workers is a semaphore with a slot held for each proof being generated, nil if unbounded
+	workers chan struct{}
+	// finalizedOnly only proves against blocks finalizedBlock is at or past
+	finalizedOnly bool
+	// finality is where the finalized block is read from when finalizedOnly, the node's finalized
+	// tag if nil
+	finality relayer.FinalityOracle
 }
 
-func New(blocker blocker, client *rpc.Client) (*Prover, error) {
+func New(blocker blocker, client relayer.Caller) (*Prover, error) {
 	if blocker == nil {
 		return nil, relayer.ErrNoEthClient
 	}
@@ -29,6 +36,19 @@ func New(blocker blocker, client *rpc.Client) (*Prover, error) {
 	}, nil
 }
 
+// WithMaxConcurrentProofs bounds how many proofs generate at once, the rest queue until
+// one finishes, so a backlog of messages doesn't open a request per message against the node.
+// n <= 0 leaves it unbounded.
+func (p *Prover) WithMaxConcurrentProofs(n int) *Prover {
+	if n <= 0 {
+		p.workers = nil
+	} else {
+		p.workers = make(chan struct{}, n)
+	}
+
+	return p
+}
+
 func (p *Prover) BlockNumberByHash(ctx context.Context, hash common.Hash) (*big.Int, error) {
 	type Block struct {
 		Number string `json:"number"`
@@ -39,6 +59,11 @@ func (p *Prover) BlockNumberByHash(ctx context.Context, hash common.Hash) (*big.
 	if err != nil {
 		return nil, err
 	}
+
+	// a null result unmarshals into an empty block
+	if len(block.Number) < 2 {
+		return nil, errors.Wrapf(ErrBlockNotFound, "hash: %v", hash.Hex())
+	}
 	blockNumber := new(big.Int)
 	blockNumber.SetString(block.Number[2:], 16)
 	return blockNumber, nil
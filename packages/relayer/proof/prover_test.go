@@ -12,7 +12,8 @@ import (
 
 func newTestProver() *Prover {
 	return &Prover{
-		blocker: &mock.Blocker{},
+		blocker:   &mock.Blocker{},
+		rpcClient: &mock.Caller{},
 	}
 }
 
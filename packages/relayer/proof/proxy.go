@@ -0,0 +1,27 @@
+package proof
+
+import (
+	"context"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+// ImplementationSlot is the EIP-1967 storage slot upgradeable proxies keep their implementation's
+// address in
+var ImplementationSlot = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+
+// Implementation returns the implementation behind address if it's an EIP-1967 proxy, or the zero
+// address if it isn't one. A proxy's state, signals included, is in the proxy's own storage, so
+// proofs are always of the proxy, never the implementation.
+func Implementation(ctx context.Context, c relayer.Caller, address common.Address) (common.Address, error) {
+	var slot hexutil.Bytes
+
+	if err := c.CallContext(ctx, &slot, "eth_getStorageAt", address, ImplementationSlot, "latest"); err != nil {
+		return common.Address{}, errors.Wrap(err, "c.CallContext")
+	}
+
+	return common.BytesToAddress(slot), nil
+}
@@ -0,0 +1,56 @@
+package proof
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	signalServiceProxy          = common.HexToAddress("0x1000777700000000000000000000000000000007")
+	signalServiceImplementation = common.HexToAddress("0x2000777700000000000000000000000000000007")
+)
+
+func Test_Implementation(t *testing.T) {
+	caller := &mock.Caller{
+		Implementations: map[common.Address]common.Address{signalServiceProxy: signalServiceImplementation},
+	}
+
+	implementation, err := Implementation(context.Background(), caller, signalServiceProxy)
+	assert.Nil(t, err)
+	assert.Equal(t, signalServiceImplementation, implementation)
+
+	implementation, err = Implementation(context.Background(), caller, signalServiceImplementation)
+	assert.Nil(t, err)
+	assert.Equal(t, common.Address{}, implementation)
+}
+
+func Test_EncodedSignalProof_proxy(t *testing.T) {
+	app := common.HexToAddress("0x63FaC9201494f0bd17B9892B9fae4d52fe3BD377")
+	signal := [32]byte{0x1}
+
+	p := newTestProver()
+	caller := &mock.Caller{
+		Implementations: map[common.Address]common.Address{signalServiceProxy: signalServiceImplementation},
+	}
+
+	signalService := SignalService{
+		Address:        signalServiceProxy,
+		Implementation: signalServiceImplementation,
+		Version:        SignalServiceV1,
+	}
+
+	_, err := p.EncodedSignalProof(context.Background(), caller, signalService, app, signal, mock.Header.TxHash)
+	assert.Nil(t, err)
+
+	// the signal is in the proxy's storage, so it's the proxy's slot that's proven, and verifies
+	slot := SignalSlot(app, signal)
+
+	assert.Equal(t, []mock.ProofRequest{{
+		Address: signalServiceProxy,
+		Key:     common.Bytes2Hex(slot[:]),
+	}}, caller.ProofRequests())
+}
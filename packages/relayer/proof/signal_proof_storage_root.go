@@ -0,0 +1,33 @@
+package proof
+
+import (
+	"math/big"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/encoding"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+)
+
+// SignalProofStorageRoot decodes an encoded SignalProof, returning the block it's for and the
+// SignalService storage root its storage proof is against, the hash of the proof's root node.
+// EncodedSignalProof only returns proofs whose storage root the SignalService's account proof
+// resolves to at that block.
+func SignalProofStorageRoot(encodedProof []byte) (*big.Int, common.Hash, error) {
+	signalProof, err := encoding.DecodeSignalProof(encodedProof)
+	if err != nil {
+		return nil, common.Hash{}, errors.Wrap(ErrMalformedProof, err.Error())
+	}
+
+	var storageProof [][]byte
+	if err := rlp.DecodeBytes(signalProof.Proof, &storageProof); err != nil {
+		return nil, common.Hash{}, errors.Wrapf(ErrMalformedProof, "storage proof is not rlp encoded nodes: %v", err)
+	}
+
+	if len(storageProof) == 0 {
+		return nil, common.Hash{}, errors.Wrap(ErrMalformedProof, "storage proof has no nodes")
+	}
+
+	return signalProof.Height, crypto.Keccak256Hash(storageProof[0]), nil
+}
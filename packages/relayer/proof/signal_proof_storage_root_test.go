@@ -0,0 +1,23 @@
+package proof
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SignalProofStorageRoot(t *testing.T) {
+	c := newStateCaller(t, verifySignal)
+
+	height, storageRoot, err := SignalProofStorageRoot(c.encodedProof(t, verifySignal, verifyHeight))
+	assert.Nil(t, err)
+	assert.Equal(t, verifyHeight, height)
+	assert.Equal(t, c.storageTrie.Hash(), storageRoot)
+
+	_, _, err = SignalProofStorageRoot(encodeProof(t, proofList{}, verifyHeight))
+	assert.True(t, errors.Is(err, ErrMalformedProof))
+
+	_, _, err = SignalProofStorageRoot([]byte{0x1})
+	assert.True(t, errors.Is(err, ErrMalformedProof))
+}
@@ -0,0 +1,190 @@
+package proof
+
+import (
+	"context"
+	"encoding/binary"
+	"math/big"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+// nolint: lll
+const (
+	signalServiceV2ABIJSON   = `[{"inputs":[{"internalType":"uint64","name":"chainId","type":"uint64"},{"internalType":"address","name":"app","type":"address"},{"internalType":"bytes32","name":"signal","type":"bytes32"}],"name":"getSignalSlot","outputs":[{"internalType":"bytes32","name":"signalSlot","type":"bytes32"}],"stateMutability":"pure","type":"function"}]`
+	addressResolverABIJSON   = `[{"inputs":[{"internalType":"bytes32","name":"name","type":"bytes32"},{"internalType":"bool","name":"allowZeroAddress","type":"bool"}],"name":"resolve","outputs":[{"internalType":"address payable","name":"","type":"address"}],"stateMutability":"view","type":"function"}]`
+	signalServiceResolveName = "signal_service"
+)
+
+var (
+	signalServiceV2ABI = mustParseABI(signalServiceV2ABIJSON)
+	addressResolverABI = mustParseABI(addressResolverABIJSON)
+)
+
+// SignalServiceVersion is a SignalService deployment's storage layout, which decides the slot
+// a signal is stored at.
+type SignalServiceVersion int
+
+var (
+	// SignalServiceV1 stores signals at keccak256(app, signal).
+	SignalServiceV1 SignalServiceVersion = 1
+	// SignalServiceV2 namespaces signals by chain, at keccak256("SIGNAL", chainId, app, signal).
+	SignalServiceV2 SignalServiceVersion = 2
+)
+
+// SignalService is a deployment to prove signals against. During a migration the old and new
+// deployments run side by side, so which one a message's signal is in depends on the message.
+type SignalService struct {
+	// Address is the SignalService signals are sent with, which is the proxy if it's behind one,
+	// since signals are in its storage. Storage and account proofs are always of Address.
+	Address common.Address
+	// Implementation is the contract behind Address if it's an EIP-1967 proxy, only for logging
+	Implementation common.Address
+	// Version defaults to SignalServiceV1 when unset.
+	Version SignalServiceVersion
+	// ChainID is the chain the SignalService is deployed on, which SignalServiceV2 slots include.
+	ChainID uint64
+}
+
+// Slot is the storage slot `signal` sent by `app` is stored at in this deployment.
+func (s SignalService) Slot(app common.Address, signal [32]byte) [32]byte {
+	if s.Version == SignalServiceV2 {
+		return SignalSlotV2(s.ChainID, app, signal)
+	}
+
+	return SignalSlot(app, signal)
+}
+
+// SignalSlotV2 is the SignalServiceV2 storage slot `signal` sent by `app` on chainID is stored at.
+func SignalSlotV2(chainID uint64, app common.Address, signal [32]byte) [32]byte {
+	var id [8]byte
+
+	binary.BigEndian.PutUint64(id[:], chainID)
+
+	return crypto.Keccak256Hash([]byte("SIGNAL"), id[:], app.Bytes(), signal[:])
+}
+
+// ResolveSignalService asks app, the contract that sent a signal, which SignalService it sends
+// signals with at blockNumber, i.e. the source bridge at the block a message was sent in.
+func ResolveSignalService(
+	ctx context.Context,
+	c relayer.Caller,
+	app common.Address,
+	blockNumber *big.Int,
+) (common.Address, error) {
+	var name [32]byte
+
+	copy(name[:], signalServiceResolveName)
+
+	data, err := addressResolverABI.Pack("resolve", name, false)
+	if err != nil {
+		return common.Address{}, errors.Wrap(err, "addressResolverABI.Pack")
+	}
+
+	var result hexutil.Bytes
+
+	err = c.CallContext(ctx,
+		&result,
+		"eth_call",
+		map[string]interface{}{
+			"to":   app,
+			"data": hexutil.Bytes(data),
+		},
+		hexutil.EncodeBig(blockNumber),
+	)
+	if err != nil {
+		return common.Address{}, errors.Wrap(err, "c.CallContext")
+	}
+
+	out, err := addressResolverABI.Unpack("resolve", result)
+	if err != nil {
+		return common.Address{}, errors.Wrap(err, "addressResolverABI.Unpack")
+	}
+
+	addr, ok := out[0].(common.Address)
+	if !ok {
+		return common.Address{}, errors.New("unexpected resolve return type")
+	}
+
+	return addr, nil
+}
+
+// DetectSignalServiceVersion asks the SignalService at address for a probe signal's slot with each
+// version's `getSignalSlot`, and returns the version whose slot matches ours. It returns
+// ErrSignalSlotMismatch if neither does, since any proof we generated would be for the wrong slot.
+func DetectSignalServiceVersion(
+	ctx context.Context,
+	c relayer.Caller,
+	address common.Address,
+	chainID uint64,
+) (SignalServiceVersion, error) {
+	onChain, v1Err := signalSlotOnChain(ctx, c, address, signalServiceABI, probeApp, probeSignal)
+	if v1Err == nil && onChain == SignalSlot(probeApp, probeSignal) {
+		return SignalServiceV1, nil
+	}
+
+	// a SignalServiceV2 doesn't have the V1 getSignalSlot, so the call above reverts
+	onChainV2, v2Err := signalSlotOnChain(ctx, c, address, signalServiceV2ABI, chainID, probeApp, probeSignal)
+	if v2Err == nil && onChainV2 == SignalSlotV2(chainID, probeApp, probeSignal) {
+		return SignalServiceV2, nil
+	}
+
+	if v1Err != nil && v2Err != nil {
+		return 0, errors.Wrapf(v2Err, "SignalService %v getSignalSlot", address.Hex())
+	}
+
+	if v1Err != nil {
+		onChain = onChainV2
+	}
+
+	return 0, errors.Wrapf(
+		ErrSignalSlotMismatch,
+		"SignalService %v getSignalSlot returned %v, which matches no layout we know. was the contract upgraded?",
+		address.Hex(),
+		common.Hash(onChain).Hex(),
+	)
+}
+
+func signalSlotOnChain(
+	ctx context.Context,
+	c relayer.Caller,
+	address common.Address,
+	contractABI abi.ABI,
+	args ...interface{},
+) ([32]byte, error) {
+	data, err := contractABI.Pack("getSignalSlot", args...)
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "contractABI.Pack")
+	}
+
+	var result hexutil.Bytes
+
+	err = c.CallContext(ctx,
+		&result,
+		"eth_call",
+		map[string]interface{}{
+			"to":   address,
+			"data": hexutil.Bytes(data),
+		},
+		"latest",
+	)
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "c.CallContext")
+	}
+
+	out, err := contractABI.Unpack("getSignalSlot", result)
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "contractABI.Unpack")
+	}
+
+	slot, ok := out[0].([32]byte)
+	if !ok {
+		return [32]byte{}, errors.New("unexpected getSignalSlot return type")
+	}
+
+	return slot, nil
+}
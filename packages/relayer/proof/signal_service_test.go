@@ -0,0 +1,112 @@
+package proof
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	v1SignalService = common.HexToAddress("0x1B80c7bCCEF6D6BEfB62Db052b3E3D782c566a4c")
+	v2SignalService = common.HexToAddress("0x1000777700000000000000000000000000000007")
+	oldBridge       = common.HexToAddress("0xBb7a150E1247Da7B4c339f0997Bb18A4038147Af")
+	newBridge       = common.HexToAddress("0x1000777700000000000000000000000000000004")
+)
+
+func Test_SignalService_Slot(t *testing.T) {
+	app := common.HexToAddress("0x63FaC9201494f0bd17B9892B9fae4d52fe3BD377")
+	signal := [32]byte{0x1}
+
+	// unset is V1, so callers that only know an address keep proving the same slot
+	assert.Equal(t, SignalSlot(app, signal), SignalService{}.Slot(app, signal))
+	assert.Equal(t, SignalSlot(app, signal), SignalService{Version: SignalServiceV1}.Slot(app, signal))
+
+	v2 := SignalService{Version: SignalServiceV2, ChainID: 5}.Slot(app, signal)
+	assert.Equal(t, SignalSlotV2(5, app, signal), v2)
+	assert.NotEqual(t, SignalSlot(app, signal), v2)
+
+	// abi.encodePacked("SIGNAL", uint64(5), app, signal)
+	packed := append([]byte("SIGNAL"), 0, 0, 0, 0, 0, 0, 0, 5)
+	packed = append(packed, app.Bytes()...)
+	packed = append(packed, signal[:]...)
+	assert.Equal(t, crypto.Keccak256Hash(packed), common.Hash(v2))
+
+	// slots are namespaced by chain
+	assert.NotEqual(t, v2, SignalSlotV2(6, app, signal))
+}
+
+func Test_DetectSignalServiceVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		caller  *mock.Caller
+		address common.Address
+		want    SignalServiceVersion
+		wantErr error
+	}{
+		{
+			"v1",
+			&mock.Caller{V2SignalServices: []common.Address{v2SignalService}},
+			v1SignalService,
+			SignalServiceV1,
+			nil,
+		},
+		{
+			"v2",
+			&mock.Caller{V2SignalServices: []common.Address{v2SignalService}},
+			v2SignalService,
+			SignalServiceV2,
+			nil,
+		},
+		{
+			"layoutChanged",
+			&mock.Caller{WrongSignalSlot: true},
+			v1SignalService,
+			0,
+			ErrSignalSlotMismatch,
+		},
+		{
+			"layoutChangedV2",
+			&mock.Caller{WrongSignalSlot: true, V2SignalServices: []common.Address{v2SignalService}},
+			v2SignalService,
+			0,
+			ErrSignalSlotMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectSignalServiceVersion(context.Background(), tt.caller, tt.address, mock.MockChainID.Uint64())
+			assert.Equal(t, tt.want, got)
+
+			if tt.wantErr == nil {
+				assert.Nil(t, err)
+			} else {
+				assert.True(t, errors.Is(err, tt.wantErr))
+			}
+		})
+	}
+}
+
+func Test_ResolveSignalService(t *testing.T) {
+	caller := &mock.Caller{SignalServices: map[common.Address]common.Address{
+		oldBridge: v1SignalService,
+		newBridge: v2SignalService,
+	}}
+
+	got, err := ResolveSignalService(context.Background(), caller, oldBridge, big.NewInt(1))
+	assert.Nil(t, err)
+	assert.Equal(t, v1SignalService, got)
+
+	got, err = ResolveSignalService(context.Background(), caller, newBridge, big.NewInt(1))
+	assert.Nil(t, err)
+	assert.Equal(t, v2SignalService, got)
+
+	_, err = ResolveSignalService(context.Background(), caller, common.Address{}, big.NewInt(1))
+	assert.NotNil(t, err)
+}
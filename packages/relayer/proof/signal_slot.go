@@ -0,0 +1,39 @@
+package proof
+
+import (
+	"context"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+var (
+	// probeApp and probeSignal are arbitrary, getSignalSlot is pure so they don't need to have been sent.
+	probeApp    = common.HexToAddress("0x0000000000000000000000000000000000000001")
+	probeSignal = [32]byte{0x1}
+)
+
+// SignalSlot is the SignalServiceV1 storage slot `signal` sent by `app` is stored at,
+// which is what we request a storage proof for.
+func SignalSlot(app common.Address, signal [32]byte) [32]byte {
+	return crypto.Keccak256Hash(app.Bytes(), signal[:])
+}
+
+// VerifySignalSlotLayout checks the SignalService computes signal slots with a layout we know,
+// SignalServiceV1 or SignalServiceV2. If the contract is upgraded with a different storage
+// layout, every proof we generate would be for the wrong slot, so this should be checked
+// on startup. It returns ErrSignalSlotMismatch if no layout matches.
+func VerifySignalSlotLayout(
+	ctx context.Context,
+	c relayer.Caller,
+	signalServiceAddress common.Address,
+	chainID uint64,
+) error {
+	if _, err := DetectSignalServiceVersion(ctx, c, signalServiceAddress, chainID); err != nil {
+		return errors.Wrap(err, "DetectSignalServiceVersion")
+	}
+
+	return nil
+}
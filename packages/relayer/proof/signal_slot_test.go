@@ -0,0 +1,46 @@
+package proof
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_VerifySignalSlotLayout(t *testing.T) {
+	tests := []struct {
+		name    string
+		caller  *mock.Caller
+		wantErr error
+	}{
+		{
+			"matches",
+			&mock.Caller{},
+			nil,
+		},
+		{
+			"matchesV2",
+			&mock.Caller{V2SignalServices: []common.Address{{}}},
+			nil,
+		},
+		{
+			"layoutChanged",
+			&mock.Caller{WrongSignalSlot: true},
+			ErrSignalSlotMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifySignalSlotLayout(context.Background(), tt.caller, common.Address{}, mock.MockChainID.Uint64())
+			if tt.wantErr == nil {
+				assert.Nil(t, err)
+			} else {
+				assert.True(t, errors.Is(err, tt.wantErr))
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package proof
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/encoding"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/pkg/errors"
+)
+
+type VerifySignalProofOpts struct {
+	SignalServiceAddress common.Address
+	// SignalServiceVersion is the SignalService's storage layout, V1 if unset.
+	SignalServiceVersion SignalServiceVersion
+	// ChainID is the source chain's ID, V2 SignalServices key slots by it.
+	ChainID uint64
+	App     common.Address
+	Signal  [32]byte
+	// EncodedProof is an encoded SignalProof, as generated by EncodedSignalProof.
+	EncodedProof []byte
+	// BlockNumber is the block to verify against, defaults to the height in the proof.
+	BlockNumber *big.Int
+}
+
+// VerifyStep is a check VerifySignalProof passed, and what it found.
+type VerifyStep struct {
+	Name   string
+	Detail string
+}
+
+// VerifySignalProof checks an encoded SignalProof proves `signal` was sent by `app`, the same
+// way LibBridgeSignal does on chain, to diagnose proofs that fail to process. It returns the
+// steps that passed, and the error from the step that didn't, which wraps one of
+// ErrMalformedProof, ErrBlockNotFound, ErrStateRootMismatch, ErrAccountProofInvalid,
+// ErrStorageProofInvalid or ErrSignalNotSet.
+//
+// Only the state root and the SignalService account proof come from the node,
+// the storage proof is verified locally.
+func VerifySignalProof(
+	ctx context.Context,
+	c relayer.Caller,
+	opts VerifySignalProofOpts,
+) ([]VerifyStep, error) {
+	steps := make([]VerifyStep, 0)
+
+	signalProof, err := encoding.DecodeSignalProof(opts.EncodedProof)
+	if err != nil {
+		return steps, errors.Wrap(ErrMalformedProof, err.Error())
+	}
+
+	var storageProof [][]byte
+	if err := rlp.DecodeBytes(signalProof.Proof, &storageProof); err != nil {
+		return steps, errors.Wrapf(ErrMalformedProof, "storage proof is not rlp encoded nodes: %v", err)
+	}
+
+	steps = append(steps, VerifyStep{
+		Name:   "decode proof",
+		Detail: fmt.Sprintf("height %v, %v storage proof nodes", signalProof.Height, len(storageProof)),
+	})
+
+	blockNumber := opts.BlockNumber
+	if blockNumber == nil {
+		blockNumber = signalProof.Height
+	}
+
+	if signalProof.Height.Cmp(blockNumber) != 0 {
+		return steps, errors.Wrapf(
+			ErrStateRootMismatch,
+			"proof is for block %v, not block %v",
+			signalProof.Height,
+			blockNumber,
+		)
+	}
+
+	block, err := blockByNumber(ctx, c, hexutil.EncodeBig(blockNumber))
+	if err != nil {
+		return steps, err
+	}
+
+	if block == nil {
+		return steps, errors.Wrapf(ErrBlockNotFound, "number: %v", blockNumber)
+	}
+
+	steps = append(steps, VerifyStep{
+		Name:   "fetch block",
+		Detail: fmt.Sprintf("block %v %v, state root %v", blockNumber, block.Hash.Hex(), block.StateRoot.Hex()),
+	})
+
+	storageRoot, err := signalServiceStorageRoot(ctx, c, opts.SignalServiceAddress, blockNumber, block.StateRoot)
+	if err != nil {
+		return steps, err
+	}
+
+	steps = append(steps, VerifyStep{
+		Name:   "account proof",
+		Detail: fmt.Sprintf("SignalService %v storage root %v", opts.SignalServiceAddress.Hex(), storageRoot.Hex()),
+	})
+
+	// a proof with a different root than the SignalService's storage was generated
+	// against another block's state, verifying it would only fail on the first node.
+	if len(storageProof) == 0 || crypto.Keccak256Hash(storageProof[0]) != storageRoot {
+		var proofRoot common.Hash
+		if len(storageProof) > 0 {
+			proofRoot = crypto.Keccak256Hash(storageProof[0])
+		}
+
+		return steps, errors.Wrapf(
+			ErrStateRootMismatch,
+			"proof's storage root is %v, the SignalService storage root at block %v is %v",
+			proofRoot.Hex(),
+			blockNumber,
+			storageRoot.Hex(),
+		)
+	}
+
+	slot := SignalService{
+		Address: opts.SignalServiceAddress,
+		Version: opts.SignalServiceVersion,
+		ChainID: opts.ChainID,
+	}.Slot(opts.App, opts.Signal)
+
+	value, err := trie.VerifyProof(storageRoot, crypto.Keccak256(slot[:]), proofDB(storageProof))
+	if err != nil {
+		return steps, errors.Wrap(ErrStorageProofInvalid, err.Error())
+	}
+
+	steps = append(steps, VerifyStep{
+		Name:   "storage proof",
+		Detail: fmt.Sprintf("slot %v", common.Hash(slot).Hex()),
+	})
+
+	if value == nil {
+		return steps, errors.Wrapf(ErrSignalNotSet, "slot %v is empty", common.Hash(slot).Hex())
+	}
+
+	var stored []byte
+	if err := rlp.DecodeBytes(value, &stored); err != nil || !bytes.Equal(stored, []byte{0x1}) {
+		return steps, errors.Wrapf(ErrSignalNotSet, "slot %v is %v, not 1", common.Hash(slot).Hex(), hexutil.Encode(value))
+	}
+
+	steps = append(steps, VerifyStep{
+		Name:   "signal",
+		Detail: fmt.Sprintf("%v sent by %v", common.Hash(opts.Signal).Hex(), opts.App.Hex()),
+	})
+
+	return steps, nil
+}
+
+// signalServiceStorageRoot gets the SignalService account proof at blockNumber from the node,
+// and verifies it against stateRoot, returning the account's storage root.
+func signalServiceStorageRoot(
+	ctx context.Context,
+	c relayer.Caller,
+	signalServiceAddress common.Address,
+	blockNumber *big.Int,
+	stateRoot common.Hash,
+) (common.Hash, error) {
+	var ethProof StorageProof
+
+	err := c.CallContext(ctx,
+		&ethProof,
+		"eth_getProof",
+		signalServiceAddress,
+		[]string{},
+		hexutil.EncodeBig(blockNumber),
+	)
+	if err != nil {
+		return common.Hash{}, errors.Wrap(wrapGetProofError(err, blockNumber), "c.CallContext")
+	}
+
+	if len(ethProof.AccountProof) == 0 {
+		return common.Hash{}, stateRootPrunedError("eth_getProof returned no accountProof", blockNumber)
+	}
+
+	encodedAccount, err := trie.VerifyProof(
+		stateRoot,
+		crypto.Keccak256(signalServiceAddress.Bytes()),
+		proofDB(ethProof.AccountProof),
+	)
+	if err != nil {
+		return common.Hash{}, errors.Wrap(ErrAccountProofInvalid, err.Error())
+	}
+
+	if encodedAccount == nil {
+		return common.Hash{}, errors.Wrapf(
+			ErrAccountProofInvalid,
+			"no account at %v in block %v, is it the SignalService address?",
+			signalServiceAddress.Hex(),
+			blockNumber,
+		)
+	}
+
+	var account types.StateAccount
+	if err := rlp.DecodeBytes(encodedAccount, &account); err != nil {
+		return common.Hash{}, errors.Wrap(ErrAccountProofInvalid, err.Error())
+	}
+
+	return account.Root, nil
+}
+
+// proofDB indexes merkle proof nodes by their hash, for trie.VerifyProof to look them up.
+// The hasher and hash are reused across nodes, since Put copies the key.
+func proofDB(nodes [][]byte) *memorydb.Database {
+	db := memorydb.New()
+
+	hasher := crypto.NewKeccakState()
+	hash := make([]byte, common.HashLength)
+
+	for _, node := range nodes {
+		hasher.Reset()
+		_, _ = hasher.Write(node)
+		_, _ = hasher.Read(hash)
+
+		_ = db.Put(hash, node)
+	}
+
+	return db
+}
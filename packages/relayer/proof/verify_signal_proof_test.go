@@ -0,0 +1,253 @@
+package proof
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/encoding"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	verifySignalService = common.HexToAddress("0x1000777700000000000000000000000000000007")
+	verifyApp           = common.HexToAddress("0x1000777700000000000000000000000000000004")
+	verifySignal        = [32]byte{0x1}
+	verifyHeight        = big.NewInt(5)
+)
+
+// proofList collects the nodes trie.Prove writes, in order from the root.
+type proofList [][]byte
+
+func (l *proofList) Put(key []byte, value []byte) error {
+	*l = append(*l, value)
+
+	return nil
+}
+
+func (l *proofList) Delete(key []byte) error {
+	return nil
+}
+
+// stateCaller answers eth_getBlockByNumber and eth_getProof from a real state trie
+// holding just the SignalService account, at verifyHeight.
+type stateCaller struct {
+	signalService SignalService
+	stateRoot     common.Hash
+	accountTrie   *trie.Trie
+	storageTrie   *trie.Trie
+	storageProof  proofList
+}
+
+func newStateCaller(t *testing.T, sentSignals ...[32]byte) *stateCaller {
+	return newStateCallerFor(t, SignalService{}, sentSignals...)
+}
+
+// newStateCallerFor stores sentSignals at signalService's slots.
+func newStateCallerFor(t *testing.T, signalService SignalService, sentSignals ...[32]byte) *stateCaller {
+	storageTrie := trie.NewEmpty(trie.NewDatabase(rawdb.NewMemoryDatabase()))
+
+	for _, signal := range sentSignals {
+		slot := signalService.Slot(verifyApp, signal)
+		assert.Nil(t, storageTrie.TryUpdate(crypto.Keccak256(slot[:]), []byte{0x1}))
+	}
+
+	encodedAccount, err := rlp.EncodeToBytes(&types.StateAccount{
+		Balance:  common.Big0,
+		Root:     storageTrie.Hash(),
+		CodeHash: crypto.Keccak256(nil),
+	})
+	assert.Nil(t, err)
+
+	accountTrie := trie.NewEmpty(trie.NewDatabase(rawdb.NewMemoryDatabase()))
+	assert.Nil(t, accountTrie.TryUpdate(crypto.Keccak256(verifySignalService.Bytes()), encodedAccount))
+
+	return &stateCaller{
+		signalService: signalService,
+		stateRoot:     accountTrie.Hash(),
+		accountTrie:   accountTrie,
+		storageTrie:   storageTrie,
+	}
+}
+
+// encodedProof is what EncodedSignalProof would generate for signal at height.
+func (c *stateCaller) encodedProof(t *testing.T, signal [32]byte, height *big.Int) []byte {
+	slot := c.signalService.Slot(verifyApp, signal)
+
+	var nodes proofList
+	assert.Nil(t, c.storageTrie.Prove(crypto.Keccak256(slot[:]), 0, &nodes))
+
+	return encodeProof(t, nodes, height)
+}
+
+func encodeProof(t *testing.T, nodes proofList, height *big.Int) []byte {
+	rlpEncoded, err := rlp.EncodeToBytes([][]byte(nodes))
+	assert.Nil(t, err)
+
+	encoded, err := encoding.EncodeSignalProof(encoding.SignalProof{Height: height, Proof: rlpEncoded})
+	assert.Nil(t, err)
+
+	return encoded
+}
+
+func (c *stateCaller) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	switch method {
+	case "eth_getBlockByNumber":
+		if args[0] != hexutil.EncodeBig(verifyHeight) {
+			return nil
+		}
+
+		*result.(**taggedBlock) = &taggedBlock{
+			Number:    (*hexutil.Big)(verifyHeight),
+			Hash:      common.Hash{0x5},
+			StateRoot: c.stateRoot,
+		}
+
+		return nil
+	case "eth_getProof":
+		addr := args[0].(common.Address)
+
+		var nodes proofList
+		if err := c.accountTrie.Prove(crypto.Keccak256(addr.Bytes()), 0, &nodes); err != nil {
+			return err
+		}
+
+		*result.(*StorageProof) = StorageProof{AccountProof: Slice(nodes)}
+
+		return nil
+	}
+
+	return fmt.Errorf("unexpected method %v", method)
+}
+
+func Test_VerifySignalProof(t *testing.T) {
+	// enough signals for the storage trie to have branch nodes
+	sent := [][32]byte{verifySignal}
+	for i := 2; i < 20; i++ {
+		sent = append(sent, [32]byte{byte(i)})
+	}
+
+	c := newStateCaller(t, sent...)
+
+	valid := c.encodedProof(t, verifySignal, verifyHeight)
+
+	otherState := newStateCaller(t, verifySignal, [32]byte{0x2})
+
+	slot := SignalSlot(verifyApp, verifySignal)
+
+	var truncated proofList
+	assert.Nil(t, c.storageTrie.Prove(crypto.Keccak256(slot[:]), 0, &truncated))
+	truncated = truncated[:len(truncated)-1]
+
+	tests := []struct {
+		name      string
+		opts      VerifySignalProofOpts
+		wantSteps int
+		wantErr   error
+	}{
+		{
+			"success",
+			VerifySignalProofOpts{EncodedProof: valid},
+			5,
+			nil,
+		},
+		{
+			"successAtBlock",
+			VerifySignalProofOpts{EncodedProof: valid, BlockNumber: verifyHeight},
+			5,
+			nil,
+		},
+		{
+			"malformedProof",
+			VerifySignalProofOpts{EncodedProof: []byte{0x1}},
+			0,
+			ErrMalformedProof,
+		},
+		{
+			"differentBlock",
+			VerifySignalProofOpts{EncodedProof: valid, BlockNumber: big.NewInt(6)},
+			1,
+			ErrStateRootMismatch,
+		},
+		{
+			"blockNotFound",
+			VerifySignalProofOpts{EncodedProof: c.encodedProof(t, verifySignal, big.NewInt(6))},
+			1,
+			ErrBlockNotFound,
+		},
+		{
+			"wrongSignalService",
+			VerifySignalProofOpts{EncodedProof: valid, SignalServiceAddress: common.Address{0x1}},
+			2,
+			ErrAccountProofInvalid,
+		},
+		{
+			"proofFromOtherState",
+			VerifySignalProofOpts{EncodedProof: otherState.encodedProof(t, verifySignal, verifyHeight)},
+			3,
+			ErrStateRootMismatch,
+		},
+		{
+			"truncatedStorageProof",
+			VerifySignalProofOpts{EncodedProof: encodeProof(t, truncated, verifyHeight)},
+			3,
+			ErrStorageProofInvalid,
+		},
+		{
+			"signalNotSet",
+			VerifySignalProofOpts{
+				EncodedProof: c.encodedProof(t, [32]byte{0xff}, verifyHeight),
+				Signal:       [32]byte{0xff},
+			},
+			4,
+			ErrSignalNotSet,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := tt.opts
+			if opts.SignalServiceAddress == (common.Address{}) {
+				opts.SignalServiceAddress = verifySignalService
+			}
+
+			opts.App = verifyApp
+			if opts.Signal == [32]byte{} {
+				opts.Signal = verifySignal
+			}
+
+			steps, err := VerifySignalProof(context.Background(), c, opts)
+			assert.Len(t, steps, tt.wantSteps)
+
+			if tt.wantErr == nil {
+				assert.Nil(t, err)
+			} else {
+				assert.True(t, errors.Is(err, tt.wantErr), "got %v", err)
+			}
+		})
+	}
+}
+
+func Test_VerifySignalProof_v2(t *testing.T) {
+	c := newStateCallerFor(t, SignalService{Version: SignalServiceV2, ChainID: 5}, verifySignal, [32]byte{0x2})
+
+	steps, err := VerifySignalProof(context.Background(), c, VerifySignalProofOpts{
+		SignalServiceAddress: verifySignalService,
+		SignalServiceVersion: SignalServiceV2,
+		ChainID:              5,
+		App:                  verifyApp,
+		Signal:               verifySignal,
+		EncodedProof:         c.encodedProof(t, verifySignal, verifyHeight),
+	})
+	assert.Nil(t, err)
+	assert.Len(t, steps, 5)
+}
@@ -0,0 +1,36 @@
+package proof
+
+import (
+	"context"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+)
+
+// acquireWorker blocks until fewer than the max concurrent proofs are generating, or ctx is
+// done. Once acquired it must be released with releaseWorker.
+func (p *Prover) acquireWorker(ctx context.Context) error {
+	if p.workers == nil {
+		return nil
+	}
+
+	relayer.ProofQueueDepth.Inc()
+	defer relayer.ProofQueueDepth.Dec()
+
+	select {
+	case p.workers <- struct{}{}:
+		relayer.ProofWorkersActive.Inc()
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *Prover) releaseWorker() {
+	if p.workers == nil {
+		return
+	}
+
+	<-p.workers
+
+	relayer.ProofWorkersActive.Dec()
+}
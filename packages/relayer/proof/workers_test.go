@@ -0,0 +1,59 @@
+package proof
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_acquireWorker(t *testing.T) {
+	p, err := New(&mock.Blocker{}, &mock.Caller{})
+	assert.Nil(t, err)
+
+	p = p.WithMaxConcurrentProofs(2)
+
+	assert.Nil(t, p.acquireWorker(context.Background()))
+	assert.Nil(t, p.acquireWorker(context.Background()))
+
+	// both workers are busy, so the next proof queues until the context is done
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.Equal(t, context.DeadlineExceeded, p.acquireWorker(ctx))
+
+	p.releaseWorker()
+
+	assert.Nil(t, p.acquireWorker(context.Background()))
+	assert.Equal(t, 2, len(p.workers))
+}
+
+func Test_acquireWorker_unbounded(t *testing.T) {
+	p, err := New(&mock.Blocker{}, &mock.Caller{})
+	assert.Nil(t, err)
+
+	p = p.WithMaxConcurrentProofs(0)
+
+	for i := 0; i < 100; i++ {
+		assert.Nil(t, p.acquireWorker(context.Background()))
+	}
+
+	p.releaseWorker()
+}
+
+func Test_EncodedSignalProof_canceledWhileQueued(t *testing.T) {
+	p, err := New(&mock.Blocker{}, &mock.Caller{})
+	assert.Nil(t, err)
+
+	p = p.WithMaxConcurrentProofs(1)
+
+	assert.Nil(t, p.acquireWorker(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = p.EncodedSignalProof(ctx, &mock.Caller{}, SignalService{}, [20]byte{}, [32]byte{}, [32]byte{})
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,10 @@
+#/bin/sh
+
+# requires protoc, protoc-gen-go v1.28.1 and protoc-gen-go-grpc v1.2.0 on the PATH
+
+protoc --proto_path=admin/adminpb \
+    --go_out=admin/adminpb --go_opt=paths=source_relative \
+    --go-grpc_out=admin/adminpb --go-grpc_opt=paths=source_relative \
+    admin.proto
+
+exit 0
@@ -0,0 +1,44 @@
+package relayer
+
+import (
+	"context"
+	"math/big"
+	"time"
+)
+
+// QuarantinedLog is a database model of a bridge log the indexer matched to an event it handles by
+// its signature, but couldn't unpack, i.e. the event's data layout changed in a contract upgrade. It
+// holds the raw log, so it can be decoded again once the relayer knows the new layout.
+type QuarantinedLog struct {
+	ID          int    `json:"id"`
+	ChainID     int64  `json:"chainID"`
+	EventName   string `json:"eventName"`
+	BlockNumber uint64 `json:"blockNumber"`
+	BlockHash   string `json:"blockHash"`
+	TxHash      string `json:"txHash"`
+	LogIndex    uint   `json:"logIndex"`
+	// Topics are the log's topics, hex encoded and comma separated, and Data its hex encoded data
+	Topics string `json:"topics"`
+	Data   string `json:"data"`
+	// Error is why the log couldn't be unpacked
+	Error     string    `json:"error"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// SaveQuarantinedLogOpts is required to store a new QuarantinedLog
+type SaveQuarantinedLogOpts struct {
+	ChainID     *big.Int
+	EventName   string
+	BlockNumber uint64
+	BlockHash   string
+	TxHash      string
+	LogIndex    uint
+	Topics      string
+	Data        string
+	Error       string
+}
+
+// QuarantinedLogRepository is used to interact with the logs that couldn't be unpacked in the store
+type QuarantinedLogRepository interface {
+	Save(ctx context.Context, opts SaveQuarantinedLogOpts) error
+}
@@ -0,0 +1,59 @@
+package relayer
+
+// Rejection is why a message can't be processed, and whether retrying it may help
+type Rejection struct {
+	Reason RejectionReason `json:"reason"`
+	// Retriable is whether the message may be processed later without the source chain changing
+	Retriable bool `json:"retriable"`
+	// RetryHint is what to do, or wait for, before retrying
+	RetryHint string `json:"retryHint"`
+}
+
+var rejectionRetryHints = map[RejectionReason]Rejection{
+	RejectionReasonNotYetSynced: {
+		Retriable: true,
+		RetryHint: "retry once the destination chain has synced the block the message was sent in, " +
+			"it's retried automatically",
+	},
+	RejectionReasonSignalNotSent: {
+		RetryHint: "don't retry, the message was never sent on the source chain",
+	},
+	RejectionReasonStatePruned: {
+		RetryHint: "don't retry against this node, proving the message needs an archive node",
+	},
+	RejectionReasonOutOfScope: {
+		RetryHint: "don't retry, the destination chain isn't relayed to, process it another way",
+	},
+	RejectionReasonExpired: {
+		RetryHint: "don't retry, the message is too old to be relayed, process it another way",
+	},
+	RejectionReasonBlocked: {
+		RetryHint: "don't retry, the message's sender or recipient is blocklisted",
+	},
+}
+
+// NewRejection is the Rejection for reason, nil if reason is empty
+func NewRejection(reason RejectionReason) *Rejection {
+	if reason == "" {
+		return nil
+	}
+
+	r := rejectionRetryHints[reason]
+	r.Reason = reason
+
+	return &r
+}
+
+// RejectionReasonForStatus is the reason a message with status is never processed, empty if it may be
+func RejectionReasonForStatus(status EventStatus) RejectionReason {
+	switch status {
+	case EventStatusOutOfScope:
+		return RejectionReasonOutOfScope
+	case EventStatusStale:
+		return RejectionReasonExpired
+	case EventStatusBlocked:
+		return RejectionReasonBlocked
+	default:
+		return ""
+	}
+}
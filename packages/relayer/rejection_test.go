@@ -0,0 +1,65 @@
+package relayer
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewRejection(t *testing.T) {
+	assert.Nil(t, NewRejection(""))
+
+	for _, reason := range RejectionReasons {
+		r := NewRejection(reason)
+		assert.Equal(t, reason, r.Reason)
+		assert.NotEmpty(t, r.RetryHint)
+		assert.Equal(t, reason == RejectionReasonNotYetSynced, r.Retriable)
+	}
+}
+
+func Test_Event_Rejection(t *testing.T) {
+	tests := []struct {
+		name  string
+		event *Event
+		want  RejectionReason
+	}{
+		{"new", &Event{Status: EventStatusNew}, ""},
+		{
+			"failedProcessing",
+			&Event{Status: EventStatusNew, RejectionReason: RejectionReasonNotYetSynced},
+			RejectionReasonNotYetSynced,
+		},
+		{"blocked", &Event{Status: EventStatusBlocked}, RejectionReasonBlocked},
+		{"outOfScope", &Event{Status: EventStatusOutOfScope}, RejectionReasonOutOfScope},
+		{
+			"stale",
+			&Event{Status: EventStatusStale, RejectionReason: RejectionReasonNotYetSynced},
+			RejectionReasonExpired,
+		},
+		{"doneSince", &Event{Status: EventStatusDone, RejectionReason: RejectionReasonNotYetSynced}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, NewRejection(tt.want), tt.event.Rejection())
+		})
+	}
+}
+
+func Test_Event_MarshalJSON(t *testing.T) {
+	b, err := json.Marshal(&Event{ID: 1, Status: EventStatusNew, RejectionReason: RejectionReasonStatePruned})
+	assert.Nil(t, err)
+
+	var fields map[string]interface{}
+
+	assert.Nil(t, json.Unmarshal(b, &fields))
+	assert.Equal(t, float64(1), fields["id"])
+	assert.Equal(t, "state-pruned", fields["rejectionReason"])
+
+	rejection, ok := fields["rejection"].(map[string]interface{})
+	assert.True(t, ok)
+	assert.Equal(t, "state-pruned", rejection["reason"])
+	assert.Equal(t, false, rejection["retriable"])
+	assert.NotEmpty(t, rejection["retryHint"])
+}
@@ -1,6 +1,7 @@
 package repo
 
 import (
+	"context"
 	"math/big"
 
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
@@ -21,13 +22,16 @@ func NewBlockRepository(db relayer.DB) (*BlockRepository, error) {
 	}, nil
 }
 
-func (r *BlockRepository) startQuery() *gorm.DB {
-	return r.db.GormDB().Table("processed_blocks")
+func (r *BlockRepository) startQuery(ctx context.Context) *gorm.DB {
+	return r.db.GormDB().WithContext(ctx).Table("processed_blocks")
 }
 
 func (r *BlockRepository) Save(opts relayer.SaveBlockOpts) error {
+	ctx, cancel := queryContext(context.Background(), r.db)
+	defer cancel()
+
 	exists := &relayer.Block{}
-	_ = r.startQuery().Where("block_height = ?", opts.Height).Where("chain_id = ?", opts.ChainID.Int64()).First(exists)
+	_ = r.startQuery(ctx).Where("block_height = ?", opts.Height).Where("chain_id = ?", opts.ChainID.Int64()).First(exists)
 	// block processed already
 	if exists.Height == opts.Height {
 		return nil
@@ -39,7 +43,7 @@ func (r *BlockRepository) Save(opts relayer.SaveBlockOpts) error {
 		ChainID:   opts.ChainID.Int64(),
 		EventName: opts.EventName,
 	}
-	if err := r.startQuery().Create(b).Error; err != nil {
+	if err := r.startQuery(ctx).Create(b).Error; err != nil {
 		return err
 	}
 
@@ -47,9 +51,12 @@ func (r *BlockRepository) Save(opts relayer.SaveBlockOpts) error {
 }
 
 func (r *BlockRepository) GetLatestBlockProcessedForEvent(eventName string, chainID *big.Int) (*relayer.Block, error) {
+	ctx, cancel := queryContext(context.Background(), r.db)
+	defer cancel()
+
 	b := &relayer.Block{}
 	if err := r.
-		startQuery().
+		startQuery(ctx).
 		Raw(`SELECT id, block_height, hash, event_name, chain_id 
 		FROM processed_blocks 
 		WHERE block_height = 
@@ -0,0 +1,74 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BridgeStatusChangeRepository struct {
+	db relayer.DB
+}
+
+func NewBridgeStatusChangeRepository(db relayer.DB) (*BridgeStatusChangeRepository, error) {
+	if db == nil {
+		return nil, relayer.ErrNoDB
+	}
+
+	return &BridgeStatusChangeRepository{
+		db: db,
+	}, nil
+}
+
+func (r *BridgeStatusChangeRepository) startQuery(ctx context.Context) *gorm.DB {
+	return r.db.GormDB().WithContext(ctx).Table("bridge_status_changes")
+}
+
+func (r *BridgeStatusChangeRepository) startReadQuery(ctx context.Context) *gorm.DB {
+	return readDB(ctx, r.db).WithContext(ctx).Table("bridge_status_changes")
+}
+
+// Save stores a BridgeStatusChange, unless the log it mirrors was already saved, i.e. when its
+// block is indexed again.
+func (r *BridgeStatusChangeRepository) Save(ctx context.Context, opts relayer.SaveBridgeStatusChangeOpts) error {
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
+	c := &relayer.BridgeStatusChange{
+		MsgHash:     opts.MsgHash,
+		ChainID:     opts.ChainID.Int64(),
+		Status:      opts.Status,
+		BlockNumber: opts.BlockNumber,
+		TxHash:      opts.TxHash,
+		LogIndex:    opts.LogIndex,
+	}
+
+	if err := r.startQuery(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(c).Error; err != nil {
+		return errors.Wrap(err, "r.startQuery.Create")
+	}
+
+	return nil
+}
+
+// FindAllByMsgHash finds msgHash's status changes, in the order they were indexed
+func (r *BridgeStatusChangeRepository) FindAllByMsgHash(
+	ctx context.Context,
+	msgHash string,
+) ([]*relayer.BridgeStatusChange, error) {
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
+	changes := make([]*relayer.BridgeStatusChange, 0)
+
+	if err := r.startReadQuery(ctx).
+		Where("msg_hash = ?", msgHash).
+		Order("id asc").
+		Find(&changes).Error; err != nil {
+		return nil, errors.Wrap(err, "r.startReadQuery.Find")
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,71 @@
+package repo
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/db"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func Test_NewBridgeStatusChangeRepo(t *testing.T) {
+	tests := []struct {
+		name    string
+		db      relayer.DB
+		wantErr error
+	}{
+		{
+			"success",
+			&db.DB{},
+			nil,
+		},
+		{
+			"noDb",
+			nil,
+			relayer.ErrNoDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewBridgeStatusChangeRepository(tt.db)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestIntegration_BridgeStatusChange_SaveAndFind(t *testing.T) {
+	db, close, err := testMysql(t)
+	assert.Equal(t, nil, err)
+
+	defer close()
+
+	changeRepo, err := NewBridgeStatusChangeRepository(db)
+	assert.Equal(t, nil, err)
+
+	ctx := context.Background()
+
+	for _, opts := range []relayer.SaveBridgeStatusChangeOpts{
+		{MsgHash: "0x1", Status: relayer.EventStatusRetriable, BlockNumber: 10, TxHash: "0xa", LogIndex: 1},
+		{MsgHash: "0x2", Status: relayer.EventStatusDone, BlockNumber: 10, TxHash: "0xa", LogIndex: 2},
+		{MsgHash: "0x1", Status: relayer.EventStatusDone, BlockNumber: 12, TxHash: "0xb", LogIndex: 0},
+		// the first again, as if its block were indexed again
+		{MsgHash: "0x1", Status: relayer.EventStatusRetriable, BlockNumber: 10, TxHash: "0xa", LogIndex: 1},
+	} {
+		opts.ChainID = big.NewInt(1)
+		assert.Equal(t, nil, changeRepo.Save(ctx, opts))
+	}
+
+	changes, err := changeRepo.FindAllByMsgHash(ctx, "0x1")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(changes))
+	assert.Equal(t, relayer.EventStatusRetriable, changes[0].Status)
+	assert.Equal(t, relayer.EventStatusDone, changes[1].Status)
+	assert.Equal(t, "0xb", changes[1].TxHash)
+
+	changes, err = changeRepo.FindAllByMsgHash(ctx, "0x3")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(changes))
+}
@@ -7,7 +7,7 @@ import (
 
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer/db"
-	"github.com/pressly/goose/v3"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/migrations"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
 	"gorm.io/driver/mysql"
@@ -64,12 +64,8 @@ func testMysql(t *testing.T) (relayer.DB, func(), error) {
 		t.Fatal(err)
 	}
 
-	if err := goose.SetDialect("mysql"); err != nil {
-		t.Fatal(err)
-	}
-
 	sqlDB, _ := gormDB.DB()
-	if err := goose.Up(sqlDB, "../migrations"); err != nil {
+	if err := migrations.Up(context.Background(), sqlDB); err != nil {
 		t.Fatal(err)
 	}
 
@@ -0,0 +1,128 @@
+package repo
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CrossChainSyncRepository struct {
+	db relayer.DB
+}
+
+func NewCrossChainSyncRepository(db relayer.DB) (*CrossChainSyncRepository, error) {
+	if db == nil {
+		return nil, relayer.ErrNoDB
+	}
+
+	return &CrossChainSyncRepository{
+		db: db,
+	}, nil
+}
+
+func (r *CrossChainSyncRepository) startQuery(ctx context.Context) *gorm.DB {
+	return r.db.GormDB().WithContext(ctx).Table("cross_chain_syncs")
+}
+
+func (r *CrossChainSyncRepository) startReadQuery(ctx context.Context) *gorm.DB {
+	return readDB(ctx, r.db).WithContext(ctx).Table("cross_chain_syncs")
+}
+
+// Save upserts a CrossChainSync, a source height re-synced after a reorg
+// overwrites the previously stored row.
+func (r *CrossChainSyncRepository) Save(ctx context.Context, opts relayer.SaveCrossChainSyncOpts) error {
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
+	s := &relayer.CrossChainSync{
+		ChainID:    opts.ChainID.Int64(),
+		SrcHeight:  opts.SrcHeight,
+		BlockHash:  opts.BlockHash.Hex(),
+		SignalRoot: opts.SignalRoot.Hex(),
+		SyncedAt:   opts.SyncedAt,
+		DestTxHash: opts.DestTxHash.Hex(),
+	}
+
+	if err := r.startQuery(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "chain_id"}, {Name: "src_height"}},
+		DoUpdates: clause.AssignmentColumns([]string{"block_hash", "signal_root", "synced_at", "dest_tx_hash"}),
+	}).Create(s).Error; err != nil {
+		return errors.Wrap(err, "r.startQuery.Create")
+	}
+
+	return nil
+}
+
+// LatestSyncedHeight returns the highest source height synced to chainID, or 0 if none has been.
+func (r *CrossChainSyncRepository) LatestSyncedHeight(ctx context.Context, chainID *big.Int) (uint64, error) {
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
+	var height uint64
+
+	if err := r.startReadQuery(ctx).
+		Select("COALESCE(MAX(src_height), 0)").
+		Where("chain_id = ?", chainID.Int64()).
+		Scan(&height).Error; err != nil {
+		return 0, errors.Wrap(err, "r.startQuery.Scan")
+	}
+
+	return height, nil
+}
+
+// FindSyncByHeight returns the CrossChainSync for srcHeight, or nil if that height was never synced.
+func (r *CrossChainSyncRepository) FindSyncByHeight(
+	ctx context.Context,
+	chainID *big.Int,
+	srcHeight uint64,
+) (*relayer.CrossChainSync, error) {
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
+	s := &relayer.CrossChainSync{}
+
+	if err := r.startReadQuery(ctx).
+		Where("chain_id = ?", chainID.Int64()).
+		Where("src_height = ?", srcHeight).
+		First(s).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, "r.startQuery.First")
+	}
+
+	return s, nil
+}
+
+// FindLatestSyncBetween returns the CrossChainSync with the highest source height from fromHeight to
+// toHeight, inclusive, or nil if none of them were synced.
+func (r *CrossChainSyncRepository) FindLatestSyncBetween(
+	ctx context.Context,
+	chainID *big.Int,
+	fromHeight uint64,
+	toHeight uint64,
+) (*relayer.CrossChainSync, error) {
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
+	s := &relayer.CrossChainSync{}
+
+	if err := r.startReadQuery(ctx).
+		Where("chain_id = ?", chainID.Int64()).
+		Where("src_height BETWEEN ? AND ?", fromHeight, toHeight).
+		Order("src_height DESC").
+		First(s).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, "r.startQuery.First")
+	}
+
+	return s, nil
+}
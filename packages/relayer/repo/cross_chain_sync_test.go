@@ -0,0 +1,87 @@
+package repo
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/db"
+	"github.com/ethereum/go-ethereum/common"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func Test_NewCrossChainSyncRepo(t *testing.T) {
+	tests := []struct {
+		name    string
+		db      relayer.DB
+		wantErr error
+	}{
+		{
+			"success",
+			&db.DB{},
+			nil,
+		},
+		{
+			"noDb",
+			nil,
+			relayer.ErrNoDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewCrossChainSyncRepository(tt.db)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestIntegration_CrossChainSync_SaveAndFind(t *testing.T) {
+	db, close, err := testMysql(t)
+	assert.Equal(t, nil, err)
+
+	defer close()
+
+	syncRepo, err := NewCrossChainSyncRepository(db)
+	assert.Equal(t, nil, err)
+
+	ctx := context.Background()
+
+	for _, height := range []uint64{100, 200, 200} {
+		err = syncRepo.Save(ctx, relayer.SaveCrossChainSyncOpts{
+			ChainID:    big.NewInt(1),
+			SrcHeight:  height,
+			BlockHash:  common.BigToHash(new(big.Int).SetUint64(height)),
+			SignalRoot: common.HexToHash("0x1234"),
+			SyncedAt:   time.Unix(1234, 0),
+			DestTxHash: common.HexToHash("0x5678"),
+		})
+		assert.Equal(t, nil, err)
+	}
+
+	latest, err := syncRepo.LatestSyncedHeight(ctx, big.NewInt(1))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint64(200), latest)
+
+	latest, err = syncRepo.LatestSyncedHeight(ctx, big.NewInt(2))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint64(0), latest)
+
+	s, err := syncRepo.FindSyncByHeight(ctx, big.NewInt(1), 100)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, common.BigToHash(big.NewInt(100)).Hex(), s.BlockHash)
+
+	s, err = syncRepo.FindSyncByHeight(ctx, big.NewInt(1), 101)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, (*relayer.CrossChainSync)(nil), s)
+
+	s, err = syncRepo.FindLatestSyncBetween(ctx, big.NewInt(1), 50, 150)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint64(100), s.SrcHeight)
+
+	s, err = syncRepo.FindLatestSyncBetween(ctx, big.NewInt(1), 101, 199)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, (*relayer.CrossChainSync)(nil), s)
+}
@@ -2,12 +2,16 @@ package repo
 
 import (
 	"context"
-	"gorm.io/gorm"
+	"math/big"
+	"net/http"
 	"strings"
+	"time"
 
-	"net/http"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/morkid/paginate"
 	"github.com/pkg/errors"
 	"gorm.io/datatypes"
@@ -27,7 +31,7 @@ func NewEventRepository(db relayer.DB) (*EventRepository, error) {
 	}, nil
 }
 
-func (r *EventRepository) Save(ctx context.Context, opts relayer.SaveEventOpts) (*relayer.Event, error) {
+func newEvent(opts relayer.SaveEventOpts) *relayer.Event {
 	e := &relayer.Event{
 		Data:                   datatypes.JSON(opts.Data),
 		Status:                 opts.Status,
@@ -42,36 +46,222 @@ func (r *EventRepository) Save(ctx context.Context, opts relayer.SaveEventOpts)
 		MsgHash:                opts.MsgHash,
 		MessageOwner:           opts.MessageOwner,
 		Event:                  opts.Event,
+		MessageCallTo:          opts.MessageCallTo,
+		MessageCallSelector:    opts.MessageCallSelector,
+		DuplicateOfEventID:     opts.DuplicateOfEventID,
+		BlockNumber:            opts.BlockNumber,
+		MessageSender:          strings.ToLower(opts.MessageSender),
+		MessageTo:              strings.ToLower(opts.MessageTo),
+		MessageSentTimestamp:   opts.MessageSentTimestamp,
 	}
 
-	if err := r.db.GormDB().Create(e).Error; err != nil {
-		return nil, errors.Wrap(err, "r.db.Create")
+	if opts.TxHash != "" {
+		txHash, logIndex := opts.TxHash, opts.LogIndex
+		e.TxHash, e.LogIndex = &txHash, &logIndex
 	}
 
-	return e, nil
+	if opts.DestChainID != nil {
+		e.DestChainID = opts.DestChainID.Int64()
+	}
+
+	return e
+}
+
+// Save saves an event, or if one was already saved from the same log, returns that one.
+func (r *EventRepository) Save(ctx context.Context, opts relayer.SaveEventOpts) (*relayer.Event, error) {
+	e := newEvent(opts)
+
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
+	result := r.db.GormDB().WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(e)
+	if result.Error != nil {
+		return nil, errors.Wrap(result.Error, "r.db.Create")
+	}
+
+	if result.RowsAffected > 0 {
+		return e, nil
+	}
+
+	saved, err := r.findByLogs(ctx, []relayer.SaveEventOpts{opts})
+	if err != nil {
+		return nil, errors.Wrap(err, "r.findByLogs")
+	}
+
+	return saved[0], nil
+}
+
+// SaveBatch saves events in multi-row inserts of up to batchSize rows, in one transaction, so they're
+// either all saved or none are. Events already saved from the same log are skipped. It returns the events
+// in the order of opts, as saved.
+func (r *EventRepository) SaveBatch(
+	ctx context.Context,
+	opts []relayer.SaveEventOpts,
+	batchSize int,
+) ([]*relayer.Event, error) {
+	events := make([]*relayer.Event, 0, len(opts))
+
+	for _, o := range opts {
+		events = append(events, newEvent(o))
+	}
+
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
+	if len(events) == 0 {
+		return events, nil
+	}
+
+	err := r.db.GormDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(events, batchSize).Error; err != nil {
+			return errors.Wrap(err, "tx.CreateInBatches")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the IDs the insert filled in are off once a row in it was skipped
+	saved, err := r.findByLogs(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "r.findByLogs")
+	}
+
+	return saved, nil
+}
+
+// eventLog identifies the log an event was saved from
+type eventLog struct {
+	name     string
+	txHash   string
+	logIndex uint
+}
+
+// findByLogs finds the events saved from the logs in opts, all for the same chain, in the order of opts.
+// It reads from the primary, since they were saved moments ago.
+func (r *EventRepository) findByLogs(ctx context.Context, opts []relayer.SaveEventOpts) ([]*relayer.Event, error) {
+	txHashes := make([]string, 0, len(opts))
+	names := make([]string, 0)
+
+	for _, o := range opts {
+		if o.TxHash == "" {
+			return nil, errors.Errorf("event %v for msgHash %v has no log", o.Name, o.MsgHash)
+		}
+
+		txHashes = append(txHashes, o.TxHash)
+
+		if !relayer.IsInSlice(o.Name, names) {
+			names = append(names, o.Name)
+		}
+	}
+
+	found := make([]*relayer.Event, 0)
+
+	if err := r.db.GormDB().WithContext(ctx).
+		Where("chain_id = ?", opts[0].ChainID.Int64()).
+		Where("name IN ?", names).
+		Where("tx_hash IN ?", txHashes).
+		Find(&found).Error; err != nil {
+		return nil, errors.Wrap(err, "r.db.Find")
+	}
+
+	byLog := make(map[eventLog]*relayer.Event, len(found))
+
+	for _, e := range found {
+		if e.TxHash != nil && e.LogIndex != nil {
+			byLog[eventLog{e.Name, *e.TxHash, *e.LogIndex}] = e
+		}
+	}
+
+	events := make([]*relayer.Event, 0, len(opts))
+
+	for _, o := range opts {
+		e, ok := byLog[eventLog{o.Name, o.TxHash, o.LogIndex}]
+		if !ok {
+			return nil, errors.Errorf("event %v from txHash %v log %v wasn't saved", o.Name, o.TxHash, o.LogIndex)
+		}
+
+		events = append(events, e)
+	}
+
+	return events, nil
 }
 
 func (r *EventRepository) UpdateStatus(ctx context.Context, id int, status relayer.EventStatus) error {
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
 	e := &relayer.Event{}
-	if err := r.db.GormDB().Where("id = ?", id).First(e).Error; err != nil {
+	if err := r.db.GormDB().WithContext(ctx).Where("id = ?", id).First(e).Error; err != nil {
 		return errors.Wrap(err, "r.db.First")
 	}
 
 	e.Status = status
-	if err := r.db.GormDB().Save(e).Error; err != nil {
+	if err := r.db.GormDB().WithContext(ctx).Save(e).Error; err != nil {
 		return errors.Wrap(err, "r.db.Save")
 	}
 
 	return nil
 }
 
+// UpdateStatusIf updates the event's status to status only if it's still from, in one statement so
+// only one of two concurrent callers does, reporting whether it did.
+func (r *EventRepository) UpdateStatusIf(
+	ctx context.Context,
+	id int,
+	from relayer.EventStatus,
+	status relayer.EventStatus,
+) (bool, error) {
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
+	result := r.db.GormDB().WithContext(ctx).Model(&relayer.Event{}).
+		Where("id = ?", id).
+		Where("status = ?", from).
+		Update("status", status)
+	if result.Error != nil {
+		return false, errors.Wrap(result.Error, "r.db.Update")
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// UpdateTimeToDone stores the source MessageSent block timestamp and the destination
+// block timestamp the message was marked done in, along with how long it took between them,
+// which the caller corrects for the chains' clocks drifting apart.
+func (r *EventRepository) UpdateTimeToDone(
+	ctx context.Context,
+	id int,
+	messageSentTimestamp uint64,
+	doneTimestamp uint64,
+	timeToDoneInSeconds uint64,
+) error {
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
+	if err := r.db.GormDB().WithContext(ctx).Model(&relayer.Event{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"message_sent_timestamp":  messageSentTimestamp,
+		"done_timestamp":          doneTimestamp,
+		"time_to_done_in_seconds": timeToDoneInSeconds,
+	}).Error; err != nil {
+		return errors.Wrap(err, "r.db.Updates")
+	}
+
+	return nil
+}
+
 func (r *EventRepository) FirstByMsgHash(
 	ctx context.Context,
 	msgHash string,
 ) (*relayer.Event, error) {
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
 	e := &relayer.Event{}
 	// find all message sent events
-	if err := r.db.GormDB().Where("msg_hash = ?", msgHash).
+	if err := readDB(ctx, r.db).WithContext(ctx).Where("msg_hash = ?", msgHash).
 		First(&e).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -88,9 +278,12 @@ func (r *EventRepository) FirstByEventAndMsgHash(
 	event string,
 	msgHash string,
 ) (*relayer.Event, error) {
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
 	e := &relayer.Event{}
 	// find all message sent events
-	if err := r.db.GormDB().Where("msg_hash = ?", msgHash).
+	if err := readDB(ctx, r.db).WithContext(ctx).Where("msg_hash = ?", msgHash).
 		Where("event = ?", event).
 		First(&e).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -112,7 +305,10 @@ func (r *EventRepository) FindAllByAddress(
 		DefaultSize: 100,
 	})
 
-	q := r.db.GormDB().
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
+	q := readDB(ctx, r.db).WithContext(ctx).
 		Model(&relayer.Event{}).Where("message_owner = ?", strings.ToLower(opts.Address.Hex()))
 
 	if opts.EventType != nil {
@@ -131,6 +327,14 @@ func (r *EventRepository) FindAllByAddress(
 		q = q.Where("event = ?", *opts.Event)
 	}
 
+	if opts.Sender != nil {
+		q = q.Where("message_sender = ?", strings.ToLower(opts.Sender.Hex()))
+	}
+
+	if opts.DestChainID != nil {
+		q = q.Where("dest_chain_id = ?", opts.DestChainID.Int64())
+	}
+
 	reqCtx := pg.With(q)
 
 	page := reqCtx.Request(req).Response(&[]relayer.Event{})
@@ -138,9 +342,511 @@ func (r *EventRepository) FindAllByAddress(
 	return page, nil
 }
 
+func (r *EventRepository) FindAllForExport(
+	ctx context.Context,
+	opts relayer.FindAllForExportOpts,
+) ([]*relayer.ExportedEvent, error) {
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
+	q := readDB(ctx, r.db).WithContext(ctx).
+		Model(&relayer.Event{}).
+		Where("id > ?", opts.AfterID)
+
+	if !opts.From.IsZero() {
+		q = q.Where("created_at >= ?", opts.From)
+	}
+
+	if !opts.To.IsZero() {
+		q = q.Where("created_at < ?", opts.To)
+	}
+
+	if opts.ChainID != nil {
+		q = q.Where("chain_id = ?", opts.ChainID.Int64())
+	}
+
+	if opts.Status != nil {
+		q = q.Where("status = ?", *opts.Status)
+	}
+
+	if opts.Name != "" {
+		q = q.Where("name = ?", opts.Name)
+	}
+
+	if opts.MessageOwner != "" {
+		q = q.Where("message_owner = ?", opts.MessageOwner)
+	}
+
+	if opts.Limit > 0 {
+		q = q.Limit(opts.Limit)
+	}
+
+	events := make([]*relayer.ExportedEvent, 0)
+
+	if err := q.Order("id asc").Find(&events).Error; err != nil {
+		return nil, errors.Wrap(err, "q.Find")
+	}
+
+	return events, nil
+}
+
+// FindAllByStatus finds all events for chainID with the given status, oldest first.
+func (r *EventRepository) FindAllByStatus(
+	ctx context.Context,
+	chainID *big.Int,
+	status relayer.EventStatus,
+) ([]*relayer.Event, error) {
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
+	events := make([]*relayer.Event, 0)
+
+	if err := readDB(ctx, r.db).WithContext(ctx).
+		Where("chain_id = ?", chainID.Int64()).
+		Where("status = ?", status).
+		Order("id asc").
+		Find(&events).Error; err != nil {
+		return nil, errors.Wrap(err, "r.db.Find")
+	}
+
+	return events, nil
+}
+
+// FindAllByBlockNumber finds the events indexed for chainID from logs in blockNumber, oldest first.
+func (r *EventRepository) FindAllByBlockNumber(
+	ctx context.Context,
+	chainID *big.Int,
+	blockNumber uint64,
+) ([]*relayer.Event, error) {
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
+	events := make([]*relayer.Event, 0)
+
+	if err := readDB(ctx, r.db).WithContext(ctx).
+		Where("chain_id = ?", chainID.Int64()).
+		Where("block_number = ?", blockNumber).
+		Order("id asc").
+		Find(&events).Error; err != nil {
+		return nil, errors.Wrap(err, "r.db.Find")
+	}
+
+	return events, nil
+}
+
+// FindAllMessageSentFromBlock finds chainID's MessageSent events from blocks at or after fromBlock,
+// in the order they were emitted.
+func (r *EventRepository) FindAllMessageSentFromBlock(
+	ctx context.Context,
+	chainID *big.Int,
+	fromBlock uint64,
+) ([]*relayer.Event, error) {
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
+	events := make([]*relayer.Event, 0)
+
+	if err := readDB(ctx, r.db).WithContext(ctx).
+		Where("chain_id = ?", chainID.Int64()).
+		Where("name = ?", relayer.EventNameMessageSent).
+		Where("block_number >= ?", fromBlock).
+		Order("block_number asc").
+		Order("id asc").
+		Find(&events).Error; err != nil {
+		return nil, errors.Wrap(err, "r.db.Find")
+	}
+
+	return events, nil
+}
+
+// FindProcessable finds up to limit MessageSent events for chainID that are new or retriable,
+// from blocks at or below syncedHeight, the height the destination has synced the chain's headers to,
+// and due to be retried by now, the longest due first.
+func (r *EventRepository) FindProcessable(
+	ctx context.Context,
+	chainID *big.Int,
+	syncedHeight uint64,
+	now time.Time,
+	limit int,
+) ([]*relayer.Event, error) {
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
+	events := make([]*relayer.Event, 0)
+
+	if err := readDB(ctx, r.db).WithContext(ctx).
+		Where("chain_id = ?", chainID.Int64()).
+		Where("status IN ?", []relayer.EventStatus{relayer.EventStatusNew, relayer.EventStatusRetriable}).
+		Where("block_number <= ?", syncedHeight).
+		Where("name = ?", relayer.EventNameMessageSent).
+		Where("next_retry_at <= ?", now).
+		Order("next_retry_at asc").
+		Order("id asc").
+		Limit(limit).
+		Find(&events).Error; err != nil {
+		return nil, errors.Wrap(err, "r.db.Find")
+	}
+
+	return events, nil
+}
+
+// MarkStale marks chainID's MessageSent events that are still new and were sent before sentBefore
+// as stale, returning how many it marked. Events with a processing transaction, and messages from
+// exceptSenders, aren't. Events indexed without the timestamp of the block they were sent in go by
+// when they were indexed instead.
+func (r *EventRepository) MarkStale(
+	ctx context.Context,
+	chainID *big.Int,
+	sentBefore time.Time,
+	exceptSenders []common.Address,
+) (int64, error) {
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
+	q := r.db.GormDB().WithContext(ctx).Model(&relayer.Event{}).
+		Where("chain_id = ?", chainID.Int64()).
+		Where("status = ?", relayer.EventStatusNew).
+		Where("name = ?", relayer.EventNameMessageSent).
+		Where("processing_tx_hash = ''").
+		Where(
+			"(message_sent_timestamp > 0 AND message_sent_timestamp < ?) OR (message_sent_timestamp = 0 AND created_at < ?)",
+			sentBefore.Unix(),
+			sentBefore,
+		)
+
+	if len(exceptSenders) > 0 {
+		senders := make([]string, 0, len(exceptSenders))
+		for _, sender := range exceptSenders {
+			senders = append(senders, strings.ToLower(sender.Hex()))
+		}
+
+		q = q.Where("message_sender NOT IN ?", senders)
+	}
+
+	result := q.Update("status", relayer.EventStatusStale)
+	if result.Error != nil {
+		return 0, errors.Wrap(result.Error, "r.db.Update")
+	}
+
+	return result.RowsAffected, nil
+}
+
+// FindTopFailingRecipients finds the limit recipients with the most Retriable or Failed
+// messages sent to them, most failures first. Recipients are the messages' To, checksummed,
+// the same as message_recipient_failures_ops_total's recipient label.
+func (r *EventRepository) FindTopFailingRecipients(
+	ctx context.Context,
+	limit int,
+) ([]*relayer.FailingRecipient, error) {
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
+	recipients := make([]*relayer.FailingRecipient, 0)
+
+	if err := readDB(ctx, r.db).WithContext(ctx).
+		Model(&relayer.Event{}).
+		Select("message_to AS recipient, COUNT(*) AS failures").
+		Where("name = ?", relayer.EventNameMessageSent).
+		Where("status IN ?", []relayer.EventStatus{relayer.EventStatusRetriable, relayer.EventStatusFailed}).
+		Where("message_to != ''").
+		Group("message_to").
+		Order("failures desc, recipient asc").
+		Limit(limit).
+		Scan(&recipients).Error; err != nil {
+		return nil, errors.Wrap(err, "r.db.Scan")
+	}
+
+	for _, recipient := range recipients {
+		recipient.Recipient = common.HexToAddress(recipient.Recipient).Hex()
+	}
+
+	return recipients, nil
+}
+
+// MarkPendingSent records that a processMessage transaction was sent for the event,
+// so its outcome can be reconciled if we restart before it's known. It's only recorded
+// while the event is still being processed, so e.g. marking it stale in the meantime stands.
+func (r *EventRepository) MarkPendingSent(ctx context.Context, id int, txHash common.Hash) error {
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
+	if err := r.db.GormDB().WithContext(ctx).Model(&relayer.Event{}).
+		Where("id = ?", id).
+		Where("status IN ?", relayer.ProcessingStatuses).
+		Updates(map[string]interface{}{
+			"status":             relayer.EventStatusPendingSent,
+			"processing_tx_hash": txHash.Hex(),
+		}).Error; err != nil {
+		return errors.Wrap(err, "r.db.Updates")
+	}
+
+	return nil
+}
+
+// MarkProcessedUnconfirmed records that the event's message was processed by txHash, which isn't
+// deep enough on the destination chain yet to be sure a reorg won't undo it. It's only recorded if
+// the event is still pendingSent.
+func (r *EventRepository) MarkProcessedUnconfirmed(ctx context.Context, id int, txHash common.Hash) error {
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
+	if err := r.db.GormDB().WithContext(ctx).Model(&relayer.Event{}).
+		Where("id = ?", id).
+		Where("status = ?", relayer.EventStatusPendingSent).
+		Updates(map[string]interface{}{
+			"status":             relayer.EventStatusProcessedUnconfirmed,
+			"processing_tx_hash": txHash.Hex(),
+		}).Error; err != nil {
+		return errors.Wrap(err, "r.db.Updates")
+	}
+
+	return nil
+}
+
+// UpdateProcessingError records the error the last attempt to process the event failed with, and
+// the reason it found the message can't be processed, if it's known
+func (r *EventRepository) UpdateProcessingError(
+	ctx context.Context,
+	id int,
+	processingError string,
+	rejectionReason relayer.RejectionReason,
+) error {
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
+	if err := r.db.GormDB().WithContext(ctx).Model(&relayer.Event{}).Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"processing_error": processingError,
+			"rejection_reason": rejectionReason,
+		}).Error; err != nil {
+		return errors.Wrap(err, "r.db.Update")
+	}
+
+	return nil
+}
+
+// UpdateNextRetry records why the last attempt to process the event failed and when it's next retried,
+// an empty reason and nil nextRetryAt if it isn't
+func (r *EventRepository) UpdateNextRetry(
+	ctx context.Context,
+	id int,
+	reason relayer.RetryReason,
+	nextRetryAt *time.Time,
+) error {
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
+	if err := r.db.GormDB().WithContext(ctx).Model(&relayer.Event{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"retry_reason":  reason,
+		"next_retry_at": nextRetryAt,
+	}).Error; err != nil {
+		return errors.Wrap(err, "r.db.Updates")
+	}
+
+	return nil
+}
+
+// RetryWaitingOnSync makes chainID's new MessageSent events waiting for the destination chain to sync
+// due to be retried at now, returning how many it made due.
+func (r *EventRepository) RetryWaitingOnSync(ctx context.Context, chainID *big.Int, now time.Time) (int64, error) {
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
+	result := r.db.GormDB().WithContext(ctx).Model(&relayer.Event{}).
+		Where("chain_id = ?", chainID.Int64()).
+		Where("status = ?", relayer.EventStatusNew).
+		Where("name = ?", relayer.EventNameMessageSent).
+		Where("retry_reason = ?", relayer.RetryReasonNotSynced).
+		Where("next_retry_at IS NULL").
+		Update("next_retry_at", now)
+	if result.Error != nil {
+		return 0, errors.Wrap(result.Error, "r.db.Update")
+	}
+
+	return result.RowsAffected, nil
+}
+
+// UpdateProcessingBlock records the destination block the event's processMessage transaction was
+// mined in
+func (r *EventRepository) UpdateProcessingBlock(ctx context.Context, id int, blockNumber uint64) error {
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
+	if err := r.db.GormDB().WithContext(ctx).Model(&relayer.Event{}).Where("id = ?", id).
+		Update("processing_block_number", blockNumber).Error; err != nil {
+		return errors.Wrap(err, "r.db.Update")
+	}
+
+	return nil
+}
+
+// FindDoneProcessedBetween finds chainID's done events whose processMessage transaction was mined in
+// a destination block from fromBlock to toBlock, inclusive.
+func (r *EventRepository) FindDoneProcessedBetween(
+	ctx context.Context,
+	chainID *big.Int,
+	fromBlock uint64,
+	toBlock uint64,
+) ([]*relayer.Event, error) {
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
+	events := make([]*relayer.Event, 0)
+
+	if err := readDB(ctx, r.db).WithContext(ctx).
+		Where("chain_id = ?", chainID.Int64()).
+		Where("status = ?", relayer.EventStatusDone).
+		Where("processing_block_number BETWEEN ? AND ?", fromBlock, toBlock).
+		Order("id asc").
+		Find(&events).Error; err != nil {
+		return nil, errors.Wrap(err, "r.db.Find")
+	}
+
+	return events, nil
+}
+
 func (r *EventRepository) Delete(
 	ctx context.Context,
 	id int,
 ) error {
-	return r.db.GormDB().Delete(relayer.Event{}, id).Error
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
+	return r.db.GormDB().WithContext(ctx).Delete(relayer.Event{}, id).Error
+}
+
+// LatestBlockNumber finds the highest block an event was indexed from for chainID, 0 if none
+// have been.
+func (r *EventRepository) LatestBlockNumber(ctx context.Context, chainID *big.Int) (uint64, error) {
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
+	var blockNumber *uint64
+
+	if err := readDB(ctx, r.db).WithContext(ctx).
+		Model(&relayer.Event{}).
+		Select("MAX(block_number)").
+		Where("chain_id = ?", chainID.Int64()).
+		Scan(&blockNumber).Error; err != nil {
+		return 0, errors.Wrap(err, "r.db.Scan")
+	}
+
+	if blockNumber == nil {
+		return 0, nil
+	}
+
+	return *blockNumber, nil
+}
+
+// LatestMessageSent finds the MessageSent event indexed from the highest block for chainID,
+// nil if none have been.
+func (r *EventRepository) LatestMessageSent(ctx context.Context, chainID *big.Int) (*relayer.Event, error) {
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
+	e := &relayer.Event{}
+
+	if err := readDB(ctx, r.db).WithContext(ctx).
+		Where("chain_id = ?", chainID.Int64()).
+		Where("event = ?", relayer.EventNameMessageSent).
+		Order("block_number DESC").
+		Order("id DESC").
+		First(e).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, "r.db.First")
+	}
+
+	return e, nil
+}
+
+// Stats aggregates the messages relayed since since, and counts the messages waiting to be processed.
+// Messages relayed are the ones done with a done timestamp, which only the relayer's own processing
+// records, so messages done by someone else aren't counted.
+func (r *EventRepository) Stats(ctx context.Context, since time.Time) (*relayer.EventStats, error) {
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
+	relayed := func() *gorm.DB {
+		return readDB(ctx, r.db).WithContext(ctx).
+			Model(&relayer.Event{}).
+			Where("name = ?", relayer.EventNameMessageSent).
+			Where("status = ?", relayer.EventStatusDone).
+			Where("done_timestamp >= ?", since.Unix())
+	}
+
+	var done struct {
+		Count   int64
+		Average *float64
+	}
+
+	if err := relayed().
+		Select("COUNT(*) AS count, AVG(time_to_done_in_seconds) AS average").
+		Scan(&done).Error; err != nil {
+		return nil, errors.Wrap(err, "r.db.Scan")
+	}
+
+	// amounts are uint256s, up to 78 digits, which MySQL's DECIMAL(65) can't hold, so they're grouped
+	// by amount and summed here
+	var amounts []struct {
+		relayer.TokenValue
+		Count int64
+	}
+
+	if err := relayed().
+		Select("chain_id, canonical_token_address, canonical_token_symbol, canonical_token_decimals, amount, " +
+			"COUNT(*) AS count").
+		Group("chain_id, canonical_token_address, canonical_token_symbol, canonical_token_decimals, amount").
+		Order("chain_id, canonical_token_address, canonical_token_symbol, canonical_token_decimals").
+		Scan(&amounts).Error; err != nil {
+		return nil, errors.Wrap(err, "r.db.Scan")
+	}
+
+	values := make([]*relayer.TokenValue, 0)
+	sums := make([]*big.Int, 0)
+
+	for _, a := range amounts {
+		amount, ok := new(big.Int).SetString(a.Amount, 10)
+		if !ok {
+			amount = new(big.Int)
+		}
+
+		amount.Mul(amount, big.NewInt(a.Count))
+
+		last := len(values) - 1
+		if last >= 0 && values[last].ChainID == a.ChainID &&
+			values[last].CanonicalTokenAddress == a.CanonicalTokenAddress &&
+			values[last].CanonicalTokenSymbol == a.CanonicalTokenSymbol &&
+			values[last].CanonicalTokenDecimals == a.CanonicalTokenDecimals {
+			sums[last].Add(sums[last], amount)
+			continue
+		}
+
+		value := a.TokenValue
+		values = append(values, &value)
+		sums = append(sums, amount)
+	}
+
+	for i, v := range values {
+		v.Amount = sums[i].String()
+	}
+
+	var backlog int64
+
+	if err := readDB(ctx, r.db).WithContext(ctx).
+		Model(&relayer.Event{}).
+		Where("name = ?", relayer.EventNameMessageSent).
+		Where("status IN ?", relayer.BacklogStatuses).
+		Count(&backlog).Error; err != nil {
+		return nil, errors.Wrap(err, "r.db.Count")
+	}
+
+	return &relayer.EventStats{
+		MessagesRelayed:            done.Count,
+		AverageTimeToDoneInSeconds: done.Average,
+		ValueBridged:               values,
+		Backlog:                    backlog,
+	}, nil
 }
@@ -2,11 +2,13 @@ package repo
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
 	"github.com/MXCzkEVM/mxc-mono/packages/relayer/db"
@@ -199,6 +201,39 @@ func TestIntegration_Event_UpdateStatus(t *testing.T) {
 	}
 }
 
+func TestIntegration_Event_UpdateStatusIf(t *testing.T) {
+	db, close, err := testMysql(t)
+	assert.Equal(t, nil, err)
+
+	defer close()
+
+	eventRepo, err := NewEventRepository(db)
+	assert.Equal(t, nil, err)
+
+	e, err := eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+		Name:    relayer.EventNameMessageSent,
+		ChainID: big.NewInt(1),
+		Data:    "{\"data\":\"something\"}",
+		Status:  relayer.EventStatusHeld,
+		MsgHash: "0x1",
+		Event:   relayer.EventNameMessageSent,
+	})
+	assert.Equal(t, nil, err)
+
+	updated, err := eventRepo.UpdateStatusIf(context.Background(), e.ID, relayer.EventStatusHeld, relayer.EventStatusNew)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, updated)
+
+	// no longer held
+	updated, err = eventRepo.UpdateStatusIf(context.Background(), e.ID, relayer.EventStatusHeld, relayer.EventStatusNew)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, updated)
+
+	e, err = eventRepo.FirstByMsgHash(context.Background(), "0x1")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, relayer.EventStatusNew, e.Status)
+}
+
 func TestIntegration_Event_FindAllByAddress(t *testing.T) {
 	db, close, err := testMysql(t)
 	assert.Equal(t, nil, err)
@@ -481,3 +516,837 @@ func TestIntegration_Event_FirstByMsgHash(t *testing.T) {
 		})
 	}
 }
+
+func TestIntegration_Event_FindAllForExport(t *testing.T) {
+	db, close, err := testMysql(t)
+	assert.Equal(t, nil, err)
+
+	defer close()
+
+	eventRepo, err := NewEventRepository(db)
+	assert.Equal(t, nil, err)
+
+	for i := 0; i < 3; i++ {
+		status := relayer.EventStatusDone
+		if i == 2 {
+			status = relayer.EventStatusNew
+		}
+
+		_, err = eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+			Name:         "name",
+			Data:         "{}",
+			ChainID:      big.NewInt(1),
+			Status:       status,
+			MsgHash:      fmt.Sprintf("0x%v", i),
+			MessageOwner: fmt.Sprintf("0x%v", i%2),
+		})
+		assert.Equal(t, nil, err)
+	}
+
+	done := relayer.EventStatusDone
+
+	tests := []struct {
+		name    string
+		opts    relayer.FindAllForExportOpts
+		wantIDs []int
+	}{
+		{
+			"all",
+			relayer.FindAllForExportOpts{},
+			[]int{1, 2, 3},
+		},
+		{
+			"afterIDWithLimit",
+			relayer.FindAllForExportOpts{AfterID: 1, Limit: 1},
+			[]int{2},
+		},
+		{
+			"byStatus",
+			relayer.FindAllForExportOpts{Status: &done},
+			[]int{1, 2},
+		},
+		{
+			"byChainID",
+			relayer.FindAllForExportOpts{ChainID: big.NewInt(2)},
+			[]int{},
+		},
+		{
+			"inTheFuture",
+			relayer.FindAllForExportOpts{From: time.Now().Add(time.Hour)},
+			[]int{},
+		},
+		{
+			"byName",
+			relayer.FindAllForExportOpts{Name: "other"},
+			[]int{},
+		},
+		{
+			"byMessageOwner",
+			relayer.FindAllForExportOpts{MessageOwner: "0x0"},
+			[]int{1, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := eventRepo.FindAllForExport(context.Background(), tt.opts)
+			assert.Equal(t, nil, err)
+
+			ids := make([]int, 0)
+			for _, e := range events {
+				ids = append(ids, e.ID)
+			}
+
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
+
+func TestIntegration_Event_MarkPendingSentAndFindAllByStatus(t *testing.T) {
+	db, close, err := testMysql(t)
+	assert.Equal(t, nil, err)
+
+	defer close()
+
+	eventRepo, err := NewEventRepository(db)
+	assert.Equal(t, nil, err)
+
+	for _, chainID := range []int64{1, 2} {
+		_, err := eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+			Name:    relayer.EventNameMessageSent,
+			ChainID: big.NewInt(chainID),
+			Data:    "{\"data\":\"something\"}",
+			Status:  relayer.EventStatusNew,
+			MsgHash: "0x1",
+			Event:   relayer.EventNameMessageSent,
+		})
+		assert.Equal(t, nil, err)
+	}
+
+	txHash := common.HexToHash("0x123")
+
+	assert.Equal(t, nil, eventRepo.MarkPendingSent(context.Background(), 1, txHash))
+
+	events, err := eventRepo.FindAllByStatus(context.Background(), big.NewInt(1), relayer.EventStatusPendingSent)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, 1, events[0].ID)
+	assert.Equal(t, txHash.Hex(), events[0].ProcessingTxHash)
+
+	events, err = eventRepo.FindAllByStatus(context.Background(), big.NewInt(2), relayer.EventStatusPendingSent)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(events))
+}
+
+func TestIntegration_Event_MarkProcessedUnconfirmed(t *testing.T) {
+	db, close, err := testMysql(t)
+	assert.Equal(t, nil, err)
+
+	defer close()
+
+	eventRepo, err := NewEventRepository(db)
+	assert.Equal(t, nil, err)
+
+	_, err = eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+		Name:    relayer.EventNameMessageSent,
+		ChainID: big.NewInt(1),
+		Data:    "{\"data\":\"something\"}",
+		Status:  relayer.EventStatusNew,
+		MsgHash: "0x1",
+		Event:   relayer.EventNameMessageSent,
+	})
+	assert.Equal(t, nil, err)
+
+	pendingTxHash := common.HexToHash("0x123")
+	minedTxHash := common.HexToHash("0x456")
+
+	assert.Equal(t, nil, eventRepo.MarkPendingSent(context.Background(), 1, pendingTxHash))
+	assert.Equal(t, nil, eventRepo.MarkProcessedUnconfirmed(context.Background(), 1, minedTxHash))
+
+	events, err := eventRepo.FindAllByStatus(context.Background(), big.NewInt(1), relayer.EventStatusProcessedUnconfirmed)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, minedTxHash.Hex(), events[0].ProcessingTxHash)
+}
+
+func TestIntegration_Event_UpdateProcessingError(t *testing.T) {
+	db, close, err := testMysql(t)
+	assert.Equal(t, nil, err)
+
+	defer close()
+
+	eventRepo, err := NewEventRepository(db)
+	assert.Equal(t, nil, err)
+
+	_, err = eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+		Name:    relayer.EventNameMessageSent,
+		ChainID: big.NewInt(1),
+		Data:    "{\"data\":\"something\"}",
+		Status:  relayer.EventStatusNew,
+		MsgHash: "0x1",
+		Event:   relayer.EventNameMessageSent,
+	})
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, nil, eventRepo.UpdateProcessingError(
+		context.Background(),
+		1,
+		"message not received",
+		relayer.RejectionReasonNotYetSynced,
+	))
+
+	e, err := eventRepo.FirstByMsgHash(context.Background(), "0x1")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "message not received", e.ProcessingError)
+	assert.Equal(t, relayer.RejectionReasonNotYetSynced, e.RejectionReason)
+}
+
+func TestIntegration_Event_RetryDue(t *testing.T) {
+	db, close, err := testMysql(t)
+	assert.Equal(t, nil, err)
+
+	defer close()
+
+	eventRepo, err := NewEventRepository(db)
+	assert.Equal(t, nil, err)
+
+	for _, msgHash := range []string{"0x1", "0x2", "0x3"} {
+		_, err = eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+			Name:    relayer.EventNameMessageSent,
+			ChainID: big.NewInt(1),
+			Data:    "{\"data\":\"something\"}",
+			Status:  relayer.EventStatusNew,
+			MsgHash: msgHash,
+			Event:   relayer.EventNameMessageSent,
+		})
+		assert.Equal(t, nil, err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	past, future := now.Add(-time.Minute), now.Add(time.Hour)
+
+	assert.Equal(t, nil, eventRepo.UpdateNextRetry(context.Background(), 1, relayer.RetryReasonTransient, &past))
+	assert.Equal(t, nil, eventRepo.UpdateNextRetry(context.Background(), 2, relayer.RetryReasonGasTooHigh, &future))
+	assert.Equal(t, nil, eventRepo.UpdateNextRetry(context.Background(), 3, relayer.RetryReasonNotSynced, nil))
+
+	due, err := eventRepo.FindProcessable(context.Background(), big.NewInt(1), 0, now, 10)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(due))
+	assert.Equal(t, 1, due[0].ID)
+	assert.Equal(t, relayer.RetryReasonTransient, due[0].RetryReason)
+
+	// the destination chain syncing makes the message waiting on it due
+	made, err := eventRepo.RetryWaitingOnSync(context.Background(), big.NewInt(1), now)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(1), made)
+
+	due, err = eventRepo.FindProcessable(context.Background(), big.NewInt(1), 0, now, 10)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(due))
+	assert.Equal(t, 1, due[0].ID)
+	assert.Equal(t, 3, due[1].ID)
+
+	// cleared, a message is no longer due
+	assert.Equal(t, nil, eventRepo.UpdateNextRetry(context.Background(), 1, "", nil))
+
+	due, err = eventRepo.FindProcessable(context.Background(), big.NewInt(1), 0, now, 10)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(due))
+	assert.Equal(t, 3, due[0].ID)
+}
+
+func TestIntegration_Event_FindDoneProcessedBetween(t *testing.T) {
+	db, close, err := testMysql(t)
+	assert.Equal(t, nil, err)
+
+	defer close()
+
+	eventRepo, err := NewEventRepository(db)
+	assert.Equal(t, nil, err)
+
+	for _, msgHash := range []string{"0x1", "0x2", "0x3", "0x4"} {
+		_, err = eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+			Name:    relayer.EventNameMessageSent,
+			ChainID: big.NewInt(1),
+			Data:    "{\"data\":\"something\"}",
+			Status:  relayer.EventStatusDone,
+			MsgHash: msgHash,
+			Event:   relayer.EventNameMessageSent,
+		})
+		assert.Equal(t, nil, err)
+	}
+
+	// 4 was done without its transaction's block being recorded
+	assert.Equal(t, nil, eventRepo.UpdateProcessingBlock(context.Background(), 1, 10))
+	assert.Equal(t, nil, eventRepo.UpdateProcessingBlock(context.Background(), 2, 12))
+	assert.Equal(t, nil, eventRepo.UpdateProcessingBlock(context.Background(), 3, 13))
+	assert.Equal(t, nil, eventRepo.UpdateStatus(context.Background(), 2, relayer.EventStatusRetriable))
+
+	events, err := eventRepo.FindDoneProcessedBetween(context.Background(), big.NewInt(1), 10, 12)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, 1, events[0].ID)
+	assert.Equal(t, uint64(10), *events[0].ProcessingBlockNumber)
+
+	events, err = eventRepo.FindDoneProcessedBetween(context.Background(), big.NewInt(1), 11, 20)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, 3, events[0].ID)
+}
+
+func TestIntegration_Event_FindTopFailingRecipients(t *testing.T) {
+	db, close, err := testMysql(t)
+	assert.Equal(t, nil, err)
+
+	defer close()
+
+	eventRepo, err := NewEventRepository(db)
+	assert.Equal(t, nil, err)
+
+	for i, opts := range []struct {
+		to     string
+		status relayer.EventStatus
+	}{
+		{"0x2", relayer.EventStatusRetriable},
+		{"0x1", relayer.EventStatusRetriable},
+		{"0x2", relayer.EventStatusFailed},
+		{"0x3", relayer.EventStatusRetriable},
+		{"0x1", relayer.EventStatusDone},
+		{"0x4", relayer.EventStatusNew},
+	} {
+		_, err = eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+			Name:      relayer.EventNameMessageSent,
+			ChainID:   big.NewInt(1),
+			Data:      "{\"data\":\"something\"}",
+			Status:    opts.status,
+			MsgHash:   fmt.Sprintf("0x%d", i),
+			Event:     relayer.EventNameMessageSent,
+			MessageTo: opts.to,
+		})
+		assert.Equal(t, nil, err)
+	}
+
+	recipients, err := eventRepo.FindTopFailingRecipients(context.Background(), 2)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []*relayer.FailingRecipient{
+		{Recipient: common.HexToAddress("0x2").Hex(), Failures: 2},
+		{Recipient: common.HexToAddress("0x1").Hex(), Failures: 1},
+	}, recipients)
+}
+
+func TestIntegration_Event_LatestBlockNumber(t *testing.T) {
+	db, close, err := testMysql(t)
+	assert.Equal(t, nil, err)
+
+	defer close()
+
+	eventRepo, err := NewEventRepository(db)
+	assert.Equal(t, nil, err)
+
+	blockNumber, err := eventRepo.LatestBlockNumber(context.Background(), big.NewInt(1))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint64(0), blockNumber)
+
+	for i, opts := range []struct {
+		chainID     int64
+		blockNumber uint64
+	}{
+		{1, 5},
+		{1, 42},
+		{1, 7},
+		{2, 1000},
+	} {
+		_, err = eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+			Name:        relayer.EventNameMessageSent,
+			ChainID:     big.NewInt(opts.chainID),
+			Data:        "{\"data\":\"something\"}",
+			Status:      relayer.EventStatusNew,
+			MsgHash:     fmt.Sprintf("0x%d", i),
+			Event:       relayer.EventNameMessageSent,
+			BlockNumber: opts.blockNumber,
+		})
+		assert.Equal(t, nil, err)
+	}
+
+	blockNumber, err = eventRepo.LatestBlockNumber(context.Background(), big.NewInt(1))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint64(42), blockNumber)
+}
+
+func TestIntegration_Event_LatestMessageSent(t *testing.T) {
+	db, close, err := testMysql(t)
+	assert.Equal(t, nil, err)
+
+	defer close()
+
+	eventRepo, err := NewEventRepository(db)
+	assert.Equal(t, nil, err)
+
+	e, err := eventRepo.LatestMessageSent(context.Background(), big.NewInt(1))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, e == nil)
+
+	for i, opts := range []struct {
+		chainID     int64
+		event       string
+		blockNumber uint64
+	}{
+		{1, relayer.EventNameMessageSent, 5},
+		{1, relayer.EventNameMessageSent, 42},
+		{1, relayer.EventNameMessageStatusChanged, 50},
+		{1, relayer.EventNameMessageSent, 7},
+		{2, relayer.EventNameMessageSent, 1000},
+	} {
+		_, err = eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+			Name:        opts.event,
+			ChainID:     big.NewInt(opts.chainID),
+			Data:        "{\"data\":\"something\"}",
+			Status:      relayer.EventStatusNew,
+			MsgHash:     fmt.Sprintf("0x%d", i),
+			Event:       opts.event,
+			BlockNumber: opts.blockNumber,
+		})
+		assert.Equal(t, nil, err)
+	}
+
+	e, err = eventRepo.LatestMessageSent(context.Background(), big.NewInt(1))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "0x1", e.MsgHash)
+	assert.Equal(t, uint64(42), e.BlockNumber)
+}
+
+func TestIntegration_Event_FindAllByBlockNumber(t *testing.T) {
+	db, close, err := testMysql(t)
+	assert.Equal(t, nil, err)
+
+	defer close()
+
+	eventRepo, err := NewEventRepository(db)
+	assert.Equal(t, nil, err)
+
+	for i, opts := range []struct {
+		name        string
+		chainID     int64
+		blockNumber uint64
+	}{
+		{relayer.EventNameMessageSent, 1, 5},
+		{relayer.EventNameMessageStatusChanged, 1, 5},
+		{relayer.EventNameMessageSent, 1, 6},
+		{relayer.EventNameMessageSent, 2, 5},
+	} {
+		_, err = eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+			Name:        opts.name,
+			ChainID:     big.NewInt(opts.chainID),
+			Data:        "{\"data\":\"something\"}",
+			Status:      relayer.EventStatusNew,
+			MsgHash:     fmt.Sprintf("0x%d", i),
+			Event:       opts.name,
+			BlockNumber: opts.blockNumber,
+		})
+		assert.Equal(t, nil, err)
+	}
+
+	events, err := eventRepo.FindAllByBlockNumber(context.Background(), big.NewInt(1), 5)
+	assert.Equal(t, nil, err)
+
+	ids := make([]int, 0)
+	for _, e := range events {
+		ids = append(ids, e.ID)
+	}
+
+	assert.Equal(t, []int{1, 2}, ids)
+}
+
+func TestIntegration_Event_FindProcessable(t *testing.T) {
+	db, close, err := testMysql(t)
+	assert.Equal(t, nil, err)
+
+	defer close()
+
+	eventRepo, err := NewEventRepository(db)
+	assert.Equal(t, nil, err)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	past, earlier, future := now.Add(-time.Minute), now.Add(-time.Hour), now.Add(time.Hour)
+
+	for i, opts := range []struct {
+		name        string
+		chainID     int64
+		status      relayer.EventStatus
+		blockNumber uint64
+		nextRetryAt *time.Time
+	}{
+		{relayer.EventNameMessageSent, 1, relayer.EventStatusNew, 5, &past},
+		{relayer.EventNameMessageSent, 1, relayer.EventStatusRetriable, 10, &past},
+		{relayer.EventNameMessageSent, 1, relayer.EventStatusDone, 5, &past},
+		{relayer.EventNameMessageSent, 1, relayer.EventStatusFailed, 5, &past},
+		{relayer.EventNameMessageSent, 1, relayer.EventStatusNew, 11, &past},
+		{relayer.EventNameMessageSent, 2, relayer.EventStatusNew, 5, &past},
+		{relayer.EventNameMessageStatusChanged, 1, relayer.EventStatusNew, 5, &past},
+		{relayer.EventNameMessageSent, 1, relayer.EventStatusNew, 1, &earlier},
+		{relayer.EventNameMessageSent, 1, relayer.EventStatusNew, 1, &future},
+		{relayer.EventNameMessageSent, 1, relayer.EventStatusNew, 1, nil},
+	} {
+		e, err := eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+			Name:        opts.name,
+			ChainID:     big.NewInt(opts.chainID),
+			Data:        "{\"data\":\"something\"}",
+			Status:      opts.status,
+			MsgHash:     fmt.Sprintf("0x%d", i),
+			Event:       opts.name,
+			BlockNumber: opts.blockNumber,
+		})
+		assert.Equal(t, nil, err)
+
+		assert.Equal(t, nil, eventRepo.UpdateNextRetry(context.Background(), e.ID, "", opts.nextRetryAt))
+	}
+
+	// the longest due first, and neither those not yet due nor those not scheduled
+	tests := []struct {
+		name         string
+		syncedHeight uint64
+		limit        int
+		wantIDs      []int
+	}{
+		{"synced", 10, 10, []int{8, 1, 2}},
+		{"limit", 10, 2, []int{8, 1}},
+		{"notSynced", 0, 10, []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := eventRepo.FindProcessable(context.Background(), big.NewInt(1), tt.syncedHeight, now, tt.limit)
+			assert.Equal(t, nil, err)
+
+			ids := make([]int, 0)
+			for _, e := range events {
+				ids = append(ids, e.ID)
+			}
+
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
+
+func TestIntegration_Event_MarkStale(t *testing.T) {
+	db, close, err := testMysql(t)
+	assert.Equal(t, nil, err)
+
+	defer close()
+
+	eventRepo, err := NewEventRepository(db)
+	assert.Equal(t, nil, err)
+
+	sentLongAgo := uint64(time.Now().Add(-2 * time.Hour).Unix())
+	sentRecently := uint64(time.Now().Unix())
+	excepted := common.HexToAddress("0xabc")
+
+	for i, opts := range []struct {
+		chainID int64
+		status  relayer.EventStatus
+		sentAt  uint64
+		sender  common.Address
+	}{
+		// sent long ago, however recently it was indexed
+		{1, relayer.EventStatusNew, sentLongAgo, common.Address{}},
+		{1, relayer.EventStatusRetriable, sentLongAgo, common.Address{}},
+		{2, relayer.EventStatusNew, sentLongAgo, common.Address{}},
+		{1, relayer.EventStatusNew, sentRecently, common.Address{}},
+		// indexed without its block's timestamp, so it goes by when it was indexed
+		{1, relayer.EventStatusNew, 0, common.Address{}},
+		{1, relayer.EventStatusNew, sentLongAgo, excepted},
+		// processed, then requeued after the transaction was reorged out
+		{1, relayer.EventStatusNew, sentLongAgo, common.Address{}},
+	} {
+		_, err = eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+			Name:                 relayer.EventNameMessageSent,
+			ChainID:              big.NewInt(opts.chainID),
+			Data:                 "{\"data\":\"something\"}",
+			Status:               opts.status,
+			MsgHash:              fmt.Sprintf("0x%d", i),
+			Event:                relayer.EventNameMessageSent,
+			MessageSender:        opts.sender.Hex(),
+			DestChainID:          big.NewInt(2),
+			MessageSentTimestamp: opts.sentAt,
+		})
+		assert.Equal(t, nil, err)
+	}
+
+	assert.Equal(t, nil, eventRepo.MarkPendingSent(context.Background(), 7, common.HexToHash("0x123")))
+	assert.Equal(t, nil, eventRepo.UpdateStatus(context.Background(), 7, relayer.EventStatusNew))
+
+	marked, err := eventRepo.MarkStale(context.Background(), big.NewInt(1), time.Now().Add(-time.Hour), []common.Address{excepted})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(1), marked)
+
+	for msgHash, want := range map[string]relayer.EventStatus{
+		"0x0": relayer.EventStatusStale,
+		"0x1": relayer.EventStatusRetriable,
+		"0x2": relayer.EventStatusNew,
+		"0x3": relayer.EventStatusNew,
+		"0x4": relayer.EventStatusNew,
+		"0x5": relayer.EventStatusNew,
+		"0x6": relayer.EventStatusNew,
+	} {
+		e, err := eventRepo.FirstByMsgHash(context.Background(), msgHash)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, want, e.Status)
+	}
+
+	// once it's been that long since it was indexed too
+	marked, err = eventRepo.MarkStale(context.Background(), big.NewInt(1), time.Now().Add(time.Minute), nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(3), marked)
+
+	e, err := eventRepo.FirstByMsgHash(context.Background(), "0x4")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, relayer.EventStatusStale, e.Status)
+}
+
+func TestIntegration_Event_SaveBatch(t *testing.T) {
+	db, close, err := testMysql(t)
+	assert.Equal(t, nil, err)
+
+	defer close()
+
+	eventRepo, err := NewEventRepository(db)
+	assert.Equal(t, nil, err)
+
+	opts := make([]relayer.SaveEventOpts, 0)
+
+	for i := 0; i < 5; i++ {
+		opts = append(opts, relayer.SaveEventOpts{
+			Name:        relayer.EventNameMessageSent,
+			ChainID:     big.NewInt(1),
+			Data:        "{\"data\":\"something\"}",
+			Status:      relayer.EventStatusNew,
+			MsgHash:     fmt.Sprintf("0x%d", i),
+			Event:       relayer.EventNameMessageSent,
+			BlockNumber: 5,
+			TxHash:      common.HexToHash("0xabc").Hex(),
+			LogIndex:    uint(i),
+		})
+	}
+
+	// the first three saved on their own, as if the batch had been partly written before
+	for _, o := range opts[:3] {
+		_, err = eventRepo.Save(context.Background(), o)
+		assert.Equal(t, nil, err)
+	}
+
+	events, err := eventRepo.SaveBatch(context.Background(), opts, 2)
+	assert.Equal(t, nil, err)
+
+	ids := make([]int, 0)
+	for _, e := range events {
+		ids = append(ids, e.ID)
+	}
+
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, ids)
+	assert.Equal(t, "0x4", events[4].MsgHash)
+	assert.Equal(t, uint(4), *events[4].LogIndex)
+
+	// written again, nothing's added
+	_, err = eventRepo.SaveBatch(context.Background(), opts, 2)
+	assert.Equal(t, nil, err)
+
+	events, err = eventRepo.FindAllByBlockNumber(context.Background(), big.NewInt(1), 5)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 5, len(events))
+}
+
+func TestIntegration_Event_FindAllMessageSentFromBlock(t *testing.T) {
+	db, close, err := testMysql(t)
+	assert.Equal(t, nil, err)
+
+	defer close()
+
+	eventRepo, err := NewEventRepository(db)
+	assert.Equal(t, nil, err)
+
+	for i, opts := range []struct {
+		name        string
+		chainID     int64
+		blockNumber uint64
+	}{
+		{relayer.EventNameMessageSent, 1, 6},
+		{relayer.EventNameMessageSent, 1, 5},
+		{relayer.EventNameMessageStatusChanged, 1, 5},
+		{relayer.EventNameMessageSent, 1, 4},
+		{relayer.EventNameMessageSent, 2, 5},
+	} {
+		_, err = eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+			Name:        opts.name,
+			ChainID:     big.NewInt(opts.chainID),
+			Data:        "{\"data\":\"something\"}",
+			Status:      relayer.EventStatusNew,
+			MsgHash:     fmt.Sprintf("0x%d", i),
+			Event:       opts.name,
+			BlockNumber: opts.blockNumber,
+		})
+		assert.Equal(t, nil, err)
+	}
+
+	events, err := eventRepo.FindAllMessageSentFromBlock(context.Background(), big.NewInt(1), 5)
+	assert.Equal(t, nil, err)
+
+	ids := make([]int, 0)
+	for _, e := range events {
+		ids = append(ids, e.ID)
+	}
+
+	assert.Equal(t, []int{2, 1}, ids)
+}
+
+func TestIntegration_Event_FindAllByAddress_senderAndDestChainID(t *testing.T) {
+	db, close, err := testMysql(t)
+	assert.Equal(t, nil, err)
+
+	defer close()
+
+	eventRepo, err := NewEventRepository(db)
+	assert.Equal(t, nil, err)
+
+	sender := common.HexToAddress("0x1")
+
+	for i, opts := range []struct {
+		sender      common.Address
+		destChainID int64
+	}{
+		{sender, 2},
+		{sender, 3},
+		{common.HexToAddress("0x2"), 2},
+	} {
+		_, err = eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+			Name:          relayer.EventNameMessageSent,
+			Data:          "{\"data\":\"something\"}",
+			ChainID:       big.NewInt(1),
+			Status:        relayer.EventStatusNew,
+			MsgHash:       fmt.Sprintf("0x%d", i),
+			MessageOwner:  addr.Hex(),
+			Event:         relayer.EventNameMessageSent,
+			MessageSender: opts.sender.Hex(),
+			DestChainID:   big.NewInt(opts.destChainID),
+		})
+		assert.Equal(t, nil, err)
+	}
+
+	tests := []struct {
+		name    string
+		opts    relayer.FindAllByAddressOpts
+		wantIDs []int
+	}{
+		{
+			"sender",
+			relayer.FindAllByAddressOpts{Address: addr, Sender: &sender},
+			[]int{1, 2},
+		},
+		{
+			"destChainID",
+			relayer.FindAllByAddressOpts{Address: addr, DestChainID: big.NewInt(2)},
+			[]int{1, 3},
+		},
+		{
+			"both",
+			relayer.FindAllByAddressOpts{Address: addr, Sender: &sender, DestChainID: big.NewInt(3)},
+			[]int{2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "/events", nil)
+			assert.Equal(t, nil, err)
+
+			page, err := eventRepo.FindAllByAddress(context.Background(), req, tt.opts)
+			assert.Equal(t, nil, err)
+
+			items, err := json.Marshal(page.Items)
+			assert.Equal(t, nil, err)
+
+			var events []relayer.Event
+			assert.Equal(t, nil, json.Unmarshal(items, &events))
+
+			ids := make([]int, 0)
+			for _, e := range events {
+				ids = append(ids, e.ID)
+			}
+
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
+
+func TestIntegration_Event_Stats(t *testing.T) {
+	db, close, err := testMysql(t)
+	assert.Equal(t, nil, err)
+
+	defer close()
+
+	eventRepo, err := NewEventRepository(db)
+	assert.Equal(t, nil, err)
+
+	since := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+
+	stats, err := eventRepo.Stats(context.Background(), since)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(0), stats.MessagesRelayed)
+	assert.Equal(t, (*float64)(nil), stats.AverageTimeToDoneInSeconds)
+	assert.Equal(t, 0, len(stats.ValueBridged))
+
+	native := "0x0000000000000000000000000000000000000000"
+	maxUint256 := "115792089237316195423570985008687907853269984665640564039457584007913129639935"
+
+	for i, opts := range []struct {
+		status     relayer.EventStatus
+		token      string
+		amount     string
+		doneAt     time.Time
+		timeToDone uint64
+	}{
+		{relayer.EventStatusDone, native, "100", since.Add(time.Hour), 10},
+		// more than a uint64, which the sum mustn't overflow
+		{relayer.EventStatusDone, native, "20000000000000000000", since.Add(2 * time.Hour), 30},
+		{relayer.EventStatusDone, "0xaa", "5", since.Add(3 * time.Hour), 50},
+		// the largest uint256, twice, which DECIMAL(65) couldn't hold
+		{relayer.EventStatusDone, "0xbb", maxUint256, since.Add(4 * time.Hour), 30},
+		{relayer.EventStatusDone, "0xbb", maxUint256, since.Add(5 * time.Hour), 30},
+		// relayed before since
+		{relayer.EventStatusDone, "0xaa", "1000", since.Add(-time.Hour), 1000},
+		{relayer.EventStatusNew, native, "1", time.Time{}, 0},
+		{relayer.EventStatusRetriable, native, "1", time.Time{}, 0},
+		{relayer.EventStatusFailed, native, "1", time.Time{}, 0},
+	} {
+		e, err := eventRepo.Save(context.Background(), relayer.SaveEventOpts{
+			Name:                  relayer.EventNameMessageSent,
+			ChainID:               big.NewInt(1),
+			Data:                  "{\"data\":\"something\"}",
+			Status:                opts.status,
+			MsgHash:               fmt.Sprintf("0x%d", i),
+			Event:                 relayer.EventNameMessageSent,
+			CanonicalTokenAddress: opts.token,
+			Amount:                opts.amount,
+		})
+		assert.Equal(t, nil, err)
+
+		if !opts.doneAt.IsZero() {
+			err = eventRepo.UpdateTimeToDone(context.Background(), e.ID, 0, uint64(opts.doneAt.Unix()), opts.timeToDone)
+			assert.Equal(t, nil, err)
+		}
+	}
+
+	stats, err = eventRepo.Stats(context.Background(), since)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(5), stats.MessagesRelayed)
+	assert.Equal(t, float64(30), *stats.AverageTimeToDoneInSeconds)
+	assert.Equal(t, []*relayer.TokenValue{
+		{ChainID: 1, CanonicalTokenAddress: native, Amount: "20000000000000000100"},
+		{ChainID: 1, CanonicalTokenAddress: "0xaa", Amount: "5"},
+		{
+			ChainID:               1,
+			CanonicalTokenAddress: "0xbb",
+			Amount:                "231584178474632390847141970017375815706539969331281128078915168015826259279870",
+		},
+	}, stats.ValueBridged)
+	assert.Equal(t, int64(2), stats.Backlog)
+}
@@ -0,0 +1,86 @@
+package repo
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GasSpendRepository struct {
+	db relayer.DB
+}
+
+func NewGasSpendRepository(db relayer.DB) (*GasSpendRepository, error) {
+	if db == nil {
+		return nil, relayer.ErrNoDB
+	}
+
+	return &GasSpendRepository{
+		db: db,
+	}, nil
+}
+
+func (r *GasSpendRepository) startQuery(ctx context.Context) *gorm.DB {
+	return r.db.GormDB().WithContext(ctx).Table("gas_spends")
+}
+
+func (r *GasSpendRepository) startReadQuery(ctx context.Context) *gorm.DB {
+	return readDB(ctx, r.db).WithContext(ctx).Table("gas_spends")
+}
+
+// Save stores a GasSpend, unless the transaction's was already saved, i.e. when a pending
+// transaction found after a restart is reconciled.
+func (r *GasSpendRepository) Save(ctx context.Context, opts relayer.SaveGasSpendOpts) error {
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
+	s := &relayer.GasSpend{
+		ChainID:  opts.ChainID.Int64(),
+		MsgHash:  opts.MsgHash,
+		TxHash:   opts.TxHash,
+		GasUsed:  opts.GasUsed,
+		GasSpent: opts.GasSpent.String(),
+	}
+
+	// created_at is left to the database's clock, which SpentWithin's window is by
+	if err := r.startQuery(ctx).
+		Omit("created_at").
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(s).Error; err != nil {
+		return errors.Wrap(err, "r.startQuery.Create")
+	}
+
+	return nil
+}
+
+// SpentWithin sums the gas spent on chainID by the transactions recorded in the last window, by the
+// database's clock, so it's the same whichever instance asks.
+func (r *GasSpendRepository) SpentWithin(
+	ctx context.Context,
+	chainID *big.Int,
+	window time.Duration,
+) (*big.Int, error) {
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
+	var sum string
+
+	if err := r.startReadQuery(ctx).
+		Select("CAST(COALESCE(SUM(gas_spent), 0) AS CHAR)").
+		Where("chain_id = ? AND created_at >= NOW() - INTERVAL ? SECOND", chainID.Int64(), int64(window/time.Second)).
+		Scan(&sum).Error; err != nil {
+		return nil, errors.Wrap(err, "r.startReadQuery.Scan")
+	}
+
+	spent, ok := new(big.Int).SetString(sum, 10)
+	if !ok {
+		return nil, errors.Errorf("invalid gas spent sum %v", sum)
+	}
+
+	return spent, nil
+}
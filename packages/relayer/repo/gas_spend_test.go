@@ -0,0 +1,75 @@
+package repo
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/db"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func Test_NewGasSpendRepo(t *testing.T) {
+	tests := []struct {
+		name    string
+		db      relayer.DB
+		wantErr error
+	}{
+		{
+			"success",
+			&db.DB{},
+			nil,
+		},
+		{
+			"noDb",
+			nil,
+			relayer.ErrNoDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewGasSpendRepository(tt.db)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestIntegration_GasSpend_SaveAndSpentWithin(t *testing.T) {
+	db, close, err := testMysql(t)
+	assert.Equal(t, nil, err)
+
+	defer close()
+
+	gasSpendRepo, err := NewGasSpendRepository(db)
+	assert.Equal(t, nil, err)
+
+	ctx := context.Background()
+
+	spent, err := gasSpendRepo.SpentWithin(ctx, big.NewInt(1), time.Hour)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "0", spent.String())
+
+	// more than a uint64 of wei, which the sum mustn't overflow
+	large, _ := new(big.Int).SetString("20000000000000000000", 10)
+
+	for _, opts := range []relayer.SaveGasSpendOpts{
+		{ChainID: big.NewInt(1), MsgHash: "0x1", TxHash: "0xa", GasUsed: 100000, GasSpent: large},
+		{ChainID: big.NewInt(1), MsgHash: "0x2", TxHash: "0xb", GasUsed: 50000, GasSpent: big.NewInt(500)},
+		// the first again, as if it were reconciled after a restart
+		{ChainID: big.NewInt(1), MsgHash: "0x1", TxHash: "0xa", GasUsed: 100000, GasSpent: large},
+		{ChainID: big.NewInt(2), MsgHash: "0x3", TxHash: "0xc", GasUsed: 50000, GasSpent: big.NewInt(700)},
+	} {
+		assert.Equal(t, nil, gasSpendRepo.Save(ctx, opts))
+	}
+
+	spent, err = gasSpendRepo.SpentWithin(ctx, big.NewInt(1), time.Hour)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "20000000000000000500", spent.String())
+
+	spent, err = gasSpendRepo.SpentWithin(ctx, big.NewInt(2), time.Hour)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "700", spent.String())
+}
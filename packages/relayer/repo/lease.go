@@ -0,0 +1,76 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type LeaseRepository struct {
+	db relayer.DB
+}
+
+func NewLeaseRepository(db relayer.DB) (*LeaseRepository, error) {
+	if db == nil {
+		return nil, relayer.ErrNoDB
+	}
+
+	return &LeaseRepository{
+		db: db,
+	}, nil
+}
+
+func (r *LeaseRepository) startQuery(ctx context.Context) *gorm.DB {
+	return r.db.GormDB().WithContext(ctx).Table("leases")
+}
+
+// TryAcquire upserts the lease, only taking it over from another holder once it has expired.
+// MySQL applies the assignments in order, so expires_at is only moved on when holder is,
+// or already was, the one acquiring it.
+func (r *LeaseRepository) TryAcquire(
+	ctx context.Context,
+	name string,
+	holder string,
+	ttl time.Duration,
+) (bool, error) {
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
+	if err := r.db.GormDB().WithContext(ctx).Exec(
+		"INSERT INTO leases (name, holder, expires_at) VALUES (?, ?, NOW(3) + INTERVAL ? MICROSECOND) "+
+			"ON DUPLICATE KEY UPDATE "+
+			"holder = IF(holder = VALUES(holder) OR expires_at < NOW(3), VALUES(holder), holder), "+
+			"expires_at = IF(holder = VALUES(holder), VALUES(expires_at), expires_at)",
+		name,
+		holder,
+		ttl.Microseconds(),
+	).Error; err != nil {
+		return false, errors.Wrap(err, "r.db.Exec")
+	}
+
+	var current string
+
+	// read from the primary, a replica could still have the previous holder
+	if err := r.startQuery(ctx).Select("holder").Where("name = ?", name).Scan(&current).Error; err != nil {
+		return false, errors.Wrap(err, "r.startQuery.Scan")
+	}
+
+	return current == holder, nil
+}
+
+// Release expires the lease if holder has it. Another holder's lease is left alone.
+func (r *LeaseRepository) Release(ctx context.Context, name string, holder string) error {
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
+	if err := r.startQuery(ctx).
+		Where("name = ? AND holder = ?", name, holder).
+		Update("expires_at", gorm.Expr("NOW(3)")).Error; err != nil {
+		return errors.Wrap(err, "r.startQuery.Update")
+	}
+
+	return nil
+}
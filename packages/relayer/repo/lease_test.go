@@ -0,0 +1,83 @@
+package repo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/db"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func Test_NewLeaseRepo(t *testing.T) {
+	tests := []struct {
+		name    string
+		db      relayer.DB
+		wantErr error
+	}{
+		{
+			"success",
+			&db.DB{},
+			nil,
+		},
+		{
+			"noDb",
+			nil,
+			relayer.ErrNoDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewLeaseRepository(tt.db)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestIntegration_Lease_TryAcquireAndRelease(t *testing.T) {
+	db, close, err := testMysql(t)
+	assert.Equal(t, nil, err)
+
+	defer close()
+
+	leaseRepo, err := NewLeaseRepository(db)
+	assert.Equal(t, nil, err)
+
+	ctx := context.Background()
+
+	acquired, err := leaseRepo.TryAcquire(ctx, "relayer", "a", time.Second)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, acquired)
+
+	// held by a until it expires
+	acquired, err = leaseRepo.TryAcquire(ctx, "relayer", "b", time.Second)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, acquired)
+
+	acquired, err = leaseRepo.TryAcquire(ctx, "relayer", "a", time.Second)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, acquired)
+
+	time.Sleep(1100 * time.Millisecond)
+
+	acquired, err = leaseRepo.TryAcquire(ctx, "relayer", "b", time.Minute)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, acquired)
+
+	// only the holder can release it
+	assert.Equal(t, nil, leaseRepo.Release(ctx, "relayer", "a"))
+
+	acquired, err = leaseRepo.TryAcquire(ctx, "relayer", "a", time.Minute)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, acquired)
+
+	assert.Equal(t, nil, leaseRepo.Release(ctx, "relayer", "b"))
+
+	time.Sleep(10 * time.Millisecond)
+
+	acquired, err = leaseRepo.TryAcquire(ctx, "relayer", "a", time.Minute)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, acquired)
+}
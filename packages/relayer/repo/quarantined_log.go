@@ -0,0 +1,56 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type QuarantinedLogRepository struct {
+	db relayer.DB
+}
+
+func NewQuarantinedLogRepository(db relayer.DB) (*QuarantinedLogRepository, error) {
+	if db == nil {
+		return nil, relayer.ErrNoDB
+	}
+
+	return &QuarantinedLogRepository{
+		db: db,
+	}, nil
+}
+
+func (r *QuarantinedLogRepository) startQuery(ctx context.Context) *gorm.DB {
+	return r.db.GormDB().WithContext(ctx).Table("quarantined_logs")
+}
+
+// Save stores a QuarantinedLog, unless the log was already quarantined, i.e. when its block is
+// filtered again after a restart or by a reindex.
+func (r *QuarantinedLogRepository) Save(ctx context.Context, opts relayer.SaveQuarantinedLogOpts) error {
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
+	l := &relayer.QuarantinedLog{
+		ChainID:     opts.ChainID.Int64(),
+		EventName:   opts.EventName,
+		BlockNumber: opts.BlockNumber,
+		BlockHash:   opts.BlockHash,
+		TxHash:      opts.TxHash,
+		LogIndex:    opts.LogIndex,
+		Topics:      opts.Topics,
+		Data:        opts.Data,
+		Error:       opts.Error,
+	}
+
+	if err := r.startQuery(ctx).
+		Omit("created_at").
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(l).Error; err != nil {
+		return errors.Wrap(err, "r.startQuery.Create")
+	}
+
+	return nil
+}
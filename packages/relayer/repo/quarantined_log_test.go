@@ -0,0 +1,70 @@
+package repo
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/db"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func Test_NewQuarantinedLogRepo(t *testing.T) {
+	tests := []struct {
+		name    string
+		db      relayer.DB
+		wantErr error
+	}{
+		{
+			"success",
+			&db.DB{},
+			nil,
+		},
+		{
+			"noDb",
+			nil,
+			relayer.ErrNoDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewQuarantinedLogRepository(tt.db)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestIntegration_QuarantinedLog_Save(t *testing.T) {
+	db, close, err := testMysql(t)
+	assert.Equal(t, nil, err)
+
+	defer close()
+
+	quarantinedLogRepo, err := NewQuarantinedLogRepository(db)
+	assert.Equal(t, nil, err)
+
+	opts := relayer.SaveQuarantinedLogOpts{
+		ChainID:     big.NewInt(1),
+		EventName:   relayer.EventNameMessageSent,
+		BlockNumber: 5,
+		BlockHash:   "0x5",
+		TxHash:      "0xa",
+		LogIndex:    2,
+		Topics:      "0x1,0x2",
+		Data:        "0x0102",
+		Error:       "abi: cannot marshal in to go type: length insufficient",
+	}
+
+	// the second is the same log filtered again, and isn't stored twice
+	assert.Equal(t, nil, quarantinedLogRepo.Save(context.Background(), opts))
+	assert.Equal(t, nil, quarantinedLogRepo.Save(context.Background(), opts))
+
+	var logs []relayer.QuarantinedLog
+
+	assert.Equal(t, nil, db.GormDB().Table("quarantined_logs").Find(&logs).Error)
+	assert.Equal(t, 1, len(logs))
+	assert.Equal(t, "0x0102", logs[0].Data)
+	assert.Equal(t, uint(2), logs[0].LogIndex)
+}
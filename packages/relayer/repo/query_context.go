@@ -0,0 +1,17 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+)
+
+// queryContext bounds ctx by the db's query timeout, so a slow query or an exhausted
+// connection pool fails the call instead of hanging it.
+func queryContext(ctx context.Context, db relayer.DB) (context.Context, context.CancelFunc) {
+	if timeout := db.QueryTimeout(); timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+
+	return context.WithCancel(ctx)
+}
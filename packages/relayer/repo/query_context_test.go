@@ -0,0 +1,43 @@
+package repo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/db"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func Test_queryContext(t *testing.T) {
+	ctx, cancel := queryContext(context.Background(), db.New(&gorm.DB{}))
+	defer cancel()
+
+	_, ok := ctx.Deadline()
+	assert.False(t, ok)
+
+	ctx, cancel = queryContext(context.Background(), db.New(&gorm.DB{}).WithQueryTimeout(time.Second))
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Second), deadline, 100*time.Millisecond)
+}
+
+func TestIntegration_queryContext_timesOut(t *testing.T) {
+	d, close, err := testMysql(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer close()
+
+	d.(*db.DB).WithQueryTimeout(100 * time.Millisecond)
+
+	ctx, cancel := queryContext(context.Background(), d)
+	defer cancel()
+
+	err = d.GormDB().WithContext(ctx).Exec("SELECT SLEEP(2)").Error
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,20 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"gorm.io/gorm"
+)
+
+// readDB is where read-only queries go: the read replica, unless ctx was pinned to the
+// primary with relayer.WithPrimaryReads. Reads that are part of a write, or that the
+// indexer relies on to not go backwards, like the processed block checkpoint, always
+// use the primary.
+func readDB(ctx context.Context, db relayer.DB) *gorm.DB {
+	if relayer.PrimaryReads(ctx) {
+		return db.GormDB()
+	}
+
+	return db.ReadGormDB()
+}
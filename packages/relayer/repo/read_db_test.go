@@ -0,0 +1,23 @@
+package repo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/db"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func Test_readDB(t *testing.T) {
+	primary := &gorm.DB{}
+	replica := &gorm.DB{}
+
+	assert.Same(t, primary, readDB(context.Background(), db.New(primary)))
+
+	d := db.New(primary).WithReplica(replica)
+
+	assert.Same(t, replica, readDB(context.Background(), d))
+	assert.Same(t, primary, readDB(relayer.WithPrimaryReads(context.Background()), d))
+}
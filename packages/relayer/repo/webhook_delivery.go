@@ -0,0 +1,79 @@
+package repo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookDeliveryRepository struct {
+	db relayer.DB
+}
+
+// webhookDelivery is a row of webhook_deliveries. URLs are too long to index, so
+// deliveries are unique by the key and the URL's sha256.
+type webhookDelivery struct {
+	ID             int
+	IdempotencyKey string
+	URL            string
+	URLHash        string
+}
+
+func NewWebhookDeliveryRepository(db relayer.DB) (*WebhookDeliveryRepository, error) {
+	if db == nil {
+		return nil, relayer.ErrNoDB
+	}
+
+	return &WebhookDeliveryRepository{
+		db: db,
+	}, nil
+}
+
+// startQuery always uses the primary, a delivery read from a lagging replica would be sent twice
+func (r *WebhookDeliveryRepository) startQuery(ctx context.Context) *gorm.DB {
+	return r.db.GormDB().WithContext(ctx).Table("webhook_deliveries")
+}
+
+func (r *WebhookDeliveryRepository) IsDelivered(ctx context.Context, idempotencyKey string, url string) (bool, error) {
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
+	var count int64
+
+	if err := r.startQuery(ctx).
+		Where("idempotency_key = ? AND url_hash = ?", idempotencyKey, urlHash(url)).
+		Count(&count).Error; err != nil {
+		return false, errors.Wrap(err, "r.startQuery.Count")
+	}
+
+	return count > 0, nil
+}
+
+// SaveDelivered records a delivery, recording it again is a no-op.
+func (r *WebhookDeliveryRepository) SaveDelivered(ctx context.Context, idempotencyKey string, url string) error {
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
+	d := &webhookDelivery{
+		IdempotencyKey: idempotencyKey,
+		URL:            url,
+		URLHash:        urlHash(url),
+	}
+
+	if err := r.startQuery(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(d).Error; err != nil {
+		return errors.Wrap(err, "r.startQuery.Create")
+	}
+
+	return nil
+}
+
+func urlHash(url string) string {
+	sum := sha256.Sum256([]byte(url))
+
+	return hex.EncodeToString(sum[:])
+}
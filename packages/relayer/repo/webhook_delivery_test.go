@@ -0,0 +1,74 @@
+package repo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/db"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func Test_NewWebhookDeliveryRepo(t *testing.T) {
+	tests := []struct {
+		name    string
+		db      relayer.DB
+		wantErr error
+	}{
+		{
+			"success",
+			&db.DB{},
+			nil,
+		},
+		{
+			"noDb",
+			nil,
+			relayer.ErrNoDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewWebhookDeliveryRepository(tt.db)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestIntegration_WebhookDelivery_SaveDelivered(t *testing.T) {
+	db, close, err := testMysql(t)
+	assert.Equal(t, nil, err)
+
+	defer close()
+
+	deliveryRepo, err := NewWebhookDeliveryRepository(db)
+	assert.Equal(t, nil, err)
+
+	delivered, err := deliveryRepo.IsDelivered(context.Background(), "0x1:done", "https://example.com/hook")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, delivered)
+
+	assert.Equal(t, nil, deliveryRepo.SaveDelivered(context.Background(), "0x1:done", "https://example.com/hook"))
+
+	// saving twice is a no-op
+	assert.Equal(t, nil, deliveryRepo.SaveDelivered(context.Background(), "0x1:done", "https://example.com/hook"))
+
+	delivered, err = deliveryRepo.IsDelivered(context.Background(), "0x1:done", "https://example.com/hook")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, delivered)
+
+	// the same change to another url, or another change to the url, is still to be delivered
+	delivered, err = deliveryRepo.IsDelivered(context.Background(), "0x1:done", "https://example.com/other")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, delivered)
+
+	delivered, err = deliveryRepo.IsDelivered(context.Background(), "0x1:retriable", "https://example.com/hook")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, delivered)
+}
+
+func Test_urlHash(t *testing.T) {
+	assert.Equal(t, 64, len(urlHash("https://example.com/hook")))
+	assert.Equal(t, urlHash("https://example.com/hook"), urlHash("https://example.com/hook"))
+	assert.NotEqual(t, urlHash("https://example.com/hook"), urlHash("https://example.com/other"))
+}
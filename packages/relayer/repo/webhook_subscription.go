@@ -0,0 +1,72 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type WebhookSubscriptionRepository struct {
+	db relayer.DB
+}
+
+func NewWebhookSubscriptionRepository(db relayer.DB) (*WebhookSubscriptionRepository, error) {
+	if db == nil {
+		return nil, relayer.ErrNoDB
+	}
+
+	return &WebhookSubscriptionRepository{
+		db: db,
+	}, nil
+}
+
+func (r *WebhookSubscriptionRepository) startQuery(ctx context.Context) *gorm.DB {
+	return r.db.GormDB().WithContext(ctx).Table("webhook_subscriptions")
+}
+
+func (r *WebhookSubscriptionRepository) startReadQuery(ctx context.Context) *gorm.DB {
+	return readDB(ctx, r.db).WithContext(ctx).Table("webhook_subscriptions")
+}
+
+func (r *WebhookSubscriptionRepository) Save(
+	ctx context.Context,
+	opts relayer.SaveWebhookSubscriptionOpts,
+) (*relayer.WebhookSubscription, error) {
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
+	s := &relayer.WebhookSubscription{
+		MessageOwner: opts.MessageOwner.Hex(),
+		URL:          opts.URL,
+		Statuses:     relayer.JoinEventStatuses(opts.Statuses),
+	}
+
+	if err := r.startQuery(ctx).Create(s).Error; err != nil {
+		return nil, errors.Wrap(err, "r.startQuery.Create")
+	}
+
+	return s, nil
+}
+
+// FindAllByMessageOwner finds subscriptions for a message owner, which is stored checksummed
+// the same way events store it.
+func (r *WebhookSubscriptionRepository) FindAllByMessageOwner(
+	ctx context.Context,
+	messageOwner string,
+) ([]*relayer.WebhookSubscription, error) {
+	ctx, cancel := queryContext(ctx, r.db)
+	defer cancel()
+
+	subs := make([]*relayer.WebhookSubscription, 0)
+
+	if err := r.startReadQuery(ctx).
+		Where("message_owner = ?", common.HexToAddress(messageOwner).Hex()).
+		Find(&subs).Error; err != nil {
+		return nil, errors.Wrap(err, "r.startQuery.Find")
+	}
+
+	return subs, nil
+}
@@ -0,0 +1,65 @@
+package repo
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/db"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func Test_NewWebhookSubscriptionRepo(t *testing.T) {
+	tests := []struct {
+		name    string
+		db      relayer.DB
+		wantErr error
+	}{
+		{
+			"success",
+			&db.DB{},
+			nil,
+		},
+		{
+			"noDb",
+			nil,
+			relayer.ErrNoDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewWebhookSubscriptionRepository(tt.db)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestIntegration_WebhookSubscription_FindAllByMessageOwner(t *testing.T) {
+	db, close, err := testMysql(t)
+	assert.Equal(t, nil, err)
+
+	defer close()
+
+	subRepo, err := NewWebhookSubscriptionRepository(db)
+	assert.Equal(t, nil, err)
+
+	_, err = subRepo.Save(context.Background(), relayer.SaveWebhookSubscriptionOpts{
+		MessageOwner: addr,
+		URL:          "https://example.com/hook",
+		Statuses:     []relayer.EventStatus{relayer.EventStatusDone},
+	})
+	assert.Equal(t, nil, err)
+
+	// lookups are case insensitive
+	subs, err := subRepo.FindAllByMessageOwner(context.Background(), strings.ToLower(addr.Hex()))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(subs))
+	assert.Equal(t, "https://example.com/hook", subs[0].URL)
+	assert.Equal(t, "done", subs[0].Statuses)
+
+	subs, err = subRepo.FindAllByMessageOwner(context.Background(), "0x63FaC9201494f0bd17B9892B9fae4d52fe3BD377")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(subs))
+}
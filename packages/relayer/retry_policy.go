@@ -0,0 +1,145 @@
+package relayer
+
+import (
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const retryUntilSynced = "sync"
+
+// RetryDelay is how long a message waits to be retried after failing for a RetryReason
+type RetryDelay struct {
+	// UntilSynced waits for the destination chain to sync another source block instead of Delay
+	UntilSynced bool
+	Delay       time.Duration
+	// Jitter adds up to this much to Delay at random, so messages that failed together aren't all
+	// retried together
+	Jitter time.Duration
+}
+
+func (d RetryDelay) String() string {
+	if d.UntilSynced {
+		return retryUntilSynced
+	}
+
+	if d.Jitter > 0 {
+		return d.Delay.String() + "+" + d.Jitter.String()
+	}
+
+	return d.Delay.String()
+}
+
+// RetryPolicy is the RetryDelay for each RetryReason, a reason missing from it isn't retried
+type RetryPolicy map[RetryReason]RetryDelay
+
+// DefaultRetryPolicy waits for the next sync for messages not synced yet, a while for gas to come
+// down, and retries anything else straight away, with jitter.
+var DefaultRetryPolicy = RetryPolicy{
+	RetryReasonNotSynced:  {UntilSynced: true},
+	RetryReasonGasTooHigh: {Delay: 30 * time.Second},
+	RetryReasonTransient:  {Jitter: 5 * time.Second},
+}
+
+// ParseRetryPolicy parses comma separated "reason=sync" or "reason=delay[+jitter]", i.e.
+// "gas-too-high=1m,transient=0s+10s", overriding DefaultRetryPolicy's delays for those reasons.
+// Empty is DefaultRetryPolicy.
+func ParseRetryPolicy(s string) (RetryPolicy, error) {
+	policy := make(RetryPolicy, len(DefaultRetryPolicy))
+	for reason, delay := range DefaultRetryPolicy {
+		policy[reason] = delay
+	}
+
+	if s == "" {
+		return policy, nil
+	}
+
+	for _, entry := range strings.Split(s, ",") {
+		reason, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || !isRetryReason(RetryReason(reason)) {
+			return nil, errors.Wrapf(ErrInvalidRetryPolicy, "entry: %v", entry)
+		}
+
+		delay, err := parseRetryDelay(value)
+		if err != nil {
+			return nil, errors.Wrapf(ErrInvalidRetryPolicy, "entry: %v", entry)
+		}
+
+		policy[RetryReason(reason)] = delay
+	}
+
+	return policy, nil
+}
+
+func isRetryReason(reason RetryReason) bool {
+	for _, r := range RetryReasons {
+		if r == reason {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseRetryDelay(s string) (RetryDelay, error) {
+	if s == retryUntilSynced {
+		return RetryDelay{UntilSynced: true}, nil
+	}
+
+	delayStr, jitterStr, hasJitter := strings.Cut(s, "+")
+
+	delay, err := time.ParseDuration(delayStr)
+	if err != nil || delay < 0 {
+		return RetryDelay{}, errors.Errorf("invalid delay: %v", delayStr)
+	}
+
+	d := RetryDelay{Delay: delay}
+
+	if hasJitter {
+		jitter, err := time.ParseDuration(jitterStr)
+		if err != nil || jitter < 0 {
+			return RetryDelay{}, errors.Errorf("invalid jitter: %v", jitterStr)
+		}
+
+		d.Jitter = jitter
+	}
+
+	return d, nil
+}
+
+// NextRetryAt is when a message that failed for reason at now is due to be retried. It's nil, and
+// false, if reason isn't retried, and nil, and true, if it's retried once the destination chain
+// syncs again.
+func (p RetryPolicy) NextRetryAt(reason RetryReason, now time.Time) (*time.Time, bool) {
+	d, ok := p[reason]
+	if !ok {
+		return nil, false
+	}
+
+	if d.UntilSynced {
+		return nil, true
+	}
+
+	at := now.Add(d.Delay)
+
+	if d.Jitter > 0 {
+		// nolint: gosec
+		at = at.Add(time.Duration(rand.Int63n(int64(d.Jitter))))
+	}
+
+	return &at, true
+}
+
+func (p RetryPolicy) String() string {
+	entries := make([]string, 0, len(p))
+
+	for _, reason := range RetryReasons {
+		if d, ok := p[reason]; ok {
+			entries = append(entries, string(reason)+"="+d.String())
+		}
+	}
+
+	return strings.Join(entries, ",")
+}
@@ -0,0 +1,93 @@
+package relayer
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseRetryPolicy(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    RetryPolicy
+		wantErr bool
+	}{
+		{"", DefaultRetryPolicy, false},
+		{
+			"gas-too-high=1m",
+			RetryPolicy{
+				RetryReasonNotSynced:  {UntilSynced: true},
+				RetryReasonGasTooHigh: {Delay: time.Minute},
+				RetryReasonTransient:  {Jitter: 5 * time.Second},
+			},
+			false,
+		},
+		{
+			"not-synced=10s, transient=1s+2s,gas-too-high=sync",
+			RetryPolicy{
+				RetryReasonNotSynced:  {Delay: 10 * time.Second},
+				RetryReasonGasTooHigh: {UntilSynced: true},
+				RetryReasonTransient:  {Delay: time.Second, Jitter: 2 * time.Second},
+			},
+			false,
+		},
+		{"unknown=1s", nil, true},
+		{"transient", nil, true},
+		{"transient=soon", nil, true},
+		{"transient=-1s", nil, true},
+		{"transient=1s+", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParseRetryPolicy(tt.s)
+			assert.Equal(t, tt.wantErr, errors.Is(err, ErrInvalidRetryPolicy))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_ParseRetryPolicy_doesntChangeDefault(t *testing.T) {
+	_, err := ParseRetryPolicy("transient=1h")
+	assert.Nil(t, err)
+
+	assert.Equal(t, 5*time.Second, DefaultRetryPolicy[RetryReasonTransient].Jitter)
+	assert.Equal(t, time.Duration(0), DefaultRetryPolicy[RetryReasonTransient].Delay)
+}
+
+func Test_RetryPolicy_NextRetryAt(t *testing.T) {
+	now := time.Unix(1000, 0)
+
+	policy := RetryPolicy{
+		RetryReasonNotSynced:  {UntilSynced: true},
+		RetryReasonGasTooHigh: {Delay: 30 * time.Second},
+		RetryReasonTransient:  {Delay: time.Second, Jitter: 5 * time.Second},
+	}
+
+	at, ok := policy.NextRetryAt(RetryReasonNotSynced, now)
+	assert.True(t, ok)
+	assert.Nil(t, at)
+
+	at, ok = policy.NextRetryAt(RetryReasonGasTooHigh, now)
+	assert.True(t, ok)
+	assert.Equal(t, now.Add(30*time.Second), *at)
+
+	for i := 0; i < 20; i++ {
+		at, ok = policy.NextRetryAt(RetryReasonTransient, now)
+		assert.True(t, ok)
+		assert.False(t, at.Before(now.Add(time.Second)))
+		assert.True(t, at.Before(now.Add(6*time.Second)))
+	}
+
+	delete(policy, RetryReasonTransient)
+
+	at, ok = policy.NextRetryAt(RetryReasonTransient, now)
+	assert.False(t, ok)
+	assert.Nil(t, at)
+}
+
+func Test_RetryPolicy_String(t *testing.T) {
+	assert.Equal(t, "not-synced=sync,gas-too-high=30s,transient=0s+5s", DefaultRetryPolicy.String())
+}
@@ -0,0 +1,11 @@
+package runway
+
+import "github.com/pkg/errors"
+
+var (
+	ErrNoGasSpendRepository = errors.New("runway: a gas spend repository is required")
+	ErrNoClient             = errors.New("runway: a client is required")
+	ErrNoAccounts           = errors.New("runway: at least one account is required")
+	ErrInvalidWindow        = errors.New("runway: window must be at least an hour")
+	ErrInvalidInterval      = errors.New("runway: interval must be positive")
+)
@@ -0,0 +1,175 @@
+package runway
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+)
+
+// Client is the chain's RPC connection, as the estimator queries it
+type Client interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+}
+
+// Estimator works out how long the relayer keys' balance on a chain lasts, at the hourly rate gas
+// was spent there over a rolling window. A window longer than the relayer has been recording gas
+// spent understates the rate, so the runway is overstated until it's been running a whole window.
+type Estimator struct {
+	gasSpendRepo relayer.GasSpendRepository
+	client       Client
+	accounts     []common.Address
+	window       time.Duration
+	interval     time.Duration
+
+	chainIDMu sync.Mutex
+	// chainID is the client's, cached the first time it's needed
+	chainID *big.Int
+}
+
+type NewEstimatorOpts struct {
+	GasSpendRepo relayer.GasSpendRepository
+	Client       Client
+	// Accounts are the relayer keys, whose balances are summed
+	Accounts []common.Address
+	// Window is how far back the gas spent is averaged over, at least an hour
+	Window time.Duration
+	// Interval is how often Start refreshes the relayer_gas_runway_hours metric
+	Interval time.Duration
+}
+
+func NewEstimator(opts NewEstimatorOpts) (*Estimator, error) {
+	if opts.GasSpendRepo == nil {
+		return nil, ErrNoGasSpendRepository
+	}
+
+	if opts.Client == nil {
+		return nil, ErrNoClient
+	}
+
+	if len(opts.Accounts) == 0 {
+		return nil, ErrNoAccounts
+	}
+
+	if opts.Window < time.Hour {
+		return nil, ErrInvalidWindow
+	}
+
+	if opts.Interval <= 0 {
+		return nil, ErrInvalidInterval
+	}
+
+	return &Estimator{
+		gasSpendRepo: opts.GasSpendRepo,
+		client:       opts.Client,
+		accounts:     opts.Accounts,
+		window:       opts.Window,
+		interval:     opts.Interval,
+	}, nil
+}
+
+// Start estimates the runway straight away, then every interval until ctx is done, so the metric
+// is kept up to date without anything asking for it
+func (e *Estimator) Start(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := e.Runway(ctx); err != nil {
+			log.Warnf("gas runway: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Runway estimates the runway now, and updates the relayer_gas_runway_hours metric with it, which
+// is +Inf while no gas was spent in the window
+func (e *Estimator) Runway(ctx context.Context) (*relayer.Runway, error) {
+	chainID, err := e.getChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	balance := new(big.Int)
+
+	for _, account := range e.accounts {
+		b, err := e.client.BalanceAt(ctx, account, nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "e.client.BalanceAt(%v)", account.Hex())
+		}
+
+		balance.Add(balance, b)
+	}
+
+	spent, err := e.gasSpendRepo.SpentWithin(ctx, chainID, e.window)
+	if err != nil {
+		return nil, errors.Wrap(err, "e.gasSpendRepo.SpentWithin")
+	}
+
+	r := estimate(chainID, balance, spent, e.window)
+
+	hoursRemaining := math.Inf(1)
+	if r.HoursRemaining != nil {
+		hoursRemaining = *r.HoursRemaining
+	}
+
+	relayer.GasRunwayHours.WithLabelValues(chainID.String()).Set(hoursRemaining)
+
+	return r, nil
+}
+
+func (e *Estimator) getChainID(ctx context.Context) (*big.Int, error) {
+	e.chainIDMu.Lock()
+	defer e.chainIDMu.Unlock()
+
+	if e.chainID == nil {
+		chainID, err := e.client.ChainID(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "e.client.ChainID")
+		}
+
+		e.chainID = chainID
+	}
+
+	return e.chainID, nil
+}
+
+// estimate is how long balance lasts, at the hourly average of spent over window
+func estimate(chainID *big.Int, balance *big.Int, spent *big.Int, window time.Duration) *relayer.Runway {
+	hours := window.Hours()
+
+	perHour, _ := new(big.Float).Quo(new(big.Float).SetInt(spent), big.NewFloat(hours)).Int(nil)
+
+	r := &relayer.Runway{
+		ChainID:          chainID.Int64(),
+		Balance:          balance.String(),
+		WindowInHours:    hours,
+		GasSpentInWindow: spent.String(),
+		GasSpentPerHour:  perHour.String(),
+	}
+
+	if spent.Sign() > 0 {
+		// balance / (spent / hours), without rounding the rate
+		remaining, _ := new(big.Float).Quo(
+			new(big.Float).Mul(new(big.Float).SetInt(balance), big.NewFloat(hours)),
+			new(big.Float).SetInt(spent),
+		).Float64()
+
+		r.HoursRemaining = &remaining
+	}
+
+	return r
+}
@@ -0,0 +1,157 @@
+package runway
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+)
+
+type fakeClient struct {
+	balances map[common.Address]*big.Int
+	fail     bool
+}
+
+func (c *fakeClient) ChainID(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(167001), nil
+}
+
+func (c *fakeClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	if c.fail {
+		return nil, errors.New("fail")
+	}
+
+	return c.balances[account], nil
+}
+
+var (
+	account1 = common.HexToAddress("0x01")
+	account2 = common.HexToAddress("0x02")
+)
+
+func Test_NewEstimator(t *testing.T) {
+	valid := NewEstimatorOpts{
+		GasSpendRepo: mock.NewGasSpendRepository(),
+		Client:       &fakeClient{},
+		Accounts:     []common.Address{account1},
+		Window:       24 * time.Hour,
+		Interval:     time.Minute,
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(o *NewEstimatorOpts)
+		wantErr error
+	}{
+		{"success", func(o *NewEstimatorOpts) {}, nil},
+		{"noGasSpendRepo", func(o *NewEstimatorOpts) { o.GasSpendRepo = nil }, ErrNoGasSpendRepository},
+		{"noClient", func(o *NewEstimatorOpts) { o.Client = nil }, ErrNoClient},
+		{"noAccounts", func(o *NewEstimatorOpts) { o.Accounts = nil }, ErrNoAccounts},
+		{"windowUnderAnHour", func(o *NewEstimatorOpts) { o.Window = time.Minute }, ErrInvalidWindow},
+		{"invalidInterval", func(o *NewEstimatorOpts) { o.Interval = 0 }, ErrInvalidInterval},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := valid
+			tt.modify(&opts)
+
+			_, err := NewEstimator(opts)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_Runway(t *testing.T) {
+	gasSpendRepo := mock.NewGasSpendRepository()
+
+	e, err := NewEstimator(NewEstimatorOpts{
+		GasSpendRepo: gasSpendRepo,
+		Client: &fakeClient{balances: map[common.Address]*big.Int{
+			account1: big.NewInt(600),
+			account2: big.NewInt(400),
+		}},
+		Accounts: []common.Address{account1, account2},
+		Window:   10 * time.Hour,
+		Interval: time.Minute,
+	})
+	assert.Nil(t, err)
+
+	// nothing spent yet, so the balance lasts indefinitely
+	r, err := e.Runway(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "1000", r.Balance)
+	assert.Equal(t, "0", r.GasSpentPerHour)
+	assert.Nil(t, r.HoursRemaining)
+	assert.True(t, math.IsInf(testutil.ToFloat64(relayer.GasRunwayHours.WithLabelValues("167001")), 1))
+
+	for _, opts := range []relayer.SaveGasSpendOpts{
+		{ChainID: big.NewInt(167001), TxHash: "0xa", GasSpent: big.NewInt(150)},
+		{ChainID: big.NewInt(167001), TxHash: "0xb", GasSpent: big.NewInt(50)},
+		// spent on another chain, from other accounts
+		{ChainID: big.NewInt(1), TxHash: "0xc", GasSpent: big.NewInt(1000)},
+	} {
+		assert.Nil(t, gasSpendRepo.Save(context.Background(), opts))
+	}
+
+	r, err = e.Runway(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, int64(167001), r.ChainID)
+	assert.Equal(t, float64(10), r.WindowInHours)
+	assert.Equal(t, "200", r.GasSpentInWindow)
+	assert.Equal(t, "20", r.GasSpentPerHour)
+	assert.Equal(t, float64(50), *r.HoursRemaining)
+	assert.Equal(t, float64(50), testutil.ToFloat64(relayer.GasRunwayHours.WithLabelValues("167001")))
+}
+
+func Test_Runway_errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		client       *fakeClient
+		gasSpendRepo *mock.GasSpendRepository
+	}{
+		{
+			"balance",
+			&fakeClient{fail: true},
+			mock.NewGasSpendRepository(),
+		},
+		{
+			"gasSpent",
+			&fakeClient{balances: map[common.Address]*big.Int{account1: big.NewInt(1)}},
+			&mock.GasSpendRepository{Err: errors.New("fail")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := NewEstimator(NewEstimatorOpts{
+				GasSpendRepo: tt.gasSpendRepo,
+				Client:       tt.client,
+				Accounts:     []common.Address{account1},
+				Window:       time.Hour,
+				Interval:     time.Minute,
+			})
+			assert.Nil(t, err)
+
+			_, err = e.Runway(context.Background())
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func Test_estimate_fractionalRate(t *testing.T) {
+	// 7 wei over 3 hours is 2.33 an hour, which the runway isn't rounded to
+	r := estimate(big.NewInt(1), big.NewInt(7), big.NewInt(7), 3*time.Hour)
+
+	assert.Equal(t, "2", r.GasSpentPerHour)
+	assert.Equal(t, float64(3), *r.HoursRemaining)
+}
@@ -0,0 +1,19 @@
+package relayer
+
+// SyncProgress is how far an indexer has got catching up with its chain's head
+type SyncProgress struct {
+	ProcessedHeight uint64 `json:"processedHeight"`
+	HeadHeight      uint64 `json:"headHeight"`
+	// BlocksPerSecond is how fast the gap to the head is closing over a rolling window,
+	// net of the head moving on, negative if it's growing
+	BlocksPerSecond float64 `json:"blocksPerSecond"`
+	// ETASeconds is how long until caught up at that rate, nil if the gap isn't closing
+	ETASeconds *float64 `json:"etaSeconds"`
+	// CaughtUp is whether the indexer is within the confirmation depth of the head
+	CaughtUp bool `json:"caughtUp"`
+}
+
+// SyncProgressReporter reports an indexer's catch up progress
+type SyncProgressReporter interface {
+	SyncProgress() SyncProgress
+}
@@ -0,0 +1,17 @@
+package relayer
+
+import (
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/contracts/bridge"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// TxBuilder assembles and sends the transaction that processes a message on the destination
+// bridge. opts already has the nonce, gas limit and fees the processor chose for calling the
+// bridge directly, which the destination Bridge itself does.
+type TxBuilder interface {
+	ProcessMessage(opts *bind.TransactOpts, message bridge.IBridgeMessage, proof []byte) (*types.Transaction, error)
+	// ResetNonce forgets any nonce of its own the TxBuilder assigned ahead of the chain's, when
+	// a transaction it sent was never mined and the processor starts again from the node's.
+	ResetNonce()
+}
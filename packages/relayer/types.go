@@ -133,9 +133,14 @@ func DecodeMessageSentData(event *bridge.BridgeMessageSent) (EventType, *Canonic
 			return eventType, nil, big.NewInt(0), errors.Wrap(err, "tokenVaultMD.GetAbi()")
 		}
 
+		// anything that isn't a TokenVault call is an arbitrary cross-chain call
+		if len(event.Message.Data) < 4 {
+			return EventTypeMessageCall, &canonicalToken, event.Message.DepositValue, nil
+		}
+
 		method, err := tokenVaultABI.MethodById(event.Message.Data[:4])
 		if err != nil {
-			return eventType, nil, big.NewInt(0), errors.Wrap(err, "tokenVaultABI.MethodById")
+			return EventTypeMessageCall, &canonicalToken, event.Message.DepositValue, nil
 		}
 
 		inputsMap := make(map[string]interface{})
@@ -188,6 +188,19 @@ func Test_DecodeMessageSentData(t *testing.T) {
 			big.NewInt(1),
 			nil,
 		},
+		{
+			"messageCall",
+			&bridge.BridgeMessageSent{
+				Message: bridge.IBridgeMessage{
+					DepositValue: big.NewInt(2),
+					Data:         common.Hex2Bytes("a9059cbb"),
+				},
+			},
+			EventTypeMessageCall,
+			&CanonicalToken{},
+			big.NewInt(2),
+			nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,37 @@
+package relayer
+
+import "runtime"
+
+// The build's version, commit and date, injected at build time with i.e.
+// -ldflags "-X github.com/MXCzkEVM/mxc-mono/packages/relayer.Version=v0.6.0", dev if they aren't.
+var (
+	Version   = "dev"
+	GitCommit = "dev"
+	BuildDate = "dev"
+)
+
+// BuildInfo is which build of the relayer is running
+type BuildInfo struct {
+	Version   string `json:"version"`
+	GitCommit string `json:"gitCommit"`
+	GoVersion string `json:"goVersion"`
+	BuildDate string `json:"buildDate"`
+}
+
+// CurrentBuildInfo returns the running build's BuildInfo
+func CurrentBuildInfo() BuildInfo {
+	return BuildInfo{
+		Version:   Version,
+		GitCommit: GitCommit,
+		GoVersion: runtime.Version(),
+		BuildDate: BuildDate,
+	}
+}
+
+// RecordBuildInfo sets relayer_build_info for the running build, so dashboards can group
+// instances by it.
+func RecordBuildInfo() {
+	info := CurrentBuildInfo()
+
+	RelayerBuildInfo.WithLabelValues(info.Version, info.GitCommit, info.GoVersion, info.BuildDate).Set(1)
+}
@@ -0,0 +1,28 @@
+package relayer
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CurrentBuildInfo(t *testing.T) {
+	assert.Equal(t, BuildInfo{
+		Version:   "dev",
+		GitCommit: "dev",
+		GoVersion: runtime.Version(),
+		BuildDate: "dev",
+	}, CurrentBuildInfo())
+}
+
+func Test_RecordBuildInfo(t *testing.T) {
+	RecordBuildInfo()
+
+	assert.Equal(
+		t,
+		float64(1),
+		testutil.ToFloat64(RelayerBuildInfo.WithLabelValues("dev", "dev", runtime.Version(), "dev")),
+	)
+}
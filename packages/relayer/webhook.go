@@ -0,0 +1,103 @@
+package relayer
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// MessageStatusChange is sent to webhook subscribers when a message's status changes on chain
+type MessageStatusChange struct {
+	MsgHash      string
+	Status       EventStatus
+	TxHash       string
+	ChainID      int64
+	MessageOwner string
+	Timestamp    int64
+}
+
+// IdempotencyKey identifies the change, so a consumer can ignore repeat deliveries of it.
+// A message reaches each status once, so its hash and the status are enough.
+func (c MessageStatusChange) IdempotencyKey() string {
+	return c.MsgHash + ":" + c.Status.String()
+}
+
+// StatusChangeNotifier is told about every message status change the indexer observes
+type StatusChangeNotifier interface {
+	Notify(ctx context.Context, change MessageStatusChange) error
+}
+
+// WebhookSubscription is a URL to notify of status changes to messages owned by MessageOwner.
+// Statuses is a comma separated list of EventStatus strings, i.e. "done,retriable",
+// and notifies on every status when empty.
+type WebhookSubscription struct {
+	ID           int    `json:"id"`
+	MessageOwner string `json:"messageOwner"`
+	URL          string `json:"url"`
+	Statuses     string `json:"statuses"`
+}
+
+// SaveWebhookSubscriptionOpts is required to store a new WebhookSubscription
+type SaveWebhookSubscriptionOpts struct {
+	MessageOwner common.Address
+	URL          string
+	Statuses     []EventStatus
+}
+
+// WebhookSubscriptionRepository is used to interact with webhook subscriptions in the store
+type WebhookSubscriptionRepository interface {
+	Save(ctx context.Context, opts SaveWebhookSubscriptionOpts) (*WebhookSubscription, error)
+	FindAllByMessageOwner(ctx context.Context, messageOwner string) ([]*WebhookSubscription, error)
+}
+
+// WebhookDeliveryRepository records which status changes have been delivered to which URLs,
+// so deliveries retried after a restart or a re-index aren't sent again.
+type WebhookDeliveryRepository interface {
+	IsDelivered(ctx context.Context, idempotencyKey string, url string) (bool, error)
+	SaveDelivered(ctx context.Context, idempotencyKey string, url string) error
+}
+
+// ParseEventStatus returns the EventStatus with the given String() representation
+func ParseEventStatus(s string) (EventStatus, error) {
+	for status := EventStatusNew; status <= EventStatusProcessedUnconfirmed; status++ {
+		if status.String() == s {
+			return status, nil
+		}
+	}
+
+	return 0, errors.Errorf("invalid status: %v", s)
+}
+
+// ParseEventStatuses parses a comma separated list of EventStatus strings
+func ParseEventStatuses(s string) ([]EventStatus, error) {
+	statuses := make([]EventStatus, 0)
+
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		status, err := ParseEventStatus(part)
+		if err != nil {
+			return nil, err
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// JoinEventStatuses is the inverse of ParseEventStatuses
+func JoinEventStatuses(statuses []EventStatus) string {
+	parts := make([]string, 0, len(statuses))
+
+	for _, s := range statuses {
+		parts = append(parts, s.String())
+	}
+
+	return strings.Join(parts, ",")
+}
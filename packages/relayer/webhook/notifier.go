@@ -0,0 +1,280 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	// SignatureHeader holds "sha256=" followed by the hex encoded HMAC-SHA256 of the request
+	// body, keyed with the webhook secret. Receivers should recompute and compare it.
+	SignatureHeader = "X-Relayer-Signature"
+	// IdempotencyKeyHeader holds the payload's idempotency key, the same on every delivery of
+	// a status change, so receivers can ignore repeats.
+	IdempotencyKeyHeader = "X-Relayer-Idempotency-Key"
+
+	defaultTimeout    = 10 * time.Second
+	defaultMaxRetries = 5
+	defaultBackoff    = time.Second
+)
+
+// Payload is the JSON body POSTed to webhooks
+type Payload struct {
+	IdempotencyKey string `json:"idempotencyKey"`
+	MsgHash        string `json:"msgHash"`
+	Status         string `json:"status"`
+	TxHash         string `json:"txHash"`
+	ChainID        int64  `json:"chainID"`
+	MessageOwner   string `json:"messageOwner"`
+	Timestamp      int64  `json:"timestamp"`
+}
+
+// Notifier POSTs signed payloads to the configured URL, and to every subscription
+// for the message's owner, when a message's status changes.
+type Notifier struct {
+	url              string
+	secret           []byte
+	statuses         []relayer.EventStatus
+	subscriptionRepo relayer.WebhookSubscriptionRepository
+	deliveryRepo     relayer.WebhookDeliveryRepository
+	httpClient       *http.Client
+	maxRetries       int
+	backoff          time.Duration
+}
+
+type NewNotifierOpts struct {
+	// URL is notified of every message, and is optional
+	URL string
+	// Secret signs every payload
+	Secret string
+	// Statuses filters which statuses notify URL, every status notifies when empty
+	Statuses         []relayer.EventStatus
+	SubscriptionRepo relayer.WebhookSubscriptionRepository
+	// DeliveryRepo records successful deliveries, so they aren't repeated
+	DeliveryRepo relayer.WebhookDeliveryRepository
+	HTTPClient   *http.Client
+	MaxRetries   int
+	Backoff      time.Duration
+}
+
+func NewNotifier(opts NewNotifierOpts) (*Notifier, error) {
+	if opts.Secret == "" {
+		return nil, relayer.ErrNoWebhookSecret
+	}
+
+	if opts.SubscriptionRepo == nil {
+		return nil, relayer.ErrNoWebhookSubscriptionRepository
+	}
+
+	if opts.DeliveryRepo == nil {
+		return nil, relayer.ErrNoWebhookDeliveryRepository
+	}
+
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultTimeout}
+	}
+
+	maxRetries := opts.MaxRetries
+	if maxRetries <= 0 {
+		maxRetries = defaultMaxRetries
+	}
+
+	backoff := opts.Backoff
+	if backoff <= 0 {
+		backoff = defaultBackoff
+	}
+
+	return &Notifier{
+		url:              opts.URL,
+		secret:           []byte(opts.Secret),
+		statuses:         opts.Statuses,
+		subscriptionRepo: opts.SubscriptionRepo,
+		deliveryRepo:     opts.DeliveryRepo,
+		httpClient:       httpClient,
+		maxRetries:       maxRetries,
+		backoff:          backoff,
+	}, nil
+}
+
+// Notify sends the status change to every interested URL it hasn't already been delivered to.
+// A failing URL doesn't stop the others from being notified, the first error is returned
+// once all have been tried.
+//
+// Deliveries are at least once: a change is only recorded as delivered once the URL accepts it,
+// so a crash in between, or a failure to record it, means it's sent again with the same
+// idempotency key.
+func (n *Notifier) Notify(ctx context.Context, change relayer.MessageStatusChange) error {
+	urls, err := n.urlsFor(ctx, change)
+	if err != nil {
+		return errors.Wrap(err, "n.urlsFor")
+	}
+
+	if len(urls) == 0 {
+		return nil
+	}
+
+	key := change.IdempotencyKey()
+
+	body, err := json.Marshal(Payload{
+		IdempotencyKey: key,
+		MsgHash:        change.MsgHash,
+		Status:         change.Status.String(),
+		TxHash:         change.TxHash,
+		ChainID:        change.ChainID,
+		MessageOwner:   change.MessageOwner,
+		Timestamp:      change.Timestamp,
+	})
+	if err != nil {
+		return errors.Wrap(err, "json.Marshal")
+	}
+
+	var firstErr error
+
+	for _, url := range urls {
+		if err := n.deliver(ctx, url, key, body); err != nil {
+			log.Errorf("msgHash: %v, webhook %v failed: %v", change.MsgHash, url, err)
+
+			if firstErr == nil {
+				firstErr = errors.Wrapf(err, "n.deliver(%v)", url)
+			}
+		}
+	}
+
+	return firstErr
+}
+
+// deliver posts body to url unless it has already been delivered there, and records it once it is.
+func (n *Notifier) deliver(ctx context.Context, url string, key string, body []byte) error {
+	delivered, err := n.deliveryRepo.IsDelivered(ctx, key, url)
+	if err != nil {
+		// sending it again is better than not at all, the key lets the receiver ignore it
+		log.Warnf("webhook %v, n.deliveryRepo.IsDelivered(%v): %v", url, key, err)
+	}
+
+	if delivered {
+		log.Infof("webhook %v already delivered %v, skipping", url, key)
+
+		return nil
+	}
+
+	if err := n.postWithRetry(ctx, url, key, body); err != nil {
+		return err
+	}
+
+	if err := n.deliveryRepo.SaveDelivered(ctx, key, url); err != nil {
+		log.Warnf("webhook %v, n.deliveryRepo.SaveDelivered(%v): %v", url, key, err)
+	}
+
+	return nil
+}
+
+func (n *Notifier) urlsFor(ctx context.Context, change relayer.MessageStatusChange) ([]string, error) {
+	urls := make([]string, 0)
+
+	if n.url != "" && statusMatches(n.statuses, change.Status) {
+		urls = append(urls, n.url)
+	}
+
+	if change.MessageOwner == "" {
+		return urls, nil
+	}
+
+	subs, err := n.subscriptionRepo.FindAllByMessageOwner(ctx, change.MessageOwner)
+	if err != nil {
+		return nil, errors.Wrap(err, "n.subscriptionRepo.FindAllByMessageOwner")
+	}
+
+	for _, s := range subs {
+		statuses, err := relayer.ParseEventStatuses(s.Statuses)
+		if err != nil {
+			log.Warnf("webhook subscription %v has invalid statuses %v: %v", s.ID, s.Statuses, err)
+			continue
+		}
+
+		if statusMatches(statuses, change.Status) {
+			urls = append(urls, s.URL)
+		}
+	}
+
+	return urls, nil
+}
+
+func statusMatches(statuses []relayer.EventStatus, status relayer.EventStatus) bool {
+	return len(statuses) == 0 || relayer.IsInSlice(status, statuses)
+}
+
+// postWithRetry retries failed requests up to n.maxRetries times, doubling the wait between attempts.
+// Every attempt carries the same idempotency key.
+func (n *Notifier) postWithRetry(ctx context.Context, url string, key string, body []byte) error {
+	backoff := n.backoff
+
+	for attempt := 0; ; attempt++ {
+		retriable, err := n.post(ctx, url, key, body)
+		if err == nil {
+			return nil
+		}
+
+		if !retriable || attempt >= n.maxRetries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+	}
+}
+
+// post sends a single signed request. It reports whether a failure is worth retrying,
+// client errors other than rate limiting will fail the same way next time.
+func (n *Notifier) post(ctx context.Context, url string, key string, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, errors.Wrap(err, "http.NewRequestWithContext")
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(n.secret, body))
+	req.Header.Set(IdempotencyKeyHeader, key)
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return true, errors.Wrap(err, "n.httpClient.Do")
+	}
+
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+
+	retriable := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+
+	return retriable, fmt.Errorf("unexpected status code %v", resp.StatusCode)
+}
+
+// Sign returns the SignatureHeader value for body
+func Sign(secret []byte, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
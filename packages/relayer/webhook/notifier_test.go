@@ -0,0 +1,261 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer"
+	"github.com/MXCzkEVM/mxc-mono/packages/relayer/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testSecret = "secret"
+	testOwner  = common.HexToAddress("0x63FaC9201494f0bd17B9892B9fae4d52fe3BD377")
+)
+
+func newTestNotifier(t *testing.T, url string, statuses []relayer.EventStatus) (*Notifier, *mock.WebhookSubscriptionRepository) {
+	repo := mock.NewWebhookSubscriptionRepository()
+
+	n, err := NewNotifier(NewNotifierOpts{
+		URL:              url,
+		Secret:           testSecret,
+		Statuses:         statuses,
+		SubscriptionRepo: repo,
+		DeliveryRepo:     mock.NewWebhookDeliveryRepository(),
+		MaxRetries:       2,
+		Backoff:          time.Millisecond,
+	})
+	assert.Nil(t, err)
+
+	return n, repo
+}
+
+func Test_NewNotifier(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    NewNotifierOpts
+		wantErr error
+	}{
+		{
+			"success",
+			NewNotifierOpts{
+				Secret:           testSecret,
+				SubscriptionRepo: mock.NewWebhookSubscriptionRepository(),
+				DeliveryRepo:     mock.NewWebhookDeliveryRepository(),
+			},
+			nil,
+		},
+		{
+			"noSecret",
+			NewNotifierOpts{
+				SubscriptionRepo: mock.NewWebhookSubscriptionRepository(),
+				DeliveryRepo:     mock.NewWebhookDeliveryRepository(),
+			},
+			relayer.ErrNoWebhookSecret,
+		},
+		{
+			"noSubscriptionRepo",
+			NewNotifierOpts{Secret: testSecret, DeliveryRepo: mock.NewWebhookDeliveryRepository()},
+			relayer.ErrNoWebhookSubscriptionRepository,
+		},
+		{
+			"noDeliveryRepo",
+			NewNotifierOpts{Secret: testSecret, SubscriptionRepo: mock.NewWebhookSubscriptionRepository()},
+			relayer.ErrNoWebhookDeliveryRepository,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewNotifier(tt.opts)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_Notify_signsPayload(t *testing.T) {
+	var got Payload
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		assert.Equal(t, Sign([]byte(testSecret), body), r.Header.Get(SignatureHeader))
+		assert.Equal(t, "0x1:done", r.Header.Get(IdempotencyKeyHeader))
+		assert.Nil(t, json.Unmarshal(body, &got))
+	}))
+	defer srv.Close()
+
+	n, _ := newTestNotifier(t, srv.URL, nil)
+
+	err := n.Notify(context.Background(), relayer.MessageStatusChange{
+		MsgHash:   "0x1",
+		Status:    relayer.EventStatusDone,
+		TxHash:    "0x2",
+		ChainID:   1,
+		Timestamp: 1234,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, Payload{
+		IdempotencyKey: "0x1:done",
+		MsgHash:        "0x1",
+		Status:         "done",
+		TxHash:         "0x2",
+		ChainID:        1,
+		Timestamp:      1234,
+	}, got)
+}
+
+func Test_Notify_filtersStatuses(t *testing.T) {
+	var calls int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer srv.Close()
+
+	n, repo := newTestNotifier(t, srv.URL, []relayer.EventStatus{relayer.EventStatusDone})
+
+	_, _ = repo.Save(context.Background(), relayer.SaveWebhookSubscriptionOpts{
+		MessageOwner: testOwner,
+		URL:          srv.URL,
+		Statuses:     []relayer.EventStatus{relayer.EventStatusRetriable},
+	})
+
+	// only the global URL wants done
+	assert.Nil(t, n.Notify(context.Background(), relayer.MessageStatusChange{
+		Status:       relayer.EventStatusDone,
+		MessageOwner: testOwner.Hex(),
+	}))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	// only the owner's subscription wants retriable
+	assert.Nil(t, n.Notify(context.Background(), relayer.MessageStatusChange{
+		Status:       relayer.EventStatusRetriable,
+		MessageOwner: testOwner.Hex(),
+	}))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	// nobody wants failed
+	assert.Nil(t, n.Notify(context.Background(), relayer.MessageStatusChange{
+		Status:       relayer.EventStatusFailed,
+		MessageOwner: testOwner.Hex(),
+	}))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func Test_Notify_retries(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		wantCalls int32
+		wantErr   bool
+	}{
+		{"serverErrorRetriedThenGivesUp", http.StatusInternalServerError, 3, true},
+		{"badRequestNotRetried", http.StatusBadRequest, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&calls, 1)
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			n, _ := newTestNotifier(t, srv.URL, nil)
+
+			err := n.Notify(context.Background(), relayer.MessageStatusChange{Status: relayer.EventStatusDone})
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantCalls, atomic.LoadInt32(&calls))
+		})
+	}
+}
+
+func Test_Notify_retryAfterNetworkErrorSendsSameKey(t *testing.T) {
+	var keys []string
+
+	var bodies [][]byte
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		bodies = append(bodies, body)
+
+		// drop the connection without answering the first attempt
+		if len(keys) == 1 {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			assert.Nil(t, err)
+			conn.Close()
+		}
+	}))
+	defer srv.Close()
+
+	n, _ := newTestNotifier(t, srv.URL, nil)
+
+	err := n.Notify(context.Background(), relayer.MessageStatusChange{MsgHash: "0x1", Status: relayer.EventStatusDone})
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{"0x1:done", "0x1:done"}, keys)
+	assert.Equal(t, bodies[0], bodies[1])
+}
+
+func Test_Notify_skipsDelivered(t *testing.T) {
+	var calls int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer srv.Close()
+
+	n, _ := newTestNotifier(t, srv.URL, nil)
+
+	change := relayer.MessageStatusChange{MsgHash: "0x1", Status: relayer.EventStatusDone}
+
+	assert.Nil(t, n.Notify(context.Background(), change))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	// i.e. the event is indexed again after a restart
+	assert.Nil(t, n.Notify(context.Background(), change))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	// a new status is a new delivery
+	change.Status = relayer.EventStatusRetriable
+
+	assert.Nil(t, n.Notify(context.Background(), change))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func Test_Notify_failedDeliveryNotRecorded(t *testing.T) {
+	var calls int32
+
+	status := int32(http.StatusInternalServerError)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(int(atomic.LoadInt32(&status)))
+	}))
+	defer srv.Close()
+
+	n, _ := newTestNotifier(t, srv.URL, nil)
+
+	change := relayer.MessageStatusChange{MsgHash: "0x1", Status: relayer.EventStatusDone}
+
+	assert.NotNil(t, n.Notify(context.Background(), change))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+	atomic.StoreInt32(&status, http.StatusOK)
+
+	assert.Nil(t, n.Notify(context.Background(), change))
+	assert.Equal(t, int32(4), atomic.LoadInt32(&calls))
+}
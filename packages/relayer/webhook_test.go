@@ -0,0 +1,48 @@
+package relayer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseEventStatuses(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    []EventStatus
+		wantErr bool
+	}{
+		{"empty", "", []EventStatus{}, false},
+		{"one", "done", []EventStatus{EventStatusDone}, false},
+		{"many", "done, retriable,held", []EventStatus{EventStatusDone, EventStatusRetriable, EventStatusHeld}, false},
+		{"invalid", "done,pending", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseEventStatuses(tt.s)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+
+			if err == nil {
+				roundTripped, err := ParseEventStatuses(JoinEventStatuses(got))
+				assert.Nil(t, err)
+				assert.Equal(t, got, roundTripped)
+			}
+		})
+	}
+}
+
+func Test_MessageStatusChange_IdempotencyKey(t *testing.T) {
+	change := MessageStatusChange{MsgHash: "0x1", Status: EventStatusDone, TxHash: "0x2"}
+
+	assert.Equal(t, "0x1:done", change.IdempotencyKey())
+
+	// the same change seen in another transaction, i.e. after a reorg, is still the same delivery
+	change.TxHash = "0x3"
+	assert.Equal(t, "0x1:done", change.IdempotencyKey())
+
+	change.Status = EventStatusRetriable
+	assert.Equal(t, "0x1:retriable", change.IdempotencyKey())
+}